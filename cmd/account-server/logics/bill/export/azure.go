@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package export
+
+import "hcm/pkg/logs"
+
+// AzureBillItemHeaders is the headers of Azure bill item.
+var AzureBillItemHeaders []string
+
+func init() {
+	var err error
+	AzureBillItemHeaders, err = AzureBillItemTable{}.GetHeaders()
+	if err != nil {
+		logs.Errorf("GetAzureHeader failed: %v", err)
+	}
+}
+
+var _ Table = (*AzureBillItemTable)(nil)
+
+// AzureBillItemTable azure账单导出表结构
+type AzureBillItemTable struct {
+	Site        string `header:"站点类型"`
+	AccountDate string `header:"核算年月"`
+
+	BizID   string `header:"业务"`
+	BizName string `header:"业务名称"`
+
+	RootAccountName string `header:"一级账号名称"`
+	MainAccountName string `header:"二级账号名称"`
+	Region          string `header:"地域"`
+
+	SubscriptionName string `header:"订阅名称"`
+	ResourceGroup    string `header:"资源组"`
+	ConsumedService  string `header:"服务名称"`
+	ProductName      string `header:"产品名称"`
+	MeterCategory    string `header:"计量类别"`
+	MeterSubCategory string `header:"计量子类别"`
+	MeterName        string `header:"计量名称"`
+	ResourceName     string `header:"资源名称"`
+	ChargeType       string `header:"计费类型"`
+	Frequency        string `header:"计费频率"`
+	EffectivePrice   string `header:"单价"`
+	Quantity         string `header:"用量"`
+	UnitOfMeasure    string `header:"用量单位"`
+	Currency         string `header:"外币类型"`
+	Cost             string `header:"外币成本(元)"`
+	ExchangeRate     string `header:"汇率"`
+	RMBCost          string `header:"人民币成本(元)"`
+}
+
+// GetHeaders ...
+func (a AzureBillItemTable) GetHeaders() ([]string, error) {
+	return parseHeader(a)
+}
+
+// GetHeaderValues ...
+func (a AzureBillItemTable) GetHeaderValues() ([]string, error) {
+	return parseHeaderFields(a)
+}
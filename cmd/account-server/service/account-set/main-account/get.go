@@ -81,6 +81,12 @@ func (s *service) Get(cts *rest.Contexts) (interface{}, error) {
 			account.Extension.CloudInitPassword = ""
 		}
 		return account, err
+	case enumor.TCloud:
+		account, err := s.client.DataService().TCloud.MainAccount.Get(cts.Kit, accountID)
+		if account != nil {
+			account.Extension.CloudInitPassword = ""
+		}
+		return account, err
 	default:
 		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", baseInfo.Vendor))
 	}
@@ -75,6 +75,8 @@ func (s *service) AddRootAccount(cts *rest.Contexts) (interface{}, error) {
 		accountID, err = s.addForZenlayer(cts, req)
 	case enumor.Kaopu:
 		accountID, err = s.addForKaopu(cts, req)
+	case enumor.TCloud:
+		accountID, err = s.addForTCloud(cts, req)
 	}
 	if err != nil {
 		logs.Errorf("add root account for [%s] failed, err: %v, rid: %s", req.Vendor, err, cts.Kit.Rid)
@@ -296,3 +298,34 @@ func (s *service) addForKaopu(cts *rest.Contexts, req *proto.RootAccountAddReq)
 	}
 	return result.ID, err
 }
+
+func (s *service) addForTCloud(cts *rest.Contexts, req *proto.RootAccountAddReq) (string, error) {
+	extension := &dataproto.TCloudRootAccountExtensionCreateReq{
+		CloudMainAccountID: req.Extension["cloud_main_account_id"],
+		CloudSubAccountID:  req.Extension["cloud_sub_account_id"],
+		CloudSecretID:      req.Extension["cloud_secret_id"],
+		CloudSecretKey:     req.Extension["cloud_secret_key"],
+	}
+	if err := extension.Validate(); err != nil {
+		return "", err
+	}
+
+	result, err := s.client.DataService().TCloud.RootAccount.Create(
+		cts.Kit,
+		&dataproto.RootAccountCreateReq[dataproto.TCloudRootAccountExtensionCreateReq]{
+			Name:        req.Name,
+			CloudID:     req.Extension["cloud_main_account_id"],
+			Email:       req.Email,
+			Managers:    req.Managers,
+			BakManagers: req.BakManagers,
+			Site:        req.Site,
+			DeptID:      req.DeptID,
+			Memo:        req.Memo,
+			Extension:   extension,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return result.ID, err
+}
@@ -80,6 +80,12 @@ func (s *service) GetRootAccount(cts *rest.Contexts) (interface{}, error) {
 		// if account != nil {
 		// }
 		return account, err
+	case enumor.TCloud:
+		account, err := s.client.DataService().TCloud.RootAccount.Get(cts.Kit, accountID)
+		if account != nil {
+			account.Extension.CloudSecretKey = ""
+		}
+		return account, err
 	default:
 		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", baseInfo.Vendor))
 	}
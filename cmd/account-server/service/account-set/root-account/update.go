@@ -71,6 +71,8 @@ func (s *service) UpdateRootAccount(cts *rest.Contexts) (interface{}, error) {
 		result, err = s.updateForZenlayer(cts, req, accountID)
 	case enumor.Kaopu:
 		result, err = s.updateForKaopu(cts, req, accountID)
+	case enumor.TCloud:
+		result, err = s.updateForTCloud(cts, req, accountID)
 	default:
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
@@ -357,3 +359,49 @@ func (s *service) updateForKaopu(cts *rest.Contexts, req *proto.RootAccountUpdat
 
 	return nil, nil
 }
+
+func (s *service) updateForTCloud(cts *rest.Contexts, req *proto.RootAccountUpdateReq, accountID string) (interface{}, error) {
+	var (
+		extension *proto.TCloudRootAccountExtensionUpdateReq
+	)
+	if req.Extension != nil {
+		// 解析Extension
+		extension = new(proto.TCloudRootAccountExtensionUpdateReq)
+		if err := common.DecodeExtension(cts.Kit, req.Extension, extension); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		// 校验Extension
+		err := extension.Validate()
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+	var shouldUpdatedExtension *dataproto.TCloudRootAccountExtensionUpdateReq = nil
+	if req.Extension != nil {
+		shouldUpdatedExtension = &dataproto.TCloudRootAccountExtensionUpdateReq{
+			CloudSubAccountID: extension.CloudSubAccountID,
+			CloudSecretID:     &extension.CloudSecretID,
+			CloudSecretKey:    &extension.CloudSecretKey,
+		}
+	}
+
+	// 更新
+	_, err := s.client.DataService().TCloud.RootAccount.Update(
+		cts.Kit,
+		accountID,
+		&dataproto.RootAccountUpdateReq[dataproto.TCloudRootAccountExtensionUpdateReq]{
+			Name:        req.Name,
+			Managers:    req.Managers,
+			BakManagers: req.BakManagers,
+			Memo:        req.Memo,
+			DeptID:      req.DeptID,
+			Extension:   shouldUpdatedExtension,
+		},
+	)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,230 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billitem
+
+import (
+	"fmt"
+
+	"hcm/cmd/account-server/logics/bill/export"
+	"hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/account-set"
+	billapi "hcm/pkg/api/core/bill"
+	databill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/TencentBlueKing/gopkg/conv"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
+	"github.com/shopspring/decimal"
+)
+
+func (b *billItemSvc) exportAzureBillItems(kt *kit.Kit, req *bill.ExportBillItemReq,
+	rate *decimal.Decimal) (any, error) {
+
+	rootAccountMap, mainAccountMap, bizNameMap, err := b.fetchAccountBizInfo(kt, enumor.Azure)
+	if err != nil {
+		logs.Errorf("[exportAzureBillItems] prepare related data failed: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(kt, generateFilename(enumor.Azure))
+	defer func() {
+		if closeFunc != nil {
+			closeFunc()
+		}
+	}()
+	if err != nil {
+		logs.Errorf("create writer failed: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+	if err = writer.Write(export.AzureBillItemHeaders); err != nil {
+		logs.Errorf("csv write header failed: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	convFunc := func(items []*billapi.AzureBillItem) error {
+		if len(items) == 0 {
+			return nil
+		}
+		table, err := convertAzureBillItems(kt, items, bizNameMap, mainAccountMap, rootAccountMap, rate)
+		if err != nil {
+			logs.Errorf("[exportAzureBillItems] convert to raw data error: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+		err = writer.WriteAll(table)
+		if err != nil {
+			logs.Errorf("csv write data failed: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+		return nil
+	}
+	err = b.fetchAzureBillItems(kt, req, convFunc)
+	if err != nil {
+		logs.Errorf("fetch azure bill items failed: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return &bill.FileDownloadResp{
+		ContentTypeStr:        "application/octet-stream",
+		ContentDispositionStr: fmt.Sprintf(`attachment; filename="%s"`, filename),
+		FilePath:              filepath,
+	}, nil
+}
+
+func convertAzureBillItems(kt *kit.Kit, items []*billapi.AzureBillItem, bizNameMap map[int64]string,
+	mainAccountMap map[string]*protocore.BaseMainAccount, rootAccountMap map[string]*protocore.BaseRootAccount,
+	rate *decimal.Decimal) ([][]string, error) {
+
+	result := make([][]string, 0, len(items))
+	for _, item := range items {
+		mainAccount, ok := mainAccountMap[item.MainAccountID]
+		if !ok {
+			return nil, fmt.Errorf("main account(%s) not found", item.MainAccountID)
+		}
+		rootAccount, ok := rootAccountMap[item.RootAccountID]
+		if !ok {
+			return nil, fmt.Errorf("root account(%s) not found", item.RootAccountID)
+		}
+		bizName, ok := bizNameMap[item.BkBizID]
+		if !ok {
+			logs.Warnf("biz(%d) not found", item.BkBizID)
+		}
+
+		extension := &armconsumption.LegacyUsageDetailProperties{}
+		if item.Extension.AzureRawBillItem != nil && item.Extension.Properties != nil {
+			extension = item.Extension.Properties
+		}
+		meter := extension.MeterDetails
+		if meter == nil {
+			meter = &armconsumption.MeterDetailsResponse{}
+		}
+		itemRate := getItemExchangeRate(item.Currency, rate)
+
+		var table = export.AzureBillItemTable{
+			Site:             string(mainAccount.Site),
+			AccountDate:      fmt.Sprintf("%d%02d", item.BillYear, item.BillMonth),
+			BizID:            conv.ToString(item.BkBizID),
+			BizName:          bizName,
+			RootAccountName:  rootAccount.Name,
+			MainAccountName:  mainAccount.Name,
+			Region:           converter.PtrToVal(extension.ResourceLocation),
+			SubscriptionName: converter.PtrToVal(extension.SubscriptionName),
+			ResourceGroup:    converter.PtrToVal(extension.ResourceGroup),
+			ConsumedService:  converter.PtrToVal(extension.ConsumedService),
+			ProductName:      converter.PtrToVal(extension.Product),
+			MeterCategory:    converter.PtrToVal(meter.MeterCategory),
+			MeterSubCategory: converter.PtrToVal(meter.MeterSubCategory),
+			MeterName:        converter.PtrToVal(meter.MeterName),
+			ResourceName:     converter.PtrToVal(extension.ResourceName),
+			ChargeType:       converter.PtrToVal(extension.ChargeType),
+			Frequency:        converter.PtrToVal(extension.Frequency),
+			EffectivePrice:   conv.ToString(converter.PtrToVal(extension.EffectivePrice)),
+			Quantity:         item.ResAmount.String(),
+			UnitOfMeasure:    item.ResAmountUnit,
+			Currency:         string(item.Currency),
+			Cost:             item.Cost.String(),
+			ExchangeRate:     itemRate.String(),
+			RMBCost:          item.Cost.Mul(itemRate).String(),
+		}
+		values, err := table.GetHeaderValues()
+		if err != nil {
+			logs.Errorf("get header fields failed, table: %v, error: %v, rid: %s", table, err, kt.Rid)
+			return nil, err
+		}
+		result = append(result, values)
+	}
+	return result, nil
+}
+
+func (b *billItemSvc) fetchAzureBillItems(kt *kit.Kit, req *bill.ExportBillItemReq,
+	convertFunc func([]*billapi.AzureBillItem) error) error {
+
+	totalCount, err := b.fetchAzureBillItemCount(kt, req)
+	if err != nil {
+		logs.Errorf("fetch azure bill item count failed: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+	exportLimit := min(totalCount, req.ExportLimit)
+
+	commonOpt := &databill.ItemCommonOpt{
+		Vendor: enumor.Azure,
+		Year:   req.BillYear,
+		Month:  req.BillMonth,
+	}
+	lastID := ""
+	for offset := uint64(0); offset < exportLimit; offset = offset + uint64(core.DefaultMaxPageLimit) {
+		left := exportLimit - offset
+		expr := req.Filter
+		if len(lastID) > 0 {
+			expr, err = tools.And(expr, tools.RuleIDGreaterThan(lastID))
+			if err != nil {
+				logs.Errorf("build filter failed: %v, rid: %s", err, kt.Rid)
+				return err
+			}
+		}
+		billListReq := &databill.BillItemListReq{
+			ItemCommonOpt: commonOpt,
+			ListReq: &core.ListReq{
+				Filter: expr,
+				Page: &core.BasePage{
+					Start: 0,
+					Limit: min(uint(left), core.DefaultMaxPageLimit),
+					Sort:  "id",
+					Order: core.Ascending,
+				},
+			},
+		}
+		result, err := b.client.DataService().Azure.Bill.ListBillItem(kt, billListReq)
+		if err != nil {
+			logs.Errorf("list azure bill item failed: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+		if len(result.Details) == 0 {
+			continue
+		}
+		if err = convertFunc(result.Details); err != nil {
+			logs.Errorf("convert azure bill item failed: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+		lastID = result.Details[len(result.Details)-1].ID
+	}
+	return nil
+}
+
+func (b *billItemSvc) fetchAzureBillItemCount(kt *kit.Kit, req *bill.ExportBillItemReq) (uint64, error) {
+	countReq := &databill.BillItemListReq{
+		ItemCommonOpt: &databill.ItemCommonOpt{
+			Vendor: enumor.Azure,
+			Year:   req.BillYear,
+			Month:  req.BillMonth,
+		},
+		ListReq: &core.ListReq{Filter: req.Filter, Page: core.NewCountPage()},
+	}
+	details, err := b.client.DataService().Azure.Bill.ListBillItem(kt, countReq)
+	if err != nil {
+		return 0, err
+	}
+	return details.Count, nil
+}
@@ -78,6 +78,12 @@ func (b *billItemSvc) ExportBillItems(cts *rest.Contexts) (any, error) {
 		return b.exportGcpBillItems(cts.Kit, req, rate)
 	case enumor.Aws:
 		return b.exportAwsBillItems(cts.Kit, req, rate)
+	case enumor.Azure:
+		return b.exportAzureBillItems(cts.Kit, req, rate)
+	case enumor.TCloud:
+		return b.exportTCloudBillItems(cts.Kit, req, rate)
+	case enumor.Zenlayer:
+		return b.exportZenlayerBillItems(cts.Kit, req, rate)
 	default:
 		return nil, fmt.Errorf("unsupport %s vendor", vendor)
 	}
@@ -113,6 +119,14 @@ func (b *billItemSvc) getExchangeRate(kt *kit.Kit, year, month int) (*decimal.De
 	return result.Details[0].ExchangeRate, nil
 }
 
+// getItemExchangeRate 账单明细币种为人民币时不需要换算，汇率为1
+func getItemExchangeRate(currency enumor.CurrencyCode, rate *decimal.Decimal) decimal.Decimal {
+	if currency == enumor.CurrencyRMB {
+		return decimal.NewFromInt(1)
+	}
+	return *rate
+}
+
 func (b *billItemSvc) listRootAccount(kt *kit.Kit, vendor enumor.Vendor) (
 	map[string]*accountset.BaseRootAccount, error) {
 
@@ -50,6 +50,10 @@ func AdaptAuthOptions(a *meta.ResourceAttribute) (client.ActionID, []client.Reso
 		return genSubnetResource(a)
 	case meta.Disk:
 		return genDiskResource(a)
+	case meta.DiskSnapshot:
+		return genIaaSResourceResource(a)
+	case meta.KeyPair:
+		return genIaaSResourceResource(a)
 	case meta.SecurityGroup:
 		return genSecurityGroupResource(a)
 	case meta.SecurityGroupRule:
@@ -90,6 +94,12 @@ func AdaptAuthOptions(a *meta.ResourceAttribute) (client.ActionID, []client.Reso
 		return sys.CloudSelectionRecommend, make([]client.Resource, 0), nil
 	case meta.ArgumentTemplate:
 		return genArgumentTemplateResource(a)
+	case meta.SGRuleTemplate:
+		return genSGRuleTemplateResource(a)
+	case meta.BizAssignRule:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	case meta.SubnetReservedCidr:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
 	case meta.Cert:
 		return genCertResource(a)
 	case meta.LoadBalancer:
@@ -530,6 +530,11 @@ func genArgumentTemplateResource(a *meta.ResourceAttribute) (client.ActionID, []
 	return genIaaSResourceResource(a)
 }
 
+// genSGRuleTemplateResource generate security group rule template related iam resource.
+func genSGRuleTemplateResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
 // genCertResource generate cert related iam resource.
 func genCertResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
 	res := client.Resource{
@@ -606,9 +611,11 @@ func genLoadBalancerResource(a *meta.ResourceAttribute) (client.ActionID, []clie
 	case meta.Update:
 		// update resource is related to hcm account resource
 		return sys.CLBResOperate, []client.Resource{res}, nil
-	case meta.Delete:
+	case meta.Delete, meta.Recycle:
 		// delete resource is related to hcm account resource
 		return sys.CLBResDelete, []client.Resource{res}, nil
+	case meta.Destroy, meta.Recover:
+		return sys.RecycleBinOperate, []client.Resource{res}, nil
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
 	}
@@ -632,8 +639,10 @@ func genBizLoadBalancerResource(a *meta.ResourceAttribute) (client.ActionID, []c
 		return sys.BizCLBResCreate, []client.Resource{res}, nil
 	case meta.Update:
 		return sys.BizCLBResOperate, []client.Resource{res}, nil
-	case meta.Delete:
+	case meta.Delete, meta.Recycle:
 		return sys.BizCLBResDelete, []client.Resource{res}, nil
+	case meta.Destroy, meta.Recover:
+		return sys.BizRecycleBinOperate, []client.Resource{res}, nil
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
 	}
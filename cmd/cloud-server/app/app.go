@@ -75,6 +75,7 @@ func (ds *cloudServer) prepare(opt *options.Option) error {
 	// init metrics
 	network := cc.CloudServer().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
+	metrics.InitResSyncMetrics(metrics.Register())
 
 	// init service discovery.
 	svcOpt := serviced.NewServiceOption(cc.CloudServerName, cc.CloudServer().Network, opt.Sys)
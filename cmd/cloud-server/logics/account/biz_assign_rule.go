@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	dsbizassign "hcm/pkg/api/data-service/cloud/biz-assign-rule"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ApplyBizAssignRule 同步完成后按自动分配规则将账号下新同步的未分配资源分配到业务，失败只记录日志，不影响同步结果
+func ApplyBizAssignRule(kt *kit.Kit, dataCli *dataservice.Client, accountID string) {
+	result, err := dataCli.Global.BizAssignRule.Apply(kt, &dsbizassign.ApplyReq{AccountID: accountID})
+	if err != nil {
+		logs.Errorf("apply biz assign rule after sync failed, err: %v, accountID: %s, rid: %s", err, accountID,
+			kt.Rid)
+		return
+	}
+
+	for _, one := range result.Details {
+		logs.Infof("auto assign %d %s to biz %d by rule %s, accountID: %s, rid: %s", len(one.ResIDs), one.ResType,
+			one.BkBizID, one.RuleID, accountID, kt.Rid)
+	}
+}
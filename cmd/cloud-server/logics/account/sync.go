@@ -23,9 +23,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"hcm/cmd/cloud-server/service/sync/aws"
 	"hcm/cmd/cloud-server/service/sync/azure"
+	"hcm/cmd/cloud-server/service/sync/detail"
 	"hcm/cmd/cloud-server/service/sync/gcp"
 	"hcm/cmd/cloud-server/service/sync/huawei"
 	"hcm/cmd/cloud-server/service/sync/lock"
@@ -80,11 +82,15 @@ func Sync(kt *kit.Kit, cli *client.ClientSet, vendor enumor.Vendor, accountID st
 			}
 		}()
 
+		startedAt := time.Now()
 		resType, err := syncer.SyncAllResource(kt, cli, accountID, isNeedSyncPublicResFlag)
+		detail.RecordAccountSyncRun(kt, cli.DataService(), vendor, accountID, resType, startedAt, err)
 		if err != nil {
 			logs.Errorf("[%s] sync account %s failed on %s, err: %v, rid: %s", vendor, accountID, resType, err, kt.Rid)
+			return
 		}
 
+		ApplyBizAssignRule(kt, cli.DataService(), accountID)
 	}(leaseID)
 
 	return nil
@@ -0,0 +1,176 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot ...
+package disksnapshot
+
+import (
+	"fmt"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/pkg/api/core"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	hcproto "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Interface define disk snapshot interface.
+type Interface interface {
+	CreateDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, req *hcproto.DiskSnapshotCreateReq) (
+		*core.CloudCreateResult, error)
+	DeleteDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, id string) error
+	DeleteRecycledDiskSnapshot(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo) (
+		*core.BatchOperateResult, error)
+	RollbackDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, id, diskID string) error
+	CreateDiskFromSnapshot(kt *kit.Kit, vendor enumor.Vendor, req *hcproto.DiskFromSnapshotCreateReq) (
+		*core.CloudCreateResult, error)
+}
+
+type diskSnapshot struct {
+	client *client.ClientSet
+	audit  audit.Interface
+}
+
+// NewDiskSnapshot new disk snapshot.
+func NewDiskSnapshot(client *client.ClientSet, audit audit.Interface) Interface {
+	return &diskSnapshot{
+		client: client,
+		audit:  audit,
+	}
+}
+
+// CreateDiskSnapshot create disk snapshot, snapshot is synced into db by hc-service after created.
+func (s *diskSnapshot) CreateDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, req *hcproto.DiskSnapshotCreateReq) (
+	*core.CloudCreateResult, error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		return s.client.HCService().TCloud.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	case enumor.Aws:
+		return s.client.HCService().Aws.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	case enumor.HuaWei:
+		return s.client.HCService().HuaWei.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	case enumor.Azure:
+		return s.client.HCService().Azure.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	case enumor.Gcp:
+		return s.client.HCService().Gcp.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	default:
+		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", vendor))
+	}
+}
+
+// DeleteDiskSnapshot delete disk snapshot.
+func (s *diskSnapshot) DeleteDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, id string) error {
+	if err := s.audit.ResDeleteAudit(kt, enumor.DiskSnapshotAuditResType, []string{id}); err != nil {
+		logs.Errorf("create delete disk snapshot audit failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	req := &hcproto.DiskSnapshotDeleteReq{ID: id}
+	switch vendor {
+	case enumor.TCloud:
+		return s.client.HCService().TCloud.DiskSnapshot.DeleteDiskSnapshot(kt, req)
+	case enumor.Aws:
+		return s.client.HCService().Aws.DiskSnapshot.DeleteDiskSnapshot(kt, req)
+	case enumor.HuaWei:
+		return s.client.HCService().HuaWei.DiskSnapshot.DeleteDiskSnapshot(kt, req)
+	case enumor.Azure:
+		return s.client.HCService().Azure.DiskSnapshot.DeleteDiskSnapshot(kt, req)
+	case enumor.Gcp:
+		return s.client.HCService().Gcp.DiskSnapshot.DeleteDiskSnapshot(kt, req)
+	default:
+		return errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", vendor))
+	}
+}
+
+// DeleteRecycledDiskSnapshot batch delete recycled disk snapshot.
+func (s *diskSnapshot) DeleteRecycledDiskSnapshot(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+	*core.BatchOperateResult, error) {
+
+	if len(basicInfoMap) == 0 {
+		return nil, nil
+	}
+
+	if len(basicInfoMap) > constant.BatchOperationMaxLimit {
+		return nil, errf.Newf(errf.InvalidParameter, "disk snapshot length should <= %d",
+			constant.BatchOperationMaxLimit)
+	}
+
+	res := new(core.BatchOperateResult)
+	for id, info := range basicInfoMap {
+		if err := s.DeleteDiskSnapshot(kt, info.Vendor, id); err != nil {
+			res.Failed = &core.FailedInfo{ID: id, Error: err}
+			return res, err
+		}
+		res.Succeeded = append(res.Succeeded, id)
+	}
+
+	return res, nil
+}
+
+// RollbackDiskSnapshot 使用快照原地回滚源云盘，仅 tcloud、huawei 支持
+func (s *diskSnapshot) RollbackDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, id, diskID string) error {
+	if vendor != enumor.TCloud && vendor != enumor.HuaWei {
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support rollback disk snapshot, "+
+			"please create disk from snapshot instead", vendor)
+	}
+
+	operationInfo := protoaudit.CloudResourceOperationInfo{
+		ResType:           enumor.DiskSnapshotAuditResType,
+		ResID:             id,
+		Action:            protoaudit.Rollback,
+		AssociatedResType: enumor.DiskAuditResType,
+		AssociatedResID:   diskID,
+	}
+	if err := s.audit.ResOperationAudit(kt, operationInfo); err != nil {
+		logs.Errorf("create rollback disk snapshot audit failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	req := &hcproto.DiskSnapshotRollbackReq{ID: id}
+	switch vendor {
+	case enumor.TCloud:
+		return s.client.HCService().TCloud.DiskSnapshot.RollbackDiskSnapshot(kt, req)
+	default:
+		return s.client.HCService().HuaWei.DiskSnapshot.RollbackDiskSnapshot(kt, req)
+	}
+}
+
+// CreateDiskFromSnapshot 基于快照创建新云盘，仅 aws、azure、gcp 支持
+func (s *diskSnapshot) CreateDiskFromSnapshot(kt *kit.Kit, vendor enumor.Vendor,
+	req *hcproto.DiskFromSnapshotCreateReq) (*core.CloudCreateResult, error) {
+
+	switch vendor {
+	case enumor.Aws:
+		return s.client.HCService().Aws.DiskSnapshot.CreateDiskFromSnapshot(kt, req)
+	case enumor.Azure:
+		return s.client.HCService().Azure.DiskSnapshot.CreateDiskFromSnapshot(kt, req)
+	case enumor.Gcp:
+		return s.client.HCService().Gcp.DiskSnapshot.CreateDiskFromSnapshot(kt, req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support create disk from snapshot, "+
+			"please rollback disk snapshot instead", vendor)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package eip
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// ValidateNotBound 校验eip未绑定主机，已绑定的eip不允许回收及删除
+func ValidateNotBound(kt *kit.Kit, cli *dataservice.Client, ids []string) error {
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		relReq := &core.ListReq{
+			Filter: tools.ContainersExpression("eip_id", batch),
+			Page:   &core.BasePage{Count: true},
+		}
+		relRes, err := cli.Global.ListEipCvmRel(kt, relReq)
+		if err != nil {
+			logs.Errorf("list eip cvm rel failed, err: %v, eip ids: %v, rid: %s", err, batch, kt.Rid)
+			return err
+		}
+
+		if converter.PtrToVal(relRes.Count) > 0 {
+			return errf.Newf(errf.InvalidParameter, "some eips(ids: %v) are bound to cvm, please disassociate first",
+				batch)
+		}
+	}
+
+	return nil
+}
+
+// DeleteRecycledEip batch delete recycled eip.
+func (e *eip) DeleteRecycledEip(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+	*core.BatchOperateResult, error) {
+
+	if len(basicInfoMap) == 0 {
+		return nil, nil
+	}
+
+	if len(basicInfoMap) > constant.BatchOperationMaxLimit {
+		return nil, errf.Newf(errf.InvalidParameter, "eip length should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	ids := make([]string, 0, len(basicInfoMap))
+	for id := range basicInfoMap {
+		ids = append(ids, id)
+	}
+
+	// eip在回收站期间可能被重新绑定，删除前需要再次检查
+	if err := ValidateNotBound(kt, e.client.DataService(), ids); err != nil {
+		return nil, err
+	}
+
+	res := new(core.BatchOperateResult)
+	for _, id := range ids {
+		if err := e.audit.ResDeleteAudit(kt, enumor.EipAuditResType, []string{id}); err != nil {
+			logs.Errorf("create delete eip audit failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+			res.Failed = &core.FailedInfo{ID: id, Error: err}
+			return res, err
+		}
+
+		if err := e.DeleteEip(kt, basicInfoMap[id].Vendor, id); err != nil {
+			res.Failed = &core.FailedInfo{ID: id, Error: err}
+			return res, err
+		}
+		res.Succeeded = append(res.Succeeded, id)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"fmt"
+
+	logicaudit "hcm/cmd/cloud-server/logics/audit"
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// Assign 分配SSH密钥对到业务下
+func Assign(kt *kit.Kit, cli *dataservice.Client, ids []string, bizID int64) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("ids is required")
+	}
+
+	if err := ValidateBeforeAssign(kt, cli, bizID, ids); err != nil {
+		return err
+	}
+
+	// create assign audit
+	audit := logicaudit.NewAudit(cli)
+	if err := audit.ResBizAssignAudit(kt, enumor.KeyPairAuditResType, ids, bizID); err != nil {
+		logs.Errorf("create assign key pair audit failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	req := &dataproto.KeyPairBatchUpdateReq{
+		IDs:     ids,
+		BkBizID: bizID,
+	}
+	if err := cli.Global.BatchUpdateKeyPair(kt, req); err != nil {
+		logs.Errorf("batch update key pair failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ValidateBeforeAssign 分配前置校验，已分配到其他业务的密钥对不允许再次分配
+func ValidateBeforeAssign(kt *kit.Kit, cli *dataservice.Client, targetBizID int64, ids []string) error {
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleIn("id", ids),
+			tools.RuleNotIn("bk_biz_id", []int64{constant.UnassignedBiz, targetBizID}),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	listResp, err := cli.Global.ListKeyPair(kt, listReq)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, req: %+v, rid: %s", err, listReq, kt.Rid)
+		return err
+	}
+
+	if len(listResp.Details) != 0 {
+		return fmt.Errorf("key pair(ids=%v) already assigned", slice.Map(listResp.Details,
+			func(kp *corekeypair.BaseKeyPair) string { return kp.ID }))
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair ...
+package keypair
+
+import (
+	"fmt"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	hcproto "hcm/pkg/api/hc-service/key-pair"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Interface define key pair interface.
+type Interface interface {
+	CreateKeyPair(kt *kit.Kit, vendor enumor.Vendor, req *hcproto.KeyPairCreateReq) (*hcproto.KeyPairCreateResult,
+		error)
+	DeleteKeyPair(kt *kit.Kit, vendor enumor.Vendor, id string) error
+	AssociateKeyPair(kt *kit.Kit, vendor enumor.Vendor, opt *AssociateOption) error
+	DisassociateKeyPair(kt *kit.Kit, vendor enumor.Vendor, opt *AssociateOption) error
+}
+
+// AssociateOption 绑定/解绑密钥对与主机参数
+type AssociateOption struct {
+	ID        string
+	CvmID     string
+	ForceStop *bool
+	Auth      *typekeypair.HuaWeiKeyPairAuth
+}
+
+type keyPair struct {
+	client *client.ClientSet
+	audit  audit.Interface
+}
+
+// NewKeyPair new key pair.
+func NewKeyPair(client *client.ClientSet, audit audit.Interface) Interface {
+	return &keyPair{
+		client: client,
+		audit:  audit,
+	}
+}
+
+// CreateKeyPair create or import key pair, key pair is synced into db by hc-service after created.
+func (k *keyPair) CreateKeyPair(kt *kit.Kit, vendor enumor.Vendor, req *hcproto.KeyPairCreateReq) (
+	*hcproto.KeyPairCreateResult, error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		return k.client.HCService().TCloud.KeyPair.CreateKeyPair(kt, req)
+	case enumor.Aws:
+		return k.client.HCService().Aws.KeyPair.CreateKeyPair(kt, req)
+	case enumor.HuaWei:
+		return k.client.HCService().HuaWei.KeyPair.CreateKeyPair(kt, req)
+	case enumor.Azure:
+		return k.client.HCService().Azure.KeyPair.CreateKeyPair(kt, req)
+	default:
+		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", vendor))
+	}
+}
+
+// DeleteKeyPair delete key pair.
+func (k *keyPair) DeleteKeyPair(kt *kit.Kit, vendor enumor.Vendor, id string) error {
+	if err := k.audit.ResDeleteAudit(kt, enumor.KeyPairAuditResType, []string{id}); err != nil {
+		logs.Errorf("create delete key pair audit failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	req := &hcproto.KeyPairDeleteReq{ID: id}
+	switch vendor {
+	case enumor.TCloud:
+		return k.client.HCService().TCloud.KeyPair.DeleteKeyPair(kt, req)
+	case enumor.Aws:
+		return k.client.HCService().Aws.KeyPair.DeleteKeyPair(kt, req)
+	case enumor.HuaWei:
+		return k.client.HCService().HuaWei.KeyPair.DeleteKeyPair(kt, req)
+	case enumor.Azure:
+		return k.client.HCService().Azure.KeyPair.DeleteKeyPair(kt, req)
+	default:
+		return errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", vendor))
+	}
+}
+
+// AssociateKeyPair 绑定密钥对到主机，仅 tcloud、huawei 支持
+func (k *keyPair) AssociateKeyPair(kt *kit.Kit, vendor enumor.Vendor, opt *AssociateOption) error {
+	if err := k.operationAudit(kt, vendor, protoaudit.Associate, opt); err != nil {
+		return err
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		req := &hcproto.TCloudKeyPairAssociateReq{ID: opt.ID, CvmID: opt.CvmID, ForceStop: opt.ForceStop}
+		return k.client.HCService().TCloud.KeyPair.AssociateKeyPair(kt, req)
+	default:
+		return k.client.HCService().HuaWei.KeyPair.AssociateKeyPair(kt, huaweiAssociateReq(opt))
+	}
+}
+
+// DisassociateKeyPair 解绑主机上的密钥对，仅 tcloud、huawei 支持
+func (k *keyPair) DisassociateKeyPair(kt *kit.Kit, vendor enumor.Vendor, opt *AssociateOption) error {
+	if err := k.operationAudit(kt, vendor, protoaudit.Disassociate, opt); err != nil {
+		return err
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		req := &hcproto.TCloudKeyPairAssociateReq{ID: opt.ID, CvmID: opt.CvmID, ForceStop: opt.ForceStop}
+		return k.client.HCService().TCloud.KeyPair.DisassociateKeyPair(kt, req)
+	default:
+		return k.client.HCService().HuaWei.KeyPair.DisassociateKeyPair(kt, huaweiAssociateReq(opt))
+	}
+}
+
+func (k *keyPair) operationAudit(kt *kit.Kit, vendor enumor.Vendor, action protoaudit.OperationAction,
+	opt *AssociateOption) error {
+
+	if vendor != enumor.TCloud && vendor != enumor.HuaWei {
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support %s key pair", vendor, action)
+	}
+
+	operationInfo := protoaudit.CloudResourceOperationInfo{
+		ResType:           enumor.KeyPairAuditResType,
+		ResID:             opt.ID,
+		Action:            action,
+		AssociatedResType: enumor.CvmAuditResType,
+		AssociatedResID:   opt.CvmID,
+	}
+	if err := k.audit.ResOperationAudit(kt, operationInfo); err != nil {
+		logs.Errorf("create %s key pair audit failed, err: %v, id: %s, cvm: %s, rid: %s", action, err, opt.ID,
+			opt.CvmID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func huaweiAssociateReq(opt *AssociateOption) *hcproto.HuaWeiKeyPairAssociateReq {
+	return &hcproto.HuaWeiKeyPairAssociateReq{ID: opt.ID, CvmID: opt.CvmID, Auth: opt.Auth}
+}
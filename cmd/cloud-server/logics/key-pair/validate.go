@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ValidateCvmKeyPair 校验创建主机使用的SSH密钥对，密钥对需与主机在同一账号及地域下，且与主机归属同一业务
+func ValidateCvmKeyPair(kt *kit.Kit, cli *dataservice.Client, vendor enumor.Vendor, accountID, region,
+	cloudKeyPairID string, bizID int64) error {
+
+	if len(cloudKeyPairID) == 0 {
+		return nil
+	}
+
+	listReq := &core.ListReq{
+		Fields: []string{"id", "bk_biz_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", vendor),
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("cloud_id", cloudKeyPairID),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	listResp, err := cli.Global.ListKeyPair(kt, listReq)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, req: %+v, rid: %s", err, listReq, kt.Rid)
+		return err
+	}
+
+	if len(listResp.Details) == 0 {
+		return errf.Newf(errf.InvalidParameter, "key pair(%s) not found in account(%s) region(%s)", cloudKeyPairID,
+			accountID, region)
+	}
+
+	if listResp.Details[0].BkBizID != bizID {
+		return errf.Newf(errf.InvalidParameter, "key pair(%s) is not in biz(%d)", cloudKeyPairID, bizID)
+	}
+
+	return nil
+}
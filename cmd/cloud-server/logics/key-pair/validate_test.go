@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/rest/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDataService 记录查询密钥对的请求，并返回指定的密钥对列表
+type fakeDataService struct {
+	keyPairs []*corekeypair.BaseKeyPair
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+// GetServers ...
+func (f *fakeDataService) GetServers() ([]string, error) {
+	return []string{"http://data-service"}, nil
+}
+
+// Do ...
+func (f *fakeDataService) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, req)
+	f.bodies = append(f.bodies, body)
+
+	resp, err := json.Marshal(&rest.Response{Code: errf.OK, Data: &dataproto.ListResult{Details: f.keyPairs}})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Status: http.StatusText(http.StatusOK),
+		Body: io.NopCloser(bytes.NewReader(resp))}, nil
+}
+
+func newTestDataService(keyPairs ...*corekeypair.BaseKeyPair) (*fakeDataService, *dataservice.Client) {
+	fake := &fakeDataService{keyPairs: keyPairs}
+	return fake, dataservice.NewClient(&client.Capability{Client: fake, Discover: fake}, "v1")
+}
+
+func TestValidateCvmKeyPair(t *testing.T) {
+	kt := kit.New()
+
+	// 未指定密钥对时不查询
+	fake, cli := newTestDataService()
+	assert.NoError(t, ValidateCvmKeyPair(kt, cli, enumor.TCloud, "account", "ap-guangzhou", "", 1))
+	assert.Empty(t, fake.requests)
+
+	// 按主机的账号、地域及密钥对云ID查询
+	fake, cli = newTestDataService(&corekeypair.BaseKeyPair{ID: "kp", BkBizID: 1})
+	assert.NoError(t, ValidateCvmKeyPair(kt, cli, enumor.TCloud, "account", "ap-guangzhou", "skey-1", 1))
+	assert.Len(t, fake.requests, 1)
+	assert.Equal(t, "/api/v1/data/key_pairs/list", fake.requests[0].URL.Path)
+	expected, err := json.Marshal(&core.ListReq{
+		Fields: []string{"id", "bk_biz_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", enumor.TCloud),
+			tools.RuleEqual("account_id", "account"),
+			tools.RuleEqual("region", "ap-guangzhou"),
+			tools.RuleEqual("cloud_id", "skey-1"),
+		),
+		Page: core.NewDefaultBasePage(),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(fake.bodies[0]))
+
+	// 密钥对不在主机的账号及地域下
+	_, cli = newTestDataService()
+	err = ValidateCvmKeyPair(kt, cli, enumor.TCloud, "account", "ap-guangzhou", "skey-1", 1)
+	assert.Error(t, err)
+	assert.Equal(t, errf.InvalidParameter, errf.Error(err).Code)
+
+	// 密钥对未分配到主机所属业务
+	for _, bizID := range []int64{constant.UnassignedBiz, 2} {
+		_, cli = newTestDataService(&corekeypair.BaseKeyPair{ID: "kp", BkBizID: bizID})
+		err = ValidateCvmKeyPair(kt, cli, enumor.TCloud, "account", "ap-guangzhou", "skey-1", 1)
+		assert.Error(t, err)
+		assert.Equal(t, errf.InvalidParameter, errf.Error(err).Code)
+	}
+}
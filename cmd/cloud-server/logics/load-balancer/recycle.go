@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lblogic
+
+import (
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/pkg/api/core"
+	hcproto "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// RecyclableVendors 支持回收的负载均衡云厂商，回收到期后需要调用云上接口删除负载均衡，目前仅腾讯云支持
+var RecyclableVendors = []enumor.Vendor{enumor.TCloud}
+
+// ValidateRecycleVendor 校验负载均衡的云厂商是否支持回收
+func ValidateRecycleVendor(infoMap map[string]types.CloudResourceBasicInfo) error {
+	for id, info := range infoMap {
+		if !slice.IsItemInSlice(RecyclableVendors, info.Vendor) {
+			return errf.Newf(errf.InvalidParameter, "recycle load balancer(%s) of vendor %s is not supported, "+
+				"supported vendors: %v", id, info.Vendor, RecyclableVendors)
+		}
+	}
+
+	return nil
+}
+
+// ValidateRecyclable 校验负载均衡是否可以回收，开启删除保护的负载均衡不允许回收
+func ValidateRecyclable(kt *kit.Kit, cli *dataservice.Client, infoMap map[string]types.CloudResourceBasicInfo) error {
+	if err := ValidateRecycleVendor(infoMap); err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(infoMap))
+	for id := range infoMap {
+		ids = append(ids, id)
+	}
+
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		lbReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		lbResp, err := cli.TCloud.LoadBalancer.ListLoadBalancer(kt, lbReq)
+		if err != nil {
+			logs.Errorf("list tcloud load balancer failed, err: %v, ids: %v, rid: %s", err, batch, kt.Rid)
+			return err
+		}
+		for _, lb := range lbResp.Details {
+			if converter.PtrToVal(lb.Extension.DeleteProtect) {
+				return errf.Newf(errf.InvalidParameter, "%s(%s) is protected for deletion", lb.Name, lb.CloudID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteRecycledLoadBalancer 删除已回收的负载均衡，先记录审计再删除，云上删除负载均衡时会一并删除其下的监听器及规则
+func DeleteRecycledLoadBalancer(kt *kit.Kit, cli *client.ClientSet, auditCli audit.Interface,
+	basicInfoMap map[string]types.CloudResourceBasicInfo) (*core.BatchOperateResult, error) {
+
+	if len(basicInfoMap) == 0 {
+		return nil, nil
+	}
+
+	if len(basicInfoMap) > constant.BatchOperationMaxLimit {
+		return nil, errf.Newf(errf.InvalidParameter, "load balancer length should <= %d",
+			constant.BatchOperationMaxLimit)
+	}
+
+	// 负载均衡在回收站期间可能被开启删除保护，删除前需要再次检查
+	if err := ValidateRecyclable(kt, cli.DataService(), basicInfoMap); err != nil {
+		return nil, err
+	}
+
+	res := new(core.BatchOperateResult)
+	for id, info := range basicInfoMap {
+		if err := auditCli.ResDeleteAudit(kt, enumor.LoadBalancerAuditResType, []string{id}); err != nil {
+			logs.Errorf("create load balancer delete audit failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+			res.Failed = &core.FailedInfo{ID: id, Error: err}
+			return res, err
+		}
+
+		delReq := &hcproto.TCloudBatchDeleteLoadbalancerReq{
+			AccountID: info.AccountID,
+			Region:    info.Region,
+			IDs:       []string{id},
+		}
+		if err := cli.HCService().TCloud.Clb.BatchDeleteLoadBalancer(kt, delReq); err != nil {
+			logs.Errorf("delete tcloud load balancer failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+			res.Failed = &core.FailedInfo{ID: id, Error: err}
+			return res, err
+		}
+		res.Succeeded = append(res.Succeeded, id)
+	}
+
+	return res, nil
+}
@@ -24,17 +24,24 @@ import (
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/cvm"
 	"hcm/cmd/cloud-server/logics/disk"
+	disksnapshot "hcm/cmd/cloud-server/logics/disk-snapshot"
 	"hcm/cmd/cloud-server/logics/eip"
+	keypair "hcm/cmd/cloud-server/logics/key-pair"
+	securitygroup "hcm/cmd/cloud-server/logics/security-group"
 	"hcm/pkg/client"
 	"hcm/pkg/thirdparty/esb"
 )
 
 // Logics defines cloud-server common logics.
 type Logics struct {
-	Audit audit.Interface
-	Disk  disk.Interface
-	Cvm   cvm.Interface
-	Eip   eip.Interface
+	Audit        audit.Interface
+	Disk         disk.Interface
+	DiskSnapshot disksnapshot.Interface
+	Cvm          cvm.Interface
+	Eip          eip.Interface
+	KeyPair      keypair.Interface
+
+	SecurityGroup securitygroup.Interface
 }
 
 // NewLogics create a new cloud server logics.
@@ -43,9 +50,13 @@ func NewLogics(c *client.ClientSet, esbClient esb.Client) *Logics {
 	eipLogics := eip.NewEip(c, auditLogics)
 	diskLogics := disk.NewDisk(c, auditLogics)
 	return &Logics{
-		Audit: auditLogics,
-		Disk:  disk.NewDisk(c, auditLogics),
-		Cvm:   cvm.NewCvm(c, auditLogics, eipLogics, diskLogics, esbClient),
-		Eip:   eip.NewEip(c, auditLogics),
+		Audit:        auditLogics,
+		Disk:         disk.NewDisk(c, auditLogics),
+		DiskSnapshot: disksnapshot.NewDiskSnapshot(c, auditLogics),
+		Cvm:          cvm.NewCvm(c, auditLogics, eipLogics, diskLogics, esbClient),
+		Eip:          eip.NewEip(c, auditLogics),
+		KeyPair:      keypair.NewKeyPair(c, auditLogics),
+
+		SecurityGroup: securitygroup.NewSecurityGroup(c, auditLogics),
 	}
 }
@@ -20,11 +20,16 @@
 package logicsrecycle
 
 import (
+	"fmt"
+
+	"hcm/pkg/api/core"
 	corerr "hcm/pkg/api/core/recycle-record"
 	"hcm/pkg/api/data-service/recycle-record"
 	"hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/slice"
@@ -63,3 +68,48 @@ func MarkRecordSuccess(kt *kit.Kit, ds *dataservice.Client, recordIDs []string)
 			constant.RecycleUpdateRecordFailed, recordIDs, err, kt.Rid)
 	}
 }
+
+// ListWaitingRecords 获取待处理的回收记录，只能批量处理处于同一个回收任务的且是等待回收的指定类型资源的记录
+func ListWaitingRecords(kt *kit.Kit, ds *dataservice.Client, resType enumor.CloudResourceType,
+	recordIDs []string) (*recyclerecord.ListResult, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", recordIDs),
+		Page:   &core.BasePage{Limit: constant.BatchOperationMaxLimit},
+	}
+	records, err := ds.Global.RecycleRecord.ListRecycleRecord(kt, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records.Details) != len(recordIDs) {
+		return nil, errf.New(errf.InvalidParameter, "some record_ids are not in recycle bin")
+	}
+
+	taskID := ""
+	for _, one := range records.Details {
+		if len(taskID) == 0 {
+			taskID = one.TaskID
+		} else if taskID != one.TaskID {
+			return nil, errf.Newf(errf.InvalidParameter, "only %s in one task can be reclaimed at the same time",
+				resType)
+		}
+
+		if one.Status != enumor.WaitingRecycleRecordStatus {
+			return nil, errf.NewFromErr(errf.InvalidParameter,
+				fmt.Errorf("record: %s not is wait_recycle status", one.ID))
+		}
+
+		if one.ResType != resType {
+			return nil, errf.NewFromErr(errf.InvalidParameter,
+				fmt.Errorf("record: %s not is %s recycle record", one.ID, resType))
+		}
+
+		if one.RecycleType == enumor.RecycleTypeRelated {
+			return nil, errf.NewFromErr(errf.InvalidParameter,
+				fmt.Errorf("related recycled %s(%s) can not be operated", resType, one.ResID))
+		}
+	}
+
+	return records, nil
+}
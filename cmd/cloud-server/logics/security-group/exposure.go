@@ -0,0 +1,635 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"net/netip"
+	"sort"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// AnalyzeExposure 分析主机通过安全组(gcp 为 vpc 防火墙规则)对访问来源放通的端口段。
+// 主机关联多个安全组时，腾讯云按安全组绑定顺序依次匹配，微软云取网卡与所在子网安全组同时放通的端口，
+// 其他云取各安全组放通端口的并集。
+func (sg *securityGroup) AnalyzeExposure(kt *kit.Kit, cvms []corecvm.BaseCvm, req *cloudserver.SGExposureReq) (
+	*cloudserver.SGExposureResult, error) {
+
+	source := netip.MustParsePrefix("0.0.0.0/0")
+	if len(req.Source) != 0 {
+		parsed, err := cloudserver.ParseExposureSource(req.Source)
+		if err != nil {
+			return nil, err
+		}
+		source = parsed
+	}
+
+	cvmSGMap, azureNICMap, err := sg.listCvmSecurityGroupIDs(kt, cvms)
+	if err != nil {
+		return nil, err
+	}
+
+	sgRuleMap, err := sg.listSecurityGroupExposureRules(kt, cvms, cvmSGMap)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcRuleMap, err := sg.listGcpExposureRules(kt, cvms)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cloudserver.SGExposureResult{
+		Source:  source.String(),
+		Details: make([]cloudserver.CvmExposure, 0, len(cvms)),
+	}
+	rangeCvmMap := make(map[portExposure][]string)
+	for _, cvm := range cvms {
+		detail := cloudserver.CvmExposure{
+			CvmID:               cvm.ID,
+			CloudID:             cvm.CloudID,
+			Name:                cvm.Name,
+			Vendor:              cvm.Vendor,
+			BkBizID:             cvm.BkBizID,
+			Region:              cvm.Region,
+			PublicIPv4Addresses: cvm.PublicIPv4Addresses,
+			PublicIPv6Addresses: cvm.PublicIPv6Addresses,
+			SecurityGroupIDs:    cvmSGMap[cvm.ID],
+			Exposures:           make([]cloudserver.PortExposure, 0),
+			UnresolvedRules:     make([]cloudserver.ExposureRuleRef, 0),
+		}
+
+		exposures := make([]portExposure, 0)
+		switch {
+		case cvm.Vendor == enumor.Gcp:
+			rules := make([]exposureRule, 0)
+			for _, cloudVpcID := range cvm.CloudVpcIDs {
+				rules = append(rules, vpcRuleMap[gcpVpcKey(cvm.AccountID, cloudVpcID)]...)
+			}
+			exposures, detail.UnresolvedRules = evaluateExposure(rules, source)
+
+		case cvm.Vendor == enumor.TCloud:
+			groups := slice.Map(detail.SecurityGroupIDs, func(sgID string) []exposureRule { return sgRuleMap[sgID] })
+			exposures, detail.UnresolvedRules = evaluateOrderedExposure(groups, source)
+
+		case cvm.Vendor == enumor.Azure && len(azureNICMap[cvm.ID]) != 0:
+			// 流量可以经由任意一个网卡进入主机，各网卡之间取并集
+			for _, nic := range azureNICMap[cvm.ID] {
+				nicExposures, unresolved := nic.evaluate(sgRuleMap, source)
+				exposures = append(exposures, nicExposures...)
+				detail.UnresolvedRules = append(detail.UnresolvedRules, unresolved...)
+			}
+
+		default:
+			for _, sgID := range detail.SecurityGroupIDs {
+				sgExposures, unresolved := evaluateExposure(sgRuleMap[sgID], source)
+				exposures = append(exposures, sgExposures...)
+				detail.UnresolvedRules = append(detail.UnresolvedRules, unresolved...)
+			}
+		}
+
+		detail.Exposures = groupPortExposures(exposures, req.Protocol, req.Port)
+		for _, one := range detail.Exposures {
+			key := portExposure{protocol: one.Protocol, ports: portRange{from: one.FromPort, to: one.ToPort}}
+			rangeCvmMap[key] = append(rangeCvmMap[key], cvm.ID)
+		}
+		result.Details = append(result.Details, detail)
+	}
+
+	result.PortRanges = make([]cloudserver.PortRangeExposure, 0, len(rangeCvmMap))
+	for key, cvmIDs := range rangeCvmMap {
+		result.PortRanges = append(result.PortRanges, cloudserver.PortRangeExposure{
+			Protocol: key.protocol,
+			FromPort: key.ports.from,
+			ToPort:   key.ports.to,
+			CvmIDs:   cvmIDs,
+		})
+	}
+	sort.Slice(result.PortRanges, func(i, j int) bool {
+		return lessPortRange(result.PortRanges[i].Protocol, result.PortRanges[i].FromPort, result.PortRanges[i].ToPort,
+			result.PortRanges[j].Protocol, result.PortRanges[j].FromPort, result.PortRanges[j].ToPort)
+	})
+
+	return result, nil
+}
+
+// groupPortExposures 按协议及端口段聚合放通规则，并按协议、端口过滤
+func groupPortExposures(exposures []portExposure, protocol string, port *int64) []cloudserver.PortExposure {
+	indexMap := make(map[portExposure]int)
+	result := make([]cloudserver.PortExposure, 0)
+	for _, one := range exposures {
+		if len(protocol) != 0 && one.protocol != protocol {
+			continue
+		}
+		if port != nil && (one.protocol == protocolICMP || *port < one.ports.from || *port > one.ports.to) {
+			continue
+		}
+
+		key := portExposure{protocol: one.protocol, ports: one.ports}
+		idx, exist := indexMap[key]
+		if !exist {
+			idx = len(result)
+			indexMap[key] = idx
+			result = append(result, cloudserver.PortExposure{
+				Protocol: one.protocol,
+				FromPort: one.ports.from,
+				ToPort:   one.ports.to,
+				Rules:    make([]cloudserver.ExposureRuleRef, 0),
+			})
+		}
+		if !slice.IsItemInSlice(result[idx].Rules, one.ref) {
+			result[idx].Rules = append(result[idx].Rules, one.ref)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return lessPortRange(result[i].Protocol, result[i].FromPort, result[i].ToPort,
+			result[j].Protocol, result[j].FromPort, result[j].ToPort)
+	})
+	return result
+}
+
+func lessPortRange(protocolA string, fromA, toA int64, protocolB string, fromB, toB int64) bool {
+	if protocolA != protocolB {
+		return protocolA > protocolB
+	}
+	if fromA != fromB {
+		return fromA < fromB
+	}
+	return toA < toB
+}
+
+// azureNICSecurityGroup 微软云网卡及其所在子网关联的安全组，入站流量需要同时被两者放通
+type azureNICSecurityGroup struct {
+	nicSGID    string
+	subnetSGID string
+}
+
+// evaluate 计算网卡安全组与子网安全组同时放通的端口段，未关联安全组的一层不做限制，两层均未关联时不计算
+func (n azureNICSecurityGroup) evaluate(sgRuleMap map[string][]exposureRule, source netip.Prefix) ([]portExposure,
+	[]cloudserver.ExposureRuleRef) {
+
+	switch {
+	case len(n.nicSGID) == 0 && len(n.subnetSGID) == 0:
+		return make([]portExposure, 0), make([]cloudserver.ExposureRuleRef, 0)
+	case len(n.nicSGID) == 0:
+		return evaluateExposure(sgRuleMap[n.subnetSGID], source)
+	case len(n.subnetSGID) == 0:
+		return evaluateExposure(sgRuleMap[n.nicSGID], source)
+	}
+
+	nicExposures, nicUnresolved := evaluateExposure(sgRuleMap[n.nicSGID], source)
+	subnetExposures, subnetUnresolved := evaluateExposure(sgRuleMap[n.subnetSGID], source)
+	return intersectPortExposures(nicExposures, subnetExposures), append(nicUnresolved, subnetUnresolved...)
+}
+
+// listCvmSecurityGroupIDs 查询主机关联的安全组，腾讯云安全组按绑定顺序排列，微软云安全组可能关联在主机网卡及所在子网上，
+// 同时返回微软云主机各网卡关联的安全组
+func (sg *securityGroup) listCvmSecurityGroupIDs(kt *kit.Kit, cvms []corecvm.BaseCvm) (map[string][]string,
+	map[string][]azureNICSecurityGroup, error) {
+
+	result := make(map[string][]string)
+	azureCvmIDs := make([]string, 0)
+	tcloudCvmIDs := make([]string, 0)
+	for _, cvm := range cvms {
+		switch cvm.Vendor {
+		case enumor.Azure:
+			azureCvmIDs = append(azureCvmIDs, cvm.ID)
+		case enumor.TCloud:
+			tcloudCvmIDs = append(tcloudCvmIDs, cvm.ID)
+		}
+	}
+
+	cvmIDs := slice.Map(cvms, func(cvm corecvm.BaseCvm) string { return cvm.ID })
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("cvm_id", cvmIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		relRes, err := sg.client.DataService().Global.SGCvmRel.ListSgCvmRels(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list security group cvm rel failed, err: %v, cvm ids: %v, rid: %s", err, cvmIDs, kt.Rid)
+			return nil, nil, err
+		}
+		for _, rel := range relRes.Details {
+			result[rel.CvmID] = append(result[rel.CvmID], rel.SecurityGroupID)
+		}
+		if uint(len(relRes.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	azureNICMap := make(map[string][]azureNICSecurityGroup)
+	if len(azureCvmIDs) != 0 {
+		var err error
+		azureNICMap, err = sg.listAzureNICSecurityGroups(kt, azureCvmIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		for cvmID, nics := range azureNICMap {
+			for _, nic := range nics {
+				for _, sgID := range []string{nic.nicSGID, nic.subnetSGID} {
+					if len(sgID) != 0 {
+						result[cvmID] = append(result[cvmID], sgID)
+					}
+				}
+			}
+		}
+	}
+
+	for cvmID, sgIDs := range result {
+		result[cvmID] = slice.Unique(sgIDs)
+	}
+
+	if len(tcloudCvmIDs) != 0 {
+		if err := sg.sortTCloudSecurityGroupIDs(kt, tcloudCvmIDs, result); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return result, azureNICMap, nil
+}
+
+// sortTCloudSecurityGroupIDs 按主机绑定安全组的顺序对腾讯云主机的安全组排序，腾讯云按绑定顺序依次匹配安全组规则
+func (sg *securityGroup) sortTCloudSecurityGroupIDs(kt *kit.Kit, cvmIDs []string, result map[string][]string) error {
+	cvmCloudSGMap := make(map[string][]string)
+	for _, batch := range slice.Split(cvmIDs, int(core.DefaultMaxPageLimit)) {
+		cvmReq := &protocloud.CvmListReq{
+			Field:  []string{"id", "extension"},
+			Filter: tools.ContainersExpression("id", batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		cvmRes, err := sg.client.DataService().TCloud.Cvm.ListCvmExt(kt.Ctx, kt.Header(), cvmReq)
+		if err != nil {
+			logs.Errorf("list tcloud cvm failed, err: %v, ids: %v, rid: %s", err, batch, kt.Rid)
+			return err
+		}
+		for _, cvm := range cvmRes.Details {
+			if cvm.Extension != nil {
+				cvmCloudSGMap[cvm.ID] = cvm.Extension.CloudSecurityGroupIDs
+			}
+		}
+	}
+
+	sgIDs := make([]string, 0)
+	for _, cvmID := range cvmIDs {
+		sgIDs = append(sgIDs, result[cvmID]...)
+	}
+	sgCloudIDMap := make(map[string]string)
+	for _, batch := range slice.Split(slice.Unique(sgIDs), int(core.DefaultMaxPageLimit)) {
+		sgReq := &protocloud.SecurityGroupListReq{
+			Field:  []string{"id", "cloud_id"},
+			Filter: tools.ContainersExpression("id", batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		sgRes, err := sg.client.DataService().Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), sgReq)
+		if err != nil {
+			logs.Errorf("list security group failed, err: %v, ids: %v, rid: %s", err, batch, kt.Rid)
+			return err
+		}
+		for _, one := range sgRes.Details {
+			sgCloudIDMap[one.ID] = one.CloudID
+		}
+	}
+
+	for _, cvmID := range cvmIDs {
+		orderMap := make(map[string]int)
+		for idx, cloudID := range cvmCloudSGMap[cvmID] {
+			orderMap[cloudID] = idx
+		}
+		ids := result[cvmID]
+		// 未在主机绑定列表中的安全组排在最后
+		order := func(sgID string) int {
+			if idx, exist := orderMap[sgCloudIDMap[sgID]]; exist {
+				return idx
+			}
+			return len(orderMap)
+		}
+		sort.SliceStable(ids, func(i, j int) bool { return order(ids[i]) < order(ids[j]) })
+	}
+
+	return nil
+}
+
+// listAzureNICSecurityGroups 通过主机网卡查询微软云网卡及所在子网关联的安全组，key 为主机ID
+func (sg *securityGroup) listAzureNICSecurityGroups(kt *kit.Kit, cvmIDs []string) (
+	map[string][]azureNICSecurityGroup, error) {
+
+	nicCvmMap := make(map[string]string)
+	relReq := &core.ListReq{
+		Filter: tools.ContainersExpression("cvm_id", cvmIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		relRes, err := sg.client.DataService().Global.NetworkInterfaceCvmRel.ListNetworkCvmRels(kt, relReq)
+		if err != nil {
+			logs.Errorf("list network interface cvm rel failed, err: %v, cvm ids: %v, rid: %s", err, cvmIDs, kt.Rid)
+			return nil, err
+		}
+		for _, rel := range relRes.Details {
+			nicCvmMap[rel.NetworkInterfaceID] = rel.CvmID
+		}
+		if uint(len(relRes.Details)) < relReq.Page.Limit {
+			break
+		}
+		relReq.Page.Start += uint32(relReq.Page.Limit)
+	}
+
+	nicSGMap := make(map[string]string)
+	nicSubnetMap := make(map[string]string)
+	nicIDs := converter.MapKeyToStringSlice(nicCvmMap)
+	sort.Strings(nicIDs)
+	for _, batch := range slice.Split(nicIDs, int(core.DefaultMaxPageLimit)) {
+		nicReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		nicRes, err := sg.client.DataService().Azure.NetworkInterface.ListNetworkInterfaceExt(kt.Ctx, kt.Header(),
+			nicReq)
+		if err != nil {
+			logs.Errorf("list azure network interface failed, err: %v, ids: %v, rid: %s", err, batch, kt.Rid)
+			return nil, err
+		}
+		for _, nic := range nicRes.Details {
+			nicSubnetMap[nic.ID] = nic.SubnetID
+			if nic.Extension != nil {
+				nicSGMap[nic.ID] = converter.PtrToVal(nic.Extension.SecurityGroupID)
+			}
+		}
+	}
+
+	subnetSGMap := make(map[string]string)
+	subnetIDs := slice.Unique(slice.Filter(converter.MapValueToSlice(nicSubnetMap),
+		func(id string) bool { return len(id) != 0 }))
+	for _, batch := range slice.Split(subnetIDs, int(core.DefaultMaxPageLimit)) {
+		subnetReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		subnetRes, err := sg.client.DataService().Azure.Subnet.ListSubnetExt(kt.Ctx, kt.Header(), subnetReq)
+		if err != nil {
+			logs.Errorf("list azure subnet failed, err: %v, ids: %v, rid: %s", err, batch, kt.Rid)
+			return nil, err
+		}
+		for _, subnet := range subnetRes.Details {
+			if subnet.Extension != nil {
+				subnetSGMap[subnet.ID] = subnet.Extension.SecurityGroupID
+			}
+		}
+	}
+
+	result := make(map[string][]azureNICSecurityGroup)
+	for _, nicID := range nicIDs {
+		if _, exist := nicSubnetMap[nicID]; !exist {
+			continue
+		}
+		cvmID := nicCvmMap[nicID]
+		result[cvmID] = append(result[cvmID], azureNICSecurityGroup{
+			nicSGID:    nicSGMap[nicID],
+			subnetSGID: subnetSGMap[nicSubnetMap[nicID]],
+		})
+	}
+
+	return result, nil
+}
+
+// listSecurityGroupExposureRules 查询安全组入站规则并归一化，key 为安全组ID
+func (sg *securityGroup) listSecurityGroupExposureRules(kt *kit.Kit, cvms []corecvm.BaseCvm,
+	cvmSGMap map[string][]string) (map[string][]exposureRule, error) {
+
+	vendorSGMap := make(map[enumor.Vendor][]string)
+	for _, cvm := range cvms {
+		vendorSGMap[cvm.Vendor] = append(vendorSGMap[cvm.Vendor], cvmSGMap[cvm.ID]...)
+	}
+
+	result := make(map[string][]exposureRule)
+	for vendor, sgIDs := range vendorSGMap {
+		for _, sgID := range slice.Unique(sgIDs) {
+			rules, err := sg.listExposureRules(kt, vendor, sgID)
+			if err != nil {
+				logs.Errorf("list %s security group rule failed, err: %v, sg id: %s, rid: %s", vendor, err, sgID,
+					kt.Rid)
+				return nil, err
+			}
+			result[sgID] = rules
+		}
+	}
+
+	return result, nil
+}
+
+func (sg *securityGroup) listExposureRules(kt *kit.Kit, vendor enumor.Vendor, sgID string) ([]exposureRule, error) {
+	ds := sg.client.DataService()
+	ingressFilter := tools.EqualExpression("type", enumor.Ingress)
+	result := make([]exposureRule, 0)
+
+	switch vendor {
+	case enumor.TCloud:
+		rules, err := listAllRules(func(page *core.BasePage) ([]corecloud.TCloudSecurityGroupRule, error) {
+			res, err := ds.TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&protocloud.TCloudSGRuleListReq{Filter: ingressFilter, Page: page}, sgID)
+			if err != nil {
+				return nil, err
+			}
+			return res.Details, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		resolver, err := sg.listTCloudTplResolver(kt, rules)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			result = append(result, convTCloudRule(rule, resolver)...)
+		}
+
+	case enumor.Aws:
+		rules, err := listAllRules(func(page *core.BasePage) ([]corecloud.AwsSecurityGroupRule, error) {
+			res, err := ds.Aws.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&protocloud.AwsSGRuleListReq{Filter: ingressFilter, Page: page}, sgID)
+			if err != nil {
+				return nil, err
+			}
+			return res.Details, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = slice.Map(rules, convAwsRule)
+
+	case enumor.HuaWei:
+		rules, err := listAllRules(func(page *core.BasePage) ([]corecloud.HuaWeiSecurityGroupRule, error) {
+			res, err := ds.HuaWei.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&protocloud.HuaWeiSGRuleListReq{Filter: ingressFilter, Page: page}, sgID)
+			if err != nil {
+				return nil, err
+			}
+			return res.Details, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = slice.Map(rules, convHuaWeiRule)
+
+	case enumor.Azure:
+		rules, err := listAllRules(func(page *core.BasePage) ([]corecloud.AzureSecurityGroupRule, error) {
+			res, err := ds.Azure.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&protocloud.AzureSGRuleListReq{Filter: ingressFilter, Page: page}, sgID)
+			if err != nil {
+				return nil, err
+			}
+			return res.Details, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = slice.Map(rules, convAzureRule)
+
+	default:
+		logs.Warnf("security group exposure analysis not support vendor: %s, sg id: %s, rid: %s", vendor, sgID,
+			kt.Rid)
+	}
+
+	return result, nil
+}
+
+// listTCloudTplResolver 查询规则引用的腾讯云参数模板，地址组、协议端口组会继续查询组内模板
+func (sg *securityGroup) listTCloudTplResolver(kt *kit.Kit, rules []corecloud.TCloudSecurityGroupRule) (
+	tcloudTplResolver, error) {
+
+	cloudIDs := make([]string, 0)
+	for _, rule := range rules {
+		for _, cloudID := range []*string{rule.CloudAddressID, rule.CloudAddressGroupID, rule.CloudServiceID,
+			rule.CloudServiceGroupID} {
+
+			if converter.PtrToVal(cloudID) != "" {
+				cloudIDs = append(cloudIDs, *cloudID)
+			}
+		}
+	}
+
+	resolver := make(tcloudTplResolver)
+	for len(cloudIDs) != 0 {
+		memberIDs := make([]string, 0)
+		for _, batch := range slice.Split(slice.Unique(cloudIDs), int(core.DefaultMaxPageLimit)) {
+			listReq := &core.ListReq{
+				Filter: tools.ExpressionAnd(
+					tools.RuleEqual("vendor", enumor.TCloud),
+					tools.RuleIn("cloud_id", batch),
+				),
+				Page: core.NewDefaultBasePage(),
+			}
+			res, err := sg.client.DataService().Global.ArgsTpl.ListArgsTpl(kt, listReq)
+			if err != nil {
+				logs.Errorf("list argument template failed, err: %v, cloud ids: %v, rid: %s", err, batch, kt.Rid)
+				return nil, err
+			}
+			for _, tpl := range res.Details {
+				resolver[tpl.CloudID] = tpl
+				for _, member := range converter.PtrToVal(tpl.GroupTemplates) {
+					if _, exist := resolver[member]; !exist {
+						memberIDs = append(memberIDs, member)
+					}
+				}
+			}
+		}
+		cloudIDs = memberIDs
+	}
+
+	return resolver, nil
+}
+
+// listGcpExposureRules 查询谷歌云主机所在 vpc 的入站防火墙规则并归一化，key 为 gcpVpcKey
+func (sg *securityGroup) listGcpExposureRules(kt *kit.Kit, cvms []corecvm.BaseCvm) (map[string][]exposureRule,
+	error) {
+
+	cloudVpcIDs := make([]string, 0)
+	for _, cvm := range cvms {
+		if cvm.Vendor == enumor.Gcp {
+			cloudVpcIDs = append(cloudVpcIDs, cvm.CloudVpcIDs...)
+		}
+	}
+
+	result := make(map[string][]exposureRule)
+	for _, batch := range slice.Split(slice.Unique(cloudVpcIDs), constant.BatchOperationMaxLimit) {
+		vpcFilter := tools.ContainersExpression("cloud_vpc_id", batch)
+		rules, err := listAllRules(func(page *core.BasePage) ([]corecloud.GcpFirewallRule, error) {
+			res, err := sg.client.DataService().Gcp.Firewall.ListFirewallRule(kt.Ctx, kt.Header(),
+				&protocloud.GcpFirewallRuleListReq{Filter: vpcFilter, Page: page})
+			if err != nil {
+				return nil, err
+			}
+			return res.Details, nil
+		})
+		if err != nil {
+			logs.Errorf("list gcp firewall rule failed, err: %v, cloud vpc ids: %v, rid: %s", err, batch, kt.Rid)
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			if rule.Disabled || !isIngress(rule.Type) {
+				continue
+			}
+			key := gcpVpcKey(rule.AccountID, rule.CloudVpcID)
+			result[key] = append(result[key], convGcpRule(rule)...)
+		}
+	}
+
+	return result, nil
+}
+
+func gcpVpcKey(accountID, cloudVpcID string) string {
+	return accountID + "/" + cloudVpcID
+}
+
+func isIngress(ruleType string) bool {
+	return enumor.SecurityGroupRuleType(ruleType) == enumor.Ingress || ruleType == "INGRESS"
+}
+
+// listAllRules 分页查询全部规则
+func listAllRules[T any](list func(page *core.BasePage) ([]T, error)) ([]T, error) {
+	result := make([]T, 0)
+	page := core.NewDefaultBasePage()
+	for {
+		details, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, details...)
+		if uint(len(details)) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+	return result, nil
+}
@@ -0,0 +1,559 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	corecloud "hcm/pkg/api/core/cloud"
+	argstpl "hcm/pkg/api/core/cloud/argument-template"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+const (
+	protocolTCP  = "tcp"
+	protocolUDP  = "udp"
+	protocolICMP = "icmp"
+
+	maxPort int64 = 65535
+)
+
+// exposureProtocols 暴露面分析关注的协议，其他协议(gre、esp等)不参与分析
+var exposureProtocols = []string{protocolTCP, protocolUDP, protocolICMP}
+
+var (
+	anyIPv4 = netip.MustParsePrefix("0.0.0.0/0")
+	anyIPv6 = netip.MustParsePrefix("::/0")
+)
+
+// portRange 闭区间端口段，icmp 等无端口协议使用 [0, 0]
+type portRange struct {
+	from int64
+	to   int64
+}
+
+// exposureRule 各云厂商入站规则归一化后的结构
+type exposureRule struct {
+	ref cloudserver.ExposureRuleRef
+	// protocols 规则生效的协议
+	protocols []string
+	// ports 规则生效的端口段，为空表示全部端口
+	ports []portRange
+	// sources 规则的来源网段
+	sources []netip.Prefix
+	// unresolved 来源引用了安全组、前缀列表等无法展开为网段的对象
+	unresolved bool
+	allow      bool
+	// priority 值越小越先匹配
+	priority int64
+}
+
+// covers 规则来源是否完整覆盖访问来源
+func (r exposureRule) covers(source netip.Prefix) bool {
+	for _, prefix := range r.sources {
+		if prefix.Addr().Is4() != source.Addr().Is4() {
+			continue
+		}
+		if prefix.Bits() <= source.Bits() && prefix.Contains(source.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r exposureRule) hasProtocol(protocol string) bool {
+	for _, one := range r.protocols {
+		if one == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// portExposure 单条规则放通的端口段
+type portExposure struct {
+	protocol string
+	ports    portRange
+	ref      cloudserver.ExposureRuleRef
+}
+
+// evaluateExposure 按优先级逐条匹配规则(先匹配先生效，同优先级拒绝优先)，计算对访问来源放通的端口段。
+// 来源未完整覆盖访问来源的规则不参与计算，因此部分拒绝不会遮蔽后续的放通规则，结果偏保守。
+func evaluateExposure(rules []exposureRule, source netip.Prefix) ([]portExposure, []cloudserver.ExposureRuleRef) {
+	sorted := make([]exposureRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].priority != sorted[j].priority {
+			return sorted[i].priority < sorted[j].priority
+		}
+		return !sorted[i].allow && sorted[j].allow
+	})
+
+	exposures := make([]portExposure, 0)
+	unresolved := make([]cloudserver.ExposureRuleRef, 0)
+	unresolvedExist := make(map[cloudserver.ExposureRuleRef]struct{})
+	for _, rule := range sorted {
+		if rule.unresolved && rule.allow {
+			if _, exist := unresolvedExist[rule.ref]; !exist {
+				unresolvedExist[rule.ref] = struct{}{}
+				unresolved = append(unresolved, rule.ref)
+			}
+		}
+	}
+
+	for _, protocol := range exposureProtocols {
+		decided := make([]portRange, 0)
+		for _, rule := range sorted {
+			if rule.unresolved || !rule.hasProtocol(protocol) || !rule.covers(source) {
+				continue
+			}
+
+			ranges := rule.ports
+			if protocol == protocolICMP || len(ranges) == 0 {
+				ranges = []portRange{fullPortRange(protocol)}
+			}
+
+			if rule.allow {
+				for _, one := range subtractPortRanges(ranges, decided) {
+					exposures = append(exposures, portExposure{protocol: protocol, ports: one, ref: rule.ref})
+				}
+			}
+			decided = mergePortRanges(append(decided, ranges...))
+		}
+	}
+
+	return exposures, unresolved
+}
+
+// evaluateOrderedExposure 按安全组绑定顺序计算放通端口段，前面的安全组优先级更高，其拒绝规则会遮蔽后续安全组的放通规则，
+// 用于腾讯云等主机关联的多个安全组按绑定顺序依次匹配的场景
+func evaluateOrderedExposure(groups [][]exposureRule, source netip.Prefix) ([]portExposure,
+	[]cloudserver.ExposureRuleRef) {
+
+	rules := make([]exposureRule, 0)
+	for idx, group := range groups {
+		for _, rule := range group {
+			// 安全组顺序作为高位，组内规则优先级作为低位
+			rule.priority = int64(idx)<<32 + rule.priority
+			rules = append(rules, rule)
+		}
+	}
+
+	return evaluateExposure(rules, source)
+}
+
+// intersectPortExposures 计算两层规则同时放通的端口段，用于微软云子网与网卡安全组需要同时放通的场景，
+// 交集端口段会同时保留两层的放通规则
+func intersectPortExposures(a, b []portExposure) []portExposure {
+	result := make([]portExposure, 0)
+	for _, one := range a {
+		for _, other := range b {
+			if one.protocol != other.protocol || one.ports.to < other.ports.from || other.ports.to < one.ports.from {
+				continue
+			}
+
+			overlap := portRange{from: max(one.ports.from, other.ports.from), to: min(one.ports.to, other.ports.to)}
+			result = append(result, portExposure{protocol: one.protocol, ports: overlap, ref: one.ref},
+				portExposure{protocol: one.protocol, ports: overlap, ref: other.ref})
+		}
+	}
+	return result
+}
+
+func fullPortRange(protocol string) portRange {
+	if protocol == protocolICMP {
+		return portRange{from: 0, to: 0}
+	}
+	return portRange{from: 0, to: maxPort}
+}
+
+// mergePortRanges 合并重叠或相邻的端口段
+func mergePortRanges(ranges []portRange) []portRange {
+	if len(ranges) == 0 {
+		return ranges
+	}
+
+	sorted := make([]portRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].from < sorted[j].from })
+
+	merged := []portRange{sorted[0]}
+	for _, one := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if one.from <= last.to+1 {
+			if one.to > last.to {
+				last.to = one.to
+			}
+			continue
+		}
+		merged = append(merged, one)
+	}
+	return merged
+}
+
+// subtractPortRanges 计算 ranges 中未被 decided 覆盖的端口段，decided 需已合并
+func subtractPortRanges(ranges []portRange, decided []portRange) []portRange {
+	result := make([]portRange, 0)
+	for _, one := range mergePortRanges(ranges) {
+		from := one.from
+		for _, cut := range decided {
+			if cut.to < from || cut.from > one.to {
+				continue
+			}
+			if cut.from > from {
+				result = append(result, portRange{from: from, to: cut.from - 1})
+			}
+			from = cut.to + 1
+			if from > one.to {
+				break
+			}
+		}
+		if from <= one.to {
+			result = append(result, portRange{from: from, to: one.to})
+		}
+	}
+	return result
+}
+
+// parseProtocol 将各云厂商的协议名称转换为暴露面分析关注的协议，all 表示全部协议
+func parseProtocol(protocol string) []string {
+	switch strings.ToLower(strings.TrimSpace(protocol)) {
+	case "", "all", "-1", "*":
+		return exposureProtocols
+	case "tcp", "6":
+		return []string{protocolTCP}
+	case "udp", "17":
+		return []string{protocolUDP}
+	case "icmp", "1", "icmpv6", "58":
+		return []string{protocolICMP}
+	default:
+		return nil
+	}
+}
+
+// parsePorts 解析端口表达式，支持 "22"、"80,443"、"8000-9000"，全部端口返回空
+func parsePorts(ports ...string) ([]portRange, error) {
+	result := make([]portRange, 0)
+	for _, expr := range ports {
+		for _, part := range strings.Split(expr, ",") {
+			part = strings.TrimSpace(part)
+			switch strings.ToLower(part) {
+			case "", "all", "*", "-1":
+				return nil, nil
+			}
+
+			from, to, found := strings.Cut(part, "-")
+			if !found {
+				to = from
+			}
+			fromPort, err := strconv.ParseInt(strings.TrimSpace(from), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port: %s", part)
+			}
+			toPort, err := strconv.ParseInt(strings.TrimSpace(to), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port: %s", part)
+			}
+			if fromPort < 0 || toPort > maxPort || fromPort > toPort {
+				return nil, fmt.Errorf("invalid port range: %s", part)
+			}
+			result = append(result, portRange{from: fromPort, to: toPort})
+		}
+	}
+	return result, nil
+}
+
+// parseSource 解析来源地址，支持单个地址及 CIDR，无法解析时返回 false
+func parseSource(source string) (netip.Prefix, bool) {
+	prefix, err := cloudserver.ParseExposureSource(strings.TrimSpace(source))
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	return prefix, true
+}
+
+// tcloudService 腾讯云协议端口模板展开后的协议及端口
+type tcloudService struct {
+	protocols []string
+	ports     []portRange
+}
+
+// tcloudTplResolver 展开腾讯云参数模板，key 为模板云上ID
+type tcloudTplResolver map[string]argstpl.BaseArgsTpl
+
+// addresses 展开地址模板或地址组模板，模板不存在时返回 false
+func (r tcloudTplResolver) addresses(cloudID string) ([]netip.Prefix, bool) {
+	tpl, exist := r[cloudID]
+	if !exist {
+		return nil, false
+	}
+
+	switch tpl.Type {
+	case enumor.AddressType:
+		result := make([]netip.Prefix, 0)
+		for _, one := range converter.PtrToVal(tpl.Templates) {
+			if prefix, ok := parseSource(converter.PtrToVal(one.Address)); ok {
+				result = append(result, prefix)
+			}
+		}
+		return result, true
+	case enumor.AddressGroupType:
+		result := make([]netip.Prefix, 0)
+		for _, member := range converter.PtrToVal(tpl.GroupTemplates) {
+			prefixes, ok := r.addresses(member)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, prefixes...)
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// services 展开协议端口模板或协议端口组模板，模板不存在时返回 false
+func (r tcloudTplResolver) services(cloudID string) ([]tcloudService, bool) {
+	tpl, exist := r[cloudID]
+	if !exist {
+		return nil, false
+	}
+
+	switch tpl.Type {
+	case enumor.ServiceType:
+		result := make([]tcloudService, 0)
+		for _, one := range converter.PtrToVal(tpl.Templates) {
+			// 协议端口格式：tcp:80、udp:53,60、tcp:3306-20000、icmp、ALL
+			protocol, ports, _ := strings.Cut(converter.PtrToVal(one.Address), ":")
+			portRanges, err := parsePorts(ports)
+			if err != nil {
+				continue
+			}
+			result = append(result, tcloudService{protocols: parseProtocol(protocol), ports: portRanges})
+		}
+		return result, true
+	case enumor.ServiceGroupType:
+		result := make([]tcloudService, 0)
+		for _, member := range converter.PtrToVal(tpl.GroupTemplates) {
+			services, ok := r.services(member)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, services...)
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// convTCloudRule 转换腾讯云入站规则，协议端口模板会按模板内容展开为多条规则
+func convTCloudRule(rule corecloud.TCloudSecurityGroupRule, resolver tcloudTplResolver) []exposureRule {
+	base := exposureRule{
+		ref:      cloudserver.ExposureRuleRef{SecurityGroupID: rule.SecurityGroupID, RuleID: rule.ID},
+		allow:    strings.EqualFold(rule.Action, "ACCEPT"),
+		priority: rule.CloudPolicyIndex,
+	}
+
+	switch {
+	case converter.PtrToVal(rule.IPv4Cidr) != "" || converter.PtrToVal(rule.IPv6Cidr) != "":
+		for _, cidr := range []string{converter.PtrToVal(rule.IPv4Cidr), converter.PtrToVal(rule.IPv6Cidr)} {
+			if prefix, ok := parseSource(cidr); ok {
+				base.sources = append(base.sources, prefix)
+			}
+		}
+	case converter.PtrToVal(rule.CloudAddressID) != "" || converter.PtrToVal(rule.CloudAddressGroupID) != "":
+		cloudID := converter.PtrToVal(rule.CloudAddressID)
+		if cloudID == "" {
+			cloudID = converter.PtrToVal(rule.CloudAddressGroupID)
+		}
+		prefixes, ok := resolver.addresses(cloudID)
+		base.sources = prefixes
+		base.unresolved = !ok
+	default:
+		// 来源为安全组
+		base.unresolved = true
+	}
+
+	serviceCloudID := converter.PtrToVal(rule.CloudServiceID)
+	if serviceCloudID == "" {
+		serviceCloudID = converter.PtrToVal(rule.CloudServiceGroupID)
+	}
+	if serviceCloudID == "" {
+		ports, err := parsePorts(converter.PtrToVal(rule.Port))
+		if err != nil {
+			base.unresolved = true
+		}
+		base.protocols = parseProtocol(converter.PtrToVal(rule.Protocol))
+		base.ports = ports
+		return []exposureRule{base}
+	}
+
+	services, ok := resolver.services(serviceCloudID)
+	if !ok {
+		base.unresolved = true
+		return []exposureRule{base}
+	}
+
+	result := make([]exposureRule, 0, len(services))
+	for _, service := range services {
+		one := base
+		one.protocols = service.protocols
+		one.ports = service.ports
+		result = append(result, one)
+	}
+	return result
+}
+
+// convAwsRule 转换亚马逊入站规则，亚马逊安全组只有放通规则
+func convAwsRule(rule corecloud.AwsSecurityGroupRule) exposureRule {
+	result := exposureRule{
+		ref:       cloudserver.ExposureRuleRef{SecurityGroupID: rule.SecurityGroupID, RuleID: rule.ID},
+		protocols: parseProtocol(converter.PtrToVal(rule.Protocol)),
+		allow:     true,
+	}
+
+	fromPort, toPort := converter.PtrToVal(rule.FromPort), converter.PtrToVal(rule.ToPort)
+	if rule.FromPort != nil && rule.ToPort != nil && fromPort >= 0 && toPort <= maxPort && fromPort <= toPort {
+		result.ports = []portRange{{from: fromPort, to: toPort}}
+	}
+
+	for _, cidr := range []string{converter.PtrToVal(rule.IPv4Cidr), converter.PtrToVal(rule.IPv6Cidr)} {
+		if prefix, ok := parseSource(cidr); ok {
+			result.sources = append(result.sources, prefix)
+		}
+	}
+	if len(result.sources) == 0 {
+		// 来源为前缀列表或安全组
+		result.unresolved = true
+	}
+
+	return result
+}
+
+// convHuaWeiRule 转换华为云入站规则，未指定远端时表示对应 IP 版本的全部地址
+func convHuaWeiRule(rule corecloud.HuaWeiSecurityGroupRule) exposureRule {
+	result := exposureRule{
+		ref:       cloudserver.ExposureRuleRef{SecurityGroupID: rule.SecurityGroupID, RuleID: rule.ID},
+		protocols: parseProtocol(rule.Protocol),
+		allow:     strings.EqualFold(rule.Action, "allow"),
+		priority:  rule.Priority,
+	}
+
+	ports, err := parsePorts(rule.Port)
+	if err != nil {
+		result.unresolved = true
+	}
+	result.ports = ports
+
+	switch {
+	case rule.RemoteIPPrefix != "":
+		if prefix, ok := parseSource(rule.RemoteIPPrefix); ok {
+			result.sources = []netip.Prefix{prefix}
+		}
+	case rule.CloudRemoteGroupID != "" || rule.CloudRemoteAddressGroupID != "":
+		result.unresolved = true
+	case strings.EqualFold(rule.Ethertype, "IPv6"):
+		result.sources = []netip.Prefix{anyIPv6}
+	default:
+		result.sources = []netip.Prefix{anyIPv4}
+	}
+
+	return result
+}
+
+// convAzureRule 转换微软云入站规则，来源为 *、Internet 时表示全部地址，其他服务标记不视为公网来源
+func convAzureRule(rule corecloud.AzureSecurityGroupRule) exposureRule {
+	result := exposureRule{
+		ref:       cloudserver.ExposureRuleRef{SecurityGroupID: rule.SecurityGroupID, RuleID: rule.ID},
+		protocols: parseProtocol(rule.Protocol),
+		allow:     strings.EqualFold(rule.Access, "Allow"),
+		priority:  int64(rule.Priority),
+	}
+
+	portExprs := converter.PtrToSlice(rule.DestinationPortRanges)
+	if rule.DestinationPortRange != nil {
+		portExprs = append(portExprs, *rule.DestinationPortRange)
+	}
+	ports, err := parsePorts(portExprs...)
+	if err != nil {
+		result.unresolved = true
+	}
+	result.ports = ports
+
+	sources := converter.PtrToSlice(rule.SourceAddressPrefixes)
+	if rule.SourceAddressPrefix != nil {
+		sources = append(sources, *rule.SourceAddressPrefix)
+	}
+	for _, source := range sources {
+		switch strings.ToLower(source) {
+		case "*", "internet", "any":
+			result.sources = append(result.sources, anyIPv4, anyIPv6)
+			continue
+		}
+		if prefix, ok := parseSource(source); ok {
+			result.sources = append(result.sources, prefix)
+		}
+	}
+	if len(sources) == 0 && len(rule.CloudSourceAppSecurityGroupIDs) != 0 {
+		result.unresolved = true
+	}
+
+	return result
+}
+
+// convGcpRule 转换谷歌云入站防火墙规则，指定了目标网络标记或服务账号的规则无法确定生效的主机，视为无法解析
+func convGcpRule(rule corecloud.GcpFirewallRule) []exposureRule {
+	ref := cloudserver.ExposureRuleRef{RuleID: rule.ID}
+	unresolved := len(rule.TargetTags) != 0 || len(rule.TargetServiceAccounts) != 0 || len(rule.SourceRanges) == 0
+
+	sources := make([]netip.Prefix, 0, len(rule.SourceRanges))
+	for _, one := range rule.SourceRanges {
+		if prefix, ok := parseSource(one); ok {
+			sources = append(sources, prefix)
+		}
+	}
+
+	result := make([]exposureRule, 0, len(rule.Allowed)+len(rule.Denied))
+	convSets := func(sets []corecloud.GcpProtocolSet, allow bool) {
+		for _, set := range sets {
+			ports, err := parsePorts(set.Port...)
+			result = append(result, exposureRule{
+				ref:        ref,
+				protocols:  parseProtocol(set.Protocol),
+				ports:      ports,
+				sources:    sources,
+				unresolved: unresolved || err != nil,
+				allow:      allow,
+				priority:   rule.Priority,
+			})
+		}
+	}
+	convSets(rule.Allowed, true)
+	convSets(rule.Denied, false)
+
+	return result
+}
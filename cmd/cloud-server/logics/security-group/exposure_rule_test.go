@@ -0,0 +1,177 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"net/netip"
+	"testing"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	corecloud "hcm/pkg/api/core/cloud"
+	argstpl "hcm/pkg/api/core/cloud/argument-template"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateExposure(t *testing.T) {
+	anySource := netip.MustParsePrefix("0.0.0.0/0")
+	tests := []struct {
+		name  string
+		rules []exposureRule
+		want  []portExposure
+	}{
+		{
+			name: "deny before allow masks port",
+			rules: []exposureRule{
+				{ref: cloudserver.ExposureRuleRef{RuleID: "allow"}, protocols: []string{protocolTCP},
+					ports: []portRange{{from: 0, to: 100}}, sources: []netip.Prefix{anySource}, allow: true,
+					priority: 2},
+				{ref: cloudserver.ExposureRuleRef{RuleID: "deny"}, protocols: []string{protocolTCP},
+					ports: []portRange{{from: 22, to: 22}}, sources: []netip.Prefix{anySource}, priority: 1},
+			},
+			want: []portExposure{
+				{protocol: protocolTCP, ports: portRange{from: 0, to: 21},
+					ref: cloudserver.ExposureRuleRef{RuleID: "allow"}},
+				{protocol: protocolTCP, ports: portRange{from: 23, to: 100},
+					ref: cloudserver.ExposureRuleRef{RuleID: "allow"}},
+			},
+		},
+		{
+			name: "private source is not exposure",
+			rules: []exposureRule{
+				{ref: cloudserver.ExposureRuleRef{RuleID: "private"}, protocols: []string{protocolTCP},
+					sources: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}, allow: true},
+			},
+			want: []portExposure{},
+		},
+		{
+			name: "same priority deny first",
+			rules: []exposureRule{
+				{ref: cloudserver.ExposureRuleRef{RuleID: "allow"}, protocols: exposureProtocols,
+					sources: []netip.Prefix{anySource}, allow: true, priority: 1},
+				{ref: cloudserver.ExposureRuleRef{RuleID: "deny"}, protocols: []string{protocolUDP, protocolICMP},
+					sources: []netip.Prefix{anySource}, priority: 1},
+			},
+			want: []portExposure{
+				{protocol: protocolTCP, ports: portRange{from: 0, to: maxPort},
+					ref: cloudserver.ExposureRuleRef{RuleID: "allow"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := evaluateExposure(tt.rules, anySource)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluateOrderedExposure(t *testing.T) {
+	anySource := netip.MustParsePrefix("0.0.0.0/0")
+	first := []exposureRule{
+		{ref: cloudserver.ExposureRuleRef{RuleID: "deny-ssh"}, protocols: []string{protocolTCP},
+			ports: []portRange{{from: 22, to: 22}}, sources: []netip.Prefix{anySource}, priority: 5},
+	}
+	second := []exposureRule{
+		{ref: cloudserver.ExposureRuleRef{RuleID: "allow-all"}, protocols: []string{protocolTCP},
+			sources: []netip.Prefix{anySource}, allow: true, priority: 0},
+	}
+
+	got, _ := evaluateOrderedExposure([][]exposureRule{first, second}, anySource)
+	assert.Equal(t, []portExposure{
+		{protocol: protocolTCP, ports: portRange{from: 0, to: 21},
+			ref: cloudserver.ExposureRuleRef{RuleID: "allow-all"}},
+		{protocol: protocolTCP, ports: portRange{from: 23, to: maxPort},
+			ref: cloudserver.ExposureRuleRef{RuleID: "allow-all"}},
+	}, got, "deny in the first bound group shadows allow in later group")
+
+	got, _ = evaluateOrderedExposure([][]exposureRule{second, first}, anySource)
+	assert.Equal(t, []portExposure{
+		{protocol: protocolTCP, ports: portRange{from: 0, to: maxPort},
+			ref: cloudserver.ExposureRuleRef{RuleID: "allow-all"}},
+	}, got, "allow in the first bound group wins")
+}
+
+func TestIntersectPortExposures(t *testing.T) {
+	nicRef := cloudserver.ExposureRuleRef{RuleID: "nic"}
+	subnetRef := cloudserver.ExposureRuleRef{RuleID: "subnet"}
+	nic := []portExposure{
+		{protocol: protocolTCP, ports: portRange{from: 0, to: 1000}, ref: nicRef},
+		{protocol: protocolUDP, ports: portRange{from: 53, to: 53}, ref: nicRef},
+	}
+	subnet := []portExposure{
+		{protocol: protocolTCP, ports: portRange{from: 443, to: 8080}, ref: subnetRef},
+	}
+
+	assert.Equal(t, []portExposure{
+		{protocol: protocolTCP, ports: portRange{from: 443, to: 1000}, ref: nicRef},
+		{protocol: protocolTCP, ports: portRange{from: 443, to: 1000}, ref: subnetRef},
+	}, intersectPortExposures(nic, subnet))
+	assert.Empty(t, intersectPortExposures(nic, nil))
+}
+
+func TestConvTCloudRuleWithTemplate(t *testing.T) {
+	resolver := tcloudTplResolver{
+		"ipm-1": {CloudID: "ipm-1", Type: enumor.AddressType,
+			Templates: &[]argstpl.TemplateInfo{{Address: converter.ValToPtr("0.0.0.0/0")}}},
+		"ipmg-1": {CloudID: "ipmg-1", Type: enumor.AddressGroupType, GroupTemplates: &[]string{"ipm-1"}},
+		"ppm-1": {CloudID: "ppm-1", Type: enumor.ServiceType,
+			Templates: &[]argstpl.TemplateInfo{{Address: converter.ValToPtr("tcp:22,3389")},
+				{Address: converter.ValToPtr("udp:53")}}},
+	}
+	rule := corecloud.TCloudSecurityGroupRule{
+		ID:                  "rule-1",
+		SecurityGroupID:     "sg-1",
+		CloudAddressGroupID: converter.ValToPtr("ipmg-1"),
+		CloudServiceID:      converter.ValToPtr("ppm-1"),
+		Action:              "ACCEPT",
+	}
+
+	rules := convTCloudRule(rule, resolver)
+	got, unresolved := evaluateExposure(rules, netip.MustParsePrefix("1.1.1.1/32"))
+	assert.Empty(t, unresolved)
+
+	ref := cloudserver.ExposureRuleRef{SecurityGroupID: "sg-1", RuleID: "rule-1"}
+	assert.Equal(t, []portExposure{
+		{protocol: protocolTCP, ports: portRange{from: 22, to: 22}, ref: ref},
+		{protocol: protocolTCP, ports: portRange{from: 3389, to: 3389}, ref: ref},
+		{protocol: protocolUDP, ports: portRange{from: 53, to: 53}, ref: ref},
+	}, got)
+
+	rule.CloudServiceID = converter.ValToPtr("ppm-not-exist")
+	_, unresolved = evaluateExposure(convTCloudRule(rule, resolver), netip.MustParsePrefix("1.1.1.1/32"))
+	assert.Equal(t, []cloudserver.ExposureRuleRef{ref}, unresolved)
+}
+
+func TestParsePorts(t *testing.T) {
+	ports, err := parsePorts("22, 80-90")
+	assert.NoError(t, err)
+	assert.Equal(t, []portRange{{from: 22, to: 22}, {from: 80, to: 90}}, ports)
+
+	ports, err = parsePorts("ALL")
+	assert.NoError(t, err)
+	assert.Empty(t, ports)
+
+	_, err = parsePorts("90-80")
+	assert.Error(t, err)
+}
@@ -0,0 +1,326 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+	"strings"
+
+	actionsg "hcm/cmd/task-server/logics/action/security-group"
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	coresgruletpl "hcm/pkg/api/core/cloud/sg-rule-template"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcproto "hcm/pkg/api/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// DiffRuleTemplate 对比安全组同步后的规则，返回模板中存在但安全组中不存在的规则，及安全组中存在但模板中不存在的规则
+func (sg *securityGroup) DiffRuleTemplate(kt *kit.Kit, vendor enumor.Vendor, sgID string,
+	rules []coresgruletpl.Rule) (missing []coresgruletpl.Rule, extra []coresgruletpl.Rule, err error) {
+
+	existing, err := sg.listRulesAsTemplate(kt, vendor, sgID)
+	if err != nil {
+		logs.Errorf("list security group rules failed, err: %v, sg: %s, rid: %s", err, sgID, kt.Rid)
+		return nil, nil, err
+	}
+
+	missing, extra = diffTemplateRules(rules, existing)
+	return missing, extra, nil
+}
+
+// SGRuleTemplateDrift 生成模板绑定的安全组偏离报告
+func (sg *securityGroup) SGRuleTemplateDrift(kt *kit.Kit, tpl *coresgruletpl.SGRuleTemplate,
+	rels []coresgruletpl.SGRuleTemplateRel) ([]cloudserver.SGRuleTemplateRelDrift, error) {
+
+	result := make([]cloudserver.SGRuleTemplateRelDrift, 0, len(rels))
+	for _, rel := range rels {
+		missing, extra, err := sg.DiffRuleTemplate(kt, rel.Vendor, rel.SecurityGroupID, tpl.Rules)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, cloudserver.SGRuleTemplateRelDrift{
+			SGRuleTemplateRel: rel,
+			Status:            driftStatus(rel.AppliedVersion, tpl.Version, missing, extra),
+			MissingRules:      missing,
+			ExtraRules:        extra,
+		})
+	}
+
+	return result, nil
+}
+
+// NewSGRuleTemplateApplyOpt 生成应用规则模板的异步任务参数，只创建安全组中缺失的规则
+func NewSGRuleTemplateApplyOpt(rel coresgruletpl.SGRuleTemplateRel, version uint64,
+	missing []coresgruletpl.Rule) (*actionsg.ApplySGRuleTemplateOption, error) {
+
+	opt := &actionsg.ApplySGRuleTemplateOption{
+		RelID:           rel.ID,
+		TemplateVersion: version,
+		Vendor:          rel.Vendor,
+		SGID:            rel.SecurityGroupID,
+	}
+
+	ingress, egress := splitRulesByType(missing)
+	for _, rules := range [][]coresgruletpl.Rule{ingress, egress} {
+		if len(rules) == 0 {
+			continue
+		}
+
+		switch rel.Vendor {
+		case enumor.TCloud:
+			req := &hcproto.TCloudSGRuleCreateReq{AccountID: rel.AccountID}
+			ruleSet := slice.Map(rules, tplToTCloudRule)
+			if rules[0].Type == enumor.Ingress {
+				req.IngressRuleSet = ruleSet
+			} else {
+				req.EgressRuleSet = ruleSet
+			}
+			opt.TCloudRuleReqs = append(opt.TCloudRuleReqs, req)
+
+		case enumor.Aws:
+			req := &hcproto.AwsSGRuleCreateReq{AccountID: rel.AccountID}
+			ruleSet := slice.Map(rules, tplToAwsRule)
+			if rules[0].Type == enumor.Ingress {
+				req.IngressRuleSet = ruleSet
+			} else {
+				req.EgressRuleSet = ruleSet
+			}
+			opt.AwsRuleReqs = append(opt.AwsRuleReqs, req)
+
+		default:
+			return nil, errf.NewFromErr(errf.InvalidParameter,
+				fmt.Errorf("security group rule template not support vendor: %s", rel.Vendor))
+		}
+	}
+
+	return opt, nil
+}
+
+func (sg *securityGroup) listRulesAsTemplate(kt *kit.Kit, vendor enumor.Vendor, sgID string) (
+	[]coresgruletpl.Rule, error) {
+
+	ds := sg.client.DataService()
+	switch vendor {
+	case enumor.TCloud:
+		rules, err := listAllRules(func(page *core.BasePage) ([]corecloud.TCloudSecurityGroupRule, error) {
+			res, err := ds.TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&protocloud.TCloudSGRuleListReq{Page: page}, sgID)
+			if err != nil {
+				return nil, err
+			}
+			return res.Details, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return slice.Map(rules, tcloudRuleToTpl), nil
+
+	case enumor.Aws:
+		rules, err := listAllRules(func(page *core.BasePage) ([]corecloud.AwsSecurityGroupRule, error) {
+			res, err := ds.Aws.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&protocloud.AwsSGRuleListReq{Page: page}, sgID)
+			if err != nil {
+				return nil, err
+			}
+			return res.Details, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return slice.Map(rules, awsRuleToTpl), nil
+
+	default:
+		return nil, errf.NewFromErr(errf.InvalidParameter,
+			fmt.Errorf("security group rule template not support vendor: %s", vendor))
+	}
+}
+
+// diffTemplateRules 按 Rule.Key 比较，返回 expected 中不存在于 existing 的规则，及 existing 中不存在于 expected 的规则
+func diffTemplateRules(expected, existing []coresgruletpl.Rule) (missing, extra []coresgruletpl.Rule) {
+	return subtractTemplateRules(expected, existing), subtractTemplateRules(existing, expected)
+}
+
+// subtractTemplateRules 返回 rules 中不存在于 excluded 的规则
+func subtractTemplateRules(rules, excluded []coresgruletpl.Rule) []coresgruletpl.Rule {
+	keys := make(map[string]struct{}, len(excluded))
+	for _, rule := range excluded {
+		keys[rule.Key()] = struct{}{}
+	}
+
+	result := make([]coresgruletpl.Rule, 0)
+	for _, rule := range rules {
+		if _, exists := keys[rule.Key()]; !exists {
+			result = append(result, rule)
+		}
+	}
+
+	return result
+}
+
+func driftStatus(appliedVersion, version uint64, missing, extra []coresgruletpl.Rule) enumor.SGRuleTemplateDriftStatus {
+	switch {
+	case appliedVersion == 0:
+		return enumor.SGRuleTemplateNotApplied
+	case appliedVersion < version:
+		return enumor.SGRuleTemplateOutdated
+	case len(missing) != 0 || len(extra) != 0:
+		return enumor.SGRuleTemplateDrifted
+	default:
+		return enumor.SGRuleTemplateInSync
+	}
+}
+
+func splitRulesByType(rules []coresgruletpl.Rule) (ingress, egress []coresgruletpl.Rule) {
+	for _, rule := range rules {
+		if rule.Type == enumor.Ingress {
+			ingress = append(ingress, rule)
+		} else {
+			egress = append(egress, rule)
+		}
+	}
+
+	return ingress, egress
+}
+
+// normalizeProtocol 将云上规则的协议转换为规则模板的协议，无法识别的协议原样返回
+func normalizeProtocol(protocol string) string {
+	switch strings.ToLower(protocol) {
+	case "", "all", "-1":
+		return coresgruletpl.ProtocolAll
+	case "tcp", "6":
+		return coresgruletpl.ProtocolTCP
+	case "udp", "17":
+		return coresgruletpl.ProtocolUDP
+	case "icmp", "1", "icmpv6", "58":
+		return coresgruletpl.ProtocolICMP
+	default:
+		return strings.ToLower(protocol)
+	}
+}
+
+func tcloudRuleToTpl(rule corecloud.TCloudSecurityGroupRule) coresgruletpl.Rule {
+	result := coresgruletpl.Rule{
+		Type:     rule.Type,
+		Protocol: normalizeProtocol(converter.PtrToVal(rule.Protocol)),
+		Port:     converter.PtrToVal(rule.Port),
+		IPv4Cidr: converter.PtrToVal(rule.IPv4Cidr),
+		IPv6Cidr: converter.PtrToVal(rule.IPv6Cidr),
+		Action:   strings.ToLower(rule.Action),
+		Memo:     converter.PtrToVal(rule.Memo),
+	}
+
+	if result.Protocol == coresgruletpl.ProtocolICMP || result.Protocol == coresgruletpl.ProtocolAll ||
+		len(result.Port) == 0 || strings.EqualFold(result.Port, coresgruletpl.PortAll) {
+		result.Port = coresgruletpl.PortAll
+	}
+
+	return result
+}
+
+func awsRuleToTpl(rule corecloud.AwsSecurityGroupRule) coresgruletpl.Rule {
+	result := coresgruletpl.Rule{
+		Type:     rule.Type,
+		Protocol: normalizeProtocol(converter.PtrToVal(rule.Protocol)),
+		Port:     coresgruletpl.PortAll,
+		IPv4Cidr: converter.PtrToVal(rule.IPv4Cidr),
+		IPv6Cidr: converter.PtrToVal(rule.IPv6Cidr),
+		Action:   coresgruletpl.RuleActionAccept,
+		Memo:     converter.PtrToVal(rule.Memo),
+	}
+
+	if (result.Protocol == coresgruletpl.ProtocolTCP || result.Protocol == coresgruletpl.ProtocolUDP) &&
+		rule.FromPort != nil && rule.ToPort != nil {
+		result.Port = coresgruletpl.FormatPort(*rule.FromPort, *rule.ToPort)
+	}
+
+	return result
+}
+
+func tplToTCloudRule(rule coresgruletpl.Rule) hcproto.TCloudSGRuleCreate {
+	protocol := strings.ToUpper(rule.Protocol)
+	if rule.Protocol == coresgruletpl.ProtocolICMP && len(rule.IPv6Cidr) != 0 {
+		protocol = "ICMPv6"
+	}
+
+	port := coresgruletpl.PortAll
+	if from, to, err := rule.PortRange(); err == nil {
+		port = coresgruletpl.FormatPort(from, to)
+	}
+
+	result := hcproto.TCloudSGRuleCreate{
+		Protocol: converter.ValToPtr(protocol),
+		Port:     converter.ValToPtr(port),
+		Action:   strings.ToUpper(rule.Action),
+	}
+	if len(rule.IPv4Cidr) != 0 {
+		result.IPv4Cidr = converter.ValToPtr(rule.IPv4Cidr)
+	}
+	if len(rule.IPv6Cidr) != 0 {
+		result.IPv6Cidr = converter.ValToPtr(rule.IPv6Cidr)
+	}
+	if len(rule.Memo) != 0 {
+		result.Memo = converter.ValToPtr(rule.Memo)
+	}
+
+	return result
+}
+
+func tplToAwsRule(rule coresgruletpl.Rule) hcproto.AwsSGRuleCreate {
+	protocol := rule.Protocol
+	var fromPort, toPort int64 = -1, -1
+	switch rule.Protocol {
+	case coresgruletpl.ProtocolTCP, coresgruletpl.ProtocolUDP:
+		fromPort, toPort = 0, 65535
+		if from, to, err := rule.PortRange(); err == nil && from >= 0 {
+			fromPort, toPort = from, to
+		}
+	case coresgruletpl.ProtocolICMP:
+		if len(rule.IPv6Cidr) != 0 {
+			protocol = "icmpv6"
+		}
+	case coresgruletpl.ProtocolAll:
+		protocol = "-1"
+	}
+
+	result := hcproto.AwsSGRuleCreate{
+		Protocol: converter.ValToPtr(protocol),
+		FromPort: converter.ValToPtr(fromPort),
+		ToPort:   converter.ValToPtr(toPort),
+	}
+	if len(rule.IPv4Cidr) != 0 {
+		result.IPv4Cidr = converter.ValToPtr(rule.IPv4Cidr)
+	}
+	if len(rule.IPv6Cidr) != 0 {
+		result.IPv6Cidr = converter.ValToPtr(rule.IPv6Cidr)
+	}
+	if len(rule.Memo) != 0 {
+		result.Memo = converter.ValToPtr(rule.Memo)
+	}
+
+	return result
+}
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"testing"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	coresgruletpl "hcm/pkg/api/core/cloud/sg-rule-template"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTemplateRules(t *testing.T) {
+	tpl := []coresgruletpl.Rule{
+		{Type: enumor.Ingress, Protocol: "tcp", Port: "22", IPv4Cidr: "10.0.0.1/8", Action: "accept"},
+		{Type: enumor.Ingress, Protocol: "tcp", Port: "0-65535", IPv4Cidr: "0.0.0.0/0", Action: "drop"},
+		{Type: enumor.Egress, Protocol: "all", Port: "ALL", IPv6Cidr: "::/0", Action: "accept"},
+	}
+
+	tcloudRules := []corecloud.TCloudSecurityGroupRule{
+		{Type: enumor.Ingress, Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("22"),
+			IPv4Cidr: converter.ValToPtr("10.0.0.0/8"), Action: "ACCEPT", Memo: converter.ValToPtr("ssh")},
+		{Type: enumor.Ingress, Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("ALL"),
+			IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "DROP"},
+	}
+	missing, extra := diffTemplateRules(tpl, slice.Map(tcloudRules, tcloudRuleToTpl))
+	assert.Equal(t, []coresgruletpl.Rule{tpl[2]}, missing)
+	assert.Empty(t, extra)
+
+	awsRules := []corecloud.AwsSecurityGroupRule{
+		{Type: enumor.Ingress, Protocol: converter.ValToPtr("tcp"), FromPort: converter.ValToPtr(int64(22)),
+			ToPort: converter.ValToPtr(int64(22)), IPv4Cidr: converter.ValToPtr("10.0.0.0/8")},
+		{Type: enumor.Egress, Protocol: converter.ValToPtr("-1"), IPv6Cidr: converter.ValToPtr("::/0")},
+		{Type: enumor.Ingress, Protocol: converter.ValToPtr("tcp"), FromPort: converter.ValToPtr(int64(3389)),
+			ToPort: converter.ValToPtr(int64(3389)), IPv4Cidr: converter.ValToPtr("0.0.0.0/0")},
+	}
+	missing, extra = diffTemplateRules(tpl, slice.Map(awsRules, awsRuleToTpl))
+	assert.Equal(t, []coresgruletpl.Rule{tpl[1]}, missing)
+	assert.Equal(t, []coresgruletpl.Rule{awsRuleToTpl(awsRules[2])}, extra)
+}
+
+func TestTemplateRuleRoundTrip(t *testing.T) {
+	rules := []coresgruletpl.Rule{
+		{Type: enumor.Ingress, Protocol: "tcp", Port: "8000-9000", IPv4Cidr: "10.0.0.0/8", Action: "accept"},
+		{Type: enumor.Ingress, Protocol: "udp", Port: "ALL", IPv4Cidr: "10.0.0.0/8", Action: "accept"},
+		{Type: enumor.Egress, Protocol: "icmp", Port: "ALL", IPv6Cidr: "::/0", Action: "accept"},
+	}
+
+	for _, rule := range rules {
+		created := tplToTCloudRule(rule)
+		tcloudRule := tcloudRuleToTpl(corecloud.TCloudSecurityGroupRule{Type: rule.Type, Protocol: created.Protocol,
+			Port: created.Port, IPv4Cidr: created.IPv4Cidr, IPv6Cidr: created.IPv6Cidr, Action: created.Action})
+		assert.Equal(t, rule.Key(), tcloudRule.Key())
+
+		awsCreated := tplToAwsRule(rule)
+		awsRule := awsRuleToTpl(corecloud.AwsSecurityGroupRule{Type: rule.Type, Protocol: awsCreated.Protocol,
+			FromPort: awsCreated.FromPort, ToPort: awsCreated.ToPort, IPv4Cidr: awsCreated.IPv4Cidr,
+			IPv6Cidr: awsCreated.IPv6Cidr})
+		assert.Equal(t, rule.Key(), awsRule.Key())
+	}
+}
+
+func TestDriftStatus(t *testing.T) {
+	missing := []coresgruletpl.Rule{{Type: enumor.Ingress}}
+	assert.Equal(t, enumor.SGRuleTemplateNotApplied, driftStatus(0, 1, nil, nil))
+	assert.Equal(t, enumor.SGRuleTemplateOutdated, driftStatus(1, 2, nil, nil))
+	assert.Equal(t, enumor.SGRuleTemplateDrifted, driftStatus(2, 2, missing, nil))
+	assert.Equal(t, enumor.SGRuleTemplateDrifted, driftStatus(2, 2, nil, missing))
+	assert.Equal(t, enumor.SGRuleTemplateInSync, driftStatus(2, 2, nil, nil))
+}
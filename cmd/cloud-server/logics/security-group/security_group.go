@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package securitygroup ...
+package securitygroup
+
+import (
+	"fmt"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	coresgruletpl "hcm/pkg/api/core/cloud/sg-rule-template"
+	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// Interface define security group interface.
+type Interface interface {
+	DeleteSecurityGroup(kt *kit.Kit, vendor enumor.Vendor, id string) error
+	DeleteRecycledSecurityGroup(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo) (
+		*core.BatchOperateResult, error)
+	AnalyzeExposure(kt *kit.Kit, cvms []corecvm.BaseCvm, req *cloudserver.SGExposureReq) (
+		*cloudserver.SGExposureResult, error)
+	DiffRuleTemplate(kt *kit.Kit, vendor enumor.Vendor, sgID string, rules []coresgruletpl.Rule) (
+		missing []coresgruletpl.Rule, extra []coresgruletpl.Rule, err error)
+	SGRuleTemplateDrift(kt *kit.Kit, tpl *coresgruletpl.SGRuleTemplate, rels []coresgruletpl.SGRuleTemplateRel) (
+		[]cloudserver.SGRuleTemplateRelDrift, error)
+}
+
+type securityGroup struct {
+	client *client.ClientSet
+	audit  audit.Interface
+}
+
+// NewSecurityGroup new security group.
+func NewSecurityGroup(client *client.ClientSet, audit audit.Interface) Interface {
+	return &securityGroup{
+		client: client,
+		audit:  audit,
+	}
+}
+
+// DeleteSecurityGroup delete security group.
+func (sg *securityGroup) DeleteSecurityGroup(kt *kit.Kit, vendor enumor.Vendor, id string) error {
+	if err := sg.audit.ResDeleteAudit(kt, enumor.SecurityGroupAuditResType, []string{id}); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return sg.client.HCService().TCloud.SecurityGroup.DeleteSecurityGroup(kt, id)
+	case enumor.Aws:
+		return sg.client.HCService().Aws.SecurityGroup.DeleteSecurityGroup(kt, id)
+	case enumor.HuaWei:
+		return sg.client.HCService().HuaWei.SecurityGroup.DeleteSecurityGroup(kt, id)
+	case enumor.Azure:
+		return sg.client.HCService().Azure.SecurityGroup.DeleteSecurityGroup(kt, id)
+	default:
+		return errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", vendor))
+	}
+}
+
+// DeleteRecycledSecurityGroup batch delete recycled security group.
+func (sg *securityGroup) DeleteRecycledSecurityGroup(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+	*core.BatchOperateResult, error) {
+
+	if len(basicInfoMap) == 0 {
+		return nil, nil
+	}
+
+	if len(basicInfoMap) > constant.BatchOperationMaxLimit {
+		return nil, errf.Newf(errf.InvalidParameter, "security group length should <= %d",
+			constant.BatchOperationMaxLimit)
+	}
+
+	ids := make([]string, 0, len(basicInfoMap))
+	for id := range basicInfoMap {
+		ids = append(ids, id)
+	}
+
+	// 安全组在回收站期间可能被重新绑定，删除前需要再次检查
+	if err := ValidateNotBound(kt, sg.client.DataService(), ids); err != nil {
+		return nil, err
+	}
+
+	res := new(core.BatchOperateResult)
+	for _, id := range ids {
+		if err := sg.DeleteSecurityGroup(kt, basicInfoMap[id].Vendor, id); err != nil {
+			res.Failed = &core.FailedInfo{ID: id, Error: err}
+			return res, err
+		}
+		res.Succeeded = append(res.Succeeded, id)
+	}
+
+	return res, nil
+}
+
+// ValidateNotBound 校验安全组未绑定主机、负载均衡等资源，已绑定的安全组不允许回收及删除
+func ValidateNotBound(kt *kit.Kit, cli *dataservice.Client, ids []string) error {
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		relReq := &core.ListReq{
+			Filter: tools.ContainersExpression("security_group_id", batch),
+			Page:   &core.BasePage{Count: true},
+		}
+		commonRelRes, err := cli.Global.SGCommonRel.ListSgCommonRels(kt, relReq)
+		if err != nil {
+			logs.Errorf("list security group common rel failed, err: %v, sg ids: %v, rid: %s", err, batch, kt.Rid)
+			return err
+		}
+		if commonRelRes.Count > 0 {
+			return errf.Newf(errf.InvalidParameter, "some security groups(ids: %v) are bound to resources, "+
+				"please disassociate first", batch)
+		}
+
+		cvmRelRes, err := cli.Global.SGCvmRel.ListSgCvmRels(kt.Ctx, kt.Header(), relReq)
+		if err != nil {
+			logs.Errorf("list security group cvm rel failed, err: %v, sg ids: %v, rid: %s", err, batch, kt.Rid)
+			return err
+		}
+		if cvmRelRes.Count > 0 {
+			return errf.Newf(errf.InvalidParameter, "some security groups(ids: %v) are bound to cvm, "+
+				"please disassociate first", batch)
+		}
+	}
+
+	return nil
+}
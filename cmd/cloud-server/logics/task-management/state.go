@@ -17,11 +17,10 @@
  * to the current version of the project delivered to anyone in the future.
  */
 
-package task
+// Package taskmanagement 任务管理相关逻辑
+package taskmanagement
 
 import (
-	"time"
-
 	"hcm/pkg/api/core"
 	coretask "hcm/pkg/api/core/task"
 	datatask "hcm/pkg/api/data-service/task"
@@ -30,46 +29,50 @@ import (
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
-	"hcm/pkg/serviced"
 	"hcm/pkg/tools/slice"
 )
 
-// TimingHandleTaskMgmtState 定时更新任务管理数据状态
-func TimingHandleTaskMgmtState(c *client.ClientSet, sd serviced.State, interval time.Duration) {
+// RefreshRunningState 刷新所有执行中的任务管理数据状态，由task-server周期任务流定期调用
+func RefreshRunningState(kt *kit.Kit, c *client.ClientSet) error {
+	// 刷新后的数据会离开running状态，因此按id游标分页，避免按偏移量分页时遗漏数据
+	lastID := ""
 	for {
-		time.Sleep(interval)
-
-		if !sd.IsMaster() {
-			continue
-		}
-
-		kt := core.NewBackendKit()
 		listReq := &core.ListReq{
-			Filter: tools.EqualExpression("state", enumor.TaskManagementRunning),
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("state", enumor.TaskManagementRunning),
+				tools.RuleIDGreaterThan(lastID),
+			),
 			Fields: []string{"id", "state", "flow_ids"},
-			Page:   core.NewDefaultBasePage(),
+			Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "id", Order: core.Ascending},
 		}
 		list, err := c.DataService().Global.TaskManagement.List(kt, listReq)
 		if err != nil {
 			logs.Errorf("list task management failed, err: %v, req: %+v, rid: %s", err, listReq, kt.Rid)
-			continue
+			return err
 		}
 
 		for _, management := range list.Details {
-			if _, err = refreshTaskMgmtState(kt, c, management); err != nil {
+			if _, err = RefreshState(kt, c, management); err != nil {
 				logs.Errorf("refresh task management state failed, err: %v, data: %+v, rid: %s", err, management,
 					kt.Rid)
 				continue
 			}
 		}
+
+		if len(list.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		lastID = list.Details[len(list.Details)-1].ID
 	}
+
+	return nil
 }
 
-// refreshTaskMgmtState 刷新任务管理数据状态，按照下面步骤执行:
+// RefreshState 刷新任务管理数据状态，按照下面步骤执行:
 // 1. 先判断任务管理数据对应的状态，如果状态处于未完结状态（即处于running），则返回；
 // 2. 如果有处于running的数据，根据flow id查询下面的flow是否都已经执行完了，未执行完则返回；
 // 3. 如果都已经执行完了，判断任务详情里的数据结果，根据结果更新任务管理数据状态，返回结果。
-func refreshTaskMgmtState(kt *kit.Kit, c *client.ClientSet, data coretask.Management) (enumor.TaskManagementState,
+func RefreshState(kt *kit.Kit, c *client.ClientSet, data coretask.Management) (enumor.TaskManagementState,
 	error) {
 
 	if data.State != enumor.TaskManagementRunning {
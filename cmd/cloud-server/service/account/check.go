@@ -95,6 +95,8 @@ func ParseAndCheckTCloudExtension(
 				CloudSubAccountID:  extension.CloudSubAccountID,
 				CloudSecretID:      extension.CloudSecretID,
 				CloudSecretKey:     extension.CloudSecretKey,
+				CredentialMode:     extension.CredentialMode,
+				CloudRoleArn:       extension.CloudRoleArn,
 			},
 		)
 		if err != nil {
@@ -129,6 +131,9 @@ func ParseAndCheckAwsExtension(
 				CloudIamUsername: extension.CloudIamUsername,
 				CloudSecretID:    extension.CloudSecretID,
 				CloudSecretKey:   extension.CloudSecretKey,
+				CredentialMode:   extension.CredentialMode,
+				CloudRoleArn:     extension.CloudRoleArn,
+				CloudExternalID:  extension.CloudExternalID,
 			},
 		)
 		if err != nil {
@@ -237,6 +242,7 @@ func ParseAndCheckAzureExtension(
 				CloudApplicationName:  extension.CloudApplicationName,
 				CloudSubscriptionName: extension.CloudSubscriptionName,
 				CloudSubscriptionID:   extension.CloudSubscriptionID,
+				CredentialMode:        extension.CredentialMode,
 			},
 		)
 		if err != nil {
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// invalidateCredentialCache 通知所有hc-service节点清除账号凭证缓存。通知失败不影响账号的更新和删除，只记录日志，
+// hc-service的缓存会在TTL过期后自动失效。
+func (a *accountSvc) invalidateCredentialCache(kt *kit.Kit, vendor enumor.Vendor, accountID string) {
+	clients, err := a.client.HCServiceAllNodes()
+	if err != nil {
+		logs.Errorf("get all hc-service nodes failed, skip invalidating credential cache of account: %s, err: %v, "+
+			"rid: %s", accountID, err, kt.Rid)
+		return
+	}
+
+	for _, cli := range clients {
+		switch vendor {
+		case enumor.TCloud:
+			err = cli.TCloud.Account.InvalidateCredentialCache(kt, accountID)
+		case enumor.Aws:
+			err = cli.Aws.Account.InvalidateCredentialCache(kt, accountID)
+		case enumor.HuaWei:
+			err = cli.HuaWei.Account.InvalidateCredentialCache(kt, accountID)
+		case enumor.Gcp:
+			err = cli.Gcp.Account.InvalidateCredentialCache(kt, accountID)
+		case enumor.Azure:
+			err = cli.Azure.Account.InvalidateCredentialCache(kt, accountID)
+		default:
+			err = fmt.Errorf("no support vendor: %s", vendor)
+		}
+		if err != nil {
+			logs.Errorf("invalidate credential cache of account: %s failed, err: %v, rid: %s", accountID, err, kt.Rid)
+		}
+	}
+}
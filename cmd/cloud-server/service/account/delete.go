@@ -75,8 +75,10 @@ func (a *accountSvc) DeleteAccount(cts *rest.Contexts) (interface{}, error) {
 		return accountResp, err
 	}
 
-	retryNum := 3
 	vendor := resp.Details[0].Vendor
+	a.invalidateCredentialCache(cts.Kit, vendor, accountID)
+
+	retryNum := 3
 	switch vendor {
 	case enumor.Aws:
 		for retryNum > 0 {
@@ -52,6 +52,8 @@ func InitAccountService(c *capability.Capability) {
 	h.Add("ResourceList", http.MethodPost, "/accounts/resources/accounts/list", svc.ResourceList)
 	h.Add("GetAccount", http.MethodGet, "/accounts/{account_id}", svc.GetAccount)
 	h.Add("GetSyncDetail", http.MethodGet, "/accounts/sync_details/{account_id}", svc.GetSyncDetail)
+	h.Add("ListSyncRunRecord", http.MethodPost, "/accounts/{account_id}/sync_run_records/list",
+		svc.ListSyncRunRecord)
 	h.Add("UpdateAccount", http.MethodPatch, "/accounts/{account_id}", svc.UpdateAccount)
 	h.Add("SyncCloudResource", http.MethodPost, "/accounts/{account_id}/sync", svc.SyncCloudResource)
 	h.Add("DeleteAccount", http.MethodDelete, "/accounts/{account_id}", svc.DeleteAccount)
@@ -62,6 +64,10 @@ func InitAccountService(c *capability.Capability) {
 	h.Add("SyncBizCloudResourceByCond", http.MethodPost,
 		"/bizs/{bk_biz_id}/vendors/{vendor}/accounts/{account_id}/resources/{res}/sync_by_cond",
 		svc.SyncBizCloudResourceByCond)
+	h.Add("SyncCloudResourcePreview", http.MethodPost,
+		"/vendors/{vendor}/accounts/{account_id}/resources/{res}/sync_preview", svc.SyncCloudResourcePreview)
+	h.Add("SyncCloudResourceConfirm", http.MethodPost,
+		"/vendors/{vendor}/accounts/{account_id}/resources/{res}/sync_confirm", svc.SyncCloudResourceConfirm)
 
 	// 获取账号配额
 	h.Add("GetBizTCloudZoneQuota", http.MethodPost,
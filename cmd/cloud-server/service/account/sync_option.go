@@ -0,0 +1,153 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/lock"
+	"hcm/cmd/cloud-server/service/sync/option"
+	cloudaccount "hcm/pkg/api/cloud-server/account"
+	hcsync "hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+// SyncCloudResourcePreview 预览账号下指定资源的同步计划，只返回待新增、更新、删除的资源，不写入数据
+func (a *accountSvc) SyncCloudResourcePreview(cts *rest.Contexts) (any, error) {
+	accountID := cts.PathParameter("account_id").String()
+	resType := enumor.CloudResourceType(cts.PathParameter("res").String())
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+
+	// 校验用户有该账号的访问权限
+	if err := a.checkPermission(cts, meta.Find, accountID); err != nil {
+		return nil, err
+	}
+
+	req, err := a.decodeResSyncWithOptionReq(cts, vendor, accountID, resType)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := option.SyncRes(cts.Kit, a.client, vendor, accountID, resType, req.Regions,
+		hcsync.SyncDeleteOption{DryRun: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cloudaccount.ResSyncPreviewResult{Details: make([]cloudaccount.ResSyncRegionPlan, 0, len(req.Regions))}
+	for _, region := range req.Regions {
+		result.Details = append(result.Details, cloudaccount.ResSyncRegionPlan{
+			Region:         region,
+			SyncPlanResult: plans[region],
+		})
+	}
+
+	return result, nil
+}
+
+// SyncCloudResourceConfirm 确认删除后同步账号下指定资源，用于账号全量同步删除数量超过删除保护阈值被拒绝时，按资源确认删除
+func (a *accountSvc) SyncCloudResourceConfirm(cts *rest.Contexts) (any, error) {
+	accountID := cts.PathParameter("account_id").String()
+	resType := enumor.CloudResourceType(cts.PathParameter("res").String())
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+
+	// 校验用户有该账号的更新权限
+	if err := a.checkPermission(cts, meta.Update, accountID); err != nil {
+		return nil, err
+	}
+
+	req, err := a.decodeResSyncWithOptionReq(cts, vendor, accountID, resType)
+	if err != nil {
+		return nil, err
+	}
+
+	leaseID, err := lock.Manager.TryLock(lock.Key(accountID))
+	if err != nil {
+		if err == lock.ErrLockFailed {
+			return nil, errors.New("synchronization is in progress")
+		}
+		return nil, err
+	}
+	logs.Infof("lock account sync key: %s, rid: %s", lock.Key(accountID), cts.Kit.Rid)
+
+	go func(kt *kit.Kit, leaseID etcd3.LeaseID) {
+		defer func() {
+			if err := lock.Manager.UnLock(leaseID); err != nil {
+				// 锁已经超时释放了
+				if strings.Contains(err.Error(), "requested lease not found") {
+					return
+				}
+
+				logs.Errorf("[%s]: unlock account sync lock for confirm sync failed, err: %v, account: %s, "+
+					"leaseID: %d, rid: %s", constant.AccountSyncFailed, err, accountID, leaseID, kt.Rid)
+			}
+			logs.Infof("unlock account sync key: %s, rid: %s", lock.Key(accountID), kt.Rid)
+		}()
+
+		startAt := time.Now()
+		_, err := option.SyncRes(kt, a.client, vendor, accountID, resType, req.Regions, hcsync.SyncDeleteOption{})
+		if err != nil {
+			logs.Errorf("[%s] confirm delete sync failed on resource(%s), err: %v, account: %s, req: %+v, "+
+				"cost: %s, rid: %s", vendor, resType, err, accountID, req, time.Since(startAt), kt.Rid)
+			return
+		}
+		logs.Infof("[%s] confirm delete sync succeed on resource(%s), account: %s, req: %+v, cost: %s, rid: %s",
+			vendor, resType, accountID, req, time.Since(startAt), kt.Rid)
+	}(cts.Kit, leaseID)
+
+	return "started", nil
+}
+
+func (a *accountSvc) decodeResSyncWithOptionReq(cts *rest.Contexts, vendor enumor.Vendor, accountID string,
+	resType enumor.CloudResourceType) (*cloudaccount.ResSyncWithOptionReq, error) {
+
+	req := new(cloudaccount.ResSyncWithOptionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := option.ValidateRes(vendor, resType); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 查询该账号对应的Vendor
+	baseInfo, err := a.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.AccountCloudResType, accountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if baseInfo.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "account not found by vendor: %s", vendor)
+	}
+
+	return req, nil
+}
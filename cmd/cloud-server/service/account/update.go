@@ -85,20 +85,31 @@ func (a *accountSvc) UpdateAccount(cts *rest.Contexts) (interface{}, error) {
 		}
 	}
 
+	var result interface{}
 	switch baseInfo.Vendor {
 	case enumor.TCloud:
-		return a.updateForTCloud(cts, req, accountID)
+		result, err = a.updateForTCloud(cts, req, accountID)
 	case enumor.Aws:
-		return a.updateForAws(cts, req, accountID)
+		result, err = a.updateForAws(cts, req, accountID)
 	case enumor.HuaWei:
-		return a.updateForHuaWei(cts, req, accountID)
+		result, err = a.updateForHuaWei(cts, req, accountID)
 	case enumor.Gcp:
-		return a.updateForGcp(cts, req, accountID)
+		result, err = a.updateForGcp(cts, req, accountID)
 	case enumor.Azure:
-		return a.updateForAzure(cts, req, accountID)
+		result, err = a.updateForAzure(cts, req, accountID)
 	default:
 		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", baseInfo.Vendor))
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新了密钥等扩展信息时，需要清除hc-service缓存的账号凭证
+	if req.Extension != nil {
+		a.invalidateCredentialCache(cts.Kit, baseInfo.Vendor, accountID)
+	}
+
+	return result, nil
 }
 
 func (a *accountSvc) updateForTCloud(
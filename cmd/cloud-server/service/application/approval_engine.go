@@ -0,0 +1,143 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/slice"
+)
+
+// approvalSystemOperator 节点超时由系统自动处理时记录的操作人
+const approvalSystemOperator = "system"
+
+// newApprovalDetail 根据审批流程的节点配置生成单据初始的审批详情
+func newApprovalDetail(stages []dataproto.ApprovalStage, now time.Time) *dataproto.ApprovalDetail {
+	detail := &dataproto.ApprovalDetail{
+		Revision:       0,
+		CurrentStage:   0,
+		StageStartedAt: now.Format(constant.TimeStdFormat),
+		Stages:         stages,
+		Records:        make([]dataproto.ApprovalRecord, 0),
+	}
+	detail.CurrentApprovers = currentApprovers(detail)
+
+	return detail
+}
+
+// currentApprovers 返回当前节点的审批人
+func currentApprovers(detail *dataproto.ApprovalDetail) []string {
+	stage := currentStage(detail)
+	if stage == nil {
+		return make([]string, 0)
+	}
+
+	return stage.Approvers
+}
+
+// currentStage 返回当前待审批的节点，审批已结束时返回nil
+func currentStage(detail *dataproto.ApprovalDetail) *dataproto.ApprovalStage {
+	if detail.CurrentStage < 0 || detail.CurrentStage >= len(detail.Stages) {
+		return nil
+	}
+
+	return &detail.Stages[detail.CurrentStage]
+}
+
+// isStageTimeout 判断当前节点是否已超时
+func isStageTimeout(detail *dataproto.ApprovalDetail, now time.Time) (bool, error) {
+	stage := currentStage(detail)
+	if stage == nil || stage.TimeoutHour <= 0 {
+		return false, nil
+	}
+
+	startedAt, err := time.Parse(constant.TimeStdFormat, detail.StageStartedAt)
+	if err != nil {
+		return false, fmt.Errorf("parse stage started at %s failed, err: %v", detail.StageStartedAt, err)
+	}
+
+	return now.Sub(startedAt) >= time.Duration(stage.TimeoutHour)*time.Hour, nil
+}
+
+// applyApprovalAction 在审批详情上执行审批操作，返回操作后的单据状态
+// 同意后进入下个节点，最后一个节点同意则审批通过；驳回直接结束审批；转派替换当前节点审批人并重新计时；
+// 超时按节点配置的 TimeoutAction 自动同意或驳回
+func applyApprovalAction(detail *dataproto.ApprovalDetail, operator string, action enumor.ApprovalAction,
+	transferTo []string, memo string, now time.Time) (enumor.ApplicationStatus, error) {
+
+	stage := currentStage(detail)
+	if stage == nil {
+		return "", errors.New("approval has already finished")
+	}
+
+	effectAction := action
+	if action == enumor.ApprovalActionTimeout {
+		effectAction = stage.TimeoutAction
+		operator = approvalSystemOperator
+	} else if !slice.IsItemInSlice(stage.Approvers, operator) {
+		return "", fmt.Errorf("%s is not approver of current stage %s", operator, stage.Name)
+	}
+
+	record := dataproto.ApprovalRecord{
+		Stage:      detail.CurrentStage,
+		Operator:   operator,
+		Action:     action,
+		Memo:       memo,
+		OperatedAt: now.Format(constant.TimeStdFormat),
+	}
+
+	status := enumor.Pending
+	switch effectAction {
+	case enumor.ApprovalActionApprove:
+		detail.CurrentStage++
+		detail.StageStartedAt = now.Format(constant.TimeStdFormat)
+		if detail.CurrentStage >= len(detail.Stages) {
+			status = enumor.Pass
+		}
+
+	case enumor.ApprovalActionReject:
+		status = enumor.Rejected
+
+	case enumor.ApprovalActionTransfer:
+		if len(transferTo) == 0 {
+			return "", errors.New("transfer_to is required when transfer approval")
+		}
+		stage.Approvers = transferTo
+		detail.StageStartedAt = now.Format(constant.TimeStdFormat)
+		record.TransferTo = transferTo
+
+	default:
+		return "", fmt.Errorf("unsupported approval action: %s", effectAction)
+	}
+
+	detail.Records = append(detail.Records, record)
+	detail.Revision++
+	detail.CurrentApprovers = currentApprovers(detail)
+	if status == enumor.Rejected {
+		detail.CurrentApprovers = make([]string, 0)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"testing"
+	"time"
+
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestApprovalDetail(now time.Time) *dataproto.ApprovalDetail {
+	stages := []dataproto.ApprovalStage{
+		{Name: "leader", Approvers: []string{"alice"}, TimeoutHour: 1, TimeoutAction: enumor.ApprovalActionApprove},
+		{Name: "ops", Approvers: []string{"bob", "carol"}},
+	}
+	return newApprovalDetail(stages, now)
+}
+
+func Test_applyApprovalAction(t *testing.T) {
+	now := time.Now()
+
+	detail := newTestApprovalDetail(now)
+	assert.Equal(t, []string{"alice"}, detail.CurrentApprovers)
+
+	_, err := applyApprovalAction(detail, "bob", enumor.ApprovalActionApprove, nil, "", now)
+	assert.Error(t, err, "bob is not approver of first stage")
+
+	status, err := applyApprovalAction(detail, "alice", enumor.ApprovalActionApprove, nil, "ok", now)
+	assert.NoError(t, err)
+	assert.Equal(t, enumor.Pending, status)
+	assert.Equal(t, 1, detail.CurrentStage)
+	assert.Equal(t, []string{"bob", "carol"}, detail.CurrentApprovers)
+
+	status, err = applyApprovalAction(detail, "carol", enumor.ApprovalActionTransfer, []string{"dave"}, "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, enumor.Pending, status)
+	assert.Equal(t, []string{"dave"}, detail.CurrentApprovers)
+
+	status, err = applyApprovalAction(detail, "dave", enumor.ApprovalActionApprove, nil, "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, enumor.Pass, status)
+	assert.Empty(t, detail.CurrentApprovers)
+	assert.Equal(t, int64(3), detail.Revision)
+	assert.Len(t, detail.Records, 3)
+
+	_, err = applyApprovalAction(detail, "dave", enumor.ApprovalActionApprove, nil, "", now)
+	assert.Error(t, err, "approval has already finished")
+
+	detail = newTestApprovalDetail(now)
+	status, err = applyApprovalAction(detail, "alice", enumor.ApprovalActionReject, nil, "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, enumor.Rejected, status)
+	assert.Empty(t, detail.CurrentApprovers)
+}
+
+func Test_approvalTimeout(t *testing.T) {
+	now := time.Now()
+	detail := newTestApprovalDetail(now.Add(-2 * time.Hour))
+
+	timeout, err := isStageTimeout(detail, now)
+	assert.NoError(t, err)
+	assert.True(t, timeout)
+
+	status, err := applyApprovalAction(detail, "", enumor.ApprovalActionTimeout, nil, "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, enumor.Pending, status)
+	assert.Equal(t, approvalSystemOperator, detail.Records[0].Operator)
+
+	// 第二个节点未配置超时时间
+	timeout, err = isStageTimeout(detail, now.Add(100*time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, timeout)
+}
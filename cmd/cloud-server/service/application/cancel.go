@@ -22,9 +22,12 @@ package application
 import (
 	"fmt"
 
+	dataproto "hcm/pkg/api/data-service"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
 )
 
 // CancelApplication ...
@@ -43,6 +46,15 @@ func (a *applicationSvc) CancelApplication(cts *rest.Contexts) (interface{}, err
 		)
 	}
 
+	if application.Source == enumor.ApplicationSourceHCM {
+		// 内置审批引擎的单据只能撤销审批中的单据
+		if application.Status != enumor.Pending {
+			return nil, errf.Newf(errf.InvalidParameter, "application is %s, can not be cancelled",
+				application.Status)
+		}
+		return nil, a.cancelNativeApplication(cts, application)
+	}
+
 	// 根据SN调用ITSM接口撤销单据
 	err = a.itsmCli.WithdrawTicket(cts.Kit, application.SN, cts.Kit.User)
 	if err != nil {
@@ -57,3 +69,38 @@ func (a *applicationSvc) CancelApplication(cts *rest.Contexts) (interface{}, err
 
 	return nil, nil
 }
+
+// cancelNativeApplication 撤销内置审批引擎单据，基于审批详情版本号更新，避免与并发审批操作互相覆盖
+func (a *applicationSvc) cancelNativeApplication(cts *rest.Contexts, application *dataproto.ApplicationResp) error {
+	detail := new(dataproto.ApprovalDetail)
+	if err := json.UnmarshalFromString(application.ApprovalDetail, detail); err != nil {
+		return fmt.Errorf("unmarshal application approval detail failed, err: %v", err)
+	}
+
+	revision := detail.Revision
+	detail.Revision++
+	detail.CurrentApprovers = make([]string, 0)
+
+	detailStr, err := json.MarshalToString(detail)
+	if err != nil {
+		return fmt.Errorf("marshal application approval detail failed, err: %v", err)
+	}
+
+	updateReq := &dataproto.ApplicationUpdateReq{
+		Status:           enumor.Cancelled,
+		ApprovalDetail:   &detailStr,
+		ApprovalRevision: &revision,
+	}
+	if _, err = a.client.DataService().Global.Application.UpdateApplication(cts.Kit, application.ID,
+		updateReq); err != nil {
+
+		if ef := errf.Error(err); ef != nil && ef.Code == errf.RecordNotUpdate {
+			return errf.New(errf.RecordNotUpdate,
+				"application may have been approved by others, please refresh and retry")
+		}
+		logs.Errorf("cancel application failed, err: %v, id: %s, rid: %s", err, application.ID, cts.Kit.Rid)
+		return err
+	}
+
+	return nil
+}
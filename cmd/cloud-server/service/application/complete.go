@@ -54,7 +54,7 @@ func (a *applicationSvc) CompleteForCreateMainAccount(cts *rest.Contexts) (inter
 
 	// extension params check
 	switch completeReq.Vendor {
-	case enumor.HuaWei, enumor.Azure, enumor.Zenlayer, enumor.Kaopu:
+	case enumor.HuaWei, enumor.Azure, enumor.Zenlayer, enumor.Kaopu, enumor.TCloud:
 		if _, ok := completeReq.Extension[completeReq.Vendor.GetMainAccountNameFieldName()]; !ok {
 			return nil, errf.Newf(errf.InvalidParameter, "extension %s is required",
 				completeReq.Vendor.GetMainAccountNameFieldName())
@@ -21,6 +21,8 @@ package application
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"hcm/cmd/cloud-server/service/application/handlers"
 	accounthandler "hcm/cmd/cloud-server/service/application/handlers/account"
@@ -92,46 +94,36 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 		return nil, err
 	}
 
-	// 查询审批流程服务ID
+	// 主机、硬盘、VPC、负载均衡需要记录业务ID
 	applicationType := handler.GetType()
-	serviceID, managers, err := a.getApprovalProcessInfo(cts, applicationType)
-	if err != nil {
-		return nil, fmt.Errorf("get approval process service id and managers failed, err: %v", err)
-	}
-
-	// 生成ITSM的回调地址
-	callbackUrl := a.getCallbackUrl()
-
-	// 渲染ITSM单据标题
-	itsmTitle, err := handler.RenderItsmTitle()
-	if err != nil {
-		return nil, fmt.Errorf("render itsm ticket title error: %w", err)
+	var bkBizIDs = make([]int64, 0)
+	if applicationType == enumor.CreateCvm || applicationType == enumor.CreateDisk ||
+		applicationType == enumor.CreateVpc || applicationType == enumor.CreateLoadBalancer {
+		bkBizIDs = handler.GetBkBizIDs()
 	}
 
-	// 渲染ITSM单据申请内容
-	itsmForm, err := handler.RenderItsmForm()
+	// 查询审批流程
+	process, err := a.getApprovalProcess(cts.Kit, applicationType, bkBizIDs)
 	if err != nil {
-		return nil, fmt.Errorf("render itsm ticket form error: %w", err)
+		return nil, fmt.Errorf("get approval process failed, err: %v", err)
 	}
 
-	// 获取ITSM单据涉及到的各个节点审批人
-	approvers := handler.GetItsmApprover(managers)
-
-	// 调用ITSM创建单据
-	sn, err := a.itsmCli.CreateTicket(
-		cts.Kit,
-		&itsm.CreateTicketParams{
-			ServiceID:      serviceID,
-			Creator:        cts.Kit.User,
-			CallbackURL:    callbackUrl,
-			Title:          itsmTitle,
-			ContentDisplay: itsmForm,
-			// ITSM流程里使用变量引用的方式设置各个节点审批人
-			VariableApprovers: approvers,
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("call itsm create ticket api failed, err: %w", err)
+	// 内置审批引擎直接生成单据号及审批详情，否则调用ITSM创建单据
+	source := enumor.ApplicationSourceITSM
+	var sn, approvalDetail string
+	if process.Engine == enumor.ApprovalEngineNative {
+		now := time.Now()
+		source = enumor.ApplicationSourceHCM
+		sn = genNativeApplicationSN(now)
+		approvalDetail, err = json.MarshalToString(newApprovalDetail(process.Stages, now))
+		if err != nil {
+			return nil, fmt.Errorf("json marshal approval detail failed, err: %w", err)
+		}
+	} else {
+		sn, err = a.createItsmTicket(cts, handler, process)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// 调用DB创建单据
@@ -143,25 +135,19 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 		)
 	}
 
-	// 主机、硬盘、VPC、负载均衡需要记录业务ID
-	var bkBizIDs = make([]int64, 0)
-	if applicationType == enumor.CreateCvm || applicationType == enumor.CreateDisk ||
-		applicationType == enumor.CreateVpc || applicationType == enumor.CreateLoadBalancer {
-		bkBizIDs = handler.GetBkBizIDs()
-	}
-
 	result, err := a.client.DataService().Global.Application.CreateApplication(
 		cts.Kit.Ctx,
 		cts.Kit.Header(),
 		&dataproto.ApplicationCreateReq{
 			SN:             sn,
-			Source:         enumor.ApplicationSourceITSM,
+			Source:         source,
 			Type:           applicationType,
 			Status:         enumor.Pending,
 			BkBizIDs:       bkBizIDs,
 			Applicant:      cts.Kit.User,
 			Content:        content,
 			DeliveryDetail: "{}",
+			ApprovalDetail: approvalDetail,
 			Memo:           req.Remark,
 		},
 	)
@@ -172,6 +158,48 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 	return result, nil
 }
 
+// createItsmTicket 调用ITSM创建审批单据，返回单据号
+func (a *applicationSvc) createItsmTicket(cts *rest.Contexts, handler handlers.ApplicationHandler,
+	process *dataproto.ApprovalProcessResp) (string, error) {
+
+	// 生成ITSM的回调地址
+	callbackUrl := a.getCallbackUrl()
+
+	// 渲染ITSM单据标题
+	itsmTitle, err := handler.RenderItsmTitle()
+	if err != nil {
+		return "", fmt.Errorf("render itsm ticket title error: %w", err)
+	}
+
+	// 渲染ITSM单据申请内容
+	itsmForm, err := handler.RenderItsmForm()
+	if err != nil {
+		return "", fmt.Errorf("render itsm ticket form error: %w", err)
+	}
+
+	// 获取ITSM单据涉及到的各个节点审批人
+	approvers := handler.GetItsmApprover(strings.Split(process.Managers, ","))
+
+	// 调用ITSM创建单据
+	sn, err := a.itsmCli.CreateTicket(
+		cts.Kit,
+		&itsm.CreateTicketParams{
+			ServiceID:      process.ServiceID,
+			Creator:        cts.Kit.User,
+			CallbackURL:    callbackUrl,
+			Title:          itsmTitle,
+			ContentDisplay: itsmForm,
+			// ITSM流程里使用变量引用的方式设置各个节点审批人
+			VariableApprovers: approvers,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("call itsm create ticket api failed, err: %w", err)
+	}
+
+	return sn, nil
+}
+
 func parseReqFromRequestBody[T any](cts *rest.Contexts) (*T, error) {
 	req := new(T)
 	if err := cts.DecodeInto(req); err != nil {
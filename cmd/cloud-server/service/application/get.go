@@ -23,9 +23,13 @@ import (
 	"fmt"
 
 	proto "hcm/pkg/api/cloud-server/application"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/slice"
 )
 
 // GetApplication ...
@@ -38,7 +42,7 @@ func (a *applicationSvc) GetApplication(cts *rest.Contexts) (interface{}, error)
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	if application.Applicant != cts.Kit.User {
+	if application.Applicant != cts.Kit.User && !isCurrentApprover(application, cts.Kit.User) {
 		_, authorized, err := a.authorizer.Authorize(cts.Kit, meta.ResourceAttribute{Basic: &meta.Basic{
 			Type:   meta.Application,
 			Action: meta.Find,
@@ -53,22 +57,42 @@ func (a *applicationSvc) GetApplication(cts *rest.Contexts) (interface{}, error)
 		}
 	}
 
-	// 查询审批链接
-	ticket, err := a.itsmCli.GetTicketResult(cts.Kit, application.SN)
-	if err != nil {
-		return nil, fmt.Errorf("call itsm get ticket url failed, err: %v", err)
+	// 查询审批链接，内置审批引擎的单据没有审批链接，审批进度在审批详情中
+	var ticketUrl string
+	if application.Source != enumor.ApplicationSourceHCM {
+		ticket, err := a.itsmCli.GetTicketResult(cts.Kit, application.SN)
+		if err != nil {
+			return nil, fmt.Errorf("call itsm get ticket url failed, err: %v", err)
+		}
+		ticketUrl = ticket.TicketURL
 	}
 
 	return &proto.ApplicationGetResp{
 		ID:             application.ID,
+		Source:         application.Source,
 		SN:             application.SN,
 		Type:           application.Type,
 		Status:         application.Status,
 		Applicant:      application.Applicant,
 		Content:        RemoveSenseField(application.Content),
 		DeliveryDetail: application.DeliveryDetail,
+		ApprovalDetail: application.ApprovalDetail,
 		Memo:           application.Memo,
 		Revision:       application.Revision,
-		TicketUrl:      ticket.TicketURL,
+		TicketUrl:      ticketUrl,
 	}, nil
 }
+
+// isCurrentApprover 判断用户是否为内置审批引擎单据当前节点的审批人
+func isCurrentApprover(application *dataproto.ApplicationResp, user string) bool {
+	if application.Source != enumor.ApplicationSourceHCM {
+		return false
+	}
+
+	detail := new(dataproto.ApprovalDetail)
+	if err := json.UnmarshalFromString(application.ApprovalDetail, detail); err != nil {
+		return false
+	}
+
+	return slice.IsItemInSlice(detail.CurrentApprovers, user)
+}
@@ -97,6 +97,8 @@ func (a *ApplicationOfAddAccount) createForTCloud() (string, error) {
 				CloudSubAccountID:  a.req.Extension["cloud_sub_account_id"],
 				CloudSecretID:      a.req.Extension["cloud_secret_id"],
 				CloudSecretKey:     a.req.Extension["cloud_secret_key"],
+				CredentialMode:     enumor.AccountCredentialMode(a.req.Extension["credential_mode"]),
+				CloudRoleArn:       a.req.Extension["cloud_role_arn"],
 			},
 		},
 	)
@@ -122,6 +124,9 @@ func (a *ApplicationOfAddAccount) createForAws() (string, error) {
 				CloudIamUsername: a.req.Extension["cloud_iam_username"],
 				CloudSecretID:    a.req.Extension["cloud_secret_id"],
 				CloudSecretKey:   a.req.Extension["cloud_secret_key"],
+				CredentialMode:   enumor.AccountCredentialMode(a.req.Extension["credential_mode"]),
+				CloudRoleArn:     a.req.Extension["cloud_role_arn"],
+				CloudExternalID:  a.req.Extension["cloud_external_id"],
 			},
 		},
 	)
@@ -203,6 +208,7 @@ func (a *ApplicationOfAddAccount) createForAzure() (string, error) {
 				CloudApplicationID:    a.req.Extension["cloud_application_id"],
 				CloudApplicationName:  a.req.Extension["cloud_application_name"],
 				CloudClientSecretKey:  a.req.Extension["cloud_client_secret_key"],
+				CredentialMode:        enumor.AccountCredentialMode(a.req.Extension["credential_mode"]),
 			},
 		},
 	)
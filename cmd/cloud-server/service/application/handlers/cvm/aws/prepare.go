@@ -29,8 +29,14 @@ import (
 
 // PrepareReq 预处理请求参数，比如敏感数据加密
 func (a *ApplicationOfCreateAwsCvm) PrepareReq() error {
-	// 密码加密
-	encryptedPassword := a.Cipher.EncryptToBase64(a.req.Password)
+	// 密码加密，使用SSH密钥对登录时无密码
+	if len(a.req.Password) == 0 {
+		return nil
+	}
+	encryptedPassword, err := a.Cipher.EncryptToBase64(a.req.Password)
+	if err != nil {
+		return fmt.Errorf("encrypt password failed, err: %w", err)
+	}
 	a.req.Password = encryptedPassword
 	a.req.ConfirmedPassword = encryptedPassword
 
@@ -52,6 +58,9 @@ func (a *ApplicationOfCreateAwsCvm) GenerateApplicationContent() interface{} {
 // PrepareReqFromContent 预处理请求参数，对于申请内容来着DB，其实入库前是加密了的
 func (a *ApplicationOfCreateAwsCvm) PrepareReqFromContent() error {
 	// 解密密码
+	if len(a.req.Password) == 0 {
+		return nil
+	}
 	password, err := a.Cipher.DecryptFromBase64(a.req.Password)
 	if err != nil {
 		return fmt.Errorf("decrypt password failed, err: %w", err)
@@ -19,7 +19,10 @@
 
 package azure
 
-import logicsaccount "hcm/cmd/cloud-server/logics/account"
+import (
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	keypair "hcm/cmd/cloud-server/logics/key-pair"
+)
 
 // CheckReq 检查申请单的数据是否正确
 func (a *ApplicationOfCreateAzureCvm) CheckReq() error {
@@ -31,5 +34,11 @@ func (a *ApplicationOfCreateAzureCvm) CheckReq() error {
 		return err
 	}
 
+	// 密钥对需与主机在同一账号及地域下，且已分配到申请的业务
+	if err := keypair.ValidateCvmKeyPair(a.Cts.Kit, a.Client.DataService(), a.Vendor(), a.req.AccountID,
+		a.req.Region, a.req.CloudKeyPairID, a.req.BkBizID); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"errors"
 
 	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	keypair "hcm/cmd/cloud-server/logics/key-pair"
 )
 
 // CheckReq 检查申请单的数据是否正确
@@ -35,6 +36,12 @@ func (a *ApplicationOfCreateHuaWeiCvm) CheckReq() error {
 		return err
 	}
 
+	// 密钥对需与主机在同一账号及地域下，且已分配到申请的业务
+	if err := keypair.ValidateCvmKeyPair(a.Cts.Kit, a.Client.DataService(), a.Vendor(), a.req.AccountID,
+		a.req.Region, a.req.CloudKeyPairID, a.req.BkBizID); err != nil {
+		return err
+	}
+
 	// TCloud 支持 DryRun，可预校验
 	result, err := a.Client.HCService().HuaWei.Cvm.BatchCreateCvm(a.Cts.Kit, a.toHcProtoHuaWeiBatchCreateReq(true))
 	if err != nil {
@@ -137,6 +137,17 @@ func (a *ApplicationOfCreateTCloudCvm) renderBaseInfo() ([]formItem, error) {
 	}
 	formItems = append(formItems, formItem{Label: "镜像", Value: imageInfo.Name})
 
+	// 登录方式
+	loginMode := "密码"
+	if len(req.CloudKeyPairID) != 0 {
+		keyPair, err := a.GetKeyPair(a.Vendor(), req.AccountID, req.Region, req.CloudKeyPairID)
+		if err != nil {
+			return formItems, err
+		}
+		loginMode = fmt.Sprintf("SSH密钥对(%s)", keyPair.Name)
+	}
+	formItems = append(formItems, formItem{Label: "登录方式", Value: loginMode})
+
 	return formItems, nil
 }
 
@@ -26,6 +26,7 @@ import (
 
 // ApplicationHandler 定义了申请单的表单校验，与itsm对接、审批通过后的资源交付函数
 // 创建申请单：CheckReq -> PrepareReq -> CreateITSMTicket -> GenerateApplicationContent -> "SaveToDB"
+// 使用内置审批引擎时不会创建ITSM单据，GetItsmApprover、RenderItsmTitle、RenderItsmForm 不会被调用
 // 审批通过交付："LoadApplicationFromDB" -> PrepareReqForContent-> CheckReq -> Deliver -> "UpdateStatusToDB"
 // Note: 这里创建申请单的请求数据和交付资源的请求数据结构是一样的，这是一种"偷懒"行为，
 // 更好的方式是Handler拆分成两种抽象：申请单创建者Creator、申请单交付者Deliverer，然后定义各自的数据结构
@@ -49,6 +49,7 @@ func (a *ApplicationOfCreateMainAccount) CheckReq() error {
 	case enumor.Azure:
 	case enumor.Zenlayer:
 	case enumor.Kaopu:
+	case enumor.TCloud:
 	default:
 		return fmt.Errorf("vendor [%s] is not supported", a.req.Vendor)
 	}
@@ -85,6 +85,8 @@ func (a *ApplicationOfCreateMainAccount) Complete() (enumor.ApplicationStatus, m
 		accountID, err = a.createForZenlayer(&rootAccount.BaseRootAccount)
 	case enumor.Kaopu:
 		accountID, err = a.createForKaopu(&rootAccount.BaseRootAccount)
+	case enumor.TCloud:
+		accountID, err = a.createForTCloud(&rootAccount.BaseRootAccount)
 	}
 	if err != nil {
 		logs.Errorf("create main account for [%s] failed, err: %v, rid: %s", a.req.Vendor, err, a.Cts.Kit.Rid)
@@ -137,7 +139,9 @@ func (a *ApplicationOfCreateMainAccount) createForAws(rootAccount *protocore.Bas
 		CloudMainAccountName: accountResp.AccountName,
 		CloudMainAccountID:   accountResp.AccountID,
 	}
-	extension.EncryptSecretKey(a.Cipher)
+	if err := extension.EncryptSecretKey(a.Cipher); err != nil {
+		return "", fmt.Errorf("encrypt main account secret key failed, err: %v, rid: %s", err, a.Cts.Kit.Rid)
+	}
 
 	result, err := a.Client.DataService().Aws.MainAccount.Create(
 		a.Cts.Kit,
@@ -199,7 +203,9 @@ func (a *ApplicationOfCreateMainAccount) createForGcp(rootAccount *protocore.Bas
 		CloudProjectID:   accountResp.ProjectID,
 		CloudProjectName: accountResp.ProjectName,
 	}
-	extension.EncryptSecretKey(a.Cipher)
+	if err := extension.EncryptSecretKey(a.Cipher); err != nil {
+		return "", fmt.Errorf("encrypt main account secret key failed, err: %v, rid: %s", err, a.Cts.Kit.Rid)
+	}
 
 	result, err := a.Client.DataService().Gcp.MainAccount.Create(
 		a.Cts.Kit,
@@ -238,7 +244,9 @@ func (a *ApplicationOfCreateMainAccount) createForAzure(rootAccount *protocore.B
 		CloudSubscriptionName: comReq.Extension[a.Vendor().GetMainAccountNameFieldName()],
 		CloudInitPassword:     comReq.Extension[a.Vendor().GetMainAccountInitPasswordFieldName()],
 	}
-	extension.EncryptSecretKey(a.Cipher)
+	if err := extension.EncryptSecretKey(a.Cipher); err != nil {
+		return "", fmt.Errorf("encrypt main account secret key failed, err: %v, rid: %s", err, a.Cts.Kit.Rid)
+	}
 
 	result, err := a.Client.DataService().Azure.MainAccount.Create(
 		a.Cts.Kit,
@@ -276,7 +284,9 @@ func (a *ApplicationOfCreateMainAccount) createForHuaWei(rootAccount *protocore.
 		CloudMainAccountName: comReq.Extension[a.Vendor().GetMainAccountNameFieldName()],
 		CloudInitPassword:    comReq.Extension[a.Vendor().GetMainAccountInitPasswordFieldName()],
 	}
-	extension.EncryptSecretKey(a.Cipher)
+	if err := extension.EncryptSecretKey(a.Cipher); err != nil {
+		return "", fmt.Errorf("encrypt main account secret key failed, err: %v, rid: %s", err, a.Cts.Kit.Rid)
+	}
 
 	result, err := a.Client.DataService().HuaWei.MainAccount.Create(
 		a.Cts.Kit,
@@ -314,7 +324,9 @@ func (a *ApplicationOfCreateMainAccount) createForZenlayer(rootAccount *protocor
 		CloudMainAccountName: comReq.Extension[a.Vendor().GetMainAccountNameFieldName()],
 		CloudInitPassword:    comReq.Extension[a.Vendor().GetMainAccountInitPasswordFieldName()],
 	}
-	extension.EncryptSecretKey(a.Cipher)
+	if err := extension.EncryptSecretKey(a.Cipher); err != nil {
+		return "", fmt.Errorf("encrypt main account secret key failed, err: %v, rid: %s", err, a.Cts.Kit.Rid)
+	}
 
 	result, err := a.Client.DataService().Zenlayer.MainAccount.Create(
 		a.Cts.Kit,
@@ -352,7 +364,9 @@ func (a *ApplicationOfCreateMainAccount) createForKaopu(rootAccount *protocore.B
 		CloudMainAccountName: comReq.Extension[a.Vendor().GetMainAccountNameFieldName()],
 		CloudInitPassword:    comReq.Extension[a.Vendor().GetMainAccountInitPasswordFieldName()],
 	}
-	extension.EncryptSecretKey(a.Cipher)
+	if err := extension.EncryptSecretKey(a.Cipher); err != nil {
+		return "", fmt.Errorf("encrypt main account secret key failed, err: %v, rid: %s", err, a.Cts.Kit.Rid)
+	}
 
 	result, err := a.Client.DataService().Kaopu.MainAccount.Create(
 		a.Cts.Kit,
@@ -381,6 +395,46 @@ func (a *ApplicationOfCreateMainAccount) createForKaopu(rootAccount *protocore.B
 	return result.ID, nil
 }
 
+func (a *ApplicationOfCreateMainAccount) createForTCloud(rootAccount *protocore.BaseRootAccount) (string, error) {
+	req := a.req
+	comReq := a.completeReq
+
+	extension := &dataproto.TCloudMainAccountExtensionCreateReq{
+		CloudMainAccountID:   comReq.Extension[a.Vendor().GetMainAccountIDFieldName()],
+		CloudMainAccountName: comReq.Extension[a.Vendor().GetMainAccountNameFieldName()],
+		CloudInitPassword:    comReq.Extension[a.Vendor().GetMainAccountInitPasswordFieldName()],
+	}
+	if err := extension.EncryptSecretKey(a.Cipher); err != nil {
+		return "", fmt.Errorf("encrypt main account secret key failed, err: %v, rid: %s", err, a.Cts.Kit.Rid)
+	}
+
+	result, err := a.Client.DataService().TCloud.MainAccount.Create(
+		a.Cts.Kit,
+		&dataproto.MainAccountCreateReq[dataproto.TCloudMainAccountExtensionCreateReq]{
+			Name:              a.completeReq.Extension[a.Vendor().GetMainAccountNameFieldName()],
+			CloudID:           a.completeReq.Extension[a.Vendor().GetMainAccountIDFieldName()],
+			Email:             req.Email,
+			Managers:          req.Managers,
+			BakManagers:       req.BakManagers,
+			Site:              req.Site,
+			BusinessType:      req.BusinessType,
+			Status:            enumor.MainAccountStatusRUNNING,
+			ParentAccountName: rootAccount.Name,
+			ParentAccountID:   rootAccount.ID,
+			DeptID:            req.DeptID,
+			BkBizID:           req.BkBizID,
+			OpProductID:       req.OpProductID,
+			Memo:              req.Memo,
+			Extension:         extension,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return result.ID, nil
+}
+
 func (a *ApplicationOfCreateMainAccount) sendMail(account *dataproto.MainAccountGetBaseResult) {
 	if account == nil {
 		logs.Errorf("send mail failed, account should not be nil when send email, rid: %s", a.Cts.Kit.Rid)
@@ -404,6 +458,8 @@ func (a *ApplicationOfCreateMainAccount) sendMail(account *dataproto.MainAccount
 		loginUrl = ZenlayerLoginAddress
 	case enumor.Kaopu:
 		loginUrl = KaopuLoginAddress
+	case enumor.TCloud:
+		loginUrl = TCloudLoginAddress
 	default:
 		logs.Errorf("send mail failed, unknown vendor: %s, rid: %s", account.Vendor, a.Cts.Kit.Rid)
 		return
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tidwall/gjson"
 
@@ -62,6 +63,8 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 	h.Add("GetApplication", "GET", "/applications/{application_id}", svc.GetApplication)
 	h.Add("CancelApplication", "PATCH", "/applications/{application_id}/cancel", svc.CancelApplication)
 	h.Add("ApproveApplication", "POST", "/applications/approve", svc.ApproveApplication)
+	h.Add("ApproveByNative", "POST", "/applications/{application_id}/approval_actions", svc.ApproveByNative)
+	h.Add("ListTodoApplications", "POST", "/applications/approvals/todo/list", svc.ListTodoApplications)
 
 	h.Add("CreateForAddAccount", "POST", "/applications/types/add_account", svc.CreateForAddAccount)
 	h.Add("CreateForCreateCvm", "POST", "/vendors/{vendor}/applications/types/create_cvm", svc.CreateForCreateCvm)
@@ -84,6 +87,10 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 	initApplicationServiceHooks(svc, h)
 	h.Load(c.WebService)
 	bizH.Load(c.WebService)
+
+	if c.State != nil {
+		go svc.timingHandleApprovalTimeout(c.State, time.Minute)
+	}
 }
 
 func bizService(h *rest.Handler, svc *applicationSvc) {
@@ -117,43 +124,6 @@ func (a *applicationSvc) getHandlerOption(cts *rest.Contexts) *handlers.HandlerO
 	}
 }
 
-func (a *applicationSvc) getApprovalProcessInfo(
-	cts *rest.Contexts, applicationType enumor.ApplicationType,
-) (int64, []string, error) {
-	// DB中添加4条记录，分别对应add_account、create_cvm、create_vpc、create_disk
-	// Note：目前4条记录对应一个itsm流程id，后续如果要使用其它流程可直接修改数据库适配
-	// 新增类型只需要增加对应的tye和DB记录
-	result, err := a.client.DataService().Global.ApprovalProcess.ListApprovalProcesses(
-		cts.Kit.Ctx,
-		cts.Kit.Header(),
-		&dataproto.ApprovalProcessListReq{
-			Filter: &filter.Expression{
-				Op: filter.And,
-				Rules: []filter.RuleFactory{
-					filter.AtomRule{
-						Field: "application_type",
-						Op:    filter.Equal.Factory(),
-						Value: string(applicationType),
-					},
-				},
-			},
-			Page: &core.BasePage{
-				Count: false,
-				Start: 0,
-				Limit: 1,
-			},
-		},
-	)
-	if err != nil {
-		return 0, nil, err
-	}
-	if result.Details == nil || len(result.Details) != 1 {
-		return 0, nil, fmt.Errorf("approval process of [%s] not init", applicationType)
-	}
-
-	return result.Details[0].ServiceID, strings.Split(result.Details[0].Managers, ","), nil
-}
-
 func (a *applicationSvc) updateStatusWithDetail(
 	cts *rest.Contexts, applicationID string, status enumor.ApplicationStatus, deliveryDetail string,
 ) error {
@@ -0,0 +1,248 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	proto "hcm/pkg/api/cloud-server/application"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/rand"
+)
+
+// getApprovalProcess 查询申请单的审批流程，单据只属于一个业务时优先使用该业务的流程，否则使用对所有业务生效的默认流程
+func (a *applicationSvc) getApprovalProcess(kt *kit.Kit, applicationType enumor.ApplicationType,
+	bkBizIDs []int64) (*dataproto.ApprovalProcessResp, error) {
+
+	bizIDs := []int64{dataproto.ApprovalProcessDefaultBizID}
+	if len(bkBizIDs) == 1 {
+		bizIDs = append(bizIDs, bkBizIDs[0])
+	}
+
+	listReq := &dataproto.ApprovalProcessListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("application_type", string(applicationType)),
+			tools.RuleIn("bk_biz_id", bizIDs),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := a.client.DataService().Global.ApprovalProcess.ListApprovalProcesses(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultProcess *dataproto.ApprovalProcessResp
+	for _, one := range result.Details {
+		if one.BkBizID != dataproto.ApprovalProcessDefaultBizID {
+			return one, nil
+		}
+		defaultProcess = one
+	}
+
+	if defaultProcess == nil {
+		return nil, fmt.Errorf("approval process of [%s] not init", applicationType)
+	}
+
+	return defaultProcess, nil
+}
+
+// genNativeApplicationSN 生成内置审批引擎的单据号
+func genNativeApplicationSN(now time.Time) string {
+	return "HCM" + now.Format("20060102150405") + strings.ToUpper(rand.String(6))
+}
+
+// ApproveByNative 内置审批引擎的审批操作，包括同意、驳回及转派
+func (a *applicationSvc) ApproveByNative(cts *rest.Contexts) (interface{}, error) {
+	applicationID := cts.PathParameter("application_id").String()
+
+	req := new(proto.ApprovalActionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	application, err := a.client.DataService().Global.Application.GetApplication(
+		cts.Kit.Ctx, cts.Kit.Header(), applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Source != enumor.ApplicationSourceHCM {
+		return nil, errf.Newf(errf.InvalidParameter, "application %s is not approved by hcm", applicationID)
+	}
+
+	if application.Status != enumor.Pending {
+		return nil, errf.Newf(errf.InvalidParameter, "application %s is %s, can not be approved", applicationID,
+			application.Status)
+	}
+
+	return nil, a.handleApprovalAction(cts, application, cts.Kit.User, req.Action, req.TransferTo, req.Memo)
+}
+
+// handleApprovalAction 执行审批操作并更新单据，审批通过后进行资源交付
+func (a *applicationSvc) handleApprovalAction(cts *rest.Contexts, application *dataproto.ApplicationResp,
+	operator string, action enumor.ApprovalAction, transferTo []string, memo string) error {
+
+	detail := new(dataproto.ApprovalDetail)
+	if err := json.UnmarshalFromString(application.ApprovalDetail, detail); err != nil {
+		return fmt.Errorf("unmarshal application approval detail failed, err: %v", err)
+	}
+
+	revision := detail.Revision
+	status, err := applyApprovalAction(detail, operator, action, transferTo, memo, time.Now())
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	detailStr, err := json.MarshalToString(detail)
+	if err != nil {
+		return fmt.Errorf("marshal application approval detail failed, err: %v", err)
+	}
+
+	// 对于审批通过，则下个状态为交付中，其他状态则保持原样
+	nextStatus := status
+	if status == enumor.Pass {
+		nextStatus = enumor.Delivering
+	}
+
+	updateReq := &dataproto.ApplicationUpdateReq{
+		Status:           nextStatus,
+		ApprovalDetail:   &detailStr,
+		ApprovalRevision: &revision,
+	}
+	if _, err = a.client.DataService().Global.Application.UpdateApplication(cts.Kit, application.ID,
+		updateReq); err != nil {
+
+		if ef := errf.Error(err); ef != nil && ef.Code == errf.RecordNotUpdate {
+			return errf.New(errf.RecordNotUpdate,
+				"application may have been approved by others, please refresh and retry")
+		}
+		logs.Errorf("update application approval detail failed, err: %v, id: %s, action: %s, rid: %s", err,
+			application.ID, action, cts.Kit.Rid)
+		return err
+	}
+
+	// 只有审批详情更新成功的请求才能触发交付，避免并发审批导致重复交付
+	if status == enumor.Pass {
+		go a.deliver(cts, application)
+	}
+
+	return nil
+}
+
+// ListTodoApplications 查询待当前用户审批的内置审批引擎单据
+func (a *applicationSvc) ListTodoApplications(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ApplicationListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req.Filter.Rules = append(req.Filter.Rules,
+		tools.RuleEqual("source", enumor.ApplicationSourceHCM),
+		tools.RuleEqual("status", enumor.Pending),
+		tools.RuleJSONContains("approval_detail.current_approvers", cts.Kit.User),
+	)
+
+	return a.listApplications(cts, req)
+}
+
+// timingHandleApprovalTimeout 定时处理内置审批引擎中已超时的审批节点
+func (a *applicationSvc) timingHandleApprovalTimeout(state serviced.State, interval time.Duration) {
+	for {
+		time.Sleep(interval)
+
+		if !state.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		if err := a.handleApprovalTimeout(kt); err != nil {
+			logs.Errorf("handle approval timeout failed, err: %v, rid: %s", err, kt.Rid)
+		}
+	}
+}
+
+func (a *applicationSvc) handleApprovalTimeout(kt *kit.Kit) error {
+	// 超时处理后的单据会离开待审批状态，因此按id游标分页，避免按偏移量分页时遗漏单据
+	lastID := ""
+	for {
+		listReq := &dataproto.ApplicationListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("source", enumor.ApplicationSourceHCM),
+				tools.RuleEqual("status", enumor.Pending),
+				tools.RuleIDGreaterThan(lastID),
+			),
+			Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "id", Order: core.Ascending},
+		}
+		result, err := a.client.DataService().Global.Application.ListApplication(kt, listReq)
+		if err != nil {
+			logs.Errorf("list pending application failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		for _, one := range result.Details {
+			detail := new(dataproto.ApprovalDetail)
+			if err = json.UnmarshalFromString(one.ApprovalDetail, detail); err != nil {
+				logs.Errorf("unmarshal application(%s) approval detail failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+				continue
+			}
+
+			timeout, err := isStageTimeout(detail, time.Now())
+			if err != nil {
+				logs.Errorf("check application(%s) approval timeout failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+				continue
+			}
+			if !timeout {
+				continue
+			}
+
+			// 交付会修改执行人，每个单据使用单独的kit
+			cts := &rest.Contexts{Kit: kt.NewSubKit()}
+			err = a.handleApprovalAction(cts, one, approvalSystemOperator, enumor.ApprovalActionTimeout, nil, "")
+			if err != nil {
+				logs.Errorf("handle application(%s) approval timeout failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		lastID = result.Details[len(result.Details)-1].ID
+	}
+
+	return nil
+}
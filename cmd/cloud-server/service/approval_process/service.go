@@ -26,7 +26,9 @@ import (
 	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/filter"
@@ -43,6 +45,9 @@ func InitService(c *capability.Capability) {
 
 	h.Add("GetApprovalProcessServiceID", http.MethodGet, "/approval_processes/service_id",
 		svc.GetApprovalProcessServiceID)
+	h.Add("CreateApprovalProcess", http.MethodPost, "/approval_processes/create", svc.CreateApprovalProcess)
+	h.Add("UpdateApprovalProcess", http.MethodPatch, "/approval_processes/{id}", svc.UpdateApprovalProcess)
+	h.Add("ListApprovalProcess", http.MethodPost, "/approval_processes/list", svc.ListApprovalProcess)
 
 	h.Load(c.WebService)
 }
@@ -81,3 +86,72 @@ func (svc *service) GetApprovalProcessServiceID(cts *rest.Contexts) (interface{}
 
 	return serviceIds, nil
 }
+
+// CreateApprovalProcess 按业务及申请类型创建审批流程
+func (svc *service) CreateApprovalProcess(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataproto.ApprovalProcessCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeApplicationManage(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.ApprovalProcess.CreateApprovalProcesses(cts.Kit.Ctx, cts.Kit.Header(),
+		req)
+}
+
+// UpdateApprovalProcess 更新审批流程
+func (svc *service) UpdateApprovalProcess(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dataproto.ApprovalProcessUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeApplicationManage(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.ApprovalProcess.UpdateApprovalProcesses(cts.Kit.Ctx, cts.Kit.Header(),
+		id, req)
+}
+
+// ListApprovalProcess 查询审批流程
+func (svc *service) ListApprovalProcess(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeApplicationManage(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &dataproto.ApprovalProcessListReq{Filter: req.Filter, Page: req.Page}
+	return svc.client.DataService().Global.ApprovalProcess.ListApprovalProcesses(cts.Kit.Ctx, cts.Kit.Header(),
+		listReq)
+}
+
+// authorizeApplicationManage 审批流程的配置需要单据管理权限
+func (svc *service) authorizeApplicationManage(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Application, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
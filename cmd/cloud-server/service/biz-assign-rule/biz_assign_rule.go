@@ -0,0 +1,256 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bizassign resource auto assign to biz rule service
+package bizassign
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	proto "hcm/pkg/api/cloud-server/assign"
+	"hcm/pkg/api/core"
+	dsbizassign "hcm/pkg/api/data-service/cloud/biz-assign-rule"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// InitService initialize the biz assign rule service.
+func InitService(c *capability.Capability) {
+	s := &svc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateBizAssignRule", http.MethodPost, "/biz_assign_rules/create", s.CreateBizAssignRule)
+	h.Add("ListBizAssignRule", http.MethodPost, "/biz_assign_rules/list", s.ListBizAssignRule)
+	h.Add("UpdateBizAssignRule", http.MethodPatch, "/biz_assign_rules/{id}", s.UpdateBizAssignRule)
+	h.Add("BatchDeleteBizAssignRule", http.MethodDelete, "/biz_assign_rules/batch", s.BatchDeleteBizAssignRule)
+	h.Add("PreviewBizAssignRule", http.MethodPost, "/biz_assign_rules/preview", s.PreviewBizAssignRule)
+	h.Add("PreviewBizAssignRuleByID", http.MethodPost, "/biz_assign_rules/{id}/preview", s.PreviewBizAssignRuleByID)
+	h.Add("ApplyBizAssignRule", http.MethodPost, "/biz_assign_rules/apply", s.ApplyBizAssignRule)
+
+	h.Load(c.WebService)
+}
+
+type svc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+func (svc *svc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.BizAssignRule, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// CreateBizAssignRule create biz assign rule.
+func (svc *svc) CreateBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BizAssignRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &dsbizassign.BatchCreateReq{
+		Rules: []dsbizassign.RuleCreate{{
+			Name:     req.Name,
+			ResType:  req.ResType,
+			Rule:     req.Rule,
+			BkBizID:  req.BkBizID,
+			Priority: req.Priority,
+			Enabled:  req.Enabled == nil || *req.Enabled,
+			Memo:     req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.BizAssignRule.BatchCreate(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create biz assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, fmt.Errorf("create biz assign rule but return ids: %v", result.IDs)
+	}
+
+	return core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// ListBizAssignRule list biz assign rule.
+func (svc *svc) ListBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.BizAssignRule.List(cts.Kit, req)
+}
+
+// UpdateBizAssignRule update biz assign rule.
+func (svc *svc) UpdateBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(proto.BizAssignRuleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dsbizassign.BatchUpdateReq{
+		Rules: []dsbizassign.RuleUpdate{{
+			ID:       id,
+			Name:     req.Name,
+			Rule:     req.Rule,
+			BkBizID:  req.BkBizID,
+			Priority: req.Priority,
+			Enabled:  req.Enabled,
+			Memo:     req.Memo,
+		}},
+	}
+	if err := svc.client.DataService().Global.BizAssignRule.BatchUpdate(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update biz assign rule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteBizAssignRule batch delete biz assign rule, resources already assigned by the rules are not affected.
+func (svc *svc) BatchDeleteBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	deleteReq := &dsbizassign.BatchDeleteReq{BatchDeleteReq: *req}
+	if err := deleteReq.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.BizAssignRule.BatchDelete(cts.Kit, deleteReq); err != nil {
+		logs.Errorf("delete biz assign rule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// PreviewBizAssignRule preview unassigned resources matched by a rule which is not saved yet.
+func (svc *svc) PreviewBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BizAssignRulePreviewReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	previewReq := &dsbizassign.PreviewReq{
+		ResType:   req.ResType,
+		Rule:      req.Rule,
+		BkBizID:   req.BkBizID,
+		AccountID: req.AccountID,
+	}
+	return svc.client.DataService().Global.BizAssignRule.Preview(cts.Kit, previewReq)
+}
+
+// PreviewBizAssignRuleByID preview unassigned resources matched by an existing rule.
+func (svc *svc) PreviewBizAssignRuleByID(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(proto.BizAssignRuleAccountReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	previewReq := &dsbizassign.PreviewReq{RuleID: id, AccountID: req.AccountID}
+	return svc.client.DataService().Global.BizAssignRule.Preview(cts.Kit, previewReq)
+}
+
+// ApplyBizAssignRule apply biz assign rules immediately, assign matched unassigned resources to rule's biz.
+func (svc *svc) ApplyBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BizAssignRuleApplyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Apply); err != nil {
+		return nil, err
+	}
+
+	applyReq := &dsbizassign.ApplyReq{AccountID: req.AccountID, RuleIDs: req.RuleIDs}
+	result, err := svc.client.DataService().Global.BizAssignRule.Apply(cts.Kit, applyReq)
+	if err != nil {
+		logs.Errorf("apply biz assign rule failed, err: %v, req: %+v, rid: %s", err, converter.PtrToVal(req),
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/client"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/iam/auth"
+	"hcm/pkg/serviced"
 	"hcm/pkg/thirdparty/api-gateway/bkbase"
 	"hcm/pkg/thirdparty/api-gateway/cmsi"
 	"hcm/pkg/thirdparty/api-gateway/itsm"
@@ -46,4 +47,5 @@ type Capability struct {
 	ItsmCli    itsm.Client
 	BKBaseCli  bkbase.Client
 	CmsiCli    cmsi.Client
+	State      serviced.State
 }
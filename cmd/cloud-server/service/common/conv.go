@@ -65,6 +65,7 @@ func ConvTCloudCvmCreateReq(req *cscvm.TCloudCvmCreateReq) *hcproto.TCloudBatchC
 		InstanceType:          req.InstanceType,
 		CloudImageID:          req.CloudImageID,
 		Password:              req.Password,
+		CloudKeyPairID:        req.CloudKeyPairID,
 		RequiredCount:         req.RequiredCount,
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		CloudVpcID:            req.CloudVpcID,
@@ -124,6 +125,7 @@ func ConvAwsCvmCreateReq(req *cscvm.AwsCvmCreateReq) *hcproto.AwsBatchCreateReq
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		BlockDeviceMapping:    blockDeviceMapping,
 		Password:              req.Password,
+		CloudKeyPairID:        req.CloudKeyPairID,
 		RequiredCount:         req.RequiredCount,
 	}
 
@@ -205,6 +207,7 @@ func ConvAzureCvmCreateReq(req *cscvm.AzureCvmCreateReq) *hcproto.AzureCreateReq
 		CloudImageID:         req.CloudImageID,
 		Username:             req.Username,
 		Password:             req.Password,
+		CloudKeyPairID:       req.CloudKeyPairID,
 		CloudSubnetID:        req.CloudSubnetID,
 		CloudSecurityGroupID: req.CloudSecurityGroupIDs[0],
 		OSDisk: &typecvm.AzureOSDisk{
@@ -247,6 +250,7 @@ func ConvHuaWeiCvmCreateReq(req *cscvm.HuaWeiCvmCreateReq) *hcproto.HuaWeiBatchC
 		InstanceType:          req.InstanceType,
 		CloudImageID:          req.CloudImageID,
 		Password:              req.Password,
+		CloudKeyPairID:        req.CloudKeyPairID,
 		RequiredCount:         int32(req.RequiredCount),
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		CloudVpcID:            req.CloudVpcID,
@@ -0,0 +1,238 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"hcm/cmd/cloud-server/logics/audit"
+	logicsrecycle "hcm/cmd/cloud-server/logics/recycle"
+	csrecycle "hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
+	corerr "hcm/pkg/api/core/recycle-record"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/api/data-service/cloud"
+	dsrr "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ResRecycler 资源回收、恢复及销毁已回收资源的通用流程，各类资源只需提供资源类型及差异化的校验、销毁逻辑
+type ResRecycler struct {
+	Client     *client.ClientSet
+	Authorizer auth.Authorizer
+	Audit      audit.Interface
+
+	CloudResType enumor.CloudResourceType
+	AuditResType enumor.AuditResourceType
+	IamResType   meta.ResourceType
+	// BasicInfoFields 查询资源基本信息的字段，需要包含回收状态
+	BasicInfoFields []string
+
+	// ValidateRecycle 回收前的资源校验，如资源是否仍被绑定，为空则不校验
+	ValidateRecycle func(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) error
+	// DeleteRecycled 销毁已回收的资源
+	DeleteRecycled func(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+		*core.BatchOperateResult, error)
+}
+
+// RecycleInfo 待回收资源的ID及回收参数
+type RecycleInfo struct {
+	ID      string
+	Options interface{}
+}
+
+// Recycle 回收资源，鉴权及校验通过后记录审计并创建回收记录
+func (r *ResRecycler) Recycle(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	infos []RecycleInfo) (interface{}, error) {
+
+	ids := make([]string, 0, len(infos))
+	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(infos))
+	for _, info := range infos {
+		ids = append(ids, info.ID)
+		auditInfos = append(auditInfos, protoaudit.CloudResRecycleAuditInfo{ResID: info.ID, Data: info.Options})
+	}
+
+	basicInfoMap, err := r.listBasicInfo(cts.Kit, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: r.Authorizer, ResType: r.IamResType,
+		Action: meta.Recycle, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	if r.ValidateRecycle != nil {
+		if err = r.ValidateRecycle(cts.Kit, basicInfoMap); err != nil {
+			return nil, err
+		}
+	}
+
+	// create recycle audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: r.AuditResType,
+		Action:  protoaudit.Recycle,
+		Infos:   auditInfos,
+	}
+	if err = r.Audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
+		logs.Errorf("create %s recycle audit failed, err: %v, rid: %s", r.CloudResType, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// create recycle record
+	opt := &dsrr.BatchRecycleReq{
+		ResType:            r.CloudResType,
+		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+		Infos:              make([]dsrr.RecycleReq, 0, len(infos)),
+	}
+	for _, info := range infos {
+		opt.Infos = append(opt.Infos, dsrr.RecycleReq{ID: info.ID, Detail: info.Options})
+	}
+
+	taskID, err := r.Client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csrecycle.RecycleResult{TaskID: taskID}, nil
+}
+
+// Recover 恢复处于等待回收状态的资源
+func (r *ResRecycler) Recover(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	recordIDs []string) (interface{}, error) {
+
+	records, err := logicsrecycle.ListWaitingRecords(cts.Kit, r.Client.DataService(), r.CloudResType, recordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(records.Details))
+	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(records.Details))
+	for _, record := range records.Details {
+		ids = append(ids, record.ResID)
+		auditInfos = append(auditInfos, protoaudit.CloudResRecycleAuditInfo{ResID: record.ResID, Data: record.Detail})
+	}
+
+	basicInfoMap, err := r.listBasicInfo(cts.Kit, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: r.Authorizer, ResType: r.IamResType,
+		Action: meta.Recover, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create recover audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: r.AuditResType,
+		Action:  protoaudit.Recover,
+		Infos:   auditInfos,
+	}
+	if err = r.Audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
+		logs.Errorf("create %s recover audit failed, err: %v, rid: %s", r.CloudResType, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt := &dsrr.BatchRecoverReq{
+		ResType:   r.CloudResType,
+		RecordIDs: recordIDs,
+	}
+	if err = r.Client.DataService().Global.RecycleRecord.BatchRecoverCloudResource(cts.Kit, opt); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteRecycled 逐条销毁已回收的资源，遇到失败即停止，已销毁成功的回收记录会被标记为成功
+func (r *ResRecycler) BatchDeleteRecycled(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	recordIDs []string) (interface{}, error) {
+
+	records, err := logicsrecycle.ListWaitingRecords(cts.Kit, r.Client.DataService(), r.CloudResType, recordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	opRet := new(core.BatchOperateResult)
+	var recycleErr error
+	for _, record := range records.Details {
+		recycleErr = r.destroyOneRecord(cts, validHandler, record)
+		if recycleErr != nil {
+			logs.Errorf("fail to destroy %s recycle record(%s), err: %v, rid: %s", r.CloudResType, record.ID,
+				recycleErr, cts.Kit.Rid)
+
+			opRet.Failed = &core.FailedInfo{ID: record.ID, Error: recycleErr}
+			if ef := errf.Error(recycleErr); ef != nil && ef.Code == errf.RecordNotFound {
+				logicsrecycle.MarkRecordFailed(cts.Kit, r.Client.DataService(), recycleErr, []string{record.ID})
+			}
+			break
+		}
+		opRet.Succeeded = append(opRet.Succeeded, record.ID)
+	}
+
+	if len(opRet.Succeeded) > 0 {
+		logicsrecycle.MarkRecordSuccess(cts.Kit, r.Client.DataService(), opRet.Succeeded)
+	}
+	return opRet, recycleErr
+}
+
+func (r *ResRecycler) destroyOneRecord(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	record corerr.RecycleRecord) error {
+
+	basicInfoMap, err := r.listBasicInfo(cts.Kit, []string{record.ResID})
+	if err != nil {
+		return err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: r.Authorizer, ResType: r.IamResType,
+		Action: meta.Destroy, BasicInfos: basicInfoMap})
+	if err != nil {
+		return err
+	}
+
+	if _, err = r.DeleteRecycled(cts.Kit, basicInfoMap); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ResRecycler) listBasicInfo(kt *kit.Kit, ids []string) (map[string]types.CloudResourceBasicInfo, error) {
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: r.CloudResType,
+		IDs:          ids,
+		Fields:       r.BasicInfoFields,
+	}
+	return r.Client.DataService().Global.Cloud.ListResBasicInfo(kt, basicInfoReq)
+}
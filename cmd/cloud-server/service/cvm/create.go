@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"hcm/cmd/cloud-server/logics/async"
+	keypair "hcm/cmd/cloud-server/logics/key-pair"
 	"hcm/cmd/cloud-server/service/common"
 	actioncvm "hcm/cmd/task-server/logics/action/cvm"
 	cloudserver "hcm/pkg/api/cloud-server"
@@ -34,6 +35,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 )
@@ -64,15 +66,15 @@ func (svc *cvmSvc) CreateCvm(cts *rest.Contexts) (interface{}, error) {
 	tasks := make([]ts.CustomFlowTask, 0)
 	switch info.Vendor {
 	case enumor.TCloud:
-		tasks, err = svc.buildCreateTCloudCvmTasks(req.Data)
+		tasks, err = svc.buildCreateTCloudCvmTasks(cts.Kit, req.Data)
 	case enumor.Aws:
-		tasks, err = svc.buildCreateAwsCvmTasks(req.Data)
+		tasks, err = svc.buildCreateAwsCvmTasks(cts.Kit, req.Data)
 	case enumor.HuaWei:
-		tasks, err = svc.buildCreateHuaWeiCvmTasks(req.Data)
+		tasks, err = svc.buildCreateHuaWeiCvmTasks(cts.Kit, req.Data)
 	case enumor.Gcp:
 		tasks, err = svc.buildCreateGcpCvmTasks(req.Data)
 	case enumor.Azure:
-		tasks, err = svc.buildCreateAzureCvmTasks(req.Data)
+		tasks, err = svc.buildCreateAzureCvmTasks(cts.Kit, req.Data)
 	default:
 		return nil, fmt.Errorf("vendor: %s not support", info.Vendor)
 	}
@@ -94,7 +96,7 @@ func (svc *cvmSvc) CreateCvm(cts *rest.Contexts) (interface{}, error) {
 	return result, async.WaitTaskToEnd(cts.Kit, svc.client.TaskServer(), result.ID)
 }
 
-func (svc *cvmSvc) buildCreateAzureCvmTasks(body json.RawMessage) ([]ts.CustomFlowTask, error) {
+func (svc *cvmSvc) buildCreateAzureCvmTasks(kt *kit.Kit, body json.RawMessage) ([]ts.CustomFlowTask, error) {
 
 	req := new(cscvm.AzureCvmCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
@@ -105,6 +107,12 @@ func (svc *cvmSvc) buildCreateAzureCvmTasks(body json.RawMessage) ([]ts.CustomFl
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 资源下创建的主机未分配业务，密钥对需与主机在同一账号及地域下且未分配业务
+	if err := keypair.ValidateCvmKeyPair(kt, svc.client.DataService(), enumor.Azure, req.AccountID, req.Region,
+		req.CloudKeyPairID, constant.UnassignedBiz); err != nil {
+		return nil, err
+	}
+
 	tasks := actioncvm.BuildCreateCvmTasks(req.RequiredCount, constant.UnassignedBiz, 1,
 		func(actionID action.ActIDType, count int64) ts.CustomFlowTask {
 			req.RequiredCount = count
@@ -121,7 +129,7 @@ func (svc *cvmSvc) buildCreateAzureCvmTasks(body json.RawMessage) ([]ts.CustomFl
 	return tasks, nil
 }
 
-func (svc *cvmSvc) buildCreateHuaWeiCvmTasks(body json.RawMessage) ([]ts.CustomFlowTask, error) {
+func (svc *cvmSvc) buildCreateHuaWeiCvmTasks(kt *kit.Kit, body json.RawMessage) ([]ts.CustomFlowTask, error) {
 
 	req := new(cscvm.HuaWeiCvmCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
@@ -132,6 +140,12 @@ func (svc *cvmSvc) buildCreateHuaWeiCvmTasks(body json.RawMessage) ([]ts.CustomF
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 资源下创建的主机未分配业务，密钥对需与主机在同一账号及地域下且未分配业务
+	if err := keypair.ValidateCvmKeyPair(kt, svc.client.DataService(), enumor.HuaWei, req.AccountID, req.Region,
+		req.CloudKeyPairID, constant.UnassignedBiz); err != nil {
+		return nil, err
+	}
+
 	tasks := actioncvm.BuildCreateCvmTasks(req.RequiredCount, constant.UnassignedBiz,
 		constant.BatchCreateCvmFromCloudMaxLimit,
 		func(actionID action.ActIDType, count int64) ts.CustomFlowTask {
@@ -177,7 +191,7 @@ func (svc *cvmSvc) buildCreateGcpCvmTasks(body json.RawMessage) ([]ts.CustomFlow
 	return tasks, nil
 }
 
-func (svc *cvmSvc) buildCreateAwsCvmTasks(body json.RawMessage) ([]ts.CustomFlowTask, error) {
+func (svc *cvmSvc) buildCreateAwsCvmTasks(kt *kit.Kit, body json.RawMessage) ([]ts.CustomFlowTask, error) {
 
 	req := new(cscvm.AwsCvmCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
@@ -188,6 +202,12 @@ func (svc *cvmSvc) buildCreateAwsCvmTasks(body json.RawMessage) ([]ts.CustomFlow
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 资源下创建的主机未分配业务，密钥对需与主机在同一账号及地域下且未分配业务
+	if err := keypair.ValidateCvmKeyPair(kt, svc.client.DataService(), enumor.Aws, req.AccountID, req.Region,
+		req.CloudKeyPairID, constant.UnassignedBiz); err != nil {
+		return nil, err
+	}
+
 	tasks := actioncvm.BuildCreateCvmTasks(req.RequiredCount, constant.UnassignedBiz,
 		constant.BatchCreateCvmFromCloudMaxLimit,
 		func(actionID action.ActIDType, count int64) ts.CustomFlowTask {
@@ -205,7 +225,7 @@ func (svc *cvmSvc) buildCreateAwsCvmTasks(body json.RawMessage) ([]ts.CustomFlow
 	return tasks, nil
 }
 
-func (svc *cvmSvc) buildCreateTCloudCvmTasks(body json.RawMessage) ([]ts.CustomFlowTask, error) {
+func (svc *cvmSvc) buildCreateTCloudCvmTasks(kt *kit.Kit, body json.RawMessage) ([]ts.CustomFlowTask, error) {
 
 	req := new(cscvm.TCloudCvmCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
@@ -216,6 +236,12 @@ func (svc *cvmSvc) buildCreateTCloudCvmTasks(body json.RawMessage) ([]ts.CustomF
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 资源下创建的主机未分配业务，密钥对需与主机在同一账号及地域下且未分配业务
+	if err := keypair.ValidateCvmKeyPair(kt, svc.client.DataService(), enumor.TCloud, req.AccountID, req.Region,
+		req.CloudKeyPairID, constant.UnassignedBiz); err != nil {
+		return nil, err
+	}
+
 	tasks := actioncvm.BuildCreateCvmTasks(req.RequiredCount, constant.UnassignedBiz,
 		constant.BatchCreateCvmFromCloudMaxLimit,
 		func(actionID action.ActIDType, count int64) ts.CustomFlowTask {
@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot ...
+package disksnapshot
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/rest"
+)
+
+// InitDiskSnapshotService initialize the disk snapshot service.
+func InitDiskSnapshotService(c *capability.Capability) {
+	svc := &diskSnapshotSvc{
+		client:      c.ApiClient,
+		authorizer:  c.Authorizer,
+		audit:       c.Audit,
+		snapshotLgc: c.Logics.DiskSnapshot,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListDiskSnapshot", http.MethodPost, "/disk_snapshots/list", svc.ListDiskSnapshot)
+	h.Add("GetDiskSnapshot", http.MethodGet, "/disk_snapshots/{id}", svc.GetDiskSnapshot)
+	h.Add("CreateDiskSnapshot", http.MethodPost, "/disk_snapshots/create", svc.CreateDiskSnapshot)
+	h.Add("DeleteDiskSnapshot", http.MethodDelete, "/disk_snapshots/{id}", svc.DeleteDiskSnapshot)
+	h.Add("RollbackDiskSnapshot", http.MethodPost, "/disk_snapshots/{id}/rollback", svc.RollbackDiskSnapshot)
+	h.Add("CreateDiskFromSnapshot", http.MethodPost, "/disk_snapshots/{id}/disks/create",
+		svc.CreateDiskFromSnapshot)
+	h.Add("AssignDiskSnapshot", http.MethodPost, "/disk_snapshots/assign/bizs", svc.AssignDiskSnapshot)
+
+	// disk snapshot apis in biz
+	h.Add("ListBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/list", svc.ListBizDiskSnapshot)
+	h.Add("GetBizDiskSnapshot", http.MethodGet, "/bizs/{bk_biz_id}/disk_snapshots/{id}", svc.GetBizDiskSnapshot)
+	h.Add("CreateBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/create",
+		svc.CreateBizDiskSnapshot)
+	h.Add("DeleteBizDiskSnapshot", http.MethodDelete, "/bizs/{bk_biz_id}/disk_snapshots/{id}",
+		svc.DeleteBizDiskSnapshot)
+	h.Add("RollbackBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/{id}/rollback",
+		svc.RollbackBizDiskSnapshot)
+	h.Add("CreateBizDiskFromSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/{id}/disks/create",
+		svc.CreateBizDiskFromSnapshot)
+
+	// recycle operation in res
+	h.Add("RecycleDiskSnapshot", http.MethodPost, "/disk_snapshots/recycle", svc.RecycleDiskSnapshot)
+	h.Add("RecoverDiskSnapshot", http.MethodPost, "/disk_snapshots/recover", svc.RecoverDiskSnapshot)
+	h.Add("BatchDeleteRecycledDiskSnapshot", http.MethodDelete, "/recycled/disk_snapshots/batch",
+		svc.BatchDeleteRecycledDiskSnapshot)
+
+	// recycle operation in biz
+	h.Add("RecycleBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/recycle",
+		svc.RecycleBizDiskSnapshot)
+	h.Add("RecoverBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/recover",
+		svc.RecoverBizDiskSnapshot)
+	h.Add("BatchDeleteBizRecycledDiskSnapshot", http.MethodDelete, "/bizs/{bk_biz_id}/recycled/disk_snapshots/batch",
+		svc.BatchDeleteBizRecycledDiskSnapshot)
+
+	h.Load(c.WebService)
+}
@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"hcm/cmd/cloud-server/service/common"
+	cloudproto "hcm/pkg/api/cloud-server/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// RecycleDiskSnapshot recycle disk snapshot.
+func (svc *diskSnapshotSvc) RecycleDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// RecycleBizDiskSnapshot recycle biz disk snapshot.
+func (svc *diskSnapshotSvc) RecycleBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *diskSnapshotSvc) recycleDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(cloudproto.DiskSnapshotRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	infos := make([]common.RecycleInfo, 0, len(req.Infos))
+	for _, info := range req.Infos {
+		infos = append(infos, common.RecycleInfo{ID: info.ID, Options: info.DiskSnapshotRecycleOptions})
+	}
+
+	return svc.recycler().Recycle(cts, validHandler, infos)
+}
+
+// RecoverDiskSnapshot recover disk snapshot.
+func (svc *diskSnapshotSvc) RecoverDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// RecoverBizDiskSnapshot recover biz disk snapshot.
+func (svc *diskSnapshotSvc) RecoverBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *diskSnapshotSvc) recoverDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(cloudproto.DiskSnapshotRecoverReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.recycler().Recover(cts, validHandler, req.RecordIDs)
+}
+
+// BatchDeleteRecycledDiskSnapshot batch delete recycled disk snapshots.
+func (svc *diskSnapshotSvc) BatchDeleteRecycledDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizRecycledDiskSnapshot batch delete biz recycled disk snapshots.
+func (svc *diskSnapshotSvc) BatchDeleteBizRecycledDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *diskSnapshotSvc) batchDeleteRecycledDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cloudproto.DiskSnapshotDeleteRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.recycler().BatchDeleteRecycled(cts, validHandler, req.RecordIDs)
+}
+
+func (svc *diskSnapshotSvc) recycler() *common.ResRecycler {
+	return &common.ResRecycler{
+		Client:          svc.client,
+		Authorizer:      svc.authorizer,
+		Audit:           svc.audit,
+		CloudResType:    enumor.DiskSnapshotCloudResType,
+		AuditResType:    enumor.DiskSnapshotAuditResType,
+		IamResType:      meta.DiskSnapshot,
+		BasicInfoFields: types.ResWithRecycleBasicFields,
+		DeleteRecycled:  svc.snapshotLgc.DeleteRecycledDiskSnapshot,
+	}
+}
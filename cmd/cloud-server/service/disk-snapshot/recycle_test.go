@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/tools/hooks/handler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskSnapshotRecycleTransition(t *testing.T) {
+	// 回收、恢复时按资源类型更新对应表的回收状态
+	tableName, err := enumor.DiskSnapshotCloudResType.ConvTableName()
+	assert.NoError(t, err)
+	assert.Equal(t, table.DiskSnapshotTable, tableName)
+
+	tests := []struct {
+		status  string
+		action  meta.Action
+		allowed bool
+	}{
+		// 未回收及已恢复的快照可以回收，不能恢复或销毁
+		{status: "", action: meta.Recycle, allowed: true},
+		{status: "", action: meta.Recover, allowed: false},
+		{status: "", action: meta.Destroy, allowed: false},
+		{status: enumor.RecoverStatus, action: meta.Recycle, allowed: true},
+		{status: enumor.RecoverStatus, action: meta.Destroy, allowed: false},
+		// 回收中的快照只能恢复或销毁，不能再次回收或回滚
+		{status: enumor.RecycleStatus, action: meta.Recycle, allowed: false},
+		{status: enumor.RecycleStatus, action: meta.Update, allowed: false},
+		{status: enumor.RecycleStatus, action: meta.Recover, allowed: true},
+		{status: enumor.RecycleStatus, action: meta.Destroy, allowed: true},
+	}
+
+	for _, tt := range tests {
+		for bizID, validHandler := range map[string]handler.ValidWithAuthHandler{"": handler.ResOperateAuth,
+			"1": handler.BizOperateAuth} {
+
+			info := newBasicInfo(enumor.DiskSnapshotCloudResType, "snap", 0)
+			if len(bizID) != 0 {
+				info.BkBizID = 1
+			}
+			info.RecycleStatus = tt.status
+
+			err := validHandler(newTestContexts(bizID), &handler.ValidWithAuthOption{Authorizer: new(fakeAuthorizer),
+				ResType: meta.DiskSnapshot, Action: tt.action,
+				BasicInfos: map[string]types.CloudResourceBasicInfo{info.ID: *info}})
+			assert.Equal(t, tt.allowed, err == nil, "status: %s, action: %s, biz: %s", tt.status, tt.action, bizID)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	disklgc "hcm/cmd/cloud-server/logics/disk"
+	snapshotlgc "hcm/cmd/cloud-server/logics/disk-snapshot"
+	cloudproto "hcm/pkg/api/cloud-server/disk-snapshot"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	"hcm/pkg/api/data-service/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	hcproto "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+type diskSnapshotSvc struct {
+	client      *client.ClientSet
+	authorizer  auth.Authorizer
+	audit       audit.Interface
+	snapshotLgc snapshotlgc.Interface
+}
+
+// ListDiskSnapshot list disk snapshot.
+func (svc *diskSnapshotSvc) ListDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.listDiskSnapshot(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizDiskSnapshot list biz disk snapshot.
+func (svc *diskSnapshotSvc) ListBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.listDiskSnapshot(cts, handler.ListBizAuthRes)
+}
+
+func (svc *diskSnapshotSvc) listDiskSnapshot(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(cloudproto.DiskSnapshotListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.DiskSnapshot, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &dataproto.ListResult{Details: make([]*coresnapshot.BaseDiskSnapshot, 0)}, nil
+	}
+
+	return svc.client.DataService().Global.ListDiskSnapshot(cts.Kit, &core.ListReq{Filter: expr, Page: req.Page})
+}
+
+// GetDiskSnapshot get disk snapshot.
+func (svc *diskSnapshotSvc) GetDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.getDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// GetBizDiskSnapshot get biz disk snapshot.
+func (svc *diskSnapshotSvc) GetBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.getDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *diskSnapshotSvc) getDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.DiskSnapshotCloudResType, id)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.DiskSnapshot,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return getDiskSnapshotExt(cts.Kit, svc.client.DataService().TCloud.ListDiskSnapshot, id)
+	case enumor.Aws:
+		return getDiskSnapshotExt(cts.Kit, svc.client.DataService().Aws.ListDiskSnapshot, id)
+	case enumor.HuaWei:
+		return getDiskSnapshotExt(cts.Kit, svc.client.DataService().HuaWei.ListDiskSnapshot, id)
+	case enumor.Azure:
+		return getDiskSnapshotExt(cts.Kit, svc.client.DataService().Azure.ListDiskSnapshot, id)
+	case enumor.Gcp:
+		return getDiskSnapshotExt(cts.Kit, svc.client.DataService().Gcp.ListDiskSnapshot, id)
+	default:
+		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", basicInfo.Vendor))
+	}
+}
+
+func getDiskSnapshotExt[T coresnapshot.Extension](kt *kit.Kit,
+	listFunc func(kt *kit.Kit, req *core.ListReq) (*dataproto.ListExtResult[T], error), id string) (
+	*coresnapshot.DiskSnapshot[T], error) {
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := listFunc(kt, req)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk snapshot: %s not found", id)
+	}
+
+	return result.Details[0], nil
+}
+
+// CreateDiskSnapshot create disk snapshot.
+func (svc *diskSnapshotSvc) CreateDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// CreateBizDiskSnapshot create biz disk snapshot.
+func (svc *diskSnapshotSvc) CreateBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *diskSnapshotSvc) createDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cloudproto.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	diskInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.DiskCloudResType,
+		req.DiskID, types.ResWithRecycleBasicFields...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 快照创建在源云盘所在账号下，按源云盘校验业务及权限
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.DiskSnapshot,
+		Action: meta.Create, BasicInfo: diskInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &hcproto.DiskSnapshotCreateReq{DiskID: req.DiskID, Name: req.Name, Description: req.Description}
+	result, err := svc.snapshotLgc.CreateDiskSnapshot(cts.Kit, diskInfo.Vendor, createReq)
+	if err != nil {
+		logs.Errorf("create disk snapshot failed, err: %v, disk: %s, rid: %s", err, req.DiskID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 业务下云盘创建的快照与云盘归属同一业务
+	if diskInfo.BkBizID != constant.UnassignedBiz {
+		err = snapshotlgc.Assign(cts.Kit, svc.client.DataService(), []string{result.ID}, diskInfo.BkBizID)
+		if err != nil {
+			logs.Errorf("assign disk snapshot to biz failed, err: %v, id: %s, biz: %d, rid: %s", err, result.ID,
+				diskInfo.BkBizID, cts.Kit.Rid)
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteDiskSnapshot delete disk snapshot.
+func (svc *diskSnapshotSvc) DeleteDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// DeleteBizDiskSnapshot delete biz disk snapshot.
+func (svc *diskSnapshotSvc) DeleteBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *diskSnapshotSvc) deleteDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.DiskSnapshotCloudResType, id, types.ResWithRecycleBasicFields...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.DiskSnapshot,
+		Action: meta.Delete, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, svc.snapshotLgc.DeleteDiskSnapshot(cts.Kit, basicInfo.Vendor, id)
+}
+
+// RollbackDiskSnapshot rollback disk snapshot to its source disk.
+func (svc *diskSnapshotSvc) RollbackDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.rollbackDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// RollbackBizDiskSnapshot rollback biz disk snapshot to its source disk.
+func (svc *diskSnapshotSvc) RollbackBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.rollbackDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *diskSnapshotSvc) rollbackDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.DiskSnapshotCloudResType, id, types.ResWithRecycleBasicFields...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Fields: []string{"id", "disk_id"},
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	snapshots, err := svc.client.DataService().Global.ListDiskSnapshot(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(snapshots.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk snapshot: %s not found", id)
+	}
+
+	diskID := snapshots.Details[0].DiskID
+	if len(diskID) == 0 {
+		return nil, errf.Newf(errf.InvalidParameter, "source disk of disk snapshot: %s not found", id)
+	}
+
+	diskInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.DiskCloudResType, diskID,
+		types.ResWithRecycleBasicFields...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = validateRollbackAuth(cts, validHandler, svc.authorizer, basicInfo, diskInfo); err != nil {
+		return nil, err
+	}
+
+	return nil, svc.snapshotLgc.RollbackDiskSnapshot(cts.Kit, basicInfo.Vendor, id, diskID)
+}
+
+// validateRollbackAuth 回滚会覆盖源云盘数据，按更新操作鉴权。源云盘与快照可能已分配到不同业务，需同时校验源云盘所属业务及其更新权限
+func validateRollbackAuth(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler, authorizer auth.Authorizer,
+	snapshotInfo, diskInfo *types.CloudResourceBasicInfo) error {
+
+	err := validHandler(cts, &handler.ValidWithAuthOption{Authorizer: authorizer, ResType: meta.DiskSnapshot,
+		Action: meta.Update, BasicInfo: snapshotInfo})
+	if err != nil {
+		return err
+	}
+
+	return validHandler(cts, &handler.ValidWithAuthOption{Authorizer: authorizer, ResType: meta.Disk,
+		Action: meta.Update, BasicInfo: diskInfo})
+}
+
+// CreateDiskFromSnapshot create disk from snapshot.
+func (svc *diskSnapshotSvc) CreateDiskFromSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskFromSnapshot(cts, handler.ResOperateAuth)
+}
+
+// CreateBizDiskFromSnapshot create biz disk from snapshot.
+func (svc *diskSnapshotSvc) CreateBizDiskFromSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskFromSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *diskSnapshotSvc) createDiskFromSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+
+	req := new(cloudproto.DiskFromSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.DiskSnapshotCloudResType, id, types.ResWithRecycleBasicFields...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 校验快照所属业务及查看权限
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.DiskSnapshot,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	// 云盘创建在快照所在账号下，并归属快照所在业务，按此校验云盘创建权限
+	diskInfo := &types.CloudResourceBasicInfo{ResType: enumor.DiskCloudResType, ID: id, Vendor: basicInfo.Vendor,
+		AccountID: basicInfo.AccountID, BkBizID: basicInfo.BkBizID}
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Disk,
+		Action: meta.Create, BasicInfo: diskInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &hcproto.DiskFromSnapshotCreateReq{
+		ID:       id,
+		DiskName: req.DiskName,
+		Zone:     req.Zone,
+		DiskType: req.DiskType,
+	}
+	result, err := svc.snapshotLgc.CreateDiskFromSnapshot(cts.Kit, basicInfo.Vendor, createReq)
+	if err != nil {
+		logs.Errorf("create disk from snapshot failed, err: %v, snapshot: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 业务下快照创建的云盘与快照归属同一业务
+	if basicInfo.BkBizID != constant.UnassignedBiz {
+		err = disklgc.Assign(cts.Kit, svc.client.DataService(), []string{result.ID}, uint64(basicInfo.BkBizID),
+			false)
+		if err != nil {
+			logs.Errorf("assign disk to biz failed, err: %v, id: %s, biz: %d, rid: %s", err, result.ID,
+				basicInfo.BkBizID, cts.Kit.Rid)
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// AssignDiskSnapshot assign disk snapshot to biz.
+func (svc *diskSnapshotSvc) AssignDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(cloudproto.DiskSnapshotAssignReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: enumor.DiskSnapshotCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.DiskSnapshot,
+			Action: meta.Assign, ResourceID: info.AccountID}, BizID: int64(req.BkBizID)})
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	return nil, snapshotlgc.Assign(cts.Kit, svc.client.DataService(), req.IDs, int64(req.BkBizID))
+}
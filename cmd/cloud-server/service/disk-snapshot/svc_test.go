@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAuthorizer 记录鉴权的资源，对 denied 类型的资源返回无权限
+type fakeAuthorizer struct {
+	auth.Authorizer
+	denied     meta.ResourceType
+	authorized []meta.Basic
+}
+
+// AuthorizeWithPerm ...
+func (a *fakeAuthorizer) AuthorizeWithPerm(_ *kit.Kit, resources ...meta.ResourceAttribute) error {
+	for _, res := range resources {
+		if res.Basic.Type == a.denied {
+			return errf.New(errf.PermissionDenied, "no permission")
+		}
+		a.authorized = append(a.authorized, *res.Basic)
+	}
+	return nil
+}
+
+func newTestContexts(bizID string) *rest.Contexts {
+	req := restful.NewRequest(httptest.NewRequest(http.MethodPost, "/", nil))
+	if len(bizID) != 0 {
+		req.PathParameters()["bk_biz_id"] = bizID
+	}
+	return &rest.Contexts{Kit: kit.New(), Request: req}
+}
+
+func newBasicInfo(resType enumor.CloudResourceType, id string, bizID int64) *types.CloudResourceBasicInfo {
+	return &types.CloudResourceBasicInfo{ResType: resType, ID: id, Vendor: enumor.TCloud, AccountID: "account",
+		BkBizID: bizID}
+}
+
+func TestValidateRollbackAuth(t *testing.T) {
+	snapshot := newBasicInfo(enumor.DiskSnapshotCloudResType, "snap", 1)
+	disk := newBasicInfo(enumor.DiskCloudResType, "disk", 1)
+
+	// 快照及源云盘都需要按更新操作鉴权
+	authorizer := new(fakeAuthorizer)
+	assert.NoError(t, validateRollbackAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, snapshot, disk))
+	assert.Equal(t, []meta.Basic{{Type: meta.DiskSnapshot, Action: meta.Update},
+		{Type: meta.Disk, Action: meta.Update}}, authorizer.authorized)
+
+	// 源云盘已分配到其他业务
+	authorizer = new(fakeAuthorizer)
+	otherBizDisk := newBasicInfo(enumor.DiskCloudResType, "disk", 2)
+	err := validateRollbackAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, snapshot, otherBizDisk)
+	assert.Error(t, err)
+	assert.Equal(t, []meta.Basic{{Type: meta.DiskSnapshot, Action: meta.Update}}, authorizer.authorized)
+
+	// 没有源云盘的更新权限
+	authorizer = &fakeAuthorizer{denied: meta.Disk}
+	assert.Error(t, validateRollbackAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, snapshot, disk))
+
+	// 没有快照的更新权限时不再校验源云盘
+	authorizer = &fakeAuthorizer{denied: meta.DiskSnapshot}
+	assert.Error(t, validateRollbackAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, snapshot, disk))
+	assert.Empty(t, authorizer.authorized)
+
+	// 源云盘在回收站中
+	recycledDisk := newBasicInfo(enumor.DiskCloudResType, "disk", 1)
+	recycledDisk.RecycleStatus = enumor.RecycleStatus
+	assert.Error(t, validateRollbackAuth(newTestContexts("1"), handler.BizOperateAuth, new(fakeAuthorizer), snapshot,
+		recycledDisk))
+
+	// 资源下回滚时，源云盘已分配到业务
+	unassignedSnapshot := newBasicInfo(enumor.DiskSnapshotCloudResType, "snap", 0)
+	assert.Error(t, validateRollbackAuth(newTestContexts(""), handler.ResOperateAuth, new(fakeAuthorizer),
+		unassignedSnapshot, disk))
+	assert.NoError(t, validateRollbackAuth(newTestContexts(""), handler.ResOperateAuth, new(fakeAuthorizer),
+		unassignedSnapshot, newBasicInfo(enumor.DiskCloudResType, "disk", 0)))
+}
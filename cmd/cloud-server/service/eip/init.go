@@ -58,6 +58,11 @@ func InitEipService(c *capability.Capability) {
 	h.Add("DisassociateEip", http.MethodPost, "/eips/disassociate", svc.DisassociateEip)
 	h.Add("CreateEip", http.MethodPost, "/eips/create", svc.CreateEip)
 
+	// recycle operation in res
+	h.Add("RecycleEip", http.MethodPost, "/eips/recycle", svc.RecycleEip)
+	h.Add("RecoverEip", http.MethodPost, "/eips/recover", svc.RecoverEip)
+	h.Add("BatchDeleteRecycledEip", http.MethodDelete, "/recycled/eips/batch", svc.BatchDeleteRecycledEip)
+
 	// eip apis in biz
 	h.Add("ListBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/list", svc.ListBizEip)
 	h.Add("ListBizEipExtByCvmID", http.MethodGet, "/bizs/{bk_biz_id}/vendors/{vendor}/eips/cvms/{cvm_id}",
@@ -70,5 +75,11 @@ func InitEipService(c *capability.Capability) {
 	h.Add("DisassociateBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/disassociate", svc.DisassociateBizEip)
 	h.Add("CreateBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/create", svc.CreateBizEip)
 
+	// recycle operation in biz
+	h.Add("RecycleBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/recycle", svc.RecycleBizEip)
+	h.Add("RecoverBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/recover", svc.RecoverBizEip)
+	h.Add("BatchDeleteBizRecycledEip", http.MethodDelete, "/bizs/{bk_biz_id}/recycled/eips/batch",
+		svc.BatchDeleteBizRecycledEip)
+
 	h.Load(c.WebService)
 }
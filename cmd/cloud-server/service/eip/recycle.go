@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package eip
+
+import (
+	"hcm/cmd/cloud-server/logics/eip"
+	"hcm/cmd/cloud-server/service/common"
+	cloudproto "hcm/pkg/api/cloud-server/eip"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// RecycleEip recycle eip.
+func (svc *eipSvc) RecycleEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleEip(cts, handler.ResOperateAuth)
+}
+
+// RecycleBizEip recycle biz eip.
+func (svc *eipSvc) RecycleBizEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleEip(cts, handler.BizOperateAuth)
+}
+
+func (svc *eipSvc) recycleEip(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(cloudproto.EipRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	infos := make([]common.RecycleInfo, 0, len(req.Infos))
+	for _, info := range req.Infos {
+		infos = append(infos, common.RecycleInfo{ID: info.ID, Options: info.EipRecycleOptions})
+	}
+
+	return svc.recycler().Recycle(cts, validHandler, infos)
+}
+
+// RecoverEip recover eip.
+func (svc *eipSvc) RecoverEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverEip(cts, handler.ResOperateAuth)
+}
+
+// RecoverBizEip recover biz eip.
+func (svc *eipSvc) RecoverBizEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverEip(cts, handler.BizOperateAuth)
+}
+
+func (svc *eipSvc) recoverEip(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(cloudproto.EipRecoverReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.recycler().Recover(cts, validHandler, req.RecordIDs)
+}
+
+// BatchDeleteRecycledEip batch delete recycled eips.
+func (svc *eipSvc) BatchDeleteRecycledEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledEip(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizRecycledEip batch delete biz recycled eips.
+func (svc *eipSvc) BatchDeleteBizRecycledEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledEip(cts, handler.BizOperateAuth)
+}
+
+func (svc *eipSvc) batchDeleteRecycledEip(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cloudproto.EipDeleteRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.recycler().BatchDeleteRecycled(cts, validHandler, req.RecordIDs)
+}
+
+func (svc *eipSvc) recycler() *common.ResRecycler {
+	return &common.ResRecycler{
+		Client:          svc.client,
+		Authorizer:      svc.authorizer,
+		Audit:           svc.audit,
+		CloudResType:    enumor.EipCloudResType,
+		AuditResType:    enumor.EipAuditResType,
+		IamResType:      meta.Eip,
+		BasicInfoFields: append(types.CommonBasicInfoFields, "recycle_status"),
+		ValidateRecycle: func(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) error {
+			return eip.ValidateNotBound(kt, svc.client.DataService(), converter.MapKeyToStringSlice(basicInfoMap))
+		},
+		DeleteRecycled: svc.eip.DeleteRecycledEip,
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair ...
+package keypair
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/rest"
+)
+
+// InitKeyPairService initialize the key pair service.
+func InitKeyPairService(c *capability.Capability) {
+	svc := &keyPairSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+		keyPairLgc: c.Logics.KeyPair,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListKeyPair", http.MethodPost, "/key_pairs/list", svc.ListKeyPair)
+	h.Add("GetKeyPair", http.MethodGet, "/key_pairs/{id}", svc.GetKeyPair)
+	h.Add("CreateKeyPair", http.MethodPost, "/key_pairs/create", svc.CreateKeyPair)
+	h.Add("DeleteKeyPair", http.MethodDelete, "/key_pairs/{id}", svc.DeleteKeyPair)
+	h.Add("AssociateKeyPair", http.MethodPost, "/key_pairs/{id}/associate", svc.AssociateKeyPair)
+	h.Add("DisassociateKeyPair", http.MethodPost, "/key_pairs/{id}/disassociate", svc.DisassociateKeyPair)
+	h.Add("AssignKeyPair", http.MethodPost, "/key_pairs/assign/bizs", svc.AssignKeyPair)
+
+	// key pair apis in biz
+	h.Add("ListBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/list", svc.ListBizKeyPair)
+	h.Add("GetBizKeyPair", http.MethodGet, "/bizs/{bk_biz_id}/key_pairs/{id}", svc.GetBizKeyPair)
+	h.Add("CreateBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/create", svc.CreateBizKeyPair)
+	h.Add("DeleteBizKeyPair", http.MethodDelete, "/bizs/{bk_biz_id}/key_pairs/{id}", svc.DeleteBizKeyPair)
+	h.Add("AssociateBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/{id}/associate",
+		svc.AssociateBizKeyPair)
+	h.Add("DisassociateBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/{id}/disassociate",
+		svc.DisassociateBizKeyPair)
+
+	h.Load(c.WebService)
+}
@@ -0,0 +1,350 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"fmt"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	keypairlgc "hcm/cmd/cloud-server/logics/key-pair"
+	"hcm/cmd/cloud-server/service/common"
+	cloudproto "hcm/pkg/api/cloud-server/key-pair"
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/api/data-service/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	hcproto "hcm/pkg/api/hc-service/key-pair"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+type keyPairSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+	keyPairLgc keypairlgc.Interface
+}
+
+// ListKeyPair list key pair.
+func (svc *keyPairSvc) ListKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.listKeyPair(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizKeyPair list biz key pair.
+func (svc *keyPairSvc) ListBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.listKeyPair(cts, handler.ListBizAuthRes)
+}
+
+func (svc *keyPairSvc) listKeyPair(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	req := new(cloudproto.KeyPairListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.KeyPair, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &dataproto.ListResult{Details: make([]*corekeypair.BaseKeyPair, 0)}, nil
+	}
+
+	return svc.client.DataService().Global.ListKeyPair(cts.Kit, &core.ListReq{Filter: expr, Page: req.Page})
+}
+
+// GetKeyPair get key pair.
+func (svc *keyPairSvc) GetKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.getKeyPair(cts, handler.ResOperateAuth)
+}
+
+// GetBizKeyPair get biz key pair.
+func (svc *keyPairSvc) GetBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.getKeyPair(cts, handler.BizOperateAuth)
+}
+
+func (svc *keyPairSvc) getKeyPair(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	id := cts.PathParameter("id").String()
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.KeyPairCloudResType, id)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.KeyPair,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return getKeyPairExt(cts.Kit, svc.client.DataService().TCloud.ListKeyPair, id)
+	case enumor.Aws:
+		return getKeyPairExt(cts.Kit, svc.client.DataService().Aws.ListKeyPair, id)
+	case enumor.HuaWei:
+		return getKeyPairExt(cts.Kit, svc.client.DataService().HuaWei.ListKeyPair, id)
+	case enumor.Azure:
+		return getKeyPairExt(cts.Kit, svc.client.DataService().Azure.ListKeyPair, id)
+	default:
+		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", basicInfo.Vendor))
+	}
+}
+
+func getKeyPairExt[T corekeypair.Extension](kt *kit.Kit,
+	listFunc func(kt *kit.Kit, req *core.ListReq) (*dataproto.ListExtResult[T], error), id string) (
+	*corekeypair.KeyPair[T], error) {
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := listFunc(kt, req)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", id)
+	}
+
+	return result.Details[0], nil
+}
+
+// CreateKeyPair create or import key pair.
+func (svc *keyPairSvc) CreateKeyPair(cts *rest.Contexts) (interface{}, error) {
+	bizID := int64(constant.UnassignedBiz)
+	return svc.createKeyPair(cts, bizID, handler.ResOperateAuth)
+}
+
+// CreateBizKeyPair create or import biz key pair.
+func (svc *keyPairSvc) CreateBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, err
+	}
+	return svc.createKeyPair(cts, bizID, handler.BizOperateAuth)
+}
+
+func (svc *keyPairSvc) createKeyPair(cts *rest.Contexts, bizID int64, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cloudproto.KeyPairCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate biz and authorize
+	err := validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.KeyPair,
+		Action: meta.Create, BasicInfo: common.GetCloudResourceBasicInfo(req.AccountID, bizID)})
+	if err != nil {
+		return nil, err
+	}
+
+	accountInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.AccountCloudResType,
+		req.AccountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	createReq := &hcproto.KeyPairCreateReq{
+		AccountID:         req.AccountID,
+		Region:            req.Region,
+		Name:              req.Name,
+		PublicKey:         req.PublicKey,
+		ProjectID:         req.ProjectID,
+		ResourceGroupName: req.ResourceGroupName,
+	}
+	result, err := svc.keyPairLgc.CreateKeyPair(cts.Kit, accountInfo.Vendor, createReq)
+	if err != nil {
+		logs.Errorf("create key pair failed, err: %v, account: %s, name: %s, rid: %s", err, req.AccountID,
+			req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 业务下创建的密钥对直接分配到该业务
+	if bizID != constant.UnassignedBiz {
+		if err = keypairlgc.Assign(cts.Kit, svc.client.DataService(), []string{result.ID}, bizID); err != nil {
+			logs.Errorf("assign key pair to biz failed, err: %v, id: %s, biz: %d, rid: %s", err, result.ID, bizID,
+				cts.Kit.Rid)
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteKeyPair delete key pair.
+func (svc *keyPairSvc) DeleteKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteKeyPair(cts, handler.ResOperateAuth)
+}
+
+// DeleteBizKeyPair delete biz key pair.
+func (svc *keyPairSvc) DeleteBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteKeyPair(cts, handler.BizOperateAuth)
+}
+
+func (svc *keyPairSvc) deleteKeyPair(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	id := cts.PathParameter("id").String()
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.KeyPairCloudResType, id)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.KeyPair,
+		Action: meta.Delete, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, svc.keyPairLgc.DeleteKeyPair(cts.Kit, basicInfo.Vendor, id)
+}
+
+// AssociateKeyPair associate key pair with cvm.
+func (svc *keyPairSvc) AssociateKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.associateKeyPair(cts, handler.ResOperateAuth, true)
+}
+
+// AssociateBizKeyPair associate biz key pair with cvm.
+func (svc *keyPairSvc) AssociateBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.associateKeyPair(cts, handler.BizOperateAuth, true)
+}
+
+// DisassociateKeyPair disassociate key pair from cvm.
+func (svc *keyPairSvc) DisassociateKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.associateKeyPair(cts, handler.ResOperateAuth, false)
+}
+
+// DisassociateBizKeyPair disassociate biz key pair from cvm.
+func (svc *keyPairSvc) DisassociateBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.associateKeyPair(cts, handler.BizOperateAuth, false)
+}
+
+func (svc *keyPairSvc) associateKeyPair(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	associate bool) (interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+
+	req := new(cloudproto.KeyPairAssociateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 密钥对与主机的ID分属不同的表，可能相同，需分别查询
+	keyPairInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.KeyPairCloudResType, id,
+		types.CommonBasicInfoFields...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	cvmInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.CvmCloudResType, req.CvmID,
+		types.ResWithRecycleBasicFields...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if err = validateAssociateAuth(cts, validHandler, svc.authorizer, keyPairInfo, cvmInfo); err != nil {
+		return nil, err
+	}
+
+	opt := &keypairlgc.AssociateOption{ID: id, CvmID: req.CvmID, ForceStop: req.ForceStop, Auth: req.Auth}
+	if associate {
+		return nil, svc.keyPairLgc.AssociateKeyPair(cts.Kit, keyPairInfo.Vendor, opt)
+	}
+
+	return nil, svc.keyPairLgc.DisassociateKeyPair(cts.Kit, keyPairInfo.Vendor, opt)
+}
+
+// validateAssociateAuth 绑定/解绑会替换主机上的登录公钥，密钥对需与主机在同一账号下，并按密钥对及主机各自的更新操作鉴权
+func validateAssociateAuth(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler, authorizer auth.Authorizer,
+	keyPairInfo, cvmInfo *types.CloudResourceBasicInfo) error {
+
+	if keyPairInfo.AccountID != cvmInfo.AccountID {
+		return errf.Newf(errf.InvalidParameter, "key pair: %s and cvm: %s are not in the same account",
+			keyPairInfo.ID, cvmInfo.ID)
+	}
+
+	err := validHandler(cts, &handler.ValidWithAuthOption{Authorizer: authorizer, ResType: meta.KeyPair,
+		Action: meta.Update, BasicInfo: keyPairInfo})
+	if err != nil {
+		return err
+	}
+
+	return validHandler(cts, &handler.ValidWithAuthOption{Authorizer: authorizer, ResType: meta.Cvm,
+		Action: meta.Update, BasicInfo: cvmInfo})
+}
+
+// AssignKeyPair assign key pair to biz.
+func (svc *keyPairSvc) AssignKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(cloudproto.KeyPairAssignReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: enumor.KeyPairCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.KeyPair,
+			Action: meta.Assign, ResourceID: info.AccountID}, BizID: int64(req.BkBizID)})
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	return nil, keypairlgc.Assign(cts.Kit, svc.client.DataService(), req.IDs, int64(req.BkBizID))
+}
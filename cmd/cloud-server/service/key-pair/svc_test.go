@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAuthorizer 记录鉴权的资源，对 denied 类型的资源返回无权限
+type fakeAuthorizer struct {
+	auth.Authorizer
+	denied     meta.ResourceType
+	authorized []meta.Basic
+}
+
+// AuthorizeWithPerm ...
+func (a *fakeAuthorizer) AuthorizeWithPerm(_ *kit.Kit, resources ...meta.ResourceAttribute) error {
+	for _, res := range resources {
+		if res.Basic.Type == a.denied {
+			return errf.New(errf.PermissionDenied, "no permission")
+		}
+		a.authorized = append(a.authorized, *res.Basic)
+	}
+	return nil
+}
+
+func newTestContexts(bizID string) *rest.Contexts {
+	req := restful.NewRequest(httptest.NewRequest(http.MethodPost, "/", nil))
+	if len(bizID) != 0 {
+		req.PathParameters()["bk_biz_id"] = bizID
+	}
+	return &rest.Contexts{Kit: kit.New(), Request: req}
+}
+
+func newBasicInfo(resType enumor.CloudResourceType, id, accountID string, bizID int64) *types.CloudResourceBasicInfo {
+	return &types.CloudResourceBasicInfo{ResType: resType, ID: id, Vendor: enumor.TCloud, AccountID: accountID,
+		BkBizID: bizID}
+}
+
+func TestValidateAssociateAuth(t *testing.T) {
+	keyPair := newBasicInfo(enumor.KeyPairCloudResType, "kp", "account", 1)
+	cvm := newBasicInfo(enumor.CvmCloudResType, "cvm", "account", 1)
+
+	// 密钥对及主机都需要按更新操作鉴权
+	authorizer := new(fakeAuthorizer)
+	assert.NoError(t, validateAssociateAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, keyPair, cvm))
+	assert.Equal(t, []meta.Basic{{Type: meta.KeyPair, Action: meta.Update},
+		{Type: meta.Cvm, Action: meta.Update}}, authorizer.authorized)
+
+	// 密钥对与主机不在同一账号下时不鉴权
+	authorizer = new(fakeAuthorizer)
+	otherAccountCvm := newBasicInfo(enumor.CvmCloudResType, "cvm", "other", 1)
+	err := validateAssociateAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, keyPair, otherAccountCvm)
+	assert.Error(t, err)
+	assert.Equal(t, errf.InvalidParameter, errf.Error(err).Code)
+	assert.Empty(t, authorizer.authorized)
+
+	// 主机已分配到其他业务
+	authorizer = new(fakeAuthorizer)
+	otherBizCvm := newBasicInfo(enumor.CvmCloudResType, "cvm", "account", 2)
+	assert.Error(t, validateAssociateAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, keyPair,
+		otherBizCvm))
+	assert.Equal(t, []meta.Basic{{Type: meta.KeyPair, Action: meta.Update}}, authorizer.authorized)
+
+	// 没有主机的更新权限
+	authorizer = &fakeAuthorizer{denied: meta.Cvm}
+	assert.Error(t, validateAssociateAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, keyPair, cvm))
+
+	// 没有密钥对的更新权限时不再校验主机
+	authorizer = &fakeAuthorizer{denied: meta.KeyPair}
+	assert.Error(t, validateAssociateAuth(newTestContexts("1"), handler.BizOperateAuth, authorizer, keyPair, cvm))
+	assert.Empty(t, authorizer.authorized)
+
+	// 主机在回收站中
+	recycledCvm := newBasicInfo(enumor.CvmCloudResType, "cvm", "account", 1)
+	recycledCvm.RecycleStatus = enumor.RecycleStatus
+	assert.Error(t, validateAssociateAuth(newTestContexts("1"), handler.BizOperateAuth, new(fakeAuthorizer), keyPair,
+		recycledCvm))
+
+	// 资源下绑定时，主机已分配到业务
+	unassignedKeyPair := newBasicInfo(enumor.KeyPairCloudResType, "kp", "account", 0)
+	assert.Error(t, validateAssociateAuth(newTestContexts(""), handler.ResOperateAuth, new(fakeAuthorizer),
+		unassignedKeyPair, cvm))
+	assert.NoError(t, validateAssociateAuth(newTestContexts(""), handler.ResOperateAuth, new(fakeAuthorizer),
+		unassignedKeyPair, newBasicInfo(enumor.CvmCloudResType, "cvm", "account", 0)))
+}
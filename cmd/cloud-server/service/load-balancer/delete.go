@@ -176,7 +176,7 @@ func (svc *lbSvc) batchDeleteLoadBalancer(cts *rest.Contexts, validHandler handl
 	infoReq := dataproto.ListResourceBasicInfoReq{
 		ResourceType: enumor.LoadBalancerCloudResType,
 		IDs:          req.IDs,
-		Fields:       append(types.CommonBasicInfoFields, "region"),
+		Fields:       append(types.CommonBasicInfoFields, "region", "recycle_status"),
 	}
 	lbInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, infoReq)
 	if err != nil {
@@ -79,11 +79,53 @@ func (svc *lbSvc) listListener(cts *rest.Contexts, authHandler handler.ListAuthR
 	case enumor.TCloud:
 		lblInfoList, err := svc.getTCloudUrlRuleAndTargetGroupMap(cts.Kit, lbID, req)
 		return &cslb.ListListenerResult{Details: lblInfoList}, err
+	case enumor.HuaWei:
+		lblInfoList, err := svc.listHuaWeiListener(cts.Kit, lbID, req)
+		return &cslb.ListListenerResult{Details: lblInfoList}, err
+	case enumor.Aws:
+		lblInfoList, err := svc.listAwsListener(cts.Kit, lbID, req)
+		return &cslb.ListListenerResult{Details: lblInfoList}, err
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "lbID: %s vendor: %s not support", lbID, basicInfo.Vendor)
 	}
 }
 
+// listHuaWeiListener 返回华为云监听器基础信息，华为云监听器下无域名及url规则
+func (svc *lbSvc) listHuaWeiListener(kt *kit.Kit, lbID string, req *core.ListReq) ([]*cslb.ListenerListInfo,
+	error) {
+
+	listenerList, err := svc.client.DataService().HuaWei.LoadBalancer.ListListener(kt, req)
+	if err != nil {
+		logs.Errorf("list huawei listener failed, lbID: %s, err: %v, rid: %s", lbID, err, kt.Rid)
+		return nil, err
+	}
+
+	lblInfoList := make([]*cslb.ListenerListInfo, 0, len(listenerList.Details))
+	for _, lbl := range listenerList.Details {
+		lblInfoList = append(lblInfoList, &cslb.ListenerListInfo{BaseListener: *lbl.BaseListener})
+	}
+
+	return lblInfoList, nil
+}
+
+// listAwsListener 返回aws监听器基础信息，aws转发规则挂在监听器下，不返回域名及url数量
+func (svc *lbSvc) listAwsListener(kt *kit.Kit, lbID string, req *core.ListReq) ([]*cslb.ListenerListInfo,
+	error) {
+
+	listenerList, err := svc.client.DataService().Aws.LoadBalancer.ListListener(kt, req)
+	if err != nil {
+		logs.Errorf("list aws listener failed, lbID: %s, err: %v, rid: %s", lbID, err, kt.Rid)
+		return nil, err
+	}
+
+	lblInfoList := make([]*cslb.ListenerListInfo, 0, len(listenerList.Details))
+	for _, lbl := range listenerList.Details {
+		lblInfoList = append(lblInfoList, &cslb.ListenerListInfo{BaseListener: *lbl.BaseListener})
+	}
+
+	return lblInfoList, nil
+}
+
 // 返回监听器信息， 域名数量和url数量，绑定目标组同步状态
 func (svc *lbSvc) getTCloudUrlRuleAndTargetGroupMap(kt *kit.Kit, lbID string,
 	req *core.ListReq) ([]*cslb.ListenerListInfo, error) {
@@ -259,6 +301,10 @@ func (svc *lbSvc) getListener(cts *rest.Contexts, validHandler handler.ListAuthR
 	switch basicInfo.Vendor {
 	case enumor.TCloud:
 		return svc.getTCloudListener(cts.Kit, id)
+	case enumor.HuaWei:
+		return svc.client.DataService().HuaWei.LoadBalancer.GetListener(cts.Kit, id)
+	case enumor.Aws:
+		return svc.client.DataService().Aws.LoadBalancer.GetListener(cts.Kit, id)
 
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "id: %s vendor: %s not support", id, basicInfo.Vendor)
@@ -379,10 +425,10 @@ func (svc *lbSvc) listListenerCountByLbIDs(cts *rest.Contexts,
 	}
 
 	switch basicInfo.Vendor {
-	case enumor.TCloud:
+	case enumor.TCloud, enumor.HuaWei:
 		resList, err = svc.client.DataService().Global.LoadBalancer.CountLoadBalancerListener(cts.Kit, req)
 		if err != nil {
-			logs.Errorf("tcloud count load balancer listener failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+			logs.Errorf("count load balancer listener failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
 			return nil, err
 		}
 		return resList, nil
@@ -59,6 +59,12 @@ func InitService(c *capability.Capability) {
 		"/load_balancers/{id}/lock/status", svc.GetLoadBalancerLockStatus)
 	h.Add("ListResLoadBalancerQuotas", http.MethodPost, "/load_balancers/quotas", svc.ListResLoadBalancerQuotas)
 
+	// recycle operation in res
+	h.Add("RecycleLoadBalancer", http.MethodPost, "/load_balancers/recycle", svc.RecycleLoadBalancer)
+	h.Add("RecoverLoadBalancer", http.MethodPost, "/load_balancers/recover", svc.RecoverLoadBalancer)
+	h.Add("BatchDeleteRecycledLoadBalancer", http.MethodDelete, "/recycled/load_balancers/batch",
+		svc.BatchDeleteRecycledLoadBalancer)
+
 	bizH := rest.NewHandler()
 	bizH.Path("/bizs/{bk_biz_id}")
 	bizService(bizH, svc)
@@ -78,6 +84,10 @@ func bizService(h *rest.Handler, svc *lbSvc) {
 		"/load_balancers/with/delete_protection/list", svc.ListBizLoadBalancerWithDeleteProtect)
 	h.Add("GetBizLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetBizLoadBalancer)
 	h.Add("BatchDeleteBizLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteBizLoadBalancer)
+	h.Add("RecycleBizLoadBalancer", http.MethodPost, "/load_balancers/recycle", svc.RecycleBizLoadBalancer)
+	h.Add("RecoverBizLoadBalancer", http.MethodPost, "/load_balancers/recover", svc.RecoverBizLoadBalancer)
+	h.Add("BatchDeleteBizRecycledLoadBalancer", http.MethodDelete, "/recycled/load_balancers/batch",
+		svc.BatchDeleteBizRecycledLoadBalancer)
 
 	h.Add("ListBizListener", http.MethodPost, "/load_balancers/{lb_id}/listeners/list", svc.ListBizListener)
 	h.Add("GetBizListener", http.MethodGet, "/listeners/{id}", svc.GetBizListener)
@@ -190,6 +190,10 @@ func (svc *lbSvc) getLoadBalancer(cts *rest.Contexts, validHandler handler.ListA
 	switch basicInfo.Vendor {
 	case enumor.TCloud:
 		return svc.client.DataService().TCloud.LoadBalancer.Get(cts.Kit, id)
+	case enumor.HuaWei:
+		return svc.client.DataService().HuaWei.LoadBalancer.Get(cts.Kit, id)
+	case enumor.Aws:
+		return svc.client.DataService().Aws.LoadBalancer.Get(cts.Kit, id)
 
 	default:
 		return nil, errf.Newf(errf.Unknown, "id: %s vendor: %s not support", id, basicInfo.Vendor)
@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	lblogic "hcm/cmd/cloud-server/logics/load-balancer"
+	"hcm/cmd/cloud-server/service/common"
+	cslb "hcm/pkg/api/cloud-server/load-balancer"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// RecycleLoadBalancer recycle load balancer.
+func (svc *lbSvc) RecycleLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleLoadBalancer(cts, handler.ResOperateAuth)
+}
+
+// RecycleBizLoadBalancer recycle biz load balancer.
+func (svc *lbSvc) RecycleBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleLoadBalancer(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) recycleLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(cslb.LoadBalancerRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	infos := make([]common.RecycleInfo, 0, len(req.Infos))
+	for _, info := range req.Infos {
+		infos = append(infos, common.RecycleInfo{ID: info.ID, Options: info.LoadBalancerRecycleOptions})
+	}
+
+	return svc.recycler().Recycle(cts, validHandler, infos)
+}
+
+// RecoverLoadBalancer recover load balancer.
+func (svc *lbSvc) RecoverLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverLoadBalancer(cts, handler.ResOperateAuth)
+}
+
+// RecoverBizLoadBalancer recover biz load balancer.
+func (svc *lbSvc) RecoverBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverLoadBalancer(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) recoverLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(cslb.LoadBalancerRecoverReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.recycler().Recover(cts, validHandler, req.RecordIDs)
+}
+
+// BatchDeleteRecycledLoadBalancer batch delete recycled load balancers.
+func (svc *lbSvc) BatchDeleteRecycledLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledLoadBalancer(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizRecycledLoadBalancer batch delete biz recycled load balancers.
+func (svc *lbSvc) BatchDeleteBizRecycledLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledLoadBalancer(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) batchDeleteRecycledLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cslb.LoadBalancerDeleteRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.recycler().BatchDeleteRecycled(cts, validHandler, req.RecordIDs)
+}
+
+func (svc *lbSvc) recycler() *common.ResRecycler {
+	return &common.ResRecycler{
+		Client:          svc.client,
+		Authorizer:      svc.authorizer,
+		Audit:           svc.audit,
+		CloudResType:    enumor.LoadBalancerCloudResType,
+		AuditResType:    enumor.LoadBalancerAuditResType,
+		IamResType:      meta.LoadBalancer,
+		BasicInfoFields: append(types.CommonBasicInfoFields, "region", "recycle_status"),
+		ValidateRecycle: func(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) error {
+			return lblogic.ValidateRecyclable(kt, svc.client.DataService(), basicInfoMap)
+		},
+		DeleteRecycled: func(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+			*core.BatchOperateResult, error) {
+
+			return lblogic.DeleteRecycledLoadBalancer(kt, svc.client, svc.audit, basicInfoMap)
+		},
+	}
+}
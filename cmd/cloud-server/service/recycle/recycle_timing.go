@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"hcm/cmd/cloud-server/logics"
+	lblogic "hcm/cmd/cloud-server/logics/load-balancer"
 	"hcm/cmd/cloud-server/logics/recycle"
 	"hcm/pkg/api/core"
 	recyclerecord "hcm/pkg/api/core/recycle-record"
@@ -59,6 +60,10 @@ func RecycleTiming(c *client.ClientSet, state serviced.State, conf cc.Recycle, e
 
 	go r.recycleTiming(enumor.DiskCloudResType, r.recycleDiskWorker, conf)
 	go r.recycleTiming(enumor.CvmCloudResType, r.recycleCvmWorker, conf)
+	go r.recycleTiming(enumor.EipCloudResType, r.recycleEipWorker, conf)
+	go r.recycleTiming(enumor.LoadBalancerCloudResType, r.recycleLoadBalancerWorker, conf)
+	go r.recycleTiming(enumor.SecurityGroupCloudResType, r.recycleSecurityGroupWorker, conf)
+	go r.recycleTiming(enumor.DiskSnapshotCloudResType, r.recycleDiskSnapshotWorker, conf)
 }
 
 type recycleWorker func(kt *kit.Kit, info *types.CloudResourceBasicInfo) error
@@ -195,3 +200,44 @@ func (r *recycle) recycleCvmWorker(kt *kit.Kit, info *types.CloudResourceBasicIn
 	}
 	return nil
 }
+
+func (r *recycle) recycleEipWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+	res, err := r.logics.Eip.DeleteRecycledEip(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info})
+	if err != nil {
+		logs.Errorf("delete eip failed, err: %v, res: %+v, eip: %s, rid: %s", err, res, info.ID, kt.Rid)
+		return err
+	}
+	return nil
+}
+
+func (r *recycle) recycleLoadBalancerWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+	// 云上删除负载均衡时会一并删除其下的监听器及规则
+	res, err := lblogic.DeleteRecycledLoadBalancer(kt, r.client, r.logics.Audit,
+		map[string]types.CloudResourceBasicInfo{info.ID: *info})
+	if err != nil {
+		logs.Errorf("delete load balancer failed, err: %v, res: %+v, lb: %s, rid: %s", err, res, info.ID, kt.Rid)
+		return err
+	}
+	return nil
+}
+
+func (r *recycle) recycleSecurityGroupWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+	res, err := r.logics.SecurityGroup.DeleteRecycledSecurityGroup(kt,
+		map[string]types.CloudResourceBasicInfo{info.ID: *info})
+	if err != nil {
+		logs.Errorf("delete security group failed, err: %v, res: %+v, sg: %s, rid: %s", err, res, info.ID, kt.Rid)
+		return err
+	}
+	return nil
+}
+
+func (r *recycle) recycleDiskSnapshotWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+	res, err := r.logics.DiskSnapshot.DeleteRecycledDiskSnapshot(kt,
+		map[string]types.CloudResourceBasicInfo{info.ID: *info})
+	if err != nil {
+		logs.Errorf("delete disk snapshot failed, err: %v, res: %+v, snapshot: %s, rid: %s", err, res, info.ID,
+			kt.Rid)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package restag resource tag cloud server service.
+package restag
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitResourceTagService initial the resource tag service
+func InitResourceTagService(c *capability.Capability) {
+	svc := &resTagSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	// 资源下资源标签相关接口
+	h.Add("BatchAddResourceTag", http.MethodPost, "/resource_tags/batch/add", svc.BatchAddResourceTag)
+	h.Add("BatchRemoveResourceTag", http.MethodPost, "/resource_tags/batch/remove", svc.BatchRemoveResourceTag)
+	h.Add("ListResourceTag", http.MethodPost, "/resource_tags/list", svc.ListResourceTag)
+
+	// 业务下资源标签相关接口
+	h.Add("BatchAddBizResourceTag", http.MethodPost, "/bizs/{bk_biz_id}/resource_tags/batch/add",
+		svc.BatchAddBizResourceTag)
+	h.Add("BatchRemoveBizResourceTag", http.MethodPost, "/bizs/{bk_biz_id}/resource_tags/batch/remove",
+		svc.BatchRemoveBizResourceTag)
+	h.Add("ListBizResourceTag", http.MethodPost, "/bizs/{bk_biz_id}/resource_tags/list", svc.ListBizResourceTag)
+
+	h.Load(c.WebService)
+}
+
+type resTagSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
@@ -0,0 +1,352 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package restag
+
+import (
+	"fmt"
+
+	csrestag "hcm/pkg/api/cloud-server/resource-tag"
+	"hcm/pkg/api/core"
+	corerestag "hcm/pkg/api/core/cloud/resource-tag"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	hcrestag "hcm/pkg/api/hc-service/resource-tag"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// resTypeMetaMap 支持标签的资源类型对应的鉴权资源类型
+var resTypeMetaMap = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:          meta.Cvm,
+	enumor.DiskCloudResType:         meta.Disk,
+	enumor.VpcCloudResType:          meta.Vpc,
+	enumor.SubnetCloudResType:       meta.Subnet,
+	enumor.EipCloudResType:          meta.Eip,
+	enumor.LoadBalancerCloudResType: meta.LoadBalancer,
+}
+
+// resTypeFieldsMap 操作云上标签需要的资源基础字段，eip、vpc、子网的名称可能为空，不在此查询
+var resTypeFieldsMap = map[enumor.CloudResourceType][]string{
+	enumor.CvmCloudResType:          append(types.ResWithRecycleBasicFields, "cloud_id", "region", "zone", "name"),
+	enumor.DiskCloudResType:         append(types.ResWithRecycleBasicFields, "cloud_id", "region", "zone", "name"),
+	enumor.VpcCloudResType:          append(types.CommonBasicInfoFields, "cloud_id", "region"),
+	enumor.SubnetCloudResType:       append(types.CommonBasicInfoFields, "cloud_id", "region", "zone"),
+	enumor.EipCloudResType:          append(types.ResWithRecycleBasicFields, "cloud_id", "region"),
+	enumor.LoadBalancerCloudResType: append(types.ResWithRecycleBasicFields, "cloud_id", "region"),
+}
+
+// BatchAddResourceTag batch add or update tags of resources.
+func (svc *resTagSvc) BatchAddResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchAddResourceTag(cts, handler.ResOperateAuth)
+}
+
+// BatchAddBizResourceTag batch add or update tags of biz resources.
+func (svc *resTagSvc) BatchAddBizResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchAddResourceTag(cts, handler.BizOperateAuth)
+}
+
+func (svc *resTagSvc) batchAddResourceTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrestag.BatchAddReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	groups, err := svc.listTagResGroup(cts, validHandler, meta.Update, req.ResType, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		addReq := &hcrestag.BatchAddReq{
+			AccountID: group.accountID,
+			Region:    group.region,
+			ResType:   req.ResType,
+			Resources: group.resources,
+			Tags:      req.Tags,
+		}
+		if err = svc.batchAddCloudTag(cts.Kit, group.vendor, addReq); err != nil {
+			logs.Errorf("[%s] batch add resource tag failed, err: %v, req: %+v, rid: %s", group.vendor, err, addReq,
+				cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// BatchRemoveResourceTag batch remove tags of resources.
+func (svc *resTagSvc) BatchRemoveResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchRemoveResourceTag(cts, handler.ResOperateAuth)
+}
+
+// BatchRemoveBizResourceTag batch remove tags of biz resources.
+func (svc *resTagSvc) BatchRemoveBizResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchRemoveResourceTag(cts, handler.BizOperateAuth)
+}
+
+func (svc *resTagSvc) batchRemoveResourceTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrestag.BatchRemoveReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	groups, err := svc.listTagResGroup(cts, validHandler, meta.Update, req.ResType, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		removeReq := &hcrestag.BatchRemoveReq{
+			AccountID: group.accountID,
+			Region:    group.region,
+			ResType:   req.ResType,
+			Resources: group.resources,
+			TagKeys:   req.TagKeys,
+		}
+		if err = svc.batchRemoveCloudTag(cts.Kit, group.vendor, removeReq); err != nil {
+			logs.Errorf("[%s] batch remove resource tag failed, err: %v, req: %+v, rid: %s", group.vendor, err,
+				removeReq, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ListResourceTag list tags of resources.
+func (svc *resTagSvc) ListResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResourceTag(cts, handler.ResOperateAuth)
+}
+
+// ListBizResourceTag list tags of biz resources.
+func (svc *resTagSvc) ListBizResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResourceTag(cts, handler.BizOperateAuth)
+}
+
+func (svc *resTagSvc) listResourceTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrestag.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: req.ResType,
+		IDs:          req.IDs,
+		Fields:       types.CommonBasicInfoFields,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: resTypeMetaMap[req.ResType], Action: meta.Find, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_type", req.ResType),
+			tools.RuleIn("res_id", req.IDs),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	details := make([]corerestag.ResourceTag, 0)
+	for {
+		result, err := svc.client.DataService().Global.ResourceTag.List(cts.Kit, listReq)
+		if err != nil {
+			logs.Errorf("list resource tag failed, err: %v, req: %+v, rid: %s", err, listReq, cts.Kit.Rid)
+			return nil, err
+		}
+
+		details = append(details, result.Details...)
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return &core.ListResultT[corerestag.ResourceTag]{Details: details}, nil
+}
+
+// tagResGroup 同一账号、地域下的待操作标签资源
+type tagResGroup struct {
+	vendor    enumor.Vendor
+	accountID string
+	region    string
+	resources []hcrestag.TagResource
+}
+
+// listTagResGroup 查询资源信息并鉴权，按照账号和地域对资源分组
+func (svc *resTagSvc) listTagResGroup(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	action meta.Action, resType enumor.CloudResourceType, ids []string) ([]*tagResGroup, error) {
+
+	ids = slice.Unique(ids)
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: resType,
+		IDs:          ids,
+		Fields:       resTypeFieldsMap[resType],
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(basicInfoMap) != len(ids) {
+		return nil, errf.Newf(errf.RecordNotFound, "some %s not found, ids: %v", resType, ids)
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: resTypeMetaMap[resType],
+		Action: action, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	if resType == enumor.EipCloudResType {
+		if err = svc.fillEipName(cts.Kit, basicInfoMap); err != nil {
+			return nil, err
+		}
+	}
+
+	groupMap := make(map[string]*tagResGroup)
+	groups := make([]*tagResGroup, 0)
+	for _, id := range ids {
+		info := basicInfoMap[id]
+		if err = corerestag.ValidateVendorResType(info.Vendor, resType); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		key := fmt.Sprintf("%s/%s", info.AccountID, info.Region)
+		group, exists := groupMap[key]
+		if !exists {
+			group = &tagResGroup{vendor: info.Vendor, accountID: info.AccountID, region: info.Region}
+			groupMap[key] = group
+			groups = append(groups, group)
+		}
+		group.resources = append(group.resources, hcrestag.TagResource{
+			ID:      info.ID,
+			CloudID: info.CloudID,
+			Name:    info.Name,
+			Zone:    info.Zone,
+		})
+	}
+
+	return groups, nil
+}
+
+// fillEipName 补充eip名称，gcp通过名称操作eip，eip名称可能为空，因此单独查询
+func (svc *resTagSvc) fillEipName(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo) error {
+	ids := make([]string, 0, len(infoMap))
+	for id := range infoMap {
+		ids = append(ids, id)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   &core.BasePage{Limit: constant.BatchOperationMaxLimit},
+		Fields: []string{"id", "name"},
+	}
+	result, err := svc.client.DataService().Global.ListEip(kt, listReq)
+	if err != nil {
+		logs.Errorf("list eip failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	for _, one := range result.Details {
+		info, exists := infoMap[one.ID]
+		if !exists {
+			continue
+		}
+		info.Name = converter.PtrToVal(one.Name)
+		infoMap[one.ID] = info
+	}
+
+	return nil
+}
+
+// hcTagClient hc-service各云厂商资源标签客户端
+type hcTagClient interface {
+	BatchAdd(kt *kit.Kit, req *hcrestag.BatchAddReq) error
+	BatchRemove(kt *kit.Kit, req *hcrestag.BatchRemoveReq) error
+}
+
+func (svc *resTagSvc) hcTagClient(vendor enumor.Vendor) (hcTagClient, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return svc.client.HCService().TCloud.ResourceTag, nil
+	case enumor.Aws:
+		return svc.client.HCService().Aws.ResourceTag, nil
+	case enumor.HuaWei:
+		return svc.client.HCService().HuaWei.ResourceTag, nil
+	case enumor.Gcp:
+		return svc.client.HCService().Gcp.ResourceTag, nil
+	case enumor.Azure:
+		return svc.client.HCService().Azure.ResourceTag, nil
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support resource tag", vendor)
+	}
+}
+
+func (svc *resTagSvc) batchAddCloudTag(kt *kit.Kit, vendor enumor.Vendor, req *hcrestag.BatchAddReq) error {
+	cli, err := svc.hcTagClient(vendor)
+	if err != nil {
+		return err
+	}
+
+	return cli.BatchAdd(kt, req)
+}
+
+func (svc *resTagSvc) batchRemoveCloudTag(kt *kit.Kit, vendor enumor.Vendor, req *hcrestag.BatchRemoveReq) error {
+	cli, err := svc.hcTagClient(vendor)
+	if err != nil {
+		return err
+	}
+
+	return cli.BatchRemove(kt, req)
+}
@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// AnalyzeSecurityGroupExposure analyze cvm exposure by security group rules.
+func (svc *securityGroupSvc) AnalyzeSecurityGroupExposure(cts *rest.Contexts) (interface{}, error) {
+	return svc.analyzeSecurityGroupExposure(cts, handler.ListResourceAuthRes)
+}
+
+// AnalyzeBizSecurityGroupExposure analyze biz cvm exposure by security group rules.
+func (svc *securityGroupSvc) AnalyzeBizSecurityGroupExposure(cts *rest.Contexts) (interface{}, error) {
+	return svc.analyzeSecurityGroupExposure(cts, handler.ListBizAuthRes)
+}
+
+func (svc *securityGroupSvc) analyzeSecurityGroupExposure(cts *rest.Contexts,
+	authHandler handler.ListAuthResHandler) (interface{}, error) {
+
+	req := new(cloudserver.SGExposureReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 暴露面分析以主机为维度，按主机的查看权限过滤
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Cvm, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &cloudserver.SGExposureResult{Source: req.Source, Details: make([]cloudserver.CvmExposure, 0),
+			PortRanges: make([]cloudserver.PortRangeExposure, 0)}, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	cvmRes, err := svc.client.DataService().Global.Cvm.ListCvm(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.sgLgc.AnalyzeExposure(cts.Kit, cvmRes.Details, req)
+}
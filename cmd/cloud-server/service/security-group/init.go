@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	sglogic "hcm/cmd/cloud-server/logics/security-group"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -35,6 +36,7 @@ func InitSecurityGroupService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		sgLgc:      c.Logics.SecurityGroup,
 	}
 
 	h := rest.NewHandler()
@@ -72,6 +74,14 @@ func InitSecurityGroupService(c *capability.Capability) {
 		"/security_group/{id}/common/list", svc.ListResourceIdBySecurityGroup)
 	h.Add("ListCvmIdBySecurityGroup", http.MethodPost,
 		"/security_group/{id}/cvm/list", svc.ListCvmIdBySecurityGroup)
+	h.Add("AnalyzeSecurityGroupExposure", http.MethodPost, "/security_groups/exposure/analyze",
+		svc.AnalyzeSecurityGroupExposure)
+
+	// recycle operation in res
+	h.Add("RecycleSecurityGroup", http.MethodPost, "/security_groups/recycle", svc.RecycleSecurityGroup)
+	h.Add("RecoverSecurityGroup", http.MethodPost, "/security_groups/recover", svc.RecoverSecurityGroup)
+	h.Add("BatchDeleteRecycledSecurityGroup", http.MethodDelete, "/recycled/security_groups/batch",
+		svc.BatchDeleteRecycledSecurityGroup)
 
 	bizService(h, svc)
 	initSecurityGroupServiceHooks(svc, h)
@@ -124,10 +134,41 @@ func bizService(h *rest.Handler, svc *securityGroupSvc) {
 		"/bizs/{bk_biz_id}/security_group/{id}/common/list", svc.ListBizResourceIDBySecurityGroup)
 	h.Add("ListBizCvmIdBySecurityGroup", http.MethodPost,
 		"/bizs/{bk_biz_id}/security_group/{id}/cvm/list", svc.ListBizCvmIdBySecurityGroup)
+	h.Add("AnalyzeBizSecurityGroupExposure", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/exposure/analyze",
+		svc.AnalyzeBizSecurityGroupExposure)
+
+	// security group rule template in biz
+	h.Add("CreateBizSGRuleTemplate", http.MethodPost, "/bizs/{bk_biz_id}/security_group_rule_templates/create",
+		svc.CreateBizSGRuleTemplate)
+	h.Add("ListBizSGRuleTemplate", http.MethodPost, "/bizs/{bk_biz_id}/security_group_rule_templates/list",
+		svc.ListBizSGRuleTemplate)
+	h.Add("UpdateBizSGRuleTemplate", http.MethodPatch, "/bizs/{bk_biz_id}/security_group_rule_templates/{id}",
+		svc.UpdateBizSGRuleTemplate)
+	h.Add("BatchDeleteBizSGRuleTemplate", http.MethodDelete, "/bizs/{bk_biz_id}/security_group_rule_templates/batch",
+		svc.BatchDeleteBizSGRuleTemplate)
+	h.Add("BindBizSGRuleTemplate", http.MethodPost, "/bizs/{bk_biz_id}/security_group_rule_templates/{id}/bind",
+		svc.BindBizSGRuleTemplate)
+	h.Add("UnbindBizSGRuleTemplate", http.MethodPost, "/bizs/{bk_biz_id}/security_group_rule_templates/{id}/unbind",
+		svc.UnbindBizSGRuleTemplate)
+	h.Add("ListBizSGRuleTemplateRel", http.MethodPost,
+		"/bizs/{bk_biz_id}/security_group_rule_templates/{id}/rels/list", svc.ListBizSGRuleTemplateRel)
+	h.Add("ApplyBizSGRuleTemplate", http.MethodPost, "/bizs/{bk_biz_id}/security_group_rule_templates/{id}/apply",
+		svc.ApplyBizSGRuleTemplate)
+	h.Add("DriftBizSGRuleTemplate", http.MethodPost, "/bizs/{bk_biz_id}/security_group_rule_templates/{id}/drift",
+		svc.DriftBizSGRuleTemplate)
+
+	// recycle operation in biz
+	h.Add("RecycleBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/recycle",
+		svc.RecycleBizSecurityGroup)
+	h.Add("RecoverBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/recover",
+		svc.RecoverBizSecurityGroup)
+	h.Add("BatchDeleteBizRecycledSecurityGroup", http.MethodDelete, "/bizs/{bk_biz_id}/recycled/security_groups/batch",
+		svc.BatchDeleteBizRecycledSecurityGroup)
 }
 
 type securityGroupSvc struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	sgLgc      sglogic.Interface
 }
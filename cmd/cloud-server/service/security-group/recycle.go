@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	sglogic "hcm/cmd/cloud-server/logics/security-group"
+	"hcm/cmd/cloud-server/service/common"
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// RecycleSecurityGroup recycle security group.
+func (svc *securityGroupSvc) RecycleSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleSecurityGroup(cts, handler.ResOperateAuth)
+}
+
+// RecycleBizSecurityGroup recycle biz security group.
+func (svc *securityGroupSvc) RecycleBizSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleSecurityGroup(cts, handler.BizOperateAuth)
+}
+
+func (svc *securityGroupSvc) recycleSecurityGroup(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(proto.SecurityGroupRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	infos := make([]common.RecycleInfo, 0, len(req.Infos))
+	for _, info := range req.Infos {
+		infos = append(infos, common.RecycleInfo{ID: info.ID, Options: info.SecurityGroupRecycleOptions})
+	}
+
+	return svc.recycler().Recycle(cts, validHandler, infos)
+}
+
+// RecoverSecurityGroup recover security group.
+func (svc *securityGroupSvc) RecoverSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverSecurityGroup(cts, handler.ResOperateAuth)
+}
+
+// RecoverBizSecurityGroup recover biz security group.
+func (svc *securityGroupSvc) RecoverBizSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverSecurityGroup(cts, handler.BizOperateAuth)
+}
+
+func (svc *securityGroupSvc) recoverSecurityGroup(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(proto.SecurityGroupRecoverReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.recycler().Recover(cts, validHandler, req.RecordIDs)
+}
+
+// BatchDeleteRecycledSecurityGroup batch delete recycled security groups.
+func (svc *securityGroupSvc) BatchDeleteRecycledSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledSecurityGroup(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizRecycledSecurityGroup batch delete biz recycled security groups.
+func (svc *securityGroupSvc) BatchDeleteBizRecycledSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledSecurityGroup(cts, handler.BizOperateAuth)
+}
+
+func (svc *securityGroupSvc) batchDeleteRecycledSecurityGroup(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(proto.SecurityGroupDeleteRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.recycler().BatchDeleteRecycled(cts, validHandler, req.RecordIDs)
+}
+
+func (svc *securityGroupSvc) recycler() *common.ResRecycler {
+	return &common.ResRecycler{
+		Client:          svc.client,
+		Authorizer:      svc.authorizer,
+		Audit:           svc.audit,
+		CloudResType:    enumor.SecurityGroupCloudResType,
+		AuditResType:    enumor.SecurityGroupAuditResType,
+		IamResType:      meta.SecurityGroup,
+		BasicInfoFields: append(types.CommonBasicInfoFields, "recycle_status"),
+		ValidateRecycle: func(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) error {
+			return sglogic.ValidateNotBound(kt, svc.client.DataService(), converter.MapKeyToStringSlice(basicInfoMap))
+		},
+		DeleteRecycled: svc.sgLgc.DeleteRecycledSecurityGroup,
+	}
+}
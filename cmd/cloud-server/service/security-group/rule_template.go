@@ -0,0 +1,555 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+
+	sglogic "hcm/cmd/cloud-server/logics/security-group"
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	coresgruletpl "hcm/pkg/api/core/cloud/sg-rule-template"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dssgruletpl "hcm/pkg/api/data-service/cloud/sg-rule-template"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/counter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// CreateBizSGRuleTemplate create biz security group rule template.
+func (svc *securityGroupSvc) CreateBizSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(cloudserver.SGRuleTemplateCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err = handler.BizOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: meta.SGRuleTemplate, Action: meta.Create, BasicInfo: &types.CloudResourceBasicInfo{BkBizID: bizID}})
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &dssgruletpl.BatchCreateReq{
+		Templates: []dssgruletpl.TemplateCreate{{Name: req.Name, BkBizID: bizID, Rules: req.Rules, Memo: req.Memo}},
+	}
+	result, err := svc.client.DataService().Global.SGRuleTemplate.BatchCreate(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create security group rule template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, fmt.Errorf("create security group rule template but return ids: %v", result.IDs)
+	}
+
+	return core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// ListBizSGRuleTemplate list biz security group rule template.
+func (svc *securityGroupSvc) ListBizSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, noPermFlag, err := handler.ListBizAuthRes(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.SGRuleTemplate, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &dssgruletpl.ListResp{Count: 0, Details: make([]coresgruletpl.SGRuleTemplate, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.SGRuleTemplate.List(cts.Kit, req)
+}
+
+// UpdateBizSGRuleTemplate update biz security group rule template.
+func (svc *securityGroupSvc) UpdateBizSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(cloudserver.SGRuleTemplateUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.authBizSGRuleTemplate(cts, id, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if req.Rules != nil {
+		if err := svc.validateBoundVendor(cts.Kit, id, req.Rules); err != nil {
+			return nil, err
+		}
+	}
+
+	updateReq := &dssgruletpl.BatchUpdateReq{
+		Templates: []dssgruletpl.TemplateUpdate{{ID: id, Name: req.Name, Rules: req.Rules, Memo: req.Memo}},
+	}
+	if err := svc.client.DataService().Global.SGRuleTemplate.BatchUpdate(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update security group rule template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteBizSGRuleTemplate batch delete biz security group rule template, bound rels are deleted too.
+func (svc *securityGroupSvc) BatchDeleteBizSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", req.IDs),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "bk_biz_id"},
+	}
+	result, err := svc.client.DataService().Global.SGRuleTemplate.List(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list security group rule template failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != len(req.IDs) {
+		return nil, errf.New(errf.RecordNotFound, "some security group rule templates not found")
+	}
+
+	basicInfos := make(map[string]types.CloudResourceBasicInfo, len(result.Details))
+	for _, one := range result.Details {
+		basicInfos[one.ID] = types.CloudResourceBasicInfo{ID: one.ID, BkBizID: one.BkBizID}
+	}
+	err = handler.BizOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: meta.SGRuleTemplate, Action: meta.Delete, BasicInfos: basicInfos})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.SGRuleTemplate.BatchDelete(cts.Kit, req); err != nil {
+		logs.Errorf("delete security group rule template failed, err: %v, ids: %v, rid: %s", err, req.IDs,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BindBizSGRuleTemplate bind security groups to biz security group rule template.
+func (svc *securityGroupSvc) BindBizSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(cloudserver.SGRuleTemplateBindReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tpl, err := svc.authBizSGRuleTemplate(cts, id, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	sgInfos, err := svc.authBizSGForRuleTemplate(cts, req.SecurityGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range sgInfos {
+		for _, rule := range tpl.Rules {
+			if err := rule.ValidateVendor(info.Vendor); err != nil {
+				return nil, errf.Newf(errf.InvalidParameter, "security group %s can not bind template, err: %v",
+					info.ID, err)
+			}
+		}
+	}
+
+	rels, err := svc.listSGRuleTemplateRels(cts.Kit, tools.ExpressionAnd(tools.RuleEqual("template_id", id),
+		tools.RuleIn("security_group_id", req.SecurityGroupIDs)))
+	if err != nil {
+		return nil, err
+	}
+	boundSGMap := make(map[string]struct{}, len(rels))
+	for _, rel := range rels {
+		boundSGMap[rel.SecurityGroupID] = struct{}{}
+	}
+
+	createReq := &dssgruletpl.RelBatchCreateReq{Rels: make([]dssgruletpl.RelCreate, 0, len(sgInfos))}
+	for _, sgID := range slice.Unique(req.SecurityGroupIDs) {
+		if _, exists := boundSGMap[sgID]; exists {
+			continue
+		}
+		createReq.Rels = append(createReq.Rels, dssgruletpl.RelCreate{
+			TemplateID:      id,
+			SecurityGroupID: sgID,
+			Vendor:          sgInfos[sgID].Vendor,
+			AccountID:       sgInfos[sgID].AccountID,
+		})
+	}
+
+	if len(createReq.Rels) == 0 {
+		return &core.BatchCreateResult{IDs: make([]string, 0)}, nil
+	}
+
+	result, err := svc.client.DataService().Global.SGRuleTemplate.BatchCreateRel(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("bind security group rule template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UnbindBizSGRuleTemplate unbind security groups from biz security group rule template, rules created by
+// template are not deleted.
+func (svc *securityGroupSvc) UnbindBizSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(cloudserver.SGRuleTemplateBindReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.authBizSGRuleTemplate(cts, id, meta.Update); err != nil {
+		return nil, err
+	}
+
+	rels, err := svc.listSGRuleTemplateRels(cts.Kit, tools.ExpressionAnd(tools.RuleEqual("template_id", id),
+		tools.RuleIn("security_group_id", req.SecurityGroupIDs)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rels) == 0 {
+		return nil, nil
+	}
+
+	delReq := &dssgruletpl.RelBatchDeleteReq{BatchDeleteReq: core.BatchDeleteReq{
+		IDs: slice.Map(rels, func(rel coresgruletpl.SGRuleTemplateRel) string { return rel.ID }),
+	}}
+	if err := svc.client.DataService().Global.SGRuleTemplate.BatchDeleteRel(cts.Kit, delReq); err != nil {
+		logs.Errorf("unbind security group rule template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizSGRuleTemplateRel list security groups bound to biz security group rule template.
+func (svc *securityGroupSvc) ListBizSGRuleTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.authBizSGRuleTemplate(cts, id, meta.Find); err != nil {
+		return nil, err
+	}
+
+	expr, err := tools.And(tools.EqualExpression("template_id", id), req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.SGRuleTemplate.ListRel(cts.Kit, req)
+}
+
+// ApplyBizSGRuleTemplate apply biz security group rule template to bound security groups by async flow, only rules
+// missing in security group are created.
+func (svc *securityGroupSvc) ApplyBizSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(cloudserver.SGRuleTemplateApplyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tpl, err := svc.authBizSGRuleTemplate(cts, id, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = svc.authBizSGForRuleTemplate(cts, req.SecurityGroupIDs); err != nil {
+		return nil, err
+	}
+
+	sgIDs := slice.Unique(req.SecurityGroupIDs)
+	rels, err := svc.listSGRuleTemplateRels(cts.Kit, tools.ExpressionAnd(tools.RuleEqual("template_id", id),
+		tools.RuleIn("security_group_id", sgIDs)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rels) != len(sgIDs) {
+		return nil, errf.Newf(errf.InvalidParameter, "security groups should be bound to template %s first", id)
+	}
+
+	result := &cloudserver.SGRuleTemplateApplyResult{SkippedSecurityGroupIDs: make([]string, 0)}
+	tasks := make([]ts.CustomFlowTask, 0, len(rels))
+	applying := make([]dssgruletpl.RelUpdate, 0, len(rels))
+	nextID := counter.NewNumStringCounter(1, 10)
+	for _, rel := range rels {
+		// 应用模板只补齐缺失的规则，不删除安全组中模板以外的规则
+		missing, _, err := svc.sgLgc.DiffRuleTemplate(cts.Kit, rel.Vendor, rel.SecurityGroupID, tpl.Rules)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(missing) == 0 {
+			result.SkippedSecurityGroupIDs = append(result.SkippedSecurityGroupIDs, rel.SecurityGroupID)
+		}
+
+		opt, err := sglogic.NewSGRuleTemplateApplyOpt(rel, tpl.Version, missing)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   action.ActIDType(nextID()),
+			ActionName: enumor.ActionApplySGRuleTemplate,
+			Params:     opt,
+		})
+		applying = append(applying, dssgruletpl.RelUpdate{ID: rel.ID, ApplyStatus: enumor.SGRuleTemplateApplying})
+	}
+
+	err = svc.client.DataService().Global.SGRuleTemplate.BatchUpdateRel(cts.Kit,
+		&dssgruletpl.RelBatchUpdateReq{Rels: applying})
+	if err != nil {
+		logs.Errorf("update security group rule template rel to applying failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	flowReq := &ts.AddCustomFlowReq{
+		Name:  enumor.FlowApplySGRuleTemplate,
+		Tasks: tasks,
+	}
+	flow, err := svc.client.TaskServer().CreateCustomFlow(cts.Kit, flowReq)
+	if err != nil {
+		logs.Errorf("call taskserver to create apply security group rule template flow failed, err: %v, rid: %s",
+			err, cts.Kit.Rid)
+		svc.resetApplyingRels(cts.Kit, applying, err)
+		return nil, err
+	}
+	result.FlowID = flow.ID
+
+	return result, nil
+}
+
+// DriftBizSGRuleTemplate report whether security groups bound to biz security group rule template have diverged
+// from template since last sync.
+func (svc *securityGroupSvc) DriftBizSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(cloudserver.SGRuleTemplateDriftReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tpl, err := svc.authBizSGRuleTemplate(cts, id, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{Filter: tools.EqualExpression("template_id", id), Page: req.Page}
+	rels, err := svc.client.DataService().Global.SGRuleTemplate.ListRel(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list security group rule template rel failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details, err := svc.sgLgc.SGRuleTemplateDrift(cts.Kit, tpl, rels.Details)
+	if err != nil {
+		logs.Errorf("get security group rule template drift failed, err: %v, id: %s, rid: %s", err, id,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &cloudserver.SGRuleTemplateDriftResult{TemplateID: tpl.ID, TemplateVersion: tpl.Version,
+		Details: details}, nil
+}
+
+// authBizSGRuleTemplate get security group rule template and authorize it in url biz.
+func (svc *securityGroupSvc) authBizSGRuleTemplate(cts *rest.Contexts, id string, action meta.Action) (
+	*coresgruletpl.SGRuleTemplate, error) {
+
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", id), Page: core.NewDefaultBasePage()}
+	result, err := svc.client.DataService().Global.SGRuleTemplate.List(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("get security group rule template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "security group rule template %s not found", id)
+	}
+	tpl := result.Details[0]
+
+	err = handler.BizOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: meta.SGRuleTemplate, Action: action,
+		BasicInfo: &types.CloudResourceBasicInfo{ID: tpl.ID, BkBizID: tpl.BkBizID}})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tpl, nil
+}
+
+// authBizSGForRuleTemplate authorize security groups update permission, and check security groups vendor.
+func (svc *securityGroupSvc) authBizSGForRuleTemplate(cts *rest.Contexts, sgIDs []string) (
+	map[string]types.CloudResourceBasicInfo, error) {
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.SecurityGroupCloudResType,
+		IDs:          slice.Unique(sgIDs),
+		Fields:       append(types.CommonBasicInfoFields, "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handler.BizOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: meta.SecurityGroup, Action: meta.Update, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range basicInfoMap {
+		if !slice.IsItemInSlice(coresgruletpl.SupportedVendors, info.Vendor) {
+			return nil, errf.Newf(errf.InvalidParameter, "security group %s vendor %s not support rule template",
+				info.ID, info.Vendor)
+		}
+	}
+
+	return basicInfoMap, nil
+}
+
+// validateBoundVendor check new rules can be applied to all bound security groups.
+func (svc *securityGroupSvc) validateBoundVendor(kt *kit.Kit, id string, rules []coresgruletpl.Rule) error {
+	rels, err := svc.listSGRuleTemplateRels(kt, tools.EqualExpression("template_id", id))
+	if err != nil {
+		return err
+	}
+
+	vendors := slice.Unique(slice.Map(rels, func(rel coresgruletpl.SGRuleTemplateRel) enumor.Vendor {
+		return rel.Vendor
+	}))
+	for _, vendor := range vendors {
+		for _, rule := range rules {
+			if err := rule.ValidateVendor(vendor); err != nil {
+				return errf.Newf(errf.InvalidParameter, "template is bound to %s security group, err: %v", vendor, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (svc *securityGroupSvc) listSGRuleTemplateRels(kt *kit.Kit, expr *filter.Expression) (
+	[]coresgruletpl.SGRuleTemplateRel, error) {
+
+	result := make([]coresgruletpl.SGRuleTemplateRel, 0)
+	listReq := &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+	for {
+		rels, err := svc.client.DataService().Global.SGRuleTemplate.ListRel(kt, listReq)
+		if err != nil {
+			logs.Errorf("list security group rule template rel failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, rels.Details...)
+		if uint(len(rels.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// resetApplyingRels set applying rels to failed when apply flow create failed.
+func (svc *securityGroupSvc) resetApplyingRels(kt *kit.Kit, rels []dssgruletpl.RelUpdate, cause error) {
+	msg := cause.Error()
+	for idx := range rels {
+		rels[idx].ApplyStatus = enumor.SGRuleTemplateApplyFailed
+		rels[idx].ApplyMessage = &msg
+	}
+
+	err := svc.client.DataService().Global.SGRuleTemplate.BatchUpdateRel(kt, &dssgruletpl.RelBatchUpdateReq{Rels: rels})
+	if err != nil {
+		logs.Errorf("reset security group rule template rel status failed, err: %v, rid: %s", err, kt.Rid)
+	}
+}
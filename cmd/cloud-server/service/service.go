@@ -40,20 +40,24 @@ import (
 	"hcm/cmd/cloud-server/service/audit"
 	bandwidthpackage "hcm/cmd/cloud-server/service/bandwidth-package"
 	"hcm/cmd/cloud-server/service/bill"
+	bizassign "hcm/cmd/cloud-server/service/biz-assign-rule"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/cert"
 	cloudselection "hcm/cmd/cloud-server/service/cloud-selection"
 	"hcm/cmd/cloud-server/service/cvm"
 	"hcm/cmd/cloud-server/service/disk"
+	disksnapshot "hcm/cmd/cloud-server/service/disk-snapshot"
 	"hcm/cmd/cloud-server/service/eip"
 	"hcm/cmd/cloud-server/service/firewall"
 	"hcm/cmd/cloud-server/service/image"
 	instancetype "hcm/cmd/cloud-server/service/instance-type"
+	keypair "hcm/cmd/cloud-server/service/key-pair"
 	loadbalancer "hcm/cmd/cloud-server/service/load-balancer"
 	networkinterface "hcm/cmd/cloud-server/service/network-interface"
 	"hcm/cmd/cloud-server/service/recycle"
 	"hcm/cmd/cloud-server/service/region"
 	resourcegroup "hcm/cmd/cloud-server/service/resource-group"
+	restag "hcm/cmd/cloud-server/service/resource-tag"
 	routetable "hcm/cmd/cloud-server/service/route-table"
 	securitygroup "hcm/cmd/cloud-server/service/security-group"
 	subaccount "hcm/cmd/cloud-server/service/sub-account"
@@ -98,6 +102,8 @@ type Service struct {
 	itsmCli   itsm.Client
 	bkBaseCli bkbase.Client
 	cmsiCli   cmsi.Client
+	// state 用于判断当前实例是否为主节点，定时任务只在主节点执行
+	state serviced.State
 }
 
 // NewService create a service instance.
@@ -106,6 +112,7 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	svr.state = sd
 
 	etcdCfg, err := cc.CloudServer().Service.Etcd.ToConfig()
 	if err != nil {
@@ -121,6 +128,10 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		go sync.CloudResourceSync(interval, sd, apiClientSet)
 	}
 
+	if cc.CloudServer().CloudResource.Sync.IncrementalSync.Enable {
+		go sync.CloudResourceIncrementalSync(cc.CloudServer().CloudResource.Sync.IncrementalSync, sd, apiClientSet)
+	}
+
 	if cc.CloudServer().BillConfig.Enable {
 		interval := time.Duration(cc.CloudServer().BillConfig.SyncIntervalMin) * time.Minute
 		go bill.CloudBillConfigCreate(interval, sd, apiClientSet)
@@ -130,8 +141,6 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 
 	go appcvm.TimingHandleDeliverApplication(svr.client, 2*time.Second)
 
-	go task.TimingHandleTaskMgmtState(apiClientSet, sd, time.Second)
-
 	return svr, nil
 }
 
@@ -209,9 +218,7 @@ func getCloudClientSvr(sd serviced.ServiceDiscover) (*client.ClientSet, esb.Clie
 
 // newCipherFromConfig 根据配置文件里的加密配置，选择配置的算法并生成对应的加解密器
 func newCipherFromConfig(cryptoConfig cc.Crypto) (cryptography.Crypto, error) {
-	// TODO: 目前只支持国际加密，还未支持中国国家商业加密，待后续支持再调整
-	cfg := cryptoConfig.AesGcm
-	return cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+	return cryptography.NewFromConfig(cryptoConfig)
 }
 
 // ListenAndServeRest listen and serve the restful server
@@ -287,6 +294,7 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 		ItsmCli:    s.itsmCli,
 		BKBaseCli:  s.bkBaseCli,
 		CmsiCli:    s.cmsiCli,
+		State:      s.state,
 	}
 
 	account.InitAccountService(c)
@@ -294,11 +302,14 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	firewall.InitFirewallService(c)
 	vpc.InitVpcService(c)
 	disk.InitDiskService(c)
+	disksnapshot.InitDiskSnapshotService(c)
+	keypair.InitKeyPairService(c)
 	subnet.InitSubnetService(c)
 	image.InitImageService(c)
 	routetable.InitRouteTableService(c)
 	cvm.InitCvmService(c)
 	resourcegroup.InitResourceGroupService(c)
+	restag.InitResourceTagService(c)
 	zone.InitZoneService(c)
 	region.InitRegionService(c)
 	eip.InitEipService(c)
@@ -309,6 +320,7 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	application.InitApplicationService(c, bkHcmUrl)
 	audit.InitService(c)
 	assign.InitService(c)
+	bizassign.InitService(c)
 	recycle.InitService(c)
 	bill.InitBillService(c)
 
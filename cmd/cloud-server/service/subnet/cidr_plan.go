@@ -0,0 +1,461 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package subnet
+
+import (
+	"net"
+	"sort"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	routetable "hcm/pkg/api/data-service/cloud/route-table"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/cidr"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/maps"
+)
+
+// tcloudPeeringGatewayType 腾讯云对等连接路由的下一跳类型
+const tcloudPeeringGatewayType = "PEERCONNECTION"
+
+// PlanSubnetCidr plan subnet cidr.
+func (svc *subnetSvc) PlanSubnetCidr(cts *rest.Contexts) (interface{}, error) {
+	return svc.planSubnetCidr(cts, handler.ResOperateAuth)
+}
+
+// PlanBizSubnetCidr plan biz subnet cidr.
+func (svc *subnetSvc) PlanBizSubnetCidr(cts *rest.Contexts) (interface{}, error) {
+	return svc.planSubnetCidr(cts, handler.BizOperateAuth)
+}
+
+// planSubnetCidr 根据vpc网段和已同步的子网，为待创建的子网推荐不重叠的网段。除了vpc下已有子网，
+// 还会避开vpc所属业务的预留网段、同一管控区域下其他vpc的网段，以及对等连接路由的目的网段。
+func (svc *subnetSvc) planSubnetCidr(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cloudserver.SubnetCidrPlanReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.VpcCloudResType,
+		req.VpcID, "vendor", "account_id", "bk_biz_id")
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Vpc,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	vpcs, err := svc.listVpcCidr(cts.Kit, basicInfo.Vendor, tools.EqualExpression("id", req.VpcID))
+	if err != nil {
+		return nil, err
+	}
+	if len(vpcs) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "vpc: %s not found", req.VpcID)
+	}
+	vpc := vpcs[0]
+
+	vpcNets := vpc.nets(req.IPType)
+	if len(vpcNets) == 0 {
+		return nil, errf.Newf(errf.InvalidParameter, "vpc: %s has no %s cidr", req.VpcID, req.IPType)
+	}
+
+	maskLen := req.MaskLen
+	if req.HostCount != 0 {
+		if maskLen, err = cidr.HostNumToMasklen(req.IPType, req.HostCount); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	used, err := svc.listVpcSubnetNets(cts.Kit, req.VpcID)
+	if err != nil {
+		return nil, err
+	}
+
+	occupied, err := svc.listOccupiedCidr(cts.Kit, vpc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cloudserver.SubnetCidrPlanResult{
+		VpcCidrs:  make([]string, 0, len(vpcNets)),
+		MaskLen:   maskLen,
+		Cidrs:     make([]string, 0),
+		Conflicts: make([]cloudserver.SubnetCidrConflict, 0),
+	}
+	for _, vpcNet := range vpcNets {
+		result.VpcCidrs = append(result.VpcCidrs, vpcNet.String())
+		for _, one := range occupied {
+			if !cidr.IsOverlapped(vpcNet, one.net) {
+				continue
+			}
+			result.Conflicts = append(result.Conflicts, cloudserver.SubnetCidrConflict{
+				Source:  one.source,
+				ResID:   one.resID,
+				Cidr:    one.net.String(),
+				VpcCidr: vpcNet.String(),
+			})
+		}
+	}
+
+	for _, one := range occupied {
+		used = append(used, one.net)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 1
+	}
+	for _, vpcNet := range vpcNets {
+		if len(result.Cidrs) >= limit {
+			break
+		}
+
+		// 掩码长度短于vpc网段的无法在该网段中分配
+		if ones, _ := vpcNet.Mask.Size(); maskLen < ones {
+			continue
+		}
+
+		nets, err := cidr.AvailableNets(vpcNet, used, maskLen, limit-len(result.Cidrs))
+		if err != nil {
+			logs.Errorf("plan subnet cidr in %s failed, err: %v, rid: %s", vpcNet.String(), err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range nets {
+			result.Cidrs = append(result.Cidrs, one.String())
+		}
+	}
+
+	return result, nil
+}
+
+// vpcCidrInfo vpc的网段信息
+type vpcCidrInfo struct {
+	ID        string
+	Vendor    enumor.Vendor
+	BkCloudID int64
+	BkBizID   int64
+	Cidrs     map[enumor.IPAddressType][]string
+}
+
+func (v *vpcCidrInfo) add(ipType enumor.IPAddressType, cidrStr string) {
+	if v.Cidrs == nil {
+		v.Cidrs = make(map[enumor.IPAddressType][]string)
+	}
+	v.Cidrs[ipType] = append(v.Cidrs[ipType], cidrStr)
+}
+
+// nets 返回vpc下指定ip地址类型的网段，无法解析的网段会被忽略
+func (v *vpcCidrInfo) nets(ipType enumor.IPAddressType) []net.IPNet {
+	result := make([]net.IPNet, 0, len(v.Cidrs[ipType]))
+	for _, one := range v.Cidrs[ipType] {
+		_, ipNet, err := net.ParseCIDR(one)
+		if err != nil {
+			continue
+		}
+		result = append(result, *ipNet)
+	}
+	return result
+}
+
+// allNets 返回vpc下所有ip地址类型的网段
+func (v *vpcCidrInfo) allNets() []net.IPNet {
+	return append(v.nets(enumor.Ipv4), v.nets(enumor.Ipv6)...)
+}
+
+func newVpcCidrInfo[T corecloud.VpcExtension](vpc corecloud.Vpc[T]) vpcCidrInfo {
+	return vpcCidrInfo{
+		ID:        vpc.ID,
+		Vendor:    vpc.Vendor,
+		BkCloudID: vpc.BkCloudID,
+		BkBizID:   vpc.BkBizID,
+	}
+}
+
+// listVpcCidr list vpc cidr info by filter, gcp vpc has no cidr and is not supported.
+func (svc *subnetSvc) listVpcCidr(kt *kit.Kit, vendor enumor.Vendor, expr *filter.Expression) (
+	[]vpcCidrInfo, error) {
+
+	req := &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+	result := make([]vpcCidrInfo, 0)
+	for {
+		var count int
+		switch vendor {
+		case enumor.TCloud:
+			resp, err := svc.client.DataService().TCloud.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				logs.Errorf("list tcloud vpc failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range resp.Details {
+				info := newVpcCidrInfo(one)
+				for _, c := range converter.PtrToVal(one.Extension).Cidr {
+					info.add(c.Type, c.Cidr)
+				}
+				result = append(result, info)
+			}
+			count = len(resp.Details)
+		case enumor.Aws:
+			resp, err := svc.client.DataService().Aws.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				logs.Errorf("list aws vpc failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range resp.Details {
+				info := newVpcCidrInfo(one)
+				for _, c := range converter.PtrToVal(one.Extension).Cidr {
+					info.add(c.Type, c.Cidr)
+				}
+				result = append(result, info)
+			}
+			count = len(resp.Details)
+		case enumor.Azure:
+			resp, err := svc.client.DataService().Azure.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				logs.Errorf("list azure vpc failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range resp.Details {
+				info := newVpcCidrInfo(one)
+				for _, c := range converter.PtrToVal(one.Extension).Cidr {
+					info.add(c.Type, c.Cidr)
+				}
+				result = append(result, info)
+			}
+			count = len(resp.Details)
+		case enumor.HuaWei:
+			resp, err := svc.client.DataService().HuaWei.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				logs.Errorf("list huawei vpc failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range resp.Details {
+				info := newVpcCidrInfo(one)
+				for _, c := range converter.PtrToVal(one.Extension).Cidr {
+					info.add(c.Type, c.Cidr)
+				}
+				result = append(result, info)
+			}
+			count = len(resp.Details)
+		default:
+			return nil, errf.Newf(errf.InvalidParameter, "plan subnet cidr not support vendor: %s", vendor)
+		}
+
+		if uint(count) < core.DefaultMaxPageLimit {
+			break
+		}
+
+		req.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return result, nil
+}
+
+// listVpcSubnetNets 查询vpc下已同步子网的网段
+func (svc *subnetSvc) listVpcSubnetNets(kt *kit.Kit, vpcID string) ([]net.IPNet, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("vpc_id", vpcID),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"ipv4_cidr", "ipv6_cidr"},
+	}
+
+	result := make([]net.IPNet, 0)
+	for {
+		resp, err := svc.client.DataService().Global.Subnet.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list subnet by vpc failed, err: %v, vpc: %s, rid: %s", err, vpcID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			for _, c := range append(one.Ipv4Cidr, one.Ipv6Cidr...) {
+				_, ipNet, err := net.ParseCIDR(c)
+				if err != nil {
+					logs.Warnf("parse subnet cidr %s failed, err: %v, rid: %s", c, err, kt.Rid)
+					continue
+				}
+				result = append(result, *ipNet)
+			}
+		}
+
+		if uint(len(resp.Details)) < core.DefaultMaxPageLimit {
+			break
+		}
+
+		req.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return result, nil
+}
+
+// occupiedCidr 被vpc外部来源占用的网段
+type occupiedCidr struct {
+	source enumor.SubnetCidrConflictSource
+	resID  string
+	net    net.IPNet
+}
+
+// listOccupiedCidr 查询vpc外部来源占用的网段，包括业务预留网段、同一管控区域下其他vpc的网段以及对等连接路由的目的网段
+func (svc *subnetSvc) listOccupiedCidr(kt *kit.Kit, vpc vpcCidrInfo) ([]occupiedCidr, error) {
+	result := make([]occupiedCidr, 0)
+
+	if vpc.BkBizID > 0 {
+		reserved, _, err := svc.getBizReservedCidr(kt, vpc.BkBizID)
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range reserved {
+			_, ipNet, err := net.ParseCIDR(one.Cidr)
+			if err != nil {
+				logs.Warnf("parse biz reserved cidr %s failed, err: %v, rid: %s", one.Cidr, err, kt.Rid)
+				continue
+			}
+			result = append(result, occupiedCidr{source: enumor.ReservedCidrConflictSource, net: *ipNet})
+		}
+	}
+
+	if vpc.BkCloudID != constant.UnbindBkCloudID {
+		expr := tools.ExpressionAnd(tools.RuleEqual("bk_cloud_id", vpc.BkCloudID), tools.RuleNotEqual("id", vpc.ID))
+		for _, vendor := range []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.Azure, enumor.HuaWei} {
+			vpcs, err := svc.listVpcCidr(kt, vendor, expr)
+			if err != nil {
+				return nil, err
+			}
+			for _, one := range vpcs {
+				for _, ipNet := range one.allNets() {
+					result = append(result, occupiedCidr{source: enumor.CloudAreaVpcCidrConflictSource,
+						resID: one.ID, net: ipNet})
+				}
+			}
+		}
+	}
+
+	peering, err := svc.listPeeringRouteCidr(kt, vpc)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, peering...)
+
+	return result, nil
+}
+
+// listPeeringRouteCidr 查询vpc路由表中下一跳为对等连接的路由的目的网段，即对端vpc的网段，目前仅支持腾讯云和aws
+func (svc *subnetSvc) listPeeringRouteCidr(kt *kit.Kit, vpc vpcCidrInfo) ([]occupiedCidr, error) {
+	if vpc.Vendor != enumor.TCloud && vpc.Vendor != enumor.Aws {
+		return make([]occupiedCidr, 0), nil
+	}
+
+	rtReq := &core.ListReq{
+		Filter: tools.EqualExpression("vpc_id", vpc.ID),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	rtResp, err := svc.client.DataService().Global.RouteTable.List(kt.Ctx, kt.Header(), rtReq)
+	if err != nil {
+		logs.Errorf("list route table by vpc failed, err: %v, vpc: %s, rid: %s", err, vpc.ID, kt.Rid)
+		return nil, err
+	}
+
+	result := make([]occupiedCidr, 0)
+	if len(rtResp.Details) == 0 {
+		return result, nil
+	}
+
+	rtIDs := make([]string, 0, len(rtResp.Details))
+	for _, one := range rtResp.Details {
+		rtIDs = append(rtIDs, one.ID)
+	}
+
+	destinations := make(map[string]string)
+	switch vpc.Vendor {
+	case enumor.TCloud:
+		req := &routetable.TCloudRouteListReq{ListReq: &core.ListReq{
+			Filter: tools.ExpressionAnd(tools.RuleIn("route_table_id", rtIDs),
+				tools.RuleEqual("gateway_type", tcloudPeeringGatewayType)),
+			Page: core.NewDefaultBasePage(),
+		}}
+		resp, err := svc.client.DataService().TCloud.RouteTable.ListAllRoute(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list tcloud peering route failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for _, one := range resp.Details {
+			destinations[one.DestinationCidrBlock] = one.CloudGatewayID
+			if ipv6 := converter.PtrToVal(one.DestinationIpv6CidrBlock); len(ipv6) != 0 {
+				destinations[ipv6] = one.CloudGatewayID
+			}
+		}
+	case enumor.Aws:
+		req := &routetable.AwsRouteListReq{ListReq: &core.ListReq{
+			Filter: tools.ExpressionAnd(tools.RuleIn("route_table_id", rtIDs),
+				tools.RuleNotEqual("cloud_vpc_peering_connection_id", "")),
+			Page: core.NewDefaultBasePage(),
+		}}
+		resp, err := svc.client.DataService().Aws.RouteTable.ListAllRoute(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list aws peering route failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for _, one := range resp.Details {
+			peeringID := converter.PtrToVal(one.CloudVpcPeeringConnectionID)
+			for _, dest := range []*string{one.DestinationCidrBlock, one.DestinationIpv6CidrBlock} {
+				if len(converter.PtrToVal(dest)) != 0 {
+					destinations[*dest] = peeringID
+				}
+			}
+		}
+	}
+
+	dests := maps.Keys(destinations)
+	sort.Strings(dests)
+
+	for _, dest := range dests {
+		peeringID := destinations[dest]
+		_, ipNet, err := net.ParseCIDR(dest)
+		if err != nil {
+			logs.Warnf("parse peering route destination %s failed, err: %v, rid: %s", dest, err, kt.Rid)
+			continue
+		}
+		result = append(result, occupiedCidr{source: enumor.PeeringRouteCidrConflictSource, resID: peeringID,
+			net: *ipNet})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package subnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	cgconf "hcm/pkg/api/core/global-config"
+	datagconf "hcm/pkg/api/data-service/global_config"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// GetBizSubnetReservedCidr get biz reserved subnet cidr.
+func (svc *subnetSvc) GetBizSubnetReservedCidr(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := parseBizID(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.authorizer.AuthorizeWithPerm(cts.Kit, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access}, BizID: bizID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cidrs, _, err := svc.getBizReservedCidr(cts.Kit, bizID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cloudserver.SubnetReservedCidrResult{Cidrs: cidrs}, nil
+}
+
+// UpdateBizSubnetReservedCidr update biz reserved subnet cidr, the given cidrs will replace all the existing ones.
+func (svc *subnetSvc) UpdateBizSubnetReservedCidr(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := parseBizID(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(cloudserver.SubnetReservedCidrUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err = svc.authorizer.AuthorizeWithPerm(cts.Kit, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.SubnetReservedCidr, Action: meta.Update}, BizID: bizID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Cidrs == nil {
+		req.Cidrs = make([]cgconf.SubnetReservedCidr, 0)
+	}
+	value, err := json.Marshal(req.Cidrs)
+	if err != nil {
+		return nil, err
+	}
+
+	_, configID, err := svc.getBizReservedCidr(cts.Kit, bizID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(configID) != 0 {
+		updateReq := &datagconf.BatchUpdateReq{
+			Configs: []cgconf.GlobalConfig{{ID: configID, ConfigValue: value}},
+		}
+		if err = svc.client.DataService().Global.GlobalConfig.BatchUpdate(cts.Kit, updateReq); err != nil {
+			logs.Errorf("update biz %d reserved subnet cidr failed, err: %v, rid: %s", bizID, err, cts.Kit.Rid)
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	createReq := &datagconf.BatchCreateReq{
+		Configs: []cgconf.GlobalConfig{{
+			ConfigKey:   strconv.FormatInt(bizID, 10),
+			ConfigValue: value,
+			ConfigType:  cgconf.SubnetReservedCidrConfigType,
+		}},
+	}
+	if _, err = svc.client.DataService().Global.GlobalConfig.BatchCreate(cts.Kit, createReq); err != nil {
+		logs.Errorf("create biz %d reserved subnet cidr failed, err: %v, rid: %s", bizID, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// getBizReservedCidr 查询业务预留的子网网段，返回预留网段以及对应的全局配置ID，未配置时全局配置ID为空
+func (svc *subnetSvc) getBizReservedCidr(kt *kit.Kit, bizID int64) ([]cgconf.SubnetReservedCidr, string, error) {
+	listReq := &datagconf.ListReq{
+		ListReq: core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("config_type", cgconf.SubnetReservedCidrConfigType),
+				tools.RuleEqual("config_key", strconv.FormatInt(bizID, 10)),
+			),
+			Page: core.NewDefaultBasePage(),
+		},
+	}
+	resp, err := svc.client.DataService().Global.GlobalConfig.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list biz %d reserved subnet cidr failed, err: %v, rid: %s", bizID, err, kt.Rid)
+		return nil, "", err
+	}
+
+	cidrs := make([]cgconf.SubnetReservedCidr, 0)
+	if len(resp.Details) == 0 {
+		return cidrs, "", nil
+	}
+
+	config := resp.Details[0]
+	if err = json.Unmarshal([]byte(config.ConfigValue), &cidrs); err != nil {
+		logs.Errorf("unmarshal biz %d reserved subnet cidr failed, err: %v, rid: %s", bizID, err, kt.Rid)
+		return nil, "", fmt.Errorf("unmarshal biz reserved subnet cidr failed, err: %v", err)
+	}
+
+	return cidrs, config.ID, nil
+}
+
+func parseBizID(cts *rest.Contexts) (int64, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return 0, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID <= 0 {
+		return 0, errf.New(errf.InvalidParameter, "biz id is invalid")
+	}
+
+	return bizID, nil
+}
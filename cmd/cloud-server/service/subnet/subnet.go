@@ -70,6 +70,7 @@ func InitSubnetService(c *capability.Capability) {
 	h.Add("CountSubnetAvailableIPs", "POST", "/subnets/{id}/ips/count", svc.CountSubnetAvailableIPs)
 	h.Add("ListCountResSubnetAvailIPs", "POST", "/subnets/ips/count/list",
 		svc.ListCountResSubnetAvailIPs)
+	h.Add("PlanSubnetCidr", "POST", "/subnets/cidr/plan", svc.PlanSubnetCidr)
 
 	// subnet apis in biz
 	h.Add("CreateBizSubnet", "POST", "/bizs/{bk_biz_id}/subnets/create", svc.CreateBizSubnet)
@@ -80,6 +81,11 @@ func InitSubnetService(c *capability.Capability) {
 	h.Add("CountBizSubnetAvailIPs", "POST", "/bizs/{bk_biz_id}/subnets/{id}/ips/count", svc.CountBizSubnetAvailIPs)
 	h.Add("ListCountBizSubnetAvailIPs", "POST", "/bizs/{bk_biz_id}/subnets/ips/count/list",
 		svc.ListCountBizSubnetAvailIPs)
+	h.Add("PlanBizSubnetCidr", "POST", "/bizs/{bk_biz_id}/subnets/cidr/plan", svc.PlanBizSubnetCidr)
+	h.Add("GetBizSubnetReservedCidr", "GET", "/bizs/{bk_biz_id}/subnets/cidr/reserved",
+		svc.GetBizSubnetReservedCidr)
+	h.Add("UpdateBizSubnetReservedCidr", "PATCH", "/bizs/{bk_biz_id}/subnets/cidr/reserved",
+		svc.UpdateBizSubnetReservedCidr)
 
 	h.Load(c.WebService)
 }
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"hcm/cmd/cloud-server/service/sync/detail"
+	"hcm/cmd/cloud-server/service/sync/option"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
@@ -89,6 +90,12 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return "", hitErr
 	}
 
+	// 同步写入前按账号汇总检查待删除的资源数量
+	listRegions := func(enumor.CloudResourceType) ([]string, error) { return regions, nil }
+	if hitErr = option.CheckAccountDelete(kt, cliSet, enumor.Aws, opt.AccountID, listRegions); hitErr != nil {
+		return "", hitErr
+	}
+
 	sd := &detail.SyncDetail{
 		Kt:        kt,
 		DataCli:   cliSet.DataService(),
@@ -100,6 +107,14 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.DiskCloudResType, hitErr
 	}
 
+	if hitErr = SyncDiskSnapshot(kt, cliSet, opt.AccountID, regions, sd); hitErr != nil {
+		return enumor.DiskSnapshotCloudResType, hitErr
+	}
+
+	if hitErr = SyncKeyPair(kt, cliSet, opt.AccountID, regions, sd); hitErr != nil {
+		return enumor.KeyPairCloudResType, hitErr
+	}
+
 	if hitErr = SyncVpc(kt, cliSet, opt.AccountID, regions, sd); hitErr != nil {
 		return enumor.VpcCloudResType, hitErr
 	}
@@ -124,5 +139,9 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.SubAccountCloudResType, hitErr
 	}
 
+	if hitErr = SyncLoadBalancer(kt, cliSet, opt.AccountID, regions, sd); hitErr != nil {
+		return enumor.LoadBalancerCloudResType, hitErr
+	}
+
 	return "", nil
 }
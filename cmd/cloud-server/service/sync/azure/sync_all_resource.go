@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"hcm/cmd/cloud-server/service/sync/detail"
+	"hcm/cmd/cloud-server/service/sync/option"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
@@ -86,6 +87,12 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return "", hitErr
 	}
 
+	// 同步写入前按账号汇总检查待删除的资源数量
+	listRegions := func(enumor.CloudResourceType) ([]string, error) { return resourceGroupNames, nil }
+	if hitErr = option.CheckAccountDelete(kt, cliSet, enumor.Azure, opt.AccountID, listRegions); hitErr != nil {
+		return "", hitErr
+	}
+
 	if opt.SyncPublicResource {
 		syncOpt := &SyncPublicResourceOption{
 			AccountID:          opt.AccountID,
@@ -107,6 +114,14 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.DiskCloudResType, hitErr
 	}
 
+	if hitErr = SyncDiskSnapshot(kt, cliSet, opt.AccountID, resourceGroupNames, sd); hitErr != nil {
+		return enumor.DiskSnapshotCloudResType, hitErr
+	}
+
+	if hitErr = SyncKeyPair(kt, cliSet, opt.AccountID, resourceGroupNames, sd); hitErr != nil {
+		return enumor.KeyPairCloudResType, hitErr
+	}
+
 	if hitErr = SyncSG(kt, cliSet, opt.AccountID, resourceGroupNames, sd); hitErr != nil {
 		return enumor.SecurityGroupCloudResType, hitErr
 	}
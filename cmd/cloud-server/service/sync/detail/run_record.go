@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package detail
+
+import (
+	"time"
+
+	dssync "hcm/pkg/api/data-service/cloud/sync"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/metrics"
+)
+
+// RecordAccountSyncRun 记录账号全量同步的运行记录并上报指标，失败时 failedRes 为同步失败的资源类型。
+// 各资源的变更数量由 hc-service 按资源记录，账号粒度只记录结果和耗时，记录失败不影响同步。
+func RecordAccountSyncRun(kt *kit.Kit, dataCli *dataservice.Client, vendor enumor.Vendor, accountID string,
+	failedRes enumor.CloudResourceType, startedAt time.Time, syncErr error) {
+
+	endedAt := time.Now()
+	state := enumor.SyncSuccess
+	failedReason := ""
+	if syncErr != nil {
+		state = enumor.SyncFailed
+		failedReason = syncErr.Error()
+	}
+
+	metrics.ObserveResSync(metrics.ResSyncObservation{
+		Vendor:    string(vendor),
+		AccountID: accountID,
+		Scope:     string(enumor.SyncRunAccountScope),
+		ResType:   string(failedRes),
+		State:     string(state),
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+	})
+
+	req := &dssync.CreateRunRecordReq{
+		Items: []dssync.RunRecordCreateField{{
+			Vendor:       vendor,
+			AccountID:    accountID,
+			Scope:        enumor.SyncRunAccountScope,
+			ResType:      failedRes,
+			State:        state,
+			FailedReason: failedReason,
+			Rid:          kt.Rid,
+			StartedAt:    startedAt,
+			EndedAt:      endedAt,
+		}},
+	}
+	if _, err := dataCli.Global.SyncRunRecord.BatchCreate(kt, req); err != nil {
+		logs.Errorf("create %s account sync run record failed, err: %v, account: %s, rid: %s", vendor, err,
+			accountID, kt.Rid)
+	}
+}
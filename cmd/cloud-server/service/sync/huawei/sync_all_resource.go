@@ -23,7 +23,10 @@ import (
 	"time"
 
 	"hcm/cmd/cloud-server/service/sync/detail"
+	"hcm/cmd/cloud-server/service/sync/option"
+	"hcm/pkg/adaptor/huawei"
 	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
@@ -79,6 +82,14 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		}
 	}
 
+	// 同步写入前按账号汇总检查待删除的资源数量
+	listRegions := func(resType enumor.CloudResourceType) ([]string, error) {
+		return deleteCheckRegions(kt, cliSet.DataService(), resType)
+	}
+	if hitErr = option.CheckAccountDelete(kt, cliSet, enumor.HuaWei, opt.AccountID, listRegions); hitErr != nil {
+		return "", hitErr
+	}
+
 	sd := &detail.SyncDetail{
 		Kt:        kt,
 		DataCli:   cliSet.DataService(),
@@ -90,6 +101,14 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.DiskCloudResType, hitErr
 	}
 
+	if hitErr = SyncDiskSnapshot(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.DiskSnapshotCloudResType, hitErr
+	}
+
+	if hitErr = SyncKeyPair(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.KeyPairCloudResType, hitErr
+	}
+
 	if hitErr = SyncVpc(kt, cliSet, opt.AccountID, sd); hitErr != nil {
 		return enumor.VpcCloudResType, hitErr
 	}
@@ -118,5 +137,33 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.SubAccountCloudResType, hitErr
 	}
 
+	if hitErr = SyncCert(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.CertCloudResType, hitErr
+	}
+
+	if hitErr = SyncLoadBalancer(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.LoadBalancerCloudResType, hitErr
+	}
+
+	if hitErr = SyncArgsTpl(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.ArgumentTemplateResType, hitErr
+	}
+
 	return "", nil
 }
+
+// deleteCheckRegions 返回删除检查时资源需要检查的地域，与各资源同步时使用的地域保持一致
+func deleteCheckRegions(kt *kit.Kit, dataCli *dataservice.Client, resType enumor.CloudResourceType) ([]string,
+	error) {
+
+	switch resType {
+	case enumor.CertCloudResType:
+		return []string{constant.HuaWeiDefaultRegion}, nil
+	case enumor.CvmCloudResType, enumor.DiskCloudResType:
+		return ListRegionByService(kt, dataCli, huawei.Ecs)
+	case enumor.EipCloudResType:
+		return ListRegionByService(kt, dataCli, huawei.Eip)
+	default:
+		return ListRegionByService(kt, dataCli, huawei.Vpc)
+	}
+}
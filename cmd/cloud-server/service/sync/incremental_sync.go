@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/aws"
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcsync "hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+// incrementalSyncEventDelay 云上审计事件存在投递延迟，每次查询向前多覆盖一段时间，重复同步是幂等的
+const incrementalSyncEventDelay = 10 * time.Minute
+
+// CloudResourceIncrementalSync 定时根据云上资源变更事件增量同步云资源，只同步发生变更的资源，周期全量同步作为兜底
+func CloudResourceIncrementalSync(opt cc.IncrementalSync, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	interval := time.Duration(opt.IntervalMin) * time.Minute
+	logs.Infof("cloud resource incremental sync enable, interval: %v, vendors: %v", interval, opt.Vendors)
+
+	syncer := &incrementalSyncer{cliSet: cliSet, interval: interval, cursor: make(map[string]time.Time)}
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			// 非主节点期间的变更由新的主节点或全量同步处理，重新成为主节点后从当前时间开始
+			syncer.reset()
+			continue
+		}
+
+		start := time.Now()
+		waitGroup := new(sync.WaitGroup)
+		for _, vendor := range opt.Vendors {
+			waitGroup.Add(1)
+			go func(vendor enumor.Vendor) {
+				defer waitGroup.Done()
+
+				kt := core.NewBackendKit()
+				kt.RequestSource = enumor.AsynchronousTasks
+				syncer.syncVendor(kt, vendor)
+			}(vendor)
+		}
+		waitGroup.Wait()
+
+		logs.Infof("cloud resource incremental sync end, cost: %v", time.Since(start))
+	}
+}
+
+type incrementalSyncer struct {
+	cliSet   *client.ClientSet
+	interval time.Duration
+
+	lock sync.Mutex
+	// cursor 记录各账号上一次增量同步成功的截止时间
+	cursor map[string]time.Time
+}
+
+func (s *incrementalSyncer) reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cursor = make(map[string]time.Time)
+}
+
+// timeRange 计算账号本次需要查询的事件时间区间
+func (s *incrementalSyncer) timeRange(accountID string, end time.Time) (time.Time, time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	start, exist := s.cursor[accountID]
+	if !exist {
+		start = end.Add(-s.interval)
+	}
+	start = start.Add(-incrementalSyncEventDelay)
+
+	// 超出单次查询范围的部分由全量同步兜底
+	if end.Sub(start) > resourceevent.MaxLookupWindow {
+		start = end.Add(-resourceevent.MaxLookupWindow)
+	}
+
+	return start, end
+}
+
+func (s *incrementalSyncer) setCursor(accountID string, end time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cursor[accountID] = end
+}
+
+func (s *incrementalSyncer) syncVendor(kt *kit.Kit, vendor enumor.Vendor) {
+	listReq := &protocloud.AccountListReq{
+		Filter: &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+			&filter.AtomRule{Field: "type", Op: filter.Equal.Factory(), Value: enumor.ResourceAccount}}},
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	for {
+		accounts, err := listAccountWithRetry(kt, s.cliSet.DataService(), listReq)
+		if err != nil {
+			logs.Errorf("list %s account failed, err: %v, rid: %s", vendor, err, kt.Rid)
+			return
+		}
+
+		for _, acc := range accounts {
+			if err = s.syncAccount(kt.NewSubKit(), vendor, acc.ID); err != nil {
+				logs.Errorf("%s account %s incremental sync failed, err: %v, rid: %s", vendor, acc.ID, err, kt.Rid)
+				continue
+			}
+		}
+
+		if len(accounts) < int(core.DefaultMaxPageLimit) {
+			return
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+func (s *incrementalSyncer) syncAccount(kt *kit.Kit, vendor enumor.Vendor, accountID string) error {
+	start, end := s.timeRange(accountID, time.Now())
+	req := &hcsync.IncrementalSyncReq{
+		AccountID: accountID,
+		StartTime: start.Format(constant.TimeStdFormat),
+		EndTime:   end.Format(constant.TimeStdFormat),
+	}
+
+	var result *hcsync.IncrementalSyncResult
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		result, err = s.cliSet.HCService().TCloud.IncrementSync.Sync(kt, req)
+	case enumor.Aws:
+		req.Regions, err = aws.ListRegion(kt, s.cliSet.DataService(), accountID)
+		if err != nil {
+			return err
+		}
+		if len(req.Regions) == 0 {
+			return nil
+		}
+		result, err = s.cliSet.HCService().Aws.IncrementSync.Sync(kt, req)
+	case enumor.Azure:
+		result, err = s.cliSet.HCService().Azure.IncrementSync.Sync(kt, req)
+	default:
+		return fmt.Errorf("vendor %s not support incremental sync", vendor)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 部分资源同步失败不推进游标，下次重新查询该时间段的事件
+	if len(result.Failed) != 0 {
+		logs.Errorf("%s account %s incremental sync has failed resources, failed: %v, rid: %s", vendor, accountID,
+			result.Failed, kt.Rid)
+		return nil
+	}
+	s.setCursor(accountID, end)
+
+	logs.V(3).Infof("%s account %s incremental sync success, events: %d, synced: %v, rid: %s", vendor, accountID,
+		result.EventCount, result.Synced, kt.Rid)
+	return nil
+}
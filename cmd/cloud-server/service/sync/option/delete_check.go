@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package option
+
+import (
+	hcsync "hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListResRegionsFunc 返回账号全量同步时资源需要同步的地域，azure 为资源组名称
+type ListResRegionsFunc func(resType enumor.CloudResourceType) ([]string, error)
+
+// CheckAccountDelete 账号全量同步的删除保护检查，在同步写入任何数据前，以删除检查模式对账号下所有支持删除选项的资源
+// 逐个地域执行删除对比，汇总账号维度待删除及扫描的本地资源数量，超过删除保护阈值时拒绝该账号本次同步。
+func CheckAccountDelete(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, accountID string,
+	listRegions ListResRegionsFunc) error {
+
+	protection := cc.CloudServer().CloudResource.Sync.DeleteProtection
+	if !protection.Enabled() {
+		return nil
+	}
+
+	var deleted, scanned uint
+	opt := hcsync.SyncDeleteOption{DeleteCheck: true}
+	for resType, resPath := range optionSyncResPaths[vendor] {
+		regions, err := listRegions(resType)
+		if err != nil {
+			logs.Errorf("list %s %s sync regions for delete check failed, err: %v, account: %s, rid: %s", vendor,
+				resType, err, accountID, kt.Rid)
+			return err
+		}
+
+		for _, region := range regions {
+			plan, err := syncRegionResWithOption(kt, cliSet, vendor, accountID, resPath, region, opt)
+			if err != nil {
+				logs.Errorf("%s %s delete check failed, err: %v, account: %s, region: %s, rid: %s", vendor, resType,
+					err, accountID, region, kt.Rid)
+				return err
+			}
+			if plan == nil {
+				continue
+			}
+			deleted += plan.Deleted
+			scanned += plan.Scanned
+		}
+	}
+
+	if protection.Exceeded(deleted, scanned) {
+		logs.Errorf("%s account sync refused by delete protection, delete: %d, scanned: %d, max percent: %d, "+
+			"account: %s, rid: %s", vendor, deleted, scanned, protection.MaxPercent, accountID, kt.Rid)
+		return errf.Newf(errf.SyncDeleteProtected, "%s account %s sync will delete %d of %d local resources, which "+
+			"exceeds %d%%, please preview and confirm the resource sync", vendor, accountID, deleted, scanned,
+			protection.MaxPercent)
+	}
+
+	return nil
+}
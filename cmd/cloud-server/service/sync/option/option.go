@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package option 资源同步的预览、确认及删除检查
+package option
+
+import (
+	"fmt"
+
+	hcsync "hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// optionSyncResPaths 支持预览及确认删除同步的资源，对应 hc-service 中的同步接口路径
+var optionSyncResPaths = map[enumor.Vendor]map[enumor.CloudResourceType]string{
+	enumor.TCloud: {
+		enumor.CvmCloudResType:           "cvms/with/relation_resources",
+		enumor.DiskCloudResType:          "disks",
+		enumor.VpcCloudResType:           "vpcs",
+		enumor.SubnetCloudResType:        "subnets",
+		enumor.EipCloudResType:           "eips",
+		enumor.SecurityGroupCloudResType: "security_groups",
+		enumor.RouteTableCloudResType:    "route_tables",
+		enumor.ArgumentTemplateResType:   "argument_templates",
+		enumor.CertCloudResType:          "certs",
+		enumor.LoadBalancerCloudResType:  "load_balancers",
+	},
+	enumor.Aws: {
+		enumor.CvmCloudResType:           "cvms/with/relation_resources",
+		enumor.DiskCloudResType:          "disks",
+		enumor.VpcCloudResType:           "vpcs",
+		enumor.SubnetCloudResType:        "subnets",
+		enumor.EipCloudResType:           "eips",
+		enumor.SecurityGroupCloudResType: "security_groups",
+		enumor.RouteTableCloudResType:    "route_tables",
+		enumor.LoadBalancerCloudResType:  "load_balancers",
+	},
+	enumor.HuaWei: {
+		enumor.CvmCloudResType:           "cvms/with/relation_resources",
+		enumor.DiskCloudResType:          "disks",
+		enumor.VpcCloudResType:           "vpcs",
+		enumor.EipCloudResType:           "eips",
+		enumor.SecurityGroupCloudResType: "security_groups",
+		enumor.RouteTableCloudResType:    "route_tables",
+		enumor.ArgumentTemplateResType:   "argument_templates",
+		enumor.CertCloudResType:          "certs",
+		enumor.LoadBalancerCloudResType:  "load_balancers",
+	},
+	enumor.Azure: {
+		enumor.CvmCloudResType:              "cvms/with/relation_resources",
+		enumor.DiskCloudResType:             "disks",
+		enumor.VpcCloudResType:              "vpcs",
+		enumor.EipCloudResType:              "eips",
+		enumor.SecurityGroupCloudResType:    "security_groups",
+		enumor.RouteTableCloudResType:       "route_tables",
+		enumor.NetworkInterfaceCloudResType: "network_interfaces",
+	},
+}
+
+// ValidateRes 校验资源是否支持预览及确认删除同步
+func ValidateRes(vendor enumor.Vendor, resType enumor.CloudResourceType) error {
+	if _, ok := optionSyncResPaths[vendor][resType]; !ok {
+		return fmt.Errorf("%s %s does not support sync with dry run or confirm delete", vendor, resType)
+	}
+	return nil
+}
+
+// SyncRes 按删除选项逐个地域同步账号下指定资源，regions 对于 azure 为资源组名称。
+// 预览模式下不写入数据，返回各地域的同步计划。
+func SyncRes(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, accountID string,
+	resType enumor.CloudResourceType, regions []string, opt hcsync.SyncDeleteOption) (
+	map[string]*hcsync.SyncPlanResult, error) {
+
+	if err := ValidateRes(vendor, resType); err != nil {
+		return nil, err
+	}
+	resPath := optionSyncResPaths[vendor][resType]
+
+	plans := make(map[string]*hcsync.SyncPlanResult, len(regions))
+	for _, region := range regions {
+		plan, err := syncRegionResWithOption(kt, cliSet, vendor, accountID, resPath, region, opt)
+		if err != nil {
+			logs.Errorf("sync %s %s with option failed, err: %v, account: %s, region: %s, opt: %+v, rid: %s",
+				vendor, resType, err, accountID, region, opt, kt.Rid)
+			return nil, err
+		}
+		plans[region] = plan
+	}
+
+	return plans, nil
+}
+
+func syncRegionResWithOption(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, accountID, resPath,
+	region string, opt hcsync.SyncDeleteOption) (*hcsync.SyncPlanResult, error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		req := &hcsync.TCloudSyncReq{AccountID: accountID, Region: region, SyncDeleteOption: opt}
+		return cliSet.HCService().TCloud.ResSync.Sync(kt, resPath, req)
+	case enumor.Aws:
+		req := &hcsync.AwsSyncReq{AccountID: accountID, Region: region, SyncDeleteOption: opt}
+		return cliSet.HCService().Aws.ResSync.Sync(kt, resPath, req)
+	case enumor.HuaWei:
+		req := &hcsync.HuaWeiSyncReq{AccountID: accountID, Region: region, SyncDeleteOption: opt}
+		return cliSet.HCService().HuaWei.ResSync.Sync(kt, resPath, req)
+	case enumor.Azure:
+		req := &hcsync.AzureSyncReq{AccountID: accountID, ResourceGroupName: region, SyncDeleteOption: opt}
+		return cliSet.HCService().Azure.ResSync.Sync(kt, resPath, req)
+	default:
+		return nil, fmt.Errorf("sync with option does not support vendor: %s", vendor)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"hcm/cmd/cloud-server/service/sync/detail"
+	"hcm/cmd/cloud-server/service/sync/option"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
@@ -83,6 +84,14 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return "", hitErr
 	}
 
+	// 同步写入前按账号汇总检查待删除的资源数量
+	listRegions := func(resType enumor.CloudResourceType) ([]string, error) {
+		return deleteCheckRegions(resType, regions), nil
+	}
+	if hitErr = option.CheckAccountDelete(kt, cliSet, enumor.TCloud, opt.AccountID, listRegions); hitErr != nil {
+		return "", hitErr
+	}
+
 	sd := &detail.SyncDetail{
 		Kt:        kt,
 		DataCli:   cliSet.DataService(),
@@ -92,6 +101,8 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 
 	syncFuncMap := map[enumor.CloudResourceType]ResSyncFunc{
 		enumor.DiskCloudResType:          SyncDisk,
+		enumor.DiskSnapshotCloudResType:  SyncDiskSnapshot,
+		enumor.KeyPairCloudResType:       SyncKeyPair,
 		enumor.VpcCloudResType:           SyncVpc,
 		enumor.SubnetCloudResType:        SyncSubnet,
 		enumor.EipCloudResType:           SyncEip,
@@ -116,6 +127,8 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 func getSyncOrder() []enumor.CloudResourceType {
 	return []enumor.CloudResourceType{
 		enumor.DiskCloudResType,
+		enumor.DiskSnapshotCloudResType,
+		enumor.KeyPairCloudResType,
 		enumor.VpcCloudResType,
 		enumor.SubnetCloudResType,
 		enumor.EipCloudResType,
@@ -128,3 +141,18 @@ func getSyncOrder() []enumor.CloudResourceType {
 		enumor.SubAccountCloudResType,
 	}
 }
+
+// deleteCheckRegions 返回删除检查时资源需要检查的地域，与同步时使用的地域保持一致，全局资源只在单个地域同步
+func deleteCheckRegions(resType enumor.CloudResourceType, regions []string) []string {
+	switch resType {
+	case enumor.CertCloudResType:
+		if len(regions) > 0 {
+			return regions[:1]
+		}
+		return nil
+	case enumor.ArgumentTemplateResType:
+		return []string{constant.TCloudDefaultRegion}
+	default:
+		return regions
+	}
+}
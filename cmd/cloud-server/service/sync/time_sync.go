@@ -106,7 +106,9 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.Vendor
 				AccountID: acc.ID,
 				Vendor:    string(acc.Vendor),
 			}
+			syncStart := time.Now()
 			resName, err := syncer.SyncAllResource(kt, cliSet, acc.ID, syncPublicResource)
+			detail.RecordAccountSyncRun(kt, cliSet.DataService(), acc.Vendor, acc.ID, resName, syncStart, err)
 			if err != nil {
 				if resName != "" {
 					if err := sd.ResSyncStatusFailed(resName, err); err != nil {
@@ -121,6 +123,8 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.Vendor
 				continue
 			}
 
+			account.ApplyBizAssignRule(kt, cliSet.DataService(), acc.ID)
+
 			// 公共资源仅需要同步一次即可
 			syncPublicResource = false
 		}
@@ -22,6 +22,7 @@ package task
 import (
 	"fmt"
 
+	taskmanagement "hcm/cmd/cloud-server/logics/task-management"
 	cloudtask "hcm/pkg/api/cloud-server/task"
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/data-service/task"
@@ -171,8 +172,8 @@ func (svc *service) listTaskManagementState(cts *rest.Contexts, authHandler hand
 
 	details := make([]cloudtask.ManagementState, 0)
 	for _, management := range list.Details {
-		// 由于任务管理的状态是根据后台协程周期同步更新的，所以可能会存在任务执行完，但是还没有更新最终状态的情况，因此需要刷新下获取最新状态
-		state, err := refreshTaskMgmtState(cts.Kit, svc.client, management)
+		// 由于任务管理的状态是由task-server周期任务流定期更新的，所以可能会存在任务执行完，但是还没有更新最终状态的情况，因此需要刷新下获取最新状态
+		state, err := taskmanagement.RefreshState(cts.Kit, svc.client, management)
 		if err != nil {
 			logs.Errorf("refresh task management state failed, err: %v, data: %+v, rid: %s", err, management,
 				cts.Kit.Rid)
@@ -31,6 +31,7 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/runtime/ctl"
+	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 )
@@ -91,7 +92,7 @@ func (ds *dataService) prepare(opt *options.Option) error {
 	ds.sd = sd
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(ctl.WithBasics(sd)...); err != nil {
+	if err := ctl.LoadCtl(append(ctl.WithBasics(sd), cmd.WithReEncryptSecret(svc))...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
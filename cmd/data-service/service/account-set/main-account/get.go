@@ -131,6 +131,8 @@ func (svc *service) GetMainAccount(cts *rest.Contexts) (interface{}, error) {
 		account, err = convertToMainAccountResult[protocore.ZenlayerMainAccountExtension](baseAccount, dbAccount.Extension, svc)
 	case enumor.Kaopu:
 		account, err = convertToMainAccountResult[protocore.KaopuMainAccountExtension](baseAccount, dbAccount.Extension, svc)
+	case enumor.TCloud:
+		account, err = convertToMainAccountResult[protocore.TCloudMainAccountExtension](baseAccount, dbAccount.Extension, svc)
 	}
 
 	if err != nil {
@@ -61,6 +61,8 @@ func (svc *service) CreateRootAccount(cts *rest.Contexts) (interface{}, error) {
 		result, err = createAccount[dataproto.ZenlayerRootAccountExtensionCreateReq](vendor, svc, cts)
 	case enumor.Kaopu:
 		result, err = createAccount[dataproto.KaopuRootAccountExtensionCreateReq](vendor, svc, cts)
+	case enumor.TCloud:
+		result, err = createAccount[dataproto.TCloudRootAccountExtensionCreateReq](vendor, svc, cts)
 	default:
 		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
 	}
@@ -86,7 +88,9 @@ func createAccount[T dataproto.RootAccountExtensionCreateReq, PT dataproto.RootS
 	if req.Extension != nil {
 		p := PT(req.Extension)
 		// 加密密钥
-		p.EncryptSecretKey(svc.cipher)
+		if err := p.EncryptSecretKey(svc.cipher); err != nil {
+			return nil, fmt.Errorf("encrypt secret key of extension failed, err: %v", err)
+		}
 	}
 
 	accountID, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
@@ -119,6 +119,8 @@ func (svc *service) GetRootAccount(cts *rest.Contexts) (interface{}, error) {
 		account, err = convertToRootAccountResult[protocore.ZenlayerRootAccountExtension](baseAccount, dbAccount.Extension, svc)
 	case enumor.Kaopu:
 		account, err = convertToRootAccountResult[protocore.KaopuRootAccountExtension](baseAccount, dbAccount.Extension, svc)
+	case enumor.TCloud:
+		account, err = convertToRootAccountResult[protocore.TCloudRootAccountExtension](baseAccount, dbAccount.Extension, svc)
 	}
 
 	if err != nil {
@@ -56,6 +56,8 @@ func (svc *service) UpdateRootAccount(cts *rest.Contexts) (interface{}, error) {
 		return updateRootAccount[dataproto.ZenlayerRootAccountExtensionUpdateReq](accountID, svc, cts)
 	case enumor.Kaopu:
 		return updateRootAccount[dataproto.KaopuRootAccountExtensionUpdateReq](accountID, svc, cts)
+	case enumor.TCloud:
+		return updateRootAccount[dataproto.TCloudRootAccountExtensionUpdateReq](accountID, svc, cts)
 	}
 	return nil, nil
 }
@@ -85,7 +87,9 @@ func updateRootAccount[T dataproto.RootAccountExtensionUpdateReq, PT dataproto.R
 	if req.Extension != nil {
 		// 将参数里的SecretKey加密
 		p := PT(req.Extension)
-		p.EncryptSecretKey(svc.cipher)
+		if err := p.EncryptSecretKey(svc.cipher); err != nil {
+			return nil, fmt.Errorf("encrypt secret key of extension failed, err: %v", err)
+		}
 
 		// 查询账号
 		dbAccount, err := getRootAccountFromTable(accountID, svc, cts)
@@ -37,6 +37,7 @@ import (
 	tabletype "hcm/pkg/dal/table/types"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -80,6 +81,7 @@ func (svc *applicationSvc) CreateApplication(cts *rest.Contexts) (interface{}, e
 		Applicant:      cts.Kit.User,
 		Content:        tabletype.JsonField(req.Content),
 		DeliveryDetail: tabletype.JsonField(req.DeliveryDetail),
+		ApprovalDetail: tabletype.JsonField(req.ApprovalDetail),
 		Memo:           req.Memo,
 		Creator:        cts.Kit.User,
 		Reviser:        cts.Kit.User,
@@ -124,11 +126,27 @@ func (svc *applicationSvc) UpdateApplication(cts *rest.Contexts) (interface{}, e
 	if req.DeliveryDetail != nil {
 		application.DeliveryDetail = tabletype.JsonField(*req.DeliveryDetail)
 	}
+	if req.ApprovalDetail != nil {
+		application.ApprovalDetail = tabletype.JsonField(*req.ApprovalDetail)
+	}
+
+	rules := []*filter.AtomRule{tools.RuleEqual("id", applicationID)}
+	if req.ApprovalRevision == nil {
+		err := svc.dao.Application().Update(cts.Kit, tools.ExpressionAnd(rules...), application)
+		if err != nil {
+			logs.Errorf("update application failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("update application failed, err: %v", err)
+		}
+		return nil, nil
+	}
 
-	err := svc.dao.Application().Update(cts.Kit, tools.EqualExpression("id", applicationID), application)
+	// 审批详情版本号不一致说明单据已被其他操作更新，返回 RecordNotUpdate 错误由调用方处理
+	rules = append(rules, tools.RuleJSONEqual("approval_detail.revision", *req.ApprovalRevision))
+	err := svc.dao.Application().CompareAndUpdate(cts.Kit, tools.ExpressionAnd(rules...), application)
 	if err != nil {
-		logs.Errorf("update application failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, fmt.Errorf("update application failed, err: %v", err)
+		logs.Errorf("compare and update application failed, err: %v, revision: %d, rid: %s", err,
+			*req.ApprovalRevision, cts.Kit.Rid)
+		return nil, err
 	}
 
 	return nil, nil
@@ -147,6 +165,7 @@ func (svc *applicationSvc) convertToApplicationResp(
 		Applicant:      application.Applicant,
 		Content:        string(application.Content),
 		DeliveryDetail: string(application.DeliveryDetail),
+		ApprovalDetail: string(application.ApprovalDetail),
 		Memo:           application.Memo,
 		Revision: core.Revision{
 			Creator:   application.Creator,
@@ -33,8 +33,10 @@ import (
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	tableapplication "hcm/pkg/dal/table/application"
+	tabletype "hcm/pkg/dal/table/types"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -69,9 +71,18 @@ func (svc *approvalProcessSvc) CreateApprovalProcesses(cts *rest.Contexts) (inte
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	stages, err := tabletype.NewJsonField(req.Stages)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
 	process := &tableapplication.ApprovalProcessTable{
 		ApplicationType: string(req.ApplicationType),
+		BkBizID:         req.BkBizID,
+		Engine:          string(req.Engine),
 		ServiceID:       req.ServiceID,
+		Managers:        req.Managers,
+		Stages:          stages,
 		Creator:         cts.Kit.User,
 		Reviser:         cts.Kit.User,
 	}
@@ -110,7 +121,17 @@ func (svc *approvalProcessSvc) UpdateApprovalProcesses(cts *rest.Contexts) (inte
 	}
 
 	approvalProcess := &tableapplication.ApprovalProcessTable{
+		Engine:    string(req.Engine),
 		ServiceID: req.ServiceID,
+		Managers:  req.Managers,
+		Reviser:   cts.Kit.User,
+	}
+	if len(req.Stages) != 0 {
+		stages, err := tabletype.NewJsonField(req.Stages)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		approvalProcess.Stages = stages
 	}
 
 	err := svc.dao.ApprovalProcess().Update(cts.Kit, tools.EqualExpression("id", approvalProcessID), approvalProcess)
@@ -124,19 +145,30 @@ func (svc *approvalProcessSvc) UpdateApprovalProcesses(cts *rest.Contexts) (inte
 
 func (svc *approvalProcessSvc) convertToApprovalProcessResp(
 	approvalProcess *tableapplication.ApprovalProcessTable,
-) *proto.ApprovalProcessResp {
+) (*proto.ApprovalProcessResp, error) {
+
+	stages := make([]proto.ApprovalStage, 0)
+	if !approvalProcess.Stages.IsEmpty() {
+		if err := json.UnmarshalFromString(string(approvalProcess.Stages), &stages); err != nil {
+			return nil, fmt.Errorf("unmarshal approval process(%s) stages failed, err: %v", approvalProcess.ID, err)
+		}
+	}
+
 	return &proto.ApprovalProcessResp{
 		ID:              approvalProcess.ID,
 		ApplicationType: enumor.ApplicationType(approvalProcess.ApplicationType),
+		BkBizID:         approvalProcess.BkBizID,
+		Engine:          enumor.ApprovalEngine(approvalProcess.Engine),
 		ServiceID:       approvalProcess.ServiceID,
 		Managers:        approvalProcess.Managers,
+		Stages:          stages,
 		Revision: core.Revision{
 			Creator:   approvalProcess.Creator,
 			Reviser:   approvalProcess.Reviser,
 			CreatedAt: approvalProcess.CreatedAt.String(),
 			UpdatedAt: approvalProcess.UpdatedAt.String(),
 		},
-	}
+	}, nil
 }
 
 // ListApprovalProcesses ...
@@ -165,7 +197,12 @@ func (svc *approvalProcessSvc) ListApprovalProcesses(cts *rest.Contexts) (interf
 
 	details := make([]*proto.ApprovalProcessResp, 0, len(daoApprovalProcessResp.Details))
 	for _, approvalProcess := range daoApprovalProcessResp.Details {
-		details = append(details, svc.convertToApprovalProcessResp(approvalProcess))
+		detail, err := svc.convertToApprovalProcessResp(approvalProcess)
+		if err != nil {
+			logs.Errorf("convert approval process failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+		details = append(details, detail)
 	}
 
 	return &proto.ApprovalProcessListResult{Details: details}, nil
@@ -106,6 +106,10 @@ func (ad Audit) buildAssignAuditInfo(kt *kit.Kit, resType enumor.AuditResourceTy
 		audits, err = ad.eipAssignAuditBuild(kt, assigns)
 	case enumor.DiskAuditResType:
 		audits, err = ad.diskAssignAuditBuild(kt, assigns)
+	case enumor.DiskSnapshotAuditResType:
+		audits, err = ad.diskSnapshotAssignAuditBuild(kt, assigns)
+	case enumor.KeyPairAuditResType:
+		audits, err = ad.keyPairAssignAuditBuild(kt, assigns)
 	case enumor.CvmAuditResType:
 		audits, err = ad.cvm.CvmAssignAuditBuild(kt, assigns)
 	case enumor.NetworkInterfaceAuditResType:
@@ -75,7 +75,9 @@ func createAccount[T protocloud.AccountExtensionCreateReq, PT protocloud.SecretE
 	if req.Extension != nil {
 		p := PT(req.Extension)
 		// 加密密钥
-		p.EncryptSecretKey(svc.cipher)
+		if err := p.EncryptSecretKey(svc.cipher); err != nil {
+			return nil, fmt.Errorf("encrypt secret key of extension failed, err: %v", err)
+		}
 	}
 
 	accountID, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
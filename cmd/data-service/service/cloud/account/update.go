@@ -106,7 +106,9 @@ func updateAccount[T protocloud.AccountExtensionUpdateReq, PT protocloud.SecretE
 	if req.Extension != nil {
 		// 将参数里的SecretKey加密
 		p := PT(req.Extension)
-		p.EncryptSecretKey(svc.cipher)
+		if err := p.EncryptSecretKey(svc.cipher); err != nil {
+			return nil, fmt.Errorf("encrypt secret key of extension failed, err: %v", err)
+		}
 
 		// 查询账号
 		dbAccount, err := getAccountFromTable(accountID, svc, cts)
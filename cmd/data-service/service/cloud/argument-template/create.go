@@ -44,6 +44,8 @@ func (svc *argsTplSvc) CreateArgsTpl(cts *rest.Contexts) (interface{}, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return batchCreateArgsTpl[coreargstpl.TCloudArgsTplExtension](cts, svc, vendor)
+	case enumor.HuaWei:
+		return batchCreateArgsTpl[coreargstpl.HuaWeiArgsTplExtension](cts, svc, vendor)
 	default:
 		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
 	}
@@ -70,6 +72,7 @@ func batchCreateArgsTpl[T coreargstpl.Extension](cts *rest.Contexts, svc *argsTp
 				Vendor:         vendor,
 				BkBizID:        one.BkBizID,
 				AccountID:      one.AccountID,
+				Region:         one.Region,
 				Type:           one.Type,
 				Templates:      one.Templates,
 				GroupTemplates: one.GroupTemplates,
@@ -99,6 +99,7 @@ func convTableToBaseArgsTpl(one *tableargstpl.ArgumentTemplateTable) (*coreargst
 		Vendor:         one.Vendor,
 		BkBizID:        one.BkBizID,
 		AccountID:      one.AccountID,
+		Region:         one.Region,
 		Type:           one.Type,
 		Templates:      templates,
 		GroupTemplates: groupTemplates,
@@ -148,6 +149,8 @@ func (svc *argsTplSvc) ListArgsTplExt(cts *rest.Contexts) (interface{}, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return convArgsTplListResult[coreargstpl.TCloudArgsTplExtension](cts.Kit, result.Details)
+	case enumor.HuaWei:
+		return convArgsTplListResult[coreargstpl.HuaWeiArgsTplExtension](cts.Kit, result.Details)
 	default:
 		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
 	}
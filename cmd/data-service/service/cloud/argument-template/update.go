@@ -80,6 +80,8 @@ func (svc *argsTplSvc) BatchUpdateArgsTplExt(cts *rest.Contexts) (interface{}, e
 	switch vendor {
 	case enumor.TCloud:
 		return batchUpdateArgsTplExt[coreargstpl.TCloudArgsTplExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateArgsTplExt[coreargstpl.HuaWeiArgsTplExtension](cts, svc)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -0,0 +1,378 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bizassign
+
+import (
+	"fmt"
+	"sort"
+
+	"hcm/pkg/api/core"
+	corebizassign "hcm/pkg/api/core/cloud/biz-assign-rule"
+	"hcm/pkg/api/data-service/audit"
+	dsbizassign "hcm/pkg/api/data-service/cloud/biz-assign-rule"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var assignResAuditTypeMap = map[enumor.CloudResourceType]enumor.AuditResourceType{
+	enumor.CvmCloudResType:          enumor.CvmAuditResType,
+	enumor.DiskCloudResType:         enumor.DiskAuditResType,
+	enumor.DiskSnapshotCloudResType: enumor.DiskSnapshotAuditResType,
+	enumor.KeyPairCloudResType:      enumor.KeyPairAuditResType,
+	enumor.VpcCloudResType:          enumor.VpcCloudAuditResType,
+	enumor.SubnetCloudResType:       enumor.SubnetAuditResType,
+	enumor.EipCloudResType:          enumor.EipAuditResType,
+	enumor.LoadBalancerCloudResType: enumor.LoadBalancerAuditResType,
+	// 网卡只随主机一起分配，不支持单独配置分配规则
+	enumor.NetworkInterfaceCloudResType: enumor.NetworkInterfaceAuditResType,
+}
+
+// PreviewBizAssignRule preview unassigned resources matched by biz assign rule.
+func (svc *service) PreviewBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbizassign.PreviewReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rule := &corebizassign.BizAssignRule{ResType: req.ResType, Rule: req.Rule, BkBizID: req.BkBizID}
+	if len(req.RuleID) != 0 {
+		rules, err := svc.listRules(cts.Kit, tools.EqualExpression("id", req.RuleID))
+		if err != nil {
+			return nil, err
+		}
+		if len(rules) == 0 {
+			return nil, errf.Newf(errf.RecordNotFound, "biz assign rule %s not found", req.RuleID)
+		}
+		rule = &rules[0]
+	} else if err := validateRule(rule.ResType, rule.Rule); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids, err := svc.matchUnassignedRes(cts.Kit, rule, req.AccountID)
+	if err != nil {
+		logs.Errorf("match biz assign rule resource failed, err: %v, rule: %+v, rid: %s", err, rule, cts.Kit.Rid)
+		return nil, err
+	}
+
+	result := &dsbizassign.PreviewResult{Count: uint64(len(ids)), ResIDs: ids}
+	if len(ids) > dsbizassign.MaxPreviewResCount {
+		result.ResIDs = ids[:dsbizassign.MaxPreviewResCount]
+	}
+
+	return result, nil
+}
+
+// ApplyBizAssignRule apply enabled biz assign rules by priority, assign matched unassigned resources to rule's biz.
+// 规则按优先级从小到大依次执行，每条规则只匹配未分配的资源，因此同一资源命中多条规则时以优先级最高的规则为准。
+func (svc *service) ApplyBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbizassign.ApplyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules := []filter.RuleFactory{tools.RuleEqual("enabled", true)}
+	if len(req.RuleIDs) != 0 {
+		rules = append(rules, tools.RuleIn("id", req.RuleIDs))
+	}
+	expr, err := tools.And(rules...)
+	if err != nil {
+		return nil, err
+	}
+	assignRules, err := svc.listRules(cts.Kit, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := applyRules(assignRules,
+		func(rule *corebizassign.BizAssignRule) ([]string, error) {
+			return svc.matchUnassignedRes(cts.Kit, rule, req.AccountID)
+		},
+		func(rule *corebizassign.BizAssignRule, ids []string) ([]string, error) {
+			return svc.assignRes(cts.Kit, rule, ids)
+		})
+	if err != nil {
+		logs.Errorf("apply biz assign rules failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &dsbizassign.ApplyResult{Details: details}, nil
+}
+
+// applyRules 按优先级从小到大依次执行规则，match 只返回仍未分配的资源，assign 返回实际分配的资源，
+// 因此同一资源命中多条规则时只会被优先级最高的规则分配
+func applyRules(rules []corebizassign.BizAssignRule,
+	match func(rule *corebizassign.BizAssignRule) ([]string, error),
+	assign func(rule *corebizassign.BizAssignRule, ids []string) ([]string, error)) (
+	[]corebizassign.AssignResult, error) {
+
+	sorted := make([]corebizassign.BizAssignRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Priority < sorted[j].Priority })
+
+	result := make([]corebizassign.AssignResult, 0)
+	for idx := range sorted {
+		rule := &sorted[idx]
+		ids, err := match(rule)
+		if err != nil {
+			return nil, fmt.Errorf("match biz assign rule %s resource failed, err: %v", rule.ID, err)
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+
+		assigned, err := assign(rule, ids)
+		if err != nil {
+			return nil, fmt.Errorf("assign resource by rule %s failed, err: %v", rule.ID, err)
+		}
+
+		if len(assigned) == 0 {
+			continue
+		}
+
+		result = append(result, corebizassign.AssignResult{
+			RuleID:  rule.ID,
+			ResType: rule.ResType,
+			BkBizID: rule.BkBizID,
+			ResIDs:  assigned,
+		})
+	}
+
+	return result, nil
+}
+
+// listRules list biz assign rules sorted by priority.
+func (svc *service) listRules(kt *kit.Kit, expr *filter.Expression) ([]corebizassign.BizAssignRule, error) {
+	opt := &types.ListOption{
+		Filter: expr,
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+			Sort:  "priority",
+			Order: core.Ascending,
+		},
+	}
+
+	rules := make([]corebizassign.BizAssignRule, 0)
+	for {
+		result, err := svc.dao.BizAssignRule().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list biz assign rule failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			rule, err := convBizAssignRule(one)
+			if err != nil {
+				logs.Errorf("convert biz assign rule failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+				return nil, err
+			}
+			rules = append(rules, *rule)
+		}
+
+		if len(result.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		opt.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return rules, nil
+}
+
+// matchUnassignedRes list unassigned resource ids matched by rule, which belongs to accounts related to rule's biz.
+func (svc *service) matchUnassignedRes(kt *kit.Kit, rule *corebizassign.BizAssignRule, accountID string) (
+	[]string, error) {
+
+	accountIDs, err := svc.listBizAccountIDs(kt, rule.BkBizID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accountIDs) == 0 {
+		return make([]string, 0), nil
+	}
+
+	expr, err := matchExpr(rule, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.dao.Cloud().ListResourceIDs(kt, rule.ResType, expr)
+}
+
+// matchExpr 规则匹配账号下未分配资源的过滤条件，与手动分配的校验保持一致
+func matchExpr(rule *corebizassign.BizAssignRule, accountIDs []string) (*filter.Expression, error) {
+	rules := []filter.RuleFactory{
+		rule.Rule,
+		tools.RuleEqual("bk_biz_id", constant.UnassignedBiz),
+		tools.RuleIn("account_id", accountIDs),
+	}
+
+	switch rule.ResType {
+	case enumor.VpcCloudResType:
+		// 分配vpc时要求vpc已绑定管控区域
+		rules = append(rules, tools.RuleGreaterThan("bk_cloud_id", 0))
+	case enumor.CvmCloudResType:
+		// 分配主机时要求主机已绑定管控区域
+		rules = append(rules, tools.RuleNotEqual("bk_cloud_id", constant.UnbindBkCloudID))
+	}
+
+	return tools.And(rules...)
+}
+
+// listBizAccountIDs list ids of accounts which can be used by the biz.
+func (svc *service) listBizAccountIDs(kt *kit.Kit, bizID int64, accountID string) ([]string, error) {
+	rules := []filter.RuleFactory{tools.RuleIn("bk_biz_id", []int64{bizID, constant.AttachedAllBiz})}
+	if len(accountID) != 0 {
+		rules = append(rules, tools.RuleEqual("account_id", accountID))
+	}
+	expr, err := tools.And(rules...)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"account_id"},
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	accountIDs := make([]string, 0)
+	for {
+		result, err := svc.dao.AccountBizRel().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list account biz rel failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			accountIDs = append(accountIDs, one.AccountID)
+		}
+
+		if len(result.Details) < int(opt.Page.Limit) {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return slice.Unique(accountIDs), nil
+}
+
+// assignRes assign resources to rule's biz and record assign audits, returns ids of assigned resources.
+// 分配主机时会同时分配主机关联的eip、硬盘及网卡，关联资源已分配到其他业务的主机不会被分配。
+func (svc *service) assignRes(kt *kit.Kit, rule *corebizassign.BizAssignRule, ids []string) ([]string, error) {
+	if _, exists := assignResAuditTypeMap[rule.ResType]; !exists {
+		return nil, fmt.Errorf("resource type %s cannot be assigned", rule.ResType)
+	}
+
+	// 关联资源需要先于主机分配
+	assignRes := make([]resIDs, 0)
+	if rule.ResType == enumor.CvmCloudResType {
+		var relRes []resIDs
+		var err error
+		ids, relRes, err = svc.prepareCvmAssign(kt, rule.BkBizID, ids)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return ids, nil
+		}
+		assignRes = append(assignRes, relRes...)
+	}
+	assignRes = append(assignRes, resIDs{resType: rule.ResType, ids: ids})
+
+	_, err := svc.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range assignRes {
+			if err := svc.assignResWithTx(kt, txn, one.resType, one.ids, rule.BkBizID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// resIDs 同一类型的资源ID
+type resIDs struct {
+	resType enumor.CloudResourceType
+	ids     []string
+}
+
+func (svc *service) assignResWithTx(kt *kit.Kit, txn *sqlx.Tx, resType enumor.CloudResourceType, ids []string,
+	bizID int64) error {
+
+	auditType, exists := assignResAuditTypeMap[resType]
+	if !exists {
+		return fmt.Errorf("resource type %s cannot be assigned", resType)
+	}
+
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		// 再次限定未分配状态，避免覆盖并发期间已被手动分配的资源
+		expr := tools.ExpressionAnd(tools.RuleIn("id", batch),
+			tools.RuleEqual("bk_biz_id", constant.UnassignedBiz))
+		if err := svc.dao.Cloud().AssignResourceToBiz(kt, txn, resType, expr, bizID); err != nil {
+			return err
+		}
+
+		assigns := make([]audit.CloudResourceAssignInfo, 0, len(batch))
+		for _, id := range batch {
+			assigns = append(assigns, audit.CloudResourceAssignInfo{
+				ResType:         auditType,
+				ResID:           id,
+				AssignedResType: enumor.BizAuditAssignedResType,
+				AssignedResID:   bizID,
+			})
+		}
+
+		audits, err := svc.audit.GenCloudResAssignAudit(kt, &audit.CloudResourceAssignAuditReq{Assigns: assigns})
+		if err != nil {
+			return err
+		}
+
+		if err = svc.dao.Audit().BatchCreateWithTx(kt, txn, audits); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bizassign
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// cvmRelRes 主机关联的资源
+type cvmRelRes struct {
+	cvmID   string
+	resType enumor.CloudResourceType
+	resID   string
+}
+
+// prepareCvmAssign 查询主机关联的eip、硬盘及网卡，与手动分配主机的校验保持一致，关联资源已分配到其他业务的主机不能分配，
+// 返回可以分配的主机及其需要一起分配的关联资源
+func (svc *service) prepareCvmAssign(kt *kit.Kit, bizID int64, cvmIDs []string) ([]string, []resIDs, error) {
+	rels, err := svc.listCvmRelRes(kt, cvmIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	relTypeIDs := make(map[enumor.CloudResourceType][]string)
+	for _, rel := range rels {
+		relTypeIDs[rel.resType] = append(relTypeIDs[rel.resType], rel.resID)
+	}
+
+	conflictIDs := make(map[string]struct{})
+	for resType, ids := range relTypeIDs {
+		for _, batch := range slice.Split(slice.Unique(ids), constant.BatchOperationMaxLimit) {
+			expr := tools.ExpressionAnd(tools.RuleIn("id", batch),
+				tools.RuleNotIn("bk_biz_id", []int64{constant.UnassignedBiz, bizID}))
+			conflicts, err := svc.dao.Cloud().ListResourceIDs(kt, resType, expr)
+			if err != nil {
+				logs.Errorf("list assigned %s failed, err: %v, ids: %v, rid: %s", resType, err, batch, kt.Rid)
+				return nil, nil, err
+			}
+			for _, id := range conflicts {
+				conflictIDs[id] = struct{}{}
+			}
+		}
+	}
+
+	assignable, relRes := splitAssignableCvm(cvmIDs, rels, conflictIDs)
+	if len(assignable) != len(cvmIDs) {
+		logs.Warnf("some cvm related resources are assigned to other biz, skip assign these cvm to biz %d, "+
+			"cvm ids: %v, assignable: %v, rid: %s", bizID, cvmIDs, assignable, kt.Rid)
+	}
+
+	return assignable, relRes, nil
+}
+
+// splitAssignableCvm 过滤出关联资源均未分配到其他业务的主机，并按资源类型汇总这些主机的关联资源
+func splitAssignableCvm(cvmIDs []string, rels []cvmRelRes, conflictIDs map[string]struct{}) ([]string, []resIDs) {
+	conflictCvm := make(map[string]struct{})
+	for _, rel := range rels {
+		if _, exists := conflictIDs[rel.resID]; exists {
+			conflictCvm[rel.cvmID] = struct{}{}
+		}
+	}
+
+	assignable := make([]string, 0, len(cvmIDs))
+	for _, id := range cvmIDs {
+		if _, exists := conflictCvm[id]; !exists {
+			assignable = append(assignable, id)
+		}
+	}
+
+	relTypeIDs := make(map[enumor.CloudResourceType][]string)
+	for _, rel := range rels {
+		if _, exists := conflictCvm[rel.cvmID]; exists {
+			continue
+		}
+		relTypeIDs[rel.resType] = append(relTypeIDs[rel.resType], rel.resID)
+	}
+
+	relRes := make([]resIDs, 0, len(relTypeIDs))
+	for _, resType := range []enumor.CloudResourceType{enumor.EipCloudResType, enumor.DiskCloudResType,
+		enumor.NetworkInterfaceCloudResType} {
+
+		if ids := relTypeIDs[resType]; len(ids) != 0 {
+			relRes = append(relRes, resIDs{resType: resType, ids: slice.Unique(ids)})
+		}
+	}
+
+	return assignable, relRes
+}
+
+// listCvmRelRes 查询主机关联的eip、硬盘及网卡
+func (svc *service) listCvmRelRes(kt *kit.Kit, cvmIDs []string) ([]cvmRelRes, error) {
+	result := make([]cvmRelRes, 0)
+	for _, batch := range slice.Split(cvmIDs, constant.BatchOperationMaxLimit) {
+		opt := &types.ListOption{
+			Filter: tools.ContainersExpression("cvm_id", batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			eipRels, err := svc.dao.EipCvmRel().List(kt, opt)
+			if err != nil {
+				logs.Errorf("list eip cvm rel failed, err: %v, cvm ids: %v, rid: %s", err, batch, kt.Rid)
+				return nil, err
+			}
+			for _, rel := range eipRels.Details {
+				result = append(result, cvmRelRes{cvmID: rel.CvmID, resType: enumor.EipCloudResType, resID: rel.EipID})
+			}
+			if uint(len(eipRels.Details)) < opt.Page.Limit {
+				break
+			}
+			opt.Page.Start += uint32(opt.Page.Limit)
+		}
+
+		opt.Page = core.NewDefaultBasePage()
+		for {
+			diskRels, err := svc.dao.DiskCvmRel().List(kt, opt)
+			if err != nil {
+				logs.Errorf("list disk cvm rel failed, err: %v, cvm ids: %v, rid: %s", err, batch, kt.Rid)
+				return nil, err
+			}
+			for _, rel := range diskRels.Details {
+				result = append(result, cvmRelRes{cvmID: rel.CvmID, resType: enumor.DiskCloudResType,
+					resID: rel.DiskID})
+			}
+			if uint(len(diskRels.Details)) < opt.Page.Limit {
+				break
+			}
+			opt.Page.Start += uint32(opt.Page.Limit)
+		}
+
+		opt.Page = core.NewDefaultBasePage()
+		for {
+			niRels, err := svc.dao.NiCvmRel().List(kt, opt)
+			if err != nil {
+				logs.Errorf("list network interface cvm rel failed, err: %v, cvm ids: %v, rid: %s", err, batch,
+					kt.Rid)
+				return nil, err
+			}
+			for _, rel := range niRels.Details {
+				result = append(result, cvmRelRes{cvmID: rel.CvmID, resType: enumor.NetworkInterfaceCloudResType,
+					resID: rel.NetworkInterfaceID})
+			}
+			if uint(len(niRels.Details)) < opt.Page.Limit {
+				break
+			}
+			opt.Page.Start += uint32(opt.Page.Limit)
+		}
+	}
+
+	return result, nil
+}
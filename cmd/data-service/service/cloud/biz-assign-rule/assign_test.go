@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bizassign
+
+import (
+	"testing"
+
+	corebizassign "hcm/pkg/api/core/cloud/biz-assign-rule"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRulesPriority(t *testing.T) {
+	// 资源 -> 命中的规则
+	resRules := map[string][]string{
+		"cvm-1": {"low", "high"},
+		"cvm-2": {"low"},
+		"cvm-3": {"high"},
+	}
+	assigned := make(map[string]int64)
+
+	match := func(rule *corebizassign.BizAssignRule) ([]string, error) {
+		ids := make([]string, 0)
+		for _, id := range []string{"cvm-1", "cvm-2", "cvm-3"} {
+			if _, exists := assigned[id]; !exists && slice.IsItemInSlice(resRules[id], rule.ID) {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+	assign := func(rule *corebizassign.BizAssignRule, ids []string) ([]string, error) {
+		for _, id := range ids {
+			assigned[id] = rule.BkBizID
+		}
+		return ids, nil
+	}
+
+	rules := []corebizassign.BizAssignRule{
+		{ID: "low", ResType: enumor.CvmCloudResType, BkBizID: 2, Priority: 10},
+		{ID: "high", ResType: enumor.CvmCloudResType, BkBizID: 1, Priority: 1},
+	}
+	result, err := applyRules(rules, match, assign)
+	assert.NoError(t, err)
+	assert.Equal(t, []corebizassign.AssignResult{
+		{RuleID: "high", ResType: enumor.CvmCloudResType, BkBizID: 1, ResIDs: []string{"cvm-1", "cvm-3"}},
+		{RuleID: "low", ResType: enumor.CvmCloudResType, BkBizID: 2, ResIDs: []string{"cvm-2"}},
+	}, result)
+	assert.Equal(t, map[string]int64{"cvm-1": 1, "cvm-2": 2, "cvm-3": 1}, assigned)
+}
+
+func TestApplyRulesSkipNotAssigned(t *testing.T) {
+	rules := []corebizassign.BizAssignRule{{ID: "rule", ResType: enumor.CvmCloudResType, BkBizID: 1}}
+	result, err := applyRules(rules,
+		func(rule *corebizassign.BizAssignRule) ([]string, error) { return []string{"cvm-1"}, nil },
+		func(rule *corebizassign.BizAssignRule, ids []string) ([]string, error) { return []string{}, nil })
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestMatchExpr(t *testing.T) {
+	userRule := tools.EqualExpression("region", "ap-guangzhou")
+	accountIDs := []string{"account-1"}
+	base := []filter.RuleFactory{
+		userRule,
+		tools.RuleEqual("bk_biz_id", constant.UnassignedBiz),
+		tools.RuleIn("account_id", accountIDs),
+	}
+
+	tests := []struct {
+		resType enumor.CloudResourceType
+		extra   []filter.RuleFactory
+	}{
+		{resType: enumor.CvmCloudResType,
+			extra: []filter.RuleFactory{tools.RuleNotEqual("bk_cloud_id", constant.UnbindBkCloudID)}},
+		{resType: enumor.VpcCloudResType, extra: []filter.RuleFactory{tools.RuleGreaterThan("bk_cloud_id", 0)}},
+		{resType: enumor.DiskCloudResType},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.resType), func(t *testing.T) {
+			got, err := matchExpr(&corebizassign.BizAssignRule{ResType: tt.resType, Rule: userRule}, accountIDs)
+			assert.NoError(t, err)
+
+			rules := append(append([]filter.RuleFactory{}, base...), tt.extra...)
+			want, err := tools.And(rules...)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestSplitAssignableCvm(t *testing.T) {
+	rels := []cvmRelRes{
+		{cvmID: "cvm-1", resType: enumor.EipCloudResType, resID: "eip-1"},
+		{cvmID: "cvm-1", resType: enumor.DiskCloudResType, resID: "disk-1"},
+		{cvmID: "cvm-2", resType: enumor.DiskCloudResType, resID: "disk-2"},
+		{cvmID: "cvm-2", resType: enumor.NetworkInterfaceCloudResType, resID: "ni-2"},
+		{cvmID: "cvm-3", resType: enumor.NetworkInterfaceCloudResType, resID: "ni-3"},
+	}
+	conflictIDs := map[string]struct{}{"disk-2": {}}
+
+	assignable, relRes := splitAssignableCvm([]string{"cvm-1", "cvm-2", "cvm-3", "cvm-4"}, rels, conflictIDs)
+	assert.Equal(t, []string{"cvm-1", "cvm-3", "cvm-4"}, assignable)
+	assert.Equal(t, []resIDs{
+		{resType: enumor.EipCloudResType, ids: []string{"eip-1"}},
+		{resType: enumor.DiskCloudResType, ids: []string{"disk-1"}},
+		{resType: enumor.NetworkInterfaceCloudResType, ids: []string{"ni-3"}},
+	}, relRes)
+}
@@ -0,0 +1,290 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bizassign
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corebizassign "hcm/pkg/api/core/cloud/biz-assign-rule"
+	dsbizassign "hcm/pkg/api/data-service/cloud/biz-assign-rule"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table/cloud"
+	tablebizassign "hcm/pkg/dal/table/cloud/biz-assign-rule"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	"hcm/pkg/dal/table/cloud/disk"
+	tablesnapshot "hcm/pkg/dal/table/cloud/disk-snapshot"
+	tableeip "hcm/pkg/dal/table/cloud/eip"
+	tablekeypair "hcm/pkg/dal/table/cloud/key-pair"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// resTypeColumnsMap 规则可使用的字段为资源表的字段以及资源标签
+var resTypeColumnsMap = map[enumor.CloudResourceType]*utils.Columns{
+	enumor.CvmCloudResType:          tablecvm.TableColumns,
+	enumor.DiskCloudResType:         disk.DiskColumns,
+	enumor.DiskSnapshotCloudResType: tablesnapshot.DiskSnapshotColumns,
+	enumor.KeyPairCloudResType:      tablekeypair.KeyPairColumns,
+	enumor.VpcCloudResType:          cloud.VpcColumns,
+	enumor.SubnetCloudResType:       cloud.SubnetColumns,
+	enumor.EipCloudResType:          tableeip.EipColumns,
+	enumor.LoadBalancerCloudResType: tablelb.LoadBalancerColumns,
+}
+
+// validateRule validate rule expression's fields are all belongs to the resource type.
+func validateRule(resType enumor.CloudResourceType, rule *filter.Expression) error {
+	columns, exists := resTypeColumnsMap[resType]
+	if !exists {
+		return fmt.Errorf("resource type %s not support auto assign to biz", resType)
+	}
+
+	columnTypes := columns.ColumnTypes()
+	columnTypes["tags.*"] = enumor.String
+	return rule.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)))
+}
+
+// BatchCreateBizAssignRule batch create biz assign rule.
+func (svc *service) BatchCreateBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbizassign.BatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablebizassign.BizAssignRuleTable, 0, len(req.Rules))
+	for _, one := range req.Rules {
+		if err := validateRule(one.ResType, one.Rule); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		rule, err := json.MarshalToString(one.Rule)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		models = append(models, tablebizassign.BizAssignRuleTable{
+			Name:     one.Name,
+			ResType:  one.ResType,
+			Rule:     tabletype.JsonField(rule),
+			BkBizID:  one.BkBizID,
+			Priority: converter.ValToPtr(one.Priority),
+			Enabled:  converter.ValToPtr(one.Enabled),
+			Memo:     one.Memo,
+			Creator:  cts.Kit.User,
+			Reviser:  cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.BizAssignRule().CreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("create biz assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create biz assign rule but return id type is not []string, id type: %T", ids)
+	}
+
+	return &core.BatchCreateResult{IDs: idList}, nil
+}
+
+// BatchUpdateBizAssignRule batch update biz assign rule.
+func (svc *service) BatchUpdateBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbizassign.BatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(req.Rules))
+	for _, one := range req.Rules {
+		ids = append(ids, one.ID)
+	}
+	opt := &types.ListOption{
+		Fields: []string{"id", "res_type"},
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.BizAssignRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list biz assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	resTypeMap := make(map[string]enumor.CloudResourceType, len(listResp.Details))
+	for _, one := range listResp.Details {
+		resTypeMap[one.ID] = one.ResType
+	}
+
+	models := make(map[string]*tablebizassign.BizAssignRuleTable, len(req.Rules))
+	for _, one := range req.Rules {
+		resType, exists := resTypeMap[one.ID]
+		if !exists {
+			return nil, errf.Newf(errf.RecordNotFound, "biz assign rule %s not found", one.ID)
+		}
+
+		model := &tablebizassign.BizAssignRuleTable{
+			Name:     one.Name,
+			BkBizID:  one.BkBizID,
+			Priority: one.Priority,
+			Enabled:  one.Enabled,
+			Memo:     one.Memo,
+			Reviser:  cts.Kit.User,
+		}
+		if one.Rule != nil {
+			if err = validateRule(resType, one.Rule); err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			rule, err := json.MarshalToString(one.Rule)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+			model.Rule = tabletype.JsonField(rule)
+		}
+		models[one.ID] = model
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for id, model := range models {
+			if err := svc.dao.BizAssignRule().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", id),
+				model); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("update biz assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizAssignRule list biz assign rule.
+func (svc *service) ListBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbizassign.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.BizAssignRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list biz assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsbizassign.ListResp{Count: result.Count}, nil
+	}
+
+	details := make([]corebizassign.BizAssignRule, 0, len(result.Details))
+	for _, one := range result.Details {
+		rule, err := convBizAssignRule(one)
+		if err != nil {
+			logs.Errorf("convert biz assign rule failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			return nil, err
+		}
+		details = append(details, *rule)
+	}
+
+	return &dsbizassign.ListResp{Details: details}, nil
+}
+
+func convBizAssignRule(one tablebizassign.BizAssignRuleTable) (*corebizassign.BizAssignRule, error) {
+	var rule *filter.Expression
+	if len(one.Rule) != 0 {
+		rule = new(filter.Expression)
+		if err := json.UnmarshalFromString(string(one.Rule), rule); err != nil {
+			return nil, fmt.Errorf("unmarshal rule failed, err: %v", err)
+		}
+	}
+
+	return &corebizassign.BizAssignRule{
+		ID:       one.ID,
+		Name:     one.Name,
+		ResType:  one.ResType,
+		Rule:     rule,
+		BkBizID:  one.BkBizID,
+		Priority: converter.PtrToVal(one.Priority),
+		Enabled:  converter.PtrToVal(one.Enabled),
+		Memo:     one.Memo,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}, nil
+}
+
+// BatchDeleteBizAssignRule batch delete biz assign rule, resources already assigned are not affected.
+func (svc *service) BatchDeleteBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbizassign.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.BizAssignRule().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("delete biz assign rule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
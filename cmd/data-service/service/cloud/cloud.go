@@ -174,6 +174,8 @@ var assignResAuditTypeMap = map[enumor.CloudResourceType]enumor.AuditResourceTyp
 	enumor.EipCloudResType:              enumor.EipAuditResType,
 	enumor.CvmCloudResType:              enumor.CvmAuditResType,
 	enumor.DiskCloudResType:             enumor.DiskAuditResType,
+	enumor.DiskSnapshotCloudResType:     enumor.DiskSnapshotAuditResType,
+	enumor.KeyPairCloudResType:          enumor.KeyPairAuditResType,
 	enumor.RouteTableCloudResType:       enumor.RouteTableAuditResType,
 	enumor.GcpFirewallRuleCloudResType:  enumor.GcpFirewallRuleAuditResType,
 	enumor.NetworkInterfaceCloudResType: enumor.NetworkInterfaceAuditResType,
@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablesnapshot "hcm/pkg/dal/table/cloud/disk-snapshot"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateDiskSnapshotExt 批量创建云硬盘快照(支持 extension 字段)
+func (svc *diskSnapshotSvc) BatchCreateDiskSnapshotExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchCreateDiskSnapshotExt[coresnapshot.TCloudExtension](cts, svc, vendor)
+	case enumor.Aws:
+		return batchCreateDiskSnapshotExt[coresnapshot.AwsExtension](cts, svc, vendor)
+	case enumor.HuaWei:
+		return batchCreateDiskSnapshotExt[coresnapshot.HuaWeiExtension](cts, svc, vendor)
+	case enumor.Azure:
+		return batchCreateDiskSnapshotExt[coresnapshot.AzureExtension](cts, svc, vendor)
+	case enumor.Gcp:
+		return batchCreateDiskSnapshotExt[coresnapshot.GcpExtension](cts, svc, vendor)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+}
+
+func batchCreateDiskSnapshotExt[T coresnapshot.Extension](cts *rest.Contexts, svc *diskSnapshotSvc,
+	vendor enumor.Vendor) (interface{}, error) {
+
+	req := new(dataproto.DiskSnapshotExtBatchCreateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablesnapshot.DiskSnapshotTable, 0, len(*req))
+		for _, one := range *req {
+			extension, err := json.MarshalToString(one.Extension)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			bkBizID := one.BkBizID
+			if bkBizID == 0 {
+				bkBizID = constant.UnassignedBiz
+			}
+
+			models = append(models, &tablesnapshot.DiskSnapshotTable{
+				Vendor:      vendor,
+				AccountID:   one.AccountID,
+				CloudID:     one.CloudID,
+				BkBizID:     bkBizID,
+				Name:        one.Name,
+				Region:      one.Region,
+				Zone:        one.Zone,
+				DiskID:      one.DiskID,
+				CloudDiskID: one.CloudDiskID,
+				DiskSize:    one.DiskSize,
+				Status:      one.Status,
+				Memo:        one.Memo,
+				Extension:   tabletype.JsonField(extension),
+				Creator:     cts.Kit.User,
+				Reviser:     cts.Kit.User,
+			})
+		}
+
+		return svc.dao.DiskSnapshot().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create disk snapshot but return id type is not []string, id type: %T", result)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
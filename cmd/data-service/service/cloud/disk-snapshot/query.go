@@ -0,0 +1,152 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tablesnapshot "hcm/pkg/dal/table/cloud/disk-snapshot"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+)
+
+// ListDiskSnapshot 查询云硬盘快照列表
+func (svc *diskSnapshotSvc) ListDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	data, err := svc.dao.DiskSnapshot().List(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*coresnapshot.BaseDiskSnapshot, len(data.Details))
+	for idx := range data.Details {
+		details[idx] = convTableToBase(&data.Details[idx])
+	}
+
+	return &dataproto.ListResult{Count: data.Count, Details: details}, nil
+}
+
+// ListDiskSnapshotExt 查询云硬盘快照列表(带 extension 字段)
+func (svc *diskSnapshotSvc) ListDiskSnapshotExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	data, err := svc.dao.DiskSnapshot().List(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convTableToExtListResult[coresnapshot.TCloudExtension](data)
+	case enumor.Aws:
+		return convTableToExtListResult[coresnapshot.AwsExtension](data)
+	case enumor.HuaWei:
+		return convTableToExtListResult[coresnapshot.HuaWeiExtension](data)
+	case enumor.Azure:
+		return convTableToExtListResult[coresnapshot.AzureExtension](data)
+	case enumor.Gcp:
+		return convTableToExtListResult[coresnapshot.GcpExtension](data)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+}
+
+func convTableToExtListResult[T coresnapshot.Extension](data *types.ListDiskSnapshotDetails) (
+	*dataproto.ListExtResult[T], error) {
+
+	details := make([]*coresnapshot.DiskSnapshot[T], len(data.Details))
+	for idx := range data.Details {
+		one := &data.Details[idx]
+		extension := new(T)
+		if len(one.Extension) != 0 {
+			if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+				return nil, fmt.Errorf("unmarshal disk snapshot(%s) extension failed, err: %v", one.ID, err)
+			}
+		}
+
+		details[idx] = &coresnapshot.DiskSnapshot[T]{
+			BaseDiskSnapshot: *convTableToBase(one),
+			Extension:        extension,
+		}
+	}
+
+	return &dataproto.ListExtResult[T]{Count: data.Count, Details: details}, nil
+}
+
+func convTableToBase(one *tablesnapshot.DiskSnapshotTable) *coresnapshot.BaseDiskSnapshot {
+	return &coresnapshot.BaseDiskSnapshot{
+		ID:            one.ID,
+		Vendor:        one.Vendor,
+		AccountID:     one.AccountID,
+		CloudID:       one.CloudID,
+		BkBizID:       one.BkBizID,
+		Name:          one.Name,
+		Region:        one.Region,
+		Zone:          one.Zone,
+		DiskID:        one.DiskID,
+		CloudDiskID:   one.CloudDiskID,
+		DiskSize:      one.DiskSize,
+		Status:        one.Status,
+		RecycleStatus: one.RecycleStatus,
+		Memo:          one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesnapshot "hcm/pkg/dal/table/cloud/disk-snapshot"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchUpdateDiskSnapshot 批量更新云硬盘快照基础字段
+func (svc *diskSnapshotSvc) BatchUpdateDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataproto.DiskSnapshotBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	updateData := &tablesnapshot.DiskSnapshotTable{
+		BkBizID: req.BkBizID,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.DiskSnapshot().Update(cts.Kit, tools.ContainersExpression("id", req.IDs),
+		updateData); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchUpdateDiskSnapshotExt 批量更新云硬盘快照(支持 extension 字段)
+func (svc *diskSnapshotSvc) BatchUpdateDiskSnapshotExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchUpdateDiskSnapshotExt[coresnapshot.TCloudExtension](cts, svc)
+	case enumor.Aws:
+		return batchUpdateDiskSnapshotExt[coresnapshot.AwsExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateDiskSnapshotExt[coresnapshot.HuaWeiExtension](cts, svc)
+	case enumor.Azure:
+		return batchUpdateDiskSnapshotExt[coresnapshot.AzureExtension](cts, svc)
+	case enumor.Gcp:
+		return batchUpdateDiskSnapshotExt[coresnapshot.GcpExtension](cts, svc)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+}
+
+func batchUpdateDiskSnapshotExt[T coresnapshot.Extension](cts *rest.Contexts, svc *diskSnapshotSvc) (
+	interface{}, error) {
+
+	req := new(dataproto.DiskSnapshotExtBatchUpdateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, len(*req))
+	for idx, one := range *req {
+		ids[idx] = one.ID
+	}
+	rawExtensions, err := svc.rawExtensions(cts, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range *req {
+			updateData := &tablesnapshot.DiskSnapshotTable{
+				Name:        one.Name,
+				Zone:        one.Zone,
+				DiskID:      one.DiskID,
+				CloudDiskID: one.CloudDiskID,
+				DiskSize:    one.DiskSize,
+				Status:      one.Status,
+				Memo:        one.Memo,
+				Reviser:     cts.Kit.User,
+			}
+
+			if one.Extension != nil {
+				rawExtension, exist := rawExtensions[one.ID]
+				if !exist {
+					return nil, fmt.Errorf("disk snapshot(%s) not exist", one.ID)
+				}
+				merged, err := json.UpdateMerge(one.Extension, string(rawExtension))
+				if err != nil {
+					return nil, fmt.Errorf("disk snapshot(%s) merge extension failed, err: %v", one.ID, err)
+				}
+				updateData.Extension = tabletype.JsonField(merged)
+			}
+
+			if err := svc.dao.DiskSnapshot().UpdateByIDWithTx(cts.Kit, txn, one.ID, updateData); err != nil {
+				return nil, fmt.Errorf("update disk snapshot(%s) failed, err: %v", one.ID, err)
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update disk snapshot ext failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// rawExtensions 查询原始的 extension 字段, 返回 {"快照ID": "原始的 extension 字段"}
+func (svc *diskSnapshotSvc) rawExtensions(cts *rest.Contexts, ids []string) (map[string]tabletype.JsonField,
+	error) {
+
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "extension"},
+	}
+	data, err := svc.dao.DiskSnapshot().List(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions := make(map[string]tabletype.JsonField, len(data.Details))
+	for _, one := range data.Details {
+		extensions[one.ID] = one.Extension
+	}
+
+	return extensions, nil
+}
@@ -20,9 +20,13 @@
 package eip
 
 import (
+	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service/cloud/eip"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 
 	"github.com/jmoiron/sqlx"
@@ -39,8 +43,35 @@ func (svc *eipSvc) BatchDeleteEip(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	delIDs := make([]string, 0)
+	listOpt := &types.ListOption{Filter: req.Filter, Page: core.NewDefaultBasePage(), Fields: []string{"id"}}
+	for {
+		listResp, err := svc.dao.Eip().List(cts.Kit, listOpt)
+		if err != nil {
+			logs.Errorf("list eip failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range listResp.Details {
+			delIDs = append(delIDs, one.ID)
+		}
+
+		if uint(len(listResp.Details)) < listOpt.Page.Limit {
+			break
+		}
+		listOpt.Page.Start += uint32(listOpt.Page.Limit)
+	}
+
+	if len(delIDs) == 0 {
+		return nil, nil
+	}
+
 	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		return nil, svc.dao.Eip().DeleteWithTx(cts.Kit, txn, req.Filter)
+		if err := svc.dao.Eip().DeleteWithTx(cts.Kit, txn, req.Filter); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.dao.ResourceTag().DeleteByResWithTx(cts.Kit, txn, enumor.EipCloudResType, delIDs)
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablekeypair "hcm/pkg/dal/table/cloud/key-pair"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateKeyPairExt 批量创建SSH密钥对(支持 extension 字段)
+func (svc *keyPairSvc) BatchCreateKeyPairExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchCreateKeyPairExt[corekeypair.TCloudExtension](cts, svc, vendor)
+	case enumor.Aws:
+		return batchCreateKeyPairExt[corekeypair.AwsExtension](cts, svc, vendor)
+	case enumor.HuaWei:
+		return batchCreateKeyPairExt[corekeypair.HuaWeiExtension](cts, svc, vendor)
+	case enumor.Azure:
+		return batchCreateKeyPairExt[corekeypair.AzureExtension](cts, svc, vendor)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+}
+
+func batchCreateKeyPairExt[T corekeypair.Extension](cts *rest.Contexts, svc *keyPairSvc,
+	vendor enumor.Vendor) (interface{}, error) {
+
+	req := new(dataproto.KeyPairExtBatchCreateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablekeypair.KeyPairTable, 0, len(*req))
+		for _, one := range *req {
+			extension, err := json.MarshalToString(one.Extension)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			bkBizID := one.BkBizID
+			if bkBizID == 0 {
+				bkBizID = constant.UnassignedBiz
+			}
+
+			models = append(models, &tablekeypair.KeyPairTable{
+				Vendor:      vendor,
+				AccountID:   one.AccountID,
+				CloudID:     one.CloudID,
+				BkBizID:     bkBizID,
+				Name:        one.Name,
+				Region:      one.Region,
+				Fingerprint: one.Fingerprint,
+				PublicKey:   one.PublicKey,
+				Memo:        one.Memo,
+				Extension:   tabletype.JsonField(extension),
+				Creator:     cts.Kit.User,
+				Reviser:     cts.Kit.User,
+			})
+		}
+
+		return svc.dao.KeyPair().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create key pair but return id type is not []string, id type: %T", result)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair SSH密钥对的DB接口
+package keypair
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the key pair service
+func InitService(cap *capability.Capability) {
+	svc := &keyPairSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateKeyPairExt", http.MethodPost, "/vendors/{vendor}/key_pairs/batch/create",
+		svc.BatchCreateKeyPairExt)
+	h.Add("ListKeyPair", http.MethodPost, "/key_pairs/list", svc.ListKeyPair)
+	h.Add("ListKeyPairExt", http.MethodPost, "/vendors/{vendor}/key_pairs/list", svc.ListKeyPairExt)
+	h.Add("BatchUpdateKeyPairExt", http.MethodPatch, "/vendors/{vendor}/key_pairs", svc.BatchUpdateKeyPairExt)
+	h.Add("BatchUpdateKeyPair", http.MethodPatch, "/key_pairs", svc.BatchUpdateKeyPair)
+	h.Add("BatchDeleteKeyPair", http.MethodDelete, "/key_pairs/batch", svc.BatchDeleteKeyPair)
+
+	h.Load(cap.WebService)
+}
+
+type keyPairSvc struct {
+	dao dao.Set
+}
@@ -58,6 +58,10 @@ func (svc *lbSvc) BatchCreateLoadBalancer(cts *rest.Contexts) (any, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return batchCreateLoadBalancer[corelb.TCloudClbExtension](cts, svc, vendor)
+	case enumor.Aws:
+		return batchCreateLoadBalancer[corelb.AwsLoadBalancerExtension](cts, svc, vendor)
+	case enumor.HuaWei:
+		return batchCreateLoadBalancer[corelb.HuaWeiLoadBalancerExtension](cts, svc, vendor)
 	default:
 		return nil, errf.New(errf.InvalidParameter, "unsupported vendor: "+string(vendor))
 	}
@@ -153,6 +157,10 @@ func (svc *lbSvc) BatchCreateTargetGroup(cts *rest.Contexts) (any, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return batchCreateTargetGroup[corelb.TCloudTargetGroupExtension](cts, svc, vendor)
+	case enumor.Aws:
+		return batchCreateTargetGroup[corelb.AwsTargetGroupExtension](cts, svc, vendor)
+	case enumor.HuaWei:
+		return batchCreateTargetGroup[corelb.HuaWeiTargetGroupExtension](cts, svc, vendor)
 	default:
 		return nil, errf.New(errf.InvalidParameter, "unsupported vendor: "+string(vendor))
 	}
@@ -235,6 +243,7 @@ func convTargetGroupCreateReqToTable[T corelb.TargetGroupExtension](kt *kit.Kit,
 	}
 
 	targetGroup := &tablelb.LoadBalancerTargetGroupTable{
+		CloudID:         tg.CloudID,
 		Name:            tg.Name,
 		Vendor:          vendor,
 		AccountID:       tg.AccountID,
@@ -498,7 +507,7 @@ func (svc *lbSvc) convRuleRel(kt *kit.Kit, listenerRuleID string, rule dataproto
 		Vendor:              rule.Vendor,
 		ListenerRuleID:      listenerRuleID,
 		CloudListenerRuleID: rule.CloudID,
-		ListenerRuleType:    enumor.Layer7RuleType,
+		ListenerRuleType:    rule.RuleType,
 		TargetGroupID:       rule.TargetGroupID,
 		CloudTargetGroupID:  rule.CloudTargetGroupID,
 		LbID:                rule.LbID,
@@ -563,6 +572,10 @@ func (svc *lbSvc) BatchCreateListener(cts *rest.Contexts) (any, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return batchCreateListener[corelb.TCloudListenerExtension](cts, svc)
+	case enumor.Aws:
+		return batchCreateListener[corelb.AwsListenerExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchCreateListener[corelb.HuaWeiListenerExtension](cts, svc)
 	default:
 		return nil, errf.New(errf.InvalidParameter, "unsupported vendor: "+string(vendor))
 	}
@@ -89,6 +89,13 @@ func (svc *lbSvc) BatchDeleteLoadBalancer(cts *rest.Contexts) (any, error) {
 			return nil, err
 		}
 
+		// 删除资源标签
+		err = svc.dao.ResourceTag().DeleteByResWithTx(cts.Kit, txn, enumor.LoadBalancerCloudResType, lbIds)
+		if err != nil {
+			logs.Errorf("delete lb tags failed, err: %v, lb_ids: %v, rid: %s", err, lbIds, cts.Kit.Rid)
+			return nil, err
+		}
+
 		// 删除负载均衡
 		delFilter := tools.ContainersExpression("id", lbIds)
 		return nil, svc.dao.LoadBalancer().DeleteWithTx(cts.Kit, txn, delFilter)
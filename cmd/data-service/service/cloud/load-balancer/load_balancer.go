@@ -53,7 +53,7 @@ func InitService(cap *capability.Capability) {
 	// 监听器
 	h.Add("GetListener", http.MethodGet, "/vendors/{vendor}/listeners/{id}", svc.GetListener)
 	h.Add("ListListener", http.MethodPost, "/load_balancers/listeners/list", svc.ListListener)
-	h.Add("ListListenerExt", http.MethodPost, "/vendors/tcloud/load_balancers/listeners/list", svc.ListListenerExt)
+	h.Add("ListListenerExt", http.MethodPost, "/vendors/{vendor}/load_balancers/listeners/list", svc.ListListenerExt)
 	h.Add("BatchCreateListener", http.MethodPost, "/vendors/{vendor}/listeners/batch/create", svc.BatchCreateListener)
 	h.Add("BatchCreateListenerWithRule", http.MethodPost, "/vendors/{vendor}/listeners/rules/batch/create",
 		svc.BatchCreateListenerWithRule)
@@ -74,6 +74,21 @@ func InitService(cap *capability.Capability) {
 	h.Add("BatchDeleteTCloudUrlRule",
 		http.MethodDelete, "/vendors/tcloud/url_rules/batch", svc.BatchDeleteTCloudUrlRule)
 	h.Add("ListTCloudUrlRule", http.MethodPost, "/vendors/tcloud/load_balancers/url_rules/list", svc.ListTCloudUrlRule)
+	// aws 七层转发规则与腾讯云共用规则表
+	h.Add("BatchCreateAwsUrlRule",
+		http.MethodPost, "/vendors/aws/url_rules/batch/create", svc.BatchCreateTCloudUrlRule)
+	h.Add("BatchUpdateAwsUrlRule",
+		http.MethodPatch, "/vendors/aws/url_rules/batch/update", svc.BatchUpdateTCloudUrlRule)
+	h.Add("BatchDeleteAwsUrlRule",
+		http.MethodDelete, "/vendors/aws/url_rules/batch", svc.BatchDeleteTCloudUrlRule)
+	h.Add("ListAwsUrlRule", http.MethodPost, "/vendors/aws/load_balancers/url_rules/list", svc.ListTCloudUrlRule)
+	// 华为云监听器的默认后端服务器组以四层规则的形式记录在规则表中
+	h.Add("BatchCreateHuaWeiUrlRule",
+		http.MethodPost, "/vendors/huawei/url_rules/batch/create", svc.BatchCreateTCloudUrlRule)
+	h.Add("BatchDeleteHuaWeiUrlRule",
+		http.MethodDelete, "/vendors/huawei/url_rules/batch", svc.BatchDeleteTCloudUrlRule)
+	h.Add("ListHuaWeiUrlRule", http.MethodPost, "/vendors/huawei/load_balancers/url_rules/list",
+		svc.ListTCloudUrlRule)
 
 	// 目标组
 	h.Add("BatchCreateTargetGroup", http.MethodPost,
@@ -83,6 +98,9 @@ func InitService(cap *capability.Capability) {
 	h.Add("GetTargetGroup", http.MethodGet, "/vendors/{vendor}/target_groups/{id}", svc.GetTargetGroup)
 	h.Add("ListTargetGroup", http.MethodPost, "/load_balancers/target_groups/list", svc.ListTargetGroup)
 	h.Add("UpdateTargetGroup", http.MethodPatch, "/vendors/{vendor}/target_groups", svc.UpdateTargetGroup)
+	h.Add("ListTargetGroupExt", http.MethodPost, "/vendors/{vendor}/target_groups/list", svc.ListTargetGroupExt)
+	h.Add("BatchUpdateTargetGroup", http.MethodPatch,
+		"/vendors/{vendor}/target_groups/batch/update", svc.BatchUpdateTargetGroup)
 	h.Add("BatchDeleteTargetGroup", http.MethodDelete, "/target_groups/batch", svc.BatchDeleteTargetGroup)
 	h.Add("BatchUpdateListenerBizInfo", http.MethodPatch,
 		"/load_balancers/target_groups/bizs/batch/update", svc.BatchUpdateTargetGroupBizInfo)
@@ -144,6 +144,7 @@ func convTableToBaseLB(one *tablelb.LoadBalancerTable) *corelb.BaseLoadBalancer
 		CloudCreatedTime:     one.CloudCreatedTime,
 		CloudStatusTime:      one.CloudStatusTime,
 		CloudExpiredTime:     one.CloudExpiredTime,
+		RecycleStatus:        one.RecycleStatus,
 		Tags:                 core.TagMap(one.Tags),
 		Memo:                 one.Memo,
 		Revision: &core.Revision{
@@ -185,6 +186,10 @@ func (svc *lbSvc) ListLoadBalancerExt(cts *rest.Contexts) (interface{}, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return convLbListResult[corelb.TCloudClbExtension](data.Details)
+	case enumor.Aws:
+		return convLbListResult[corelb.AwsLoadBalancerExtension](data.Details)
+	case enumor.HuaWei:
+		return convLbListResult[corelb.HuaWeiLoadBalancerExtension](data.Details)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -219,7 +224,11 @@ func (svc *lbSvc) GetLoadBalancer(cts *rest.Contexts) (any, error) {
 	lbTable := result.Details[0]
 	switch lbTable.Vendor {
 	case enumor.TCloud:
-		return convLoadBalancerWithExt(&lbTable)
+		return convLoadBalancerWithExt[corelb.TCloudClbExtension](&lbTable)
+	case enumor.Aws:
+		return convLoadBalancerWithExt[corelb.AwsLoadBalancerExtension](&lbTable)
+	case enumor.HuaWei:
+		return convLoadBalancerWithExt[corelb.HuaWeiLoadBalancerExtension](&lbTable)
 	default:
 		return nil, fmt.Errorf("unsupport vendor: %s", vendor)
 	}
@@ -298,6 +307,11 @@ func (svc *lbSvc) ListListener(cts *rest.Contexts) (interface{}, error) {
 
 // ListListenerExt list listener with extension.
 func (svc *lbSvc) ListListenerExt(cts *rest.Contexts) (any, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
 	req := new(core.ListReq)
 	if err := cts.DecodeInto(req); err != nil {
 		return nil, err
@@ -322,16 +336,32 @@ func (svc *lbSvc) ListListenerExt(cts *rest.Contexts) (any, error) {
 		return &protocloud.ListenerListResult{Count: result.Count}, nil
 	}
 
-	details := make([]corelb.Listener[corelb.TCloudListenerExtension], 0, len(result.Details))
-	for _, one := range result.Details {
-		tmpOne, err := convTableToListener[corelb.TCloudListenerExtension](&one)
+	switch vendor {
+	case enumor.TCloud:
+		return convListenerListResult[corelb.TCloudListenerExtension](cts.Kit, result.Details)
+	case enumor.Aws:
+		return convListenerListResult[corelb.AwsListenerExtension](cts.Kit, result.Details)
+	case enumor.HuaWei:
+		return convListenerListResult[corelb.HuaWeiListenerExtension](cts.Kit, result.Details)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+}
+
+func convListenerListResult[T corelb.ListenerExtension](kt *kit.Kit, tables []tablelb.LoadBalancerListenerTable) (
+	*core.ListResultT[corelb.Listener[T]], error) {
+
+	details := make([]corelb.Listener[T], 0, len(tables))
+	for _, one := range tables {
+		tmpOne, err := convTableToListener[T](&one)
 		if err != nil {
-			logs.Errorf("fail to conv listener with extension, err: %v, rid: %s", err, cts.Kit.Rid)
+			logs.Errorf("fail to conv listener with extension, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
 		}
 		details = append(details, *tmpOne)
 	}
 
-	return &protocloud.TCloudListenerListResult{Details: details}, nil
+	return &core.ListResultT[corelb.Listener[T]]{Details: details}, nil
 }
 
 func convTableToBaseListener(one *tablelb.LoadBalancerListenerTable) *corelb.BaseListener {
@@ -560,6 +590,79 @@ func (svc *lbSvc) ListTargetGroup(cts *rest.Contexts) (interface{}, error) {
 	return &protocloud.TargetGroupListResult{Details: details}, nil
 }
 
+// ListTargetGroupExt 查询带扩展字段的目标组列表
+func (svc *lbSvc) ListTargetGroupExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LoadBalancerTargetGroup().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list target group with extension failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list target group failed, err: %v", err)
+	}
+
+	switch vendor {
+	case enumor.Aws:
+		return convTargetGroupExtListResult[corelb.AwsTargetGroupExtension](cts.Kit, result.Count,
+			result.Details)
+	case enumor.HuaWei:
+		return convTargetGroupExtListResult[corelb.HuaWeiTargetGroupExtension](cts.Kit, result.Count,
+			result.Details)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+}
+
+func convTargetGroupExtListResult[T corelb.TargetGroupExtension](kt *kit.Kit, count uint64,
+	tables []tablelb.LoadBalancerTargetGroupTable) (*protocloud.TargetGroupExtListResult[T], error) {
+
+	details := make([]corelb.TargetGroup[T], 0, len(tables))
+	for _, one := range tables {
+		tg, err := convTableToTargetGroup[T](kt, &one)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, *tg)
+	}
+
+	return &protocloud.TargetGroupExtListResult[T]{Count: count, Details: details}, nil
+}
+
+func convTableToTargetGroup[T corelb.TargetGroupExtension](kt *kit.Kit, one *tablelb.LoadBalancerTargetGroupTable) (
+	*corelb.TargetGroup[T], error) {
+
+	base, err := convTableToBaseTargetGroup(kt, one)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := new(T)
+	if len(one.Extension) != 0 {
+		if err = json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+			logs.Errorf("unmarshal target group extension failed, id: %s, err: %v, rid: %s", one.ID, err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	return &corelb.TargetGroup[T]{BaseTargetGroup: *base, Extension: extension}, nil
+}
+
 // GetTargetGroup ...
 func (svc *lbSvc) GetTargetGroup(cts *rest.Contexts) (any, error) {
 	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
@@ -590,6 +693,10 @@ func (svc *lbSvc) GetTargetGroup(cts *rest.Contexts) (any, error) {
 	switch tgInfo.Vendor {
 	case enumor.TCloud:
 		return convTableToBaseTargetGroup(cts.Kit, &tgInfo)
+	case enumor.Aws:
+		return convTableToTargetGroup[corelb.AwsTargetGroupExtension](cts.Kit, &tgInfo)
+	case enumor.HuaWei:
+		return convTableToTargetGroup[corelb.HuaWeiTargetGroupExtension](cts.Kit, &tgInfo)
 	default:
 		return nil, fmt.Errorf("unsupport vendor: %s", vendor)
 	}
@@ -668,6 +775,20 @@ func (svc *lbSvc) GetListener(cts *rest.Contexts) (any, error) {
 			return nil, err
 		}
 		return newLblInfo, nil
+	case enumor.Aws:
+		newLblInfo, err := convTableToListener[corelb.AwsListenerExtension](&lblInfo)
+		if err != nil {
+			logs.Errorf("fail to conv listener with extension, lblID: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+			return nil, err
+		}
+		return newLblInfo, nil
+	case enumor.HuaWei:
+		newLblInfo, err := convTableToListener[corelb.HuaWeiListenerExtension](&lblInfo)
+		if err != nil {
+			logs.Errorf("fail to conv listener with extension, lblID: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+			return nil, err
+		}
+		return newLblInfo, nil
 	default:
 		return nil, fmt.Errorf("unsupport vendor: %s", vendor)
 	}
@@ -54,6 +54,10 @@ func (svc *lbSvc) BatchUpdateLoadBalancer(cts *rest.Contexts) (any, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return batchUpdateLoadBalancer[corelb.TCloudClbExtension](cts, svc)
+	case enumor.Aws:
+		return batchUpdateLoadBalancer[corelb.AwsLoadBalancerExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateLoadBalancer[corelb.HuaWeiLoadBalancerExtension](cts, svc)
 
 	default:
 		return nil, fmt.Errorf("unsupport  vendor %s", vendor)
@@ -276,6 +280,61 @@ func (svc *lbSvc) UpdateTargetGroup(cts *rest.Contexts) (interface{}, error) {
 	return nil, nil
 }
 
+// BatchUpdateTargetGroup 批量更新带扩展字段的目标组
+func (svc *lbSvc) BatchUpdateTargetGroup(cts *rest.Contexts) (any, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.Aws:
+		return batchUpdateTargetGroup[corelb.AwsTargetGroupExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateTargetGroup[corelb.HuaWeiTargetGroupExtension](cts, svc)
+	default:
+		return nil, errf.New(errf.InvalidParameter, "unsupported vendor: "+string(vendor))
+	}
+}
+
+func batchUpdateTargetGroup[T corelb.TargetGroupExtension](cts *rest.Contexts, svc *lbSvc) (any, error) {
+	req := new(dataproto.TargetGroupBatchUpdateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (any, error) {
+		for _, item := range *req {
+			extensionJSON, err := tabletype.NewJsonField(item.Extension)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			model := &tablelb.LoadBalancerTargetGroupTable{
+				Name:        item.Name,
+				BkBizID:     item.BkBizID,
+				Region:      item.Region,
+				Protocol:    item.Protocol,
+				Port:        item.Port,
+				HealthCheck: item.HealthCheck,
+				Memo:        item.Memo,
+				Extension:   extensionJSON,
+				Reviser:     cts.Kit.User,
+			}
+			if err = svc.dao.LoadBalancerTargetGroup().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				logs.Errorf("update target group by id failed, err: %v, id: %s, rid: %s", err, item.ID, cts.Kit.Rid)
+				return nil, fmt.Errorf("update target group failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+}
+
 // BatchUpdateTCloudUrlRule ..
 func (svc *lbSvc) BatchUpdateTCloudUrlRule(cts *rest.Contexts) (any, error) {
 	req := new(dataproto.TCloudUrlRuleBatchUpdateReq)
@@ -384,6 +443,10 @@ func (svc *lbSvc) BatchUpdateListener(cts *rest.Contexts) (any, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return batchUpdateListener[corelb.TCloudListenerExtension](cts)
+	case enumor.Aws:
+		return batchUpdateListener[corelb.AwsListenerExtension](cts)
+	case enumor.HuaWei:
+		return batchUpdateListener[corelb.HuaWeiListenerExtension](cts)
 	default:
 		return nil, errf.New(errf.InvalidParameter, "unsupported vendor: "+string(vendor))
 	}
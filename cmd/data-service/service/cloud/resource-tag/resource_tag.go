@@ -0,0 +1,338 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package restag
+
+import (
+	"hcm/pkg/api/core"
+	corerestag "hcm/pkg/api/core/cloud/resource-tag"
+	dsrestag "hcm/pkg/api/data-service/cloud/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	daorestag "hcm/pkg/dal/dao/cloud/resource-tag"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablerestag "hcm/pkg/dal/table/cloud/resource-tag"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// tagRes 标签所属资源的基础信息
+type tagRes struct {
+	ID        string
+	CloudID   string
+	Vendor    enumor.Vendor
+	AccountID string
+}
+
+// tagDiff 资源标签的变更集合
+type tagDiff struct {
+	creates []tablerestag.ResourceTagTable
+	updates map[string]string
+	delIDs  []string
+}
+
+// SyncResourceTag sync resource tags from cloud, tags of each resource will be replaced by the given tags.
+// resources not found in db are ignored, invalid tags are skipped.
+func (svc *service) SyncResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrestag.SyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cloudIDs := slice.Map(req.Resources, func(one dsrestag.SyncResource) string { return one.CloudID })
+	resList, err := svc.dao.ResourceTag().ListResByCloudID(cts.Kit, req.ResType, req.AccountID, cloudIDs)
+	if err != nil {
+		logs.Errorf("list %s by cloud ids failed, err: %v, rid: %s", req.ResType, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(resList) == 0 {
+		return nil, nil
+	}
+
+	resMap := make(map[string]tagRes, len(resList))
+	for _, one := range resList {
+		resMap[one.CloudID] = tagRes{ID: one.ID, CloudID: one.CloudID, Vendor: one.Vendor, AccountID: one.AccountID}
+	}
+
+	existTags, err := svc.listTagByRes(cts.Kit, req.ResType, slice.Map(resList,
+		func(one daorestag.ResBasicInfo) string { return one.ID }))
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &tagDiff{updates: make(map[string]string)}
+	for _, one := range req.Resources {
+		res, exist := resMap[one.CloudID]
+		if !exist {
+			continue
+		}
+
+		expectTags := make(map[string]string, len(one.Tags))
+		for key, value := range one.Tags {
+			if err := corerestag.ValidateTag(key, value); err != nil {
+				logs.Warnf("skip invalid tag of %s(%s), err: %v, rid: %s", req.ResType, one.CloudID, err,
+					cts.Kit.Rid)
+				continue
+			}
+			expectTags[key] = value
+		}
+
+		svc.diffTag(cts.Kit, req.ResType, res, expectTags, existTags[res.ID], diff)
+
+		for key, tag := range existTags[res.ID] {
+			if _, exist := expectTags[key]; !exist {
+				diff.delIDs = append(diff.delIDs, tag.ID)
+			}
+		}
+	}
+
+	if err = svc.applyTagDiff(cts.Kit, diff); err != nil {
+		logs.Errorf("sync %s tag failed, err: %v, account: %s, rid: %s", req.ResType, err, req.AccountID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchUpsertResourceTag batch add tags to resources, value of the existing tag key will be updated.
+func (svc *service) BatchUpsertResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrestag.BatchUpsertReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resIDs := slice.Unique(req.ResIDs)
+	infos, err := svc.dao.Cloud().ListResourceBasicInfo(cts.Kit, req.ResType, resIDs, "id", "cloud_id", "vendor",
+		"account_id")
+	if err != nil {
+		logs.Errorf("list %s basic info failed, err: %v, ids: %v, rid: %s", req.ResType, err, resIDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(infos) != len(resIDs) {
+		return nil, errf.Newf(errf.RecordNotFound, "some of %s(ids=%v) not found", req.ResType, resIDs)
+	}
+
+	existTags, err := svc.listTagByRes(cts.Kit, req.ResType, resIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	expectTags := core.NewTagMap(req.Tags...)
+	diff := &tagDiff{updates: make(map[string]string)}
+	for _, one := range infos {
+		res := tagRes{ID: one.ID, CloudID: one.CloudID, Vendor: one.Vendor, AccountID: one.AccountID}
+		svc.diffTag(cts.Kit, req.ResType, res, expectTags, existTags[res.ID], diff)
+	}
+
+	if err = svc.applyTagDiff(cts.Kit, diff); err != nil {
+		logs.Errorf("upsert %s tag failed, err: %v, ids: %v, rid: %s", req.ResType, err, resIDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchRemoveResourceTag batch remove tags of resources by tag keys.
+func (svc *service) BatchRemoveResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrestag.BatchRemoveReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr := tools.ExpressionAnd(
+		tools.RuleEqual("res_type", req.ResType),
+		tools.RuleIn("res_id", req.ResIDs),
+		tools.RuleIn("tag_key", req.TagKeys),
+	)
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.ResourceTag().DeleteWithTx(cts.Kit, txn, expr)
+	})
+	if err != nil {
+		logs.Errorf("remove %s tag failed, err: %v, ids: %v, rid: %s", req.ResType, err, req.ResIDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListResourceTag list resource tag.
+func (svc *service) ListResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrestag.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ResourceTag().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list resource tag failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsrestag.ListResp{Count: result.Count}, nil
+	}
+
+	details := make([]corerestag.ResourceTag, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corerestag.ResourceTag{
+			ID:         one.ID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			ResCloudID: one.ResCloudID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			TagKey:     one.TagKey,
+			TagValue:   converter.PtrToVal(one.TagValue),
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsrestag.ListResp{Details: details}, nil
+}
+
+// listTagByRes list all tags of the resources, returns map of resource id -> tag key -> tag.
+func (svc *service) listTagByRes(kt *kit.Kit, resType enumor.CloudResourceType, resIDs []string) (
+	map[string]map[string]tablerestag.ResourceTagTable, error) {
+
+	result := make(map[string]map[string]tablerestag.ResourceTagTable, len(resIDs))
+	for _, partIDs := range slice.Split(resIDs, int(filter.DefaultMaxInLimit)) {
+		opt := &types.ListOption{
+			Fields: []string{"id", "res_id", "tag_key", "tag_value"},
+			Filter: tools.ExpressionAnd(tools.RuleEqual("res_type", resType), tools.RuleIn("res_id", partIDs)),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			listResp, err := svc.dao.ResourceTag().List(kt, opt)
+			if err != nil {
+				logs.Errorf("list %s tag failed, err: %v, rid: %s", resType, err, kt.Rid)
+				return nil, err
+			}
+
+			for _, one := range listResp.Details {
+				if _, exist := result[one.ResID]; !exist {
+					result[one.ResID] = make(map[string]tablerestag.ResourceTagTable)
+				}
+				result[one.ResID][one.TagKey] = one
+			}
+
+			if uint(len(listResp.Details)) < opt.Page.Limit {
+				break
+			}
+			opt.Page.Start += uint32(opt.Page.Limit)
+		}
+	}
+
+	return result, nil
+}
+
+// diffTag compare expect tags with exist tags of the resource, tags to create or update are added into diff.
+func (svc *service) diffTag(kt *kit.Kit, resType enumor.CloudResourceType, res tagRes, expectTags map[string]string,
+	existTags map[string]tablerestag.ResourceTagTable, diff *tagDiff) {
+
+	for key, value := range expectTags {
+		exist, found := existTags[key]
+		if !found {
+			diff.creates = append(diff.creates, tablerestag.ResourceTagTable{
+				ResType:    resType,
+				ResID:      res.ID,
+				ResCloudID: res.CloudID,
+				Vendor:     res.Vendor,
+				AccountID:  res.AccountID,
+				TagKey:     key,
+				TagValue:   converter.ValToPtr(value),
+				Creator:    kt.User,
+				Reviser:    kt.User,
+			})
+			continue
+		}
+
+		if converter.PtrToVal(exist.TagValue) != value {
+			diff.updates[exist.ID] = value
+		}
+	}
+}
+
+// applyTagDiff create, update and delete resource tags in one transaction.
+func (svc *service) applyTagDiff(kt *kit.Kit, diff *tagDiff) error {
+	if len(diff.creates) == 0 && len(diff.updates) == 0 && len(diff.delIDs) == 0 {
+		return nil
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if len(diff.creates) != 0 {
+			if _, err := svc.dao.ResourceTag().CreateWithTx(kt, txn, diff.creates); err != nil {
+				return nil, err
+			}
+		}
+
+		for id, value := range diff.updates {
+			model := &tablerestag.ResourceTagTable{TagValue: converter.ValToPtr(value), Reviser: kt.User}
+			if err := svc.dao.ResourceTag().UpdateWithTx(kt, txn, tools.EqualExpression("id", id), model); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, partIDs := range slice.Split(diff.delIDs, int(filter.DefaultMaxInLimit)) {
+			if err := svc.dao.ResourceTag().DeleteWithTx(kt, txn, tools.ContainersExpression("id",
+				partIDs)); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	return err
+}
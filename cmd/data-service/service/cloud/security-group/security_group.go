@@ -168,6 +168,7 @@ func (svc *securityGroupSvc) ListSecurityGroup(cts *rest.Contexts) (interface{},
 			CloudCreatedTime: one.CloudCreatedTime,
 			CloudUpdateTime:  one.CloudUpdateTime,
 			Tags:             core.TagMap(one.Tags),
+			RecycleStatus:    one.RecycleStatus,
 		})
 	}
 
@@ -210,6 +211,11 @@ func (svc *securityGroupSvc) BatchDeleteSecurityGroup(cts *rest.Contexts) (inter
 			return nil, err
 		}
 
+		relFilter := tools.ContainersExpression("security_group_id", delIDs)
+		if err := svc.dao.SGRuleTemplateRel().DeleteWithTx(cts.Kit, txn, relFilter); err != nil {
+			return nil, err
+		}
+
 		delFilter := tools.ContainersExpression("id", delIDs)
 		if err := svc.dao.SecurityGroup().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
 			return nil, err
@@ -304,6 +310,7 @@ func convTableToBaseSG(sgTable *tablecloud.SecurityGroupTable) *corecloud.BaseSe
 		CloudUpdateTime:  sgTable.CloudUpdateTime,
 		Tags:             core.TagMap(sgTable.Tags),
 		AccountID:        sgTable.AccountID,
+		RecycleStatus:    sgTable.RecycleStatus,
 		Creator:          sgTable.Creator,
 		Reviser:          sgTable.Reviser,
 		CreatedAt:        sgTable.CreatedAt.String(),
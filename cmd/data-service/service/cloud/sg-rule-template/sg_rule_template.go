@@ -0,0 +1,241 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgruletpl
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresgruletpl "hcm/pkg/api/core/cloud/sg-rule-template"
+	dssgruletpl "hcm/pkg/api/data-service/cloud/sg-rule-template"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesgruletpl "hcm/pkg/dal/table/cloud/sg-rule-template"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateSGRuleTemplate batch create security group rule template.
+func (svc *service) BatchCreateSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.BatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablesgruletpl.SGRuleTemplateTable, 0, len(req.Templates))
+	for _, one := range req.Templates {
+		rules, err := json.MarshalToString(one.Rules)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		models = append(models, tablesgruletpl.SGRuleTemplateTable{
+			Name:    one.Name,
+			BkBizID: one.BkBizID,
+			Rules:   tabletype.JsonField(rules),
+			Version: 1,
+			Memo:    one.Memo,
+			Creator: cts.Kit.User,
+			Reviser: cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.SGRuleTemplate().CreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("create security group rule template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create security group rule template but return id type is not []string, id type: %T",
+			ids)
+	}
+
+	return &core.BatchCreateResult{IDs: idList}, nil
+}
+
+// BatchUpdateSGRuleTemplate batch update security group rule template, template version increased when rules changed.
+func (svc *service) BatchUpdateSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.BatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(req.Templates))
+	for _, one := range req.Templates {
+		ids = append(ids, one.ID)
+	}
+	opt := &types.ListOption{
+		Fields: []string{"id", "version"},
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.SGRuleTemplate().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list security group rule template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	versionMap := make(map[string]uint64, len(listResp.Details))
+	for _, one := range listResp.Details {
+		versionMap[one.ID] = one.Version
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Templates {
+			version, exists := versionMap[one.ID]
+			if !exists {
+				return nil, errf.Newf(errf.RecordNotFound, "security group rule template %s not found", one.ID)
+			}
+
+			model := &tablesgruletpl.SGRuleTemplateTable{
+				Name:    one.Name,
+				Memo:    one.Memo,
+				Reviser: cts.Kit.User,
+			}
+			if one.Rules != nil {
+				rules, err := json.MarshalToString(one.Rules)
+				if err != nil {
+					return nil, errf.NewFromErr(errf.InvalidParameter, err)
+				}
+				model.Rules = tabletype.JsonField(rules)
+				model.Version = version + 1
+			}
+
+			if err := svc.dao.SGRuleTemplate().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				model); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("update security group rule template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSGRuleTemplate list security group rule template.
+func (svc *service) ListSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.SGRuleTemplate().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list security group rule template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dssgruletpl.ListResp{Count: result.Count}, nil
+	}
+
+	details := make([]coresgruletpl.SGRuleTemplate, 0, len(result.Details))
+	for _, one := range result.Details {
+		rules := make([]coresgruletpl.Rule, 0)
+		if len(one.Rules) != 0 {
+			if err := json.UnmarshalFromString(string(one.Rules), &rules); err != nil {
+				logs.Errorf("unmarshal security group rule template rules failed, err: %v, id: %s, rid: %s", err,
+					one.ID, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, coresgruletpl.SGRuleTemplate{
+			ID:      one.ID,
+			Name:    one.Name,
+			BkBizID: one.BkBizID,
+			Rules:   rules,
+			Version: one.Version,
+			Memo:    one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dssgruletpl.ListResp{Details: details}, nil
+}
+
+// BatchDeleteSGRuleTemplate batch delete security group rule template and its rels.
+func (svc *service) BatchDeleteSGRuleTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		relFilter := tools.ContainersExpression("template_id", req.IDs)
+		if err := svc.dao.SGRuleTemplateRel().DeleteWithTx(cts.Kit, txn, relFilter); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.SGRuleTemplate().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("id", req.IDs)); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete security group rule template failed, err: %v, ids: %v, rid: %s", err, req.IDs,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,192 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgruletpl
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresgruletpl "hcm/pkg/api/core/cloud/sg-rule-template"
+	dssgruletpl "hcm/pkg/api/data-service/cloud/sg-rule-template"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesgruletpl "hcm/pkg/dal/table/cloud/sg-rule-template"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateSGRuleTemplateRel batch create security group rule template rel.
+func (svc *service) BatchCreateSGRuleTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.RelBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablesgruletpl.SGRuleTemplateRelTable, 0, len(req.Rels))
+	for _, one := range req.Rels {
+		models = append(models, tablesgruletpl.SGRuleTemplateRelTable{
+			TemplateID:      one.TemplateID,
+			SecurityGroupID: one.SecurityGroupID,
+			Vendor:          one.Vendor,
+			AccountID:       one.AccountID,
+			ApplyStatus:     enumor.SGRuleTemplateApplyPending,
+			Creator:         cts.Kit.User,
+			Reviser:         cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.SGRuleTemplateRel().CreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("create security group rule template rel failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create security group rule template rel but return id type is not []string, "+
+			"id type: %T", ids)
+	}
+
+	return &core.BatchCreateResult{IDs: idList}, nil
+}
+
+// BatchUpdateSGRuleTemplateRel batch update security group rule template rel apply status.
+func (svc *service) BatchUpdateSGRuleTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.RelBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Rels {
+			model := &tablesgruletpl.SGRuleTemplateRelTable{
+				AppliedVersion: one.AppliedVersion,
+				ApplyStatus:    one.ApplyStatus,
+				ApplyMessage:   one.ApplyMessage,
+				AppliedAt:      one.AppliedAt,
+				Reviser:        cts.Kit.User,
+			}
+			if err := svc.dao.SGRuleTemplateRel().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				model); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("update security group rule template rel failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSGRuleTemplateRel list security group rule template rel.
+func (svc *service) ListSGRuleTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.RelListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.SGRuleTemplateRel().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list security group rule template rel failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dssgruletpl.RelListResp{Count: result.Count}, nil
+	}
+
+	details := make([]coresgruletpl.SGRuleTemplateRel, 0, len(result.Details))
+	for _, one := range result.Details {
+		rel := coresgruletpl.SGRuleTemplateRel{
+			ID:              one.ID,
+			TemplateID:      one.TemplateID,
+			SecurityGroupID: one.SecurityGroupID,
+			Vendor:          one.Vendor,
+			AccountID:       one.AccountID,
+			AppliedVersion:  one.AppliedVersion,
+			ApplyStatus:     one.ApplyStatus,
+			AppliedAt:       one.AppliedAt,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+		if one.ApplyMessage != nil {
+			rel.ApplyMessage = *one.ApplyMessage
+		}
+		details = append(details, rel)
+	}
+
+	return &dssgruletpl.RelListResp{Details: details}, nil
+}
+
+// BatchDeleteSGRuleTemplateRel batch delete security group rule template rel.
+func (svc *service) BatchDeleteSGRuleTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssgruletpl.RelBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := svc.dao.SGRuleTemplateRel().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+		return nil, err
+	})
+	if err != nil {
+		logs.Errorf("delete security group rule template rel failed, err: %v, ids: %v, rid: %s", err, req.IDs,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
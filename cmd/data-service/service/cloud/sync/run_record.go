@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	coresync "hcm/pkg/api/core/cloud/sync"
+	dssync "hcm/pkg/api/data-service/cloud/sync"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tablesync "hcm/pkg/dal/table/cloud/sync"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateSyncRunRecord create sync run record.
+func (svc *service) BatchCreateSyncRunRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssync.CreateRunRecordReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	recordIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]tablesync.SyncRunRecordTable, 0, len(req.Items))
+		for _, item := range req.Items {
+			models = append(models, tablesync.SyncRunRecordTable{
+				Vendor:       item.Vendor,
+				AccountID:    item.AccountID,
+				Scope:        item.Scope,
+				Region:       item.Region,
+				ResType:      item.ResType,
+				State:        item.State,
+				Added:        item.Added,
+				Updated:      item.Updated,
+				Deleted:      item.Deleted,
+				FailedReason: truncateFailedReason(item.FailedReason),
+				Rid:          item.Rid,
+				StartedAt:    item.StartedAt,
+				EndedAt:      item.EndedAt,
+				CostMS:       uint64(max(item.EndedAt.Sub(item.StartedAt).Milliseconds(), 0)),
+				Creator:      cts.Kit.User,
+			})
+		}
+		ids, err := svc.dao.SyncRunRecord().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create sync run record failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create sync run record commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := recordIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create sync run record but return id type not string, id type: %v",
+			reflect.TypeOf(recordIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// truncateFailedReason 失败原因超出字段长度时截断，避免因错误信息过长导致记录写入失败
+func truncateFailedReason(reason string) string {
+	runes := []rune(reason)
+	if len(runes) <= tablesync.MaxSyncFailedReasonLength {
+		return reason
+	}
+
+	return string(runes[:tablesync.MaxSyncFailedReasonLength])
+}
+
+// ListSyncRunRecord list sync run record.
+func (svc *service) ListSyncRunRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.SyncRunRecord().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list sync run record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list sync run record failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &dssync.ListRunRecordResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coresync.SyncRunRecord, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coresync.SyncRunRecord(one))
+	}
+
+	return &dssync.ListRunRecordResult{Details: details}, nil
+}
@@ -40,6 +40,10 @@ func InitService(cap *capability.Capability) {
 	h.Add("BatchCreateAccountSD", http.MethodPost, "/account_sync_details/batch/create", svc.BatchCreateAccountSD)
 	h.Add("BatchUpdateAccountSD", http.MethodPatch, "/account_sync_details/batch/update", svc.BatchUpdateAccountSD)
 
+	h.Add("ListSyncRunRecord", http.MethodPost, "/sync_run_records/list", svc.ListSyncRunRecord)
+	h.Add("BatchCreateSyncRunRecord", http.MethodPost, "/sync_run_records/batch/create",
+		svc.BatchCreateSyncRunRecord)
+
 	h.Load(cap.WebService)
 }
 
@@ -480,6 +480,11 @@ func (svc *vpcSvc) BatchDeleteVpc(cts *rest.Contexts) (interface{}, error) {
 		delVpcIDs[index] = one.ID
 	}
 
+	delSubnetIDs, err := svc.listSubnetIDsByVpc(cts.Kit, delVpcIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		delVpcFilter := tools.ContainersExpression("id", delVpcIDs)
 		if err := svc.dao.Vpc().BatchDeleteWithTx(cts.Kit, txn, delVpcFilter); err != nil {
@@ -491,6 +496,16 @@ func (svc *vpcSvc) BatchDeleteVpc(cts *rest.Contexts) (interface{}, error) {
 			return nil, err
 		}
 
+		err := svc.dao.ResourceTag().DeleteByResWithTx(cts.Kit, txn, enumor.VpcCloudResType, delVpcIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		err = svc.dao.ResourceTag().DeleteByResWithTx(cts.Kit, txn, enumor.SubnetCloudResType, delSubnetIDs)
+		if err != nil {
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -501,6 +516,34 @@ func (svc *vpcSvc) BatchDeleteVpc(cts *rest.Contexts) (interface{}, error) {
 	return nil, nil
 }
 
+// listSubnetIDsByVpc 查询vpc下的所有子网id，用于级联删除子网的资源标签
+func (svc *vpcSvc) listSubnetIDsByVpc(kt *kit.Kit, vpcIDs []string) ([]string, error) {
+	subnetIDs := make([]string, 0)
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("vpc_id", vpcIDs),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	for {
+		result, err := svc.dao.Subnet().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list subnet by vpc failed, err: %v, vpc_ids: %v, rid: %s", err, vpcIDs, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			subnetIDs = append(subnetIDs, one.ID)
+		}
+
+		if uint(len(result.Details)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return subnetIDs, nil
+}
+
 // ListVpcExt ...
 func (svc *vpcSvc) ListVpcExt(cts *rest.Contexts) (interface{}, error) {
 	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
@@ -0,0 +1,331 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaccountset "hcm/pkg/dal/table/account-set"
+	tableapplication "hcm/pkg/dal/table/application"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// secretFields 各类账号扩展字段中加密存储的字段
+var secretFields = []string{"cloud_secret_key", "cloud_service_secret_key", "cloud_client_secret_key",
+	"cloud_init_password"}
+
+// applicationSecretFields 申请单内容中加密存储的字段，如主机申请的登录密码
+var applicationSecretFields = []string{"password", "confirmed_password"}
+
+// secretRecord 带有加密字段的记录，Data 为账号的扩展字段或申请单的内容
+type secretRecord struct {
+	ID   string
+	Data tabletype.JsonField
+	Memo *string
+}
+
+// reEncryptOption 重新加密一类记录的参数
+type reEncryptOption struct {
+	// column 加密字段所在的列
+	column string
+	filter *filter.Expression
+	// reEncrypt 重新加密 Data 中的加密字段，返回重新加密后的数据及是否有变化
+	reEncrypt func(rotator cryptography.Rotator, data tabletype.JsonField) (tabletype.JsonField, bool, error)
+}
+
+// accountReEncryptOption 账号类记录的扩展字段重新加密参数
+var accountReEncryptOption = reEncryptOption{
+	column:    "extension",
+	filter:    tools.AllExpression(),
+	reEncrypt: reEncryptExtension,
+}
+
+// ReEncryptResult 重新加密的结果
+type ReEncryptResult struct {
+	Total       int      `json:"total"`
+	ReEncrypted int      `json:"re_encrypted"`
+	FailedIDs   []string `json:"failed_ids"`
+}
+
+// ReEncryptSecrets 将账号、二级账号、一级账号及申请单内容中加密存储的密钥使用当前最新的密钥重新加密，可以在线重复执行
+func (s *Service) ReEncryptSecrets(kt *kit.Kit) (interface{}, error) {
+	rotator, ok := s.cipher.(cryptography.Rotator)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "crypto keys are not configured, can not re-encrypt secrets")
+	}
+
+	result := make(map[string]*ReEncryptResult)
+	var err error
+
+	result["account"], err = reEncryptRecords(kt, rotator, accountReEncryptOption,
+		func(opt *types.ListOption) ([]secretRecord, error) {
+			resp, err := s.dao.Account().List(kt, opt)
+			if err != nil {
+				return nil, err
+			}
+			records := make([]secretRecord, 0, len(resp.Details))
+			for _, one := range resp.Details {
+				records = append(records, secretRecord{ID: one.ID, Data: one.Extension, Memo: one.Memo})
+			}
+			return records, nil
+		},
+		func(record secretRecord) error {
+			model := &tablecloud.AccountTable{Extension: record.Data, Memo: record.Memo}
+			return s.dao.Account().Update(kt, tools.EqualExpression("id", record.ID), model)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	result["main_account"], err = reEncryptRecords(kt, rotator, accountReEncryptOption,
+		func(opt *types.ListOption) ([]secretRecord, error) {
+			resp, err := s.dao.MainAccount().List(kt, opt)
+			if err != nil {
+				return nil, err
+			}
+			records := make([]secretRecord, 0, len(resp.Details))
+			for _, one := range resp.Details {
+				records = append(records, secretRecord{ID: one.ID, Data: one.Extension, Memo: one.Memo})
+			}
+			return records, nil
+		},
+		func(record secretRecord) error {
+			model := &tableaccountset.MainAccountTable{Extension: record.Data, Memo: record.Memo}
+			return s.dao.MainAccount().Update(kt, tools.EqualExpression("id", record.ID), model)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	result["root_account"], err = reEncryptRecords(kt, rotator, accountReEncryptOption,
+		func(opt *types.ListOption) ([]secretRecord, error) {
+			resp, err := s.dao.RootAccount().List(kt, opt)
+			if err != nil {
+				return nil, err
+			}
+			records := make([]secretRecord, 0, len(resp.Details))
+			for _, one := range resp.Details {
+				records = append(records, secretRecord{ID: one.ID, Data: one.Extension, Memo: one.Memo})
+			}
+			return records, nil
+		},
+		func(record secretRecord) error {
+			model := &tableaccountset.RootAccountTable{Extension: record.Data, Memo: record.Memo}
+			return s.dao.RootAccount().Update(kt, tools.EqualExpression("id", record.ID), model)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// 账号录入及主机申请的单据内容中也加密存储了密钥和密码，需要一起重新加密，否则旧密钥无法下线
+	applicationOpt := reEncryptOption{
+		column:    "content",
+		filter:    tools.ContainersExpression("type", []enumor.ApplicationType{enumor.AddAccount, enumor.CreateCvm}),
+		reEncrypt: reEncryptApplicationContent,
+	}
+	result["application"], err = reEncryptRecords(kt, rotator, applicationOpt,
+		func(opt *types.ListOption) ([]secretRecord, error) {
+			resp, err := s.dao.Application().List(kt, opt)
+			if err != nil {
+				return nil, err
+			}
+			records := make([]secretRecord, 0, len(resp.Details))
+			for _, one := range resp.Details {
+				records = append(records, secretRecord{ID: one.ID, Data: one.Content, Memo: one.Memo})
+			}
+			return records, nil
+		},
+		func(record secretRecord) error {
+			model := &tableapplication.ApplicationTable{Content: record.Data, Memo: record.Memo}
+			return s.dao.Application().Update(kt, tools.EqualExpression("id", record.ID), model)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	logs.Infof("re-encrypt secrets with key %s finished, result: %s, rid: %s", rotator.PrimaryKeyID(),
+		logs.ObjectEncode(result), kt.Rid)
+	return result, nil
+}
+
+// reEncryptRecords 分页查询记录，将其中的加密字段重新加密后更新，单条记录失败不影响其他记录
+func reEncryptRecords(kt *kit.Kit, rotator cryptography.Rotator, reOpt reEncryptOption,
+	list func(opt *types.ListOption) ([]secretRecord, error), update func(record secretRecord) error) (
+	*ReEncryptResult, error) {
+
+	result := &ReEncryptResult{FailedIDs: make([]string, 0)}
+	opt := &types.ListOption{
+		Fields: []string{"id", reOpt.column, "memo"},
+		Filter: reOpt.filter,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "id"},
+	}
+
+	for {
+		records, err := list(opt)
+		if err != nil {
+			logs.Errorf("list records to re-encrypt failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, record := range records {
+			result.Total++
+
+			data, changed, err := reOpt.reEncrypt(rotator, record.Data)
+			if err != nil {
+				logs.Errorf("re-encrypt %s %s failed, err: %v, rid: %s", record.ID, reOpt.column, err, kt.Rid)
+				result.FailedIDs = append(result.FailedIDs, record.ID)
+				continue
+			}
+
+			if !changed {
+				continue
+			}
+
+			record.Data = data
+			if err = update(record); err != nil {
+				logs.Errorf("update %s re-encrypted %s failed, err: %v, rid: %s", record.ID, reOpt.column, err,
+					kt.Rid)
+				result.FailedIDs = append(result.FailedIDs, record.ID)
+				continue
+			}
+			result.ReEncrypted++
+		}
+
+		if uint(len(records)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func reEncryptExtension(rotator cryptography.Rotator, extension tabletype.JsonField) (tabletype.JsonField, bool,
+	error) {
+
+	if extension.IsEmpty() {
+		return extension, false, nil
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.UnmarshalFromString(string(extension), &fields); err != nil {
+		return "", false, fmt.Errorf("unmarshal extension failed, err: %v", err)
+	}
+
+	changed, err := reEncryptFields(rotator, fields, secretFields)
+	if err != nil {
+		return "", false, err
+	}
+
+	if !changed {
+		return extension, false, nil
+	}
+
+	result, err := json.MarshalToString(fields)
+	if err != nil {
+		return "", false, fmt.Errorf("marshal extension failed, err: %v", err)
+	}
+
+	return tabletype.JsonField(result), true, nil
+}
+
+// reEncryptApplicationContent 重新加密申请单内容，账号录入单据的密钥在 extension 中，主机申请单据的密码在顶层字段中
+func reEncryptApplicationContent(rotator cryptography.Rotator, content tabletype.JsonField) (tabletype.JsonField,
+	bool, error) {
+
+	if content.IsEmpty() {
+		return content, false, nil
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.UnmarshalFromString(string(content), &fields); err != nil {
+		return "", false, fmt.Errorf("unmarshal content failed, err: %v", err)
+	}
+
+	changed := false
+	// gcp 主机申请的密码未加密存储
+	if vendor, _ := fields["vendor"].(string); enumor.Vendor(vendor) != enumor.Gcp {
+		fieldChanged, err := reEncryptFields(rotator, fields, applicationSecretFields)
+		if err != nil {
+			return "", false, err
+		}
+		changed = changed || fieldChanged
+	}
+
+	if extension, ok := fields["extension"].(map[string]interface{}); ok {
+		fieldChanged, err := reEncryptFields(rotator, extension, secretFields)
+		if err != nil {
+			return "", false, err
+		}
+		changed = changed || fieldChanged
+	}
+
+	if !changed {
+		return content, false, nil
+	}
+
+	result, err := json.MarshalToString(fields)
+	if err != nil {
+		return "", false, fmt.Errorf("marshal content failed, err: %v", err)
+	}
+
+	return tabletype.JsonField(result), true, nil
+}
+
+// reEncryptFields 重新加密 fields 中指定的字段，密文相同的字段重新加密后仍保持相同，如密码和确认密码
+func reEncryptFields(rotator cryptography.Rotator, fields map[string]interface{}, names []string) (bool, error) {
+	reEncryptedMap := make(map[string]string)
+	changed := false
+	for _, name := range names {
+		encrypted, ok := fields[name].(string)
+		if !ok || len(encrypted) == 0 {
+			continue
+		}
+
+		if reEncrypted, exists := reEncryptedMap[encrypted]; exists {
+			fields[name] = reEncrypted
+			continue
+		}
+
+		reEncrypted, fieldChanged, err := rotator.ReEncryptFromBase64(encrypted)
+		if err != nil {
+			return false, fmt.Errorf("re-encrypt %s failed, err: %v", name, err)
+		}
+		reEncryptedMap[encrypted] = reEncrypted
+
+		if fieldChanged {
+			fields[name] = reEncrypted
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
@@ -52,22 +52,27 @@ import (
 	accountbizrel "hcm/cmd/data-service/service/cloud/account-biz-rel"
 	argstpl "hcm/cmd/data-service/service/cloud/argument-template"
 	"hcm/cmd/data-service/service/cloud/bill"
+	bizassign "hcm/cmd/data-service/service/cloud/biz-assign-rule"
 	"hcm/cmd/data-service/service/cloud/cert"
 	"hcm/cmd/data-service/service/cloud/cvm"
 	"hcm/cmd/data-service/service/cloud/disk"
 	diskcvmrel "hcm/cmd/data-service/service/cloud/disk-cvm-rel"
+	disksnapshot "hcm/cmd/data-service/service/cloud/disk-snapshot"
 	"hcm/cmd/data-service/service/cloud/eip"
 	eipcvmrel "hcm/cmd/data-service/service/cloud/eip-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/image"
+	keypair "hcm/cmd/data-service/service/cloud/key-pair"
 	loadbalancer "hcm/cmd/data-service/service/cloud/load-balancer"
 	networkinterface "hcm/cmd/data-service/service/cloud/network-interface"
 	networkcvmrel "hcm/cmd/data-service/service/cloud/network-interface-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/region"
 	resourcegroup "hcm/cmd/data-service/service/cloud/resource-group"
+	restag "hcm/cmd/data-service/service/cloud/resource-tag"
 	routetable "hcm/cmd/data-service/service/cloud/route-table"
 	securitygroup "hcm/cmd/data-service/service/cloud/security-group"
 	sgcomrel "hcm/cmd/data-service/service/cloud/security-group-common-rel"
 	sgcvmrel "hcm/cmd/data-service/service/cloud/security-group-cvm-rel"
+	sgruletpl "hcm/cmd/data-service/service/cloud/sg-rule-template"
 	subaccount "hcm/cmd/data-service/service/cloud/sub-account"
 	sync "hcm/cmd/data-service/service/cloud/sync"
 	"hcm/cmd/data-service/service/cloud/zone"
@@ -140,9 +145,7 @@ func NewService() (*Service, error) {
 
 // newCipherFromConfig 根据配置文件里的加密配置，选择配置的算法并生成对应的加解密器
 func newCipherFromConfig(cryptoConfig cc.Crypto) (cryptography.Crypto, error) {
-	// TODO: 目前只支持国际加密，还未支持中国国家商业加密，待后续支持再调整
-	cfg := cryptoConfig.AesGcm
-	return cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+	return cryptography.NewFromConfig(cryptoConfig)
 }
 
 // ListenAndServeRest listen and serve the restful server
@@ -224,6 +227,8 @@ func (s *Service) apiSet() *restful.Container {
 	cloud.InitCloudService(capability)
 	auth.InitAuthService(capability)
 	disk.InitService(capability)
+	disksnapshot.InitService(capability)
+	keypair.InitService(capability)
 	region.InitRegionService(capability)
 	resourcegroup.InitAzureResourceGroupService(capability)
 	audit.InitAuditService(capability)
@@ -272,6 +277,9 @@ func (s *Service) apiSet() *restful.Container {
 	billexchangerate.InitService(capability)
 	billsyncrecord.InitService(capability)
 	globalconfig.InitService(capability)
+	sgruletpl.InitService(capability)
+	restag.InitService(capability)
+	bizassign.InitService(capability)
 
 	task.InitService(capability)
 
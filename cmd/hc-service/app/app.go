@@ -77,6 +77,8 @@ func (ds *hcService) prepare(opt *options.Option) error {
 	network := cc.HCService().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 	adptmetric.InitCloudApiMetrics(metrics.Register())
+	metrics.InitResSyncMetrics(metrics.Register())
+	metrics.InitCredentialCacheMetrics(metrics.Register())
 
 	// register hc service.
 	svcOpt := serviced.NewServiceOption(cc.HCServiceName, cc.HCService().Network, opt.Sys)
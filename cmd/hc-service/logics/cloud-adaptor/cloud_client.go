@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/gcp"
 	"hcm/pkg/adaptor/huawei"
 	"hcm/pkg/adaptor/tcloud"
+	"hcm/pkg/adaptor/types"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
@@ -50,70 +51,125 @@ func (cli *CloudAdaptorClient) Adaptor() *adaptor.Adaptor {
 	return cli.adaptor
 }
 
+// InvalidateCredentialCache invalidate the credential and client cache of account.
+func (cli *CloudAdaptorClient) InvalidateCredentialCache(accountID string) {
+	cli.secretCli.InvalidateCache(accountID)
+}
+
+// RoleCredential return role credential provider.
+func (cli *CloudAdaptorClient) RoleCredential() *RoleCredentialProvider {
+	return cli.secretCli.role
+}
+
 // TCloud return tcloud client.
 func (cli *CloudAdaptorClient) TCloud(kt *kit.Kit, accountID string) (tcloud.TCloud, error) {
-	secret, err := cli.secretCli.TCloudSecret(kt, accountID)
-	if err != nil {
-		return nil, err
+	// 异步任务使用限频重试的云适配器，与其他请求的分开缓存
+	retry := kt.RequestSource == enumor.AsynchronousTasks
+	item := clientItem
+	if retry {
+		item = asyncClientItem
 	}
 
-	client, err := cli.adaptor.TCloud(secret)
-	if err != nil {
-		return nil, err
-	}
-	client.SetRateLimitRetryWithRandomInterval(kt.RequestSource == enumor.AsynchronousTasks)
+	return getOrBuildClient(cli.secretCli.accountCache(), accountID, item, func() (tcloud.TCloud, bool, error) {
+		secret, err := cli.secretCli.TCloudSecret(kt, accountID)
+		if err != nil {
+			return nil, false, err
+		}
 
-	return client, nil
+		client, err := cli.adaptor.TCloud(secret)
+		if err != nil {
+			return nil, false, err
+		}
+		client.SetRateLimitRetryWithRandomInterval(retry)
+
+		return client, isLongTermSecret(secret), nil
+	})
 }
 
 // Aws return aws client.
 func (cli *CloudAdaptorClient) Aws(kt *kit.Kit, accountID string) (*aws.Aws, error) {
-	secret, cloudAccountID, site, err := cli.secretCli.AwsSecret(kt, accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	return cli.adaptor.Aws(secret, cloudAccountID, site)
+	return getOrBuildClient(cli.secretCli.accountCache(), accountID, clientItem, func() (*aws.Aws, bool, error) {
+		secret, cloudAccountID, site, err := cli.secretCli.AwsSecret(kt, accountID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		client, err := cli.adaptor.Aws(secret, cloudAccountID, site)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return client, isLongTermSecret(secret), nil
+	})
 }
 
 // HuaWei return huawei client.
 func (cli *CloudAdaptorClient) HuaWei(kt *kit.Kit, accountID string) (*huawei.HuaWei, error) {
-	secret, err := cli.secretCli.HuaWeiSecret(kt, accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	return cli.adaptor.HuaWei(secret)
+	return getOrBuildClient(cli.secretCli.accountCache(), accountID, clientItem,
+		func() (*huawei.HuaWei, bool, error) {
+			secret, err := cli.secretCli.HuaWeiSecret(kt, accountID)
+			if err != nil {
+				return nil, false, err
+			}
+
+			client, err := cli.adaptor.HuaWei(secret)
+			if err != nil {
+				return nil, false, err
+			}
+
+			return client, isLongTermSecret(secret), nil
+		})
 }
 
 // Gcp return gcp client.
 func (cli *CloudAdaptorClient) Gcp(kt *kit.Kit, accountID string) (*gcp.Gcp, error) {
-	cred, err := cli.secretCli.GcpCredential(kt, accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	return cli.adaptor.Gcp(cred)
+	return getOrBuildClient(cli.secretCli.accountCache(), accountID, clientItem, func() (*gcp.Gcp, bool, error) {
+		cred, err := cli.secretCli.GcpCredential(kt, accountID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		client, err := cli.adaptor.Gcp(cred)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return client, true, nil
+	})
 }
 
 // GcpProxy return gcp proxy client.
 func (cli *CloudAdaptorClient) GcpProxy(kt *kit.Kit, accountID string) (*gcp.Gcp, error) {
-	cred, err := cli.secretCli.GcpRegisterCredential(kt, accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	return cli.adaptor.Gcp(cred)
+	return getOrBuildClient(cli.secretCli.accountCache(), accountID, proxyClientItem, func() (*gcp.Gcp, bool, error) {
+		cred, err := cli.secretCli.GcpRegisterCredential(kt, accountID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		client, err := cli.adaptor.Gcp(cred)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return client, true, nil
+	})
 }
 
 // Azure return azure client.
 func (cli *CloudAdaptorClient) Azure(kt *kit.Kit, accountID string) (*azure.Azure, error) {
-	cred, err := cli.secretCli.AzureCredential(kt, accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	return cli.adaptor.Azure(cred)
+	return getOrBuildClient(cli.secretCli.accountCache(), accountID, clientItem, func() (*azure.Azure, bool, error) {
+		cred, err := cli.secretCli.AzureCredential(kt, accountID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		client, err := cli.adaptor.Azure(cred)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return client, true, nil
+	})
 }
 
 // AwsRoot return aws root client.
@@ -146,6 +202,22 @@ func (cli *CloudAdaptorClient) HuaWeiRoot(kt *kit.Kit, accountID string) (*huawe
 	return cli.adaptor.HuaWei(secret)
 }
 
+// TCloudRoot return tcloud client of root account.
+func (cli *CloudAdaptorClient) TCloudRoot(kt *kit.Kit, accountID string) (tcloud.TCloud, error) {
+	secret, err := cli.secretCli.TCloudRootSecret(kt, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := cli.adaptor.TCloud(secret)
+	if err != nil {
+		return nil, err
+	}
+	client.SetRateLimitRetryWithRandomInterval(kt.RequestSource == enumor.AsynchronousTasks)
+
+	return client, nil
+}
+
 // AzureRoot return azure client.
 func (cli *CloudAdaptorClient) AzureRoot(kt *kit.Kit, accountID string) (*azure.Azure, error) {
 	cred, err := cli.secretCli.AzureRootCredential(kt, accountID)
@@ -155,3 +227,8 @@ func (cli *CloudAdaptorClient) AzureRoot(kt *kit.Kit, accountID string) (*azure.
 
 	return cli.adaptor.Azure(cred)
 }
+
+// isLongTermSecret 是否为长期密钥，使用临时凭证构造的云适配器会随凭证过期，不缓存
+func isLongTermSecret(secret *types.BaseSecret) bool {
+	return len(secret.CloudSessionToken) == 0
+}
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudadaptor
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/metrics"
+	"hcm/pkg/tools/converter"
+)
+
+// credentialCache 账号凭证缓存，按账号ID缓存从data-service获取的账号信息及由其构造的云适配器，避免每次构造云适配器时都查询
+// 并解密一次密钥。账号更新或删除时由cloud-server主动通知失效，账号信息与云适配器一起失效，TTL作为通知丢失时的兜底，
+// 超出容量时淘汰最久未使用的条目。
+type credentialCache struct {
+	lock    sync.Mutex
+	enabled bool
+	ttl     time.Duration
+	maxSize int
+	items   map[string]*list.Element
+	lru     *list.List
+	// generations 账号的失效版本，每次失效时递增，获取前后版本不一致时说明获取期间账号已失效，获取结果不写入缓存。
+	// 只记录被失效过的账号，账号数量有限，不做淘汰
+	generations map[string]uint64
+}
+
+// 缓存条目中的缓存项，云适配器按构造方式区分
+const (
+	accountItem     = "account"
+	clientItem      = "client"
+	asyncClientItem = "async_client"
+	proxyClientItem = "proxy_client"
+)
+
+type credentialCacheEntry struct {
+	accountID string
+	// values 按缓存项缓存账号信息及由其构造的云适配器
+	values   map[string]any
+	expireAt time.Time
+}
+
+func newCredentialCache(opt cc.CredentialCacheOption) *credentialCache {
+	return &credentialCache{
+		// 未加载配置时(如单元测试)不开启缓存
+		enabled:     !opt.Disable && opt.TTL != nil && opt.MaxSize > 0,
+		ttl:         converter.PtrToVal(opt.TTL),
+		maxSize:     opt.MaxSize,
+		items:       make(map[string]*list.Element),
+		lru:         list.New(),
+		generations: make(map[string]uint64),
+	}
+}
+
+// get 获取未过期的缓存项，过期的条目会被移除
+func (c *credentialCache) get(accountID, item string) (any, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, exist := c.items[accountID]
+	if !exist {
+		return nil, false
+	}
+
+	entry := elem.Value.(*credentialCacheEntry)
+	if time.Now().After(entry.expireAt) {
+		c.remove(elem)
+		metrics.ObserveCredentialInvalidate("expire", 1)
+		return nil, false
+	}
+
+	value, exist := entry.values[item]
+	if !exist {
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return value, true
+}
+
+// generation 获取账号当前的失效版本
+func (c *credentialCache) generation(accountID string) uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.generations[accountID]
+}
+
+// set 设置缓存项，账号在获取期间被失效(版本不一致)时不写入。条目的有效期从首次写入开始计算，由账号信息构造的云适配器
+// 与账号信息同时过期。超出容量时淘汰最久未使用的条目
+func (c *credentialCache) set(accountID, item string, value any, generation uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.generations[accountID] != generation {
+		return
+	}
+
+	if elem, exist := c.items[accountID]; exist {
+		elem.Value.(*credentialCacheEntry).values[item] = value
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.items[accountID] = c.lru.PushFront(&credentialCacheEntry{accountID: accountID,
+		values: map[string]any{item: value}, expireAt: time.Now().Add(c.ttl)})
+
+	evicted := 0
+	for c.lru.Len() > c.maxSize {
+		c.remove(c.lru.Back())
+		evicted++
+	}
+	metrics.ObserveCredentialInvalidate("evict", evicted)
+}
+
+// invalidate 移除账号的所有缓存项，并递增失效版本，避免失效前开始的获取将旧数据写回缓存
+func (c *credentialCache) invalidate(accountID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.generations[accountID]++
+
+	elem, exist := c.items[accountID]
+	if !exist {
+		return
+	}
+
+	c.remove(elem)
+	metrics.ObserveCredentialInvalidate("invalidate", 1)
+}
+
+// remove 调用方需持有锁
+func (c *credentialCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.items, elem.Value.(*credentialCacheEntry).accountID)
+}
+
+// load 优先从缓存中获取缓存项，未命中时通过fetch获取，fetch返回可缓存时写入缓存
+func load[T any](c *credentialCache, accountID, item string, fetch func() (T, bool, error)) (value T, hit bool,
+	err error) {
+
+	if !c.enabled {
+		value, _, err = fetch()
+		return value, false, err
+	}
+
+	if cached, exist := c.get(accountID, item); exist {
+		if typed, ok := cached.(T); ok {
+			return typed, true, nil
+		}
+	}
+
+	// 获取前记录失效版本，获取期间账号被失效时不写入缓存
+	generation := c.generation(accountID)
+	value, cacheable, err := fetch()
+	if err != nil {
+		return value, false, err
+	}
+
+	if cacheable {
+		c.set(accountID, item, value, generation)
+	}
+	return value, false, nil
+}
+
+// getOrFetch 优先从缓存中获取账号信息，未命中时通过fetch获取并写入缓存
+func getOrFetch[T any](c *credentialCache, vendor enumor.Vendor, accountID, item string, fetch func() (T, error)) (
+	T, error) {
+
+	if !c.enabled {
+		return fetch()
+	}
+
+	value, hit, err := load(c, accountID, item, func() (T, bool, error) {
+		start := time.Now()
+		value, err := fetch()
+		metrics.ObserveCredentialFetch(string(vendor), time.Since(start), err)
+		return value, true, err
+	})
+	metrics.ObserveCredentialCacheLookup(string(vendor), hit)
+	return value, err
+}
+
+// getOrBuildClient 优先从缓存中获取云适配器，未命中时通过build构造，使用临时凭证构造的云适配器会随凭证过期，不写入缓存
+func getOrBuildClient[T any](c *credentialCache, accountID, item string, build func() (T, bool, error)) (T, error) {
+	value, _, err := load(c, accountID, item, build)
+	return value, err
+}
@@ -0,0 +1,163 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudadaptor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestCredentialCache(t *testing.T) {
+	ttl := time.Minute
+	cache := newCredentialCache(cc.CredentialCacheOption{TTL: &ttl, MaxSize: 2})
+
+	fetched := 0
+	fetch := func(value string) func() (string, error) {
+		return func() (string, error) {
+			fetched++
+			return value, nil
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := getOrFetch(cache, enumor.TCloud, "00000001", accountItem, fetch("a")); err != nil {
+			t.Fatalf("get or fetch failed, err: %v", err)
+		}
+	}
+	if fetched != 1 {
+		t.Errorf("cached account should only be fetched once, fetched: %d", fetched)
+	}
+
+	// 更新账号后清除缓存，需要重新获取
+	cache.invalidate("00000001")
+	value, _ := getOrFetch(cache, enumor.TCloud, "00000001", accountItem, fetch("b"))
+	if value != "b" || fetched != 2 {
+		t.Errorf("invalidated account should be fetched again, value: %s, fetched: %d", value, fetched)
+	}
+
+	// 超出容量时淘汰最久未使用的条目
+	getOrFetch(cache, enumor.Aws, "00000002", accountItem, fetch("c"))
+	getOrFetch(cache, enumor.TCloud, "00000001", accountItem, fetch("b"))
+	getOrFetch(cache, enumor.Aws, "00000003", accountItem, fetch("d"))
+	if _, hit := cache.get("00000002", accountItem); hit {
+		t.Errorf("least recently used account should be evicted")
+	}
+	if _, hit := cache.get("00000001", accountItem); !hit {
+		t.Errorf("recently used account should not be evicted")
+	}
+
+	// 获取失败时不写入缓存
+	_, err := getOrFetch(cache, enumor.Aws, "00000004", accountItem, func() (string, error) { return "", errors.New("failed") })
+	if err == nil {
+		t.Errorf("fetch error should be returned")
+	}
+	if _, hit := cache.get("00000004", accountItem); hit {
+		t.Errorf("failed fetch should not be cached")
+	}
+}
+
+func TestCredentialCacheExpire(t *testing.T) {
+	ttl := time.Millisecond
+	cache := newCredentialCache(cc.CredentialCacheOption{TTL: &ttl, MaxSize: 10})
+
+	cache.set("00000001", accountItem, "a", 0)
+	time.Sleep(5 * time.Millisecond)
+	if _, hit := cache.get("00000001", accountItem); hit {
+		t.Errorf("expired account should not be hit")
+	}
+	if len(cache.items) != 0 || cache.lru.Len() != 0 {
+		t.Errorf("expired account should be removed, items: %d, lru: %d", len(cache.items), cache.lru.Len())
+	}
+
+	disabled := newCredentialCache(cc.CredentialCacheOption{Disable: true, TTL: &ttl, MaxSize: 10})
+	fetched := 0
+	for i := 0; i < 2; i++ {
+		getOrFetch(disabled, enumor.TCloud, "00000001", accountItem, func() (string, error) { fetched++; return "a", nil })
+	}
+	if fetched != 2 {
+		t.Errorf("disabled cache should always fetch, fetched: %d", fetched)
+	}
+}
+
+func TestCredentialCacheGeneration(t *testing.T) {
+	ttl := time.Minute
+	cache := newCredentialCache(cc.CredentialCacheOption{TTL: &ttl, MaxSize: 10})
+
+	// 获取期间账号被失效时，失效前获取的旧数据不写回缓存
+	value, err := getOrFetch(cache, enumor.TCloud, "00000001", accountItem, func() (string, error) {
+		cache.invalidate("00000001")
+		return "stale", nil
+	})
+	if err != nil || value != "stale" {
+		t.Fatalf("fetched value should be returned, value: %s, err: %v", value, err)
+	}
+	if _, hit := cache.get("00000001", accountItem); hit {
+		t.Errorf("value fetched before invalidate should not be cached")
+	}
+
+	value, _ = getOrFetch(cache, enumor.TCloud, "00000001", accountItem, func() (string, error) { return "new", nil })
+	if cached, hit := cache.get("00000001", accountItem); !hit || cached != "new" {
+		t.Errorf("value fetched after invalidate should be cached, value: %v", cached)
+	}
+}
+
+func TestCredentialCacheClient(t *testing.T) {
+	ttl := time.Minute
+	cache := newCredentialCache(cc.CredentialCacheOption{TTL: &ttl, MaxSize: 10})
+
+	built := 0
+	build := func(cacheable bool) func() (string, bool, error) {
+		return func() (string, bool, error) {
+			built++
+			_, err := getOrFetch(cache, enumor.TCloud, "00000001", accountItem, func() (string, error) {
+				return "account", nil
+			})
+			return "client", cacheable, err
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := getOrBuildClient(cache, "00000001", clientItem, build(true)); err != nil {
+			t.Fatalf("get or build client failed, err: %v", err)
+		}
+	}
+	if built != 1 {
+		t.Errorf("cached client should only be built once, built: %d", built)
+	}
+
+	// 账号失效时云适配器与账号信息一起失效
+	cache.invalidate("00000001")
+	if _, hit := cache.get("00000001", clientItem); hit {
+		t.Errorf("client should be invalidated with account")
+	}
+
+	// 使用临时凭证构造的云适配器不缓存
+	getOrBuildClient(cache, "00000001", asyncClientItem, build(false))
+	if _, hit := cache.get("00000001", asyncClientItem); hit {
+		t.Errorf("client built with temporary credential should not be cached")
+	}
+	if _, hit := cache.get("00000001", accountItem); !hit {
+		t.Errorf("account should be cached when building client")
+	}
+}
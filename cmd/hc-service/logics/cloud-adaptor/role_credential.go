@@ -0,0 +1,177 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudadaptor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"hcm/pkg/adaptor"
+	"hcm/pkg/adaptor/tcloud"
+	"hcm/pkg/adaptor/types"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+)
+
+// RoleCredentialProvider 角色模式账号的凭证提供者，使用HCM持有的可信身份扮演账号下的角色获取临时凭证。
+// 临时凭证按角色缓存，在过期前 RefreshAhead 时刷新，避免每次调用云上接口前都扮演一次角色。
+type RoleCredentialProvider struct {
+	adaptor *adaptor.Adaptor
+	// option 获取角色凭证配置，tcloud 构造可信身份的云适配器，单元测试中可替换
+	option func() cc.RoleCredentialOption
+	tcloud func(s *types.BaseSecret) (tcloud.TCloud, error)
+	lock   sync.Mutex
+	cache  map[string]*cachedRoleCredential
+}
+
+// cachedRoleCredential 单个角色的临时凭证，刷新时只锁住当前角色，不影响其他角色的获取
+type cachedRoleCredential struct {
+	lock       sync.Mutex
+	credential *typeaccount.RoleCredential
+}
+
+// NewRoleCredentialProvider new role credential provider.
+func NewRoleCredentialProvider(ad *adaptor.Adaptor) *RoleCredentialProvider {
+	return &RoleCredentialProvider{
+		adaptor: ad,
+		option:  func() cc.RoleCredentialOption { return cc.HCService().RoleCredential },
+		tcloud:  ad.TCloud,
+		cache:   make(map[string]*cachedRoleCredential),
+	}
+}
+
+// TCloudSecret 使用腾讯云可信身份扮演角色，获取临时凭证
+func (p *RoleCredentialProvider) TCloudSecret(kt *kit.Kit, roleArn string) (*types.BaseSecret, error) {
+	opt := p.option()
+	trusted := opt.TCloud
+	if len(trusted.SecretID) == 0 || len(trusted.SecretKey) == 0 {
+		return nil, errors.New("tcloud trusted identity of role credential is not configured")
+	}
+
+	key := fmt.Sprintf("%s/%s", enumor.TCloud, roleArn)
+	return p.get(kt, key, func() (*typeaccount.RoleCredential, error) {
+		client, err := p.tcloud(&types.BaseSecret{
+			CloudSecretID:  trusted.SecretID,
+			CloudSecretKey: trusted.SecretKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return client.AssumeRole(kt, &typeaccount.AssumeRoleOption{
+			RoleArn:     roleArn,
+			SessionName: opt.SessionName,
+			Duration:    *opt.Duration,
+		})
+	})
+}
+
+// AwsSecret 使用账号所属站点的aws可信身份扮演角色，获取临时凭证
+func (p *RoleCredentialProvider) AwsSecret(kt *kit.Kit, roleArn, externalID string, site enumor.AccountSiteType) (
+	*types.BaseSecret, error) {
+
+	opt := p.option()
+	trusted := opt.Aws.International
+	if site == enumor.ChinaSite {
+		trusted = opt.Aws.China
+	}
+	if len(trusted.SecretID) == 0 || len(trusted.SecretKey) == 0 {
+		return nil, fmt.Errorf("aws %s site trusted identity of role credential is not configured", site)
+	}
+
+	key := fmt.Sprintf("%s/%s/%s/%s", enumor.Aws, site, roleArn, externalID)
+	return p.get(kt, key, func() (*typeaccount.RoleCredential, error) {
+		client, err := p.adaptor.Aws(&types.BaseSecret{
+			CloudSecretID:  trusted.SecretID,
+			CloudSecretKey: trusted.SecretKey,
+		}, "", site)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.AssumeRole(kt, &typeaccount.AssumeRoleOption{
+			RoleArn:     roleArn,
+			SessionName: opt.SessionName,
+			ExternalID:  externalID,
+			Duration:    *opt.Duration,
+		})
+	})
+}
+
+// AzureCredential 使用工作负载身份的联合令牌文件换取账号应用的访问令牌，令牌由azure sdk自行缓存和刷新
+func (p *RoleCredentialProvider) AzureCredential(tenantID, subscriptionID, applicationID string) (
+	*types.AzureCredential, error) {
+
+	tokenFile := p.option().Azure.FederatedTokenFile
+	if len(tokenFile) == 0 {
+		return nil, errors.New("azure federated token file of role credential is not configured")
+	}
+
+	return &types.AzureCredential{
+		CloudTenantID:           tenantID,
+		CloudSubscriptionID:     subscriptionID,
+		CloudApplicationID:      applicationID,
+		CloudFederatedTokenFile: tokenFile,
+	}, nil
+}
+
+// get 获取缓存的临时凭证，不存在或即将过期时通过assume重新获取
+func (p *RoleCredentialProvider) get(kt *kit.Kit, key string, assume func() (*typeaccount.RoleCredential, error)) (
+	*types.BaseSecret, error) {
+
+	p.lock.Lock()
+	cached, exist := p.cache[key]
+	if !exist {
+		cached = new(cachedRoleCredential)
+		p.cache[key] = cached
+	}
+	p.lock.Unlock()
+
+	cached.lock.Lock()
+	defer cached.lock.Unlock()
+
+	refreshAhead := converter.PtrToVal(p.option().RefreshAhead)
+	if cached.credential == nil || time.Now().Add(refreshAhead).After(cached.credential.ExpireAt) {
+		credential, err := assume()
+		if err != nil {
+			logs.Errorf("assume role to get temporary credential failed, err: %v, role: %s, rid: %s", err, key,
+				kt.Rid)
+			return nil, err
+		}
+		cached.credential = credential
+	}
+
+	secret := &types.BaseSecret{
+		CloudSecretID:     cached.credential.SecretID,
+		CloudSecretKey:    cached.credential.SecretKey,
+		CloudSessionToken: cached.credential.SessionToken,
+	}
+	if err := secret.Validate(); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
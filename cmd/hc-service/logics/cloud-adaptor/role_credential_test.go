@@ -0,0 +1,160 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudadaptor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hcm/pkg/adaptor"
+	mocktcloud "hcm/pkg/adaptor/mock/tcloud"
+	"hcm/pkg/adaptor/tcloud"
+	"hcm/pkg/adaptor/types"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+
+	"go.uber.org/mock/gomock"
+)
+
+// newTestRoleProvider 构造使用mock云适配器及固定配置的角色凭证提供者
+func newTestRoleProvider(t *testing.T) (*RoleCredentialProvider, *mocktcloud.MockTCloud) {
+	duration, refreshAhead := time.Hour, 5*time.Minute
+	mock := mocktcloud.NewMockTCloud(gomock.NewController(t))
+
+	provider := NewRoleCredentialProvider(adaptor.New())
+	provider.option = func() cc.RoleCredentialOption {
+		return cc.RoleCredentialOption{
+			SessionName:  "hcm",
+			Duration:     &duration,
+			RefreshAhead: &refreshAhead,
+			TCloud:       cc.TCloudTrustedIdentity{SecretID: "trusted-id", SecretKey: "trusted-key"},
+		}
+	}
+	provider.tcloud = func(s *types.BaseSecret) (tcloud.TCloud, error) { return mock, nil }
+	return provider, mock
+}
+
+func roleCredential(secretID string, expireIn time.Duration) *typeaccount.RoleCredential {
+	return &typeaccount.RoleCredential{
+		SecretID:     secretID,
+		SecretKey:    "key",
+		SessionToken: "token",
+		ExpireAt:     time.Now().Add(expireIn),
+	}
+}
+
+func TestRoleCredentialRefreshAhead(t *testing.T) {
+	provider, mock := newTestRoleProvider(t)
+	kt := kit.New()
+	roleArn := "qcs::cam::uin/100000000001:roleName/hcm"
+	key := fmt.Sprintf("%s/%s", enumor.TCloud, roleArn)
+
+	// 临时凭证远未过期时复用缓存，只扮演一次角色
+	mock.EXPECT().AssumeRole(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *kit.Kit, opt *typeaccount.AssumeRoleOption) (*typeaccount.RoleCredential, error) {
+			if opt.RoleArn != roleArn || opt.Duration != time.Hour {
+				t.Errorf("unexpected assume role option: %+v", opt)
+			}
+			return roleCredential("first", time.Hour), nil
+		}).Times(1)
+	for i := 0; i < 3; i++ {
+		secret, err := provider.TCloudSecret(kt, roleArn)
+		if err != nil {
+			t.Fatalf("get role secret failed, err: %v", err)
+		}
+		if secret.CloudSecretID != "first" || secret.CloudSessionToken != "token" {
+			t.Errorf("cached credential should be reused, secret: %+v", secret)
+		}
+	}
+
+	// 临时凭证在 RefreshAhead 内即将过期时重新扮演角色
+	provider.cache[key].credential = roleCredential("expiring", 3*time.Minute)
+	mock.EXPECT().AssumeRole(gomock.Any(), gomock.Any()).Return(roleCredential("second", time.Hour), nil).Times(1)
+	secret, err := provider.TCloudSecret(kt, roleArn)
+	if err != nil {
+		t.Fatalf("get role secret failed, err: %v", err)
+	}
+	if secret.CloudSecretID != "second" {
+		t.Errorf("expiring credential should be refreshed, secret: %+v", secret)
+	}
+
+	// 刷新失败时返回错误，不使用即将过期的凭证
+	provider.cache[key].credential = roleCredential("expiring", time.Minute)
+	mock.EXPECT().AssumeRole(gomock.Any(), gomock.Any()).Return(nil, errors.New("assume failed")).Times(1)
+	if _, err = provider.TCloudSecret(kt, roleArn); err == nil {
+		t.Errorf("assume role error should be returned")
+	}
+}
+
+func TestRoleCredentialLock(t *testing.T) {
+	provider, _ := newTestRoleProvider(t)
+	kt := kit.New()
+
+	// 同一角色并发获取时只扮演一次角色
+	var assumed int32
+	assume := func() (*typeaccount.RoleCredential, error) {
+		atomic.AddInt32(&assumed, 1)
+		time.Sleep(10 * time.Millisecond)
+		return roleCredential("id", time.Hour), nil
+	}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := provider.get(kt, "tcloud/role-a", assume); err != nil {
+				t.Errorf("get role secret failed, err: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if assumed != 1 {
+		t.Errorf("concurrent get of same role should assume once, assumed: %d", assumed)
+	}
+
+	// 不同角色互不阻塞，一个角色扮演中时其他角色仍可获取
+	blocking, release := make(chan struct{}), make(chan struct{})
+	go provider.get(kt, "tcloud/role-b", func() (*typeaccount.RoleCredential, error) {
+		close(blocking)
+		<-release
+		return roleCredential("id", time.Hour), nil
+	})
+	<-blocking
+	defer close(release)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		provider.get(kt, "tcloud/role-c", func() (*typeaccount.RoleCredential, error) {
+			return roleCredential("id", time.Hour), nil
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("get of other role should not be blocked by assuming role")
+	}
+}
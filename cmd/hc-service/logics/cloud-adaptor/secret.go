@@ -22,8 +22,13 @@ package cloudadaptor
 import (
 	"errors"
 	"fmt"
+	"sync"
 
+	"hcm/pkg/adaptor"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/api/core/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/cc"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
@@ -31,17 +36,41 @@ import (
 
 // SecretClient used to get secret by account id from data-service.
 type SecretClient struct {
-	data *dataservice.Client
+	data      *dataservice.Client
+	role      *RoleCredentialProvider
+	cacheOnce sync.Once
+	cache     *credentialCache
 }
 
 // NewSecretClient new secret client that used to get secret info from data service.
 func NewSecretClient(dataCli *dataservice.Client) *SecretClient {
-	return &SecretClient{data: dataCli}
+	return &SecretClient{
+		data: dataCli,
+		role: NewRoleCredentialProvider(adaptor.New()),
+	}
+}
+
+// accountCache 首次使用时按配置构造凭证缓存，避免未加载配置时(如单元测试)构造客户端即读取配置
+func (cli *SecretClient) accountCache() *credentialCache {
+	cli.cacheOnce.Do(func() {
+		if cli.cache == nil {
+			cli.cache = newCredentialCache(cc.HCService().CredentialCache)
+		}
+	})
+	return cli.cache
+}
+
+// InvalidateCache 清除账号的凭证及云适配器缓存，账号更新或删除时调用
+func (cli *SecretClient) InvalidateCache(accountID string) {
+	cli.accountCache().invalidate(accountID)
 }
 
 // TCloudSecret get tcloud secret and validate secret.
 func (cli *SecretClient) TCloudSecret(kt *kit.Kit, accountID string) (*types.BaseSecret, error) {
-	account, err := cli.data.TCloud.Account.Get(kt.Ctx, kt.Header(), accountID)
+	account, err := getOrFetch(cli.accountCache(), enumor.TCloud, accountID, accountItem,
+		func() (*dataproto.AccountGetResult[cloud.TCloudAccountExtension], error) {
+			return cli.data.TCloud.Account.Get(kt.Ctx, kt.Header(), accountID)
+		})
 	if err != nil {
 		return nil, fmt.Errorf("get tcloud account failed, err: %v", err)
 	}
@@ -54,6 +83,11 @@ func (cli *SecretClient) TCloudSecret(kt *kit.Kit, accountID string) (*types.Bas
 		return nil, errors.New("tcloud account extension is nil")
 	}
 
+	// 角色模式下扮演账号下的角色获取临时凭证
+	if account.Extension.CredentialMode.IsRole() {
+		return cli.role.TCloudSecret(kt, account.Extension.CloudRoleArn)
+	}
+
 	secret := &types.BaseSecret{
 		CloudSecretID:  account.Extension.CloudSecretID,
 		CloudSecretKey: account.Extension.CloudSecretKey,
@@ -70,7 +104,10 @@ func (cli *SecretClient) TCloudSecret(kt *kit.Kit, accountID string) (*types.Bas
 func (cli *SecretClient) AwsSecret(kt *kit.Kit, accountID string) (
 	*types.BaseSecret, string, enumor.AccountSiteType, error) {
 
-	account, err := cli.data.Aws.Account.Get(kt.Ctx, kt.Header(), accountID)
+	account, err := getOrFetch(cli.accountCache(), enumor.Aws, accountID, accountItem,
+		func() (*dataproto.AccountGetResult[cloud.AwsAccountExtension], error) {
+			return cli.data.Aws.Account.Get(kt.Ctx, kt.Header(), accountID)
+		})
 	if err != nil {
 		return nil, "", "", fmt.Errorf("get aws account failed, err: %v", err)
 	}
@@ -83,6 +120,16 @@ func (cli *SecretClient) AwsSecret(kt *kit.Kit, accountID string) (
 		return nil, "", "", errors.New("aws account extension is nil")
 	}
 
+	// 角色模式下扮演账号下的角色获取临时凭证
+	if account.Extension.CredentialMode.IsRole() {
+		secret, err := cli.role.AwsSecret(kt, account.Extension.CloudRoleArn, account.Extension.CloudExternalID,
+			account.Site)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return secret, account.Extension.CloudAccountID, account.Site, nil
+	}
+
 	secret := &types.BaseSecret{
 		CloudSecretID:  account.Extension.CloudSecretID,
 		CloudSecretKey: account.Extension.CloudSecretKey,
@@ -96,7 +143,10 @@ func (cli *SecretClient) AwsSecret(kt *kit.Kit, accountID string) (
 
 // HuaWeiSecret get huawei secret and validate secret.
 func (cli *SecretClient) HuaWeiSecret(kt *kit.Kit, accountID string) (*types.BaseSecret, error) {
-	account, err := cli.data.HuaWei.Account.Get(kt.Ctx, kt.Header(), accountID)
+	account, err := getOrFetch(cli.accountCache(), enumor.HuaWei, accountID, accountItem,
+		func() (*dataproto.AccountGetResult[cloud.HuaWeiAccountExtension], error) {
+			return cli.data.HuaWei.Account.Get(kt.Ctx, kt.Header(), accountID)
+		})
 	if err != nil {
 		return nil, fmt.Errorf("get huawei account failed, err: %v", err)
 	}
@@ -123,7 +173,10 @@ func (cli *SecretClient) HuaWeiSecret(kt *kit.Kit, accountID string) (*types.Bas
 
 // AzureCredential get azure credential and validate credential.
 func (cli *SecretClient) AzureCredential(kt *kit.Kit, accountID string) (*types.AzureCredential, error) {
-	account, err := cli.data.Azure.Account.Get(kt.Ctx, kt.Header(), accountID)
+	account, err := getOrFetch(cli.accountCache(), enumor.Azure, accountID, accountItem,
+		func() (*dataproto.AccountGetResult[cloud.AzureAccountExtension], error) {
+			return cli.data.Azure.Account.Get(kt.Ctx, kt.Header(), accountID)
+		})
 	if err != nil {
 		return nil, fmt.Errorf("get azure account failed, err: %v", err)
 	}
@@ -136,6 +189,12 @@ func (cli *SecretClient) AzureCredential(kt *kit.Kit, accountID string) (*types.
 		return nil, errors.New("azure account extension is nil")
 	}
 
+	// 角色模式下使用工作负载身份
+	if account.Extension.CredentialMode.IsRole() {
+		return cli.role.AzureCredential(account.Extension.CloudTenantID, account.Extension.CloudSubscriptionID,
+			account.Extension.CloudApplicationID)
+	}
+
 	cred := &types.AzureCredential{
 		CloudTenantID:        account.Extension.CloudTenantID,
 		CloudSubscriptionID:  account.Extension.CloudSubscriptionID,
@@ -152,7 +211,10 @@ func (cli *SecretClient) AzureCredential(kt *kit.Kit, accountID string) (*types.
 
 // GcpCredential get gcp credential and validate credential.
 func (cli *SecretClient) GcpCredential(kt *kit.Kit, accountID string) (*types.GcpCredential, error) {
-	account, err := cli.data.Gcp.Account.Get(kt.Ctx, kt.Header(), accountID)
+	account, err := getOrFetch(cli.accountCache(), enumor.Gcp, accountID, accountItem,
+		func() (*dataproto.AccountGetResult[cloud.GcpAccountExtension], error) {
+			return cli.data.Gcp.Account.Get(kt.Ctx, kt.Header(), accountID)
+		})
 	if err != nil {
 		return nil, fmt.Errorf("get gcp account failed, err: %v", err)
 	}
@@ -179,7 +241,10 @@ func (cli *SecretClient) GcpCredential(kt *kit.Kit, accountID string) (*types.Gc
 
 // GcpRegisterCredential get gcp register credential and validate credential.
 func (cli *SecretClient) GcpRegisterCredential(kt *kit.Kit, accountID string) (*types.GcpCredential, error) {
-	account, err := cli.data.Gcp.Account.Get(kt.Ctx, kt.Header(), accountID)
+	account, err := getOrFetch(cli.accountCache(), enumor.Gcp, accountID, accountItem,
+		func() (*dataproto.AccountGetResult[cloud.GcpAccountExtension], error) {
+			return cli.data.Gcp.Account.Get(kt.Ctx, kt.Header(), accountID)
+		})
 	if err != nil {
 		return nil, fmt.Errorf("get gcp register account failed, err: %v", err)
 	}
@@ -271,6 +336,29 @@ func (cli *SecretClient) HuaWeiRootSecret(kt *kit.Kit, accountID string) (*types
 	return secret, nil
 }
 
+// TCloudRootSecret get tcloud root account secret and validate secret.
+func (cli *SecretClient) TCloudRootSecret(kt *kit.Kit, accountID string) (*types.BaseSecret, error) {
+	account, err := cli.data.TCloud.RootAccount.Get(kt, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("get tcloud root account failed, err: %v", err)
+	}
+
+	if account.Extension == nil {
+		return nil, errors.New("tcloud root account extension is nil")
+	}
+
+	secret := &types.BaseSecret{
+		CloudSecretID:  account.Extension.CloudSecretID,
+		CloudSecretKey: account.Extension.CloudSecretKey,
+	}
+
+	if err := secret.Validate(); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
 // AzureRootCredential get azure credential and validate credential.
 func (cli *SecretClient) AzureRootCredential(kt *kit.Kit, accountID string) (*types.AzureCredential, error) {
 	account, err := cli.data.Azure.RootAccount.Get(kt, accountID)
@@ -0,0 +1,135 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudadaptor
+
+import (
+	"testing"
+	"time"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/api/core/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+
+	"go.uber.org/mock/gomock"
+)
+
+// newTestSecretClient 构造账号信息已缓存的密钥客户端，避免依赖data-service
+func newTestSecretClient(role *RoleCredentialProvider, accounts map[string]any) *SecretClient {
+	ttl := time.Minute
+	cli := &SecretClient{
+		role:  role,
+		cache: newCredentialCache(cc.CredentialCacheOption{TTL: &ttl, MaxSize: 10}),
+	}
+	for id, account := range accounts {
+		cli.cache.set(id, accountItem, account, 0)
+	}
+	return cli
+}
+
+func TestSecretClientTCloudCredentialMode(t *testing.T) {
+	provider, mock := newTestRoleProvider(t)
+	roleArn := "qcs::cam::uin/100000000001:roleName/hcm"
+	tcloudAccount := func(ext cloud.TCloudAccountExtension) any {
+		return &dataproto.AccountGetResult[cloud.TCloudAccountExtension]{
+			BaseAccount: cloud.BaseAccount{Vendor: enumor.TCloud, Type: enumor.ResourceAccount},
+			Extension:   &ext,
+		}
+	}
+	cli := newTestSecretClient(provider, map[string]any{
+		"role": tcloudAccount(cloud.TCloudAccountExtension{CredentialMode: enumor.RoleCredentialMode,
+			CloudRoleArn: roleArn}),
+		"secret": tcloudAccount(cloud.TCloudAccountExtension{CredentialMode: enumor.SecretCredentialMode,
+			CloudSecretID: "id", CloudSecretKey: "key"}),
+		// 未设置凭证模式的存量账号视为密钥模式
+		"legacy": tcloudAccount(cloud.TCloudAccountExtension{CloudSecretID: "legacy-id", CloudSecretKey: "legacy-key"}),
+	})
+	kt := kit.New()
+
+	// 角色模式扮演账号下的角色获取临时凭证
+	mock.EXPECT().AssumeRole(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *kit.Kit, opt *typeaccount.AssumeRoleOption) (*typeaccount.RoleCredential, error) {
+			if opt.RoleArn != roleArn {
+				t.Errorf("role of account should be assumed, role: %s", opt.RoleArn)
+			}
+			return roleCredential("temporary", time.Hour), nil
+		}).Times(1)
+	secret, err := cli.TCloudSecret(kt, "role")
+	if err != nil {
+		t.Fatalf("get role mode secret failed, err: %v", err)
+	}
+	if secret.CloudSecretID != "temporary" || secret.CloudSessionToken != "token" {
+		t.Errorf("role mode should use temporary credential, secret: %+v", secret)
+	}
+
+	// 密钥模式直接使用账号密钥，不扮演角色
+	for id, want := range map[string]string{"secret": "id", "legacy": "legacy-id"} {
+		secret, err = cli.TCloudSecret(kt, id)
+		if err != nil {
+			t.Fatalf("get %s mode secret failed, err: %v", id, err)
+		}
+		if secret.CloudSecretID != want || len(secret.CloudSessionToken) != 0 {
+			t.Errorf("%s mode should use account secret, secret: %+v", id, secret)
+		}
+	}
+
+	// 角色模式未配置可信身份时返回错误
+	provider.option = func() cc.RoleCredentialOption { return cc.RoleCredentialOption{} }
+	if _, err = cli.TCloudSecret(kt, "role"); err == nil {
+		t.Errorf("role mode without trusted identity should fail")
+	}
+}
+
+func TestSecretClientAzureCredentialMode(t *testing.T) {
+	provider, _ := newTestRoleProvider(t)
+	provider.option = func() cc.RoleCredentialOption {
+		return cc.RoleCredentialOption{Azure: cc.AzureTrustedIdentity{FederatedTokenFile: "/var/run/token"}}
+	}
+	azureAccount := func(ext cloud.AzureAccountExtension) any {
+		ext.CloudTenantID, ext.CloudSubscriptionID, ext.CloudApplicationID = "tenant", "subscription", "app"
+		return &dataproto.AccountGetResult[cloud.AzureAccountExtension]{
+			BaseAccount: cloud.BaseAccount{Vendor: enumor.Azure, Type: enumor.ResourceAccount},
+			Extension:   &ext,
+		}
+	}
+	cli := newTestSecretClient(provider, map[string]any{
+		"role":   azureAccount(cloud.AzureAccountExtension{CredentialMode: enumor.RoleCredentialMode}),
+		"secret": azureAccount(cloud.AzureAccountExtension{CloudClientSecretKey: "key"}),
+	})
+	kt := kit.New()
+
+	cred, err := cli.AzureCredential(kt, "role")
+	if err != nil {
+		t.Fatalf("get role mode credential failed, err: %v", err)
+	}
+	if cred.CloudFederatedTokenFile != "/var/run/token" || len(cred.CloudClientSecretKey) != 0 {
+		t.Errorf("role mode should use federated token file, credential: %+v", cred)
+	}
+
+	cred, err = cli.AzureCredential(kt, "secret")
+	if err != nil {
+		t.Fatalf("get secret mode credential failed, err: %v", err)
+	}
+	if cred.CloudClientSecretKey != "key" || len(cred.CloudFederatedTokenFile) != 0 {
+		t.Errorf("secret mode should use client secret, credential: %+v", cred)
+	}
+}
@@ -77,23 +77,32 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.AwsCvm, corecvm.Cvm[cvm.AwsCvmExtension]](
 		cvmFromCloud, cvmFromDB, isCvmChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteCvm(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createCvm(kt, params.AccountID, params.Region, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateCvm(kt, params.AccountID, params.Region, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
+	}
+
+	if err = common.SyncResTags(kt, cli.dbCli, enumor.Aws, enumor.CvmCloudResType, params.AccountID,
+		cvmFromCloud); err != nil {
+		return nil, err
 	}
 
 	return new(SyncResult), nil
@@ -633,11 +642,15 @@ func (cli *client) RemoveCvmDeleteFromCloud(kt *kit.Kit, accountID string, regio
 			}
 
 			cloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
-			if err := cli.deleteCvm(kt, accountID, region, cloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, cloudIDs) {
+				if err := cli.deleteCvm(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(cloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -80,23 +80,32 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	}
 	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.AwsDisk, *coredisk.Disk[coredisk.AwsExtension]](
 		diskFromCloud, diskFromDB, isDiskChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteDisk(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createDisk(kt, params.AccountID, params.Region, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateDisk(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
+	}
+
+	if err = common.SyncResTags(kt, cli.dbCli, enumor.Aws, enumor.DiskCloudResType, params.AccountID,
+		diskFromCloud); err != nil {
+		return nil, err
 	}
 
 	return new(SyncResult), nil
@@ -382,12 +391,14 @@ func (cli *client) RemoveDiskDeleteFromCloud(kt *kit.Kit, accountID string, regi
 			}
 		}
 
-		if len(delCloudIDs) != 0 {
+		if len(delCloudIDs) != 0 && common.PlanDelete(kt, delCloudIDs) {
 			if err = cli.deleteDisk(kt, accountID, region, delCloudIDs); err != nil {
 				return err
 			}
+			common.RecordDeleted(kt, len(delCloudIDs))
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
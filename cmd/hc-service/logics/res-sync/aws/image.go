@@ -71,23 +71,27 @@ func (cli *client) Image(kt *kit.Kit, params *SyncBaseParams, opt *SyncImageOpti
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typesimage.AwsImage, coreimage.Image[coreimage.AwsExtension]](
 		imageFromCloud, imageFromDB, isImageChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteImage(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createImage(kt, params.AccountID, params.Region, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateImage(kt, params.AccountID, params.Region, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -310,12 +314,14 @@ func (cli *client) RemoveImageDeleteFromCloud(kt *kit.Kit, accountID string, reg
 			}
 		}
 
-		if len(delCloudIDs) != 0 {
+		if len(delCloudIDs) != 0 && common.PlanDelete(kt, delCloudIDs) {
 			if err = cli.deleteImage(kt, accountID, region, delCloudIDs); err != nil {
 				return err
 			}
+			common.RecordDeleted(kt, len(delCloudIDs))
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
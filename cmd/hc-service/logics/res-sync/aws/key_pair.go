@@ -0,0 +1,351 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"strings"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/adaptor/aws"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+)
+
+// SyncKeyPairOption ...
+type SyncKeyPairOption struct {
+}
+
+// Validate ...
+func (opt SyncKeyPairOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// KeyPair 同步SSH密钥对
+func (cli *client) KeyPair(kt *kit.Kit, params *SyncBaseParams, opt *SyncKeyPairOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPairFromCloud, err := cli.listKeyPairFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPairFromDB, err := cli.listKeyPairFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyPairFromCloud) == 0 && len(keyPairFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typekeypair.AwsKeyPair,
+		*corekeypair.KeyPair[corekeypair.AwsExtension]](keyPairFromCloud, keyPairFromDB, isKeyPairChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteKeyPair(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createKeyPair(kt, params.AccountID, params.Region, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateKeyPair(kt, params.AccountID, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) deleteKeyPair(kt *kit.Kit, accountID, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	deleteReq := &dataproto.KeyPairDeleteReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleIn("cloud_id", delCloudIDs),
+		),
+	}
+	if err := cli.dbCli.Global.BatchDeleteKeyPair(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete key pair failed, err: %v, rid: %s", enumor.Aws,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync key pair to delete success, accountID: %s, count: %d, rid: %s", enumor.Aws,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createKeyPair(kt *kit.Kit, accountID, region string, addSlice []typekeypair.AwsKeyPair) error {
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	createReq := make(dataproto.KeyPairExtBatchCreateReq[corekeypair.AwsExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		createReq = append(createReq, &dataproto.KeyPairExtCreateReq[corekeypair.AwsExtension]{
+			AccountID:   accountID,
+			CloudID:     one.GetCloudID(),
+			Name:        converter.PtrToVal(one.KeyName),
+			Region:      region,
+			Fingerprint: converter.PtrToVal(one.KeyFingerprint),
+			PublicKey:   converter.PtrToVal(one.PublicKey),
+			Extension:   convAwsKeyPairExtension(one),
+		})
+	}
+
+	for _, part := range slice.Split(createReq, constant.BatchOperationMaxLimit) {
+		req := dataproto.KeyPairExtBatchCreateReq[corekeypair.AwsExtension](part)
+		if _, err := cli.dbCli.Aws.BatchCreateKeyPair(kt, &req); err != nil {
+			logs.Errorf("[%s] request dataservice to create key pair failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync key pair to create success, accountID: %s, count: %d, rid: %s", enumor.Aws,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateKeyPair(kt *kit.Kit, accountID string,
+	updateMap map[string]typekeypair.AwsKeyPair) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updateReq := make(dataproto.KeyPairExtBatchUpdateReq[corekeypair.AwsExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		updateReq = append(updateReq, &dataproto.KeyPairExtUpdateReq[corekeypair.AwsExtension]{
+			ID:          id,
+			Name:        converter.PtrToVal(one.KeyName),
+			Fingerprint: converter.PtrToVal(one.KeyFingerprint),
+			PublicKey:   converter.PtrToVal(one.PublicKey),
+			Extension:   convAwsKeyPairExtension(one),
+		})
+	}
+
+	for _, part := range slice.Split(updateReq, constant.BatchOperationMaxLimit) {
+		req := dataproto.KeyPairExtBatchUpdateReq[corekeypair.AwsExtension](part)
+		if err := cli.dbCli.Aws.BatchUpdateKeyPair(kt, &req); err != nil {
+			logs.Errorf("[%s] request dataservice to update key pair failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync key pair to update success, accountID: %s, count: %d, rid: %s", enumor.Aws,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func convAwsKeyPairExtension(one typekeypair.AwsKeyPair) *corekeypair.AwsExtension {
+	ext := &corekeypair.AwsExtension{
+		KeyType: one.KeyType,
+	}
+	if one.CreateTime != nil {
+		ext.CloudCreatedTime = converter.ValToPtr(times.ConvStdTimeFormat(*one.CreateTime))
+	}
+
+	return ext
+}
+
+func (cli *client) listKeyPairFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typekeypair.AwsKeyPair, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typekeypair.AwsKeyPairListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, err := cli.cloudCli.ListKeyPair(kt, opt)
+	if err != nil {
+		if strings.Contains(err.Error(), aws.ErrKeyPairNotFound) {
+			return make([]typekeypair.AwsKeyPair, 0), nil
+		}
+
+		logs.Errorf("[%s] list key pair from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listKeyPairFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]*corekeypair.KeyPair[corekeypair.AwsExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", params.AccountID),
+			tools.RuleEqual("region", params.Region),
+			tools.RuleIn("cloud_id", params.CloudIDs),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aws.ListKeyPair(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list key pair from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isKeyPairChange(cloud typekeypair.AwsKeyPair, db *corekeypair.KeyPair[corekeypair.AwsExtension]) bool {
+	if converter.PtrToVal(cloud.KeyName) != db.Name {
+		return true
+	}
+
+	if converter.PtrToVal(cloud.PublicKey) != db.PublicKey {
+		return true
+	}
+
+	if converter.PtrToVal(cloud.KeyFingerprint) != db.Fingerprint {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.KeyType, db.Extension.KeyType) {
+		return true
+	}
+
+	return false
+}
+
+// RemoveKeyPairDeleteFromCloud 删除db中存在但云上已删除的密钥对
+func (cli *client) RemoveKeyPairDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+		),
+		Page: &core.BasePage{Start: 0, Limit: constant.BatchOperationMaxLimit},
+	}
+
+	delCloudIDs := make([]string, 0)
+	for {
+		resultFromDB, err := cli.dbCli.Global.ListKeyPair(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list key pair failed, err: %v, req: %v, rid: %s",
+				enumor.Aws, err, req, kt.Rid)
+			return err
+		}
+
+		if len(resultFromDB.Details) == 0 {
+			break
+		}
+
+		cloudIDs := make([]string, 0, len(resultFromDB.Details))
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+		removedIDs, err := cli.listRemoveKeyPairID(kt, params)
+		if err != nil {
+			return err
+		}
+		delCloudIDs = append(delCloudIDs, removedIDs...)
+
+		common.RecordScanned(kt, len(resultFromDB.Details))
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		if !common.PlanDelete(kt, part) {
+			continue
+		}
+		if err := cli.deleteKeyPair(kt, accountID, region, part); err != nil {
+			return err
+		}
+		common.RecordDeleted(kt, len(part))
+	}
+
+	return nil
+}
+
+// listRemoveKeyPairID aws按ID查询时只要有一个密钥对不存在就会报错，需要逐个剔除不存在的密钥对ID
+func (cli *client) listRemoveKeyPairID(kt *kit.Kit, params *SyncBaseParams) ([]string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	delCloudIDs := make([]string, 0)
+	cloudIDs := params.CloudIDs
+	for len(cloudIDs) > 0 {
+		opt := &typekeypair.AwsKeyPairListOption{
+			Region:   params.Region,
+			CloudIDs: cloudIDs,
+		}
+		_, err := cli.cloudCli.ListKeyPair(kt, opt)
+		if err == nil {
+			break
+		}
+
+		if !strings.Contains(err.Error(), aws.ErrKeyPairNotFound) {
+			logs.Errorf("[%s] list key pair from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+				enumor.Aws, err, params.AccountID, opt, kt.Rid)
+			return nil, err
+		}
+
+		var delCloudID string
+		cloudIDs, delCloudID = removeNotFoundCloudID(cloudIDs, err)
+		delCloudIDs = append(delCloudIDs, delCloudID)
+	}
+
+	return delCloudIDs, nil
+}
@@ -0,0 +1,507 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+)
+
+// SyncLBOption ...
+type SyncLBOption struct {
+}
+
+// Validate ...
+func (opt SyncLBOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// LoadBalancerWithListener 同步指定负载均衡及其下属监听器、目标组、转发规则
+func (cli *client) LoadBalancerWithListener(kt *kit.Kit, params *SyncBaseParams, opt *SyncLBOption) (*SyncResult,
+	error) {
+
+	if _, err := cli.LoadBalancer(kt, params, opt); err != nil {
+		logs.Errorf("[%s] fail to sync load balancer, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
+		return nil, err
+	}
+
+	lbList, err := cli.listLBFromDB(kt, params)
+	if err != nil {
+		logs.Errorf("[%s] fail to get lb from db before listener sync, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
+		return nil, err
+	}
+
+	for _, lb := range lbList {
+		if _, err = cli.Listener(kt, params.AccountID, params.Region, lb); err != nil {
+			logs.Errorf("[%s] fail to sync listener of lb: %s, err: %v, rid: %s", enumor.Aws, lb.CloudID, err,
+				kt.Rid)
+			return nil, err
+		}
+
+		if err = cli.LoadBalancerTargetGroup(kt, params.AccountID, params.Region, lb); err != nil {
+			logs.Errorf("[%s] fail to sync target group of lb: %s, err: %v, rid: %s", enumor.Aws, lb.CloudID,
+				err, kt.Rid)
+			return nil, err
+		}
+
+		// 规则需要关联本地目标组，在目标组同步之后执行
+		if err = cli.ListenerRule(kt, params.AccountID, params.Region, lb); err != nil {
+			logs.Errorf("[%s] fail to sync rule of lb: %s, err: %v, rid: %s", enumor.Aws, lb.CloudID, err,
+				kt.Rid)
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// LoadBalancer 同步指定负载均衡自身属性
+func (cli *client) LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLBOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lbFromCloud, err := cli.listLBFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	lbFromDB, err := cli.listLBFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lbFromCloud) == 0 && len(lbFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.AwsLoadBalancer, corelb.AwsLoadBalancer](
+		lbFromCloud, lbFromDB, isLBChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteLoadBalancer(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createLoadBalancer(kt, params.AccountID, params.Region, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateLoadBalancer(kt, params.AccountID, params.Region, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	if err = common.SyncResTags(kt, cli.dbCli, enumor.Aws, enumor.LoadBalancerCloudResType, params.AccountID,
+		lbFromCloud); err != nil {
+		return nil, err
+	}
+
+	return new(SyncResult), nil
+}
+
+// RemoveLoadBalancerDeleteFromCloud 删除存在本地但是在云上被删除的数据
+func (cli *client) RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("vendor", enumor.Aws),
+		),
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+
+	for {
+		lbFromDB, err := cli.dbCli.Global.LoadBalancer.ListLoadBalancer(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list lb failed, err: %v, req: %v, rid: %s",
+				enumor.Aws, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := slice.Map(lbFromDB.Details, func(lb corelb.BaseLoadBalancer) string { return lb.CloudID })
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+		lbFromCloud, err := cli.listLBFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(lbFromCloud) != len(cloudIDs) {
+			cloudIDMap := cvt.StringSliceToMap(cloudIDs)
+			for _, one := range lbFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+
+			delCloudIDs := cvt.MapKeyToStringSlice(cloudIDMap)
+			if common.PlanDelete(kt, delCloudIDs) {
+				if err = cli.deleteLoadBalancer(kt, accountID, region, delCloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
+			}
+		}
+
+		common.RecordScanned(kt, len(lbFromDB.Details))
+		if len(lbFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) createLoadBalancer(kt *kit.Kit, accountID string, region string,
+	addSlice []typeslb.AwsLoadBalancer) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	vpcMap, subnetMap, err := cli.getLoadBalancerRelatedRes(kt, accountID, region, addSlice)
+	if err != nil {
+		return err
+	}
+
+	createReq := &protocloud.AwsLBCreateReq{Lbs: make([]protocloud.AwsLBCreate, 0, len(addSlice))}
+	for _, one := range addSlice {
+		createReq.Lbs = append(createReq.Lbs, convCloudToDBCreate(one, accountID, region, vpcMap, subnetMap))
+	}
+
+	if _, err = cli.dbCli.Aws.LoadBalancer.BatchCreateAwsLB(kt, createReq); err != nil {
+		logs.Errorf("[%s] call data service to create aws load balancer failed, err: %v, rid: %s",
+			enumor.Aws, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync load balancer to create lb success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateLoadBalancer(kt *kit.Kit, accountID string, region string,
+	updateMap map[string]typeslb.AwsLoadBalancer) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	lbs := cvt.MapValueToSlice(updateMap)
+	vpcMap, subnetMap, err := cli.getLoadBalancerRelatedRes(kt, accountID, region, lbs)
+	if err != nil {
+		return err
+	}
+
+	updateReq := &protocloud.AwsLBBatchUpdateReq{
+		Lbs: make([]*protocloud.LoadBalancerExtUpdateReq[corelb.AwsLoadBalancerExtension], 0, len(updateMap)),
+	}
+	for id, one := range updateMap {
+		updateReq.Lbs = append(updateReq.Lbs, convCloudToDBUpdate(id, one, vpcMap, subnetMap))
+	}
+
+	if err = cli.dbCli.Aws.LoadBalancer.BatchUpdate(kt, updateReq); err != nil {
+		logs.Errorf("[%s] call data service to update aws load balancer failed, err: %v, rid: %s",
+			enumor.Aws, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync load balancer to update lb success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deleteLoadBalancer(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delLBFromCloud, err := cli.listLBFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delLBFromCloud) > 0 {
+		logs.Errorf("[%s] validate lb not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.Aws, checkParams, len(delLBFromCloud), kt.Rid)
+		return fmt.Errorf("validate lb not exist failed, before delete")
+	}
+
+	deleteReq := &protocloud.LoadBalancerBatchDeleteReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleIn("cloud_id", delCloudIDs),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("vendor", enumor.Aws),
+		),
+	}
+	if err = cli.dbCli.Global.LoadBalancer.BatchDelete(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] call data service to batch delete lb failed, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync lb to delete lb success, accountID: %s, count: %d, rid: %s", enumor.Aws,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+// getLoadBalancerRelatedRes return vpc map and subnet map of given load balancers
+func (cli *client) getLoadBalancerRelatedRes(kt *kit.Kit, accountID string, region string,
+	lbs []typeslb.AwsLoadBalancer) (map[string]*common.VpcDB, map[string]string, error) {
+
+	cloudVpcIDs := make([]string, 0, len(lbs))
+	cloudSubnetIDs := make([]string, 0, len(lbs))
+	for _, one := range lbs {
+		cloudVpcIDs = append(cloudVpcIDs, cvt.PtrToVal(one.VpcId))
+		cloudSubnetIDs = append(cloudSubnetIDs, one.GetCloudSubnetIDs()...)
+	}
+
+	vpcMap, err := cli.getVpcMap(kt, accountID, region, slice.Unique(cloudVpcIDs))
+	if err != nil {
+		logs.Errorf("fail to get vpc of load balancer during syncing, err: %v, account: %s, vpcIDs: %v, rid: %s",
+			err, accountID, cloudVpcIDs, kt.Rid)
+		return nil, nil, err
+	}
+
+	subnetMap, err := cli.getSubnetMap(kt, accountID, region, slice.Unique(cloudSubnetIDs))
+	if err != nil {
+		logs.Errorf("fail to get subnet of load balancer during syncing, err: %v, account: %s, subnetIDs: %v, "+
+			"rid: %s", err, accountID, cloudSubnetIDs, kt.Rid)
+		return nil, nil, err
+	}
+
+	return vpcMap, subnetMap, nil
+}
+
+// listLBFromCloud 指定id时分批查询，否则查询该地域下全部负载均衡
+func (cli *client) listLBFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typeslb.AwsLoadBalancer, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := make([]typeslb.AwsLoadBalancer, 0, len(params.CloudIDs))
+	for _, cloudIDs := range slice.Split(params.CloudIDs, typeslb.AwsLBDescribeMax) {
+		opt := &typeslb.AwsListOption{
+			Region:   params.Region,
+			CloudIDs: cloudIDs,
+		}
+		batch, err := cli.cloudCli.ListLoadBalancer(kt, opt)
+		if err != nil {
+			logs.Errorf("[%s] list lb from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+				enumor.Aws, err, params.AccountID, opt, kt.Rid)
+			return nil, err
+		}
+		result = append(result, batch.Details...)
+	}
+
+	return result, nil
+}
+
+func (cli *client) listLBFromDB(kt *kit.Kit, params *SyncBaseParams) ([]corelb.AwsLoadBalancer, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", params.AccountID),
+			tools.RuleEqual("region", params.Region),
+			tools.RuleIn("cloud_id", params.CloudIDs),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aws.LoadBalancer.ListLoadBalancer(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list lb from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func convCloudToDBCreate(cloud typeslb.AwsLoadBalancer, accountID string, region string,
+	vpcMap map[string]*common.VpcDB, subnetMap map[string]string) protocloud.AwsLBCreate {
+
+	cloudVpcID := cvt.PtrToVal(cloud.VpcId)
+	// aws 负载均衡每个可用区对应一个子网，这里记录第一个，完整列表保存在拓展字段中
+	var cloudSubnetID string
+	if subnetIDs := cloud.GetCloudSubnetIDs(); len(subnetIDs) > 0 {
+		cloudSubnetID = subnetIDs[0]
+	}
+	privateIPv4, publicIPv4, ipv6 := cloud.GetAddresses()
+
+	return protocloud.AwsLBCreate{
+		CloudID:              cloud.GetCloudID(),
+		Name:                 cloud.GetName(),
+		Vendor:               enumor.Aws,
+		AccountID:            accountID,
+		BkBizID:              constant.UnassignedBiz,
+		LoadBalancerType:     string(cloud.GetScheme().Convert()),
+		IPVersion:            cloud.GetIPVersion(),
+		Region:               region,
+		Zones:                cloud.GetZones(),
+		VpcID:                cvt.PtrToVal(vpcMap[cloudVpcID]).VpcID,
+		CloudVpcID:           cloudVpcID,
+		SubnetID:             subnetMap[cloudSubnetID],
+		CloudSubnetID:        cloudSubnetID,
+		PrivateIPv4Addresses: privateIPv4,
+		PublicIPv4Addresses:  publicIPv4,
+		PublicIPv6Addresses:  ipv6,
+		Domain:               cvt.PtrToVal(cloud.DNSName),
+		Status:               cloud.GetStatus(),
+		CloudCreatedTime:     times.ConvStdTimeFormat(cvt.PtrToVal(cloud.CreatedTime)),
+		Tags:                 cloud.GetTagMap(),
+		Extension:            convAwsLBExtension(cloud),
+	}
+}
+
+func convCloudToDBUpdate(id string, cloud typeslb.AwsLoadBalancer, vpcMap map[string]*common.VpcDB,
+	subnetMap map[string]string) *protocloud.LoadBalancerExtUpdateReq[corelb.AwsLoadBalancerExtension] {
+
+	cloudVpcID := cvt.PtrToVal(cloud.VpcId)
+	var cloudSubnetID string
+	if subnetIDs := cloud.GetCloudSubnetIDs(); len(subnetIDs) > 0 {
+		cloudSubnetID = subnetIDs[0]
+	}
+	privateIPv4, publicIPv4, ipv6 := cloud.GetAddresses()
+
+	return &protocloud.LoadBalancerExtUpdateReq[corelb.AwsLoadBalancerExtension]{
+		ID:                   id,
+		Name:                 cloud.GetName(),
+		IPVersion:            cloud.GetIPVersion(),
+		VpcID:                cvt.PtrToVal(vpcMap[cloudVpcID]).VpcID,
+		CloudVpcID:           cloudVpcID,
+		SubnetID:             subnetMap[cloudSubnetID],
+		CloudSubnetID:        cloudSubnetID,
+		PrivateIPv4Addresses: privateIPv4,
+		PublicIPv4Addresses:  publicIPv4,
+		PublicIPv6Addresses:  ipv6,
+		Domain:               cvt.PtrToVal(cloud.DNSName),
+		Status:               cloud.GetStatus(),
+		CloudCreatedTime:     times.ConvStdTimeFormat(cvt.PtrToVal(cloud.CreatedTime)),
+		Tags:                 cloud.GetTagMap(),
+		Extension:            convAwsLBExtension(cloud),
+	}
+}
+
+func convAwsLBExtension(cloud typeslb.AwsLoadBalancer) *corelb.AwsLoadBalancerExtension {
+	ext := &corelb.AwsLoadBalancerExtension{
+		Type:                  cloud.Type,
+		Scheme:                cloud.Scheme,
+		IPAddressType:         cloud.IpAddressType,
+		CanonicalHostedZoneID: cloud.CanonicalHostedZoneId,
+		CloudSubnetIDs:        cloud.GetCloudSubnetIDs(),
+		CloudSecurityGroupIDs: cvt.PtrToSlice(cloud.SecurityGroups),
+	}
+	if cloud.State != nil {
+		ext.StateReason = cloud.State.Reason
+	}
+	return ext
+}
+
+func isLBChange(cloud typeslb.AwsLoadBalancer, db corelb.AwsLoadBalancer) bool {
+	if db.Name != cloud.GetName() {
+		return true
+	}
+
+	if db.IPVersion != cloud.GetIPVersion() {
+		return true
+	}
+
+	if db.Domain != cvt.PtrToVal(cloud.DNSName) {
+		return true
+	}
+
+	if db.Status != cloud.GetStatus() {
+		return true
+	}
+
+	if db.CloudVpcID != cvt.PtrToVal(cloud.VpcId) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.Zones, cloud.GetZones()) {
+		return true
+	}
+
+	privateIPv4, publicIPv4, ipv6 := cloud.GetAddresses()
+	if !assert.IsStringSliceEqual(db.PrivateIPv4Addresses, privateIPv4) ||
+		!assert.IsStringSliceEqual(db.PublicIPv4Addresses, publicIPv4) ||
+		!assert.IsStringSliceEqual(db.PublicIPv6Addresses, ipv6) {
+		return true
+	}
+
+	if !assert.IsStringMapEqual(db.Tags, cloud.GetTagMap()) {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.Extension.CloudSubnetIDs, cloud.GetCloudSubnetIDs()) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.Extension.CloudSecurityGroupIDs, cvt.PtrToSlice(cloud.SecurityGroups)) {
+		return true
+	}
+
+	if cloud.State != nil && !assert.IsPtrStringEqual(db.Extension.StateReason, cloud.State.Reason) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,245 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// Listener 同步指定负载均衡下的所有监听器，aws 监听器没有名称，使用 协议:端口 作为名称
+func (cli *client) Listener(kt *kit.Kit, accountID, region string, lb corelb.AwsLoadBalancer) (*SyncResult,
+	error) {
+
+	listOpt := &typeslb.AwsListListenersOption{Region: region, LoadBalancerID: lb.CloudID}
+	lblFromCloud, err := cli.cloudCli.ListListener(kt, listOpt)
+	if err != nil {
+		logs.Errorf("[%s] list listener from cloud failed, err: %v, lb: %s, rid: %s", enumor.Aws, err,
+			lb.CloudID, kt.Rid)
+		return nil, err
+	}
+
+	lblFromDB, err := cli.listListenerFromDB(kt, lb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lblFromCloud) == 0 && len(lblFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.AwsListener, corelb.AwsListener](
+		lblFromCloud, lblFromDB, isListenerChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteListener(kt, region, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createListener(kt, accountID, lb, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateListener(kt, lb.BkBizID, region, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) listListenerFromDB(kt *kit.Kit, lbID string) ([]corelb.AwsListener, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("lb_id", lbID),
+			tools.RuleEqual("vendor", enumor.Aws),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+
+	result := make([]corelb.AwsListener, 0)
+	for {
+		resp, err := cli.dbCli.Aws.LoadBalancer.ListListener(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list listener from db failed, err: %v, lbID: %s, rid: %s", enumor.Aws, err, lbID,
+				kt.Rid)
+			return nil, err
+		}
+		result = append(result, resp.Details...)
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func (cli *client) createListener(kt *kit.Kit, accountID string, lb corelb.AwsLoadBalancer,
+	addSlice []typeslb.AwsListener) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	for _, batch := range slice.Split(addSlice, constant.BatchOperationMaxLimit) {
+		createReq := &protocloud.AwsListenerBatchCreateReq{
+			Listeners: make([]protocloud.ListenersCreateReq[corelb.AwsListenerExtension], 0, len(batch)),
+		}
+		for _, one := range batch {
+			createReq.Listeners = append(createReq.Listeners, protocloud.ListenersCreateReq[corelb.AwsListenerExtension]{
+				CloudID:   one.GetCloudID(),
+				Name:      one.GetName(),
+				Vendor:    enumor.Aws,
+				AccountID: accountID,
+				BkBizID:   lb.BkBizID,
+				LbID:      lb.ID,
+				CloudLbID: lb.CloudID,
+				Protocol:  one.GetProtocol(),
+				Port:      cvt.PtrToVal(one.Port),
+				Region:    lb.Region,
+				Extension: convAwsListenerExtension(one),
+			})
+		}
+
+		if _, err := cli.dbCli.Aws.LoadBalancer.BatchCreateAwsListener(kt, createReq); err != nil {
+			logs.Errorf("[%s] call data service to create listener failed, err: %v, rid: %s", enumor.Aws, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync listener to create listener success, lb: %s, count: %d, rid: %s", enumor.Aws,
+		lb.CloudID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateListener(kt *kit.Kit, bizID int64, region string,
+	updateMap map[string]typeslb.AwsListener) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updates := make([]*protocloud.AwsListenerUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		updates = append(updates, &protocloud.AwsListenerUpdate{
+			ID:        id,
+			Name:      one.GetName(),
+			BkBizID:   bizID,
+			Region:    region,
+			Extension: convAwsListenerExtension(one),
+		})
+	}
+
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		err := cli.dbCli.Aws.LoadBalancer.BatchUpdateAwsListener(kt,
+			&protocloud.AwsListenerUpdateReq{Listeners: batch})
+		if err != nil {
+			logs.Errorf("[%s] call data service to update listener failed, err: %v, rid: %s", enumor.Aws, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) deleteListener(kt *kit.Kit, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	for _, batch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		delReq := &protocloud.LoadBalancerBatchDeleteReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("cloud_id", batch),
+				tools.RuleEqual("vendor", enumor.Aws),
+				tools.RuleEqual("region", region),
+			),
+		}
+		if err := cli.dbCli.Global.LoadBalancer.DeleteListener(kt, delReq); err != nil {
+			logs.Errorf("[%s] call data service to delete listener failed, err: %v, ids: %v, rid: %s",
+				enumor.Aws, err, batch, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convAwsListenerExtension(cloud typeslb.AwsListener) *corelb.AwsListenerExtension {
+	ext := &corelb.AwsListenerExtension{
+		SslPolicy:                  cloud.SslPolicy,
+		DefaultCloudTargetGroupIDs: cloud.GetDefaultTargetGroupIDs(),
+		AlpnPolicy:                 cvt.PtrToSlice(cloud.AlpnPolicy),
+	}
+	for _, cert := range cloud.Certificates {
+		if cert == nil {
+			continue
+		}
+		ext.CertificateIDs = append(ext.CertificateIDs, cvt.PtrToVal(cert.CertificateArn))
+	}
+	return ext
+}
+
+func isListenerChange(cloud typeslb.AwsListener, db corelb.AwsListener) bool {
+	if db.Name != cloud.GetName() {
+		return true
+	}
+
+	if db.Protocol != cloud.GetProtocol() || db.Port != cvt.PtrToVal(cloud.Port) {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	cloudExt := convAwsListenerExtension(cloud)
+	if !assert.IsPtrStringEqual(db.Extension.SslPolicy, cloudExt.SslPolicy) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.Extension.CertificateIDs, cloudExt.CertificateIDs) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.Extension.DefaultCloudTargetGroupIDs, cloudExt.DefaultCloudTargetGroupIDs) {
+		return true
+	}
+
+	return !assert.IsStringSliceEqual(db.Extension.AlpnPolicy, cloudExt.AlpnPolicy)
+}
@@ -0,0 +1,307 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// ListenerRule 同步负载均衡下HTTP/HTTPS监听器的转发规则，需要在监听器和目标组同步之后执行。
+// 默认规则即监听器的默认动作，已记录在监听器扩展字段中，不作为规则同步
+func (cli *client) ListenerRule(kt *kit.Kit, accountID, region string, lb corelb.AwsLoadBalancer) error {
+	listeners, err := cli.listListenerFromDB(kt, lb.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, lbl := range listeners {
+		if !lbl.Protocol.IsLayer7Protocol() {
+			continue
+		}
+
+		if err = cli.listenerRule(kt, accountID, region, lb, lbl); err != nil {
+			logs.Errorf("[%s] fail to sync rule of listener: %s, err: %v, rid: %s", enumor.Aws, lbl.CloudID, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) listenerRule(kt *kit.Kit, accountID, region string, lb corelb.AwsLoadBalancer,
+	lbl corelb.AwsListener) error {
+
+	opt := &typeslb.AwsListRuleOption{Region: region, ListenerID: lbl.CloudID}
+	rules, err := cli.cloudCli.ListRule(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list rule from cloud failed, err: %v, listener: %s, rid: %s", enumor.Aws, err,
+			lbl.CloudID, kt.Rid)
+		return err
+	}
+	ruleFromCloud := slice.Filter(rules, func(one typeslb.AwsRule) bool { return !one.IsDefaultRule() })
+
+	ruleFromDB, err := cli.listRuleFromDB(kt, lbl.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(ruleFromCloud) == 0 && len(ruleFromDB) == 0 {
+		return nil
+	}
+
+	tgIDMap, err := cli.getTargetGroupIDMap(kt, accountID, region, ruleFromCloud)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.AwsRule, corelb.TCloudLbUrlRule](ruleFromCloud,
+		ruleFromDB, func(cloud typeslb.AwsRule, db corelb.TCloudLbUrlRule) bool {
+			return isRuleChange(cloud, db) || isRuleTargetGroupChange(cloud, db, tgIDMap)
+		})
+	// 目标组变化时需要重建目标组与规则的关联关系，按删除后新建处理
+	addSlice, updateMap, delCloudIDs = splitRuleTargetGroupChange(ruleFromDB, tgIDMap, addSlice, updateMap,
+		delCloudIDs)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteRule(kt, region, delCloudIDs); err != nil {
+		return err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createRule(kt, region, lb, lbl, tgIDMap, addSlice); err != nil {
+		return err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateRule(kt, region, updateMap); err != nil {
+		return err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return nil
+}
+
+func (cli *client) listRuleFromDB(kt *kit.Kit, lblID string) ([]corelb.TCloudLbUrlRule, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("lbl_id", lblID),
+			tools.RuleEqual("rule_type", enumor.Layer7RuleType),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+
+	result := make([]corelb.TCloudLbUrlRule, 0)
+	for {
+		resp, err := cli.dbCli.Aws.LoadBalancer.ListUrlRule(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list rule from db failed, err: %v, lblID: %s, rid: %s", enumor.Aws, err, lblID,
+				kt.Rid)
+			return nil, err
+		}
+		result = append(result, resp.Details...)
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// getTargetGroupIDMap 返回规则转发目标组的 云上ID -> 本地ID 映射，未同步的目标组不在结果中
+func (cli *client) getTargetGroupIDMap(kt *kit.Kit, accountID, region string, rules []typeslb.AwsRule) (
+	map[string]string, error) {
+
+	cloudIDs := make([]string, 0, len(rules))
+	for _, one := range rules {
+		if tgID := one.GetCloudTargetGroupID(); len(tgID) != 0 {
+			cloudIDs = append(cloudIDs, tgID)
+		}
+	}
+
+	tgIDMap := make(map[string]string)
+	if len(cloudIDs) == 0 {
+		return tgIDMap, nil
+	}
+
+	params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: slice.Unique(cloudIDs)}
+	tgFromDB, err := cli.listTargetGroupFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range tgFromDB {
+		tgIDMap[one.CloudID] = one.ID
+	}
+
+	return tgIDMap, nil
+}
+
+func (cli *client) createRule(kt *kit.Kit, region string, lb corelb.AwsLoadBalancer, lbl corelb.AwsListener,
+	tgIDMap map[string]string, addSlice []typeslb.AwsRule) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	tgIDs := make([]string, 0)
+	for _, batch := range slice.Split(addSlice, constant.BatchOperationMaxLimit) {
+		rules := make([]protocloud.TCloudUrlRuleCreate, 0, len(batch))
+		for _, one := range batch {
+			rule := protocloud.TCloudUrlRuleCreate{
+				Vendor:             enumor.Aws,
+				LbID:               lb.ID,
+				CloudLbID:          lb.CloudID,
+				LblID:              lbl.ID,
+				CloudLBLID:         lbl.CloudID,
+				CloudID:            one.GetCloudID(),
+				Name:               one.GetName(),
+				RuleType:           enumor.Layer7RuleType,
+				CloudTargetGroupID: one.GetCloudTargetGroupID(),
+				TargetGroupID:      tgIDMap[one.GetCloudTargetGroupID()],
+				Region:             region,
+				Domain:             one.GetDomain(),
+				URL:                one.GetURL(),
+				// aws 健康检查配置在目标组上，证书配置在监听器上
+				HealthCheck: new(corelb.TCloudHealthCheckInfo),
+				Certificate: new(corelb.TCloudCertificateInfo),
+			}
+			if len(rule.TargetGroupID) != 0 {
+				tgIDs = append(tgIDs, rule.TargetGroupID)
+			}
+			rules = append(rules, rule)
+		}
+
+		req := &protocloud.TCloudUrlRuleBatchCreateReq{UrlRules: rules}
+		if _, err := cli.dbCli.Aws.LoadBalancer.BatchCreateUrlRule(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to create rule failed, err: %v, listener: %s, rid: %s",
+				enumor.Aws, err, lbl.CloudID, kt.Rid)
+			return err
+		}
+	}
+
+	// 云上规则已经生效，关联关系直接置为绑定成功
+	relReq := &protocloud.TGListenerRelStatusUpdateReq{BindingStatus: enumor.SuccessBindingStatus}
+	for _, tgID := range slice.Unique(tgIDs) {
+		if err := cli.dbCli.Global.LoadBalancer.BatchUpdateListenerRuleRelStatusByTGID(kt, tgID, relReq); err != nil {
+			logs.Errorf("[%s] update rule rel status failed, err: %v, tgID: %s, rid: %s", enumor.Aws, err, tgID,
+				kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync rule to create rule success, listener: %s, count: %d, rid: %s", enumor.Aws,
+		lbl.CloudID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateRule(kt *kit.Kit, region string, updateMap map[string]typeslb.AwsRule) error {
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updates := make([]*protocloud.TCloudUrlRuleUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		updates = append(updates, &protocloud.TCloudUrlRuleUpdate{
+			ID:     id,
+			Name:   one.GetName(),
+			Region: region,
+			Domain: one.GetDomain(),
+			URL:    one.GetURL(),
+		})
+	}
+
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		req := &protocloud.TCloudUrlRuleBatchUpdateReq{UrlRules: batch}
+		if err := cli.dbCli.Aws.LoadBalancer.BatchUpdateUrlRule(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to update rule failed, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) deleteRule(kt *kit.Kit, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	for _, batch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		req := &protocloud.LoadBalancerBatchDeleteReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("cloud_id", batch),
+				tools.RuleEqual("region", region),
+			),
+		}
+		if err := cli.dbCli.Aws.LoadBalancer.BatchDeleteUrlRule(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to delete rule failed, err: %v, ids: %v, rid: %s", enumor.Aws,
+				err, batch, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitRuleTargetGroupChange 将转发目标组发生变化的规则从更新集合移到删除和新增集合中
+func splitRuleTargetGroupChange(dbRules []corelb.TCloudLbUrlRule, tgIDMap map[string]string,
+	addSlice []typeslb.AwsRule, updateMap map[string]typeslb.AwsRule, delCloudIDs []string) ([]typeslb.AwsRule,
+	map[string]typeslb.AwsRule, []string) {
+
+	dbMap := make(map[string]corelb.TCloudLbUrlRule, len(dbRules))
+	for _, one := range dbRules {
+		dbMap[one.ID] = one
+	}
+
+	for id, one := range updateMap {
+		db, exists := dbMap[id]
+		if !exists || !isRuleTargetGroupChange(one, db, tgIDMap) {
+			continue
+		}
+		delete(updateMap, id)
+		delCloudIDs = append(delCloudIDs, db.CloudID)
+		addSlice = append(addSlice, one)
+	}
+
+	return addSlice, updateMap, delCloudIDs
+}
+
+func isRuleChange(cloud typeslb.AwsRule, db corelb.TCloudLbUrlRule) bool {
+	return db.Name != cloud.GetName() || db.Domain != cloud.GetDomain() || db.URL != cloud.GetURL()
+}
+
+// isRuleTargetGroupChange 转发的目标组变化，或目标组在规则创建之后才同步到本地
+func isRuleTargetGroupChange(cloud typeslb.AwsRule, db corelb.TCloudLbUrlRule, tgIDMap map[string]string) bool {
+	cloudTGID := cloud.GetCloudTargetGroupID()
+	return db.CloudTargetGroupID != cloudTGID || db.TargetGroupID != tgIDMap[cloudTGID]
+}
@@ -0,0 +1,155 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"testing"
+
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+func newAwsForwardRule(arn, host, tgArn string) typeslb.AwsRule {
+	return typeslb.AwsRule{Rule: &elbv2.Rule{
+		RuleArn:  cvt.ValToPtr(arn),
+		Priority: cvt.ValToPtr("1"),
+		Conditions: []*elbv2.RuleCondition{
+			{Field: cvt.ValToPtr("host-header"), Values: []*string{cvt.ValToPtr(host)}},
+		},
+		Actions: []*elbv2.Action{
+			{Type: cvt.ValToPtr(elbv2.ActionTypeEnumForward), TargetGroupArn: cvt.ValToPtr(tgArn)},
+		},
+	}}
+}
+
+func TestSplitRuleTargetGroupChange(t *testing.T) {
+	tgIDMap := map[string]string{"tg-arn-1": "tg-1", "tg-arn-2": "tg-2"}
+	dbRules := []corelb.TCloudLbUrlRule{
+		{ID: "r-1", CloudID: "rule-1", Name: "1", Domain: "old.com", CloudTargetGroupID: "tg-arn-1",
+			TargetGroupID: "tg-1"},
+		{ID: "r-2", CloudID: "rule-2", Name: "1", Domain: "b.com", CloudTargetGroupID: "tg-arn-1",
+			TargetGroupID: "tg-1"},
+		// 规则创建时目标组尚未同步到本地
+		{ID: "r-3", CloudID: "rule-3", Name: "1", Domain: "c.com", CloudTargetGroupID: "tg-arn-2"},
+	}
+	updateMap := map[string]typeslb.AwsRule{
+		"r-1": newAwsForwardRule("rule-1", "new.com", "tg-arn-1"),
+		"r-2": newAwsForwardRule("rule-2", "b.com", "tg-arn-2"),
+		"r-3": newAwsForwardRule("rule-3", "c.com", "tg-arn-2"),
+	}
+
+	addSlice, updateMap, delCloudIDs := splitRuleTargetGroupChange(dbRules, tgIDMap, nil, updateMap, nil)
+	if len(updateMap) != 1 || updateMap["r-1"].Rule == nil {
+		t.Errorf("only rule with unchanged target group should be updated, got: %v", updateMap)
+	}
+	if len(addSlice) != 2 || len(delCloudIDs) != 2 {
+		t.Fatalf("rules with changed target group should be recreated, add: %d, del: %v", len(addSlice),
+			delCloudIDs)
+	}
+	delMap := cvt.StringSliceToMap(delCloudIDs)
+	if _, exists := delMap["rule-2"]; !exists {
+		t.Errorf("rule-2 should be deleted, got: %v", delCloudIDs)
+	}
+	if _, exists := delMap["rule-3"]; !exists {
+		t.Errorf("rule-3 should be deleted, got: %v", delCloudIDs)
+	}
+}
+
+func TestIsRuleTargetGroupChange(t *testing.T) {
+	cloud := newAwsForwardRule("rule-1", "a.com", "tg-arn-1")
+	db := corelb.TCloudLbUrlRule{CloudID: "rule-1", CloudTargetGroupID: "tg-arn-1", TargetGroupID: "tg-1"}
+
+	if isRuleTargetGroupChange(cloud, db, map[string]string{"tg-arn-1": "tg-1"}) {
+		t.Errorf("target group not changed")
+	}
+	// 目标组未同步到本地时不视为变化，避免每次同步都重建规则
+	db.TargetGroupID = ""
+	if isRuleTargetGroupChange(cloud, db, map[string]string{}) {
+		t.Errorf("target group not synced should not be treated as change")
+	}
+	if !isRuleTargetGroupChange(cloud, db, map[string]string{"tg-arn-1": "tg-1"}) {
+		t.Errorf("target group synced after rule creation should be treated as change")
+	}
+}
+
+func TestIsTargetGroupChange(t *testing.T) {
+	cloud := typeslb.AwsTargetGroup{TargetGroup: &elbv2.TargetGroup{
+		TargetGroupArn:     cvt.ValToPtr("tg-arn-1"),
+		TargetGroupName:    cvt.ValToPtr("tg"),
+		Protocol:           cvt.ValToPtr("HTTP"),
+		Port:               cvt.ValToPtr(int64(80)),
+		VpcId:              cvt.ValToPtr("vpc-1"),
+		HealthCheckEnabled: cvt.ValToPtr(true),
+		HealthCheckPath:    cvt.ValToPtr("/"),
+		Matcher:            &elbv2.Matcher{HttpCode: cvt.ValToPtr("200")},
+	}}
+	db := corelb.AwsTargetGroup{
+		BaseTargetGroup: corelb.BaseTargetGroup{CloudID: "tg-arn-1", Name: "tg", Protocol: "HTTP", Port: 80,
+			CloudVpcID: "vpc-1"},
+		Extension: convAwsTargetGroupExtension(cloud),
+	}
+	if isTargetGroupChange(cloud, db) {
+		t.Errorf("target group converted from cloud should not be changed")
+	}
+
+	cloud.Matcher.HttpCode = cvt.ValToPtr("200-299")
+	if !isTargetGroupChange(cloud, db) {
+		t.Errorf("health check matcher change should be detected")
+	}
+
+	db.Extension = nil
+	if !isTargetGroupChange(cloud, db) {
+		t.Errorf("target group without extension should be updated")
+	}
+}
+
+func TestDiffTarget(t *testing.T) {
+	newTarget := func(id string, port int64) typeslb.AwsTargetHealth {
+		return typeslb.AwsTargetHealth{TargetHealthDescription: &elbv2.TargetHealthDescription{
+			Target: &elbv2.TargetDescription{Id: cvt.ValToPtr(id), Port: cvt.ValToPtr(port)},
+		}}
+	}
+	cloud := []typeslb.AwsTargetHealth{newTarget("i-1", 80), newTarget("i-1", 8080), newTarget("10.0.0.1", 80)}
+	db := []corelb.BaseTarget{
+		{ID: "t-1", CloudInstID: "i-1", Port: 80},
+		{ID: "t-2", CloudInstID: "i-2", Port: 80},
+	}
+
+	addSlice, delIDs := diffTarget(cloud, db)
+	if len(addSlice) != 2 {
+		t.Errorf("same instance with different port should be added, got: %d", len(addSlice))
+	}
+	if len(delIDs) != 1 || delIDs[0] != "t-2" {
+		t.Errorf("unexpected delete ids: %v", delIDs)
+	}
+
+	ipTarget := convTargetCreate(newTarget("10.0.0.1", 80), "account", "region", "tg")
+	if ipTarget.IP != "10.0.0.1" || ipTarget.InstType != enumor.EniInstType {
+		t.Errorf("ip target should be converted as eni target, got: %+v", ipTarget)
+	}
+	if cvmTarget := convTargetCreate(newTarget("i-1", 80), "account", "region", "tg"); cvmTarget.InstType !=
+		enumor.CvmInstType {
+		t.Errorf("instance target should be converted as cvm target, got: %+v", cvmTarget)
+	}
+}
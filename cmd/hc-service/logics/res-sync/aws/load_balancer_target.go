@@ -0,0 +1,185 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// Target 同步目标组中的目标，aws 目标只能通过查询目标健康状态获取。目标没有可更新的属性，只做新增和删除
+func (cli *client) Target(kt *kit.Kit, accountID, region, tgID, cloudTGID string) error {
+	healthOpt := &typeslb.AwsListTargetHealthOption{Region: region, TargetGroupID: cloudTGID}
+	targetFromCloud, err := cli.cloudCli.ListTargetHealth(kt, healthOpt)
+	if err != nil {
+		logs.Errorf("[%s] list target health from cloud failed, err: %v, tg: %s, rid: %s", enumor.Aws, err,
+			cloudTGID, kt.Rid)
+		return err
+	}
+
+	targetFromDB, err := cli.listTargetFromDB(kt, tgID)
+	if err != nil {
+		return err
+	}
+
+	if len(targetFromCloud) == 0 && len(targetFromDB) == 0 {
+		return nil
+	}
+
+	addSlice, delIDs := diffTarget(targetFromCloud, targetFromDB)
+	addSlice, _, delIDs = common.PlanDiff[typeslb.AwsTargetHealth](kt, addSlice, nil, delIDs)
+
+	if err = cli.deleteTarget(kt, delIDs); err != nil {
+		return err
+	}
+
+	if err = cli.createTarget(kt, accountID, region, tgID, addSlice); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (cli *client) listTargetFromDB(kt *kit.Kit, tgID string) ([]corelb.BaseTarget, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("target_group_id", tgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	result := make([]corelb.BaseTarget, 0)
+	for {
+		resp, err := cli.dbCli.Global.LoadBalancer.ListTarget(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list target from db failed, err: %v, tgID: %s, rid: %s", enumor.Aws, err, tgID,
+				kt.Rid)
+			return nil, err
+		}
+		result = append(result, resp.Details...)
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func (cli *client) createTarget(kt *kit.Kit, accountID, region, tgID string,
+	addSlice []typeslb.AwsTargetHealth) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	targets := slice.Map(addSlice, func(one typeslb.AwsTargetHealth) *protocloud.TargetBaseReq {
+		return convTargetCreate(one, accountID, region, tgID)
+	})
+	for _, batch := range slice.Split(targets, constant.BatchOperationMaxLimit) {
+		req := &protocloud.TargetBatchCreateReq{Targets: batch}
+		if _, err := cli.dbCli.Global.LoadBalancer.BatchCreateTCloudTarget(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to create target failed, err: %v, tgID: %s, rid: %s", enumor.Aws,
+				err, tgID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) deleteTarget(kt *kit.Kit, delIDs []string) error {
+	if len(delIDs) == 0 {
+		return nil
+	}
+
+	for _, batch := range slice.Split(delIDs, constant.BatchOperationMaxLimit) {
+		req := &protocloud.LoadBalancerBatchDeleteReq{Filter: tools.ContainersExpression("id", batch)}
+		if err := cli.dbCli.Global.LoadBalancer.BatchDeleteTarget(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to delete target failed, err: %v, ids: %v, rid: %s", enumor.Aws,
+				err, batch, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// diffTarget 以 实例ID-端口 对比云上和本地的目标，返回需要新增的云上目标和需要删除的本地目标ID
+func diffTarget(cloudTargets []typeslb.AwsTargetHealth, dbTargets []corelb.BaseTarget) (
+	[]typeslb.AwsTargetHealth, []string) {
+
+	dbMap := make(map[string]string, len(dbTargets))
+	for _, one := range dbTargets {
+		dbMap[awsTargetKey(one)] = one.ID
+	}
+
+	addSlice := make([]typeslb.AwsTargetHealth, 0)
+	for _, one := range cloudTargets {
+		if _, exists := dbMap[one.GetCloudID()]; exists {
+			delete(dbMap, one.GetCloudID())
+			continue
+		}
+		addSlice = append(addSlice, one)
+	}
+
+	return addSlice, cvt.MapValueToSlice(dbMap)
+}
+
+func awsTargetKey(target corelb.BaseTarget) string {
+	return fmt.Sprintf("%s-%d", target.CloudInstID, target.Port)
+}
+
+// convTargetCreate instance 类型目标为云主机，ip 类型目标使用IP作为实例ID
+func convTargetCreate(cloud typeslb.AwsTargetHealth, accountID, region, tgID string) *protocloud.TargetBaseReq {
+	target := &protocloud.TargetBaseReq{
+		InstType:          enumor.CvmInstType,
+		CloudInstID:       cloud.GetCloudInstID(),
+		Port:              cloud.GetPort(),
+		Weight:            cvt.ValToPtr(int64(0)),
+		AccountID:         accountID,
+		TargetGroupID:     tgID,
+		TargetGroupRegion: region,
+		Zone:              cloud.GetZone(),
+	}
+	if !isInstanceTarget(cloud.GetCloudInstID()) {
+		target.InstType = enumor.EniInstType
+		target.IP = cloud.GetCloudInstID()
+		target.PrivateIPAddress = []string{cloud.GetCloudInstID()}
+	}
+	return target
+}
+
+// isInstanceTarget ip 类型目标组的目标ID为IP地址，instance 类型为 i- 开头的实例ID
+func isInstanceTarget(cloudInstID string) bool {
+	return strings.HasPrefix(cloudInstID, "i-")
+}
@@ -0,0 +1,471 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// SyncTargetGroupOption ...
+type SyncTargetGroupOption struct {
+	// BizID 新建目标组时使用的业务，一般为目标组所属负载均衡的业务
+	BizID int64 `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate ...
+func (opt SyncTargetGroupOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TargetGroup 同步指定目标组及目标组中的目标，aws 没有单独查询目标的接口，目标列表来自目标健康状态查询。
+// lambda 类型目标组没有vpc、协议和端口，不做同步
+func (cli *client) TargetGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncTargetGroupOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tgFromCloud, err := cli.listTargetGroupFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tgFromDB, err := cli.listTargetGroupFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tgFromCloud) == 0 && len(tgFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.AwsTargetGroup, corelb.AwsTargetGroup](
+		tgFromCloud, tgFromDB, isTargetGroupChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteTargetGroup(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createTargetGroup(kt, params.AccountID, params.Region, opt.BizID, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateTargetGroup(kt, params.Region, tgFromDB, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	// 重新查询，获取新建目标组的本地ID
+	tgFromDB, err = cli.listTargetGroupFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+	tgIDMap := make(map[string]string, len(tgFromDB))
+	for _, one := range tgFromDB {
+		tgIDMap[one.CloudID] = one.ID
+	}
+	for _, one := range tgFromCloud {
+		tgID, exists := tgIDMap[one.GetCloudID()]
+		if !exists {
+			// vpc 未同步或预览模式下新增的目标组不会写入，此时不同步其中的目标
+			continue
+		}
+		if err = cli.Target(kt, params.AccountID, params.Region, tgID, one.GetCloudID()); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// LoadBalancerTargetGroup 同步负载均衡关联的目标组
+func (cli *client) LoadBalancerTargetGroup(kt *kit.Kit, accountID, region string, lb corelb.AwsLoadBalancer) error {
+	cloudIDs := make([]string, 0)
+	listOpt := &typeslb.AwsListTargetGroupOption{
+		Region:         region,
+		LoadBalancerID: lb.CloudID,
+		Page:           &typeslb.AwsLBPage{PageSize: cvt.ValToPtr(int64(typeslb.AwsLBPageSizeMax))},
+	}
+	for {
+		result, err := cli.cloudCli.ListTargetGroup(kt, listOpt)
+		if err != nil {
+			logs.Errorf("[%s] list target group of lb from cloud failed, err: %v, lb: %s, rid: %s", enumor.Aws,
+				err, lb.CloudID, kt.Rid)
+			return err
+		}
+		cloudIDs = append(cloudIDs, slice.Map(result.Details, typeslb.AwsTargetGroup.GetCloudID)...)
+
+		if result.NextMarker == nil {
+			break
+		}
+		listOpt.Page.Marker = result.NextMarker
+	}
+
+	if len(cloudIDs) == 0 {
+		return nil
+	}
+
+	params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+	for _, batch := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+		params.CloudIDs = batch
+		if _, err := cli.TargetGroup(kt, params, &SyncTargetGroupOption{BizID: lb.BkBizID}); err != nil {
+			logs.Errorf("[%s] sync target group of lb failed, err: %v, lb: %s, rid: %s", enumor.Aws, err,
+				lb.CloudID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveTargetGroupDeleteFromCloud 删除存在本地但是在云上被删除的目标组
+func (cli *client) RemoveTargetGroupDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("vendor", enumor.Aws),
+			tools.RuleEqual("target_group_type", enumor.CloudTargetGroupType),
+		),
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: typeslb.AwsLBDescribeMax,
+		},
+	}
+
+	for {
+		tgFromDB, err := cli.dbCli.Global.LoadBalancer.ListTargetGroup(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list target group failed, err: %v, req: %v, rid: %s",
+				enumor.Aws, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := slice.Map(tgFromDB.Details, corelb.BaseTargetGroup.GetCloudID)
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+		tgFromCloud, err := cli.listTargetGroupFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(tgFromCloud) != len(cloudIDs) {
+			cloudIDMap := cvt.StringSliceToMap(cloudIDs)
+			for _, one := range tgFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+
+			delCloudIDs := cvt.MapKeyToStringSlice(cloudIDMap)
+			if common.PlanDelete(kt, delCloudIDs) {
+				if err = cli.deleteTargetGroup(kt, accountID, region, delCloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
+			}
+		}
+
+		common.RecordScanned(kt, len(tgFromDB.Details))
+		if uint(len(tgFromDB.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return nil
+}
+
+func (cli *client) createTargetGroup(kt *kit.Kit, accountID, region string, bizID int64,
+	addSlice []typeslb.AwsTargetGroup) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	cloudVpcIDs := slice.Unique(slice.Map(addSlice, typeslb.AwsTargetGroup.GetCloudVpcID))
+	vpcMap, err := cli.getVpcMap(kt, accountID, region, cloudVpcIDs)
+	if err != nil {
+		logs.Errorf("[%s] fail to get vpc of target group, err: %v, vpcIDs: %v, rid: %s", enumor.Aws, err,
+			cloudVpcIDs, kt.Rid)
+		return err
+	}
+
+	createReq := &protocloud.AwsTargetGroupCreateReq{
+		TargetGroups: make([]protocloud.TargetGroupBatchCreate[corelb.AwsTargetGroupExtension], 0, len(addSlice)),
+	}
+	for _, one := range addSlice {
+		if _, exists := vpcMap[one.GetCloudVpcID()]; !exists {
+			// vpc 未同步时跳过，等待下次同步
+			logs.Errorf("[%s] vpc of target group not found, skip, tg: %s, vpc: %s, rid: %s", enumor.Aws,
+				one.GetCloudID(), one.GetCloudVpcID(), kt.Rid)
+			continue
+		}
+
+		createReq.TargetGroups = append(createReq.TargetGroups, convTargetGroupCreate(one, accountID, region, bizID))
+	}
+
+	for _, batch := range slice.Split(createReq.TargetGroups, constant.BatchOperationMaxLimit) {
+		req := &protocloud.AwsTargetGroupCreateReq{TargetGroups: batch}
+		if _, err = cli.dbCli.Aws.LoadBalancer.BatchCreateAwsTargetGroup(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to create target group failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync target group to create target group success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(createReq.TargetGroups), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateTargetGroup(kt *kit.Kit, region string, tgFromDB []corelb.AwsTargetGroup,
+	updateMap map[string]typeslb.AwsTargetGroup) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	dbMap := make(map[string]corelb.AwsTargetGroup, len(tgFromDB))
+	for _, one := range tgFromDB {
+		dbMap[one.ID] = one
+	}
+
+	updates := make([]*protocloud.TargetGroupExtUpdateReq[corelb.AwsTargetGroupExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		updates = append(updates, &protocloud.TargetGroupExtUpdateReq[corelb.AwsTargetGroupExtension]{
+			ID:       id,
+			Name:     one.GetName(),
+			BkBizID:  dbMap[id].BkBizID,
+			Region:   region,
+			Protocol: one.GetProtocol(),
+			Port:     one.GetPort(),
+			// 更新时会覆盖备注，使用本地的备注
+			Memo:      dbMap[id].Memo,
+			Extension: convAwsTargetGroupExtension(one),
+		})
+	}
+
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		req := protocloud.AwsTargetGroupBatchUpdateReq(batch)
+		if err := cli.dbCli.Aws.LoadBalancer.BatchUpdateAwsTargetGroup(kt, &req); err != nil {
+			logs.Errorf("[%s] call data service to update target group failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) deleteTargetGroup(kt *kit.Kit, accountID, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	checkParams := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: delCloudIDs}
+	delTGFromCloud, err := cli.listTargetGroupFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delTGFromCloud) > 0 {
+		logs.Errorf("[%s] validate target group not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.Aws, checkParams, len(delTGFromCloud), kt.Rid)
+		return fmt.Errorf("validate target group not exist failed, before delete")
+	}
+
+	for _, batch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		delReq := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("cloud_id", batch),
+				tools.RuleEqual("vendor", enumor.Aws),
+				tools.RuleEqual("region", region),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		if err = cli.dbCli.Global.LoadBalancer.DeleteTargetGroup(kt, delReq); err != nil {
+			logs.Errorf("[%s] call data service to delete target group failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync target group to delete target group success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+// listTargetGroupFromCloud 按ARN分批查询目标组，过滤掉lambda类型的目标组
+func (cli *client) listTargetGroupFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typeslb.AwsTargetGroup,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := make([]typeslb.AwsTargetGroup, 0, len(params.CloudIDs))
+	for _, cloudIDs := range slice.Split(params.CloudIDs, typeslb.AwsLBDescribeMax) {
+		opt := &typeslb.AwsListTargetGroupOption{Region: params.Region, CloudIDs: cloudIDs}
+		batch, err := cli.cloudCli.ListTargetGroup(kt, opt)
+		if err != nil {
+			logs.Errorf("[%s] list target group from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+				enumor.Aws, err, params.AccountID, opt, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range batch.Details {
+			if cvt.PtrToVal(one.TargetType) == elbv2.TargetTypeEnumLambda {
+				continue
+			}
+			result = append(result, one)
+		}
+	}
+
+	return result, nil
+}
+
+func (cli *client) listTargetGroupFromDB(kt *kit.Kit, params *SyncBaseParams) ([]corelb.AwsTargetGroup, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := make([]corelb.AwsTargetGroup, 0, len(params.CloudIDs))
+	for _, cloudIDs := range slice.Split(params.CloudIDs, int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("account_id", params.AccountID),
+				tools.RuleEqual("region", params.Region),
+				tools.RuleEqual("vendor", enumor.Aws),
+				tools.RuleIn("cloud_id", cloudIDs),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		resp, err := cli.dbCli.Aws.LoadBalancer.ListTargetGroup(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list target group from db failed, err: %v, account: %s, req: %v, rid: %s",
+				enumor.Aws, err, params.AccountID, req, kt.Rid)
+			return nil, err
+		}
+		result = append(result, resp.Details...)
+	}
+
+	return result, nil
+}
+
+func convTargetGroupCreate(cloud typeslb.AwsTargetGroup, accountID, region string,
+	bizID int64) protocloud.TargetGroupBatchCreate[corelb.AwsTargetGroupExtension] {
+
+	return protocloud.TargetGroupBatchCreate[corelb.AwsTargetGroupExtension]{
+		CloudID:         cloud.GetCloudID(),
+		Name:            cloud.GetName(),
+		Vendor:          enumor.Aws,
+		AccountID:       accountID,
+		BkBizID:         bizID,
+		Region:          region,
+		Protocol:        cloud.GetProtocol(),
+		Port:            cloud.GetPort(),
+		CloudVpcID:      cloud.GetCloudVpcID(),
+		TargetGroupType: enumor.CloudTargetGroupType,
+		Extension:       convAwsTargetGroupExtension(cloud),
+	}
+}
+
+func convAwsTargetGroupExtension(cloud typeslb.AwsTargetGroup) *corelb.AwsTargetGroupExtension {
+	return &corelb.AwsTargetGroupExtension{
+		TargetType:           cloud.TargetType,
+		IPAddressType:        cloud.IpAddressType,
+		ProtocolVersion:      cloud.ProtocolVersion,
+		HealthCheck:          cloud.GetHealthCheck(),
+		CloudLoadBalancerIDs: cvt.PtrToSlice(cloud.LoadBalancerArns),
+	}
+}
+
+func isTargetGroupChange(cloud typeslb.AwsTargetGroup, db corelb.AwsTargetGroup) bool {
+	if db.Name != cloud.GetName() || db.Protocol != cloud.GetProtocol() || db.Port != cloud.GetPort() {
+		return true
+	}
+
+	if db.CloudVpcID != cloud.GetCloudVpcID() {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	if isAwsHealthCheckChange(cloud.GetHealthCheck(), db.Extension.HealthCheck) {
+		return true
+	}
+
+	cloudExt := convAwsTargetGroupExtension(cloud)
+	if !assert.IsPtrStringEqual(db.Extension.ProtocolVersion, cloudExt.ProtocolVersion) {
+		return true
+	}
+
+	return !assert.IsStringSliceEqual(db.Extension.CloudLoadBalancerIDs, cloudExt.CloudLoadBalancerIDs)
+}
+
+func isAwsHealthCheckChange(cloud, db *corelb.AwsHealthCheckInfo) bool {
+	if db == nil {
+		return true
+	}
+
+	if !assert.IsPtrBoolEqual(cloud.Enabled, db.Enabled) ||
+		!assert.IsPtrInt64Equal(cloud.IntervalSeconds, db.IntervalSeconds) ||
+		!assert.IsPtrInt64Equal(cloud.TimeoutSeconds, db.TimeoutSeconds) ||
+		!assert.IsPtrInt64Equal(cloud.HealthyThreshold, db.HealthyThreshold) ||
+		!assert.IsPtrInt64Equal(cloud.UnhealthyThreshold, db.UnhealthyThreshold) {
+		return true
+	}
+
+	return !assert.IsPtrStringEqual(cloud.Protocol, db.Protocol) ||
+		!assert.IsPtrStringEqual(cloud.Port, db.Port) ||
+		!assert.IsPtrStringEqual(cloud.Path, db.Path) ||
+		!assert.IsPtrStringEqual(cloud.Matcher, db.Matcher)
+}
@@ -73,23 +73,27 @@ func (cli *client) Region(kt *kit.Kit, opt *SyncRegionOption) (*SyncResult, erro
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typesregion.AwsRegion, cloudcore.AwsRegion](
 		regionFromCloud, regionFromDB, isRegionChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteRegion(kt, opt, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createRegion(kt, opt, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateRegion(kt, opt, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
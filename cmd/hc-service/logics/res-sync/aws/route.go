@@ -127,12 +127,14 @@ func (cli *client) route(kt *kit.Kit, opt *syncRouteOption) (*SyncResult, error)
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typesroutetable.AwsRoute,
 		routetable.AwsRoute](routeFromCloud, routeFromDB, isRouteChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteRoute(kt, opt.AccountID, opt.Region, opt.CloudRouteTableID, routeTable.ID,
 			delCloudIDs, routeFromDB); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
@@ -140,6 +142,7 @@ func (cli *client) route(kt *kit.Kit, opt *syncRouteOption) (*SyncResult, error)
 		if err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
@@ -147,6 +150,7 @@ func (cli *client) route(kt *kit.Kit, opt *syncRouteOption) (*SyncResult, error)
 		if err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
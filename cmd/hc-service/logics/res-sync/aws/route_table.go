@@ -74,6 +74,7 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typesroutetable.AwsRouteTable,
 		routetable.AwsRouteTable](routeTableFromCloud, routeTableFromDB, isRouteTableChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	subnetMap := make(map[string]dataproto.RouteTableSubnetReq, 0)
 
@@ -89,6 +90,7 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 				err, params.AccountID, params.Region, delCloudIDs, kt.Rid)
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
@@ -99,6 +101,7 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 		for k, v := range addSubnetMap {
 			subnetMap[k] = v
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
@@ -109,6 +112,7 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 		for k, v := range updateSubnetMap {
 			subnetMap[k] = v
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	// 更新子网的路由表信息
@@ -406,14 +410,16 @@ func (cli *client) RemoveRouteTableDeleteFromCloud(kt *kit.Kit, accountID string
 			return err
 		}
 
-		if len(delCloudIDs) != 0 {
+		if len(delCloudIDs) != 0 && common.PlanDelete(kt, delCloudIDs) {
 			if err = cli.deleteRouteTable(kt, accountID, region, delCloudIDs); err != nil {
 				logs.Errorf("delete aws routeTable failed, err: %v, account: %s, region: %s, delCloudIDs: %v, rid: %s",
 					err, accountID, region, delCloudIDs, kt.Rid)
 				return err
 			}
+			common.RecordDeleted(kt, len(delCloudIDs))
 		}
 
+		common.RecordScanned(kt, len(resultFromDB))
 		if len(resultFromDB) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -72,11 +72,13 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 
 	addSlice, updateMap, delCloudIDs := common.Diff[securitygroup.AwsSG, cloudcore.SecurityGroup[cloudcore.AwsSecurityGroupExtension]](
 		sgFromCloud, sgFromDB, isSGChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteSG(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
@@ -84,12 +86,14 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 		if err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateSG(kt, params.AccountID, params.Region, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	// 同步安全组规则
@@ -384,12 +388,14 @@ func (cli *client) RemoveSecurityGroupDeleteFromCloud(kt *kit.Kit, accountID str
 			}
 		}
 
-		if len(delCloudIDs) != 0 {
+		if len(delCloudIDs) != 0 && common.PlanDelete(kt, delCloudIDs) {
 			if err = cli.deleteSG(kt, accountID, region, delCloudIDs); err != nil {
 				return err
 			}
+			common.RecordDeleted(kt, len(delCloudIDs))
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
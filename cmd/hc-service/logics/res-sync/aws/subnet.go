@@ -74,23 +74,32 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 
 	addSubnet, updateMap, delCloudIDs := common.Diff[adtysubnet.AwsSubnet, cloudcore.Subnet[cloudcore.AwsSubnetExtension]](
 		subnetFromCloud, subnetFromDB, isAwsSubnetChange)
+	addSubnet, updateMap, delCloudIDs = common.PlanDiff(kt, addSubnet, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteSubnet(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSubnet) > 0 {
 		if err = cli.createSubnet(kt, params.AccountID, params.Region, addSubnet); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSubnet))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateSubnet(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
+	}
+
+	if err = common.SyncResTags(kt, cli.dbCli, enumor.Aws, enumor.SubnetCloudResType, params.AccountID,
+		subnetFromCloud); err != nil {
+		return nil, err
 	}
 
 	return nil, nil
@@ -344,12 +353,14 @@ func (cli *client) RemoveSubnetDeleteFromCloud(kt *kit.Kit, accountID string, re
 			}
 		}
 
-		if len(delCloudIDs) != 0 {
+		if len(delCloudIDs) != 0 && common.PlanDelete(kt, delCloudIDs) {
 			if err = cli.deleteSubnet(kt, accountID, region, delCloudIDs); err != nil {
 				return err
 			}
+			common.RecordDeleted(kt, len(delCloudIDs))
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
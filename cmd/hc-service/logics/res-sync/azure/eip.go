@@ -72,23 +72,32 @@ func (cli *client) Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption)
 
 	addEip, updateMap, delCloudIDs := common.Diff[*typeseip.AzureEip,
 		*dataeip.EipExtResult[dataeip.AzureEipExtensionResult]](eipFromCloud, eipFromDB, isEipChange)
+	addEip, updateMap, delCloudIDs = common.PlanDiff(kt, addEip, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteEip(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addEip) > 0 {
 		if err = cli.createEip(kt, params.AccountID, opt.BkBizID, addEip); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addEip))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateEip(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
+	}
+
+	if err = common.SyncResTags(kt, cli.dbCli, enumor.Azure, enumor.EipCloudResType, params.AccountID,
+		eipFromCloud); err != nil {
+		return nil, err
 	}
 
 	return new(SyncResult), nil
@@ -150,11 +159,15 @@ func (cli *client) RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, resGr
 			}
 
 			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
-			if err = cli.deleteEip(kt, accountID, resGroupName, delCloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, delCloudIDs) {
+				if err = cli.deleteEip(kt, accountID, resGroupName, delCloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
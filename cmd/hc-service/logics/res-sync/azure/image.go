@@ -74,23 +74,27 @@ func (cli *client) Image(kt *kit.Kit, opt *SyncImageOption) (*SyncResult, error)
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typesimage.AzureImage, coreimage.Image[coreimage.AzureExtension]](
 		imageFromCloud, imageFromDB, isImageChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteImage(kt, opt, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createImage(kt, opt, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateImage(kt, opt, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -0,0 +1,304 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncKeyPairOption ...
+type SyncKeyPairOption struct {
+}
+
+// Validate ...
+func (opt SyncKeyPairOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// KeyPair 同步SSH密钥对
+func (cli *client) KeyPair(kt *kit.Kit, params *SyncBaseParams, opt *SyncKeyPairOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPairFromCloud, err := cli.listKeyPairFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPairFromDB, err := cli.listKeyPairFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyPairFromCloud) == 0 && len(keyPairFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typekeypair.AzureKeyPair,
+		*corekeypair.KeyPair[corekeypair.AzureExtension]](keyPairFromCloud, keyPairFromDB, isKeyPairChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteKeyPair(kt, params.AccountID, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createKeyPair(kt, params.AccountID, params.ResourceGroupName, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateKeyPair(kt, params.AccountID, params.ResourceGroupName, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) deleteKeyPair(kt *kit.Kit, accountID string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	deleteReq := &dataproto.KeyPairDeleteReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleIn("cloud_id", delCloudIDs),
+		),
+	}
+	if err := cli.dbCli.Global.BatchDeleteKeyPair(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete key pair failed, err: %v, rid: %s", enumor.Azure,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync key pair to delete success, accountID: %s, count: %d, rid: %s", enumor.Azure,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createKeyPair(kt *kit.Kit, accountID, resGroupName string,
+	addSlice []typekeypair.AzureKeyPair) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	createReq := make(dataproto.KeyPairExtBatchCreateReq[corekeypair.AzureExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		createReq = append(createReq, &dataproto.KeyPairExtCreateReq[corekeypair.AzureExtension]{
+			AccountID: accountID,
+			CloudID:   one.GetCloudID(),
+			Name:      converter.PtrToVal(one.Name),
+			Region:    converter.PtrToVal(one.Location),
+			PublicKey: converter.PtrToVal(one.PublicKey),
+			Extension: convAzureKeyPairExtension(resGroupName),
+		})
+	}
+
+	for _, part := range slice.Split(createReq, constant.BatchOperationMaxLimit) {
+		req := dataproto.KeyPairExtBatchCreateReq[corekeypair.AzureExtension](part)
+		if _, err := cli.dbCli.Azure.BatchCreateKeyPair(kt, &req); err != nil {
+			logs.Errorf("[%s] request dataservice to create key pair failed, err: %v, rid: %s", enumor.Azure,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync key pair to create success, accountID: %s, count: %d, rid: %s", enumor.Azure,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateKeyPair(kt *kit.Kit, accountID, resGroupName string,
+	updateMap map[string]typekeypair.AzureKeyPair) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updateReq := make(dataproto.KeyPairExtBatchUpdateReq[corekeypair.AzureExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		updateReq = append(updateReq, &dataproto.KeyPairExtUpdateReq[corekeypair.AzureExtension]{
+			ID:        id,
+			Name:      converter.PtrToVal(one.Name),
+			PublicKey: converter.PtrToVal(one.PublicKey),
+			Extension: convAzureKeyPairExtension(resGroupName),
+		})
+	}
+
+	for _, part := range slice.Split(updateReq, constant.BatchOperationMaxLimit) {
+		req := dataproto.KeyPairExtBatchUpdateReq[corekeypair.AzureExtension](part)
+		if err := cli.dbCli.Azure.BatchUpdateKeyPair(kt, &req); err != nil {
+			logs.Errorf("[%s] request dataservice to update key pair failed, err: %v, rid: %s", enumor.Azure,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync key pair to update success, accountID: %s, count: %d, rid: %s", enumor.Azure,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func convAzureKeyPairExtension(resGroupName string) *corekeypair.AzureExtension {
+	return &corekeypair.AzureExtension{
+		ResourceGroupName: resGroupName,
+	}
+}
+
+func (cli *client) listKeyPairFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typekeypair.AzureKeyPair, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typekeypair.AzureKeyPairListOption{
+		ResourceGroupName: params.ResourceGroupName,
+		CloudIDs:          params.CloudIDs,
+	}
+	result, err := cli.cloudCli.ListKeyPair(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list key pair from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.Azure, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listKeyPairFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]*corekeypair.KeyPair[corekeypair.AzureExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", params.AccountID),
+			tools.RuleIn("cloud_id", params.CloudIDs),
+			tools.RuleJSONEqual("extension.resource_group_name", params.ResourceGroupName),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Azure.ListKeyPair(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list key pair from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.Azure, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isKeyPairChange(cloud typekeypair.AzureKeyPair, db *corekeypair.KeyPair[corekeypair.AzureExtension]) bool {
+	if converter.PtrToVal(cloud.Name) != db.Name {
+		return true
+	}
+
+	if converter.PtrToVal(cloud.PublicKey) != db.PublicKey {
+		return true
+	}
+
+	return false
+}
+
+// RemoveKeyPairDeleteFromCloud 删除db中存在但云上已删除的密钥对
+func (cli *client) RemoveKeyPairDeleteFromCloud(kt *kit.Kit, accountID string, resGroupName string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleJSONEqual("extension.resource_group_name", resGroupName),
+		),
+		Page: &core.BasePage{Start: 0, Limit: constant.BatchOperationMaxLimit},
+	}
+
+	delCloudIDs := make([]string, 0)
+	for {
+		resultFromDB, err := cli.dbCli.Global.ListKeyPair(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list key pair failed, err: %v, req: %v, rid: %s",
+				enumor.Azure, err, req, kt.Rid)
+			return err
+		}
+
+		if len(resultFromDB.Details) == 0 {
+			break
+		}
+
+		cloudIDs := make([]string, 0, len(resultFromDB.Details))
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		params := &SyncBaseParams{AccountID: accountID, ResourceGroupName: resGroupName, CloudIDs: cloudIDs}
+		resultFromCloud, err := cli.listKeyPairFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+			delCloudIDs = append(delCloudIDs, converter.MapKeyToStringSlice(cloudIDMap)...)
+		}
+
+		common.RecordScanned(kt, len(resultFromDB.Details))
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		if !common.PlanDelete(kt, part) {
+			continue
+		}
+		if err := cli.deleteKeyPair(kt, accountID, part); err != nil {
+			return err
+		}
+		common.RecordDeleted(kt, len(part))
+	}
+
+	return nil
+}
@@ -74,11 +74,13 @@ func (cli *client) NetworkInterface(kt *kit.Kit, params *SyncBaseParams, opt *Sy
 
 	addNetworkInterface, updateMap, delCloudIDs := common.Diff[typesni.AzureNI,
 		coreni.NetworkInterface[coreni.AzureNIExtension]](niFromCloud, niFromDB, isNIChange)
+	addNetworkInterface, updateMap, delCloudIDs = common.PlanDiff(kt, addNetworkInterface, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteNetworkInterface(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addNetworkInterface) > 0 {
@@ -86,12 +88,14 @@ func (cli *client) NetworkInterface(kt *kit.Kit, params *SyncBaseParams, opt *Sy
 			addNetworkInterface); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addNetworkInterface))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateNetworkInterface(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -153,11 +157,15 @@ func (cli *client) RemoveNetworkInterfaceDeleteFromCloud(kt *kit.Kit, accountID
 			}
 
 			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
-			if err = cli.deleteNetworkInterface(kt, accountID, resGroupName, delCloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, delCloudIDs) {
+				if err = cli.deleteNetworkInterface(kt, accountID, resGroupName, delCloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
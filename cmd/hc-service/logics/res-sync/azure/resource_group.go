@@ -71,23 +71,27 @@ func (cli *client) ResourceGroup(kt *kit.Kit, opt *SyncRGOption) (*SyncResult, e
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typesrg.AzureResourceGroup, corerg.AzureRG](
 		resourcegroupFromCloud, resourcegroupFromDB, isResourceGroupChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteResourceGroup(kt, opt, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createResourceGroup(kt, opt, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateResourceGroup(kt, opt, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -26,9 +26,11 @@ import (
 	"hcm/pkg/adaptor/types/cert"
 	typescvm "hcm/pkg/adaptor/types/cvm"
 	typesdisk "hcm/pkg/adaptor/types/disk"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
 	typeseip "hcm/pkg/adaptor/types/eip"
 	firewallrule "hcm/pkg/adaptor/types/firewall-rule"
 	typesimage "hcm/pkg/adaptor/types/image"
+	typeskeypair "hcm/pkg/adaptor/types/key-pair"
 	typeslb "hcm/pkg/adaptor/types/load-balancer"
 	typesni "hcm/pkg/adaptor/types/network-interface"
 	typesregion "hcm/pkg/adaptor/types/region"
@@ -43,7 +45,9 @@ import (
 	corecert "hcm/pkg/api/core/cloud/cert"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
 	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
 	coreimage "hcm/pkg/api/core/cloud/image"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
 	corelb "hcm/pkg/api/core/cloud/load-balancer"
 	corecloudni "hcm/pkg/api/core/cloud/network-interface"
 	coreregion "hcm/pkg/api/core/cloud/region"
@@ -101,6 +105,15 @@ type CloudResType interface {
 		typesdisk.AwsDisk |
 		typesdisk.GcpDisk |
 		typesdisk.AzureDisk |
+		typesnapshot.TCloudSnapshot |
+		typesnapshot.AwsSnapshot |
+		typesnapshot.HuaWeiSnapshot |
+		typesnapshot.AzureSnapshot |
+		typesnapshot.GcpSnapshot |
+		typeskeypair.TCloudKeyPair |
+		typeskeypair.AwsKeyPair |
+		typeskeypair.HuaWeiKeyPair |
+		typeskeypair.AzureKeyPair |
 
 		securitygroup.TCloudSG |
 		securitygroup.HuaWeiSG |
@@ -154,7 +167,18 @@ type CloudResType interface {
 		typeslb.TCloudClb |
 		typeslb.TCloudListener |
 		typeslb.TCloudUrlRule |
-		typeslb.Backend
+		typeslb.Backend |
+		typeslb.AwsLoadBalancer |
+		typeslb.AwsListener |
+		typeslb.AwsTargetGroup |
+		typeslb.AwsTargetHealth |
+		typeslb.AwsRule |
+		typeslb.HuaWeiLoadBalancer |
+		typeslb.HuaWeiListener |
+		typeslb.HuaWeiPool |
+		typeslb.HuaWeiMember |
+		cert.HuaWeiCert |
+		typeargstpl.HuaWeiArgsTplAddress
 }
 
 // TestCloudRes 测试云资源类型
@@ -209,6 +233,15 @@ type DBResType interface {
 		*coredisk.Disk[coredisk.AwsExtension] |
 		*coredisk.Disk[coredisk.GcpExtension] |
 		*coredisk.Disk[coredisk.AzureExtension] |
+		*coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension] |
+		*coresnapshot.DiskSnapshot[coresnapshot.AwsExtension] |
+		*coresnapshot.DiskSnapshot[coresnapshot.HuaWeiExtension] |
+		*coresnapshot.DiskSnapshot[coresnapshot.AzureExtension] |
+		*coresnapshot.DiskSnapshot[coresnapshot.GcpExtension] |
+		*corekeypair.KeyPair[corekeypair.TCloudExtension] |
+		*corekeypair.KeyPair[corekeypair.AwsExtension] |
+		*corekeypair.KeyPair[corekeypair.HuaWeiExtension] |
+		*corekeypair.KeyPair[corekeypair.AzureExtension] |
 
 		cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.HuaWeiSecurityGroupExtension] |
@@ -260,7 +293,15 @@ type DBResType interface {
 		corelb.TCloudLoadBalancer |
 		corelb.TCloudLbUrlRule |
 		corelb.TCloudListener |
-		corelb.BaseTarget
+		corelb.BaseTarget |
+		corelb.AwsLoadBalancer |
+		corelb.AwsListener |
+		corelb.AwsTargetGroup |
+		corelb.HuaWeiLoadBalancer |
+		corelb.HuaWeiListener |
+		corelb.HuaWeiTargetGroup |
+		*corecert.Cert[corecert.HuaWeiCertExtension] |
+		*coreargstpl.ArgsTpl[coreargstpl.HuaWeiArgsTplExtension]
 }
 
 // Diff 对比云和db资源，划分出新增数据，更新数据，删除数据。
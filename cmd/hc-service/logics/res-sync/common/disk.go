@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// ListDiskIDMapByCloudIDs 根据云盘云上ID查询db中的云盘ID，返回 {"云盘云上ID": "云盘ID"}，db中不存在的云盘不会返回
+func ListDiskIDMapByCloudIDs(kt *kit.Kit, dbCli *dataservice.Client, vendor enumor.Vendor, accountID string,
+	cloudIDs []string) (map[string]string, error) {
+
+	result := make(map[string]string, len(cloudIDs))
+	cloudIDs = slice.Unique(cloudIDs)
+	if len(cloudIDs) == 0 {
+		return result, nil
+	}
+
+	for _, part := range slice.Split(cloudIDs, int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("vendor", vendor),
+				tools.RuleEqual("account_id", accountID),
+				tools.RuleIn("cloud_id", part),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		disks, err := dbCli.Global.ListDisk(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list disk by cloud ids failed, err: %v, account: %s, rid: %s", vendor, err, accountID,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range disks.Details {
+			result[one.CloudID] = one.ID
+		}
+	}
+
+	return result, nil
+}
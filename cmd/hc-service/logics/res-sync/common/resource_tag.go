@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"hcm/pkg/api/core"
+	dsrestag "hcm/pkg/api/data-service/cloud/resource-tag"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// TagResource 支持标签同步的云资源
+type TagResource interface {
+	GetCloudID() string
+	GetTagMap() core.TagMap
+}
+
+// SyncResTags 将云上资源的标签同步到资源标签表，db中资源的标签会被云上标签全量替换，需要在资源本身同步完成后调用，
+// 预览模式下直接返回
+func SyncResTags[T TagResource](kt *kit.Kit, dbCli *dataservice.Client, vendor enumor.Vendor,
+	resType enumor.CloudResourceType, accountID string, fromCloud []T) error {
+
+	// 预览模式下不写入数据，标签变更不计入同步计划
+	if len(fromCloud) == 0 || IsDryRun(kt) {
+		return nil
+	}
+
+	for _, part := range slice.Split(fromCloud, constant.CloudResourceSyncMaxLimit) {
+		req := &dsrestag.SyncReq{
+			Vendor:    vendor,
+			ResType:   resType,
+			AccountID: accountID,
+			Resources: make([]dsrestag.SyncResource, 0, len(part)),
+		}
+		for _, one := range part {
+			req.Resources = append(req.Resources, dsrestag.SyncResource{
+				CloudID: one.GetCloudID(),
+				Tags:    one.GetTagMap(),
+			})
+		}
+
+		if err := dbCli.Global.ResourceTag.Sync(kt, req); err != nil {
+			logs.Errorf("[%s] sync %s tag failed, err: %v, account: %s, rid: %s", vendor, resType, err, accountID,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"hcm/pkg/kit"
+)
+
+// MaxPlanCloudIDs 同步计划中每类变更最多记录的云ID数量，超出部分只计数
+const MaxPlanCloudIDs = 1000
+
+type syncStatKey struct{}
+
+// SyncStat 一次同步过程中资源的变更数量统计，同步逻辑可能并发执行，需原子操作
+type SyncStat struct {
+	added   atomic.Uint64
+	updated atomic.Uint64
+	deleted atomic.Uint64
+	scanned atomic.Uint64
+
+	// dryRun 为true时只记录同步计划，不写入数据
+	dryRun bool
+	lock   sync.Mutex
+	plan   SyncPlan
+}
+
+// SyncPlan 同步计划中待变更资源的云ID，每类最多记录 MaxPlanCloudIDs 个
+type SyncPlan struct {
+	AddCloudIDs    []string
+	UpdateCloudIDs []string
+	DeleteCloudIDs []string
+	Truncated      bool
+}
+
+// Added 新增资源数量
+func (s *SyncStat) Added() uint {
+	return uint(s.added.Load())
+}
+
+// Updated 更新资源数量
+func (s *SyncStat) Updated() uint {
+	return uint(s.updated.Load())
+}
+
+// Deleted 删除资源数量
+func (s *SyncStat) Deleted() uint {
+	return uint(s.deleted.Load())
+}
+
+// Scanned 删除对比时扫描的db资源数量
+func (s *SyncStat) Scanned() uint {
+	return uint(s.scanned.Load())
+}
+
+// DryRun 是否只预览同步计划
+func (s *SyncStat) DryRun() bool {
+	return s.dryRun
+}
+
+// Plan 返回同步计划
+func (s *SyncStat) Plan() SyncPlan {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return SyncPlan{
+		AddCloudIDs:    append([]string(nil), s.plan.AddCloudIDs...),
+		UpdateCloudIDs: append([]string(nil), s.plan.UpdateCloudIDs...),
+		DeleteCloudIDs: append([]string(nil), s.plan.DeleteCloudIDs...),
+		Truncated:      s.plan.Truncated,
+	}
+}
+
+func (s *SyncStat) appendPlan(add, update, del []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.plan.AddCloudIDs = s.appendLimited(s.plan.AddCloudIDs, add)
+	s.plan.UpdateCloudIDs = s.appendLimited(s.plan.UpdateCloudIDs, update)
+	s.plan.DeleteCloudIDs = s.appendLimited(s.plan.DeleteCloudIDs, del)
+}
+
+func (s *SyncStat) appendLimited(dst, src []string) []string {
+	left := MaxPlanCloudIDs - len(dst)
+	if len(src) > left {
+		s.plan.Truncated = true
+		src = src[:left]
+	}
+
+	return append(dst, src...)
+}
+
+// WithSyncStat 在kit上挂载变更统计，后续基于该kit的同步操作都会累计到返回的统计中
+func WithSyncStat(kt *kit.Kit) *SyncStat {
+	stat := new(SyncStat)
+	kt.Ctx = context.WithValue(kt.Ctx, syncStatKey{}, stat)
+	return stat
+}
+
+// WithDryRunSyncStat 在kit上挂载预览模式的变更统计，基于该kit的同步操作只记录同步计划，不写入数据
+func WithDryRunSyncStat(kt *kit.Kit) *SyncStat {
+	stat := &SyncStat{dryRun: true}
+	kt.Ctx = context.WithValue(kt.Ctx, syncStatKey{}, stat)
+	return stat
+}
+
+func getSyncStat(kt *kit.Kit) *SyncStat {
+	if kt == nil || kt.Ctx == nil {
+		return nil
+	}
+
+	stat, _ := kt.Ctx.Value(syncStatKey{}).(*SyncStat)
+	return stat
+}
+
+// IsDryRun 当前同步是否为预览模式
+func IsDryRun(kt *kit.Kit) bool {
+	stat := getSyncStat(kt)
+	return stat != nil && stat.dryRun
+}
+
+// RecordAdded 累计写入成功的新增资源数量，kit未挂载统计时忽略
+func RecordAdded(kt *kit.Kit, count int) {
+	if stat := getSyncStat(kt); stat != nil {
+		stat.added.Add(uint64(count))
+	}
+}
+
+// RecordUpdated 累计写入成功的更新资源数量，kit未挂载统计时忽略
+func RecordUpdated(kt *kit.Kit, count int) {
+	if stat := getSyncStat(kt); stat != nil {
+		stat.updated.Add(uint64(count))
+	}
+}
+
+// RecordDeleted 累计写入成功的删除资源数量，kit未挂载统计时忽略
+func RecordDeleted(kt *kit.Kit, count int) {
+	if stat := getSyncStat(kt); stat != nil {
+		stat.deleted.Add(uint64(count))
+	}
+}
+
+// RecordScanned 累计删除对比时扫描的db资源数量，用于计算删除比例
+func RecordScanned(kt *kit.Kit, count int) {
+	stat := getSyncStat(kt)
+	if stat == nil {
+		return
+	}
+
+	stat.scanned.Add(uint64(count))
+}
+
+// PlanDiff 预览模式下记录资源对比结果，并返回空的变更集合，使后续写入逻辑不执行；
+// 非预览模式下原样返回，变更数量由调用方在写入成功后通过 RecordAdded 等累计
+func PlanDiff[T CloudResType](kt *kit.Kit, add []T, updateMap map[string]T, delCloudIDs []string) (
+	[]T, map[string]T, []string) {
+
+	stat := getSyncStat(kt)
+	if stat == nil || !stat.dryRun {
+		return add, updateMap, delCloudIDs
+	}
+	stat.added.Add(uint64(len(add)))
+	stat.updated.Add(uint64(len(updateMap)))
+	stat.deleted.Add(uint64(len(delCloudIDs)))
+
+	addCloudIDs := make([]string, 0, len(add))
+	for _, one := range add {
+		addCloudIDs = append(addCloudIDs, one.GetCloudID())
+	}
+	updateCloudIDs := make([]string, 0, len(updateMap))
+	for _, one := range updateMap {
+		updateCloudIDs = append(updateCloudIDs, one.GetCloudID())
+	}
+	stat.appendPlan(addCloudIDs, updateCloudIDs, delCloudIDs)
+
+	return nil, nil, nil
+}
+
+// PlanDelete 返回是否需要执行删除，预览模式下只记录待删除的资源，不执行删除；
+// 非预览模式下删除数量由调用方在删除成功后通过 RecordDeleted 累计
+func PlanDelete(kt *kit.Kit, delCloudIDs []string) bool {
+	stat := getSyncStat(kt)
+	if stat == nil || !stat.dryRun {
+		return true
+	}
+
+	stat.deleted.Add(uint64(len(delCloudIDs)))
+	stat.appendPlan(nil, nil, delCloudIDs)
+	return false
+}
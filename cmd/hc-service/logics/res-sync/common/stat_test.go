@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"testing"
+
+	"hcm/pkg/kit"
+)
+
+func TestPlanDiffCountAfterWrite(t *testing.T) {
+	kt := kit.New()
+	stat := WithSyncStat(kt)
+
+	add := []TestCloudRes{{CloudID: "a"}}
+	updateMap := map[string]TestCloudRes{"b": {CloudID: "b"}}
+	add, updateMap, delCloudIDs := PlanDiff(kt, add, updateMap, []string{"c", "d"})
+	if len(add) != 1 || len(updateMap) != 1 || len(delCloudIDs) != 2 {
+		t.Fatalf("diff should be returned as is, add: %v, update: %v, delete: %v", add, updateMap, delCloudIDs)
+	}
+	// 对比结果在写入成功前不计数
+	if stat.Added() != 0 || stat.Updated() != 0 || stat.Deleted() != 0 {
+		t.Errorf("diff should not be counted before write, added: %d, updated: %d, deleted: %d", stat.Added(),
+			stat.Updated(), stat.Deleted())
+	}
+	if !PlanDelete(kt, delCloudIDs) || stat.Deleted() != 0 {
+		t.Errorf("delete should be executed and not counted before write, deleted: %d", stat.Deleted())
+	}
+
+	RecordAdded(kt, len(add))
+	RecordDeleted(kt, len(delCloudIDs))
+	if stat.Added() != 1 || stat.Updated() != 0 || stat.Deleted() != 2 {
+		t.Errorf("sync stat not match, added: %d, updated: %d, deleted: %d", stat.Added(), stat.Updated(),
+			stat.Deleted())
+	}
+}
+
+func TestPlanDiffDryRun(t *testing.T) {
+	kt := kit.New()
+	stat := WithDryRunSyncStat(kt)
+
+	add := []TestCloudRes{{CloudID: "a"}}
+	updateMap := map[string]TestCloudRes{"b": {CloudID: "b"}}
+	add, updateMap, delCloudIDs := PlanDiff(kt, add, updateMap, []string{"c"})
+	if len(add) != 0 || len(updateMap) != 0 || len(delCloudIDs) != 0 {
+		t.Errorf("dry run should not return diff, add: %v, update: %v, delete: %v", add, updateMap, delCloudIDs)
+	}
+	if PlanDelete(kt, []string{"d"}) {
+		t.Errorf("dry run should not execute delete")
+	}
+
+	// 预览模式下没有写入，按对比结果计数
+	if stat.Added() != 1 || stat.Updated() != 1 || stat.Deleted() != 2 {
+		t.Errorf("sync stat not match, added: %d, updated: %d, deleted: %d", stat.Added(), stat.Updated(),
+			stat.Deleted())
+	}
+	plan := stat.Plan()
+	if len(plan.AddCloudIDs) != 1 || len(plan.UpdateCloudIDs) != 1 || len(plan.DeleteCloudIDs) != 2 {
+		t.Errorf("sync plan not match, got: %+v", plan)
+	}
+}
@@ -73,6 +73,11 @@ func (mgr *CvmRelManger) SyncRel(kt *kit.Kit, opt *SyncRelOption) error {
 		return err
 	}
 
+	// 预览模式下不同步关联关系
+	if common.IsDryRun(kt) {
+		return nil
+	}
+
 	cvmMap, err := mgr.getCvmMap(kt)
 	if err != nil {
 		logs.Errorf("get cvm map failed, err: %v, rid: %s", err, kt.Rid)
@@ -71,23 +71,27 @@ func (cli *client) Route(kt *kit.Kit, params *SyncBaseParams, opt *SyncRouteOpti
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typesroutetable.GcpRoute, cloudcoreroutetable.GcpRoute](
 		routeFromCloud, routeFromDB, isRouteChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteRoute(kt, params.AccountID, delCloudIDs, routeFromDB); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createRoute(kt, params.AccountID, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateRoute(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -302,11 +306,15 @@ func (cli *client) RemoveRouteDeleteFromCloud(kt *kit.Kit, accountID string) err
 			}
 
 			delIDs := converter.MapKeyToStringSlice(cloudIDMap)
-			if err := cli.deleteRoute(kt, accountID, delIDs, resultFromDB.Details); err != nil {
-				return err
+			if common.PlanDelete(kt, delIDs) {
+				if err := cli.deleteRoute(kt, accountID, delIDs, resultFromDB.Details); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
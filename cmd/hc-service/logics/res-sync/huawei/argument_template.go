@@ -0,0 +1,351 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeargstpl "hcm/pkg/adaptor/types/argument-template"
+	"hcm/pkg/api/core"
+	coreargstpl "hcm/pkg/api/core/cloud/argument-template"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcargstpl "hcm/pkg/api/hc-service/argument-template"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncArgsTplOption ...
+type SyncArgsTplOption struct {
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncArgsTplOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ArgsTplAddress 同步IP地址组，IP地址组为地域级资源，对应参数模版中的IP地址模版
+func (cli *client) ArgsTplAddress(kt *kit.Kit, params *SyncBaseParams, opt *SyncArgsTplOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	fromCloud, err := cli.listArgsTplAddressFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDB, err := cli.listArgsTplAddressFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fromCloud) == 0 && len(fromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeargstpl.HuaWeiArgsTplAddress,
+		*coreargstpl.ArgsTpl[coreargstpl.HuaWeiArgsTplExtension]](fromCloud, fromDB, isArgsTplAddressChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteArgsTplAddress(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createArgsTplAddress(kt, params.AccountID, params.Region, opt, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateArgsTplAddress(kt, params.AccountID, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return new(SyncResult), nil
+}
+
+// RemoveArgsTplAddressDeleteFromCloud 删除存在本地但是在云上被删除的IP地址组
+func (cli *client) RemoveArgsTplAddressDeleteFromCloud(kt *kit.Kit, accountID, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("vendor", enumor.HuaWei),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("type", enumor.AddressType),
+		),
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+
+	for {
+		resultFromDB, err := cli.dbCli.Global.ArgsTpl.ListArgsTpl(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list address group failed, req: %v, err: %v, rid: %s",
+				enumor.HuaWei, req, err, kt.Rid)
+			return err
+		}
+
+		cloudIDs := slice.Map(resultFromDB.Details, func(one coreargstpl.BaseArgsTpl) string { return one.CloudID })
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		for _, batch := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+			params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: batch}
+			resultFromCloud, err := cli.listArgsTplAddressFromCloud(kt, params)
+			if err != nil {
+				return err
+			}
+
+			// 如果有资源没有查询出来，说明数据被从云上删除
+			if len(resultFromCloud) == len(batch) {
+				continue
+			}
+
+			cloudIDMap := cvt.StringSliceToMap(batch)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+
+			delCloudIDs := cvt.MapKeyToStringSlice(cloudIDMap)
+			if common.PlanDelete(kt, delCloudIDs) {
+				if err = cli.deleteArgsTplAddress(kt, accountID, region, delCloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
+			}
+		}
+
+		common.RecordScanned(kt, len(resultFromDB.Details))
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteArgsTplAddress(kt *kit.Kit, accountID, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listArgsTplAddressFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate address group not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.HuaWei, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate address group not exist failed, before delete")
+	}
+
+	deleteReq := &protocloud.ArgsTplBatchDeleteReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleIn("cloud_id", delCloudIDs),
+		),
+	}
+	if err = cli.dbCli.Global.ArgsTpl.BatchDeleteArgsTpl(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete address group failed, err: %v, rid: %s",
+			enumor.HuaWei, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync argument template to delete address group success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateArgsTplAddress(kt *kit.Kit, accountID string,
+	updateMap map[string]typeargstpl.HuaWeiArgsTplAddress) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	for id, one := range updateMap {
+		templateJson, err := types.NewJsonField(convHuaWeiAddressTemplates(one))
+		if err != nil {
+			return fmt.Errorf("json marshal template failed, err: %w", err)
+		}
+
+		updateReq := &protocloud.ArgsTplBatchUpdateExprReq{
+			IDs:       []string{id},
+			Name:      one.Name,
+			Templates: templateJson,
+		}
+		if _, err = cli.dbCli.Global.ArgsTpl.BatchUpdateArgsTpl(kt, updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice BatchUpdateArgsTpl address group failed, err: %v, rid: %s",
+				enumor.HuaWei, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync argument template to update address group success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createArgsTplAddress(kt *kit.Kit, accountID, region string, opt *SyncArgsTplOption,
+	addSlice []typeargstpl.HuaWeiArgsTplAddress) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	createReq := &protocloud.ArgsTplBatchCreateReq[coreargstpl.HuaWeiArgsTplExtension]{
+		ArgumentTemplates: make([]protocloud.ArgsTplBatchCreate[coreargstpl.HuaWeiArgsTplExtension], 0,
+			len(addSlice)),
+	}
+	for _, one := range addSlice {
+		templateJson, err := types.NewJsonField(convHuaWeiAddressTemplates(one))
+		if err != nil {
+			return fmt.Errorf("json marshal template failed, err: %w", err)
+		}
+
+		createReq.ArgumentTemplates = append(createReq.ArgumentTemplates,
+			protocloud.ArgsTplBatchCreate[coreargstpl.HuaWeiArgsTplExtension]{
+				CloudID:   one.GetCloudID(),
+				Name:      one.Name,
+				Vendor:    string(enumor.HuaWei),
+				AccountID: accountID,
+				Region:    region,
+				BkBizID:   opt.BkBizID,
+				Type:      enumor.AddressType,
+				Templates: templateJson,
+				Memo:      cvt.ValToPtr(one.Description),
+			})
+	}
+
+	if _, err := cli.dbCli.HuaWei.BatchCreateArgsTpl(kt, createReq); err != nil {
+		logs.Errorf("[%s] request dataservice to create huawei address group failed, err: %v, rid: %s",
+			enumor.HuaWei, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync argument template to create address group success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listArgsTplAddressFromCloud(kt *kit.Kit, params *SyncBaseParams) (
+	[]typeargstpl.HuaWeiArgsTplAddress, error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typeargstpl.HuaWeiListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, err := cli.cloudCli.ListArgsTplAddress(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list address group from cloud failed, account: %s, opt: %v, err: %v, rid: %s",
+			enumor.HuaWei, params.AccountID, opt, err, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listArgsTplAddressFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]*coreargstpl.ArgsTpl[coreargstpl.HuaWeiArgsTplExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", params.AccountID),
+			tools.RuleEqual("region", params.Region),
+			tools.RuleIn("cloud_id", params.CloudIDs),
+			tools.RuleEqual("type", enumor.AddressType),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.HuaWei.ListArgsTplExt(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list address group from db failed, account: %s, req: %v, err: %v, rid: %s",
+			enumor.HuaWei, params.AccountID, req, err, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func convHuaWeiAddressTemplates(cloud typeargstpl.HuaWeiArgsTplAddress) []hcargstpl.TemplateInfo {
+	templates := make([]hcargstpl.TemplateInfo, 0, len(cloud.IpSet))
+	for _, ip := range cloud.IpSet {
+		templates = append(templates, hcargstpl.TemplateInfo{Address: cvt.ValToPtr(ip)})
+	}
+	return templates
+}
+
+func isArgsTplAddressChange(cloud typeargstpl.HuaWeiArgsTplAddress,
+	db *coreargstpl.ArgsTpl[coreargstpl.HuaWeiArgsTplExtension]) bool {
+
+	if cloud.Name != db.Name {
+		return true
+	}
+
+	dbTemplates := cvt.PtrToVal(db.Templates)
+	if len(cloud.IpSet) != len(dbTemplates) {
+		return true
+	}
+
+	for idx, ip := range cloud.IpSet {
+		if ip != cvt.PtrToVal(dbTemplates[idx].Address) {
+			return true
+		}
+	}
+
+	return false
+}
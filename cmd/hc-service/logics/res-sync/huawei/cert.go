@@ -0,0 +1,366 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typecert "hcm/pkg/adaptor/types/cert"
+	"hcm/pkg/api/core"
+	corecert "hcm/pkg/api/core/cloud/cert"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+)
+
+// SyncCertOption ...
+type SyncCertOption struct {
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+	// should match params' cloud id
+	PreCachedCertList []typecert.HuaWeiCert
+}
+
+// Validate ...
+func (opt SyncCertOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Cert 同步云证书管理服务中的证书，证书为全局资源，不区分地域
+func (cli *client) Cert(kt *kit.Kit, params *SyncBaseParams, opt *SyncCertOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	certFromCloud := opt.PreCachedCertList
+	if certFromCloud == nil {
+		var err error
+		certFromCloud, err = cli.listCertFromCloud(kt, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	certFromDB, err := cli.listCertFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certFromCloud) == 0 && len(certFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typecert.HuaWeiCert, *corecert.Cert[corecert.HuaWeiCertExtension]](
+		certFromCloud, certFromDB, isCertChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteCert(kt, params.AccountID, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createCert(kt, params.AccountID, opt, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateCert(kt, params.AccountID, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) deleteCert(kt *kit.Kit, accountID string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return nil
+	}
+
+	deleteReq := &protocloud.CertBatchDeleteReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleIn("cloud_id", delCloudIDs),
+		),
+	}
+	if err := cli.dbCli.Global.BatchDeleteCert(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete cert failed, err: %v, rid: %s",
+			enumor.HuaWei, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cert to delete cert success, accountID: %s, count: %d, rid: %s", enumor.HuaWei,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateCert(kt *kit.Kit, accountID string, updateMap map[string]typecert.HuaWeiCert) error {
+	if len(updateMap) <= 0 {
+		return nil
+	}
+
+	updateReq := make(protocloud.CertExtBatchUpdateReq[corecert.HuaWeiCertExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		domainJson, err := types.NewJsonField(one.GetDomains())
+		if err != nil {
+			return fmt.Errorf("json marshal domain failed, err: %w", err)
+		}
+
+		updateReq = append(updateReq, &protocloud.CertExtUpdateReq[corecert.HuaWeiCertExtension]{
+			ID:               id,
+			Name:             one.Name,
+			Vendor:           string(enumor.HuaWei),
+			AccountID:        accountID,
+			Domain:           domainJson,
+			CertType:         enumor.SVRServiceCertType,
+			EncryptAlgorithm: one.SignatureAlgorithm,
+			CertStatus:       one.Status,
+			CloudExpiredTime: convHuaWeiCertTime(one.ExpireTime),
+		})
+	}
+
+	if err := cli.dbCli.HuaWei.BatchUpdateCert(kt, &updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice BatchUpdateCert failed, err: %v, rid: %s", enumor.HuaWei, err,
+			kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cert to update cert success, accountID: %s, count: %d, rid: %s", enumor.HuaWei,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createCert(kt *kit.Kit, accountID string, opt *SyncCertOption,
+	addSlice []typecert.HuaWeiCert) error {
+
+	if len(addSlice) <= 0 {
+		return nil
+	}
+
+	createReq := &protocloud.CertBatchCreateReq[corecert.HuaWeiCertExtension]{
+		Certs: make([]protocloud.CertBatchCreate[corecert.HuaWeiCertExtension], 0, len(addSlice)),
+	}
+	for _, one := range addSlice {
+		domainJson, err := types.NewJsonField(one.GetDomains())
+		if err != nil {
+			return fmt.Errorf("json marshal domain failed, err: %w", err)
+		}
+
+		createReq.Certs = append(createReq.Certs, protocloud.CertBatchCreate[corecert.HuaWeiCertExtension]{
+			CloudID:          one.GetCloudID(),
+			Name:             one.Name,
+			Vendor:           string(enumor.HuaWei),
+			AccountID:        accountID,
+			BkBizID:          opt.BkBizID,
+			Domain:           domainJson,
+			CertType:         enumor.SVRServiceCertType,
+			EncryptAlgorithm: one.SignatureAlgorithm,
+			CertStatus:       one.Status,
+			CloudExpiredTime: convHuaWeiCertTime(one.ExpireTime),
+		})
+	}
+
+	if _, err := cli.dbCli.HuaWei.BatchCreateCert(kt, createReq); err != nil {
+		logs.Errorf("[%s] request dataservice to create huawei cert failed, err: %v, rid: %s", enumor.HuaWei,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cert to create cert success, accountID: %s, count: %d, rid: %s", enumor.HuaWei,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+// listAllCertFromCloud 证书接口不支持按id查询，分页获取全部证书
+func (cli *client) listAllCertFromCloud(kt *kit.Kit) ([]typecert.HuaWeiCert, error) {
+	list := make([]typecert.HuaWeiCert, 0)
+	opt := &typecert.HuaWeiListOption{Offset: 0, Limit: typecert.HuaWeiCertQueryLimit}
+	for {
+		result, err := cli.cloudCli.ListCert(kt, opt)
+		if err != nil {
+			logs.Errorf("[%s] list all cert from cloud failed, account: %s, opt: %v, err: %v, rid: %s",
+				enumor.HuaWei, cli.accountID, opt, err, kt.Rid)
+			return nil, err
+		}
+
+		list = append(list, result.Details...)
+
+		if len(result.Details) < int(opt.Limit) {
+			break
+		}
+		opt.Offset += opt.Limit
+	}
+
+	return list, nil
+}
+
+func (cli *client) listCertFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typecert.HuaWeiCert, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	all, err := cli.listAllCertFromCloud(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudIDMap := make(map[string]struct{}, len(params.CloudIDs))
+	for _, id := range params.CloudIDs {
+		cloudIDMap[id] = struct{}{}
+	}
+
+	list := make([]typecert.HuaWeiCert, 0, len(params.CloudIDs))
+	for _, one := range all {
+		if _, ok := cloudIDMap[one.GetCloudID()]; ok {
+			list = append(list, one)
+		}
+	}
+
+	return list, nil
+}
+
+func (cli *client) listCertFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]*corecert.Cert[corecert.HuaWeiCertExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", params.AccountID),
+			tools.RuleIn("cloud_id", params.CloudIDs),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.HuaWei.ListCert(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list cert from db failed, account: %s, req: %v, err: %v, rid: %s",
+			enumor.HuaWei, params.AccountID, req, err, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isCertChange(cloud typecert.HuaWeiCert, db *corecert.Cert[corecert.HuaWeiCertExtension]) bool {
+	if cloud.Name != db.Name {
+		return true
+	}
+
+	if !assert.IsPtrStringSliceEqual(cloud.GetDomains(), db.Domain) {
+		return true
+	}
+
+	if cloud.Status != db.CertStatus {
+		return true
+	}
+
+	if cloud.SignatureAlgorithm != db.EncryptAlgorithm {
+		return true
+	}
+
+	return db.CloudExpiredTime != convHuaWeiCertTime(cloud.ExpireTime)
+}
+
+// RemoveCertDeleteFromCloud 全量获取一次云上证书，删除本地存在但云上已删除的证书
+func (cli *client) RemoveCertDeleteFromCloud(kt *kit.Kit, accountID string) error {
+	allFromCloud, err := cli.listAllCertFromCloud(kt)
+	if err != nil {
+		return err
+	}
+
+	cloudIDMap := make(map[string]struct{}, len(allFromCloud))
+	for _, one := range allFromCloud {
+		cloudIDMap[one.GetCloudID()] = struct{}{}
+	}
+
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("vendor", enumor.HuaWei),
+		),
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	delCloudIDs := make([]string, 0)
+	for {
+		resultFromDB, err := cli.dbCli.Global.ListCert(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list cert failed, req: %v, err: %v, rid: %s",
+				enumor.HuaWei, req, err, kt.Rid)
+			return err
+		}
+
+		for _, one := range resultFromDB.Details {
+			if _, ok := cloudIDMap[one.CloudID]; !ok {
+				delCloudIDs = append(delCloudIDs, one.CloudID)
+			}
+		}
+
+		common.RecordScanned(kt, len(resultFromDB.Details))
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	for _, batch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		if !common.PlanDelete(kt, batch) {
+			continue
+		}
+		if err = cli.deleteCert(kt, accountID, batch); err != nil {
+			return err
+		}
+		common.RecordDeleted(kt, len(batch))
+	}
+
+	return nil
+}
+
+// convHuaWeiCertTime 证书过期时间格式为 yyyy-MM-dd HH:mm:ss.S，解析失败时返回空
+func convHuaWeiCertTime(t string) string {
+	if len(t) == 0 {
+		return ""
+	}
+
+	std, err := times.ParseToStdTime(constant.DateTimeLayout, t)
+	if err != nil {
+		logs.Errorf("[%s] parse cert time failed, time: %s, err: %v", enumor.HuaWei, t, err)
+		return ""
+	}
+	return std
+}
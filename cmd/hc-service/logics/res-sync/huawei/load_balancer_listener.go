@@ -0,0 +1,254 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// Listener 同步指定负载均衡下的所有监听器
+func (cli *client) Listener(kt *kit.Kit, accountID, region string, lb corelb.HuaWeiLoadBalancer) (*SyncResult,
+	error) {
+
+	listOpt := &typeslb.HuaWeiListListenersOption{Region: region, LoadBalancerID: lb.CloudID}
+	lblFromCloud, err := cli.cloudCli.ListListener(kt, listOpt)
+	if err != nil {
+		logs.Errorf("[%s] list listener from cloud failed, err: %v, lb: %s, rid: %s", enumor.HuaWei, err,
+			lb.CloudID, kt.Rid)
+		return nil, err
+	}
+
+	lblFromDB, err := cli.listListenerFromDB(kt, lb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lblFromCloud) == 0 && len(lblFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.HuaWeiListener, corelb.HuaWeiListener](
+		lblFromCloud, lblFromDB, isListenerChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteListener(kt, region, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createListener(kt, accountID, lb, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateListener(kt, lb.BkBizID, region, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) listListenerFromDB(kt *kit.Kit, lbID string) ([]corelb.HuaWeiListener, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("lb_id", lbID),
+			tools.RuleEqual("vendor", enumor.HuaWei),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+
+	result := make([]corelb.HuaWeiListener, 0)
+	for {
+		resp, err := cli.dbCli.HuaWei.LoadBalancer.ListListener(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list listener from db failed, err: %v, lbID: %s, rid: %s", enumor.HuaWei, err, lbID,
+				kt.Rid)
+			return nil, err
+		}
+		result = append(result, resp.Details...)
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func (cli *client) createListener(kt *kit.Kit, accountID string, lb corelb.HuaWeiLoadBalancer,
+	addSlice []typeslb.HuaWeiListener) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	for _, batch := range slice.Split(addSlice, constant.BatchOperationMaxLimit) {
+		createReq := &protocloud.HuaWeiListenerBatchCreateReq{
+			Listeners: make([]protocloud.ListenersCreateReq[corelb.HuaWeiListenerExtension], 0, len(batch)),
+		}
+		for _, one := range batch {
+			createReq.Listeners = append(createReq.Listeners,
+				protocloud.ListenersCreateReq[corelb.HuaWeiListenerExtension]{
+					CloudID:   one.GetCloudID(),
+					Name:      one.GetName(),
+					Vendor:    enumor.HuaWei,
+					AccountID: accountID,
+					BkBizID:   lb.BkBizID,
+					LbID:      lb.ID,
+					CloudLbID: lb.CloudID,
+					Protocol:  one.GetProtocol(),
+					Port:      int64(one.ProtocolPort),
+					Region:    lb.Region,
+					Extension: convHuaWeiListenerExtension(one),
+				})
+		}
+
+		if _, err := cli.dbCli.HuaWei.LoadBalancer.BatchCreateHuaWeiListener(kt, createReq); err != nil {
+			logs.Errorf("[%s] call data service to create listener failed, err: %v, rid: %s", enumor.HuaWei, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync listener to create listener success, lb: %s, count: %d, rid: %s", enumor.HuaWei,
+		lb.CloudID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateListener(kt *kit.Kit, bizID int64, region string,
+	updateMap map[string]typeslb.HuaWeiListener) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updates := make([]*protocloud.HuaWeiListenerUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		updates = append(updates, &protocloud.HuaWeiListenerUpdate{
+			ID:        id,
+			Name:      one.GetName(),
+			BkBizID:   bizID,
+			Region:    region,
+			Extension: convHuaWeiListenerExtension(one),
+		})
+	}
+
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		err := cli.dbCli.HuaWei.LoadBalancer.BatchUpdateHuaWeiListener(kt,
+			&protocloud.HuaWeiListenerUpdateReq{Listeners: batch})
+		if err != nil {
+			logs.Errorf("[%s] call data service to update listener failed, err: %v, rid: %s", enumor.HuaWei, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) deleteListener(kt *kit.Kit, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	for _, batch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		delReq := &protocloud.LoadBalancerBatchDeleteReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("cloud_id", batch),
+				tools.RuleEqual("vendor", enumor.HuaWei),
+				tools.RuleEqual("region", region),
+			),
+		}
+		if err := cli.dbCli.Global.LoadBalancer.DeleteListener(kt, delReq); err != nil {
+			logs.Errorf("[%s] call data service to delete listener failed, err: %v, ids: %v, rid: %s",
+				enumor.HuaWei, err, batch, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convHuaWeiListenerExtension(cloud typeslb.HuaWeiListener) *corelb.HuaWeiListenerExtension {
+	ext := &corelb.HuaWeiListenerExtension{
+		CertificateIDs: cloud.GetCertificateIDs(),
+	}
+	if len(cloud.DefaultPoolId) != 0 {
+		ext.DefaultCloudPoolID = cvt.ValToPtr(cloud.DefaultPoolId)
+	}
+	if len(cloud.ClientCaTlsContainerRef) != 0 {
+		ext.ClientCaTlsContainerRef = cvt.ValToPtr(cloud.ClientCaTlsContainerRef)
+	}
+	if len(cloud.TlsCiphersPolicy) != 0 {
+		ext.TlsCiphersPolicy = cvt.ValToPtr(cloud.TlsCiphersPolicy)
+	}
+	if len(cloud.SecurityPolicyId) != 0 {
+		ext.SecurityPolicyID = cvt.ValToPtr(cloud.SecurityPolicyId)
+	}
+	return ext
+}
+
+func isListenerChange(cloud typeslb.HuaWeiListener, db corelb.HuaWeiListener) bool {
+	if db.Name != cloud.GetName() {
+		return true
+	}
+
+	if db.Protocol != cloud.GetProtocol() || db.Port != int64(cloud.ProtocolPort) {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	cloudExt := convHuaWeiListenerExtension(cloud)
+	if !assert.IsPtrStringEqual(db.Extension.DefaultCloudPoolID, cloudExt.DefaultCloudPoolID) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.Extension.CertificateIDs, cloudExt.CertificateIDs) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(db.Extension.ClientCaTlsContainerRef, cloudExt.ClientCaTlsContainerRef) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(db.Extension.TlsCiphersPolicy, cloudExt.TlsCiphersPolicy) {
+		return true
+	}
+
+	return !assert.IsPtrStringEqual(db.Extension.SecurityPolicyID, cloudExt.SecurityPolicyID)
+}
@@ -0,0 +1,243 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// ListenerDefaultRule 同步监听器与默认后端服务器组的关联关系，需要在监听器和目标组同步之后执行。
+// 华为云监听器的默认后端服务器组没有独立的规则，参考腾讯云四层监听器，以监听器ID作为云上规则ID记录一条四层规则，
+// 通过该规则建立监听器与目标组的关联关系。默认后端服务器组变化时，删除原规则后重新创建
+func (cli *client) ListenerDefaultRule(kt *kit.Kit, region string, lb corelb.HuaWeiLoadBalancer) error {
+	listOpt := &typeslb.HuaWeiListListenersOption{Region: region, LoadBalancerID: lb.CloudID}
+	listeners, err := cli.cloudCli.ListListener(kt, listOpt)
+	if err != nil {
+		logs.Errorf("[%s] list listener from cloud failed, err: %v, lb: %s, rid: %s", enumor.HuaWei, err,
+			lb.CloudID, kt.Rid)
+		return err
+	}
+	ruleFromCloud := slice.Filter(listeners, func(one typeslb.HuaWeiListener) bool {
+		return len(one.DefaultPoolId) != 0
+	})
+
+	ruleFromDB, err := cli.listDefaultRuleFromDB(kt, lb.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(ruleFromCloud) == 0 && len(ruleFromDB) == 0 {
+		return nil
+	}
+
+	tgIDMap, err := cli.getTargetGroupIDMap(kt, lb.AccountID, region, ruleFromCloud)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.HuaWeiListener, corelb.TCloudLbUrlRule](
+		ruleFromCloud, ruleFromDB, func(cloud typeslb.HuaWeiListener, db corelb.TCloudLbUrlRule) bool {
+			return isDefaultRuleChange(cloud, db, tgIDMap)
+		})
+	// 规则本身没有可更新的属性，默认后端服务器组变化时按删除后新建处理
+	for id, one := range updateMap {
+		delCloudIDs = append(delCloudIDs, one.GetCloudID())
+		addSlice = append(addSlice, one)
+		delete(updateMap, id)
+	}
+	addSlice, _, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteDefaultRule(kt, region, delCloudIDs); err != nil {
+		return err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createDefaultRule(kt, region, lb, tgIDMap, addSlice); err != nil {
+		return err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	return nil
+}
+
+func (cli *client) listDefaultRuleFromDB(kt *kit.Kit, lbID string) ([]corelb.TCloudLbUrlRule, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("lb_id", lbID),
+			tools.RuleEqual("rule_type", enumor.Layer4RuleType),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+
+	result := make([]corelb.TCloudLbUrlRule, 0)
+	for {
+		resp, err := cli.dbCli.HuaWei.LoadBalancer.ListUrlRule(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list default rule from db failed, err: %v, lbID: %s, rid: %s", enumor.HuaWei, err,
+				lbID, kt.Rid)
+			return nil, err
+		}
+		result = append(result, resp.Details...)
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// getTargetGroupIDMap 返回监听器默认后端服务器组的 云上ID -> 本地ID 映射，未同步的目标组不在结果中
+func (cli *client) getTargetGroupIDMap(kt *kit.Kit, accountID, region string,
+	listeners []typeslb.HuaWeiListener) (map[string]string, error) {
+
+	cloudTGIDs := slice.Unique(slice.Map(listeners, func(one typeslb.HuaWeiListener) string {
+		return one.DefaultPoolId
+	}))
+
+	tgIDMap := make(map[string]string, len(cloudTGIDs))
+	for _, batch := range slice.Split(cloudTGIDs, constant.CloudResourceSyncMaxLimit) {
+		params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: batch}
+		tgList, err := cli.listTargetGroupFromDB(kt, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range tgList {
+			tgIDMap[one.CloudID] = one.ID
+		}
+	}
+
+	return tgIDMap, nil
+}
+
+func (cli *client) createDefaultRule(kt *kit.Kit, region string, lb corelb.HuaWeiLoadBalancer,
+	tgIDMap map[string]string, addSlice []typeslb.HuaWeiListener) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	lblFromDB, err := cli.listListenerFromDB(kt, lb.ID)
+	if err != nil {
+		return err
+	}
+	lblIDMap := make(map[string]string, len(lblFromDB))
+	for _, one := range lblFromDB {
+		lblIDMap[one.CloudID] = one.ID
+	}
+
+	rules := make([]protocloud.TCloudUrlRuleCreate, 0, len(addSlice))
+	tgIDs := make([]string, 0, len(addSlice))
+	for _, one := range addSlice {
+		lblID, lblExists := lblIDMap[one.GetCloudID()]
+		tgID, tgExists := tgIDMap[one.DefaultPoolId]
+		if !lblExists || !tgExists {
+			// 监听器或目标组未同步时跳过，等待下次同步
+			logs.Errorf("[%s] listener or default pool not found, skip, listener: %s, pool: %s, rid: %s",
+				enumor.HuaWei, one.GetCloudID(), one.DefaultPoolId, kt.Rid)
+			continue
+		}
+
+		rules = append(rules, protocloud.TCloudUrlRuleCreate{
+			Vendor:             enumor.HuaWei,
+			LbID:               lb.ID,
+			CloudLbID:          lb.CloudID,
+			LblID:              lblID,
+			CloudLBLID:         one.GetCloudID(),
+			CloudID:            one.GetCloudID(),
+			Name:               one.GetName(),
+			RuleType:           enumor.Layer4RuleType,
+			CloudTargetGroupID: one.DefaultPoolId,
+			TargetGroupID:      tgID,
+			Region:             region,
+			// 华为云健康检查配置在目标组上，证书配置在监听器上
+			HealthCheck: new(corelb.TCloudHealthCheckInfo),
+			Certificate: new(corelb.TCloudCertificateInfo),
+		})
+		tgIDs = append(tgIDs, tgID)
+	}
+
+	for _, batch := range slice.Split(rules, constant.BatchOperationMaxLimit) {
+		req := &protocloud.TCloudUrlRuleBatchCreateReq{UrlRules: batch}
+		if _, err = cli.dbCli.HuaWei.LoadBalancer.BatchCreateUrlRule(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to create default rule failed, err: %v, lb: %s, rid: %s",
+				enumor.HuaWei, err, lb.CloudID, kt.Rid)
+			return err
+		}
+	}
+
+	// 云上关联已经生效，关联关系直接置为绑定成功
+	relReq := &protocloud.TGListenerRelStatusUpdateReq{BindingStatus: enumor.SuccessBindingStatus}
+	for _, tgID := range slice.Unique(tgIDs) {
+		if err = cli.dbCli.Global.LoadBalancer.BatchUpdateListenerRuleRelStatusByTGID(kt, tgID, relReq); err != nil {
+			logs.Errorf("[%s] update default rule rel status failed, err: %v, tgID: %s, rid: %s", enumor.HuaWei,
+				err, tgID, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync default rule to create rule success, lb: %s, count: %d, rid: %s", enumor.HuaWei,
+		lb.CloudID, len(rules), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deleteDefaultRule(kt *kit.Kit, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	for _, batch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		req := &protocloud.LoadBalancerBatchDeleteReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("cloud_id", batch),
+				tools.RuleEqual("rule_type", enumor.Layer4RuleType),
+				tools.RuleEqual("region", region),
+			),
+		}
+		if err := cli.dbCli.HuaWei.LoadBalancer.BatchDeleteUrlRule(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to delete default rule failed, err: %v, ids: %v, rid: %s",
+				enumor.HuaWei, err, batch, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isDefaultRuleChange 默认后端服务器组变化，或目标组在规则创建之后才同步到本地
+func isDefaultRuleChange(cloud typeslb.HuaWeiListener, db corelb.TCloudLbUrlRule, tgIDMap map[string]string) bool {
+	if db.CloudTargetGroupID != cloud.DefaultPoolId {
+		return true
+	}
+
+	return db.TargetGroupID != tgIDMap[cloud.DefaultPoolId]
+}
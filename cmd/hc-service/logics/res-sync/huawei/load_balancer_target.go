@@ -0,0 +1,219 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// Target 同步后端服务器组中的后端服务器，以 实例ID-端口 对比云上和本地数据，权重变化时更新本地目标
+func (cli *client) Target(kt *kit.Kit, accountID, region, tgID, cloudTGID string) error {
+	listOpt := &typeslb.HuaWeiListMemberOption{Region: region, PoolID: cloudTGID}
+	targetFromCloud, err := cli.cloudCli.ListMember(kt, listOpt)
+	if err != nil {
+		logs.Errorf("[%s] list member from cloud failed, err: %v, pool: %s, rid: %s", enumor.HuaWei, err,
+			cloudTGID, kt.Rid)
+		return err
+	}
+
+	targetFromDB, err := cli.listTargetFromDB(kt, tgID)
+	if err != nil {
+		return err
+	}
+
+	if len(targetFromCloud) == 0 && len(targetFromDB) == 0 {
+		return nil
+	}
+
+	addSlice, updateMap, delIDs := diffTarget(targetFromCloud, targetFromDB)
+	addSlice, updateMap, delIDs = common.PlanDiff(kt, addSlice, updateMap, delIDs)
+
+	if err = cli.deleteTarget(kt, delIDs); err != nil {
+		return err
+	}
+	common.RecordDeleted(kt, len(delIDs))
+
+	if err = cli.createTarget(kt, accountID, region, tgID, addSlice); err != nil {
+		return err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateTarget(kt, updateMap); err != nil {
+		return err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return nil
+}
+
+func (cli *client) listTargetFromDB(kt *kit.Kit, tgID string) ([]corelb.BaseTarget, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("target_group_id", tgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	result := make([]corelb.BaseTarget, 0)
+	for {
+		resp, err := cli.dbCli.Global.LoadBalancer.ListTarget(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list target from db failed, err: %v, tgID: %s, rid: %s", enumor.HuaWei, err, tgID,
+				kt.Rid)
+			return nil, err
+		}
+		result = append(result, resp.Details...)
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func (cli *client) createTarget(kt *kit.Kit, accountID, region, tgID string,
+	addSlice []typeslb.HuaWeiMember) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	targets := slice.Map(addSlice, func(one typeslb.HuaWeiMember) *protocloud.TargetBaseReq {
+		return convTargetCreate(one, accountID, region, tgID)
+	})
+	for _, batch := range slice.Split(targets, constant.BatchOperationMaxLimit) {
+		req := &protocloud.TargetBatchCreateReq{Targets: batch}
+		if _, err := cli.dbCli.Global.LoadBalancer.BatchCreateTCloudTarget(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to create target failed, err: %v, tgID: %s, rid: %s",
+				enumor.HuaWei, err, tgID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) updateTarget(kt *kit.Kit, updateMap map[string]typeslb.HuaWeiMember) error {
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updates := make([]*protocloud.TargetUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		updates = append(updates, &protocloud.TargetUpdate{ID: id, Weight: cvt.ValToPtr(one.GetWeight())})
+	}
+
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		req := &protocloud.TargetBatchUpdateReq{Targets: batch}
+		if err := cli.dbCli.Global.LoadBalancer.BatchUpdateTarget(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to update target failed, err: %v, rid: %s", enumor.HuaWei, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) deleteTarget(kt *kit.Kit, delIDs []string) error {
+	if len(delIDs) == 0 {
+		return nil
+	}
+
+	for _, batch := range slice.Split(delIDs, constant.BatchOperationMaxLimit) {
+		req := &protocloud.LoadBalancerBatchDeleteReq{Filter: tools.ContainersExpression("id", batch)}
+		if err := cli.dbCli.Global.LoadBalancer.BatchDeleteTarget(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to delete target failed, err: %v, ids: %v, rid: %s",
+				enumor.HuaWei, err, batch, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// diffTarget 以 实例ID-端口 对比云上和本地的目标，返回需要新增的云上目标、需要更新权重的目标(本地ID -> 云上目标)
+// 和需要删除的本地目标ID
+func diffTarget(cloudTargets []typeslb.HuaWeiMember, dbTargets []corelb.BaseTarget) (
+	[]typeslb.HuaWeiMember, map[string]typeslb.HuaWeiMember, []string) {
+
+	dbMap := make(map[string]corelb.BaseTarget, len(dbTargets))
+	for _, one := range dbTargets {
+		dbMap[targetKey(one.CloudInstID, one.Port)] = one
+	}
+
+	addSlice := make([]typeslb.HuaWeiMember, 0)
+	updateMap := make(map[string]typeslb.HuaWeiMember)
+	for _, one := range cloudTargets {
+		key := targetKey(one.GetCloudInstID(), one.GetPort())
+		db, exists := dbMap[key]
+		if !exists {
+			addSlice = append(addSlice, one)
+			continue
+		}
+		delete(dbMap, key)
+		if cvt.PtrToVal(db.Weight) != one.GetWeight() {
+			updateMap[db.ID] = one
+		}
+	}
+
+	delIDs := make([]string, 0, len(dbMap))
+	for _, one := range dbMap {
+		delIDs = append(delIDs, one.ID)
+	}
+	return addSlice, updateMap, delIDs
+}
+
+func targetKey(cloudInstID string, port int64) string {
+	return fmt.Sprintf("%s-%d", cloudInstID, port)
+}
+
+// convTargetCreate 云主机类型后端服务器关联云主机，IP类型后端服务器使用IP作为实例ID
+func convTargetCreate(cloud typeslb.HuaWeiMember, accountID, region, tgID string) *protocloud.TargetBaseReq {
+	target := &protocloud.TargetBaseReq{
+		InstType:          enumor.CvmInstType,
+		InstName:          cloud.Name,
+		CloudInstID:       cloud.GetCloudInstID(),
+		Port:              cloud.GetPort(),
+		Weight:            cvt.ValToPtr(cloud.GetWeight()),
+		AccountID:         accountID,
+		TargetGroupID:     tgID,
+		TargetGroupRegion: region,
+		IP:                cloud.Address,
+		PrivateIPAddress:  []string{cloud.Address},
+	}
+	if !cloud.IsInstanceMember() {
+		target.InstType = enumor.EniInstType
+	}
+	return target
+}
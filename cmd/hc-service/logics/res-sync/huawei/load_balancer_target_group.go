@@ -0,0 +1,590 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncTargetGroupOption ...
+type SyncTargetGroupOption struct {
+	// BizID 新建目标组时使用的业务，一般为后端服务器组所属负载均衡的业务
+	BizID int64 `json:"bk_biz_id" validate:"required"`
+	// CloudVpcID 后端服务器组没有返回vpc时使用的vpc，一般为所属负载均衡的vpc
+	CloudVpcID string `json:"cloud_vpc_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncTargetGroupOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// poolSyncInfo 后端服务器组同步时需要的关联信息，健康检查和端口不在后端服务器组上，需要额外查询
+type poolSyncInfo struct {
+	// monitorMap 健康检查ID -> 健康检查
+	monitorMap map[string]typeslb.HuaWeiHealthMonitor
+	// portMap 监听器云上ID -> 监听器端口
+	portMap map[string]int64
+}
+
+// TargetGroup 同步指定后端服务器组及其中的后端服务器。华为云后端服务器组对应目标组，后端服务器对应目标，
+// 健康检查记录在目标组扩展字段中。后端服务器组没有端口，使用关联监听器的端口
+func (cli *client) TargetGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncTargetGroupOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tgFromCloud, err := cli.listTargetGroupFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tgFromDB, err := cli.listTargetGroupFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tgFromCloud) == 0 && len(tgFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	info, err := cli.getPoolSyncInfo(kt, params.Region, tgFromCloud)
+	if err != nil {
+		return nil, err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.HuaWeiPool, corelb.HuaWeiTargetGroup](
+		tgFromCloud, tgFromDB, func(cloud typeslb.HuaWeiPool, db corelb.HuaWeiTargetGroup) bool {
+			return isTargetGroupChange(cloud, db, info)
+		})
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteTargetGroup(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createTargetGroup(kt, params.AccountID, params.Region, opt, info, addSlice); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateTargetGroup(kt, params.Region, tgFromDB, info, updateMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	// 重新查询，获取新建目标组的本地ID
+	tgFromDB, err = cli.listTargetGroupFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+	tgIDMap := make(map[string]string, len(tgFromDB))
+	for _, one := range tgFromDB {
+		tgIDMap[one.CloudID] = one.ID
+	}
+	for _, one := range tgFromCloud {
+		tgID, exists := tgIDMap[one.GetCloudID()]
+		if !exists {
+			// vpc 未同步、没有关联监听器或预览模式下新增的目标组不会写入，此时不同步其中的后端服务器
+			continue
+		}
+		if err = cli.Target(kt, params.AccountID, params.Region, tgID, one.GetCloudID()); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// LoadBalancerTargetGroup 同步负载均衡下的后端服务器组，以及监听器与默认后端服务器组的关联关系，需要在监听器同步之后执行
+func (cli *client) LoadBalancerTargetGroup(kt *kit.Kit, accountID, region string,
+	lb corelb.HuaWeiLoadBalancer) error {
+
+	listOpt := &typeslb.HuaWeiListPoolOption{Region: region, LoadBalancerID: lb.CloudID}
+	pools, err := cli.cloudCli.ListPool(kt, listOpt)
+	if err != nil {
+		logs.Errorf("[%s] list pool of lb from cloud failed, err: %v, lb: %s, rid: %s", enumor.HuaWei, err,
+			lb.CloudID, kt.Rid)
+		return err
+	}
+
+	cloudIDs := slice.Map(pools, typeslb.HuaWeiPool.GetCloudID)
+	params := &SyncBaseParams{AccountID: accountID, Region: region}
+	opt := &SyncTargetGroupOption{BizID: lb.BkBizID, CloudVpcID: lb.CloudVpcID}
+	for _, batch := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+		params.CloudIDs = batch
+		if _, err = cli.TargetGroup(kt, params, opt); err != nil {
+			logs.Errorf("[%s] sync target group of lb failed, err: %v, lb: %s, rid: %s", enumor.HuaWei, err,
+				lb.CloudID, kt.Rid)
+			return err
+		}
+	}
+
+	return cli.ListenerDefaultRule(kt, region, lb)
+}
+
+// RemoveTargetGroupDeleteFromCloud 删除存在本地但是在云上被删除的目标组
+func (cli *client) RemoveTargetGroupDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("vendor", enumor.HuaWei),
+			tools.RuleEqual("target_group_type", enumor.CloudTargetGroupType),
+		),
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: typeslb.HuaWeiLBDescribeMax,
+		},
+	}
+
+	for {
+		tgFromDB, err := cli.dbCli.Global.LoadBalancer.ListTargetGroup(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list target group failed, err: %v, req: %v, rid: %s",
+				enumor.HuaWei, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := slice.Map(tgFromDB.Details, corelb.BaseTargetGroup.GetCloudID)
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+		tgFromCloud, err := cli.listTargetGroupFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(tgFromCloud) != len(cloudIDs) {
+			cloudIDMap := cvt.StringSliceToMap(cloudIDs)
+			for _, one := range tgFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+
+			delCloudIDs := cvt.MapKeyToStringSlice(cloudIDMap)
+			if common.PlanDelete(kt, delCloudIDs) {
+				if err = cli.deleteTargetGroup(kt, accountID, region, delCloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
+			}
+		}
+
+		common.RecordScanned(kt, len(tgFromDB.Details))
+		if uint(len(tgFromDB.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return nil
+}
+
+func (cli *client) createTargetGroup(kt *kit.Kit, accountID, region string, opt *SyncTargetGroupOption,
+	info *poolSyncInfo, addSlice []typeslb.HuaWeiPool) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	cloudVpcIDs := slice.Unique(slice.Map(addSlice, func(one typeslb.HuaWeiPool) string {
+		return getPoolCloudVpcID(one, opt.CloudVpcID)
+	}))
+	vpcMap, err := cli.getVpcMap(kt, accountID, region, cloudVpcIDs)
+	if err != nil {
+		logs.Errorf("[%s] fail to get vpc of target group, err: %v, vpcIDs: %v, rid: %s", enumor.HuaWei, err,
+			cloudVpcIDs, kt.Rid)
+		return err
+	}
+
+	createReq := &protocloud.HuaWeiTargetGroupCreateReq{
+		TargetGroups: make([]protocloud.TargetGroupBatchCreate[corelb.HuaWeiTargetGroupExtension], 0,
+			len(addSlice)),
+	}
+	for _, one := range addSlice {
+		cloudVpcID := getPoolCloudVpcID(one, opt.CloudVpcID)
+		if _, exists := vpcMap[cloudVpcID]; !exists {
+			// vpc 未同步时跳过，等待下次同步
+			logs.Errorf("[%s] vpc of target group not found, skip, tg: %s, vpc: %s, rid: %s", enumor.HuaWei,
+				one.GetCloudID(), cloudVpcID, kt.Rid)
+			continue
+		}
+
+		port := info.getPort(one)
+		if port == 0 {
+			// 没有关联监听器的后端服务器组不承载流量，等关联监听器后再同步
+			logs.Infof("[%s] pool not bound to any synced listener, skip, pool: %s, rid: %s", enumor.HuaWei,
+				one.GetCloudID(), kt.Rid)
+			continue
+		}
+
+		createReq.TargetGroups = append(createReq.TargetGroups,
+			protocloud.TargetGroupBatchCreate[corelb.HuaWeiTargetGroupExtension]{
+				CloudID:         one.GetCloudID(),
+				Name:            one.GetName(),
+				Vendor:          enumor.HuaWei,
+				AccountID:       accountID,
+				BkBizID:         opt.BizID,
+				Region:          region,
+				Protocol:        one.GetProtocol(),
+				Port:            port,
+				CloudVpcID:      cloudVpcID,
+				TargetGroupType: enumor.CloudTargetGroupType,
+				Memo:            cvt.ValToPtr(one.Description),
+				Extension:       convHuaWeiTargetGroupExtension(one, info),
+			})
+	}
+
+	for _, batch := range slice.Split(createReq.TargetGroups, constant.BatchOperationMaxLimit) {
+		req := &protocloud.HuaWeiTargetGroupCreateReq{TargetGroups: batch}
+		if _, err = cli.dbCli.HuaWei.LoadBalancer.BatchCreateHuaWeiTargetGroup(kt, req); err != nil {
+			logs.Errorf("[%s] call data service to create target group failed, err: %v, rid: %s", enumor.HuaWei,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync target group to create target group success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(createReq.TargetGroups), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateTargetGroup(kt *kit.Kit, region string, tgFromDB []corelb.HuaWeiTargetGroup,
+	info *poolSyncInfo, updateMap map[string]typeslb.HuaWeiPool) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	dbMap := make(map[string]corelb.HuaWeiTargetGroup, len(tgFromDB))
+	for _, one := range tgFromDB {
+		dbMap[one.ID] = one
+	}
+
+	updates := make([]*protocloud.TargetGroupExtUpdateReq[corelb.HuaWeiTargetGroupExtension], 0,
+		len(updateMap))
+	for id, one := range updateMap {
+		port := info.getPort(one)
+		if port == 0 {
+			// 后端服务器组与监听器解绑后保留原有端口
+			port = dbMap[id].Port
+		}
+		updates = append(updates, &protocloud.TargetGroupExtUpdateReq[corelb.HuaWeiTargetGroupExtension]{
+			ID:        id,
+			Name:      one.GetName(),
+			BkBizID:   dbMap[id].BkBizID,
+			Region:    region,
+			Protocol:  one.GetProtocol(),
+			Port:      port,
+			Memo:      cvt.ValToPtr(one.Description),
+			Extension: convHuaWeiTargetGroupExtension(one, info),
+		})
+	}
+
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		req := protocloud.HuaWeiTargetGroupBatchUpdateReq(batch)
+		if err := cli.dbCli.HuaWei.LoadBalancer.BatchUpdateHuaWeiTargetGroup(kt, &req); err != nil {
+			logs.Errorf("[%s] call data service to update target group failed, err: %v, rid: %s", enumor.HuaWei,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) deleteTargetGroup(kt *kit.Kit, accountID, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	checkParams := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: delCloudIDs}
+	delTGFromCloud, err := cli.listTargetGroupFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delTGFromCloud) > 0 {
+		logs.Errorf("[%s] validate target group not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.HuaWei, checkParams, len(delTGFromCloud), kt.Rid)
+		return fmt.Errorf("validate target group not exist failed, before delete")
+	}
+
+	for _, batch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		delReq := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("cloud_id", batch),
+				tools.RuleEqual("vendor", enumor.HuaWei),
+				tools.RuleEqual("region", region),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		if err = cli.dbCli.Global.LoadBalancer.DeleteTargetGroup(kt, delReq); err != nil {
+			logs.Errorf("[%s] call data service to delete target group failed, err: %v, rid: %s", enumor.HuaWei,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync target group to delete target group success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+// listTargetGroupFromCloud 按ID分批查询后端服务器组
+func (cli *client) listTargetGroupFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typeslb.HuaWeiPool, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := make([]typeslb.HuaWeiPool, 0, len(params.CloudIDs))
+	for _, cloudIDs := range slice.Split(params.CloudIDs, typeslb.HuaWeiLBDescribeMax) {
+		opt := &typeslb.HuaWeiListPoolOption{Region: params.Region, CloudIDs: cloudIDs}
+		batch, err := cli.cloudCli.ListPool(kt, opt)
+		if err != nil {
+			logs.Errorf("[%s] list pool from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+				enumor.HuaWei, err, params.AccountID, opt, kt.Rid)
+			return nil, err
+		}
+		result = append(result, batch...)
+	}
+
+	return result, nil
+}
+
+func (cli *client) listTargetGroupFromDB(kt *kit.Kit, params *SyncBaseParams) ([]corelb.HuaWeiTargetGroup,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := make([]corelb.HuaWeiTargetGroup, 0, len(params.CloudIDs))
+	for _, cloudIDs := range slice.Split(params.CloudIDs, int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("account_id", params.AccountID),
+				tools.RuleEqual("region", params.Region),
+				tools.RuleEqual("vendor", enumor.HuaWei),
+				tools.RuleIn("cloud_id", cloudIDs),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		resp, err := cli.dbCli.HuaWei.LoadBalancer.ListTargetGroup(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list target group from db failed, err: %v, account: %s, req: %v, rid: %s",
+				enumor.HuaWei, err, params.AccountID, req, kt.Rid)
+			return nil, err
+		}
+		result = append(result, resp.Details...)
+	}
+
+	return result, nil
+}
+
+// getPoolSyncInfo 查询后端服务器组的健康检查，以及关联监听器的端口
+func (cli *client) getPoolSyncInfo(kt *kit.Kit, region string, pools []typeslb.HuaWeiPool) (*poolSyncInfo,
+	error) {
+
+	info := &poolSyncInfo{
+		monitorMap: make(map[string]typeslb.HuaWeiHealthMonitor),
+		portMap:    make(map[string]int64),
+	}
+
+	monitorIDs := make([]string, 0)
+	listenerIDs := make([]string, 0)
+	for _, one := range pools {
+		if len(one.HealthmonitorId) != 0 {
+			monitorIDs = append(monitorIDs, one.HealthmonitorId)
+		}
+		listenerIDs = append(listenerIDs, one.GetCloudListenerIDs()...)
+	}
+
+	for _, batch := range slice.Split(slice.Unique(monitorIDs), typeslb.HuaWeiLBDescribeMax) {
+		opt := &typeslb.HuaWeiListHealthMonitorOption{Region: region, CloudIDs: batch}
+		monitors, err := cli.cloudCli.ListHealthMonitor(kt, opt)
+		if err != nil {
+			logs.Errorf("[%s] list health monitor from cloud failed, err: %v, opt: %v, rid: %s", enumor.HuaWei,
+				err, opt, kt.Rid)
+			return nil, err
+		}
+		for _, monitor := range monitors {
+			info.monitorMap[monitor.GetCloudID()] = monitor
+		}
+	}
+
+	for _, batch := range slice.Split(slice.Unique(listenerIDs), int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("vendor", enumor.HuaWei),
+				tools.RuleIn("cloud_id", batch),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		resp, err := cli.dbCli.HuaWei.LoadBalancer.ListListener(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list listener of pool from db failed, err: %v, rid: %s", enumor.HuaWei, err, kt.Rid)
+			return nil, err
+		}
+		for _, lbl := range resp.Details {
+			info.portMap[lbl.CloudID] = lbl.Port
+		}
+	}
+
+	return info, nil
+}
+
+// getPort 返回后端服务器组关联的第一个已同步监听器的端口，没有时返回0
+func (info *poolSyncInfo) getPort(pool typeslb.HuaWeiPool) int64 {
+	for _, lblID := range pool.GetCloudListenerIDs() {
+		if port, exists := info.portMap[lblID]; exists {
+			return port
+		}
+	}
+	return 0
+}
+
+func getPoolCloudVpcID(pool typeslb.HuaWeiPool, defaultVpcID string) string {
+	if len(pool.VpcId) != 0 {
+		return pool.VpcId
+	}
+	return defaultVpcID
+}
+
+func convHuaWeiTargetGroupExtension(cloud typeslb.HuaWeiPool,
+	info *poolSyncInfo) *corelb.HuaWeiTargetGroupExtension {
+
+	ext := &corelb.HuaWeiTargetGroupExtension{
+		LbAlgorithm:          cvt.ValToPtr(cloud.LbAlgorithm),
+		IPVersion:            cvt.ValToPtr(cloud.IpVersion),
+		PoolType:             cvt.ValToPtr(cloud.Type),
+		HealthCheck:          &corelb.HuaWeiHealthCheckInfo{Enabled: cvt.ValToPtr(false)},
+		CloudListenerIDs:     cloud.GetCloudListenerIDs(),
+		CloudLoadBalancerIDs: cloud.GetCloudLoadBalancerIDs(),
+	}
+	if len(cloud.HealthmonitorId) == 0 {
+		return ext
+	}
+
+	ext.CloudHealthMonitorID = cvt.ValToPtr(cloud.HealthmonitorId)
+	if monitor, exists := info.monitorMap[cloud.HealthmonitorId]; exists {
+		ext.HealthCheck = convHuaWeiHealthCheck(monitor)
+	}
+	return ext
+}
+
+func convHuaWeiHealthCheck(monitor typeslb.HuaWeiHealthMonitor) *corelb.HuaWeiHealthCheckInfo {
+	return &corelb.HuaWeiHealthCheckInfo{
+		Enabled:        cvt.ValToPtr(monitor.AdminStateUp),
+		Type:           cvt.ValToPtr(monitor.Type),
+		MonitorPort:    cvt.ValToPtr(int64(monitor.MonitorPort)),
+		Delay:          cvt.ValToPtr(int64(monitor.Delay)),
+		Timeout:        cvt.ValToPtr(int64(monitor.Timeout)),
+		MaxRetries:     cvt.ValToPtr(int64(monitor.MaxRetries)),
+		MaxRetriesDown: cvt.ValToPtr(int64(monitor.MaxRetriesDown)),
+		DomainName:     cvt.ValToPtr(monitor.DomainName),
+		URLPath:        cvt.ValToPtr(monitor.UrlPath),
+		HTTPMethod:     cvt.ValToPtr(monitor.HttpMethod),
+		ExpectedCodes:  cvt.ValToPtr(monitor.ExpectedCodes),
+	}
+}
+
+func isTargetGroupChange(cloud typeslb.HuaWeiPool, db corelb.HuaWeiTargetGroup, info *poolSyncInfo) bool {
+	if db.Name != cloud.GetName() || db.Protocol != cloud.GetProtocol() {
+		return true
+	}
+
+	if port := info.getPort(cloud); port != 0 && db.Port != port {
+		return true
+	}
+
+	if cvt.PtrToVal(db.Memo) != cloud.Description {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	cloudExt := convHuaWeiTargetGroupExtension(cloud, info)
+	if isHuaWeiHealthCheckChange(cloudExt.HealthCheck, db.Extension.HealthCheck) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(db.Extension.LbAlgorithm, cloudExt.LbAlgorithm) ||
+		!assert.IsPtrStringEqual(db.Extension.IPVersion, cloudExt.IPVersion) ||
+		!assert.IsPtrStringEqual(db.Extension.PoolType, cloudExt.PoolType) ||
+		!assert.IsPtrStringEqual(db.Extension.CloudHealthMonitorID, cloudExt.CloudHealthMonitorID) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.Extension.CloudListenerIDs, cloudExt.CloudListenerIDs) {
+		return true
+	}
+
+	return !assert.IsStringSliceEqual(db.Extension.CloudLoadBalancerIDs, cloudExt.CloudLoadBalancerIDs)
+}
+
+func isHuaWeiHealthCheckChange(cloud, db *corelb.HuaWeiHealthCheckInfo) bool {
+	if db == nil {
+		return true
+	}
+
+	if !assert.IsPtrBoolEqual(cloud.Enabled, db.Enabled) ||
+		!assert.IsPtrInt64Equal(cloud.MonitorPort, db.MonitorPort) ||
+		!assert.IsPtrInt64Equal(cloud.Delay, db.Delay) ||
+		!assert.IsPtrInt64Equal(cloud.Timeout, db.Timeout) ||
+		!assert.IsPtrInt64Equal(cloud.MaxRetries, db.MaxRetries) ||
+		!assert.IsPtrInt64Equal(cloud.MaxRetriesDown, db.MaxRetriesDown) {
+		return true
+	}
+
+	return !assert.IsPtrStringEqual(cloud.Type, db.Type) ||
+		!assert.IsPtrStringEqual(cloud.DomainName, db.DomainName) ||
+		!assert.IsPtrStringEqual(cloud.URLPath, db.URLPath) ||
+		!assert.IsPtrStringEqual(cloud.HTTPMethod, db.HTTPMethod) ||
+		!assert.IsPtrStringEqual(cloud.ExpectedCodes, db.ExpectedCodes)
+}
@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"testing"
+
+	typeslb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3/model"
+)
+
+func newHuaWeiPool(id, monitorID string, listenerIDs ...string) typeslb.HuaWeiPool {
+	listeners := make([]model.ListenerRef, 0, len(listenerIDs))
+	for _, one := range listenerIDs {
+		listeners = append(listeners, model.ListenerRef{Id: one})
+	}
+	return typeslb.HuaWeiPool{Pool: model.Pool{
+		Id:              id,
+		Name:            id,
+		Protocol:        "TCP",
+		LbAlgorithm:     "ROUND_ROBIN",
+		HealthmonitorId: monitorID,
+		Listeners:       listeners,
+	}}
+}
+
+func TestDiffTarget(t *testing.T) {
+	cloud := []typeslb.HuaWeiMember{
+		{Member: model.Member{Id: "m-1", Address: "10.0.0.1", ProtocolPort: 80, Weight: 10,
+			InstanceId: cvt.ValToPtr("ecs-1")}},
+		{Member: model.Member{Id: "m-2", Address: "10.0.0.2", ProtocolPort: 80, Weight: 20}},
+		{Member: model.Member{Id: "m-3", Address: "10.0.0.3", ProtocolPort: 8080, Weight: 1}},
+	}
+	db := []corelb.BaseTarget{
+		{ID: "t-1", CloudInstID: "ecs-1", Port: 80, Weight: cvt.ValToPtr(int64(10))},
+		{ID: "t-2", CloudInstID: "10.0.0.2", Port: 80, Weight: cvt.ValToPtr(int64(5))},
+		{ID: "t-4", CloudInstID: "10.0.0.4", Port: 80, Weight: cvt.ValToPtr(int64(1))},
+	}
+
+	addSlice, updateMap, delIDs := diffTarget(cloud, db)
+	if len(addSlice) != 1 || addSlice[0].GetCloudID() != "m-3" {
+		t.Errorf("only m-3 should be added, got: %v", addSlice)
+	}
+	if len(updateMap) != 1 || updateMap["t-2"].GetWeight() != 20 {
+		t.Errorf("only weight of t-2 should be updated, got: %v", updateMap)
+	}
+	if len(delIDs) != 1 || delIDs[0] != "t-4" {
+		t.Errorf("only t-4 should be deleted, got: %v", delIDs)
+	}
+
+	target := convTargetCreate(cloud[1], "account", "region", "tg-1")
+	if target.InstType != enumor.EniInstType || target.CloudInstID != "10.0.0.2" {
+		t.Errorf("ip member should be converted to eni target, got: %+v", target)
+	}
+}
+
+func TestIsTargetGroupChange(t *testing.T) {
+	info := &poolSyncInfo{
+		monitorMap: map[string]typeslb.HuaWeiHealthMonitor{
+			"hm-1": {HealthMonitor: model.HealthMonitor{Id: "hm-1", AdminStateUp: true, Type: "TCP", Delay: 5,
+				Timeout: 3, MaxRetries: 3, MaxRetriesDown: 3}},
+		},
+		portMap: map[string]int64{"lbl-1": 80, "lbl-2": 443},
+	}
+	cloud := newHuaWeiPool("pool-1", "hm-1", "lbl-1")
+	db := corelb.HuaWeiTargetGroup{
+		BaseTargetGroup: corelb.BaseTargetGroup{Name: "pool-1", Protocol: enumor.TcpProtocol, Port: 80,
+			Memo: cvt.ValToPtr("")},
+		Extension: convHuaWeiTargetGroupExtension(cloud, info),
+	}
+	if isTargetGroupChange(cloud, db, info) {
+		t.Errorf("target group converted from pool should not be changed")
+	}
+
+	if !isTargetGroupChange(newHuaWeiPool("pool-1", "hm-1", "lbl-2"), db, info) {
+		t.Errorf("listener and port change should be detected")
+	}
+
+	info.monitorMap["hm-1"] = typeslb.HuaWeiHealthMonitor{HealthMonitor: model.HealthMonitor{Id: "hm-1",
+		AdminStateUp: true, Type: "HTTP", Delay: 5, Timeout: 3, MaxRetries: 3, MaxRetriesDown: 3}}
+	if !isTargetGroupChange(cloud, db, info) {
+		t.Errorf("health monitor change should be detected")
+	}
+
+	// 后端服务器组与监听器解绑后不比较端口
+	unbound := newHuaWeiPool("pool-1", "hm-1")
+	db.Extension = convHuaWeiTargetGroupExtension(unbound, info)
+	if isTargetGroupChange(unbound, db, info) {
+		t.Errorf("unbound pool should keep port of db")
+	}
+}
+
+func TestIsDefaultRuleChange(t *testing.T) {
+	tgIDMap := map[string]string{"pool-1": "tg-1", "pool-2": "tg-2"}
+	db := corelb.TCloudLbUrlRule{CloudID: "lbl-1", CloudTargetGroupID: "pool-1", TargetGroupID: "tg-1"}
+
+	listener := typeslb.HuaWeiListener{Listener: model.Listener{Id: "lbl-1", DefaultPoolId: "pool-1"}}
+	if isDefaultRuleChange(listener, db, tgIDMap) {
+		t.Errorf("default pool not changed")
+	}
+
+	listener.DefaultPoolId = "pool-2"
+	if !isDefaultRuleChange(listener, db, tgIDMap) {
+		t.Errorf("default pool change should be detected")
+	}
+
+	// 规则创建时目标组尚未同步到本地
+	db.TargetGroupID = ""
+	listener.DefaultPoolId = "pool-1"
+	if !isDefaultRuleChange(listener, db, tgIDMap) {
+		t.Errorf("target group synced after rule should be detected")
+	}
+}
@@ -71,6 +71,7 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typesroutetable.HuaWeiRouteTable,
 		routetable.HuaWeiRouteTable](routeTableFromCloud, routeTableFromDB, isRouteTableChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	subnetMap := make(map[string]dataproto.RouteTableSubnetReq, 0)
 
@@ -86,6 +87,7 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 				err, params.AccountID, params.Region, delCloudIDs, kt.Rid)
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
@@ -96,6 +98,7 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 		for k, v := range addSubnetMap {
 			subnetMap[k] = v
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
@@ -106,6 +109,7 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 		for k, v := range updateSubnetMap {
 			subnetMap[k] = v
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	// 更新子网的路由表信息
@@ -402,19 +406,23 @@ func (cli *client) RemoveRouteTableDeleteFromCloud(kt *kit.Kit, accountID string
 			}
 
 			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
-			err = common.CancelRouteTableSubnetRel(kt, cli.dbCli, enumor.HuaWei, delCloudIDs)
-			if err != nil {
-				logs.Errorf("[%s] routetable batch cancel subnet rel failed. deleteIDs: %v, err: %v, rid: %s",
-					enumor.HuaWei, delCloudIDs, err, kt.Rid)
-				return err
-			}
-			if err = cli.deleteRouteTable(kt, accountID, region, delCloudIDs); err != nil {
-				logs.Errorf("delete huawei routeTable failed, err: %v, account: %s, region: %s, delCloudIDs: %v, rid: %s",
-					err, accountID, region, delCloudIDs, kt.Rid)
-				return err
+			if common.PlanDelete(kt, delCloudIDs) {
+				err = common.CancelRouteTableSubnetRel(kt, cli.dbCli, enumor.HuaWei, delCloudIDs)
+				if err != nil {
+					logs.Errorf("[%s] routetable batch cancel subnet rel failed. deleteIDs: %v, err: %v, rid: %s",
+						enumor.HuaWei, delCloudIDs, err, kt.Rid)
+					return err
+				}
+				if err = cli.deleteRouteTable(kt, accountID, region, delCloudIDs); err != nil {
+					logs.Errorf("delete huawei routeTable failed, err: %v, account: %s, region: %s, "+
+						"delCloudIDs: %v, rid: %s", err, accountID, region, delCloudIDs, kt.Rid)
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB))
 		if len(resultFromDB) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -83,6 +83,7 @@ func (cli *client) ArgsTplAddress(kt *kit.Kit, params *SyncBaseParams, opt *Sync
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typeargstpl.TCloudArgsTplAddress,
 		*coreargstpl.ArgsTpl[coreargstpl.TCloudArgsTplExtension]](fromCloud, fromDB, isChangeAddress)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	logs.Infof("[%s] hcservice sync argument template diff address success, addNum: %d, updateNum: %d, delNum: %d, "+
 		"rid: %s", enumor.TCloud, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
@@ -91,18 +92,21 @@ func (cli *client) ArgsTplAddress(kt *kit.Kit, params *SyncBaseParams, opt *Sync
 		if err = cli.deleteAddress(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createAddress(kt, params.AccountID, opt, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateAddress(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -474,11 +478,15 @@ func (cli *client) RemoveArgsTplAddressDeleteFromCloud(kt *kit.Kit, accountID, r
 			}
 
 			cloudIDs = converter.MapKeyToStringSlice(cloudIDMap)
-			if err = cli.deleteAddress(kt, accountID, region, cloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, cloudIDs) {
+				if err = cli.deleteAddress(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(cloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -520,6 +528,7 @@ func (cli *client) ArgsTplAddressGroup(kt *kit.Kit, params *SyncBaseParams, opt
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typeargstpl.TCloudArgsTplAddressGroup,
 		*coreargstpl.ArgsTpl[coreargstpl.TCloudArgsTplExtension]](fromCloud, fromDB, isChangeAddressGroup)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	logs.Infof("[%s] hcservice sync argument template diff address group success, addNum: %d, updateNum: %d, "+
 		"delNum: %d, rid: %s", enumor.TCloud, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
@@ -528,18 +537,21 @@ func (cli *client) ArgsTplAddressGroup(kt *kit.Kit, params *SyncBaseParams, opt
 		if err = cli.deleteAddressGroup(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createAddressGroup(kt, params.AccountID, opt, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateAddressGroup(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -730,11 +742,15 @@ func (cli *client) RemoveArgsTplAddressGroupDeleteFromCloud(kt *kit.Kit, account
 			}
 
 			cloudIDs = converter.MapKeyToStringSlice(cloudIDMap)
-			if err = cli.deleteAddressGroup(kt, accountID, region, cloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, cloudIDs) {
+				if err = cli.deleteAddressGroup(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(cloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -775,6 +791,7 @@ func (cli *client) ArgsTplService(kt *kit.Kit, params *SyncBaseParams, opt *Sync
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typeargstpl.TCloudArgsTplService,
 		*coreargstpl.ArgsTpl[coreargstpl.TCloudArgsTplExtension]](fromCloud, fromDB, isChangeService)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	logs.Infof("[%s] hcservice sync argument template diff service success, addNum: %d, updateNum: %d, delNum: %d, "+
 		"rid: %s", enumor.TCloud, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
@@ -783,18 +800,21 @@ func (cli *client) ArgsTplService(kt *kit.Kit, params *SyncBaseParams, opt *Sync
 		if err = cli.deleteService(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createService(kt, params.AccountID, opt, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateService(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -1002,11 +1022,15 @@ func (cli *client) RemoveArgsTplServiceDeleteFromCloud(kt *kit.Kit, accountID, r
 			}
 
 			cloudIDs = converter.MapKeyToStringSlice(cloudIDMap)
-			if err = cli.deleteService(kt, accountID, region, cloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, cloudIDs) {
+				if err = cli.deleteService(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(cloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -1049,6 +1073,7 @@ func (cli *client) ArgsTplServiceGroup(kt *kit.Kit, params *SyncBaseParams, opt
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typeargstpl.TCloudArgsTplServiceGroup,
 		*coreargstpl.ArgsTpl[coreargstpl.TCloudArgsTplExtension]](fromCloud, fromDB, isChangeServiceGroup)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	logs.Infof("[%s] hcservice sync argument template diff service group success, addNum: %d, updateNum: %d, "+
 		"delNum: %d, rid: %s", enumor.TCloud, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
@@ -1057,18 +1082,21 @@ func (cli *client) ArgsTplServiceGroup(kt *kit.Kit, params *SyncBaseParams, opt
 		if err = cli.deleteServiceGroup(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
 		if err = cli.createServiceGroup(kt, params.AccountID, opt, addSlice); err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateServiceGroup(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	return new(SyncResult), nil
@@ -1259,11 +1287,15 @@ func (cli *client) RemoveArgsTplServiceGroupDeleteFromCloud(kt *kit.Kit, account
 			}
 
 			cloudIDs = converter.MapKeyToStringSlice(cloudIDMap)
-			if err = cli.deleteServiceGroup(kt, accountID, region, cloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, cloudIDs) {
+				if err = cli.deleteServiceGroup(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(cloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -81,18 +81,22 @@ func (cli *client) Cert(kt *kit.Kit, params *SyncBaseParams, opt *SyncCertOption
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typecert.TCloudCert, *corecert.Cert[corecert.TCloudCertExtension]](
 		certFromCloud, certFromDB, isCertChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if err = cli.deleteCert(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 		return nil, err
 	}
+	common.RecordDeleted(kt, len(delCloudIDs))
 
 	if err = cli.createCert(kt, params.AccountID, opt, addSlice); err != nil {
 		return nil, err
 	}
+	common.RecordAdded(kt, len(addSlice))
 
 	if err = cli.updateCert(kt, params.AccountID, updateMap); err != nil {
 		return nil, err
 	}
+	common.RecordUpdated(kt, len(updateMap))
 
 	return new(SyncResult), nil
 }
@@ -356,6 +360,7 @@ func (cli *client) RemoveCertDeleteFromCloud(kt *kit.Kit, accountID, region stri
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -367,9 +372,13 @@ func (cli *client) RemoveCertDeleteFromCloud(kt *kit.Kit, accountID, region stri
 	}
 
 	for _, delCloudBatch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		if !common.PlanDelete(kt, delCloudBatch) {
+			continue
+		}
 		if err = cli.deleteCert(kt, accountID, region, delCloudBatch); err != nil {
 			return err
 		}
+		common.RecordDeleted(kt, len(delCloudBatch))
 
 	}
 
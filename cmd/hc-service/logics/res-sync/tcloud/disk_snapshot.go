@@ -0,0 +1,363 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncDiskSnapshotOption ...
+type SyncDiskSnapshotOption struct {
+}
+
+// Validate ...
+func (opt SyncDiskSnapshotOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// DiskSnapshot 同步云硬盘快照，快照关联的源云盘需已同步，否则快照的disk_id为空，待云盘同步后再次同步快照时补齐
+func (cli *client) DiskSnapshot(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskSnapshotOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshotFromCloud, err := cli.listDiskSnapshotFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotFromDB, err := cli.listDiskSnapshotFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshotFromCloud) == 0 && len(snapshotFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	cloudDiskIDs := make([]string, 0, len(snapshotFromCloud))
+	for _, one := range snapshotFromCloud {
+		cloudDiskIDs = append(cloudDiskIDs, converter.PtrToVal(one.DiskId))
+	}
+	diskIDMap, err := common.ListDiskIDMapByCloudIDs(kt, cli.dbCli, enumor.TCloud, params.AccountID, cloudDiskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typesnapshot.TCloudSnapshot,
+		*coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension]](snapshotFromCloud, snapshotFromDB,
+		func(cloud typesnapshot.TCloudSnapshot, db *coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension]) bool {
+			return isDiskSnapshotChange(cloud, db, diskIDMap)
+		})
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
+
+	if err = cli.deleteDiskSnapshot(kt, params.AccountID, delCloudIDs); err != nil {
+		return nil, err
+	}
+	common.RecordDeleted(kt, len(delCloudIDs))
+
+	if err = cli.createDiskSnapshot(kt, params.AccountID, params.Region, addSlice, diskIDMap); err != nil {
+		return nil, err
+	}
+	common.RecordAdded(kt, len(addSlice))
+
+	if err = cli.updateDiskSnapshot(kt, params.AccountID, updateMap, diskIDMap); err != nil {
+		return nil, err
+	}
+	common.RecordUpdated(kt, len(updateMap))
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) deleteDiskSnapshot(kt *kit.Kit, accountID string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return nil
+	}
+
+	deleteReq := &dataproto.DiskSnapshotDeleteReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleIn("cloud_id", delCloudIDs),
+		),
+	}
+	if err := cli.dbCli.Global.BatchDeleteDiskSnapshot(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete disk snapshot failed, err: %v, rid: %s",
+			enumor.TCloud, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync disk snapshot to delete success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createDiskSnapshot(kt *kit.Kit, accountID, region string,
+	addSlice []typesnapshot.TCloudSnapshot, diskIDMap map[string]string) error {
+
+	if len(addSlice) == 0 {
+		return nil
+	}
+
+	createReq := make(dataproto.DiskSnapshotExtBatchCreateReq[coresnapshot.TCloudExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		cloudDiskID := converter.PtrToVal(one.DiskId)
+		snapshot := &dataproto.DiskSnapshotExtCreateReq[coresnapshot.TCloudExtension]{
+			AccountID:   accountID,
+			CloudID:     one.GetCloudID(),
+			Name:        converter.PtrToVal(one.SnapshotName),
+			Region:      region,
+			DiskID:      diskIDMap[cloudDiskID],
+			CloudDiskID: cloudDiskID,
+			DiskSize:    converter.PtrToVal(one.DiskSize),
+			Status:      converter.PtrToVal(one.SnapshotState),
+			Extension:   convTCloudSnapshotExtension(one),
+		}
+		if one.Placement != nil {
+			snapshot.Zone = converter.PtrToVal(one.Placement.Zone)
+		}
+
+		createReq = append(createReq, snapshot)
+	}
+
+	for _, part := range slice.Split(createReq, constant.BatchOperationMaxLimit) {
+		req := dataproto.DiskSnapshotExtBatchCreateReq[coresnapshot.TCloudExtension](part)
+		if _, err := cli.dbCli.TCloud.BatchCreateDiskSnapshot(kt, &req); err != nil {
+			logs.Errorf("[%s] request dataservice to create disk snapshot failed, err: %v, rid: %s", enumor.TCloud,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync disk snapshot to create success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateDiskSnapshot(kt *kit.Kit, accountID string,
+	updateMap map[string]typesnapshot.TCloudSnapshot, diskIDMap map[string]string) error {
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updateReq := make(dataproto.DiskSnapshotExtBatchUpdateReq[coresnapshot.TCloudExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		cloudDiskID := converter.PtrToVal(one.DiskId)
+		updateReq = append(updateReq, &dataproto.DiskSnapshotExtUpdateReq[coresnapshot.TCloudExtension]{
+			ID:          id,
+			Name:        converter.PtrToVal(one.SnapshotName),
+			DiskID:      diskIDMap[cloudDiskID],
+			CloudDiskID: cloudDiskID,
+			DiskSize:    converter.PtrToVal(one.DiskSize),
+			Status:      converter.PtrToVal(one.SnapshotState),
+			Extension:   convTCloudSnapshotExtension(one),
+		})
+	}
+
+	for _, part := range slice.Split(updateReq, constant.BatchOperationMaxLimit) {
+		req := dataproto.DiskSnapshotExtBatchUpdateReq[coresnapshot.TCloudExtension](part)
+		if err := cli.dbCli.TCloud.BatchUpdateDiskSnapshot(kt, &req); err != nil {
+			logs.Errorf("[%s] request dataservice to update disk snapshot failed, err: %v, rid: %s", enumor.TCloud,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync disk snapshot to update success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func convTCloudSnapshotExtension(one typesnapshot.TCloudSnapshot) *coresnapshot.TCloudExtension {
+	return &coresnapshot.TCloudExtension{
+		SnapshotType:     one.SnapshotType,
+		Encrypt:          one.Encrypt,
+		DiskUsage:        one.DiskUsage,
+		IsPermanent:      one.IsPermanent,
+		DeadlineTime:     one.DeadlineTime,
+		CloudCreatedTime: one.CreateTime,
+	}
+}
+
+func (cli *client) listDiskSnapshotFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typesnapshot.TCloudSnapshot,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adcore.TCloudListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+		Page:     &adcore.TCloudPage{Offset: 0, Limit: adcore.TCloudQueryLimit},
+	}
+	result, err := cli.cloudCli.ListDiskSnapshot(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list disk snapshot from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.TCloud, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listDiskSnapshotFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]*coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", params.AccountID),
+			tools.RuleEqual("region", params.Region),
+			tools.RuleIn("cloud_id", params.CloudIDs),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.TCloud.ListDiskSnapshot(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list disk snapshot from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.TCloud, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isDiskSnapshotChange(cloud typesnapshot.TCloudSnapshot,
+	db *coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension], diskIDMap map[string]string) bool {
+
+	if converter.PtrToVal(cloud.SnapshotName) != db.Name {
+		return true
+	}
+
+	if converter.PtrToVal(cloud.SnapshotState) != db.Status {
+		return true
+	}
+
+	if converter.PtrToVal(cloud.DiskSize) != db.DiskSize {
+		return true
+	}
+
+	if diskIDMap[converter.PtrToVal(cloud.DiskId)] != db.DiskID {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.DeadlineTime, db.Extension.DeadlineTime) {
+		return true
+	}
+
+	if !assert.IsPtrBoolEqual(cloud.IsPermanent, db.Extension.IsPermanent) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.SnapshotType, db.Extension.SnapshotType) {
+		return true
+	}
+
+	return false
+}
+
+// RemoveDiskSnapshotDeleteFromCloud 删除db中存在但云上已删除的快照
+func (cli *client) RemoveDiskSnapshotDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+		),
+		Page: &core.BasePage{Start: 0, Limit: constant.BatchOperationMaxLimit},
+	}
+
+	delCloudIDs := make([]string, 0)
+	for {
+		resultFromDB, err := cli.dbCli.Global.ListDiskSnapshot(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list disk snapshot failed, err: %v, req: %v, rid: %s",
+				enumor.TCloud, err, req, kt.Rid)
+			return err
+		}
+
+		if len(resultFromDB.Details) == 0 {
+			break
+		}
+
+		cloudIDs := make([]string, 0, len(resultFromDB.Details))
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		params := &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+		resultFromCloud, err := cli.listDiskSnapshotFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+			delCloudIDs = append(delCloudIDs, converter.MapKeyToStringSlice(cloudIDMap)...)
+		}
+
+		common.RecordScanned(kt, len(resultFromDB.Details))
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		if !common.PlanDelete(kt, part) {
+			continue
+		}
+		if err := cli.deleteDiskSnapshot(kt, accountID, part); err != nil {
+			return err
+		}
+		common.RecordDeleted(kt, len(part))
+	}
+
+	return nil
+}
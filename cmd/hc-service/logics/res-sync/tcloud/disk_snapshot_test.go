@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"testing"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/stretchr/testify/assert"
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+)
+
+func newTCloudSnapshot(cloudID, name, cloudDiskID string) typesnapshot.TCloudSnapshot {
+	return typesnapshot.TCloudSnapshot{Snapshot: &cbs.Snapshot{
+		SnapshotId:    cvt.ValToPtr(cloudID),
+		SnapshotName:  cvt.ValToPtr(name),
+		SnapshotState: cvt.ValToPtr("NORMAL"),
+		DiskId:        cvt.ValToPtr(cloudDiskID),
+		DiskSize:      cvt.ValToPtr(uint64(50)),
+		IsPermanent:   cvt.ValToPtr(true),
+	}}
+}
+
+func newDBSnapshot(id, cloudID, name, diskID string) *coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension] {
+	return &coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension]{
+		BaseDiskSnapshot: coresnapshot.BaseDiskSnapshot{ID: id, CloudID: cloudID, Name: name, DiskID: diskID,
+			Status: "NORMAL", DiskSize: 50},
+		Extension: &coresnapshot.TCloudExtension{IsPermanent: cvt.ValToPtr(true)},
+	}
+}
+
+func TestDiskSnapshotDiff(t *testing.T) {
+	diskIDMap := map[string]string{"disk-1": "d-1", "disk-2": "d-2"}
+	fromCloud := []typesnapshot.TCloudSnapshot{
+		newTCloudSnapshot("snap-1", "unchanged", "disk-1"),
+		newTCloudSnapshot("snap-2", "renamed", "disk-1"),
+		// 源云盘在上次同步时尚未同步到本地，本次同步后需要补齐云盘ID
+		newTCloudSnapshot("snap-3", "disk-synced", "disk-2"),
+		newTCloudSnapshot("snap-4", "new", "disk-2"),
+	}
+	fromDB := []*coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension]{
+		newDBSnapshot("s-1", "snap-1", "unchanged", "d-1"),
+		newDBSnapshot("s-2", "snap-2", "origin", "d-1"),
+		newDBSnapshot("s-3", "snap-3", "disk-synced", ""),
+		newDBSnapshot("s-5", "snap-5", "deleted", "d-1"),
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typesnapshot.TCloudSnapshot,
+		*coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension]](fromCloud, fromDB,
+		func(cloud typesnapshot.TCloudSnapshot, db *coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension]) bool {
+			return isDiskSnapshotChange(cloud, db, diskIDMap)
+		})
+
+	assert.Equal(t, []typesnapshot.TCloudSnapshot{fromCloud[3]}, addSlice)
+	assert.Equal(t, map[string]typesnapshot.TCloudSnapshot{"s-2": fromCloud[1], "s-3": fromCloud[2]}, updateMap)
+	assert.Equal(t, []string{"snap-5"}, delCloudIDs)
+}
+
+func TestIsDiskSnapshotChange(t *testing.T) {
+	diskIDMap := map[string]string{"disk-1": "d-1"}
+	db := newDBSnapshot("s-1", "snap-1", "snap", "d-1")
+	assert.False(t, isDiskSnapshotChange(newTCloudSnapshot("snap-1", "snap", "disk-1"), db, diskIDMap))
+
+	changes := []func(cloud *typesnapshot.TCloudSnapshot){
+		func(cloud *typesnapshot.TCloudSnapshot) { cloud.SnapshotState = cvt.ValToPtr("ROLLBACKING") },
+		func(cloud *typesnapshot.TCloudSnapshot) { cloud.DiskSize = cvt.ValToPtr(uint64(100)) },
+		func(cloud *typesnapshot.TCloudSnapshot) { cloud.IsPermanent = cvt.ValToPtr(false) },
+		func(cloud *typesnapshot.TCloudSnapshot) { cloud.DeadlineTime = cvt.ValToPtr("2025-02-01 00:00:00") },
+		// 源云盘已从本地删除
+		func(cloud *typesnapshot.TCloudSnapshot) { cloud.DiskId = cvt.ValToPtr("disk-deleted") },
+	}
+	for _, change := range changes {
+		cloud := newTCloudSnapshot("snap-1", "snap", "disk-1")
+		change(&cloud)
+		assert.True(t, isDiskSnapshotChange(cloud, db, diskIDMap))
+	}
+}
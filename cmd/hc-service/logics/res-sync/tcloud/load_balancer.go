@@ -111,21 +111,30 @@ func (cli *client) LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLB
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.TCloudClb, corelb.TCloudLoadBalancer](
 		lbFromCloud, lbFromDB, isLBChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	// 删除云上已经删除的负载均衡实例
 	if err = cli.deleteLoadBalancer(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 		return nil, err
 	}
+	common.RecordDeleted(kt, len(delCloudIDs))
 
 	// 创建云上新增负载均衡实例
 	_, err = cli.createLoadBalancer(kt, params.AccountID, params.Region, addSlice)
 	if err != nil {
 		return nil, err
 	}
+	common.RecordAdded(kt, len(addSlice))
 	// 更新变更负载均衡
 	if err = cli.updateLoadBalancer(kt, params.AccountID, params.Region, updateMap); err != nil {
 		return nil, err
 	}
+	common.RecordUpdated(kt, len(updateMap))
+	if err = common.SyncResTags(kt, cli.dbCli, enumor.TCloud, enumor.LoadBalancerCloudResType, params.AccountID,
+		lbFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
@@ -196,6 +205,7 @@ func (cli *client) RemoveLoadBalancerDeleteFromCloudV2(kt *kit.Kit, params *Sync
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if uint(len(resultFromDB.Details)) < core.DefaultMaxPageLimit {
 			break
 		}
@@ -205,11 +215,15 @@ func (cli *client) RemoveLoadBalancerDeleteFromCloudV2(kt *kit.Kit, params *Sync
 	logs.Infof("[%s] will remove %d deleted load balancer from cloud, account: %s, region: %s, rid: %s",
 		enumor.TCloud, len(delCloudIDs), params.AccountID, params.Region, kt.Rid)
 	for _, idBatch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		if !common.PlanDelete(kt, idBatch) {
+			continue
+		}
 		if err := cli.deleteLoadBalancer(kt, params.AccountID, params.Region, idBatch); err != nil {
 			logs.Errorf("fail to delete removed clb, err: %v, account: %s, region: %s, cloudIds: %v, rid: %s",
 				err, params.AccountID, params.Region, idBatch, kt.Rid)
 			return err
 		}
+		common.RecordDeleted(kt, len(idBatch))
 	}
 
 	return nil
@@ -260,11 +274,15 @@ func (cli *client) RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, params *SyncRe
 		}
 
 		if len(delCloudIDs) != 0 {
-			if err = cli.deleteLoadBalancer(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, delCloudIDs) {
+				if err = cli.deleteLoadBalancer(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(lbFromDB.Details))
 		if len(lbFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
@@ -200,6 +200,7 @@ func (cli *client) deleteRemovedListener(kt *kit.Kit, lbID, region string, cloud
 			}
 		}
 
+		common.RecordScanned(kt, len(dbListeners))
 		if uint(len(dbListeners)) < page.Limit {
 			break
 		}
@@ -210,12 +211,15 @@ func (cli *client) deleteRemovedListener(kt *kit.Kit, lbID, region string, cloud
 	}
 
 	for _, cloudIds := range slice.Split(removedLblCloudIds, constant.BatchOperationMaxLimit) {
+		if !common.PlanDelete(kt, cloudIds) {
+			continue
+		}
 		if err := cli.deleteListener(kt, region, cloudIds); err != nil {
 			logs.Errorf("fail to delete removed listener for sync, err: %v, listener_cloud_ids: %v, lbID: %s, rid: %s",
 				err, cloudIds, lbID, kt.Rid)
 			return err
 		}
-
+		common.RecordDeleted(kt, len(cloudIds))
 	}
 	return nil
 }
@@ -242,21 +246,25 @@ func (cli *client) listener(kt *kit.Kit, params *SyncBaseParams, opt *SyncListen
 	}
 	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.TCloudListener, corelb.TCloudListener](
 		cloudListeners, dbListeners, isListenerChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	// 删除云上已经删除的监听器实例
 	if err = cli.deleteListener(kt, params.Region, delCloudIDs); err != nil {
 		return err
 	}
+	common.RecordDeleted(kt, len(delCloudIDs))
 
 	// 创建云上新增监听器实例， 对于四层规则一起创建对应的规则
 	_, err = cli.createListener(kt, params.AccountID, params.Region, opt, addSlice)
 	if err != nil {
 		return err
 	}
+	common.RecordAdded(kt, len(addSlice))
 	// 更新变更监听器，不更新对应四层/七层 规则
 	if err = cli.updateListener(kt, opt.BizID, params.Region, updateMap); err != nil {
 		return err
 	}
+	common.RecordUpdated(kt, len(updateMap))
 
 	// 同步监听器下的四层/七层规则
 	_, err = cli.loadBalancerRule(kt, params, opt, cloudListeners)
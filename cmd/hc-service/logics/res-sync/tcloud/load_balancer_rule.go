@@ -107,11 +107,13 @@ func (cli *client) LoadBalancerLayer4Rule(kt *kit.Kit, lbID string, l4Listeners
 	// 新增实例应该在同步监听器的时候附带创建，云上已删除的规则应该在监听器同步时被删除
 	_, updateMap, _ := common.Diff[typeslb.TCloudListener, corelb.TCloudLbUrlRule](
 		l4Listeners, dbRules, isLayer4RuleChange)
+	_, updateMap, _ = common.PlanDiff(kt, nil, updateMap, nil)
 
 	// 更新变更监听器，更新对应四层/七层 规则
 	if err = cli.updateLayer4Rule(kt, updateMap); err != nil {
 		return nil, err
 	}
+	common.RecordUpdated(kt, len(updateMap))
 
 	return new(SyncResult), nil
 
@@ -141,18 +143,22 @@ func (cli *client) ListenerLayer7Rule(kt *kit.Kit, params *SyncBaseParams, opt *
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typeslb.TCloudUrlRule, corelb.TCloudLbUrlRule](
 		cloudRules, dbRules, isLayer7RuleChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if err = cli.deleteLayer7Rule(kt, params.Region, delCloudIDs); err != nil {
 		return nil, err
 	}
+	common.RecordDeleted(kt, len(delCloudIDs))
 
 	if err = cli.updateLayer7Rule(kt, params.Region, updateMap); err != nil {
 		return nil, err
 	}
+	common.RecordUpdated(kt, len(updateMap))
 
 	if _, err = cli.createLayer7Rule(kt, params.Region, opt, addSlice); err != nil {
 		return nil, err
 	}
+	common.RecordAdded(kt, len(addSlice))
 	return nil, nil
 }
 
@@ -61,6 +61,11 @@ func (cli *client) lbSgRel(kt *kit.Kit, params *SyncBaseParams, opt *SyncLBOptio
 func (cli *client) compareLbSgRel(kt *kit.Kit, lbIDs []string, lbSgCloudMap map[string][]string,
 	sgCloudLocalIdMap map[string]string) error {
 
+	// 预览模式下不同步关联关系
+	if common.IsDryRun(kt) {
+		return nil
+	}
+
 	// 获取本地关联关系
 	relReq := &protocloud.SGCommonRelWithSecurityGroupListReq{
 		ResIDs:  lbIDs,
@@ -47,6 +47,11 @@ import (
 func (cli *client) LocalTargetGroup(kt *kit.Kit, param *SyncBaseParams, opt *SyncListenerOption,
 	cloudListeners []typeslb.TCloudListener) error {
 
+	// 预览模式下不同步目标组
+	if common.IsDryRun(kt) {
+		return nil
+	}
+
 	// 目前主要是同步健康检查
 	healthMap := make(map[string]*tclb.HealthCheck, len(cloudListeners))
 	cloudIDs := make([]string, 0, len(cloudListeners))
@@ -136,6 +141,11 @@ func (cli *client) getTargetGruop(kt *kit.Kit, lbId string, cloudIDs []string,
 // SyncBaseParams 中的CloudID作为监听器id筛选，不传的话就是同步当前LB下的全部监听器
 func (cli *client) ListenerTargets(kt *kit.Kit, param *SyncBaseParams, opt *SyncListenerOption) error {
 
+	// 预览模式下不同步目标组中的rs
+	if common.IsDryRun(kt) {
+		return nil
+	}
+
 	cloudListenerTargets, relMap, tgRsMap, lb, err := cli.listTargetRelated(kt, param, opt)
 	if err != nil {
 		logs.Errorf("fail to list related res during targets syncing, err: %v, rid: %s", err, kt.Rid)
@@ -75,11 +75,13 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 
 	addSlice, updateMap, delCloudIDs := common.Diff[securitygroup.TCloudSG, cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension]](
 		sgFromCloud, sgFromDB, isSGChange)
+	addSlice, updateMap, delCloudIDs = common.PlanDiff(kt, addSlice, updateMap, delCloudIDs)
 
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteSG(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		common.RecordDeleted(kt, len(delCloudIDs))
 	}
 
 	if len(addSlice) > 0 {
@@ -87,12 +89,14 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 		if err != nil {
 			return nil, err
 		}
+		common.RecordAdded(kt, len(addSlice))
 	}
 
 	if len(updateMap) > 0 {
 		if err = cli.updateSG(kt, params.AccountID, updateMap); err != nil {
 			return nil, err
 		}
+		common.RecordUpdated(kt, len(updateMap))
 	}
 
 	// 同步安全组规则
@@ -373,6 +377,7 @@ func (cli *client) RemoveSecurityGroupDeleteFromCloudV2(kt *kit.Kit, accountID s
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if uint(len(resultFromDB.Details)) < core.DefaultMaxPageLimit {
 			break
 		}
@@ -381,11 +386,15 @@ func (cli *client) RemoveSecurityGroupDeleteFromCloudV2(kt *kit.Kit, accountID s
 	logs.Infof("[%s] will remove %d deleted security group from cloud, account: %s, region: %s, rid: %s",
 		enumor.TCloud, len(delCloudIDs), accountID, region, kt.Rid)
 	for _, idBatch := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		if !common.PlanDelete(kt, idBatch) {
+			continue
+		}
 		if err := cli.deleteSG(kt, accountID, region, idBatch); err != nil {
 			logs.Errorf("delete removed security group failed, err: %v, account: %s, region: %s, cloudId: %v, rid: %s",
 				err, accountID, region, idBatch, kt.Rid)
 			return err
 		}
+		common.RecordDeleted(kt, len(idBatch))
 	}
 	return nil
 }
@@ -444,11 +453,15 @@ func (cli *client) RemoveSecurityGroupDeleteFromCloud(kt *kit.Kit, accountID str
 		}
 
 		if len(delCloudIDs) != 0 {
-			if err = cli.deleteSG(kt, accountID, region, delCloudIDs); err != nil {
-				return err
+			if common.PlanDelete(kt, delCloudIDs) {
+				if err = cli.deleteSG(kt, accountID, region, delCloudIDs); err != nil {
+					return err
+				}
+				common.RecordDeleted(kt, len(delCloudIDs))
 			}
 		}
 
+		common.RecordScanned(kt, len(resultFromDB.Details))
 		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
 			break
 		}
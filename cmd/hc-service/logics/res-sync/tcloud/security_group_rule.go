@@ -20,6 +20,7 @@
 package tcloud
 
 import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
@@ -151,6 +152,11 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 		createRules = append(createRules, *rule)
 	}
 
+	// 预览模式下不同步安全组规则
+	if common.IsDryRun(kt) {
+		return new(SyncResult), nil
+	}
+
 	if len(deleteRuleIDs) != 0 {
 		if err = cli.deleteSGRule(kt, sg.ID, deleteRuleIDs); err != nil {
 			return nil, err
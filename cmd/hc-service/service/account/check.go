@@ -37,11 +37,18 @@ func (svc *service) TCloudAccountCheck(cts *rest.Contexts) (interface{}, error)
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	client, err := svc.ad.Adaptor().TCloud(
-		&types.BaseSecret{
-			CloudSecretID:  req.CloudSecretID,
-			CloudSecretKey: req.CloudSecretKey,
-		})
+	secret := &types.BaseSecret{
+		CloudSecretID:  req.CloudSecretID,
+		CloudSecretKey: req.CloudSecretKey,
+	}
+	if req.CredentialMode.IsRole() {
+		var err error
+		if secret, err = svc.ad.RoleCredential().TCloudSecret(cts.Kit, req.CloudRoleArn); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := svc.ad.Adaptor().TCloud(secret)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +58,8 @@ func (svc *service) TCloudAccountCheck(cts *rest.Contexts) (interface{}, error)
 		return nil, err
 	}
 	// check if cloud account info matches the hcm account detail.
-	if infoBySecret.CloudSubAccountID != req.CloudSubAccountID {
+	// 角色模式下获取到的是角色身份，只校验主账号
+	if !req.CredentialMode.IsRole() && infoBySecret.CloudSubAccountID != req.CloudSubAccountID {
 		return nil, errf.New(errf.InvalidParameter,
 			"CloudSubAccountID does not match the account to which the secret belongs")
 	}
@@ -75,11 +83,19 @@ func (svc *service) AwsAccountCheck(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	client, err := svc.ad.Adaptor().Aws(
-		&types.BaseSecret{
-			CloudSecretID:  req.CloudSecretID,
-			CloudSecretKey: req.CloudSecretKey,
-		}, req.CloudAccountID, req.Site)
+	secret := &types.BaseSecret{
+		CloudSecretID:  req.CloudSecretID,
+		CloudSecretKey: req.CloudSecretKey,
+	}
+	if req.CredentialMode.IsRole() {
+		var err error
+		secret, err = svc.ad.RoleCredential().AwsSecret(cts.Kit, req.CloudRoleArn, req.CloudExternalID, req.Site)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := svc.ad.Adaptor().Aws(secret, req.CloudAccountID, req.Site)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +105,8 @@ func (svc *service) AwsAccountCheck(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	if infoBySecret.CloudIamUsername != req.CloudIamUsername {
+	// 角色模式下获取到的是角色会话名称，只校验账号ID
+	if !req.CredentialMode.IsRole() && infoBySecret.CloudIamUsername != req.CloudIamUsername {
 		return nil, errf.New(errf.InvalidParameter,
 			"CloudIamUsername does not match the account to which the secret belongs")
 	}
@@ -209,13 +226,22 @@ func (svc *service) AzureAccountCheck(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.Newf(errf.InvalidParameter, err.Error())
 	}
 
-	client, err := svc.ad.Adaptor().Azure(
-		&types.AzureCredential{
-			CloudTenantID:        req.CloudTenantID,
-			CloudSubscriptionID:  req.CloudSubscriptionID,
-			CloudApplicationID:   req.CloudApplicationID,
-			CloudClientSecretKey: req.CloudClientSecretKey,
-		})
+	cred := &types.AzureCredential{
+		CloudTenantID:        req.CloudTenantID,
+		CloudSubscriptionID:  req.CloudSubscriptionID,
+		CloudApplicationID:   req.CloudApplicationID,
+		CloudClientSecretKey: req.CloudClientSecretKey,
+	}
+	if req.CredentialMode.IsRole() {
+		var err error
+		cred, err = svc.ad.RoleCredential().AzureCredential(req.CloudTenantID, req.CloudSubscriptionID,
+			req.CloudApplicationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := svc.ad.Adaptor().Azure(cred)
 	if err != nil {
 		return nil, err
 	}
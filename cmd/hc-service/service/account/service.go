@@ -77,6 +77,10 @@ func InitAccountService(cap *capability.Capability) {
 	h.Add("GetTCloudNetworkAccountType", http.MethodGet, "/vendors/tcloud/accounts/{account_id}/network_type",
 		svc.GetTCloudNetworkAccountType)
 
+	// 清除账号凭证缓存
+	h.Add("InvalidateCredentialCache", http.MethodDelete, "/vendors/{vendor}/accounts/{account_id}/credential_cache",
+		svc.InvalidateCredentialCache)
+
 	initAccountServiceHooks(svc, h)
 
 	h.Load(cap.WebService)
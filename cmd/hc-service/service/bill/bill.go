@@ -59,6 +59,8 @@ func InitBillService(cap *capability.Capability) {
 	h.Add("AwsGetRootAccountBillList", "POST", "/vendors/aws/root_account_bills/list", v.AwsGetRootAccountBillList)
 	h.Add("AzureGetRootAccountBillList", "POST",
 		"/vendors/azure/root_account_bills/list", v.AzureGetRootAccountBillList)
+	h.Add("TCloudGetRootAccountBillList", "POST",
+		"/vendors/tcloud/root_account_bills/list", v.TCloudGetRootAccountBillList)
 	h.Add("AwsGetRootAccountSpTotalUsage", "GET",
 		"/vendors/aws/root_account_bills/sp_usage_total", v.AwsGetRootAccountSpTotalUsage)
 	h.Add("AwsListRootOutsideMonthBill", "GET",
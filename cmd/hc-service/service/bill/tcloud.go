@@ -27,6 +27,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
 )
 
 // TCloudGetBillList get tcloud bill list.
@@ -76,3 +77,50 @@ func (b bill) TCloudGetBillList(cts *rest.Contexts) (interface{}, error) {
 		RequestId: resp.RequestId,
 	}, nil
 }
+
+// TCloudGetRootAccountBillList get tcloud main account bill list by root account.
+func (b bill) TCloudGetRootAccountBillList(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcbillservice.TCloudRootBillListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.Page == nil {
+		req.Page = &core.TCloudPage{Offset: 0, Limit: core.TCloudQueryLimit}
+	}
+
+	cli, err := b.ad.TCloudRoot(cts.Kit, req.RootAccountID)
+	if err != nil {
+		logs.Errorf("tcloud request adaptor client err, err: %+v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt := &typesBill.TCloudBillListOption{
+		AccountID: req.RootAccountID,
+		Month:     req.Month,
+		BeginDate: req.BeginDate,
+		EndDate:   req.EndDate,
+		Page: &core.TCloudPage{
+			Offset: req.Page.Offset,
+			Limit:  req.Page.Limit,
+		},
+		Context:  req.Context,
+		PayerUin: req.MainAccountCloudID,
+	}
+	resp, err := cli.GetBillList(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("tcloud request adaptor list root account bill failed, req: %v, err: %v, rid: %s",
+			req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &hcbillservice.TCloudRootBillListResult{
+		Count:   cvt.PtrToVal(resp.Total),
+		Details: resp.DetailSet,
+		Context: resp.Context,
+	}, nil
+}
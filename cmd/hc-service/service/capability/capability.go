@@ -23,6 +23,7 @@ package capability
 import (
 	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
 	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/service/sync/handler"
 	"hcm/pkg/client"
 
 	"github.com/emicklei/go-restful/v3"
@@ -34,4 +35,5 @@ type Capability struct {
 	ClientSet    *client.ClientSet
 	CloudAdaptor *cloudclient.CloudAdaptorClient
 	ResSyncCli   ressync.Interface
+	Syncer       *handler.Syncer
 }
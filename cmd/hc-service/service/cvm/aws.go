@@ -135,6 +135,16 @@ func (svc *cvmSvc) BatchCreateAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	// aws 创建主机时需指定密钥对名称
+	keyName := ""
+	if len(req.CloudKeyPairID) != 0 {
+		keyPair, err := svc.getKeyPair(cts.Kit, req.AccountID, req.Region, req.CloudKeyPairID)
+		if err != nil {
+			return nil, err
+		}
+		keyName = keyPair.Name
+	}
+
 	createOpt := &typecvm.AwsCreateOption{
 		DryRun:                req.DryRun,
 		Region:                req.Region,
@@ -143,6 +153,7 @@ func (svc *cvmSvc) BatchCreateAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		InstanceType:          req.InstanceType,
 		CloudImageID:          req.CloudImageID,
 		Password:              req.Password,
+		KeyName:               keyName,
 		RequiredCount:         req.RequiredCount,
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		ClientToken:           req.ClientToken,
@@ -119,6 +119,16 @@ func (svc *cvmSvc) createAzureCvm(kt *kit.Kit, azureCli *azure.Azure, req *proto
 		return "", fmt.Errorf("image: %s not found", req.CloudImageID)
 	}
 
+	// azure 创建主机时需直接指定SSH公钥
+	publicKey := ""
+	if len(req.CloudKeyPairID) != 0 {
+		keyPair, err := svc.getKeyPair(kt, req.AccountID, req.Region, req.CloudKeyPairID)
+		if err != nil {
+			return "", err
+		}
+		publicKey = keyPair.PublicKey
+	}
+
 	image := imageResult.Details[0]
 	createOpt := &typecvm.AzureCreateOption{
 		ResourceGroupName: req.ResourceGroupName,
@@ -134,6 +144,7 @@ func (svc *cvmSvc) createAzureCvm(kt *kit.Kit, azureCli *azure.Azure, req *proto
 		},
 		Username:             req.Username,
 		Password:             req.Password,
+		SSHPublicKey:         publicKey,
 		CloudSubnetID:        req.CloudSubnetID,
 		CloudSecurityGroupID: req.CloudSecurityGroupID,
 		OSDisk: &typecvm.AzureOSDisk{
@@ -28,9 +28,11 @@ import (
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/core/cloud"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
 	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -80,6 +82,31 @@ func (svc *cvmSvc) listCvms(kt *kit.Kit, cvmIDs ...string) ([]corecvm.BaseCvm, e
 	return result, nil
 }
 
+// getKeyPair 按云上ID查询账号在地域下的SSH密钥对
+func (svc *cvmSvc) getKeyPair(kt *kit.Kit, accountID, region, cloudKeyPairID string) (*corekeypair.BaseKeyPair,
+	error) {
+
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("cloud_id", cloudKeyPairID),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	resp, err := svc.dataCli.Global.ListKeyPair(kt, req)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, req: %+v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	if len(resp.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", cloudKeyPairID)
+	}
+
+	return resp.Details[0], nil
+}
+
 func (svc *cvmSvc) listSecurityGroupMap(kt *kit.Kit, sgIDs ...string) (map[string]cloud.BaseSecurityGroup, error) {
 	if len(sgIDs) == 0 {
 		return nil, fmt.Errorf("security group ids is empty")
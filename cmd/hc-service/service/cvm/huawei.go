@@ -77,6 +77,7 @@ func (svc *cvmSvc) InquiryPriceHuaWeiCvm(cts *rest.Contexts) (interface{}, error
 		InstanceType:          req.InstanceType,
 		CloudImageID:          req.CloudImageID,
 		Password:              req.Password,
+		KeyName:               req.CloudKeyPairID,
 		RequiredCount:         req.RequiredCount,
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		ClientToken:           req.ClientToken,
@@ -122,6 +123,7 @@ func (svc *cvmSvc) BatchCreateHuaWeiCvm(cts *rest.Contexts) (interface{}, error)
 		InstanceType:          req.InstanceType,
 		CloudImageID:          req.CloudImageID,
 		Password:              req.Password,
+		KeyName:               req.CloudKeyPairID,
 		RequiredCount:         req.RequiredCount,
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		ClientToken:           req.ClientToken,
@@ -149,6 +149,7 @@ func (svc *cvmSvc) InquiryPriceTCloudCvm(cts *rest.Contexts) (interface{}, error
 		InstanceType:            req.InstanceType,
 		CloudImageID:            req.CloudImageID,
 		Password:                req.Password,
+		CloudKeyPairID:          req.CloudKeyPairID,
 		RequiredCount:           req.RequiredCount,
 		CloudSecurityGroupIDs:   req.CloudSecurityGroupIDs,
 		ClientToken:             req.ClientToken,
@@ -196,6 +197,7 @@ func (svc *cvmSvc) BatchCreateTCloudCvm(cts *rest.Contexts) (interface{}, error)
 		InstanceType:            req.InstanceType,
 		CloudImageID:            req.CloudImageID,
 		Password:                req.Password,
+		CloudKeyPairID:          req.CloudKeyPairID,
 		RequiredCount:           req.RequiredCount,
 		CloudSecurityGroupIDs:   req.CloudSecurityGroupIDs,
 		ClientToken:             req.ClientToken,
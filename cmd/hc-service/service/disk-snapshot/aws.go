@@ -0,0 +1,170 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// CreateAwsDiskSnapshot ...
+func (svc *service) CreateAwsDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	diskData, err := svc.DataCli.Aws.RetrieveDisk(cts.Kit.Ctx, cts.Kit.Header(), req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Aws(cts.Kit, diskData.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.AwsSnapshotCreateOption{
+		Region:      diskData.Region,
+		CloudDiskID: diskData.CloudID,
+		Name:        &req.Name,
+		Description: req.Description,
+	}
+	cloudID, err := client.CreateDiskSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create aws disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncaws.NewClient(svc.DataCli, client)
+	params := &syncaws.SyncBaseParams{
+		AccountID: diskData.AccountID,
+		Region:    diskData.Region,
+		CloudIDs:  []string{cloudID},
+	}
+	if _, err = syncClient.DiskSnapshot(cts.Kit, params, new(syncaws.SyncDiskSnapshotOption)); err != nil {
+		logs.Errorf("sync aws disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	id, err := svc.getDiskSnapshotIDByCloudID(cts.Kit, diskData.AccountID, cloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CloudCreateResult{ID: id, CloudID: cloudID}, nil
+}
+
+// DeleteAwsDiskSnapshot ...
+func (svc *service) DeleteAwsDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshot, err := getDiskSnapshot(cts.Kit, svc.DataCli.Aws.ListDiskSnapshot, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Aws(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.AwsSnapshotDeleteOption{Region: snapshot.Region, CloudID: snapshot.CloudID}
+	if err = client.DeleteDiskSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete aws disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteDiskSnapshotFromDB(cts.Kit, req.ID)
+}
+
+// CreateAwsDiskFromSnapshot 基于快照创建新云盘，返回新云盘的ID
+func (svc *service) CreateAwsDiskFromSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskFromSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// aws 快照为地域级资源，需要指定新云盘的可用区
+	if len(req.Zone) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "zone is required")
+	}
+
+	snapshot, err := getDiskSnapshot(cts.Kit, svc.DataCli.Aws.ListDiskSnapshot, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Aws(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.AwsDiskFromSnapshotOption{
+		Region:  snapshot.Region,
+		Zone:    req.Zone,
+		CloudID: snapshot.CloudID,
+	}
+	if len(req.DiskType) != 0 {
+		opt.DiskType = converter.ValToPtr(req.DiskType)
+	}
+	cloudDiskID, err := client.CreateDiskFromSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create aws disk from snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncaws.NewClient(svc.DataCli, client)
+	params := &syncaws.SyncBaseParams{
+		AccountID: snapshot.AccountID,
+		Region:    snapshot.Region,
+		CloudIDs:  []string{cloudDiskID},
+	}
+	if _, err = syncClient.Disk(cts.Kit, params, &syncaws.SyncDiskOption{BootMap: nil}); err != nil {
+		logs.Errorf("sync aws disk failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	diskID, err := svc.getDiskIDByCloudID(cts.Kit, enumor.Aws, snapshot.AccountID, cloudDiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CloudCreateResult{ID: diskID, CloudID: cloudDiskID}, nil
+}
@@ -0,0 +1,173 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	syncazure "hcm/cmd/hc-service/logics/res-sync/azure"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateAzureDiskSnapshot ...
+func (svc *service) CreateAzureDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	diskData, err := svc.DataCli.Azure.RetrieveDisk(cts.Kit.Ctx, cts.Kit.Header(), req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Azure(cts.Kit, diskData.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	resGroupName := diskData.Extension.ResourceGroupName
+	opt := &typesnapshot.AzureSnapshotCreateOption{
+		ResourceGroupName: resGroupName,
+		Region:            diskData.Region,
+		Name:              req.Name,
+		CloudDiskID:       diskData.CloudID,
+	}
+	cloudID, err := client.CreateDiskSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create azure disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncazure.NewClient(svc.DataCli, client)
+	params := &syncazure.SyncBaseParams{
+		AccountID:         diskData.AccountID,
+		ResourceGroupName: resGroupName,
+		CloudIDs:          []string{cloudID},
+	}
+	if _, err = syncClient.DiskSnapshot(cts.Kit, params, new(syncazure.SyncDiskSnapshotOption)); err != nil {
+		logs.Errorf("sync azure disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	id, err := svc.getDiskSnapshotIDByCloudID(cts.Kit, diskData.AccountID, cloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CloudCreateResult{ID: id, CloudID: cloudID}, nil
+}
+
+// DeleteAzureDiskSnapshot ...
+func (svc *service) DeleteAzureDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshot, err := getDiskSnapshot(cts.Kit, svc.DataCli.Azure.ListDiskSnapshot, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Azure(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.AzureSnapshotDeleteOption{
+		ResourceGroupName: snapshot.Extension.ResourceGroupName,
+		Name:              snapshot.Name,
+	}
+	if err = client.DeleteDiskSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete azure disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteDiskSnapshotFromDB(cts.Kit, req.ID)
+}
+
+// CreateAzureDiskFromSnapshot 基于快照创建新云盘，返回新云盘的ID
+func (svc *service) CreateAzureDiskFromSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskFromSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(req.DiskName) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "disk_name is required")
+	}
+
+	snapshot, err := getDiskSnapshot(cts.Kit, svc.DataCli.Azure.ListDiskSnapshot, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Azure(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	resGroupName := snapshot.Extension.ResourceGroupName
+	opt := &typesnapshot.AzureDiskFromSnapshotOption{
+		ResourceGroupName: resGroupName,
+		Region:            snapshot.Region,
+		Zone:              req.Zone,
+		DiskName:          req.DiskName,
+		DiskType:          req.DiskType,
+		CloudID:           snapshot.CloudID,
+	}
+	cloudDiskID, err := client.CreateDiskFromSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create azure disk from snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncazure.NewClient(svc.DataCli, client)
+	params := &syncazure.SyncBaseParams{
+		AccountID:         snapshot.AccountID,
+		ResourceGroupName: resGroupName,
+		CloudIDs:          []string{cloudDiskID},
+	}
+	if _, err = syncClient.Disk(cts.Kit, params, &syncazure.SyncDiskOption{BootMap: nil}); err != nil {
+		logs.Errorf("sync azure disk failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	diskID, err := svc.getDiskIDByCloudID(cts.Kit, enumor.Azure, snapshot.AccountID, cloudDiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CloudCreateResult{ID: diskID, CloudID: cloudDiskID}, nil
+}
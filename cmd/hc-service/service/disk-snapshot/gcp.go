@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	syncgcp "hcm/cmd/hc-service/logics/res-sync/gcp"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// CreateGcpDiskSnapshot ...
+func (svc *service) CreateGcpDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	diskData, err := svc.DataCli.Gcp.RetrieveDisk(cts.Kit, req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Gcp(cts.Kit, diskData.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.GcpSnapshotCreateOption{
+		Zone:        diskData.Zone,
+		DiskName:    diskData.Name,
+		Name:        req.Name,
+		Description: converter.PtrToVal(req.Description),
+	}
+	cloudID, err := client.CreateDiskSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create gcp disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncgcp.NewClient(svc.DataCli, client)
+	params := &syncgcp.SyncBaseParams{
+		AccountID: diskData.AccountID,
+		CloudIDs:  []string{cloudID},
+	}
+	if _, err = syncClient.DiskSnapshot(cts.Kit, params, new(syncgcp.SyncDiskSnapshotOption)); err != nil {
+		logs.Errorf("sync gcp disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	id, err := svc.getDiskSnapshotIDByCloudID(cts.Kit, diskData.AccountID, cloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CloudCreateResult{ID: id, CloudID: cloudID}, nil
+}
+
+// DeleteGcpDiskSnapshot ...
+func (svc *service) DeleteGcpDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshot, err := getDiskSnapshot(cts.Kit, svc.DataCli.Gcp.ListDiskSnapshot, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Gcp(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.GcpSnapshotDeleteOption{Name: snapshot.Name}
+	if err = client.DeleteDiskSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete gcp disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteDiskSnapshotFromDB(cts.Kit, req.ID)
+}
+
+// CreateGcpDiskFromSnapshot 基于快照创建新云盘，返回新云盘的ID
+func (svc *service) CreateGcpDiskFromSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskFromSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(req.DiskName) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "disk_name is required")
+	}
+
+	snapshot, err := getDiskSnapshot(cts.Kit, svc.DataCli.Gcp.ListDiskSnapshot, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 未指定可用区时使用源云盘所在可用区
+	zone := req.Zone
+	if len(zone) == 0 {
+		zone = snapshot.Zone
+	}
+	if len(zone) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "zone is required")
+	}
+
+	client, err := svc.Adaptor.Gcp(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.GcpDiskFromSnapshotOption{
+		Zone:     zone,
+		DiskName: req.DiskName,
+		DiskType: req.DiskType,
+		SelfLink: snapshot.Extension.SelfLink,
+	}
+	cloudDiskID, err := client.CreateDiskFromSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create gcp disk from snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncgcp.NewClient(svc.DataCli, client)
+	params := &syncgcp.SyncBaseParams{
+		AccountID: snapshot.AccountID,
+		CloudIDs:  []string{cloudDiskID},
+	}
+	if _, err = syncClient.Disk(cts.Kit, params, &syncgcp.SyncDiskOption{Zone: zone}); err != nil {
+		logs.Errorf("sync gcp disk failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	diskID, err := svc.getDiskIDByCloudID(cts.Kit, enumor.Gcp, snapshot.AccountID, cloudDiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CloudCreateResult{ID: diskID, CloudID: cloudDiskID}, nil
+}
@@ -0,0 +1,160 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot ...
+package disksnapshot
+
+import (
+	"net/http"
+
+	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitDiskSnapshotService initial the disk snapshot service
+func InitDiskSnapshotService(cap *capability.Capability) {
+	svc := &service{
+		Adaptor: cap.CloudAdaptor,
+		DataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	// 创建快照
+	h.Add("CreateTCloudDiskSnapshot", http.MethodPost, "/vendors/tcloud/disk_snapshots/create",
+		svc.CreateTCloudDiskSnapshot)
+	h.Add("CreateAwsDiskSnapshot", http.MethodPost, "/vendors/aws/disk_snapshots/create", svc.CreateAwsDiskSnapshot)
+	h.Add("CreateHuaWeiDiskSnapshot", http.MethodPost, "/vendors/huawei/disk_snapshots/create",
+		svc.CreateHuaWeiDiskSnapshot)
+	h.Add("CreateAzureDiskSnapshot", http.MethodPost, "/vendors/azure/disk_snapshots/create",
+		svc.CreateAzureDiskSnapshot)
+	h.Add("CreateGcpDiskSnapshot", http.MethodPost, "/vendors/gcp/disk_snapshots/create", svc.CreateGcpDiskSnapshot)
+
+	// 删除快照
+	h.Add("DeleteTCloudDiskSnapshot", http.MethodDelete, "/vendors/tcloud/disk_snapshots", svc.DeleteTCloudDiskSnapshot)
+	h.Add("DeleteAwsDiskSnapshot", http.MethodDelete, "/vendors/aws/disk_snapshots", svc.DeleteAwsDiskSnapshot)
+	h.Add("DeleteHuaWeiDiskSnapshot", http.MethodDelete, "/vendors/huawei/disk_snapshots", svc.DeleteHuaWeiDiskSnapshot)
+	h.Add("DeleteAzureDiskSnapshot", http.MethodDelete, "/vendors/azure/disk_snapshots", svc.DeleteAzureDiskSnapshot)
+	h.Add("DeleteGcpDiskSnapshot", http.MethodDelete, "/vendors/gcp/disk_snapshots", svc.DeleteGcpDiskSnapshot)
+
+	// 回滚快照，aws、azure、gcp 不支持原地回滚，通过基于快照创建新云盘代替
+	h.Add("RollbackTCloudDiskSnapshot", http.MethodPost, "/vendors/tcloud/disk_snapshots/rollback",
+		svc.RollbackTCloudDiskSnapshot)
+	h.Add("RollbackHuaWeiDiskSnapshot", http.MethodPost, "/vendors/huawei/disk_snapshots/rollback",
+		svc.RollbackHuaWeiDiskSnapshot)
+	h.Add("CreateAwsDiskFromSnapshot", http.MethodPost, "/vendors/aws/disk_snapshots/disks/create",
+		svc.CreateAwsDiskFromSnapshot)
+	h.Add("CreateAzureDiskFromSnapshot", http.MethodPost, "/vendors/azure/disk_snapshots/disks/create",
+		svc.CreateAzureDiskFromSnapshot)
+	h.Add("CreateGcpDiskFromSnapshot", http.MethodPost, "/vendors/gcp/disk_snapshots/disks/create",
+		svc.CreateGcpDiskFromSnapshot)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	DataCli *dataservice.Client
+	Adaptor *cloudclient.CloudAdaptorClient
+}
+
+type listDiskSnapshotFunc[T coresnapshot.Extension] func(kt *kit.Kit, req *core.ListReq) (
+	*dataproto.ListExtResult[T], error)
+
+// getDiskSnapshot 根据ID从db查询快照详情
+func getDiskSnapshot[T coresnapshot.Extension](kt *kit.Kit, listFunc listDiskSnapshotFunc[T], id string) (
+	*coresnapshot.DiskSnapshot[T], error) {
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := listFunc(kt, req)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk snapshot: %s not found", id)
+	}
+
+	return result.Details[0], nil
+}
+
+// getDiskSnapshotIDByCloudID 同步后根据云上ID查询快照在db中的ID
+func (svc *service) getDiskSnapshotIDByCloudID(kt *kit.Kit, accountID, cloudID string) (string, error) {
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("cloud_id", cloudID),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.DataCli.Global.ListDiskSnapshot(kt, req)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, cloudID: %s, rid: %s", err, cloudID, kt.Rid)
+		return "", err
+	}
+
+	if len(result.Details) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "disk snapshot: %s not found after sync", cloudID)
+	}
+
+	return result.Details[0].ID, nil
+}
+
+// deleteDiskSnapshotFromDB 云上删除成功后删除db中的快照
+func (svc *service) deleteDiskSnapshotFromDB(kt *kit.Kit, id string) error {
+	req := &dataproto.DiskSnapshotDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err := svc.DataCli.Global.BatchDeleteDiskSnapshot(kt, req); err != nil {
+		logs.Errorf("delete disk snapshot from db failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// getDiskIDByCloudID 基于快照创建的云盘同步后，根据云上ID查询云盘在db中的ID
+func (svc *service) getDiskIDByCloudID(kt *kit.Kit, vendor enumor.Vendor, accountID, cloudID string) (string,
+	error) {
+
+	diskIDMap, err := common.ListDiskIDMapByCloudIDs(kt, svc.DataCli, vendor, accountID, []string{cloudID})
+	if err != nil {
+		return "", err
+	}
+
+	id, exist := diskIDMap[cloudID]
+	if !exist {
+		return "", errf.Newf(errf.RecordNotFound, "disk: %s not found after sync", cloudID)
+	}
+
+	return id, nil
+}
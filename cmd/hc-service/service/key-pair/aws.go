@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	proto "hcm/pkg/api/hc-service/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateAwsKeyPair 创建或导入密钥对
+func (svc *service) CreateAwsKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.KeyPairCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.Adaptor.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(typekeypair.CreateResult)
+	if req.PublicKey != nil {
+		opt := &typekeypair.AwsKeyPairImportOption{Region: req.Region, Name: req.Name, PublicKey: *req.PublicKey}
+		if result.CloudID, err = client.ImportKeyPair(cts.Kit, opt); err != nil {
+			logs.Errorf("import aws key pair failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+			return nil, err
+		}
+	} else {
+		opt := &typekeypair.AwsKeyPairCreateOption{Region: req.Region, Name: req.Name}
+		if result, err = client.CreateKeyPair(cts.Kit, opt); err != nil {
+			logs.Errorf("create aws key pair failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	syncClient := syncaws.NewClient(svc.DataCli, client)
+	params := &syncaws.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  []string{result.CloudID},
+	}
+	if _, err = syncClient.KeyPair(cts.Kit, params, new(syncaws.SyncKeyPairOption)); err != nil {
+		logs.Errorf("sync aws key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	id, err := svc.getKeyPairIDByCloudID(cts.Kit, req.AccountID, req.Region, result.CloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.KeyPairCreateResult{ID: id, CloudID: result.CloudID, PrivateKey: result.PrivateKey}, nil
+}
+
+// DeleteAwsKeyPair ...
+func (svc *service) DeleteAwsKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.KeyPairDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPair, err := getKeyPair(cts.Kit, svc.DataCli.Aws.ListKeyPair, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Aws(cts.Kit, keyPair.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typekeypair.AwsKeyPairDeleteOption{Region: keyPair.Region, CloudID: keyPair.CloudID}
+	if err = client.DeleteKeyPair(cts.Kit, opt); err != nil {
+		logs.Errorf("delete aws key pair failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteKeyPairFromDB(cts.Kit, req.ID)
+}
@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	proto "hcm/pkg/api/hc-service/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateHuaWeiKeyPair 创建或导入密钥对
+func (svc *service) CreateHuaWeiKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.KeyPairCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.Adaptor.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typekeypair.HuaWeiKeyPairCreateOption{Region: req.Region, Name: req.Name, PublicKey: req.PublicKey}
+	result, err := client.CreateKeyPair(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create huawei key pair failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := synchuawei.NewClient(svc.DataCli, client)
+	params := &synchuawei.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  []string{result.CloudID},
+	}
+	if _, err = syncClient.KeyPair(cts.Kit, params, new(synchuawei.SyncKeyPairOption)); err != nil {
+		logs.Errorf("sync huawei key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	id, err := svc.getKeyPairIDByCloudID(cts.Kit, req.AccountID, req.Region, result.CloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.KeyPairCreateResult{ID: id, CloudID: result.CloudID, PrivateKey: result.PrivateKey}, nil
+}
+
+// DeleteHuaWeiKeyPair ...
+func (svc *service) DeleteHuaWeiKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.KeyPairDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPair, err := getKeyPair(cts.Kit, svc.DataCli.HuaWei.ListKeyPair, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.HuaWei(cts.Kit, keyPair.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typekeypair.HuaWeiKeyPairDeleteOption{Region: keyPair.Region, Name: keyPair.CloudID}
+	if err = client.DeleteKeyPair(cts.Kit, opt); err != nil {
+		logs.Errorf("delete huawei key pair failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteKeyPairFromDB(cts.Kit, req.ID)
+}
+
+// AssociateHuaWeiKeyPair 主机绑定密钥对，云上为异步任务，接口返回时任务已提交
+func (svc *service) AssociateHuaWeiKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.associateHuaWeiKeyPair(cts, true)
+}
+
+// DisassociateHuaWeiKeyPair 主机解绑密钥对，云上为异步任务，接口返回时任务已提交
+func (svc *service) DisassociateHuaWeiKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.associateHuaWeiKeyPair(cts, false)
+}
+
+func (svc *service) associateHuaWeiKeyPair(cts *rest.Contexts, associate bool) error {
+	req := new(proto.HuaWeiKeyPairAssociateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPair, err := getKeyPair(cts.Kit, svc.DataCli.HuaWei.ListKeyPair, req.ID)
+	if err != nil {
+		return err
+	}
+
+	cvmData, err := svc.DataCli.HuaWei.Cvm.GetCvm(cts.Kit.Ctx, cts.Kit.Header(), req.CvmID)
+	if err != nil {
+		return err
+	}
+
+	if cvmData.AccountID != keyPair.AccountID || cvmData.Region != keyPair.Region {
+		return errf.New(errf.InvalidParameter, "key pair and cvm should belong to the same account and region")
+	}
+
+	client, err := svc.Adaptor.HuaWei(cts.Kit, keyPair.AccountID)
+	if err != nil {
+		return err
+	}
+
+	if associate {
+		opt := &typekeypair.HuaWeiKeyPairAssociateOption{
+			Region:          keyPair.Region,
+			Name:            keyPair.CloudID,
+			CloudInstanceID: cvmData.CloudID,
+			Auth:            req.Auth,
+		}
+		if err = client.AssociateKeyPair(cts.Kit, opt); err != nil {
+			logs.Errorf("associate huawei key pair failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+			return err
+		}
+
+		return nil
+	}
+
+	opt := &typekeypair.HuaWeiKeyPairDisassociateOption{
+		Region:          keyPair.Region,
+		CloudInstanceID: cvmData.CloudID,
+		Auth:            req.Auth,
+	}
+	if err = client.DisassociateKeyPair(cts.Kit, opt); err != nil {
+		logs.Errorf("disassociate huawei key pair failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair ...
+package keypair
+
+import (
+	"net/http"
+
+	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitKeyPairService initial the key pair service
+func InitKeyPairService(cap *capability.Capability) {
+	svc := &service{
+		Adaptor: cap.CloudAdaptor,
+		DataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	// 创建或导入密钥对
+	h.Add("CreateTCloudKeyPair", http.MethodPost, "/vendors/tcloud/key_pairs/create", svc.CreateTCloudKeyPair)
+	h.Add("CreateAwsKeyPair", http.MethodPost, "/vendors/aws/key_pairs/create", svc.CreateAwsKeyPair)
+	h.Add("CreateHuaWeiKeyPair", http.MethodPost, "/vendors/huawei/key_pairs/create", svc.CreateHuaWeiKeyPair)
+	h.Add("CreateAzureKeyPair", http.MethodPost, "/vendors/azure/key_pairs/create", svc.CreateAzureKeyPair)
+
+	// 删除密钥对
+	h.Add("DeleteTCloudKeyPair", http.MethodDelete, "/vendors/tcloud/key_pairs", svc.DeleteTCloudKeyPair)
+	h.Add("DeleteAwsKeyPair", http.MethodDelete, "/vendors/aws/key_pairs", svc.DeleteAwsKeyPair)
+	h.Add("DeleteHuaWeiKeyPair", http.MethodDelete, "/vendors/huawei/key_pairs", svc.DeleteHuaWeiKeyPair)
+	h.Add("DeleteAzureKeyPair", http.MethodDelete, "/vendors/azure/key_pairs", svc.DeleteAzureKeyPair)
+
+	// 主机绑定/解绑密钥对，aws、azure 不支持对已创建的主机绑定密钥对
+	h.Add("AssociateTCloudKeyPair", http.MethodPost, "/vendors/tcloud/key_pairs/associate",
+		svc.AssociateTCloudKeyPair)
+	h.Add("DisassociateTCloudKeyPair", http.MethodPost, "/vendors/tcloud/key_pairs/disassociate",
+		svc.DisassociateTCloudKeyPair)
+	h.Add("AssociateHuaWeiKeyPair", http.MethodPost, "/vendors/huawei/key_pairs/associate",
+		svc.AssociateHuaWeiKeyPair)
+	h.Add("DisassociateHuaWeiKeyPair", http.MethodPost, "/vendors/huawei/key_pairs/disassociate",
+		svc.DisassociateHuaWeiKeyPair)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	DataCli *dataservice.Client
+	Adaptor *cloudclient.CloudAdaptorClient
+}
+
+type listKeyPairFunc[T corekeypair.Extension] func(kt *kit.Kit, req *core.ListReq) (
+	*dataproto.ListExtResult[T], error)
+
+// getKeyPair 根据ID从db查询密钥对详情
+func getKeyPair[T corekeypair.Extension](kt *kit.Kit, listFunc listKeyPairFunc[T], id string) (
+	*corekeypair.KeyPair[T], error) {
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := listFunc(kt, req)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", id)
+	}
+
+	return result.Details[0], nil
+}
+
+// getKeyPairIDByCloudID 同步后根据云上ID查询密钥对在db中的ID，华为云以名称作为云上ID，需同时按地域查询
+func (svc *service) getKeyPairIDByCloudID(kt *kit.Kit, accountID, region, cloudID string) (string, error) {
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("cloud_id", cloudID),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.DataCli.Global.ListKeyPair(kt, req)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, cloudID: %s, rid: %s", err, cloudID, kt.Rid)
+		return "", err
+	}
+
+	if len(result.Details) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "key pair: %s not found after sync", cloudID)
+	}
+
+	return result.Details[0].ID, nil
+}
+
+// deleteKeyPairFromDB 云上删除成功后删除db中的密钥对
+func (svc *service) deleteKeyPairFromDB(kt *kit.Kit, id string) error {
+	req := &dataproto.KeyPairDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err := svc.DataCli.Global.BatchDeleteKeyPair(kt, req); err != nil {
+		logs.Errorf("delete key pair from db failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	proto "hcm/pkg/api/hc-service/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateTCloudKeyPair 创建或导入密钥对
+func (svc *service) CreateTCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.KeyPairCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.Adaptor.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(typekeypair.CreateResult)
+	if req.PublicKey != nil {
+		opt := &typekeypair.TCloudKeyPairImportOption{
+			Region:    req.Region,
+			Name:      req.Name,
+			PublicKey: *req.PublicKey,
+			ProjectID: req.ProjectID,
+		}
+		if result.CloudID, err = client.ImportKeyPair(cts.Kit, opt); err != nil {
+			logs.Errorf("import tcloud key pair failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+			return nil, err
+		}
+	} else {
+		opt := &typekeypair.TCloudKeyPairCreateOption{Region: req.Region, Name: req.Name, ProjectID: req.ProjectID}
+		if result, err = client.CreateKeyPair(cts.Kit, opt); err != nil {
+			logs.Errorf("create tcloud key pair failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	syncClient := synctcloud.NewClient(svc.DataCli, client)
+	params := &synctcloud.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  []string{result.CloudID},
+	}
+	if _, err = syncClient.KeyPair(cts.Kit, params, new(synctcloud.SyncKeyPairOption)); err != nil {
+		logs.Errorf("sync tcloud key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	id, err := svc.getKeyPairIDByCloudID(cts.Kit, req.AccountID, req.Region, result.CloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.KeyPairCreateResult{ID: id, CloudID: result.CloudID, PrivateKey: result.PrivateKey}, nil
+}
+
+// DeleteTCloudKeyPair ...
+func (svc *service) DeleteTCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.KeyPairDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPair, err := getKeyPair(cts.Kit, svc.DataCli.TCloud.ListKeyPair, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.TCloud(cts.Kit, keyPair.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typekeypair.TCloudKeyPairDeleteOption{Region: keyPair.Region, CloudIDs: []string{keyPair.CloudID}}
+	if err = client.DeleteKeyPair(cts.Kit, opt); err != nil {
+		logs.Errorf("delete tcloud key pair failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteKeyPairFromDB(cts.Kit, req.ID)
+}
+
+// AssociateTCloudKeyPair 主机绑定密钥对
+func (svc *service) AssociateTCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.associateTCloudKeyPair(cts, true)
+}
+
+// DisassociateTCloudKeyPair 主机解绑密钥对
+func (svc *service) DisassociateTCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.associateTCloudKeyPair(cts, false)
+}
+
+func (svc *service) associateTCloudKeyPair(cts *rest.Contexts, associate bool) error {
+	req := new(proto.TCloudKeyPairAssociateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPair, err := getKeyPair(cts.Kit, svc.DataCli.TCloud.ListKeyPair, req.ID)
+	if err != nil {
+		return err
+	}
+
+	cvmData, err := svc.DataCli.TCloud.Cvm.GetCvm(cts.Kit.Ctx, cts.Kit.Header(), req.CvmID)
+	if err != nil {
+		return err
+	}
+
+	if cvmData.AccountID != keyPair.AccountID || cvmData.Region != keyPair.Region {
+		return errf.New(errf.InvalidParameter, "key pair and cvm should belong to the same account and region")
+	}
+
+	client, err := svc.Adaptor.TCloud(cts.Kit, keyPair.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typekeypair.TCloudKeyPairAssociateOption{
+		Region:           keyPair.Region,
+		CloudIDs:         []string{keyPair.CloudID},
+		CloudInstanceIDs: []string{cvmData.CloudID},
+		ForceStop:        req.ForceStop,
+	}
+	if associate {
+		err = client.AssociateKeyPair(cts.Kit, opt)
+	} else {
+		err = client.DisassociateKeyPair(cts.Kit, opt)
+	}
+	if err != nil {
+		logs.Errorf("associate or disassociate tcloud key pair failed, err: %v, associate: %v, opt: %v, rid: %s",
+			err, associate, opt, cts.Kit.Rid)
+		return err
+	}
+
+	return svc.syncTCloudKeyPair(cts.Kit, keyPair.AccountID, keyPair.Region, keyPair.CloudID)
+}
+
+// syncTCloudKeyPair 绑定关系变更后同步密钥对，刷新密钥对已绑定的实例
+func (svc *service) syncTCloudKeyPair(kt *kit.Kit, accountID, region, cloudID string) error {
+	client, err := svc.Adaptor.TCloud(kt, accountID)
+	if err != nil {
+		return err
+	}
+
+	syncClient := synctcloud.NewClient(svc.DataCli, client)
+	params := &synctcloud.SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: []string{cloudID}}
+	if _, err = syncClient.KeyPair(kt, params, new(synctcloud.SyncKeyPairOption)); err != nil {
+		logs.Errorf("sync tcloud key pair failed, err: %v, cloudID: %s, rid: %s", err, cloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package restag resource tag hc service.
+package restag
+
+import (
+	"fmt"
+	"net/http"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/adaptor/types/resource-tag"
+	corerestag "hcm/pkg/api/core/cloud/resource-tag"
+	dsrestag "hcm/pkg/api/data-service/cloud/resource-tag"
+	hcrestag "hcm/pkg/api/hc-service/resource-tag"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitResourceTagService initial resource tag service.
+func InitResourceTagService(cap *capability.Capability) {
+	svc := &resTagSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchAddResourceTag", http.MethodPost, "/vendors/{vendor}/resource_tags/batch/add", svc.BatchAddResourceTag)
+	h.Add("BatchRemoveResourceTag", http.MethodPost, "/vendors/{vendor}/resource_tags/batch/remove",
+		svc.BatchRemoveResourceTag)
+
+	h.Load(cap.WebService)
+}
+
+type resTagSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
+
+// tagClient 云资源标签操作接口，各云厂商adaptor均已实现
+type tagClient interface {
+	TagResources(kt *kit.Kit, opt *resourcetag.TagResOption) error
+	UnTagResources(kt *kit.Kit, opt *resourcetag.UnTagResOption) error
+}
+
+func (svc *resTagSvc) tagClient(kt *kit.Kit, vendor enumor.Vendor, accountID string) (tagClient, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return svc.ad.TCloud(kt, accountID)
+	case enumor.Aws:
+		return svc.ad.Aws(kt, accountID)
+	case enumor.HuaWei:
+		return svc.ad.HuaWei(kt, accountID)
+	case enumor.Gcp:
+		return svc.ad.Gcp(kt, accountID)
+	case enumor.Azure:
+		return svc.ad.Azure(kt, accountID)
+	default:
+		return nil, fmt.Errorf("vendor: %s not support resource tag", vendor)
+	}
+}
+
+func parseVendor(cts *rest.Contexts, resType enumor.CloudResourceType) (enumor.Vendor, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return "", err
+	}
+
+	if err := corerestag.ValidateVendorResType(vendor, resType); err != nil {
+		return "", err
+	}
+
+	return vendor, nil
+}
+
+// BatchAddResourceTag add or update tags of cloud resources, and then save them to db.
+func (svc *resTagSvc) BatchAddResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcrestag.BatchAddReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	vendor, err := parseVendor(cts, req.ResType)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := svc.tagClient(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &resourcetag.TagResOption{
+		Region:    req.Region,
+		ResType:   req.ResType,
+		Resources: hcrestag.ToCloudResources(req.Resources),
+		Tags:      req.Tags,
+	}
+	if err = cli.TagResources(cts.Kit, opt); err != nil {
+		logs.Errorf("[%s] tag cloud resources failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	upsertReq := &dsrestag.BatchUpsertReq{
+		ResType: req.ResType,
+		ResIDs:  hcrestag.IDs(req.Resources),
+		Tags:    req.Tags,
+	}
+	if err = svc.dataCli.Global.ResourceTag.BatchUpsert(cts.Kit, upsertReq); err != nil {
+		logs.Errorf("upsert resource tags to db failed, err: %v, req: %+v, rid: %s", err, upsertReq, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchRemoveResourceTag remove tags of cloud resources, and then remove them from db.
+func (svc *resTagSvc) BatchRemoveResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcrestag.BatchRemoveReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	vendor, err := parseVendor(cts, req.ResType)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := svc.tagClient(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &resourcetag.UnTagResOption{
+		Region:    req.Region,
+		ResType:   req.ResType,
+		Resources: hcrestag.ToCloudResources(req.Resources),
+		TagKeys:   req.TagKeys,
+	}
+	if err = cli.UnTagResources(cts.Kit, opt); err != nil {
+		logs.Errorf("[%s] untag cloud resources failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	removeReq := &dsrestag.BatchRemoveReq{
+		ResType: req.ResType,
+		ResIDs:  hcrestag.IDs(req.Resources),
+		TagKeys: req.TagKeys,
+	}
+	if err = svc.dataCli.Global.ResourceTag.BatchRemove(cts.Kit, removeReq); err != nil {
+		logs.Errorf("remove resource tags from db failed, err: %v, req: %+v, rid: %s", err, removeReq, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
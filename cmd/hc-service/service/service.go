@@ -38,16 +38,20 @@ import (
 	"hcm/cmd/hc-service/service/cert"
 	"hcm/cmd/hc-service/service/cvm"
 	"hcm/cmd/hc-service/service/disk"
+	disksnapshot "hcm/cmd/hc-service/service/disk-snapshot"
 	"hcm/cmd/hc-service/service/eip"
 	"hcm/cmd/hc-service/service/firewall"
 	"hcm/cmd/hc-service/service/image"
 	instancetype "hcm/cmd/hc-service/service/instance-type"
+	keypair "hcm/cmd/hc-service/service/key-pair"
 	loadbalancer "hcm/cmd/hc-service/service/load-balancer"
 	mainaccount "hcm/cmd/hc-service/service/main-account"
+	restag "hcm/cmd/hc-service/service/resource-tag"
 	routetable "hcm/cmd/hc-service/service/route-table"
 	securitygroup "hcm/cmd/hc-service/service/security-group"
 	"hcm/cmd/hc-service/service/subnet"
 	"hcm/cmd/hc-service/service/sync"
+	synchandler "hcm/cmd/hc-service/service/sync/handler"
 	"hcm/cmd/hc-service/service/vpc"
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
@@ -161,6 +165,7 @@ func (s *Service) apiSet() *restful.Container {
 		ClientSet:    s.clientSet,
 		CloudAdaptor: s.cloudAdaptor,
 		ResSyncCli:   ressync.NewClient(s.cloudAdaptor, s.clientSet.DataService()),
+		Syncer:       synchandler.NewSyncer(s.clientSet.DataService()),
 	}
 
 	account.InitAccountService(c)
@@ -169,6 +174,8 @@ func (s *Service) apiSet() *restful.Container {
 	vpc.InitVpcService(c)
 	subnet.InitSubnetService(c)
 	disk.InitDiskService(c)
+	disksnapshot.InitDiskSnapshotService(c)
+	keypair.InitKeyPairService(c)
 	cvm.InitCvmService(c)
 	routetable.InitRouteTableService(c)
 	eip.InitEipService(c)
@@ -181,6 +188,7 @@ func (s *Service) apiSet() *restful.Container {
 	bwpkg.InitBwPkgService(c)
 	mainaccount.InitService(c)
 	image.InitImageService(c)
+	restag.InitResourceTagService(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
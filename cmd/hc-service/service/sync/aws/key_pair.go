@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/aws"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncKeyPair ....
+func (svc *service) SyncKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.syncer.ResourceSync(cts, &keyPairHandler{cli: svc.syncCli})
+}
+
+// keyPairHandler key pair sync handler.
+type keyPairHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.AwsSyncReq
+	syncCli aws.Interface
+	// listed 云上密钥对接口一次返回地域下的全部密钥对，查询过一次后不再查询
+	listed bool
+}
+
+var _ handler.Handler = new(keyPairHandler)
+
+// Prepare ...
+func (hd *keyPairHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *keyPairHandler) Next(kt *kit.Kit) ([]string, error) {
+	if hd.listed {
+		return nil, nil
+	}
+
+	listOpt := &typekeypair.AwsKeyPairListOption{
+		Region: hd.request.Region,
+	}
+	result, err := hd.syncCli.CloudCli().ListKeyPair(kt, listOpt)
+	if err != nil {
+		logs.Errorf("request adaptor list aws key pair failed, err: %v, opt: %v, rid: %s", err, listOpt,
+			kt.Rid)
+		return nil, err
+	}
+	hd.listed = true
+
+	cloudIDs := make([]string, 0, len(result))
+	for _, one := range result {
+		cloudIDs = append(cloudIDs, one.GetCloudID())
+	}
+
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *keyPairHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	for _, partCloudIDs := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+		params := &aws.SyncBaseParams{
+			AccountID: hd.request.AccountID,
+			Region:    hd.request.Region,
+			CloudIDs:  partCloudIDs,
+		}
+		if _, err := hd.syncCli.KeyPair(kt, params, new(aws.SyncKeyPairOption)); err != nil {
+			logs.Errorf("sync aws key pair failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *keyPairHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemoveKeyPairDeleteFromCloud(kt, hd.request.AccountID, hd.request.Region)
+	if err != nil {
+		logs.Errorf("remove key pair delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			err, hd.request.AccountID, hd.request.Region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *keyPairHandler) Name() enumor.CloudResourceType {
+	return enumor.KeyPairCloudResType
+}
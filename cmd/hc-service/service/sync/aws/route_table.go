@@ -21,11 +21,10 @@ package aws
 
 import (
 	logicsrt "hcm/cmd/hc-service/logics/route-table"
-	"hcm/cmd/hc-service/service/sync/handler"
 	"hcm/pkg/rest"
 )
 
 // SyncRouteTable ....
 func (svc *service) SyncRouteTable(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &logicsrt.AwsRouteTableHandler{Cli: svc.syncCli})
+	return svc.syncer.ResourceSync(cts, &logicsrt.AwsRouteTableHandler{Cli: svc.syncCli})
 }
@@ -40,7 +40,7 @@ import (
 
 // SyncNetworkInterface ....
 func (svc *service) SyncNetworkInterface(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &networkInterfaceHandler{cli: svc.syncCli})
+	return svc.syncer.ResourceSync(cts, &networkInterfaceHandler{cli: svc.syncCli})
 }
 
 // networkInterfaceHandler networkInterface sync handler.
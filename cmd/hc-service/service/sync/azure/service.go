@@ -23,6 +23,7 @@ import (
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/cmd/hc-service/service/sync/handler"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
@@ -35,6 +36,7 @@ func InitService(cap *capability.Capability) {
 		cs:      cap.ClientSet,
 		dataCli: cap.ClientSet.DataService(),
 		syncCli: cap.ResSyncCli,
+		syncer:  cap.Syncer,
 	}
 
 	h := rest.NewHandler()
@@ -44,6 +46,8 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncSubnet", "POST", "/subnets/sync", v.SyncSubnet)
 	h.Add("SyncEip", "POST", "/eips/sync", v.SyncEip)
 	h.Add("SyncDisk", "POST", "/disks/sync", v.SyncDisk)
+	h.Add("SyncDiskSnapshot", "POST", "/disk_snapshots/sync", v.SyncDiskSnapshot)
+	h.Add("SyncKeyPair", "POST", "/key_pairs/sync", v.SyncKeyPair)
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncSecurityGroup", "POST", "/security_groups/sync", v.SyncSecurityGroup)
 	h.Add("SyncNetworkInterface", "POST", "/network_interfaces/sync", v.SyncNetworkInterface)
@@ -53,6 +57,8 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncImage", "POST", "/images/sync", v.SyncImage)
 	h.Add("SyncSubAccount", "POST", "/sub_accounts/sync", v.SyncSubAccount)
 
+	h.Add("IncrementalSync", "POST", "/resources/incremental/sync", v.IncrementalSync)
+
 	h.Load(cap.WebService)
 }
 
@@ -61,4 +67,5 @@ type service struct {
 	cs      *client.ClientSet
 	dataCli *dataservice.Client
 	syncCli ressync.Interface
+	syncer  *handler.Syncer
 }
@@ -38,7 +38,7 @@ import (
 
 // SyncFirewallRule ....
 func (svc *service) SyncFirewallRule(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &firewallHandler{cli: svc.syncCli})
+	return svc.syncer.ResourceSync(cts, &firewallHandler{cli: svc.syncCli})
 }
 
 // firewallHandler firewall sync handler.
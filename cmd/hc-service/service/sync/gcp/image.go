@@ -38,16 +38,18 @@ import (
 // SyncImage ....
 func (svc *service) SyncImage(cts *rest.Contexts) (interface{}, error) {
 	imageHandler := &imageHandler{cli: svc.syncCli}
+	var plan *sync.SyncPlanResult
 	for index, projectID := range adaptorgcp.PublicImagePlatforms {
 		imageHandler.index = index
 		imageHandler.projectID = projectID
-		err := handler.ResourceSync(cts, imageHandler)
+		imagePlan, err := svc.syncer.ResourceSync(cts, imageHandler)
 		if err != nil {
 			return nil, err
 		}
+		plan = handler.MergeSyncPlan(plan, imagePlan)
 	}
 
-	return nil, nil
+	return plan, nil
 }
 
 // imageHandler image sync handler.
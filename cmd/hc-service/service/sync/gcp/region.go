@@ -35,7 +35,7 @@ import (
 
 // SyncRegion ....
 func (svc *service) SyncRegion(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &regionHandler{cli: svc.syncCli})
+	return svc.syncer.ResourceSync(cts, &regionHandler{cli: svc.syncCli})
 }
 
 // regionHandler region sync handler.
@@ -23,6 +23,7 @@ import (
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/cmd/hc-service/service/sync/handler"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
@@ -35,6 +36,7 @@ func InitService(cap *capability.Capability) {
 		cs:      cap.ClientSet,
 		dataCli: cap.ClientSet.DataService(),
 		syncCli: cap.ResSyncCli,
+		syncer:  cap.Syncer,
 	}
 
 	h := rest.NewHandler()
@@ -43,6 +45,7 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncVpc", "POST", "/vpcs/sync", v.SyncVpc)
 	h.Add("SyncSubnet", "POST", "/subnets/sync", v.SyncSubnet)
 	h.Add("SyncDisk", "POST", "/disks/sync", v.SyncDisk)
+	h.Add("SyncDiskSnapshot", "POST", "/disk_snapshots/sync", v.SyncDiskSnapshot)
 	h.Add("SyncFirewallRule", "POST", "/firewalls/rules/sync", v.SyncFirewallRule)
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncEip", "POST", "/eips/sync", v.SyncEip)
@@ -60,4 +63,5 @@ type service struct {
 	cs      *client.ClientSet
 	dataCli *dataservice.Client
 	syncCli ressync.Interface
+	syncer  *handler.Syncer
 }
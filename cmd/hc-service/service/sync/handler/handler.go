@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"hcm/cmd/hc-service/logics/res-sync/common"
+	hcsync "hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
@@ -49,8 +50,18 @@ type Handler interface {
 	Name() enumor.CloudResourceType
 }
 
-// ResourceSync 资源同步流程。
-func ResourceSync(cts *rest.Contexts, handler Handler) error {
+// ResourceSync 资源同步流程，并记录同步运行记录。预览及删除检查模式下不写入数据，返回同步计划，否则返回nil。
+func (s *Syncer) ResourceSync(cts *rest.Contexts, handler Handler) (*hcsync.SyncPlanResult, error) {
+	run := s.startSyncRun(cts, handler.Name())
+	err := resourceSync(cts, handler, run.option)
+	run.finish(cts.Kit, err)
+	if err != nil {
+		return nil, err
+	}
+	return run.plan(), nil
+}
+
+func resourceSync(cts *rest.Contexts, handler Handler, opt hcsync.SyncDeleteOption) error {
 	kt := cts.Kit
 
 	// 解析请求参数到handler实现中，构建同步需要的客户端
@@ -64,6 +75,11 @@ func ResourceSync(cts *rest.Contexts, handler Handler) error {
 		return err
 	}
 
+	// 删除检查模式只需要删除对比的统计
+	if opt.DeleteCheck {
+		return nil
+	}
+
 	for {
 		cloudIDs, err := handler.Next(kt)
 		if err != nil {
@@ -118,7 +134,21 @@ const (
 )
 
 // ResourceSyncV2 资源同步，包含三个流程：1. 准备请求 2. 获取云上实例列表 3. 清理云上已删除实例 4. 同步实例详情
-func ResourceSyncV2[T common.CloudResType](cts *rest.Contexts, handler HandlerV2[T]) error {
+// 预览及删除检查模式下不写入数据，返回同步计划，否则返回nil。
+func ResourceSyncV2[T common.CloudResType](cts *rest.Contexts, s *Syncer, handler HandlerV2[T]) (
+	*hcsync.SyncPlanResult, error) {
+
+	run := s.startSyncRun(cts, handler.Resource())
+	err := resourceSyncV2(cts, handler, run.option)
+	run.finish(cts.Kit, err)
+	if err != nil {
+		return nil, err
+	}
+	return run.plan(), nil
+}
+
+func resourceSyncV2[T common.CloudResType](cts *rest.Contexts, handler HandlerV2[T],
+	opt hcsync.SyncDeleteOption) error {
 
 	kt := cts.Kit
 
@@ -171,6 +201,10 @@ func ResourceSyncV2[T common.CloudResType](cts *rest.Contexts, handler HandlerV2
 		return err
 	}
 	logs.Infof("[ResourceSyncV2] %s remove deleted done, rid: %s", handler.Describe(), kt.Rid)
+	// 删除检查模式只需要删除对比的统计
+	if opt.DeleteCheck {
+		return nil
+	}
 
 	// 4. 同步实例详情
 	success, failed, errs := syncResourcesDetail(kt, handler, total, allInstanceList)
@@ -21,6 +21,8 @@ package handler
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -30,6 +32,8 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
 )
 
 type TestHandler struct {
@@ -125,7 +129,7 @@ func TestResourceSyncV2(t *testing.T) {
 	}
 	cts := &rest.Contexts{Kit: kit.New()}
 	t.Run(th.TestName(), func(t *testing.T) {
-		if err := ResourceSyncV2[common.TestCloudRes](cts, th); err != nil {
+		if _, err := ResourceSyncV2[common.TestCloudRes](cts, NewSyncer(nil), th); err != nil {
 			t.Errorf("ResourceSyncV2() error = %v, handler: %s", err, th)
 		}
 		if th.WaitSyncCount() != 0 {
@@ -143,7 +147,7 @@ func TestResourceSyncV2(t *testing.T) {
 				}
 				cts := &rest.Contexts{Kit: kit.New()}
 				t.Run(th.TestName(), func(t *testing.T) {
-					if err := ResourceSyncV2[common.TestCloudRes](cts, th); err != nil {
+					if _, err := ResourceSyncV2[common.TestCloudRes](cts, NewSyncer(nil), th); err != nil {
 						t.Errorf("ResourceSyncV2() error = %v, handler: %s", err, th)
 					}
 					if th.WaitSyncCount() != 0 {
@@ -154,3 +158,39 @@ func TestResourceSyncV2(t *testing.T) {
 		}
 	}
 }
+
+// deleteCheckHandler 删除对比时发现一个云上已删除资源的测试handler
+type deleteCheckHandler struct {
+	*TestHandler
+}
+
+func (h *deleteCheckHandler) RemoveDeletedFromCloud(kt *kit.Kit, allCloudIDMap map[string]struct{}) error {
+	common.RecordScanned(kt, len(allCloudIDMap)+1)
+	if common.PlanDelete(kt, []string{"deleted-from-cloud"}) {
+		return fmt.Errorf("delete should not be executed in delete check")
+	}
+	return nil
+}
+
+func TestResourceSyncV2DeleteCheck(t *testing.T) {
+	body := `{"account_id":"00000001","region":"ap-guangzhou","delete_check":true}`
+	httpReq, err := http.NewRequest(http.MethodPost, "/api/v1/hc/vendors/tcloud/test_res/sync",
+		strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cts := &rest.Contexts{Kit: kit.New(), Request: restful.NewRequest(httpReq)}
+
+	th := &deleteCheckHandler{TestHandler: &TestHandler{total: 20, listBatchSize: 200}}
+	plan, err := ResourceSyncV2[common.TestCloudRes](cts, NewSyncer(nil), th)
+	if err != nil {
+		t.Fatalf("delete check failed, err: %v", err)
+	}
+	if plan == nil || plan.Scanned != 21 || plan.Deleted != 1 {
+		t.Errorf("delete check plan not match, got: %+v", plan)
+	}
+	// 删除检查模式下不同步实例详情
+	if th.WaitSyncCount() != th.total {
+		t.Errorf("instances should not be synced in delete check, handler: %s", th)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handler
+
+import (
+	"sort"
+
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// IncrementalSyncFunc 同步指定范围内的变更资源，scope 为资源所在地域，Azure 为资源组
+type IncrementalSyncFunc func(kt *kit.Kit, resType enumor.CloudResourceType, scope string, cloudIDs []string) error
+
+// ScopeFunc 获取变更事件所属的同步范围
+type ScopeFunc func(event resourceevent.ChangeEvent) string
+
+// RegionScope 按地域同步
+func RegionScope(event resourceevent.ChangeEvent) string {
+	return event.Region
+}
+
+// ResourceGroupScope 按资源组同步
+func ResourceGroupScope(event resourceevent.ChangeEvent) string {
+	return event.ResourceGroupName
+}
+
+type incrementalKey struct {
+	resType enumor.CloudResourceType
+	scope   string
+}
+
+// IncrementalSync 将变更事件按资源类型和同步范围分组去重后分批同步，单批失败不影响其他批次，失败的资源由周期全量同步兜底。
+func IncrementalSync(kt *kit.Kit, events []resourceevent.ChangeEvent, scopeOf ScopeFunc,
+	syncFn IncrementalSyncFunc) *sync.IncrementalSyncResult {
+
+	result := &sync.IncrementalSyncResult{
+		EventCount: len(events),
+		Synced:     make(map[enumor.CloudResourceType]int),
+		Failed:     make(map[enumor.CloudResourceType]int),
+	}
+
+	group := make(map[incrementalKey]map[string]struct{})
+	for _, one := range events {
+		key := incrementalKey{resType: one.ResType, scope: scopeOf(one)}
+		if len(key.scope) == 0 || len(one.CloudID) == 0 {
+			continue
+		}
+		if _, exist := group[key]; !exist {
+			group[key] = make(map[string]struct{})
+		}
+		group[key][one.CloudID] = struct{}{}
+	}
+
+	for key, idMap := range group {
+		cloudIDs := make([]string, 0, len(idMap))
+		for id := range idMap {
+			cloudIDs = append(cloudIDs, id)
+		}
+		sort.Strings(cloudIDs)
+
+		for _, batch := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+			if err := syncFn(kt, key.resType, key.scope, batch); err != nil {
+				logs.Errorf("incremental sync %s failed, err: %v, scope: %s, cloudIDs: %v, rid: %s", key.resType,
+					err, key.scope, batch, kt.Rid)
+				result.Failed[key.resType] += len(batch)
+				continue
+			}
+			result.Synced[key.resType] += len(batch)
+		}
+	}
+
+	return result
+}
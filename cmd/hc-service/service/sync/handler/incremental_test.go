@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func TestIncrementalSync(t *testing.T) {
+	events := []resourceevent.ChangeEvent{
+		{ResType: enumor.CvmCloudResType, CloudID: "ins-1", Region: "ap-guangzhou"},
+		{ResType: enumor.CvmCloudResType, CloudID: "ins-1", Region: "ap-guangzhou"},
+		{ResType: enumor.CvmCloudResType, CloudID: "ins-2", Region: "ap-shanghai"},
+		{ResType: enumor.SecurityGroupCloudResType, CloudID: "sg-1", Region: "ap-guangzhou"},
+		{ResType: enumor.DiskCloudResType, CloudID: "disk-1"},
+	}
+	for i := 0; i < constant.CloudResourceSyncMaxLimit+1; i++ {
+		events = append(events, resourceevent.ChangeEvent{ResType: enumor.EipCloudResType,
+			CloudID: fmt.Sprintf("eip-%d", i), Region: "ap-guangzhou"})
+	}
+
+	calls := make(map[string]int)
+	syncFn := func(kt *kit.Kit, resType enumor.CloudResourceType, scope string, cloudIDs []string) error {
+		calls[string(resType)+"/"+scope] += len(cloudIDs)
+		if len(cloudIDs) > constant.CloudResourceSyncMaxLimit {
+			t.Errorf("batch size %d exceeds limit", len(cloudIDs))
+		}
+		if resType == enumor.SecurityGroupCloudResType {
+			return errors.New("mock failure")
+		}
+		return nil
+	}
+
+	result := IncrementalSync(kit.New(), events, RegionScope, syncFn)
+
+	if calls["cvm/ap-guangzhou"] != 1 || calls["cvm/ap-shanghai"] != 1 {
+		t.Errorf("cvm should be deduplicated and grouped by region, got: %v", calls)
+	}
+	if _, exist := calls["disk/"]; exist {
+		t.Errorf("event without region should be skipped, got: %v", calls)
+	}
+	if result.Synced[enumor.CvmCloudResType] != 2 || result.Synced[enumor.EipCloudResType] != constant.CloudResourceSyncMaxLimit+1 {
+		t.Errorf("unexpected synced result: %v", result.Synced)
+	}
+	if result.Failed[enumor.SecurityGroupCloudResType] != 1 {
+		t.Errorf("unexpected failed result: %v", result.Failed)
+	}
+}
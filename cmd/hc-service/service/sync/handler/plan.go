@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handler
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	hcsync "hcm/pkg/api/hc-service/sync"
+)
+
+// MergeSyncPlan 合并同一请求中多次同步的预览结果
+func MergeSyncPlan(dst, src *hcsync.SyncPlanResult) *hcsync.SyncPlanResult {
+	if dst == nil {
+		return src
+	}
+	if src == nil {
+		return dst
+	}
+
+	dst.Scanned += src.Scanned
+	dst.Added += src.Added
+	dst.Updated += src.Updated
+	dst.Deleted += src.Deleted
+	dst.AddCloudIDs, dst.Truncated = appendPlanCloudIDs(dst.AddCloudIDs, src.AddCloudIDs, dst.Truncated)
+	dst.UpdateCloudIDs, dst.Truncated = appendPlanCloudIDs(dst.UpdateCloudIDs, src.UpdateCloudIDs, dst.Truncated)
+	dst.DeleteCloudIDs, dst.Truncated = appendPlanCloudIDs(dst.DeleteCloudIDs, src.DeleteCloudIDs, dst.Truncated)
+	dst.Truncated = dst.Truncated || src.Truncated
+	return dst
+}
+
+func appendPlanCloudIDs(dst, src []string, truncated bool) ([]string, bool) {
+	left := common.MaxPlanCloudIDs - len(dst)
+	if len(src) > left {
+		return append(dst, src[:left]...), true
+	}
+
+	return append(dst, src...), truncated
+}
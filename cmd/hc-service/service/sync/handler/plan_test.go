@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handler
+
+import (
+	"testing"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/api/hc-service/sync"
+)
+
+func TestMergeSyncPlan(t *testing.T) {
+	dst := &sync.SyncPlanResult{Added: 1, AddCloudIDs: make([]string, common.MaxPlanCloudIDs-1)}
+	src := &sync.SyncPlanResult{Added: 2, Deleted: 1, AddCloudIDs: []string{"a", "b"}, DeleteCloudIDs: []string{"c"}}
+
+	merged := MergeSyncPlan(dst, src)
+	if merged.Added != 3 || merged.Deleted != 1 {
+		t.Errorf("plan count not match, got: %+v", merged)
+	}
+	if len(merged.AddCloudIDs) != common.MaxPlanCloudIDs || !merged.Truncated {
+		t.Errorf("add cloud ids should be truncated, len: %d, truncated: %v", len(merged.AddCloudIDs),
+			merged.Truncated)
+	}
+	if len(merged.DeleteCloudIDs) != 1 {
+		t.Errorf("delete cloud ids not match, got: %v", merged.DeleteCloudIDs)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	dssync "hcm/pkg/api/data-service/cloud/sync"
+	hcsync "hcm/pkg/api/hc-service/sync"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/metrics"
+	"hcm/pkg/rest"
+)
+
+// Syncer 资源同步执行器，执行同步流程并保存同步运行记录
+type Syncer struct {
+	// runRecordCli 用于保存同步运行记录，为nil时只上报指标
+	runRecordCli *dataservice.Client
+}
+
+// NewSyncer new syncer.
+func NewSyncer(runRecordCli *dataservice.Client) *Syncer {
+	return &Syncer{runRecordCli: runRecordCli}
+}
+
+// syncScope 各云同步请求中用于标识同步范围的公共字段
+type syncScope struct {
+	AccountID         string `json:"account_id"`
+	Region            string `json:"region"`
+	Zone              string `json:"zone"`
+	ResourceGroupName string `json:"resource_group_name"`
+}
+
+// location 返回同步的地域，azure 为资源组，gcp 部分资源为可用区
+func (s syncScope) location() string {
+	switch {
+	case len(s.Region) != 0:
+		return s.Region
+	case len(s.ResourceGroupName) != 0:
+		return s.ResourceGroupName
+	default:
+		return s.Zone
+	}
+}
+
+// syncRun 一次资源同步运行
+type syncRun struct {
+	vendor    enumor.Vendor
+	scope     syncScope
+	resType   enumor.CloudResourceType
+	startedAt time.Time
+	option    hcsync.SyncDeleteOption
+	stat      *common.SyncStat
+	recordCli *dataservice.Client
+}
+
+// startSyncRun 开始记录资源同步运行，并在kit上挂载变更统计，预览及删除检查模式下挂载只记录同步计划的统计
+func (s *Syncer) startSyncRun(cts *rest.Contexts, resType enumor.CloudResourceType) *syncRun {
+	run := &syncRun{
+		resType:   resType,
+		startedAt: time.Now(),
+		recordCli: s.runRecordCli,
+	}
+	run.parseRequest(cts)
+
+	if run.option.DryRun || run.option.DeleteCheck {
+		run.stat = common.WithDryRunSyncStat(cts.Kit)
+	} else {
+		run.stat = common.WithSyncStat(cts.Kit)
+	}
+
+	return run
+}
+
+// parseRequest 预读请求体解析同步范围及删除参数，再放回请求体供 Prepare 解析
+func (run *syncRun) parseRequest(cts *rest.Contexts) {
+	if cts.Request == nil || cts.Request.Request == nil {
+		return
+	}
+
+	run.vendor = vendorFromPath(cts.Request.Request.URL.Path)
+
+	body, err := io.ReadAll(cts.Request.Request.Body)
+	if err != nil {
+		logs.Warnf("read %s sync request body failed, err: %v, rid: %s", run.resType, err, cts.Kit.Rid)
+		return
+	}
+	cts.Request.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err = json.Unmarshal(body, &run.scope); err != nil {
+		logs.Warnf("decode %s sync scope failed, err: %v, rid: %s", run.resType, err, cts.Kit.Rid)
+		return
+	}
+	if err = json.Unmarshal(body, &run.option); err != nil {
+		logs.Warnf("decode %s sync delete option failed, err: %v, rid: %s", run.resType, err, cts.Kit.Rid)
+	}
+}
+
+// vendorFromPath 从 /vendors/{vendor}/xxx/sync 格式的请求路径中解析云厂商
+func vendorFromPath(path string) enumor.Vendor {
+	fields := strings.Split(path, "/")
+	for i := 0; i < len(fields)-1; i++ {
+		if fields[i] == "vendors" {
+			return enumor.Vendor(fields[i+1])
+		}
+	}
+
+	return ""
+}
+
+// finish 结束资源同步运行，上报指标并保存运行记录，保存失败不影响同步结果，预览模式下不记录
+func (run *syncRun) finish(kt *kit.Kit, syncErr error) {
+	if run.stat.DryRun() {
+		logs.Infof("%s sync dry run done, account: %s, add: %d, update: %d, delete: %d, err: %v, rid: %s",
+			run.resType, run.scope.AccountID, run.stat.Added(), run.stat.Updated(), run.stat.Deleted(), syncErr,
+			kt.Rid)
+		return
+	}
+
+	endedAt := time.Now()
+	state := enumor.SyncSuccess
+	failedReason := ""
+	if syncErr != nil {
+		state = enumor.SyncFailed
+		failedReason = syncErr.Error()
+	}
+
+	metrics.ObserveResSync(metrics.ResSyncObservation{
+		Vendor:    string(run.vendor),
+		AccountID: run.scope.AccountID,
+		Scope:     string(enumor.SyncRunResourceScope),
+		ResType:   string(run.resType),
+		State:     string(state),
+		Added:     run.stat.Added(),
+		Updated:   run.stat.Updated(),
+		Deleted:   run.stat.Deleted(),
+		StartedAt: run.startedAt,
+		EndedAt:   endedAt,
+	})
+
+	if run.recordCli == nil || len(run.vendor) == 0 || len(run.scope.AccountID) == 0 {
+		return
+	}
+
+	req := &dssync.CreateRunRecordReq{
+		Items: []dssync.RunRecordCreateField{{
+			Vendor:       run.vendor,
+			AccountID:    run.scope.AccountID,
+			Scope:        enumor.SyncRunResourceScope,
+			Region:       run.scope.location(),
+			ResType:      run.resType,
+			State:        state,
+			Added:        run.stat.Added(),
+			Updated:      run.stat.Updated(),
+			Deleted:      run.stat.Deleted(),
+			FailedReason: failedReason,
+			Rid:          kt.Rid,
+			StartedAt:    run.startedAt,
+			EndedAt:      endedAt,
+		}},
+	}
+	if _, err := run.recordCli.Global.SyncRunRecord.BatchCreate(kt, req); err != nil {
+		logs.Errorf("create %s sync run record failed, err: %v, account: %s, rid: %s", run.resType, err,
+			run.scope.AccountID, kt.Rid)
+	}
+}
+
+// plan 返回预览模式下的同步计划，非预览模式返回nil
+func (run *syncRun) plan() *hcsync.SyncPlanResult {
+	if !run.stat.DryRun() {
+		return nil
+	}
+
+	plan := run.stat.Plan()
+	return &hcsync.SyncPlanResult{
+		ResType:        run.resType,
+		Scanned:        run.stat.Scanned(),
+		Added:          run.stat.Added(),
+		Updated:        run.stat.Updated(),
+		Deleted:        run.stat.Deleted(),
+		AddCloudIDs:    plan.AddCloudIDs,
+		UpdateCloudIDs: plan.UpdateCloudIDs,
+		DeleteCloudIDs: plan.DeleteCloudIDs,
+		Truncated:      plan.Truncated,
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+func TestStartSyncRun(t *testing.T) {
+	body := `{"account_id":"00000001","region":"ap-guangzhou"}`
+	httpReq, err := http.NewRequest(http.MethodPost, "/api/v1/hc/vendors/tcloud/disks/sync", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cts := &rest.Contexts{Kit: kit.New(), Request: restful.NewRequest(httpReq)}
+
+	run := NewSyncer(nil).startSyncRun(cts, enumor.DiskCloudResType)
+	if run.vendor != enumor.TCloud {
+		t.Errorf("vendor not match, got: %s", run.vendor)
+	}
+	if run.scope.AccountID != "00000001" || run.scope.location() != "ap-guangzhou" {
+		t.Errorf("sync scope not match, got: %+v", run.scope)
+	}
+
+	// 预读后请求体仍可被 Prepare 解析
+	req := new(sync.TCloudSyncReq)
+	if err = cts.DecodeInto(req); err != nil {
+		t.Fatalf("decode request after peek failed, err: %v", err)
+	}
+	if req.AccountID != "00000001" || req.Region != "ap-guangzhou" {
+		t.Errorf("request not match, got: %+v", req)
+	}
+
+	subKt := cts.Kit.NewSubKit()
+	common.RecordAdded(subKt, 1)
+	common.RecordUpdated(subKt, 2)
+	common.RecordDeleted(subKt, 3)
+	common.RecordAdded(cts.Kit, 1)
+	if run.stat.Added() != 2 || run.stat.Updated() != 2 || run.stat.Deleted() != 3 {
+		t.Errorf("sync stat not match, added: %d, updated: %d, deleted: %d", run.stat.Added(),
+			run.stat.Updated(), run.stat.Deleted())
+	}
+}
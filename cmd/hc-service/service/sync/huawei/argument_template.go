@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/huawei"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typeargstpl "hcm/pkg/adaptor/types/argument-template"
+	typecore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncArgsTpl 同步IP地址组到参数模版
+func (svc *service) SyncArgsTpl(cts *rest.Contexts) (interface{}, error) {
+	return svc.syncer.ResourceSync(cts, &argsTplHandler{cli: svc.syncCli})
+}
+
+// argsTplHandler argument template sync handler.
+type argsTplHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.HuaWeiSyncReq
+	syncCli huawei.Interface
+	marker  *string
+	done    bool
+}
+
+var _ handler.Handler = new(argsTplHandler)
+
+// Prepare ...
+func (hd *argsTplHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *argsTplHandler) Next(kt *kit.Kit) ([]string, error) {
+	if hd.done {
+		return nil, nil
+	}
+
+	listOpt := &typeargstpl.HuaWeiListOption{
+		Region: hd.request.Region,
+		Page: &typecore.HuaWeiPage{
+			Limit:  cvt.ValToPtr(int32(constant.CloudResourceSyncMaxLimit)),
+			Marker: hd.marker,
+		},
+	}
+	result, err := hd.syncCli.CloudCli().ListArgsTplAddress(kt, listOpt)
+	if err != nil {
+		logs.Errorf("request adaptor list huawei address group failed, err: %v, opt: %v, rid: %s", err, listOpt,
+			kt.Rid)
+		return nil, err
+	}
+
+	hd.marker = result.NextMarker
+	hd.done = result.NextMarker == nil
+
+	return slice.Map(result.Details, typeargstpl.HuaWeiArgsTplAddress.GetCloudID), nil
+}
+
+// Sync ...
+func (hd *argsTplHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &huawei.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		Region:    hd.request.Region,
+		CloudIDs:  cloudIDs,
+	}
+	opt := &huawei.SyncArgsTplOption{BkBizID: constant.UnassignedBiz}
+	if _, err := hd.syncCli.ArgsTplAddress(kt, params, opt); err != nil {
+		logs.Errorf("sync huawei address group failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *argsTplHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemoveArgsTplAddressDeleteFromCloud(kt, hd.request.AccountID, hd.request.Region)
+	if err != nil {
+		logs.Errorf("remove address group delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			err, hd.request.AccountID, hd.request.Region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *argsTplHandler) Name() enumor.CloudResourceType {
+	return enumor.ArgumentTemplateResType
+}
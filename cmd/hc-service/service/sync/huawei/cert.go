@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/huawei"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typecert "hcm/pkg/adaptor/types/cert"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncCert 同步云证书管理服务中的证书
+func (svc *service) SyncCert(cts *rest.Contexts) (interface{}, error) {
+	return svc.syncer.ResourceSync(cts, &certHandler{cli: svc.syncCli})
+}
+
+// certHandler cert sync handler.
+type certHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request        *sync.HuaWeiSyncReq
+	syncCli        huawei.Interface
+	offset         int32
+	cachedCertList []typecert.HuaWeiCert
+}
+
+var _ handler.Handler = new(certHandler)
+
+// Prepare ...
+func (hd *certHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *certHandler) Next(kt *kit.Kit) ([]string, error) {
+	listOpt := &typecert.HuaWeiListOption{
+		Offset: hd.offset,
+		Limit:  typecert.HuaWeiCertQueryLimit,
+	}
+	result, err := hd.syncCli.CloudCli().ListCert(kt, listOpt)
+	if err != nil {
+		logs.Errorf("request adaptor list huawei cert failed, err: %v, opt: %v, rid: %s", err, listOpt, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, nil
+	}
+
+	hd.offset += int32(len(result.Details))
+	hd.cachedCertList = result.Details
+	return slice.Map(result.Details, typecert.HuaWeiCert.GetCloudID), nil
+}
+
+// Sync ...
+func (hd *certHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &huawei.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		Region:    hd.request.Region,
+		CloudIDs:  cloudIDs,
+	}
+	// 证书接口不支持按id查询，因此将Next步骤中获取的证书直接传入
+	opt := &huawei.SyncCertOption{
+		BkBizID:           constant.UnassignedBiz,
+		PreCachedCertList: hd.cachedCertList,
+	}
+	if _, err := hd.syncCli.Cert(kt, params, opt); err != nil {
+		logs.Errorf("sync huawei cert failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *certHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	if err := hd.syncCli.RemoveCertDeleteFromCloud(kt, hd.request.AccountID); err != nil {
+		logs.Errorf("remove cert delete from cloud failed, err: %v, accountID: %s, rid: %s", err,
+			hd.request.AccountID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *certHandler) Name() enumor.CloudResourceType {
+	return enumor.CertCloudResType
+}
@@ -21,11 +21,10 @@ package huawei
 
 import (
 	logicsrt "hcm/cmd/hc-service/logics/route-table"
-	"hcm/cmd/hc-service/service/sync/handler"
 	"hcm/pkg/rest"
 )
 
 // SyncRouteTable ....
 func (svc *service) SyncRouteTable(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &logicsrt.HuaWeiRouteTableHandler{Cli: svc.syncCli})
+	return svc.syncer.ResourceSync(cts, &logicsrt.HuaWeiRouteTableHandler{Cli: svc.syncCli})
 }
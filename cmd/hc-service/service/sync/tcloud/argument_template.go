@@ -38,30 +38,33 @@ import (
 func (svc *service) SyncArgsTpl(cts *rest.Contexts) (interface{}, error) {
 	argsTplHandler := &argsTplAddressHandler{cli: svc.syncCli}
 
-	addressErr := handler.ResourceSync(cts, argsTplHandler)
-	if addressErr != nil {
-		return nil, addressErr
+	plan, err := svc.syncer.ResourceSync(cts, argsTplHandler)
+	if err != nil {
+		return nil, err
 	}
 
-	addressGroupErr := handler.ResourceSync(cts, &argsTplAddressGroupHandler{
+	addressGroupPlan, err := svc.syncer.ResourceSync(cts, &argsTplAddressGroupHandler{
 		cli: svc.syncCli, request: argsTplHandler.request, syncCli: argsTplHandler.syncCli})
-	if addressGroupErr != nil {
-		return nil, addressGroupErr
+	if err != nil {
+		return nil, err
 	}
+	plan = handler.MergeSyncPlan(plan, addressGroupPlan)
 
-	serviceErr := handler.ResourceSync(cts, &argsTplServiceHandler{
+	servicePlan, err := svc.syncer.ResourceSync(cts, &argsTplServiceHandler{
 		cli: svc.syncCli, request: argsTplHandler.request, syncCli: argsTplHandler.syncCli})
-	if serviceErr != nil {
-		return nil, serviceErr
+	if err != nil {
+		return nil, err
 	}
+	plan = handler.MergeSyncPlan(plan, servicePlan)
 
-	serviceGroupErr := handler.ResourceSync(cts, &argsTplServiceGroupHandler{
+	serviceGroupPlan, err := svc.syncer.ResourceSync(cts, &argsTplServiceGroupHandler{
 		cli: svc.syncCli, request: argsTplHandler.request, syncCli: argsTplHandler.syncCli})
-	if serviceGroupErr != nil {
-		return nil, serviceGroupErr
+	if err != nil {
+		return nil, err
 	}
+	plan = handler.MergeSyncPlan(plan, serviceGroupPlan)
 
-	return nil, nil
+	return plan, nil
 }
 
 // ------------- Sync Address ---------
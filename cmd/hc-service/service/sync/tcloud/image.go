@@ -36,7 +36,7 @@ import (
 
 // SyncImage ....
 func (svc *service) SyncImage(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &imageHandler{cli: svc.syncCli})
+	return svc.syncer.ResourceSync(cts, &imageHandler{cli: svc.syncCli})
 }
 
 // imageHandler image sync handler.
@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/tcloud"
+	"hcm/cmd/hc-service/service/sync/handler"
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// IncrementalSync 根据操作审计事件增量同步发生变更的资源
+func (svc *service) IncrementalSync(cts *rest.Contexts) (interface{}, error) {
+	req := new(sync.IncrementalSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	syncCli, err := svc.syncCli.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := req.TimeRange()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	events, err := syncCli.CloudCli().ListResourceChangeEvent(cts.Kit,
+		&resourceevent.ListOption{StartTime: start, EndTime: end})
+	if err != nil {
+		logs.Errorf("list tcloud resource change event failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncFn := func(kt *kit.Kit, resType enumor.CloudResourceType, region string, cloudIDs []string) error {
+		return syncChangedResource(kt, syncCli, req.AccountID, resType, region, cloudIDs)
+	}
+
+	return handler.IncrementalSync(cts.Kit, events, handler.RegionScope, syncFn), nil
+}
+
+// syncChangedResource 复用各资源按云ID同步的逻辑，云上已删除的资源会在同步时从db删除
+func syncChangedResource(kt *kit.Kit, syncCli tcloud.Interface, accountID string, resType enumor.CloudResourceType,
+	region string, cloudIDs []string) error {
+
+	params := &tcloud.SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+
+	var err error
+	switch resType {
+	case enumor.CvmCloudResType:
+		_, err = syncCli.CvmWithRelRes(kt, params, new(tcloud.SyncCvmWithRelResOption))
+	case enumor.DiskCloudResType:
+		_, err = syncCli.Disk(kt, params, new(tcloud.SyncDiskOption))
+	case enumor.EipCloudResType:
+		_, err = syncCli.Eip(kt, params, new(tcloud.SyncEipOption))
+	case enumor.SecurityGroupCloudResType:
+		_, err = syncCli.SecurityGroup(kt, params, new(tcloud.SyncSGOption))
+	case enumor.VpcCloudResType:
+		_, err = syncCli.Vpc(kt, params, new(tcloud.SyncVpcOption))
+	case enumor.SubnetCloudResType:
+		_, err = syncCli.Subnet(kt, params, new(tcloud.SyncSubnetOption))
+	case enumor.RouteTableCloudResType:
+		_, err = syncCli.RouteTable(kt, params, new(tcloud.SyncRouteTableOption))
+	case enumor.LoadBalancerCloudResType:
+		_, err = syncCli.LoadBalancerWithListener(kt, params, new(tcloud.SyncLBOption))
+	default:
+		return fmt.Errorf("resource type %s not support incremental sync", resType)
+	}
+
+	return err
+}
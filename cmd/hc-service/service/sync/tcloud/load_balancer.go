@@ -44,7 +44,7 @@ func (svc *service) SyncLoadBalancer(cts *rest.Contexts) (interface{}, error) {
 			cli:     svc.syncCli,
 		},
 	}
-	return nil, handler.ResourceSyncV2(cts, hd)
+	return handler.ResourceSyncV2(cts, svc.syncer, hd)
 }
 
 // lbHandler lb sync handler.
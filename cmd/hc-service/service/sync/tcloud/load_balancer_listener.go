@@ -42,7 +42,7 @@ import (
 
 // SyncLoadBalancerListener 同步负载均衡监听器接口
 func (svc *service) SyncLoadBalancerListener(cts *rest.Contexts) (any, error) {
-	return nil, handler.ResourceSync(cts, &lblHandler{cli: svc.syncCli, dataCli: svc.dataCli})
+	return svc.syncer.ResourceSync(cts, &lblHandler{cli: svc.syncCli, dataCli: svc.dataCli})
 }
 
 // lblHandler lb listener sync handler.
@@ -37,7 +37,7 @@ func (svc *service) SyncSecurityGroup(cts *rest.Contexts) (interface{}, error) {
 		resType: enumor.SecurityGroupCloudResType,
 		cli:     svc.syncCli,
 	}}
-	return nil, handler.ResourceSyncV2(cts, hd)
+	return handler.ResourceSyncV2(cts, svc.syncer, hd)
 }
 
 // sgHandler sg sync handler.
@@ -23,7 +23,6 @@ package vpc
 import (
 	logicsrt "hcm/cmd/hc-service/logics/route-table"
 	"hcm/cmd/hc-service/logics/subnet"
-	"hcm/cmd/hc-service/service/sync/handler"
 	"hcm/pkg/adaptor/types"
 	adcore "hcm/pkg/adaptor/types/core"
 	"hcm/pkg/api/core"
@@ -123,7 +122,7 @@ func (v vpc) HuaWeiVpcCreate(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	err = handler.ResourceSync(cts, &logicsrt.HuaWeiRouteTableHandler{
+	_, err = v.syncer.ResourceSync(cts, &logicsrt.HuaWeiRouteTableHandler{
 		DisablePrepare: true,
 		Cli:            v.syncCli,
 		Request: &sync.HuaWeiSyncReq{
@@ -25,6 +25,7 @@ import (
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/logics/subnet"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/cmd/hc-service/service/sync/handler"
 	"hcm/pkg/client"
 	"hcm/pkg/rest"
 )
@@ -36,6 +37,7 @@ func InitVpcService(cap *capability.Capability) {
 		cs:      cap.ClientSet,
 		subnet:  subnet.NewSubnet(cap.ClientSet, cap.CloudAdaptor),
 		syncCli: ressync.NewClient(cap.CloudAdaptor, cap.ClientSet.DataService()),
+		syncer:  cap.Syncer,
 	}
 
 	h := rest.NewHandler()
@@ -66,4 +68,5 @@ type vpc struct {
 	cs      *client.ClientSet
 	subnet  *subnet.Subnet
 	syncCli ressync.Interface
+	syncer  *handler.Syncer
 }
@@ -28,4 +28,6 @@ import (
 	_ "hcm/cmd/task-server/logics/action/bill/dailypull/gcp"
 	// register huawei daily pull
 	_ "hcm/cmd/task-server/logics/action/bill/dailypull/huawei"
+	// register tcloud daily pull
+	_ "hcm/cmd/task-server/logics/action/bill/dailypull/tcloud"
 )
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tcloud daily puller
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"hcm/cmd/task-server/logics/action/bill/dailypull/registry"
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	typecore "hcm/pkg/adaptor/types/core"
+	dsbill "hcm/pkg/api/data-service/bill"
+	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/shopspring/decimal"
+	billing "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
+)
+
+func init() {
+	registry.PullerRegistry[enumor.TCloud] = &TCloudPuller{}
+}
+
+// TCloudPuller tcloud puller
+type TCloudPuller struct{}
+
+// Pull pull tcloud data
+func (tp *TCloudPuller) Pull(kt run.ExecuteKit, opt *registry.PullDailyBillOption) (*registry.PullerResult, error) {
+	rootAccount, err := actcli.GetDataService().Global.RootAccount.GetBasicInfo(kt.Kit(), opt.RootAccountID)
+	if err != nil {
+		logs.Errorf("get tcloud root account %s failed, err: %v, rid: %s", opt.RootAccountID, err, kt.Kit().Rid)
+		return nil, err
+	}
+	currency := rootAccount.DefaultCurrency()
+
+	offset := uint64(0)
+	count := int64(0)
+	cost := decimal.NewFromInt(0)
+	var pageContext *string
+	for {
+		limit := uint64(typecore.TCloudQueryLimit)
+		resp, err := tp.doPull(kt, opt, currency, offset, limit, pageContext)
+		if err != nil {
+			return nil, err
+		}
+		cost = cost.Add(resp.Cost)
+		count += resp.Count
+		logs.Infof("get raw bill item %d / total %d of puller %+v", resp.fetched, resp.total, opt)
+		if uint64(resp.fetched) < limit {
+			break
+		}
+		offset += limit
+		pageContext = resp.context
+	}
+	return &registry.PullerResult{
+		Count:    count,
+		Currency: currency,
+		Cost:     cost,
+	}, nil
+}
+
+type pullResult struct {
+	registry.PullerResult
+	fetched int
+	total   uint64
+	context *string
+}
+
+func (tp *TCloudPuller) doPull(kt run.ExecuteKit, opt *registry.PullDailyBillOption, currency enumor.CurrencyCode,
+	offset, limit uint64, pageContext *string) (*pullResult, error) {
+
+	billDate := fmt.Sprintf("%d-%02d-%02d", opt.BillYear, opt.BillMonth, opt.BillDay)
+	req := &hcbillservice.TCloudRootBillListReq{
+		RootAccountID:      opt.RootAccountID,
+		MainAccountCloudID: opt.MainAccountCloudID,
+		BeginDate:          billDate + " 00:00:00",
+		EndDate:            billDate + " 23:59:59",
+		Page: &typecore.TCloudPage{
+			Offset: offset,
+			Limit:  limit,
+		},
+		Context: pageContext,
+	}
+	resp, err := actcli.GetHCService().TCloud.Bill.ListRootBill(kt.Kit(), req)
+	if err != nil {
+		return nil, fmt.Errorf("list tcloud root account bill failed, err %s", err.Error())
+	}
+
+	ret := &pullResult{fetched: len(resp.Details), total: resp.Count, context: resp.Context}
+	if len(resp.Details) == 0 {
+		return ret, nil
+	}
+
+	billItems, err := convertToRawBill(opt.BillDay, currency, resp.Details)
+	if err != nil {
+		return nil, err
+	}
+	if len(billItems) == 0 {
+		return ret, nil
+	}
+
+	filename := fmt.Sprintf("%d-%d.csv", offset, len(billItems))
+	if err := tp.createRawBill(kt, opt, filename, billItems); err != nil {
+		return nil, err
+	}
+	ret.Count = int64(len(billItems))
+	ret.Cost = getRawBillCost(billItems)
+	return ret, nil
+}
+
+func getRawBillCost(rawBills []dsbill.RawBillItem) decimal.Decimal {
+	cost := decimal.NewFromInt(0)
+	for _, bill := range rawBills {
+		cost = cost.Add(bill.BillCost)
+	}
+	return cost
+}
+
+// convertToRawBill 转换为原始账单，账单归属日与拉取日期不一致的条目会被忽略，避免跨天重复计费
+func convertToRawBill(billDay int, currency enumor.CurrencyCode, details []*billing.BillDetail) (
+	[]dsbill.RawBillItem, error) {
+
+	retList := make([]dsbill.RawBillItem, 0, len(details))
+	for _, detail := range details {
+		if detail == nil {
+			continue
+		}
+		if detail.BillDay != nil {
+			day, err := strconv.Atoi(*detail.BillDay)
+			if err == nil && day != billDay {
+				continue
+			}
+		}
+
+		cost := decimal.NewFromInt(0)
+		for _, component := range detail.ComponentSet {
+			if component == nil || component.RealCost == nil {
+				continue
+			}
+			realCost, err := decimal.NewFromString(*component.RealCost)
+			if err != nil {
+				return nil, fmt.Errorf("parse tcloud bill %s real cost %s failed, err: %v",
+					cvt.PtrToVal(detail.BillId), *component.RealCost, err)
+			}
+			cost = cost.Add(realCost)
+		}
+
+		extensionBytes, err := json.Marshal(detail)
+		if err != nil {
+			return nil, fmt.Errorf("marshal tcloud bill item %v failed", detail)
+		}
+		retList = append(retList, dsbill.RawBillItem{
+			Region:        cvt.PtrToVal(detail.RegionId),
+			HcProductCode: cvt.PtrToVal(detail.BusinessCode),
+			HcProductName: cvt.PtrToVal(detail.BusinessCodeName),
+			BillCurrency:  currency,
+			BillCost:      cost,
+			Extension:     types.JsonField(string(extensionBytes)),
+		})
+	}
+	return retList, nil
+}
+
+func (tp *TCloudPuller) createRawBill(kt run.ExecuteKit, opt *registry.PullDailyBillOption, filename string,
+	billItems []dsbill.RawBillItem) error {
+
+	storeReq := &dsbill.RawBillCreateReq{
+		RawBillPathParam: dsbill.RawBillPathParam{
+			Vendor:        enumor.TCloud,
+			RootAccountID: opt.RootAccountID,
+			MainAccountID: opt.MainAccountID,
+			BillYear:      fmt.Sprintf("%d", opt.BillYear),
+			BillMonth:     fmt.Sprintf("%02d", opt.BillMonth),
+			BillDate:      fmt.Sprintf("%02d", opt.BillDay),
+			Version:       fmt.Sprintf("%d", opt.VersionID),
+			FileName:      filename,
+		},
+	}
+	storeReq.Items = billItems
+	_, err := actcli.GetDataService().Global.Bill.CreateRawBill(kt.Kit(), storeReq)
+	if err != nil {
+		return fmt.Errorf("create raw bill to dataservice failed, err %s", err.Error())
+	}
+	return nil
+}
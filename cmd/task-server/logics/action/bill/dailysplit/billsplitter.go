@@ -36,6 +36,7 @@ var vendorSplitterFunc = map[enumor.Vendor]func() RawBillSplitter{
 	enumor.Azure:    func() RawBillSplitter { return &DefaultSplitter{} },
 	enumor.Kaopu:    func() RawBillSplitter { return &DefaultSplitter{} },
 	enumor.Zenlayer: func() RawBillSplitter { return &DefaultSplitter{} },
+	enumor.TCloud:   func() RawBillSplitter { return &DefaultSplitter{} },
 }
 
 // GetSplitter ...
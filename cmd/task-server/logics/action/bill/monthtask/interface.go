@@ -37,6 +37,8 @@ func GetRunner(vendor enumor.Vendor, taskType enumor.MonthTaskType) (MonthTaskRu
 		return newAwsRunner(taskType)
 	case enumor.HuaWei:
 		return newHuaweiRunner(taskType)
+	case enumor.TCloud:
+		return newTCloudRunner(taskType)
 	default:
 		return nil, fmt.Errorf("vendor %s not support now", vendor)
 	}
@@ -0,0 +1,242 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package monthtask
+
+import (
+	"encoding/json"
+	"fmt"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	typecore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/account-set"
+	"hcm/pkg/api/data-service/bill"
+	hcbill "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/shopspring/decimal"
+)
+
+// TCloudSupportMonthTask
+// 1. 拉根账号自身的月度账单 直接用根账号cloud_id 作为付费账号拉取
+// 2. 按各二级账号当月支出比例分摊到子账号下，并冲平根账号支出
+type TCloudSupportMonthTask struct {
+	tcloudMonthTaskBaseRunner
+}
+
+// Pull root account bill item
+func (a TCloudSupportMonthTask) Pull(kt *kit.Kit, opt *MonthTaskActionOption, index uint64) (
+	itemList []bill.RawBillItem, isFinished bool, err error) {
+
+	// 查询根账号信息
+	rootAccount, err := actcli.GetDataService().TCloud.RootAccount.Get(kt, opt.RootAccountID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rootBillReq := &hcbill.TCloudRootBillListReq{
+		RootAccountID:      opt.RootAccountID,
+		MainAccountCloudID: rootAccount.CloudID,
+		Month:              fmt.Sprintf("%d-%02d", opt.BillYear, opt.BillMonth),
+		Page: &typecore.TCloudPage{
+			Offset: index,
+			Limit:  a.GetBatchSize(kt),
+		},
+	}
+	billResp, err := actcli.GetHCService().TCloud.Bill.ListRootBill(kt, rootBillReq)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(billResp.Details) == 0 {
+		return nil, true, nil
+	}
+	currency := rootAccount.DefaultCurrency()
+	for _, detail := range billResp.Details {
+		if detail == nil {
+			continue
+		}
+		cost, err := getTCloudBillDetailCost(detail)
+		if err != nil {
+			return nil, false, err
+		}
+		extensionBytes, err := json.Marshal(detail)
+		if err != nil {
+			return nil, false, fmt.Errorf("marshal tcloud support bill item %v failed", detail)
+		}
+		itemList = append(itemList, bill.RawBillItem{
+			Region:        cvt.PtrToVal(detail.RegionId),
+			HcProductCode: cvt.PtrToVal(detail.BusinessCode),
+			HcProductName: cvt.PtrToVal(detail.BusinessCodeName),
+			BillCurrency:  currency,
+			BillCost:      cost,
+			Extension:     types.JsonField(extensionBytes),
+		})
+	}
+	supportDone := uint64(len(billResp.Details)) < a.GetBatchSize(kt)
+	return itemList, supportDone, nil
+}
+
+// Split tcloud root account fee to main account
+func (a TCloudSupportMonthTask) Split(kt *kit.Kit, opt *MonthTaskActionOption,
+	rawItemList []*bill.RawBillItem) ([]bill.BillItemCreateReq[json.RawMessage], error) {
+
+	if len(rawItemList) == 0 {
+		return nil, nil
+	}
+	a.initExtension(opt)
+
+	// 查询根账号信息
+	rootAccount, err := actcli.GetDataService().TCloud.RootAccount.Get(kt, opt.RootAccountID)
+	if err != nil {
+		logs.Errorf("failt to get root account info, err: %v, accountID: %s, rid: %s", err, opt.RootAccountID, kt.Rid)
+		return nil, err
+	}
+
+	// rootAsMainAccount 作为二级账号存在的根账号，将分摊后的账单抵冲该账号支出
+	mainAccountMap, rootAsMainAccount, err := a.listMainAccount(kt, rootAccount)
+	if err != nil {
+		logs.Errorf("fail to list main account for tcloud month task split step, err: %v, opt: %#v, rid: %s",
+			err, opt, kt.Rid)
+		return nil, err
+	}
+
+	commonItems, err := a.splitCommonExpense(kt, opt, mainAccountMap, rootAsMainAccount, rawItemList)
+	if err != nil {
+		logs.Errorf("fail to split common expense for tcloud month task split step, err: %v, opt: %#v, rid: %s",
+			err, opt, kt.Rid)
+		return nil, err
+	}
+	return commonItems, nil
+}
+
+func (a TCloudSupportMonthTask) splitCommonExpense(kt *kit.Kit, opt *MonthTaskActionOption,
+	mainAccountMap map[string]*protocore.BaseMainAccount, rootAsMainAccount *protocore.BaseMainAccount,
+	rawItemList []*bill.RawBillItem) ([]bill.BillItemCreateReq[json.RawMessage], error) {
+
+	if len(rawItemList) == 0 {
+		return nil, nil
+	}
+
+	// 聚合本批次 账单总额，并分摊给每个主账号
+	batchSum := decimal.Zero
+	for _, item := range rawItemList {
+		batchSum = batchSum.Add(item.BillCost)
+	}
+
+	summaryList, err := a.listSummaryMainForSupport(kt, opt, mainAccountMap, rootAsMainAccount.CloudID)
+	if err != nil {
+		logs.Errorf("fail to get summary main list for tcloud month task split step, err: %v, opt: %#v, rid: %s",
+			err, opt, kt.Rid)
+		return nil, err
+	}
+	if len(summaryList) == 0 {
+		logs.Warnf("no main account for tcloud month task common expense, opt: %#v, rid: %s", opt, kt.Rid)
+		return nil, nil
+	}
+
+	// 计算总额，再按比例分摊给各个二级账号
+	summaryTotal := decimal.Zero
+	for _, summaryMain := range summaryList {
+		summaryTotal = summaryTotal.Add(summaryMain.CurrentMonthCost)
+	}
+	if summaryTotal.IsZero() {
+		logs.Warnf("total cost of main accounts is zero, skip tcloud month task common expense, opt: %#v, rid: %s",
+			opt, kt.Rid)
+		return nil, nil
+	}
+
+	billItems := make([]bill.BillItemCreateReq[json.RawMessage], 0, len(summaryList))
+	for _, summary := range summaryList {
+		mainAccount := mainAccountMap[summary.MainAccountID]
+		cost := batchSum.Mul(summary.CurrentMonthCost).Div(summaryTotal)
+		extJson, err := convTCloudBillItemExtension(constant.BillCommonExpenseName, opt, mainAccount.CloudID, cost)
+		if err != nil {
+			logs.Errorf("fail to marshal tcloud common expense extension to json, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		costBillItem := convSummaryToCommonExpense(summary, cost, extJson)
+		billItems = append(billItems, costBillItem)
+
+		// 此处冲平根账号支出
+		reverseCost := cost.Neg()
+		reverseExtJson, err := convTCloudBillItemExtension(constant.BillCommonExpenseReverseName, opt,
+			mainAccount.CloudID, reverseCost)
+		if err != nil {
+			logs.Errorf("fail to marshal tcloud common expense reverse extension to json, err: %v, rid: %s",
+				err, kt.Rid)
+			return nil, err
+		}
+
+		reverseBillItem := convSummaryToCommonReverse(rootAsMainAccount, summary, reverseCost, reverseExtJson)
+		billItems = append(billItems, reverseBillItem)
+	}
+	return billItems, nil
+}
+
+// 不包含根账号自身以及用户设定的排除账号的 二级账号汇总信息
+func (a TCloudSupportMonthTask) listSummaryMainForSupport(kt *kit.Kit, opt *MonthTaskActionOption,
+	mainAccountMap map[string]*protocore.BaseMainAccount, rootCloudID string) ([]*bill.BillSummaryMain, error) {
+
+	mainAccountIDs := make([]string, 0, len(mainAccountMap))
+
+	// 排除根账号自身以及用户设定的账号
+	exCloudIdMap := cvt.StringSliceToMap(a.excludeAccountCloudIds)
+	exCloudIdMap[rootCloudID] = struct{}{}
+	for _, account := range mainAccountMap {
+		if _, exist := exCloudIdMap[account.CloudID]; exist {
+			continue
+		}
+		mainAccountIDs = append(mainAccountIDs, account.ID)
+	}
+	if len(mainAccountIDs) == 0 {
+		return nil, nil
+	}
+	summaryListReq := &bill.BillSummaryMainListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleIn("main_account_id", mainAccountIDs),
+			tools.RuleEqual("bill_year", opt.BillYear),
+			tools.RuleEqual("bill_month", opt.BillMonth),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	summaryMainResp, err := actcli.GetDataService().Global.Bill.ListBillSummaryMain(kt, summaryListReq)
+	if err != nil {
+		logs.Errorf("failt to list main account bill summary for %s month task, err: %v, rid: %s",
+			enumor.TCloud, err, kt.Rid)
+		return nil, err
+	}
+	return summaryMainResp.Details, nil
+}
+
+// GetHcProductCodes hc product code ranges
+func (a *TCloudSupportMonthTask) GetHcProductCodes() []string {
+	return []string{constant.BillCommonExpenseName, constant.BillCommonExpenseReverseName}
+}
+
+// GetBatchSize ...
+func (a TCloudSupportMonthTask) GetBatchSize(kt *kit.Kit) uint64 {
+	return typecore.TCloudQueryLimit
+}
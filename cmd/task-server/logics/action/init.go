@@ -33,6 +33,7 @@ import (
 	actionlb "hcm/cmd/task-server/logics/action/load-balancer"
 	actionsg "hcm/cmd/task-server/logics/action/security-group"
 	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
+	actiontaskmgmt "hcm/cmd/task-server/logics/action/task-management"
 	actionflow "hcm/cmd/task-server/logics/flow"
 	"hcm/pkg/async/action"
 	"hcm/pkg/client"
@@ -60,6 +61,7 @@ func register() {
 	action.RegisterAction(actionsubnet.DeleteAction{})
 	action.RegisterAction(actionsg.DeleteSgAction{})
 	action.RegisterAction(actionsg.CreateHuaweiSGRuleAction{})
+	action.RegisterAction(actionsg.ApplySGRuleTemplateAction{})
 	action.RegisterAction(actioneip.DeleteEIPAction{})
 
 	action.RegisterAction(actionlb.AddTargetToGroupAction{})
@@ -91,4 +93,8 @@ func register() {
 
 	action.RegisterAction(actionlb.SyncTCloudLoadBalancerAction{})
 	action.RegisterAction(actionlb.SyncTCloudLoadBalancerListenerAction{})
+
+	action.RegisterAction(actiontaskmgmt.RefreshStateAction{})
+	action.RegisterTpl(actiontaskmgmt.FlowRefreshStateTpl)
+	action.RegisterCronFlow(actiontaskmgmt.RefreshStateCronFlow)
 }
@@ -125,6 +125,8 @@ func (act BatchTaskTCloudCreateL7RuleAction) Run(kt run.ExecuteKit, params any)
 		logs.Errorf("fail to check exists rule, err: %v, rid: %s", err, asyncKit.Rid)
 		return nil, err
 	}
+	kt.Logger().Infof("check rule of listener %s done, mismatch: %d, exists: %d, non exists: %d",
+		opt.ListenerID, len(ruleCheckResult.Mismatch), len(ruleCheckResult.Exists), len(ruleCheckResult.NonExists))
 	// 1. 有错误，写入错误信息
 	for i := range ruleCheckResult.Mismatch {
 		mismatch := ruleCheckResult.Mismatch[i]
@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionsg
+
+import (
+	"fmt"
+	"time"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	dssgruletpl "hcm/pkg/api/data-service/cloud/sg-rule-template"
+	hcproto "hcm/pkg/api/hc-service"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+// ApplySGRuleTemplateAction apply security group rule template action
+type ApplySGRuleTemplateAction struct {
+}
+
+// ApplySGRuleTemplateOption apply security group rule template option, rule requests only contain rules which are
+// missing in security group, every request only contains ingress or egress rules.
+type ApplySGRuleTemplateOption struct {
+	RelID           string                           `json:"rel_id" validate:"required"`
+	TemplateVersion uint64                           `json:"template_version" validate:"required"`
+	Vendor          enumor.Vendor                    `json:"vendor" validate:"required"`
+	SGID            string                           `json:"sg_id" validate:"required"`
+	TCloudRuleReqs  []*hcproto.TCloudSGRuleCreateReq `json:"tcloud_rule_reqs" validate:"omitempty"`
+	AwsRuleReqs     []*hcproto.AwsSGRuleCreateReq    `json:"aws_rule_reqs" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *ApplySGRuleTemplateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	switch opt.Vendor {
+	case enumor.TCloud:
+		if len(opt.AwsRuleReqs) != 0 {
+			return fmt.Errorf("aws rule reqs is not allowed for vendor: %s", opt.Vendor)
+		}
+	case enumor.Aws:
+		if len(opt.TCloudRuleReqs) != 0 {
+			return fmt.Errorf("tcloud rule reqs is not allowed for vendor: %s", opt.Vendor)
+		}
+	default:
+		return fmt.Errorf("vendor: %s not support apply security group rule template", opt.Vendor)
+	}
+
+	return nil
+}
+
+// ParameterNew returns parameter of ApplySGRuleTemplateAction
+func (s ApplySGRuleTemplateAction) ParameterNew() (params any) {
+	return new(ApplySGRuleTemplateOption)
+}
+
+// Name ActionApplySGRuleTemplate
+func (s ApplySGRuleTemplateAction) Name() enumor.ActionName {
+	return enumor.ActionApplySGRuleTemplate
+}
+
+// Run create missing rules in security group, and record apply result to template rel.
+func (s ApplySGRuleTemplateAction) Run(kt run.ExecuteKit, params any) (any, error) {
+	opt, ok := params.(*ApplySGRuleTemplateOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	applyErr := s.createRules(kt.Kit(), opt)
+
+	update := dssgruletpl.RelUpdate{ID: opt.RelID}
+	if applyErr != nil {
+		update.ApplyStatus = enumor.SGRuleTemplateApplyFailed
+		update.ApplyMessage = converter.ValToPtr(applyErr.Error())
+	} else {
+		update.ApplyStatus = enumor.SGRuleTemplateApplySuccess
+		update.ApplyMessage = converter.ValToPtr("")
+		update.AppliedVersion = opt.TemplateVersion
+		update.AppliedAt = times.ConvStdTimeFormat(time.Now())
+	}
+
+	err := actcli.GetDataService().Global.SGRuleTemplate.BatchUpdateRel(kt.Kit(),
+		&dssgruletpl.RelBatchUpdateReq{Rels: []dssgruletpl.RelUpdate{update}})
+	if err != nil {
+		logs.Errorf("update security group rule template rel failed, err: %v, rel: %s, rid: %s", err, opt.RelID,
+			kt.Kit().Rid)
+		return nil, err
+	}
+
+	return nil, applyErr
+}
+
+func (s ApplySGRuleTemplateAction) createRules(kt *kit.Kit, opt *ApplySGRuleTemplateOption) error {
+	for _, req := range opt.TCloudRuleReqs {
+		_, err := actcli.GetHCService().TCloud.SecurityGroup.BatchCreateSecurityGroupRule(kt.Ctx, kt.Header(),
+			opt.SGID, req)
+		if err != nil {
+			logs.Errorf("create tcloud security group rule failed, err: %v, sg: %s, rid: %s", err, opt.SGID, kt.Rid)
+			return err
+		}
+	}
+
+	for _, req := range opt.AwsRuleReqs {
+		_, err := actcli.GetHCService().Aws.SecurityGroup.BatchCreateSecurityGroupRule(kt.Ctx, kt.Header(),
+			opt.SGID, req)
+		if err != nil {
+			logs.Errorf("create aws security group rule failed, err: %v, sg: %s, rid: %s", err, opt.SGID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
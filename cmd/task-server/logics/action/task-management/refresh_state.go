@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actiontaskmgmt
+
+import (
+	taskmanagement "hcm/cmd/cloud-server/logics/task-management"
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/logs"
+)
+
+var _ action.Action = new(RefreshStateAction)
+
+// RefreshStateAction 刷新执行中的任务管理数据状态
+type RefreshStateAction struct{}
+
+// Name return action name.
+func (act RefreshStateAction) Name() enumor.ActionName {
+	return enumor.ActionRefreshTaskMgmtState
+}
+
+// Run refresh running task management state.
+func (act RefreshStateAction) Run(kt run.ExecuteKit, params any) (any, error) {
+	if err := taskmanagement.RefreshRunningState(kt.Kit(), actcli.GetClientSet()); err != nil {
+		logs.Errorf("refresh running task management state failed, err: %v, rid: %s", err, kt.Kit().Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// FlowRefreshStateTpl 刷新任务管理状态任务流模版
+var FlowRefreshStateTpl = action.FlowTemplate{
+	Name:      enumor.FlowRefreshTaskMgmtState,
+	ShareData: tableasync.NewShareData(nil),
+	Tasks: []action.TaskTemplate{
+		{
+			ActionID:   "1",
+			ActionName: enumor.ActionRefreshTaskMgmtState,
+		},
+	},
+}
+
+// RefreshStateCronFlow 每分钟刷新一次任务管理状态，查询任务管理列表时也会实时刷新，因此无需更高频率
+var RefreshStateCronFlow = action.CronFlow{
+	Name:     "refresh_task_management_state",
+	Spec:     "* * * * *",
+	FlowName: enumor.FlowRefreshTaskMgmtState,
+}
@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actiontaskmgmt
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/producer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func TestRefreshStateCronFlow(t *testing.T) {
+	action.RegisterAction(RefreshStateAction{})
+	action.RegisterTpl(FlowRefreshStateTpl)
+	action.RegisterCronFlow(RefreshStateCronFlow)
+
+	next := time.Now().Add(time.Minute)
+	flow, err := producer.BuildTemplateFlow(kit.New(), &producer.AddTemplateFlowOption{
+		Name:        RefreshStateCronFlow.FlowName,
+		Memo:        RefreshStateCronFlow.Memo(),
+		ScheduledAt: &next,
+	})
+	if err != nil {
+		t.Fatalf("build cron flow failed, err: %v", err)
+	}
+	if len(flow.Tasks) != 1 || flow.Tasks[0].ActionName != enumor.ActionRefreshTaskMgmtState {
+		t.Errorf("cron flow should contain refresh state task, got: %+v", flow.Tasks)
+	}
+}
@@ -107,13 +107,17 @@ func NewService(sd serviced.ServiceDiscover, shutdownWaitTimeSec int) (*Service,
 
 func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitTimeSec int) (async.Async, error) {
 	// 创建async框架使用的backend
-	bd, err := backend.Factory(enumor.BackendMysql, dao)
+	cfg := cc.TaskServer().Async
+	var client interface{} = dao
+	if cfg.Backend == enumor.BackendBolt {
+		client = cfg.BoltFile
+	}
+	bd, err := backend.Factory(cfg.Backend, client)
 	if err != nil {
 		return nil, err
 	}
 
 	leader := leader.NewLeader(sd)
-	cfg := cc.TaskServer().Async
 	opt := &async.Option{
 		Register: metrics.Register(),
 		ConsumerOption: &consumer.Option{
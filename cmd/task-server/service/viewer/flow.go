@@ -67,13 +67,16 @@ func (svc *service) ListFlow(cts *rest.Contexts) (interface{}, error) {
 
 func convCoreFlow(one tableasync.AsyncFlowTable) coreasync.AsyncFlow {
 	return coreasync.AsyncFlow{
-		ID:        one.ID,
-		Name:      one.Name,
-		State:     one.State,
-		Reason:    one.Reason,
-		ShareData: one.ShareData,
-		Memo:      one.Memo,
-		Worker:    one.Worker,
+		ID:               one.ID,
+		Name:             one.Name,
+		State:            one.State,
+		Reason:           one.Reason,
+		ShareData:        one.ShareData,
+		Memo:             one.Memo,
+		Worker:           one.Worker,
+		ScheduledAt:      one.ScheduledAt.String(),
+		ConcurrencyKey:   one.ConcurrencyKey,
+		ConcurrencyLimit: one.ConcurrencyLimit,
 		Revision: core.Revision{
 			Creator:   one.Creator,
 			Reviser:   one.Reviser,
@@ -0,0 +1,188 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package viewer
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// GetFlowTimeline get flow execute timeline.
+func (svc *service) GetFlowTimeline(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	flowResult, err := svc.dao.AsyncFlow().List(cts.Kit, &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		logs.Errorf("list flow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(flowResult.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "flow: %s not found", id)
+	}
+
+	taskResult, err := svc.dao.AsyncFlowTask().List(cts.Kit, &types.ListOption{
+		Filter: tools.EqualExpression("flow_id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		logs.Errorf("list task failed, err: %v, flow: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	events, err := svc.listTaskEvents(cts.Kit, tools.EqualExpression("flow_id", id))
+	if err != nil {
+		return nil, err
+	}
+
+	eventMap := make(map[string][]tableasync.AsyncFlowTaskEventTable)
+	for _, one := range events {
+		eventMap[one.TaskID] = append(eventMap[one.TaskID], one)
+	}
+
+	result := &ts.FlowTimelineResult{
+		FlowID: id,
+		State:  flowResult.Details[0].State,
+		Tasks:  make([]ts.TaskTimeline, 0, len(taskResult.Details)),
+	}
+	for _, task := range taskResult.Details {
+		result.Tasks = append(result.Tasks, buildTaskTimeline(task, eventMap[task.ID]))
+	}
+
+	return result, nil
+}
+
+// GetTaskTimeline get task execute timeline.
+func (svc *service) GetTaskTimeline(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	taskResult, err := svc.dao.AsyncFlowTask().List(cts.Kit, &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		logs.Errorf("list task failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(taskResult.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "task: %s not found", id)
+	}
+
+	events, err := svc.listTaskEvents(cts.Kit, tools.EqualExpression("task_id", id))
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := buildTaskTimeline(taskResult.Details[0], events)
+	return &timeline, nil
+}
+
+// listTaskEvents 按发生时间升序查询全部任务事件
+func (svc *service) listTaskEvents(kt *kit.Kit, expr *filter.Expression) (
+	[]tableasync.AsyncFlowTaskEventTable, error) {
+
+	page := &core.BasePage{
+		Start: 0,
+		Limit: core.DefaultMaxPageLimit,
+		Sort:  "occurred_at",
+		Order: core.Ascending,
+	}
+	events := make([]tableasync.AsyncFlowTaskEventTable, 0)
+	for {
+		result, err := svc.dao.AsyncFlowTaskEvent().List(kt, &types.ListOption{Filter: expr, Page: page})
+		if err != nil {
+			logs.Errorf("list task event failed, err: %v, filter: %v, rid: %s", err, expr, kt.Rid)
+			return nil, err
+		}
+
+		events = append(events, result.Details...)
+		if uint(len(result.Details)) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return events, nil
+}
+
+// buildTaskTimeline 构建任务时间线，状态事件的耗时为在源状态停留的时长，首个状态事件从任务创建时间开始计算
+func buildTaskTimeline(task tableasync.AsyncFlowTaskTable,
+	events []tableasync.AsyncFlowTaskEventTable) ts.TaskTimeline {
+
+	timeline := ts.TaskTimeline{
+		TaskID:     task.ID,
+		ActionID:   task.ActionID,
+		ActionName: task.ActionName,
+		State:      task.State,
+		Events:     make([]coreasync.AsyncFlowTaskEvent, 0, len(events)),
+	}
+
+	start, err := time.Parse(constant.TimeStdFormat, task.CreatedAt.String())
+	if err != nil {
+		start = time.Time{}
+	}
+	last := start
+	for _, one := range events {
+		event := coreasync.AsyncFlowTaskEvent{
+			ID:          one.ID,
+			FlowID:      one.FlowID,
+			TaskID:      one.TaskID,
+			Type:        one.Type,
+			SourceState: one.SourceState,
+			TargetState: one.TargetState,
+			Attempt:     one.Attempt,
+			Level:       one.Level,
+			Message:     one.Message,
+			OccurredAt:  one.OccurredAt.Format(constant.TimeStdMsFormat),
+		}
+		if one.Attempt > timeline.Attempts {
+			timeline.Attempts = one.Attempt
+		}
+
+		if one.Type == enumor.TaskEventState {
+			if !last.IsZero() && one.OccurredAt.After(last) {
+				event.DurationMS = one.OccurredAt.Sub(last).Milliseconds()
+			}
+			last = one.OccurredAt
+		}
+		timeline.Events = append(timeline.Events, event)
+	}
+
+	if !start.IsZero() && last.After(start) {
+		timeline.DurationMS = last.Sub(start).Milliseconds()
+	}
+
+	return timeline
+}
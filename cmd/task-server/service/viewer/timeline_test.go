@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package viewer
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+)
+
+func TestBuildTaskTimeline(t *testing.T) {
+	created := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+	task := tableasync.AsyncFlowTaskTable{
+		ID:         "task-1",
+		ActionID:   "1",
+		ActionName: enumor.ActionSleep,
+		State:      enumor.TaskFailed,
+		CreatedAt:  types.Time(created.Format(constant.TimeStdFormat)),
+	}
+
+	type eventTable = tableasync.AsyncFlowTaskEventTable
+	stateEvent := func(source, target enumor.TaskState, attempt uint, offset time.Duration) eventTable {
+		return eventTable{TaskID: task.ID, Type: enumor.TaskEventState, SourceState: source,
+			TargetState: target, Attempt: attempt, OccurredAt: created.Add(offset)}
+	}
+	logEvent := eventTable{TaskID: task.ID, Type: enumor.TaskEventLog, Attempt: 1,
+		Level: enumor.TaskLogInfo, Message: "start", OccurredAt: created.Add(1500 * time.Millisecond)}
+
+	events := []eventTable{
+		stateEvent(enumor.TaskPending, enumor.TaskRunning, 1, time.Second),
+		logEvent,
+		stateEvent(enumor.TaskRunning, enumor.TaskRollback, 1, 3*time.Second),
+		stateEvent(enumor.TaskRollback, enumor.TaskPending, 2, 5*time.Second),
+		stateEvent(enumor.TaskPending, enumor.TaskRunning, 2, 5500*time.Millisecond),
+		stateEvent(enumor.TaskRunning, enumor.TaskFailed, 2, 7*time.Second),
+	}
+
+	timeline := buildTaskTimeline(task, events)
+	if timeline.TaskID != task.ID || timeline.State != task.State {
+		t.Errorf("timeline task should be %s(%s), got: %s(%s)", task.ID, task.State, timeline.TaskID, timeline.State)
+	}
+	if timeline.Attempts != 2 {
+		t.Errorf("timeline attempts should be 2, got: %d", timeline.Attempts)
+	}
+	if timeline.DurationMS != 7000 {
+		t.Errorf("timeline duration should be 7000ms, got: %d", timeline.DurationMS)
+	}
+
+	// 日志事件不计算耗时，状态事件耗时为在源状态停留的时长
+	expects := []int64{1000, 0, 2000, 2000, 500, 1500}
+	if len(timeline.Events) != len(expects) {
+		t.Fatalf("timeline should have %d events, got: %d", len(expects), len(timeline.Events))
+	}
+	for i, expect := range expects {
+		if timeline.Events[i].DurationMS != expect {
+			t.Errorf("event %d duration should be %dms, got: %d", i, expect, timeline.Events[i].DurationMS)
+		}
+	}
+	if timeline.Events[1].OccurredAt != logEvent.OccurredAt.Format(constant.TimeStdMsFormat) {
+		t.Errorf("event occurred at should be formatted with ms, got: %s", timeline.Events[1].OccurredAt)
+	}
+}
+
+func TestBuildTaskTimelineWithoutEvents(t *testing.T) {
+	task := tableasync.AsyncFlowTaskTable{ID: "task-1", State: enumor.TaskPending, CreatedAt: "invalid"}
+
+	timeline := buildTaskTimeline(task, nil)
+	if timeline.Attempts != 0 || timeline.DurationMS != 0 || len(timeline.Events) != 0 {
+		t.Errorf("timeline without events should be empty, got: %+v", timeline)
+	}
+}
@@ -40,6 +40,8 @@ func Init(cap *capability.Capability) {
 	h.Add("GetFlow", "GET", "/flows/{id}", svc.GetFlow)
 	h.Add("ListTask", "POST", "/tasks/list", svc.ListTask)
 	h.Add("GetTask", "GET", "/tasks/{id}", svc.GetTask)
+	h.Add("GetFlowTimeline", "GET", "/flows/{id}/timeline", svc.GetFlowTimeline)
+	h.Add("GetTaskTimeline", "GET", "/tasks/{id}/timeline", svc.GetTaskTimeline)
 
 	h.Load(cap.WebService)
 }
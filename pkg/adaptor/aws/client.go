@@ -27,8 +27,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/athena"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
 	curservice "github.com/aws/aws-sdk-go/service/costandusagereportservice"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
@@ -43,7 +45,11 @@ const (
 	ErrVpcNotFound        = "InvalidVpcID.NotFound"
 	ErrSubnetNotFound     = "InvalidSubnetID.NotFound"
 	ErrDiskNotFound       = "InvalidVolume.NotFound"
+	ErrSnapshotNotFound   = "InvalidSnapshot.NotFound"
+	ErrKeyPairNotFound    = "InvalidKeyPair.NotFound"
 	ErrCvmNotFound        = "InvalidInstanceID.NotFound"
+	ErrLBNotFound         = "LoadBalancerNotFound"
+	ErrTGNotFound         = "TargetGroupNotFound"
 )
 
 type clientSet struct {
@@ -51,7 +57,8 @@ type clientSet struct {
 }
 
 func newClientSet(secret *types.BaseSecret) *clientSet {
-	return &clientSet{credentials.NewStaticCredentials(secret.CloudSecretID, secret.CloudSecretKey, "")}
+	return &clientSet{credentials.NewStaticCredentials(secret.CloudSecretID, secret.CloudSecretKey,
+		secret.CloudSessionToken)}
 }
 
 func (c *clientSet) ec2Client(region string) (*ec2.EC2, error) {
@@ -215,3 +222,44 @@ func (c *clientSet) cloudFormationClient(region string) (*cloudformation.CloudFo
 
 	return cloudformation.New(sess, aws.NewConfig().WithRegion(region)), nil
 }
+
+func (c *clientSet) elbv2Client(region string) (*elbv2.ELBV2, error) {
+	cfg := &aws.Config{
+		Credentials: c.credentials,
+		DisableSSL:  nil,
+		HTTPClient:  nil,
+		LogLevel:    nil,
+		Logger:      nil,
+		MaxRetries:  nil,
+		Retryer:     nil,
+		SleepDelay:  nil,
+	}
+
+	if len(region) != 0 {
+		cfg.Region = aws.String(region)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return elbv2.New(sess), nil
+}
+
+func (c *clientSet) cloudTrailClient(region string) (*cloudtrail.CloudTrail, error) {
+	cfg := &aws.Config{
+		Credentials: c.credentials,
+	}
+
+	if len(region) != 0 {
+		cfg.Region = aws.String(region)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloudtrail.New(sess), nil
+}
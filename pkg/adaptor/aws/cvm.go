@@ -284,11 +284,6 @@ func (a *Aws) CreateCvm(kt *kit.Kit, opt *typecvm.AwsCreateOption) (*poller.Base
 		return nil, err
 	}
 
-	userData, err := genCvmBase64UserData(kt, client, opt.CloudImageID, opt.Password)
-	if err != nil {
-		return nil, fmt.Errorf("gen cvm base64 user data failed, err: %v", err)
-	}
-
 	req := &ec2.RunInstancesInput{
 		DryRun:       aws.Bool(opt.DryRun),
 		ClientToken:  opt.ClientToken,
@@ -307,12 +302,24 @@ func (a *Aws) CreateCvm(kt *kit.Kit, opt *typecvm.AwsCreateOption) (*poller.Base
 				},
 			},
 		},
-		UserData: aws.String(userData),
 		Placement: &ec2.Placement{
 			AvailabilityZone: aws.String(opt.Zone),
 		},
 	}
 
+	// 使用密码登录时通过 user data 设置密码，使用SSH密钥对登录时由云上注入公钥
+	if len(opt.Password) != 0 {
+		userData, err := genCvmBase64UserData(kt, client, opt.CloudImageID, opt.Password)
+		if err != nil {
+			return nil, fmt.Errorf("gen cvm base64 user data failed, err: %v", err)
+		}
+		req.UserData = aws.String(userData)
+	}
+
+	if len(opt.KeyName) != 0 {
+		req.KeyName = aws.String(opt.KeyName)
+	}
+
 	// 如果弹性IP指定了子网，则外部不能设置子网
 	if opt.PublicIPAssigned {
 		req.NetworkInterfaces = []*ec2.InstanceNetworkInterfaceSpecification{
@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"strings"
+
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// ListDiskSnapshot 查看当前账号拥有的云硬盘快照
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DescribeSnapshots.html
+func (a *Aws) ListDiskSnapshot(kt *kit.Kit, opt *typesnapshot.AwsSnapshotListOption) ([]typesnapshot.AwsSnapshot,
+	*string, error) {
+
+	if opt == nil {
+		return nil, nil, errf.New(errf.InvalidParameter, "aws disk snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 不指定 OwnerIds 会返回所有公共快照
+	req := &ec2.DescribeSnapshotsInput{OwnerIds: []*string{aws.String("self")}}
+	if len(opt.CloudIDs) > 0 {
+		req.SnapshotIds = converter.SliceToPtr(opt.CloudIDs)
+	} else if opt.Page != nil {
+		req.MaxResults = opt.Page.MaxResults
+		req.NextToken = opt.Page.NextToken
+	}
+
+	resp, err := client.DescribeSnapshotsWithContext(kt.Ctx, req)
+	if err != nil {
+		if !strings.Contains(err.Error(), ErrSnapshotNotFound) {
+			logs.Errorf("list aws disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		}
+
+		return nil, nil, err
+	}
+
+	snapshots := make([]typesnapshot.AwsSnapshot, 0, len(resp.Snapshots))
+	for _, one := range resp.Snapshots {
+		snapshots = append(snapshots, typesnapshot.AwsSnapshot{Snapshot: one})
+	}
+
+	return snapshots, resp.NextToken, nil
+}
+
+// CreateDiskSnapshot 创建云硬盘快照，返回快照云上ID
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_CreateSnapshot.html
+func (a *Aws) CreateDiskSnapshot(kt *kit.Kit, opt *typesnapshot.AwsSnapshotCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "aws disk snapshot create option is required")
+	}
+
+	input, err := opt.ToCreateSnapshotInput()
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.CreateSnapshotWithContext(kt.Ctx, input)
+	if err != nil {
+		logs.Errorf("create aws disk snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.SnapshotId), nil
+}
+
+// DeleteDiskSnapshot 删除云硬盘快照
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DeleteSnapshot.html
+func (a *Aws) DeleteDiskSnapshot(kt *kit.Kit, opt *typesnapshot.AwsSnapshotDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "aws disk snapshot delete option is required")
+	}
+
+	input, err := opt.ToDeleteSnapshotInput()
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteSnapshotWithContext(kt.Ctx, input); err != nil {
+		logs.Errorf("delete aws disk snapshot failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// CreateDiskFromSnapshot 基于快照创建新云硬盘，返回新云盘云上ID。aws 不支持将快照原地回滚到已有云盘
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_CreateVolume.html
+func (a *Aws) CreateDiskFromSnapshot(kt *kit.Kit, opt *typesnapshot.AwsDiskFromSnapshotOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "aws disk from snapshot option is required")
+	}
+
+	input, err := opt.ToCreateVolumeInput()
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.CreateVolumeWithContext(kt.Ctx, input)
+	if err != nil {
+		logs.Errorf("create aws disk from snapshot failed, err: %v, snapshot: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.VolumeId), nil
+}
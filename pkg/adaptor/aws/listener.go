@@ -0,0 +1,183 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// ListListener 查询负载均衡下的监听器
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeListeners.html
+func (a *Aws) ListListener(kt *kit.Kit, opt *typelb.AwsListListenersOption) ([]typelb.AwsListener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := new(elbv2.DescribeListenersInput)
+	if len(opt.CloudIDs) != 0 {
+		req.ListenerArns = aws.StringSlice(opt.CloudIDs)
+	} else {
+		req.LoadBalancerArn = aws.String(opt.LoadBalancerID)
+	}
+
+	listeners := make([]typelb.AwsListener, 0)
+	err = client.DescribeListenersPagesWithContext(kt.Ctx, req,
+		func(page *elbv2.DescribeListenersOutput, lastPage bool) bool {
+			for _, one := range page.Listeners {
+				listeners = append(listeners, typelb.AwsListener{Region: opt.Region, Listener: one})
+			}
+			return true
+		})
+	if err != nil {
+		logs.Errorf("describe aws listener failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	return listeners, nil
+}
+
+// CreateListener 创建监听器，默认动作转发到指定目标组，返回监听器ARN
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_CreateListener.html
+func (a *Aws) CreateListener(kt *kit.Kit, opt *typelb.AwsCreateListenerOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &elbv2.CreateListenerInput{
+		LoadBalancerArn: aws.String(opt.LoadBalancerID),
+		Protocol:        aws.String(string(opt.Protocol)),
+		Port:            aws.Int64(opt.Port),
+		SslPolicy:       opt.SslPolicy,
+		DefaultActions: []*elbv2.Action{{
+			Type:           aws.String(elbv2.ActionTypeEnumForward),
+			TargetGroupArn: aws.String(opt.TargetGroupID),
+		}},
+	}
+	for _, certID := range opt.CertificateIDs {
+		req.Certificates = append(req.Certificates, &elbv2.Certificate{CertificateArn: aws.String(certID)})
+	}
+
+	resp, err := client.CreateListenerWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws listener failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return "", err
+	}
+
+	if len(resp.Listeners) == 0 || resp.Listeners[0] == nil {
+		return "", errors.New("create aws listener return empty result")
+	}
+
+	return cvt.PtrToVal(resp.Listeners[0].ListenerArn), nil
+}
+
+// DeleteListener 删除监听器
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeleteListener.html
+func (a *Aws) DeleteListener(kt *kit.Kit, opt *typelb.AwsDeleteListenerOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &elbv2.DeleteListenerInput{ListenerArn: aws.String(opt.CloudID)}
+	if _, err = client.DeleteListenerWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws listener failed, err: %v, arn: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListRule 查询监听器下的转发规则，只有ALB监听器有转发规则
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeRules.html
+func (a *Aws) ListRule(kt *kit.Kit, opt *typelb.AwsListRuleOption) ([]typelb.AwsRule, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := new(elbv2.DescribeRulesInput)
+	if len(opt.CloudIDs) != 0 {
+		req.RuleArns = aws.StringSlice(opt.CloudIDs)
+	} else {
+		req.ListenerArn = aws.String(opt.ListenerID)
+	}
+
+	rules := make([]typelb.AwsRule, 0)
+	for {
+		resp, err := client.DescribeRulesWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("describe aws listener rule failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return nil, err
+		}
+		for _, one := range resp.Rules {
+			rules = append(rules, typelb.AwsRule{ListenerID: opt.ListenerID, Rule: one})
+		}
+		if resp.NextMarker == nil {
+			break
+		}
+		req.Marker = resp.NextMarker
+	}
+
+	return rules, nil
+}
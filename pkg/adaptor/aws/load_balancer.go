@@ -0,0 +1,236 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// ListLoadBalancer 查询负载均衡列表，指定的ARN不存在时跳过该负载均衡，不返回错误
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeLoadBalancers.html
+func (a *Aws) ListLoadBalancer(kt *kit.Kit, opt *typelb.AwsListOption) (*typelb.AwsListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := new(elbv2.DescribeLoadBalancersInput)
+	if len(opt.CloudIDs) != 0 {
+		req.LoadBalancerArns = aws.StringSlice(opt.CloudIDs)
+	}
+	if opt.Page != nil {
+		req.Marker = opt.Page.Marker
+		req.PageSize = opt.Page.PageSize
+	}
+
+	resp, err := client.DescribeLoadBalancersWithContext(kt.Ctx, req)
+	if err != nil {
+		if len(opt.CloudIDs) != 0 && strings.Contains(err.Error(), ErrLBNotFound) {
+			// 批量查询时只要有一个不存在整个请求都会报错，退化为逐个查询
+			return a.listLoadBalancerOneByOne(kt, client, opt)
+		}
+		logs.Errorf("describe aws load balancer failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	lbs := make([]typelb.AwsLoadBalancer, 0, len(resp.LoadBalancers))
+	for _, one := range resp.LoadBalancers {
+		lbs = append(lbs, typelb.AwsLoadBalancer{LoadBalancer: one})
+	}
+
+	if err = a.fillLoadBalancerTags(kt, client, lbs); err != nil {
+		return nil, err
+	}
+
+	return &typelb.AwsListResult{NextMarker: resp.NextMarker, Details: lbs}, nil
+}
+
+func (a *Aws) listLoadBalancerOneByOne(kt *kit.Kit, client *elbv2.ELBV2, opt *typelb.AwsListOption) (
+	*typelb.AwsListResult, error) {
+
+	lbs := make([]typelb.AwsLoadBalancer, 0, len(opt.CloudIDs))
+	for _, cloudID := range opt.CloudIDs {
+		req := &elbv2.DescribeLoadBalancersInput{LoadBalancerArns: []*string{aws.String(cloudID)}}
+		resp, err := client.DescribeLoadBalancersWithContext(kt.Ctx, req)
+		if err != nil {
+			if strings.Contains(err.Error(), ErrLBNotFound) {
+				continue
+			}
+			logs.Errorf("describe aws load balancer failed, err: %v, arn: %s, rid: %s", err, cloudID, kt.Rid)
+			return nil, err
+		}
+		for _, one := range resp.LoadBalancers {
+			lbs = append(lbs, typelb.AwsLoadBalancer{LoadBalancer: one})
+		}
+	}
+
+	if err := a.fillLoadBalancerTags(kt, client, lbs); err != nil {
+		return nil, err
+	}
+
+	return &typelb.AwsListResult{Details: lbs}, nil
+}
+
+// fillLoadBalancerTags 负载均衡查询接口不返回标签，需要单独查询
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTags.html
+func (a *Aws) fillLoadBalancerTags(kt *kit.Kit, client *elbv2.ELBV2, lbs []typelb.AwsLoadBalancer) error {
+	if len(lbs) == 0 {
+		return nil
+	}
+
+	tagMap := make(map[string][]*elbv2.Tag, len(lbs))
+	arns := slice.Map(lbs, typelb.AwsLoadBalancer.GetCloudID)
+	for _, batch := range slice.Split(arns, typelb.AwsTagDescribeMax) {
+		req := &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice(batch)}
+		resp, err := client.DescribeTagsWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("describe aws load balancer tags failed, err: %v, arns: %v, rid: %s", err, batch, kt.Rid)
+			return err
+		}
+		for _, desc := range resp.TagDescriptions {
+			if desc == nil {
+				continue
+			}
+			tagMap[cvt.PtrToVal(desc.ResourceArn)] = desc.Tags
+		}
+	}
+
+	for i := range lbs {
+		lbs[i].Tags = tagMap[lbs[i].GetCloudID()]
+	}
+	return nil
+}
+
+// CountLoadBalancer 返回给定地域下所有负载均衡数量
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeLoadBalancers.html
+func (a *Aws) CountLoadBalancer(kt *kit.Kit, region string) (int32, error) {
+	client, err := a.clientSet.elbv2Client(region)
+	if err != nil {
+		return 0, fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", region, err)
+	}
+
+	var count int32
+	req := &elbv2.DescribeLoadBalancersInput{PageSize: aws.Int64(typelb.AwsLBPageSizeMax)}
+	err = client.DescribeLoadBalancersPagesWithContext(kt.Ctx, req,
+		func(page *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+			count += int32(len(page.LoadBalancers))
+			return true
+		})
+	if err != nil {
+		logs.Errorf("count aws load balancer failed, err: %v, region: %s, rid: %s", err, region, kt.Rid)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CreateLoadBalancer 创建负载均衡，返回负载均衡ARN
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_CreateLoadBalancer.html
+func (a *Aws) CreateLoadBalancer(kt *kit.Kit, opt *typelb.AwsCreateLoadBalancerOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &elbv2.CreateLoadBalancerInput{
+		Name:    aws.String(opt.Name),
+		Type:    aws.String(string(opt.Type)),
+		Subnets: aws.StringSlice(opt.CloudSubnetIDs),
+	}
+	if len(opt.Scheme) != 0 {
+		req.Scheme = aws.String(string(opt.Scheme))
+	}
+	if len(opt.IPAddressType) != 0 {
+		req.IpAddressType = aws.String(string(opt.IPAddressType))
+	}
+	if len(opt.CloudSGIDs) != 0 {
+		req.SecurityGroups = aws.StringSlice(opt.CloudSGIDs)
+	}
+	for _, tag := range opt.Tags {
+		req.Tags = append(req.Tags, &elbv2.Tag{Key: aws.String(tag.Key), Value: aws.String(tag.Value)})
+	}
+
+	resp, err := client.CreateLoadBalancerWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws load balancer failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return "", err
+	}
+
+	if len(resp.LoadBalancers) == 0 || resp.LoadBalancers[0] == nil {
+		return "", errors.New("create aws load balancer return empty result")
+	}
+
+	return cvt.PtrToVal(resp.LoadBalancers[0].LoadBalancerArn), nil
+}
+
+// DeleteLoadBalancer 删除负载均衡，同时会删除其下的监听器，不会删除目标组
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeleteLoadBalancer.html
+func (a *Aws) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.AwsDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		req := &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(cloudID)}
+		if _, err = client.DeleteLoadBalancerWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("delete aws load balancer failed, err: %v, arn: %s, rid: %s", err, cloudID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"encoding/json"
+	"errors"
+
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+)
+
+// ListResourceChangeEvent 通过 CloudTrail 查询指定地域时间区间内的写操作事件，转换为资源变更事件
+// reference: https://docs.aws.amazon.com/awscloudtrail/latest/APIReference/API_LookupEvents.html
+func (a *Aws) ListResourceChangeEvent(kt *kit.Kit, opt *resourceevent.ListOption) ([]resourceevent.ChangeEvent,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.NewFromErr(errf.InvalidParameter, errors.New("region is required"))
+	}
+
+	client, err := a.clientSet.cloudTrailClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &cloudtrail.LookupEventsInput{
+		StartTime: aws.Time(opt.StartTime),
+		EndTime:   aws.Time(opt.EndTime),
+		LookupAttributes: []*cloudtrail.LookupAttribute{{
+			AttributeKey:   aws.String(cloudtrail.LookupAttributeKeyReadOnly),
+			AttributeValue: aws.String("false"),
+		}},
+		MaxResults: aws.Int64(50),
+	}
+
+	events := make([]resourceevent.ChangeEvent, 0)
+	err = client.LookupEventsPagesWithContext(kt.Ctx, req, func(page *cloudtrail.LookupEventsOutput, _ bool) bool {
+		for _, one := range page.Events {
+			events = append(events, convAwsEvent(opt.Region, one)...)
+		}
+		return true
+	})
+	if err != nil {
+		logs.Errorf("look up aws cloudtrail events failed, err: %v, region: %s, rid: %s", err, opt.Region, kt.Rid)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// convAwsEvent 转换 CloudTrail 事件，失败的操作以及不支持增量同步的资源会被忽略
+func convAwsEvent(region string, event *cloudtrail.Event) []resourceevent.ChangeEvent {
+	if event == nil || len(event.Resources) == 0 {
+		return nil
+	}
+
+	detail := struct {
+		ErrorCode string `json:"errorCode"`
+	}{}
+	if event.CloudTrailEvent != nil {
+		_ = json.Unmarshal([]byte(*event.CloudTrailEvent), &detail)
+	}
+	if len(detail.ErrorCode) != 0 {
+		return nil
+	}
+
+	result := make([]resourceevent.ChangeEvent, 0, len(event.Resources))
+	for _, one := range event.Resources {
+		resType, ok := resourceevent.AwsResType(converter.PtrToVal(one.ResourceType))
+		if !ok || len(converter.PtrToVal(one.ResourceName)) == 0 {
+			continue
+		}
+
+		result = append(result, resourceevent.ChangeEvent{
+			ResType:   resType,
+			CloudID:   converter.PtrToVal(one.ResourceName),
+			Region:    region,
+			EventName: converter.PtrToVal(event.EventName),
+			EventTime: converter.PtrToVal(event.EventTime),
+		})
+	}
+
+	return result
+}
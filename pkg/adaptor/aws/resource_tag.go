@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"errors"
+
+	resourcetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// TagResources 为资源添加标签，标签键已存在时更新标签值，负载均衡使用elbv2接口，其余资源使用ec2接口
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateTags.html
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_AddTags.html
+func (a *Aws) TagResources(kt *kit.Kit, opt *resourcetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return errf.NewFromErr(errf.InvalidParameter, errors.New("region is required"))
+	}
+
+	cloudIDs := resourcetag.CloudIDs(opt.Resources)
+	if opt.ResType == enumor.LoadBalancerCloudResType {
+		client, err := a.clientSet.elbv2Client(opt.Region)
+		if err != nil {
+			return err
+		}
+
+		req := &elbv2.AddTagsInput{ResourceArns: aws.StringSlice(cloudIDs)}
+		for _, one := range opt.Tags {
+			req.Tags = append(req.Tags, &elbv2.Tag{Key: aws.String(one.Key), Value: aws.String(one.Value)})
+		}
+		if _, err = client.AddTagsWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("add aws load balancer tags failed, err: %v, ids: %v, rid: %s", err, cloudIDs, kt.Rid)
+			return err
+		}
+		return nil
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.CreateTagsInput{Resources: aws.StringSlice(cloudIDs)}
+	for _, one := range opt.Tags {
+		req.Tags = append(req.Tags, &ec2.Tag{Key: aws.String(one.Key), Value: aws.String(one.Value)})
+	}
+	if _, err = client.CreateTagsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("create aws %s tags failed, err: %v, ids: %v, rid: %s", opt.ResType, err, cloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// UnTagResources 删除资源的标签
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteTags.html
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_RemoveTags.html
+func (a *Aws) UnTagResources(kt *kit.Kit, opt *resourcetag.UnTagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return errf.NewFromErr(errf.InvalidParameter, errors.New("region is required"))
+	}
+
+	cloudIDs := resourcetag.CloudIDs(opt.Resources)
+	if opt.ResType == enumor.LoadBalancerCloudResType {
+		client, err := a.clientSet.elbv2Client(opt.Region)
+		if err != nil {
+			return err
+		}
+
+		req := &elbv2.RemoveTagsInput{ResourceArns: aws.StringSlice(cloudIDs), TagKeys: aws.StringSlice(opt.TagKeys)}
+		if _, err = client.RemoveTagsWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("remove aws load balancer tags failed, err: %v, ids: %v, rid: %s", err, cloudIDs, kt.Rid)
+			return err
+		}
+		return nil
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.DeleteTagsInput{Resources: aws.StringSlice(cloudIDs)}
+	for _, key := range opt.TagKeys {
+		req.Tags = append(req.Tags, &ec2.Tag{Key: aws.String(key)})
+	}
+	if _, err = client.DeleteTagsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws %s tags failed, err: %v, ids: %v, rid: %s", opt.ResType, err, cloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// convEc2Tags 转换ec2资源标签，需要在 parseTags 之前调用，parseTags 会修改标签切片
+func convEc2Tags(tags []*ec2.Tag) core.TagMap {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagMap := make(core.TagMap, len(tags))
+	for _, one := range tags {
+		if one == nil {
+			continue
+		}
+		tagMap.Set(converter.PtrToVal(one.Key), converter.PtrToVal(one.Value))
+	}
+	return tagMap
+}
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// AssumeRole 使用当前身份扮演指定角色，获取临时凭证
+// reference: https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html
+func (a *Aws) AssumeRole(kt *kit.Kit, opt *typeaccount.AssumeRoleOption) (*typeaccount.RoleCredential, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "assume role option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.stsClient(converter.ValToPtr(a.DefaultRegion()))
+	if err != nil {
+		return nil, fmt.Errorf("init aws client failed, err: %v", err)
+	}
+
+	req := &sts.AssumeRoleInput{
+		RoleArn:         converter.ValToPtr(opt.RoleArn),
+		RoleSessionName: converter.ValToPtr(opt.SessionName),
+		DurationSeconds: converter.ValToPtr(int64(opt.Duration.Seconds())),
+	}
+	if len(opt.ExternalID) != 0 {
+		req.ExternalId = converter.ValToPtr(opt.ExternalID)
+	}
+
+	resp, err := client.AssumeRoleWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("aws assume role failed, err: %v, role: %s, rid: %s", err, opt.RoleArn, kt.Rid)
+		return nil, err
+	}
+
+	if resp.Credentials == nil {
+		return nil, errors.New("assume role return credentials is nil")
+	}
+
+	return &typeaccount.RoleCredential{
+		SecretID:     converter.PtrToVal(resp.Credentials.AccessKeyId),
+		SecretKey:    converter.PtrToVal(resp.Credentials.SecretAccessKey),
+		SessionToken: converter.PtrToVal(resp.Credentials.SessionToken),
+		ExpireAt:     converter.PtrToVal(resp.Credentials.Expiration),
+	}, nil
+}
@@ -0,0 +1,279 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// ListTargetGroup 查询目标组，指定的ARN不存在时跳过该目标组，不返回错误
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTargetGroups.html
+func (a *Aws) ListTargetGroup(kt *kit.Kit, opt *typelb.AwsListTargetGroupOption) (
+	*typelb.AwsTargetGroupListResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := new(elbv2.DescribeTargetGroupsInput)
+	if len(opt.LoadBalancerID) != 0 {
+		req.LoadBalancerArn = aws.String(opt.LoadBalancerID)
+	}
+	if len(opt.CloudIDs) != 0 {
+		req.TargetGroupArns = aws.StringSlice(opt.CloudIDs)
+	}
+	if opt.Page != nil {
+		req.Marker = opt.Page.Marker
+		req.PageSize = opt.Page.PageSize
+	}
+
+	resp, err := client.DescribeTargetGroupsWithContext(kt.Ctx, req)
+	if err != nil {
+		if len(opt.CloudIDs) != 0 && strings.Contains(err.Error(), ErrTGNotFound) {
+			// 批量查询时只要有一个不存在整个请求都会报错，退化为逐个查询
+			return a.listTargetGroupOneByOne(kt, client, opt)
+		}
+		logs.Errorf("describe aws target group failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	tgs := make([]typelb.AwsTargetGroup, 0, len(resp.TargetGroups))
+	for _, one := range resp.TargetGroups {
+		tgs = append(tgs, typelb.AwsTargetGroup{TargetGroup: one})
+	}
+
+	return &typelb.AwsTargetGroupListResult{NextMarker: resp.NextMarker, Details: tgs}, nil
+}
+
+func (a *Aws) listTargetGroupOneByOne(kt *kit.Kit, client *elbv2.ELBV2, opt *typelb.AwsListTargetGroupOption) (
+	*typelb.AwsTargetGroupListResult, error) {
+
+	tgs := make([]typelb.AwsTargetGroup, 0, len(opt.CloudIDs))
+	for _, cloudID := range opt.CloudIDs {
+		req := &elbv2.DescribeTargetGroupsInput{TargetGroupArns: []*string{aws.String(cloudID)}}
+		resp, err := client.DescribeTargetGroupsWithContext(kt.Ctx, req)
+		if err != nil {
+			if strings.Contains(err.Error(), ErrTGNotFound) {
+				continue
+			}
+			logs.Errorf("describe aws target group failed, err: %v, arn: %s, rid: %s", err, cloudID, kt.Rid)
+			return nil, err
+		}
+		for _, one := range resp.TargetGroups {
+			tgs = append(tgs, typelb.AwsTargetGroup{TargetGroup: one})
+		}
+	}
+
+	return &typelb.AwsTargetGroupListResult{Details: tgs}, nil
+}
+
+// CreateTargetGroup 创建目标组，返回目标组ARN
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_CreateTargetGroup.html
+func (a *Aws) CreateTargetGroup(kt *kit.Kit, opt *typelb.AwsCreateTargetGroupOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &elbv2.CreateTargetGroupInput{
+		Name:                aws.String(opt.Name),
+		Port:                opt.Port,
+		VpcId:               opt.CloudVpcID,
+		HealthCheckEnabled:  opt.HealthCheckEnabled,
+		HealthCheckProtocol: opt.HealthCheckProtocol,
+		HealthCheckPort:     opt.HealthCheckPort,
+		HealthCheckPath:     opt.HealthCheckPath,
+	}
+	if len(opt.Protocol) != 0 {
+		req.Protocol = aws.String(string(opt.Protocol))
+	}
+	if len(opt.TargetType) != 0 {
+		req.TargetType = aws.String(opt.TargetType)
+	}
+
+	resp, err := client.CreateTargetGroupWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws target group failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return "", err
+	}
+
+	if len(resp.TargetGroups) == 0 || resp.TargetGroups[0] == nil {
+		return "", errors.New("create aws target group return empty result")
+	}
+
+	return cvt.PtrToVal(resp.TargetGroups[0].TargetGroupArn), nil
+}
+
+// DeleteTargetGroup 删除目标组，目标组被监听器或规则引用时无法删除
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeleteTargetGroup.html
+func (a *Aws) DeleteTargetGroup(kt *kit.Kit, opt *typelb.AwsDeleteTargetGroupOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(opt.CloudID)}
+	if _, err = client.DeleteTargetGroupWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws target group failed, err: %v, arn: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RegisterTargets 向目标组注册目标
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_RegisterTargets.html
+func (a *Aws) RegisterTargets(kt *kit.Kit, opt *typelb.AwsRegisterTargetsOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "register option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(opt.TargetGroupID),
+		Targets:        convAwsTargets(opt.Targets),
+	}
+	if _, err = client.RegisterTargetsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("register aws targets failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeRegisterTargets 从目标组注销目标
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeregisterTargets.html
+func (a *Aws) DeRegisterTargets(kt *kit.Kit, opt *typelb.AwsRegisterTargetsOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "deregister option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(opt.TargetGroupID),
+		Targets:        convAwsTargets(opt.Targets),
+	}
+	if _, err = client.DeregisterTargetsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("deregister aws targets failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListTargetHealth 查询目标组下目标的健康状态，不指定目标时返回目标组下所有目标
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTargetHealth.html
+func (a *Aws) ListTargetHealth(kt *kit.Kit, opt *typelb.AwsListTargetHealthOption) ([]typelb.AwsTargetHealth, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbv2Client(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws elbv2 client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(opt.TargetGroupID)}
+	if len(opt.Targets) != 0 {
+		req.Targets = convAwsTargets(opt.Targets)
+	}
+
+	resp, err := client.DescribeTargetHealthWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("describe aws target health failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	healths := make([]typelb.AwsTargetHealth, 0, len(resp.TargetHealthDescriptions))
+	for _, one := range resp.TargetHealthDescriptions {
+		healths = append(healths, typelb.AwsTargetHealth{TargetGroupID: opt.TargetGroupID,
+			TargetHealthDescription: one})
+	}
+
+	return healths, nil
+}
+
+func convAwsTargets(targets []*typelb.AwsTarget) []*elbv2.TargetDescription {
+	result := make([]*elbv2.TargetDescription, 0, len(targets))
+	for _, one := range targets {
+		result = append(result, &elbv2.TargetDescription{
+			Id:               aws.String(one.CloudInstID),
+			Port:             one.Port,
+			AvailabilityZone: one.Zone,
+		})
+	}
+	return result
+}
@@ -20,12 +20,16 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"os"
 
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
@@ -133,6 +137,24 @@ func (c *clientSet) diskClient() (*armcompute.DisksClient, error) {
 	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, nil)
 }
 
+// snapshotClient ...
+func (c *clientSet) snapshotClient() (*armcompute.SnapshotsClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+	return armcompute.NewSnapshotsClient(c.credential.CloudSubscriptionID, credential, nil)
+}
+
+// sshPublicKeyClient ...
+func (c *clientSet) sshPublicKeyClient() (*armcompute.SSHPublicKeysClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+	return armcompute.NewSSHPublicKeysClient(c.credential.CloudSubscriptionID, credential, nil)
+}
+
 // imageClient ...
 func (c *clientSet) imageClient() (*armcompute.VirtualMachineImagesClient, error) {
 	credential, err := c.newClientSecretCredential()
@@ -143,14 +165,30 @@ func (c *clientSet) imageClient() (*armcompute.VirtualMachineImagesClient, error
 	return armcompute.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, nil)
 }
 
-// newClientSecretCredential ...
-func (c *clientSet) newClientSecretCredential() (*azidentity.ClientSecretCredential, error) {
+// newClientSecretCredential 配置了联合令牌文件时使用工作负载身份，否则使用应用密钥
+func (c *clientSet) newClientSecretCredential() (azcore.TokenCredential, error) {
+	if len(c.credential.CloudFederatedTokenFile) != 0 {
+		return azidentity.NewClientAssertionCredential(
+			c.credential.CloudTenantID,
+			c.credential.CloudApplicationID,
+			c.readFederatedToken, nil)
+	}
+
 	return azidentity.NewClientSecretCredential(
 		c.credential.CloudTenantID,
 		c.credential.CloudApplicationID,
 		c.credential.CloudClientSecretKey, nil)
 }
 
+// readFederatedToken 联合令牌文件会被定期轮换，每次换取访问令牌时都需要重新读取
+func (c *clientSet) readFederatedToken(_ context.Context) (string, error) {
+	token, err := os.ReadFile(c.credential.CloudFederatedTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("read federated token file failed, err: %v", err)
+	}
+	return string(token), nil
+}
+
 // securityGroupClient ...
 func (c *clientSet) securityGroupClient() (*armnetwork.SecurityGroupsClient, error) {
 	credential, err := c.newClientSecretCredential()
@@ -226,6 +264,21 @@ func (c *clientSet) resourceGroupsClient() (*armresources.ResourceGroupsClient,
 	return client, nil
 }
 
+// tagsClient ...
+func (c *clientSet) tagsClient() (*armresources.TagsClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armresources.NewTagsClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init tags client failed, err: %v", err)
+	}
+
+	return client, nil
+}
+
 // regionClient ...
 func (c *clientSet) regionClient() (*armsubscriptions.Client, error) {
 	credential, err := c.newClientSecretCredential()
@@ -334,3 +387,18 @@ func (c *clientSet) usageDetailClient(kt *kit.Kit) (*billClient, error) {
 func GenResourceName(namePrefix string, number int) string {
 	return fmt.Sprintf("%s-%04d", namePrefix, number)
 }
+
+// armClient generic arm client, used to call apis without sdk package, such as activity log
+func (c *clientSet) armClient() (*arm.Client, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := arm.NewClient("hcm", "v1.0.0", credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init azure arm client failed, err: %v", err)
+	}
+
+	return client, nil
+}
@@ -263,6 +263,7 @@ func converterToAzureCvm(vms []*armcompute.VirtualMachine) []*typecvm.AzureCvm {
 			Location: SPtrToLowerNoSpaceSPtr(v.Location),
 			Type:     v.Type,
 			Zones:    v.Zones,
+			Tags:     convTags(v.Tags),
 		}
 
 		if v.Properties == nil {
@@ -532,7 +533,7 @@ func (az *Azure) CreateCvm(kt *kit.Kit, opt *typecvm.AzureCreateOption) (string,
 				},
 			},
 			OSProfile: &armcompute.OSProfile{
-				AdminPassword: to.Ptr(opt.Password),
+				AdminPassword: converter.StrNilPtr(opt.Password),
 				AdminUsername: to.Ptr(opt.Username),
 				ComputerName:  to.Ptr(opt.Name),
 			},
@@ -559,6 +560,9 @@ func (az *Azure) CreateCvm(kt *kit.Kit, opt *typecvm.AzureCreateOption) (string,
 	if len(opt.Zones) != 0 {
 		instance.Zones = to.SliceOfPtrs(opt.Zones...)
 	}
+	if len(opt.SSHPublicKey) != 0 {
+		instance.Properties.OSProfile.LinuxConfiguration = genLinuxConfiguration(opt)
+	}
 	poller, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, instance, nil)
 	if err != nil {
 		logs.Errorf("begin create cvm failed, err: %v, rid: %s", err, kt.Rid)
@@ -636,3 +640,18 @@ func (az *Azure) GetCvmStatus(kt *kit.Kit, resGroupName, cvmName string) (string
 
 	return status, nil
 }
+
+// genLinuxConfiguration 使用SSH密钥对登录时将公钥写入登录用户的 authorized_keys，未指定密码时禁用密码登录
+func genLinuxConfiguration(opt *typecvm.AzureCreateOption) *armcompute.LinuxConfiguration {
+	return &armcompute.LinuxConfiguration{
+		DisablePasswordAuthentication: to.Ptr(len(opt.Password) == 0),
+		SSH: &armcompute.SSHConfiguration{
+			PublicKeys: []*armcompute.SSHPublicKey{
+				{
+					Path:    to.Ptr(fmt.Sprintf("/home/%s/.ssh/authorized_keys", opt.Username)),
+					KeyData: to.Ptr(opt.SSHPublicKey),
+				},
+			},
+		},
+	}
+}
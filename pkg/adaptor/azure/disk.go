@@ -116,6 +116,7 @@ func (az *Azure) GetDisk(kt *kit.Kit, opt *disk.AzureDiskGetOption) (*disk.Azure
 		Name:     SPtrToLowerSPtr(resp.Disk.Name),
 		Location: SPtrToLowerNoSpaceSPtr(resp.Disk.Location),
 		Type:     resp.Disk.Type,
+		Tags:     convTags(resp.Disk.Tags),
 		Status:   (*string)(resp.Disk.Properties.DiskState),
 		DiskSize: resp.Disk.Properties.DiskSizeBytes,
 		Zones:    resp.Disk.Zones,
@@ -269,6 +270,7 @@ func converterDisk(disks []*armcompute.Disk) []*disk.AzureDisk {
 			Name:     SPtrToLowerSPtr(v.Name),
 			Location: SPtrToLowerNoSpaceSPtr(v.Location),
 			Type:     v.Type,
+			Tags:     convTags(v.Tags),
 			Status:   (*string)(v.Properties.DiskState),
 			DiskSize: v.Properties.DiskSizeBytes,
 			Zones:    v.Zones,
@@ -0,0 +1,196 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"time"
+
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+)
+
+// ListDiskSnapshot 查看资源组下的云硬盘快照
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/list-by-resource-group?tabs=Go
+func (az *Azure) ListDiskSnapshot(kt *kit.Kit, opt *typesnapshot.AzureSnapshotListOption) (
+	[]typesnapshot.AzureSnapshot, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "azure disk snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	idMap := converter.StringSliceToMap(opt.CloudIDs)
+	snapshots := make([]typesnapshot.AzureSnapshot, 0)
+	pager := client.NewListByResourceGroupPager(opt.ResourceGroupName, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure disk snapshot next page failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("failed to advance page: %v", err)
+		}
+
+		for _, one := range nextResult.Value {
+			snapshot := converterSnapshot(one)
+			if len(idMap) == 0 {
+				snapshots = append(snapshots, snapshot)
+				continue
+			}
+
+			if _, exist := idMap[snapshot.GetCloudID()]; exist {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+	}
+
+	return snapshots, nil
+}
+
+func converterSnapshot(one *armcompute.Snapshot) typesnapshot.AzureSnapshot {
+	snapshot := typesnapshot.AzureSnapshot{
+		ID:       SPtrToLowerSPtr(one.ID),
+		Name:     SPtrToLowerSPtr(one.Name),
+		Location: SPtrToLowerNoSpaceSPtr(one.Location),
+		Tags:     convTags(one.Tags),
+	}
+
+	if one.SKU != nil {
+		snapshot.SKUName = (*string)(one.SKU.Name)
+	}
+
+	if prop := one.Properties; prop != nil {
+		snapshot.Status = (*string)(prop.DiskState)
+		snapshot.ProvisioningState = prop.ProvisioningState
+		snapshot.DiskSize = prop.DiskSizeGB
+		snapshot.Incremental = prop.Incremental
+		snapshot.OSType = (*string)(prop.OSType)
+		if prop.CreationData != nil {
+			snapshot.SourceResourceID = SPtrToLowerSPtr(prop.CreationData.SourceResourceID)
+		}
+		if prop.TimeCreated != nil {
+			snapshot.TimeCreated = converter.ValToPtr(prop.TimeCreated.Format(time.RFC3339))
+		}
+	}
+
+	return snapshot
+}
+
+// CreateDiskSnapshot 创建云硬盘快照，返回快照云上ID
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/create-or-update?tabs=Go
+func (az *Azure) CreateDiskSnapshot(kt *kit.Kit, opt *typesnapshot.AzureSnapshotCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "azure disk snapshot create option is required")
+	}
+
+	req, err := opt.ToSnapshot()
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return "", err
+	}
+
+	pollerResp, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, *req, nil)
+	if err != nil {
+		logs.Errorf("create azure disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return "", errorf(err)
+	}
+
+	resp, err := pollerResp.PollUntilDone(kt.Ctx, nil)
+	if err != nil {
+		logs.Errorf("poll create azure disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	return SPtrToLowerStr(resp.ID), nil
+}
+
+// DeleteDiskSnapshot 删除云硬盘快照
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/delete?tabs=Go
+func (az *Azure) DeleteDiskSnapshot(kt *kit.Kit, opt *typesnapshot.AzureSnapshotDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "azure disk snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return err
+	}
+
+	pollerResp, err := client.BeginDelete(kt.Ctx, opt.ResourceGroupName, opt.Name, nil)
+	if err != nil {
+		logs.Errorf("delete azure disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return fmt.Errorf("failed to finish the request:  %v", err)
+	}
+	_, err = pollerResp.PollUntilDone(kt.Ctx, nil)
+
+	return err
+}
+
+// CreateDiskFromSnapshot 基于快照创建新云硬盘，返回新云盘云上ID。azure 不支持将快照原地回滚到已有云盘
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/disks/create-or-update?tabs=Go
+func (az *Azure) CreateDiskFromSnapshot(kt *kit.Kit, opt *typesnapshot.AzureDiskFromSnapshotOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "azure disk from snapshot option is required")
+	}
+
+	req, err := opt.ToDisk()
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.diskClient()
+	if err != nil {
+		return "", err
+	}
+
+	pollerResp, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.DiskName, *req, nil)
+	if err != nil {
+		logs.Errorf("create azure disk from snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return "", errorf(err)
+	}
+
+	resp, err := pollerResp.PollUntilDone(kt.Ctx, nil)
+	if err != nil {
+		logs.Errorf("poll create azure disk from snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	return SPtrToLowerStr(resp.ID), nil
+}
@@ -128,6 +128,7 @@ func convertEip(one *armnetwork.PublicIPAddress, resGroupName string) *eip.Azure
 		Status:                 converter.ValToPtr(string(enumor.EipUnBind)),
 		PublicIp:               one.Properties.IPAddress,
 		Zones:                  one.Zones,
+		Tags:                   convTags(one.Tags),
 		ResourceGroupName:      strings.ToLower(resGroupName),
 		Location:               one.Location,
 		PublicIPAddressVersion: (*string)(one.Properties.PublicIPAddressVersion),
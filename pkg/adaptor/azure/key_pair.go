@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+)
+
+// ListKeyPair 查看资源组下的SSH公钥
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/ssh-public-keys/list-by-resource-group?tabs=Go
+func (az *Azure) ListKeyPair(kt *kit.Kit, opt *typekeypair.AzureKeyPairListOption) ([]typekeypair.AzureKeyPair,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "azure key pair list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.sshPublicKeyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	idMap := converter.StringSliceToMap(opt.CloudIDs)
+	keyPairs := make([]typekeypair.AzureKeyPair, 0)
+	pager := client.NewListByResourceGroupPager(opt.ResourceGroupName, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure key pair next page failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("failed to advance page: %v", err)
+		}
+
+		for _, one := range nextResult.Value {
+			keyPair := converterKeyPair(one)
+			if len(idMap) == 0 {
+				keyPairs = append(keyPairs, keyPair)
+				continue
+			}
+
+			if _, exist := idMap[keyPair.GetCloudID()]; exist {
+				keyPairs = append(keyPairs, keyPair)
+			}
+		}
+	}
+
+	return keyPairs, nil
+}
+
+func converterKeyPair(one *armcompute.SSHPublicKeyResource) typekeypair.AzureKeyPair {
+	keyPair := typekeypair.AzureKeyPair{
+		ID:       SPtrToLowerSPtr(one.ID),
+		Name:     SPtrToLowerSPtr(one.Name),
+		Location: SPtrToLowerNoSpaceSPtr(one.Location),
+	}
+
+	if one.Properties != nil {
+		keyPair.PublicKey = one.Properties.PublicKey
+	}
+
+	return keyPair
+}
+
+// CreateKeyPair 创建或导入SSH公钥。未指定公钥时先创建SSH公钥资源，再由云上生成密钥对并返回私钥
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/ssh-public-keys/generate-key-pair?tabs=Go
+func (az *Azure) CreateKeyPair(kt *kit.Kit, opt *typekeypair.AzureKeyPairCreateOption) (
+	*typekeypair.CreateResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "azure key pair create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.sshPublicKeyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resource := armcompute.SSHPublicKeyResource{Location: to.Ptr(opt.Region)}
+	if opt.PublicKey != nil {
+		resource.Properties = &armcompute.SSHPublicKeyResourceProperties{PublicKey: opt.PublicKey}
+	}
+
+	resp, err := client.Create(kt.Ctx, opt.ResourceGroupName, opt.Name, resource, nil)
+	if err != nil {
+		logs.Errorf("create azure key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return nil, errorf(err)
+	}
+
+	result := &typekeypair.CreateResult{CloudID: SPtrToLowerStr(resp.ID), Name: opt.Name}
+	if opt.PublicKey != nil {
+		return result, nil
+	}
+
+	genResp, err := client.GenerateKeyPair(kt.Ctx, opt.ResourceGroupName, opt.Name, nil)
+	if err != nil {
+		logs.Errorf("generate azure key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return nil, errorf(err)
+	}
+	result.PrivateKey = converter.PtrToVal(genResp.PrivateKey)
+
+	return result, nil
+}
+
+// DeleteKeyPair 删除SSH公钥
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/ssh-public-keys/delete?tabs=Go
+func (az *Azure) DeleteKeyPair(kt *kit.Kit, opt *typekeypair.AzureKeyPairDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "azure key pair delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.sshPublicKeyClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Delete(kt.Ctx, opt.ResourceGroupName, opt.Name, nil); err != nil {
+		logs.Errorf("delete azure key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return errorf(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const activityLogAPIVersion = "2015-04-01"
+
+// ListResourceChangeEvent 通过活动日志查询订阅下时间区间内的写操作事件，转换为资源变更事件
+// reference: https://learn.microsoft.com/en-us/rest/api/monitor/activity-logs/list
+func (az *Azure) ListResourceChangeEvent(kt *kit.Kit, opt *resourceevent.ListOption) (
+	[]resourceevent.ChangeEvent, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.armClient()
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("api-version", activityLogAPIVersion)
+	query.Set("$filter", fmt.Sprintf("eventTimestamp ge '%s' and eventTimestamp le '%s'",
+		opt.StartTime.UTC().Format(time.RFC3339), opt.EndTime.UTC().Format(time.RFC3339)))
+	query.Set("$select", "resourceId,resourceGroupName,resourceType,operationName,status,eventTimestamp,"+
+		"authorization")
+	nextLink := fmt.Sprintf("%s/subscriptions/%s/providers/Microsoft.Insights/eventtypes/management/values?%s",
+		client.Endpoint(), url.PathEscape(az.clientSet.credential.CloudSubscriptionID), query.Encode())
+
+	events := make([]resourceevent.ChangeEvent, 0)
+	for len(nextLink) != 0 {
+		req, err := runtime.NewRequest(kt.Ctx, http.MethodGet, nextLink)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Pipeline().Do(req)
+		if err != nil {
+			logs.Errorf("list azure activity log failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return nil, runtime.NewResponseError(resp)
+		}
+
+		result := new(resourceevent.AzureActivityLogResp)
+		if err = runtime.UnmarshalAsJSON(resp, result); err != nil {
+			return nil, fmt.Errorf("unmarshal azure activity log failed, err: %v", err)
+		}
+
+		for _, one := range result.Value {
+			if event, ok := convAzureActivityLog(one); ok {
+				events = append(events, event)
+			}
+		}
+		nextLink = result.NextLink
+	}
+
+	return events, nil
+}
+
+// convAzureActivityLog 转换活动日志，只关注成功的写操作和删除操作
+func convAzureActivityLog(one resourceevent.AzureActivityLog) (resourceevent.ChangeEvent, bool) {
+	if !strings.EqualFold(one.Status.Value, "Succeeded") || len(one.ResourceID) == 0 {
+		return resourceevent.ChangeEvent{}, false
+	}
+
+	if one.Authorization == nil || !(strings.HasSuffix(one.Authorization.Action, "/write") ||
+		strings.HasSuffix(one.Authorization.Action, "/delete") ||
+		strings.HasSuffix(one.Authorization.Action, "/action")) {
+		return resourceevent.ChangeEvent{}, false
+	}
+
+	resType, ok := resourceevent.AzureResType(one.ResourceType.Value)
+	if !ok {
+		return resourceevent.ChangeEvent{}, false
+	}
+
+	eventTime, _ := time.Parse(time.RFC3339Nano, one.EventTimestamp)
+	return resourceevent.ChangeEvent{
+		ResType:           resType,
+		CloudID:           strings.ToLower(one.ResourceID),
+		ResourceGroupName: strings.ToLower(one.ResourceGroupName),
+		EventName:         one.OperationName.Value,
+		EventTime:         eventTime,
+	}, true
+}
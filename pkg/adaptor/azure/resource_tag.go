@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	resourcetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// TagResources 为资源添加标签，标签键已存在时更新标签值，资源的云ID即为资源的scope
+// reference: https://learn.microsoft.com/en-us/rest/api/resources/tags/update-at-scope
+func (az *Azure) TagResources(kt *kit.Kit, opt *resourcetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.tagsClient()
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string]*string, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags[one.Key] = to.Ptr(one.Value)
+	}
+	patch := armresources.TagsPatchResource{
+		Operation:  to.Ptr(armresources.TagsPatchOperationMerge),
+		Properties: &armresources.Tags{Tags: tags},
+	}
+
+	for _, one := range opt.Resources {
+		if _, err = client.UpdateAtScope(kt.Ctx, one.CloudID, patch, nil); err != nil {
+			logs.Errorf("merge azure %s tags failed, err: %v, id: %s, rid: %s", opt.ResType, err, one.CloudID,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnTagResources 删除资源的标签，先查询资源现有标签，再按照标签键值对删除
+// reference: https://learn.microsoft.com/en-us/rest/api/resources/tags/update-at-scope
+func (az *Azure) UnTagResources(kt *kit.Kit, opt *resourcetag.UnTagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.tagsClient()
+	if err != nil {
+		return err
+	}
+
+	for _, one := range opt.Resources {
+		resp, err := client.GetAtScope(kt.Ctx, one.CloudID, nil)
+		if err != nil {
+			logs.Errorf("get azure %s tags failed, err: %v, id: %s, rid: %s", opt.ResType, err, one.CloudID, kt.Rid)
+			return err
+		}
+
+		if resp.Properties == nil {
+			continue
+		}
+
+		delTags := make(map[string]*string)
+		for _, key := range opt.TagKeys {
+			if value, exist := resp.Properties.Tags[key]; exist {
+				delTags[key] = value
+			}
+		}
+		if len(delTags) == 0 {
+			continue
+		}
+
+		patch := armresources.TagsPatchResource{
+			Operation:  to.Ptr(armresources.TagsPatchOperationDelete),
+			Properties: &armresources.Tags{Tags: delTags},
+		}
+		if _, err = client.UpdateAtScope(kt.Ctx, one.CloudID, patch, nil); err != nil {
+			logs.Errorf("delete azure %s tags failed, err: %v, id: %s, rid: %s", opt.ResType, err, one.CloudID,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convTags(tags map[string]*string) core.TagMap {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagMap := make(core.TagMap, len(tags))
+	for key, value := range tags {
+		tagMap.Set(key, converter.PtrToVal(value))
+	}
+	return tagMap
+}
@@ -0,0 +1,176 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+	"strconv"
+
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListDiskSnapshot 查看云硬盘快照
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/list
+func (g *Gcp) ListDiskSnapshot(kt *kit.Kit, opt *typesnapshot.GcpSnapshotListOption) ([]typesnapshot.GcpSnapshot,
+	string, error) {
+
+	if opt == nil {
+		return nil, "", errf.New(errf.InvalidParameter, "gcp disk snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	request := client.Snapshots.List(g.CloudProjectID()).Context(kt.Ctx)
+
+	if len(opt.CloudIDs) > 0 {
+		request.Filter(generateResourceIDsFilter(opt.CloudIDs))
+	}
+
+	if len(opt.Names) > 0 {
+		request.Filter(generateResourceFilter("name", opt.Names))
+	}
+
+	if opt.Page != nil {
+		request.MaxResults(opt.Page.PageSize).PageToken(opt.Page.PageToken)
+	}
+
+	resp, err := request.Do()
+	if err != nil {
+		logs.Errorf("list disk snapshots failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return nil, "", err
+	}
+
+	snapshots := make([]typesnapshot.GcpSnapshot, 0, len(resp.Items))
+	for _, one := range resp.Items {
+		snapshots = append(snapshots, typesnapshot.GcpSnapshot{Snapshot: one})
+	}
+
+	return snapshots, resp.NextPageToken, nil
+}
+
+// CreateDiskSnapshot 创建云硬盘快照，返回快照云上ID
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/createSnapshot
+func (g *Gcp) CreateDiskSnapshot(kt *kit.Kit, opt *typesnapshot.GcpSnapshotCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "gcp disk snapshot create option is required")
+	}
+
+	req, err := opt.ToSnapshot()
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return "", err
+	}
+
+	cloudProjectID := g.CloudProjectID()
+	op, err := client.Disks.CreateSnapshot(cloudProjectID, opt.Zone, opt.DiskName, req).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("create gcp disk snapshot failed, err: %v, disk: %s, rid: %s", err, opt.DiskName, kt.Rid)
+		return "", err
+	}
+
+	// 快照在操作完成前可能还未生成，需要等待操作结束后再查询快照ID
+	op, err = client.ZoneOperations.Wait(cloudProjectID, opt.Zone, op.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("wait gcp create disk snapshot operation failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return "", fmt.Errorf("create gcp disk snapshot failed, err: %s", op.Error.Errors[0].Message)
+	}
+
+	snapshot, err := client.Snapshots.Get(cloudProjectID, opt.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("get gcp disk snapshot failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return "", err
+	}
+
+	return strconv.FormatUint(snapshot.Id, 10), nil
+}
+
+// DeleteDiskSnapshot 删除云硬盘快照
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/delete
+func (g *Gcp) DeleteDiskSnapshot(kt *kit.Kit, opt *typesnapshot.GcpSnapshotDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "gcp disk snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Snapshots.Delete(g.CloudProjectID(), opt.Name).Context(kt.Ctx).Do(); err != nil {
+		logs.Errorf("delete gcp disk snapshot failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// CreateDiskFromSnapshot 基于快照创建新云硬盘，返回新云盘云上ID。gcp 不支持将快照原地回滚到已有云盘
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/insert
+func (g *Gcp) CreateDiskFromSnapshot(kt *kit.Kit, opt *typesnapshot.GcpDiskFromSnapshotOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "gcp disk from snapshot option is required")
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return "", err
+	}
+
+	cloudProjectID := g.CloudProjectID()
+	req, err := opt.ToDisk(cloudProjectID)
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err = client.Disks.Insert(cloudProjectID, opt.Zone, req).Context(kt.Ctx).Do(); err != nil {
+		logs.Errorf("create gcp disk from snapshot failed, err: %v, snapshot: %s, rid: %s", err, opt.SelfLink,
+			kt.Rid)
+		return "", err
+	}
+
+	cloudID, err := g.getDiskCloudID(kt, opt.Zone, opt.DiskName)
+	if err != nil {
+		return "", err
+	}
+
+	return *cloudID, nil
+}
@@ -0,0 +1,153 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	resourcetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// TagResources 为资源添加标签(label)，标签键已存在时更新标签值。gcp通过资源名称操作，主机和硬盘需要可用区，
+// eip需要地域，设置label需要携带资源当前的labelFingerprint
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/setLabels
+func (g *Gcp) TagResources(kt *kit.Kit, opt *resourcetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	for _, one := range opt.Resources {
+		err := g.updateLabels(kt, opt.ResType, opt.Region, one, func(labels map[string]string) {
+			for _, tag := range opt.Tags {
+				labels[tag.Key] = tag.Value
+			}
+		})
+		if err != nil {
+			logs.Errorf("set gcp %s labels failed, err: %v, name: %s, rid: %s", opt.ResType, err, one.Name, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnTagResources 删除资源的标签(label)
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/setLabels
+func (g *Gcp) UnTagResources(kt *kit.Kit, opt *resourcetag.UnTagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	for _, one := range opt.Resources {
+		err := g.updateLabels(kt, opt.ResType, opt.Region, one, func(labels map[string]string) {
+			for _, key := range opt.TagKeys {
+				delete(labels, key)
+			}
+		})
+		if err != nil {
+			logs.Errorf("set gcp %s labels failed, err: %v, name: %s, rid: %s", opt.ResType, err, one.Name, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateLabels 查询资源当前的label和labelFingerprint，修改后重新设置
+func (g *Gcp) updateLabels(kt *kit.Kit, resType enumor.CloudResourceType, region string,
+	res resourcetag.TagResource, modify func(labels map[string]string)) error {
+
+	if len(res.Name) == 0 {
+		return errf.New(errf.InvalidParameter, "resource name is required")
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	switch resType {
+	case enumor.CvmCloudResType:
+		if len(res.Zone) == 0 {
+			return errf.New(errf.InvalidParameter, "zone is required")
+		}
+
+		ins, err := client.Instances.Get(g.CloudProjectID(), res.Zone, res.Name).Context(kt.Ctx).Do()
+		if err != nil {
+			return err
+		}
+		req := &compute.InstancesSetLabelsRequest{Labels: copyLabels(ins.Labels, modify),
+			LabelFingerprint: ins.LabelFingerprint}
+		_, err = client.Instances.SetLabels(g.CloudProjectID(), res.Zone, res.Name, req).Context(kt.Ctx).Do()
+		return err
+
+	case enumor.DiskCloudResType:
+		if len(res.Zone) == 0 {
+			return errf.New(errf.InvalidParameter, "zone is required")
+		}
+
+		disk, err := client.Disks.Get(g.CloudProjectID(), res.Zone, res.Name).Context(kt.Ctx).Do()
+		if err != nil {
+			return err
+		}
+		req := &compute.ZoneSetLabelsRequest{Labels: copyLabels(disk.Labels, modify),
+			LabelFingerprint: disk.LabelFingerprint}
+		_, err = client.Disks.SetLabels(g.CloudProjectID(), res.Zone, res.Name, req).Context(kt.Ctx).Do()
+		return err
+
+	case enumor.EipCloudResType:
+		if len(region) == 0 {
+			return errf.New(errf.InvalidParameter, "region is required")
+		}
+
+		address, err := client.Addresses.Get(g.CloudProjectID(), region, res.Name).Context(kt.Ctx).Do()
+		if err != nil {
+			return err
+		}
+		req := &compute.RegionSetLabelsRequest{Labels: copyLabels(address.Labels, modify),
+			LabelFingerprint: address.LabelFingerprint}
+		_, err = client.Addresses.SetLabels(g.CloudProjectID(), region, res.Name, req).Context(kt.Ctx).Do()
+		return err
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "gcp %s does not support tag", resType)
+	}
+}
+
+func copyLabels(labels map[string]string, modify func(labels map[string]string)) map[string]string {
+	result := make(map[string]string, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	modify(result)
+	return result
+}
@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	typeargstpl "hcm/pkg/adaptor/types/argument-template"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3/model"
+)
+
+// ListArgsTplAddress 查询IP地址组，对应参数模版中的IP地址模版
+// reference: https://support.huaweicloud.com/api-vpc/ListAddressGroup.html
+func (h *HuaWei) ListArgsTplAddress(kt *kit.Kit, opt *typeargstpl.HuaWeiListOption) (
+	*typeargstpl.HuaWeiListResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.vpcClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new vpc client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := new(model.ListAddressGroupRequest)
+	if len(opt.CloudIDs) != 0 {
+		req.Id = &opt.CloudIDs
+	}
+	if opt.Page != nil {
+		req.Marker = opt.Page.Marker
+		req.Limit = opt.Page.Limit
+	}
+
+	resp, err := client.ListAddressGroup(req)
+	if err != nil {
+		logs.Errorf("list huawei address group failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	groups := make([]typeargstpl.HuaWeiArgsTplAddress, 0, len(cvt.PtrToVal(resp.AddressGroups)))
+	for _, one := range cvt.PtrToVal(resp.AddressGroups) {
+		groups = append(groups, typeargstpl.HuaWeiArgsTplAddress{Region: opt.Region, AddressGroup: one})
+	}
+
+	result := &typeargstpl.HuaWeiListResult{Details: groups}
+	if resp.PageInfo != nil {
+		result.NextMarker = resp.PageInfo.NextMarker
+	}
+
+	return result, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	typecert "hcm/pkg/adaptor/types/cert"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/scm/v3/model"
+)
+
+// ListCert 查询云证书管理服务(SCM)中的证书列表
+// reference: https://support.huaweicloud.com/api-ccm/ListCertificates.html
+func (h *HuaWei) ListCert(kt *kit.Kit, opt *typecert.HuaWeiListOption) (*typecert.HuaWeiListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.scmClient()
+	if err != nil {
+		return nil, fmt.Errorf("new scm client failed, err: %v", err)
+	}
+
+	req := &model.ListCertificatesRequest{
+		Offset: cvt.ValToPtr(opt.Offset),
+		Limit:  cvt.ValToPtr(opt.Limit),
+	}
+	resp, err := client.ListCertificates(req)
+	if err != nil {
+		logs.Errorf("list huawei cert failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	certs := make([]typecert.HuaWeiCert, 0, len(cvt.PtrToVal(resp.Certificates)))
+	for _, one := range cvt.PtrToVal(resp.Certificates) {
+		certs = append(certs, typecert.HuaWeiCert{CertificateDetail: one})
+	}
+
+	return &typecert.HuaWeiListResult{TotalCount: cvt.PtrToVal(resp.TotalCount), Details: certs}, nil
+}
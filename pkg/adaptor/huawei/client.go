@@ -34,6 +34,10 @@ import (
 	dcsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dcs/v2/region"
 	ecs "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2"
 	ecsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/region"
+	elb "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3"
+	elbregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3/region"
+	elbv2 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v2"
+	elbv2region "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v2/region"
 	eip "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/eip/v2"
 	eipregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/eip/v2/region"
 	eipv3 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/eip/v3"
@@ -43,8 +47,12 @@ import (
 	iam "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/iam/v3"
 	iamregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/iam/v3/region"
 	ims "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ims/v2"
+	kps "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/kps/v3"
+	kpsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/kps/v3/region"
 	rms "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/rms/v1"
 	rmsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/rms/v1/region"
+	scm "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/scm/v3"
+	scmregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/scm/v3/region"
 	vpcv2 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2"
 	vpc "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3"
 	vpcregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3/region"
@@ -234,6 +242,23 @@ func (c *clientSet) dcsClient(regionID string) (cli *dcs.DcsClient, err error) {
 	return client, nil
 }
 
+func (c *clientSet) kpsClient(regionID string) (cli *kps.KpsClient, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("huawei error recovered, err: %v", p)
+		}
+	}()
+
+	client := kps.NewKpsClient(
+		kps.KpsClientBuilder().
+			WithRegion(kpsregion.ValueOf(regionID)).
+			WithCredential(c.credentials()).
+			WithHttpConfig(config.DefaultHttpConfig()).
+			Build())
+
+	return client, nil
+}
+
 func (c *clientSet) eipClient(regionID string) (cli *eip.EipClient, err error) {
 	defer func() {
 		if p := recover(); p != nil {
@@ -316,3 +341,56 @@ func (c *clientSet) newRmsClient() (cli *rms.RmsClient, err error) {
 
 	return client, nil
 }
+
+func (c *clientSet) elbClient(regionID string) (cli *elb.ElbClient, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("huawei error recovered, err: %v", p)
+		}
+	}()
+
+	cli = elb.NewElbClient(
+		elb.ElbClientBuilder().
+			WithRegion(elbregion.ValueOf(regionID)).
+			WithCredential(c.credentials()).
+			WithHttpConfig(config.DefaultHttpConfig()).
+			Build())
+
+	return cli, nil
+}
+
+// elbClientV2 负载均衡标签接口仅在v2版本提供
+func (c *clientSet) elbClientV2(regionID string) (cli *elbv2.ElbClient, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("huawei error recovered, err: %v", p)
+		}
+	}()
+
+	cli = elbv2.NewElbClient(
+		elbv2.ElbClientBuilder().
+			WithRegion(elbv2region.ValueOf(regionID)).
+			WithCredential(c.credentials()).
+			WithHttpConfig(config.DefaultHttpConfig()).
+			Build())
+
+	return cli, nil
+}
+
+// scmClient 云证书管理为全局服务，统一使用 cn-north-4 终端节点
+func (c *clientSet) scmClient() (cli *scm.ScmClient, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("huawei error recovered, err: %v", p)
+		}
+	}()
+
+	cli = scm.NewScmClient(
+		scm.ScmClientBuilder().
+			WithRegion(scmregion.CN_NORTH_4).
+			WithCredential(c.globalCredentials()).
+			WithHttpConfig(config.DefaultHttpConfig()).
+			Build())
+
+	return cli, nil
+}
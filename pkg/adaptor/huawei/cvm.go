@@ -603,6 +603,12 @@ func (h *HuaWei) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*polle
 		},
 	}
 
+	// 密码与SSH密钥对二选一，使用SSH密钥对登录时不设置密码
+	if len(opt.KeyName) != 0 {
+		req.Body.Server.KeyName = converter.ValToPtr(opt.KeyName)
+		req.Body.Server.AdminPass = nil
+	}
+
 	if opt.PublicIPAssigned {
 		mode, err := opt.Eip.ChargingMode.EipChargingMode()
 		if err != nil {
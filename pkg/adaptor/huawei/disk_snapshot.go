@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"strings"
+
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// ListDiskSnapshot 查询云硬盘快照列表
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2046.html
+func (h *HuaWei) ListDiskSnapshot(kt *kit.Kit, opt *typesnapshot.HuaWeiSnapshotListOption) (
+	[]typesnapshot.HuaWeiSnapshot, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "huawei disk snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]*model.ListSnapshotsRequest, 0)
+	if len(opt.CloudIDs) > 0 {
+		for _, id := range opt.CloudIDs {
+			reqs = append(reqs, &model.ListSnapshotsRequest{Id: converter.ValToPtr(id)})
+		}
+	} else {
+		req := new(model.ListSnapshotsRequest)
+		if opt.Page != nil {
+			req.Offset = converter.ValToPtr(opt.Page.Offset)
+			req.Limit = converter.ValToPtr(opt.Page.Limit)
+		}
+		reqs = append(reqs, req)
+	}
+
+	snapshots := make([]typesnapshot.HuaWeiSnapshot, 0)
+	for _, req := range reqs {
+		resp, err := client.ListSnapshots(req)
+		if err != nil {
+			if strings.Contains(err.Error(), ErrDataNotFound) {
+				continue
+			}
+			logs.Errorf("list huawei disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range converter.PtrToVal(resp.Snapshots) {
+			snapshots = append(snapshots, typesnapshot.HuaWeiSnapshot{SnapshotList: one})
+		}
+	}
+
+	return snapshots, nil
+}
+
+// CreateDiskSnapshot 创建云硬盘快照，返回快照云上ID
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2043.html
+func (h *HuaWei) CreateDiskSnapshot(kt *kit.Kit, opt *typesnapshot.HuaWeiSnapshotCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "huawei disk snapshot create option is required")
+	}
+
+	req, err := opt.ToCreateSnapshotRequest()
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.CreateSnapshot(req)
+	if err != nil {
+		logs.Errorf("create huawei disk snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return "", err
+	}
+
+	if resp.Snapshot == nil {
+		return "", errf.New(errf.Aborted, "create huawei disk snapshot return snapshot is empty")
+	}
+
+	return converter.PtrToVal(resp.Snapshot.Id), nil
+}
+
+// DeleteDiskSnapshot 删除云硬盘快照
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2045.html
+func (h *HuaWei) DeleteDiskSnapshot(kt *kit.Kit, opt *typesnapshot.HuaWeiSnapshotDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "huawei disk snapshot delete option is required")
+	}
+
+	req, err := opt.ToDeleteSnapshotRequest()
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteSnapshot(req); err != nil {
+		logs.Errorf("delete huawei disk snapshot failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RollbackDiskSnapshot 回滚快照到云硬盘
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2047.html
+func (h *HuaWei) RollbackDiskSnapshot(kt *kit.Kit, opt *typesnapshot.HuaWeiSnapshotRollbackOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "huawei disk snapshot rollback option is required")
+	}
+
+	req, err := opt.ToRollbackSnapshotRequest()
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.RollbackSnapshot(req); err != nil {
+		logs.Errorf("rollback huawei disk snapshot failed, err: %v, snapshot: %s, disk: %s, rid: %s", err,
+			opt.CloudID, opt.CloudDiskID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
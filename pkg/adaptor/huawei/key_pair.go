@@ -0,0 +1,181 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/kps/v3/model"
+)
+
+// ListKeyPair 查询指定地域下的SSH密钥对，云上接口通过marker分页，这里会查询全部密钥对
+func (h *HuaWei) ListKeyPair(kt *kit.Kit, opt *typekeypair.HuaWeiKeyPairListOption) ([]typekeypair.HuaWeiKeyPair,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "huawei key pair list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.kpsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	nameMap := converter.StringSliceToMap(opt.CloudIDs)
+	keyPairs := make([]typekeypair.HuaWeiKeyPair, 0)
+	req := new(model.ListKeypairsRequest)
+	for {
+		resp, err := client.ListKeypairs(req)
+		if err != nil {
+			logs.Errorf("list huawei key pair failed, err: %v, region: %s, rid: %s", err, opt.Region, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range converter.PtrToVal(resp.Keypairs) {
+			if one.Keypair == nil {
+				continue
+			}
+
+			if _, exist := nameMap[converter.PtrToVal(one.Keypair.Name)]; len(nameMap) != 0 && !exist {
+				continue
+			}
+			keyPairs = append(keyPairs, typekeypair.HuaWeiKeyPair{Keypair: *one.Keypair})
+		}
+
+		if resp.PageInfo == nil || len(converter.PtrToVal(resp.PageInfo.NextMarker)) == 0 {
+			break
+		}
+		req.Marker = resp.PageInfo.NextMarker
+	}
+
+	return keyPairs, nil
+}
+
+// CreateKeyPair 创建或导入SSH密钥对，由云上生成密钥对时返回私钥
+func (h *HuaWei) CreateKeyPair(kt *kit.Kit, opt *typekeypair.HuaWeiKeyPairCreateOption) (
+	*typekeypair.CreateResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "huawei key pair create option is required")
+	}
+
+	req, err := opt.ToCreateKeypairRequest()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.kpsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.CreateKeypair(req)
+	if err != nil {
+		logs.Errorf("create huawei key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return nil, err
+	}
+
+	result := &typekeypair.CreateResult{CloudID: opt.Name, Name: opt.Name}
+	if resp.Keypair != nil {
+		result.PrivateKey = converter.PtrToVal(resp.Keypair.PrivateKey)
+	}
+	return result, nil
+}
+
+// DeleteKeyPair 删除SSH密钥对
+func (h *HuaWei) DeleteKeyPair(kt *kit.Kit, opt *typekeypair.HuaWeiKeyPairDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "huawei key pair delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.kpsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteKeypair(&model.DeleteKeypairRequest{KeypairName: opt.Name}); err != nil {
+		logs.Errorf("delete huawei key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// AssociateKeyPair 实例绑定SSH密钥对，云上为异步任务，接口返回时任务已提交
+func (h *HuaWei) AssociateKeyPair(kt *kit.Kit, opt *typekeypair.HuaWeiKeyPairAssociateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "huawei key pair associate option is required")
+	}
+
+	req, err := opt.ToAssociateKeypairRequest()
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.kpsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.AssociateKeypair(req); err != nil {
+		logs.Errorf("associate huawei key pair failed, err: %v, name: %s, instance: %s, rid: %s", err, opt.Name,
+			opt.CloudInstanceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DisassociateKeyPair 实例解绑SSH密钥对，云上为异步任务，接口返回时任务已提交
+func (h *HuaWei) DisassociateKeyPair(kt *kit.Kit, opt *typekeypair.HuaWeiKeyPairDisassociateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "huawei key pair disassociate option is required")
+	}
+
+	req, err := opt.ToDisassociateKeypairRequest()
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.kpsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DisassociateKeypair(req); err != nil {
+		logs.Errorf("disassociate huawei key pair failed, err: %v, instance: %s, rid: %s", err,
+			opt.CloudInstanceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3/model"
+)
+
+// ListLoadBalancer 查询负载均衡列表
+// reference: https://support.huaweicloud.com/api-elb/ListLoadBalancers.html
+func (h *HuaWei) ListLoadBalancer(kt *kit.Kit, opt *typelb.HuaWeiListOption) (*typelb.HuaWeiListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := new(model.ListLoadBalancersRequest)
+	if len(opt.CloudIDs) != 0 {
+		req.Id = &opt.CloudIDs
+	}
+	if opt.Page != nil {
+		req.Marker = opt.Page.Marker
+		req.Limit = opt.Page.Limit
+	}
+
+	resp, err := client.ListLoadBalancers(req)
+	if err != nil {
+		logs.Errorf("list huawei load balancer failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	lbs := make([]typelb.HuaWeiLoadBalancer, 0, len(cvt.PtrToVal(resp.Loadbalancers)))
+	for _, one := range cvt.PtrToVal(resp.Loadbalancers) {
+		lbs = append(lbs, typelb.HuaWeiLoadBalancer{LoadBalancer: one})
+	}
+
+	result := &typelb.HuaWeiListResult{Details: lbs}
+	if resp.PageInfo != nil {
+		result.NextMarker = resp.PageInfo.NextMarker
+	}
+
+	return result, nil
+}
+
+// ListListener 查询负载均衡下的全部监听器
+// reference: https://support.huaweicloud.com/api-elb/ListListeners.html
+func (h *HuaWei) ListListener(kt *kit.Kit, opt *typelb.HuaWeiListListenersOption) ([]typelb.HuaWeiListener,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &model.ListListenersRequest{Limit: cvt.ValToPtr(int32(typelb.HuaWeiLBPageLimitMax))}
+	if len(opt.LoadBalancerID) != 0 {
+		req.LoadbalancerId = &[]string{opt.LoadBalancerID}
+	}
+	if len(opt.CloudIDs) != 0 {
+		req.Id = &opt.CloudIDs
+	}
+
+	listeners := make([]typelb.HuaWeiListener, 0)
+	for {
+		resp, err := client.ListListeners(req)
+		if err != nil {
+			logs.Errorf("list huawei listener failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range cvt.PtrToVal(resp.Listeners) {
+			listeners = append(listeners, typelb.HuaWeiListener{Listener: one})
+		}
+
+		if resp.PageInfo == nil || resp.PageInfo.NextMarker == nil {
+			break
+		}
+		req.Marker = resp.PageInfo.NextMarker
+	}
+
+	return listeners, nil
+}
+
+// ListPool 查询后端服务器组
+// reference: https://support.huaweicloud.com/api-elb/ListPools.html
+func (h *HuaWei) ListPool(kt *kit.Kit, opt *typelb.HuaWeiListPoolOption) ([]typelb.HuaWeiPool, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &model.ListPoolsRequest{Limit: cvt.ValToPtr(int32(typelb.HuaWeiLBPageLimitMax))}
+	if len(opt.LoadBalancerID) != 0 {
+		req.LoadbalancerId = &[]string{opt.LoadBalancerID}
+	}
+	if len(opt.ListenerID) != 0 {
+		req.ListenerId = &[]string{opt.ListenerID}
+	}
+	if len(opt.CloudIDs) != 0 {
+		req.Id = &opt.CloudIDs
+	}
+
+	pools := make([]typelb.HuaWeiPool, 0)
+	for {
+		resp, err := client.ListPools(req)
+		if err != nil {
+			logs.Errorf("list huawei pool failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range cvt.PtrToVal(resp.Pools) {
+			pools = append(pools, typelb.HuaWeiPool{Pool: one})
+		}
+
+		if resp.PageInfo == nil || resp.PageInfo.NextMarker == nil {
+			break
+		}
+		req.Marker = resp.PageInfo.NextMarker
+	}
+
+	return pools, nil
+}
+
+// ListMember 查询后端服务器组下的后端服务器及其健康状态
+// reference: https://support.huaweicloud.com/api-elb/ListMembers.html
+func (h *HuaWei) ListMember(kt *kit.Kit, opt *typelb.HuaWeiListMemberOption) ([]typelb.HuaWeiMember, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &model.ListMembersRequest{PoolId: opt.PoolID, Limit: cvt.ValToPtr(int32(typelb.HuaWeiLBPageLimitMax))}
+	members := make([]typelb.HuaWeiMember, 0)
+	for {
+		resp, err := client.ListMembers(req)
+		if err != nil {
+			logs.Errorf("list huawei member failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range cvt.PtrToVal(resp.Members) {
+			members = append(members, typelb.HuaWeiMember{PoolID: opt.PoolID, Member: one})
+		}
+
+		if resp.PageInfo == nil || resp.PageInfo.NextMarker == nil {
+			break
+		}
+		req.Marker = resp.PageInfo.NextMarker
+	}
+
+	return members, nil
+}
+
+// ListHealthMonitor 查询健康检查配置
+// reference: https://support.huaweicloud.com/api-elb/ListHealthMonitors.html
+func (h *HuaWei) ListHealthMonitor(kt *kit.Kit, opt *typelb.HuaWeiListHealthMonitorOption) (
+	[]typelb.HuaWeiHealthMonitor, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, region: %s, err: %v", opt.Region, err)
+	}
+
+	req := &model.ListHealthMonitorsRequest{Limit: cvt.ValToPtr(int32(typelb.HuaWeiLBPageLimitMax))}
+	if len(opt.CloudIDs) != 0 {
+		req.Id = &opt.CloudIDs
+	}
+
+	monitors := make([]typelb.HuaWeiHealthMonitor, 0)
+	for {
+		resp, err := client.ListHealthMonitors(req)
+		if err != nil {
+			logs.Errorf("list huawei health monitor failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range cvt.PtrToVal(resp.Healthmonitors) {
+			monitors = append(monitors, typelb.HuaWeiHealthMonitor{HealthMonitor: one})
+		}
+
+		if resp.PageInfo == nil || resp.PageInfo.NextMarker == nil {
+			break
+		}
+		req.Marker = resp.PageInfo.NextMarker
+	}
+
+	return monitors, nil
+}
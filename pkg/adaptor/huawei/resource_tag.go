@@ -0,0 +1,313 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	resourcetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	ecsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
+	elbmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v2/model"
+	evsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+	vpcmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2/model"
+	vpcv3model "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3/model"
+)
+
+// TagResources 为资源添加标签，标签键已存在时更新标签值，华为云标签接口仅支持单个资源操作
+// reference: https://support.huaweicloud.com/api-ecs/ecs_02_1002.html
+func (h *HuaWei) TagResources(kt *kit.Kit, opt *resourcetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	var tagFunc func(cloudID string) error
+	switch opt.ResType {
+	case enumor.CvmCloudResType:
+		tagFunc = h.tagCvmFunc(opt)
+	case enumor.DiskCloudResType:
+		tagFunc = h.tagDiskFunc(opt)
+	case enumor.VpcCloudResType:
+		tagFunc = h.tagVpcFunc(opt)
+	case enumor.LoadBalancerCloudResType:
+		tagFunc = h.tagLoadBalancerFunc(opt)
+	default:
+		return errf.Newf(errf.InvalidParameter, "huawei %s does not support tag", opt.ResType)
+	}
+
+	for _, one := range opt.Resources {
+		if err := tagFunc(one.CloudID); err != nil {
+			logs.Errorf("create huawei %s tags failed, err: %v, id: %s, rid: %s", opt.ResType, err, one.CloudID,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) tagCvmFunc(opt *resourcetag.TagResOption) func(cloudID string) error {
+	tags := make([]ecsmodel.ServerTag, 0, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags = append(tags, ecsmodel.ServerTag{Key: one.Key, Value: one.Value})
+	}
+
+	return func(cloudID string) error {
+		client, err := h.clientSet.ecsClient(opt.Region)
+		if err != nil {
+			return fmt.Errorf("new ecs client failed, err: %v", err)
+		}
+
+		_, err = client.BatchCreateServerTags(&ecsmodel.BatchCreateServerTagsRequest{
+			ServerId: cloudID,
+			Body: &ecsmodel.BatchCreateServerTagsRequestBody{
+				Action: ecsmodel.GetBatchCreateServerTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		})
+		return err
+	}
+}
+
+func (h *HuaWei) tagDiskFunc(opt *resourcetag.TagResOption) func(cloudID string) error {
+	tags := make([]evsmodel.Tag, 0, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags = append(tags, evsmodel.Tag{Key: one.Key, Value: one.Value})
+	}
+
+	return func(cloudID string) error {
+		client, err := h.clientSet.evsClient(opt.Region)
+		if err != nil {
+			return fmt.Errorf("new evs client failed, err: %v", err)
+		}
+
+		_, err = client.BatchCreateVolumeTags(&evsmodel.BatchCreateVolumeTagsRequest{
+			VolumeId: cloudID,
+			Body: &evsmodel.BatchCreateVolumeTagsRequestBody{
+				Action: evsmodel.GetBatchCreateVolumeTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		})
+		return err
+	}
+}
+
+func (h *HuaWei) tagVpcFunc(opt *resourcetag.TagResOption) func(cloudID string) error {
+	tags := make([]vpcmodel.ResourceTag, 0, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags = append(tags, vpcmodel.ResourceTag{Key: one.Key, Value: one.Value})
+	}
+
+	return func(cloudID string) error {
+		client, err := h.clientSet.vpcClientV2(opt.Region)
+		if err != nil {
+			return fmt.Errorf("new vpc client failed, err: %v", err)
+		}
+
+		_, err = client.BatchCreateVpcTags(&vpcmodel.BatchCreateVpcTagsRequest{
+			VpcId: cloudID,
+			Body: &vpcmodel.BatchCreateVpcTagsRequestBody{
+				Action: vpcmodel.GetBatchCreateVpcTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		})
+		return err
+	}
+}
+
+func (h *HuaWei) tagLoadBalancerFunc(opt *resourcetag.TagResOption) func(cloudID string) error {
+	tags := make([]elbmodel.ResourceTag, 0, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags = append(tags, elbmodel.ResourceTag{Key: one.Key, Value: one.Value})
+	}
+
+	return func(cloudID string) error {
+		client, err := h.clientSet.elbClientV2(opt.Region)
+		if err != nil {
+			return fmt.Errorf("new elb client failed, err: %v", err)
+		}
+
+		_, err = client.BatchCreateLoadbalancerTags(&elbmodel.BatchCreateLoadbalancerTagsRequest{
+			LoadbalancerId: cloudID,
+			Body: &elbmodel.BatchCreateLoadbalancerTagsRequestBody{
+				Action: elbmodel.GetBatchCreateLoadbalancerTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		})
+		return err
+	}
+}
+
+// UnTagResources 删除资源的标签
+// reference: https://support.huaweicloud.com/api-ecs/ecs_02_1003.html
+func (h *HuaWei) UnTagResources(kt *kit.Kit, opt *resourcetag.UnTagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	var unTagFunc func(cloudID string) error
+	switch opt.ResType {
+	case enumor.CvmCloudResType:
+		unTagFunc = h.unTagCvmFunc(opt)
+	case enumor.DiskCloudResType:
+		unTagFunc = h.unTagDiskFunc(opt)
+	case enumor.VpcCloudResType:
+		unTagFunc = h.unTagVpcFunc(opt)
+	case enumor.LoadBalancerCloudResType:
+		unTagFunc = h.unTagLoadBalancerFunc(opt)
+	default:
+		return errf.Newf(errf.InvalidParameter, "huawei %s does not support tag", opt.ResType)
+	}
+
+	for _, one := range opt.Resources {
+		if err := unTagFunc(one.CloudID); err != nil {
+			logs.Errorf("delete huawei %s tags failed, err: %v, id: %s, rid: %s", opt.ResType, err, one.CloudID,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) unTagCvmFunc(opt *resourcetag.UnTagResOption) func(cloudID string) error {
+	tags := make([]ecsmodel.ServerTag, 0, len(opt.TagKeys))
+	for _, key := range opt.TagKeys {
+		tags = append(tags, ecsmodel.ServerTag{Key: key})
+	}
+
+	return func(cloudID string) error {
+		client, err := h.clientSet.ecsClient(opt.Region)
+		if err != nil {
+			return fmt.Errorf("new ecs client failed, err: %v", err)
+		}
+
+		_, err = client.BatchDeleteServerTags(&ecsmodel.BatchDeleteServerTagsRequest{
+			ServerId: cloudID,
+			Body: &ecsmodel.BatchDeleteServerTagsRequestBody{
+				Action: ecsmodel.GetBatchDeleteServerTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		})
+		return err
+	}
+}
+
+func (h *HuaWei) unTagDiskFunc(opt *resourcetag.UnTagResOption) func(cloudID string) error {
+	tags := make([]evsmodel.DeleteTagsOption, 0, len(opt.TagKeys))
+	for _, key := range opt.TagKeys {
+		tags = append(tags, evsmodel.DeleteTagsOption{Key: key})
+	}
+
+	return func(cloudID string) error {
+		client, err := h.clientSet.evsClient(opt.Region)
+		if err != nil {
+			return fmt.Errorf("new evs client failed, err: %v", err)
+		}
+
+		_, err = client.BatchDeleteVolumeTags(&evsmodel.BatchDeleteVolumeTagsRequest{
+			VolumeId: cloudID,
+			Body: &evsmodel.BatchDeleteVolumeTagsRequestBody{
+				Action: evsmodel.GetBatchDeleteVolumeTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		})
+		return err
+	}
+}
+
+func (h *HuaWei) unTagVpcFunc(opt *resourcetag.UnTagResOption) func(cloudID string) error {
+	tags := make([]vpcmodel.ResourceTag, 0, len(opt.TagKeys))
+	for _, key := range opt.TagKeys {
+		tags = append(tags, vpcmodel.ResourceTag{Key: key})
+	}
+
+	return func(cloudID string) error {
+		client, err := h.clientSet.vpcClientV2(opt.Region)
+		if err != nil {
+			return fmt.Errorf("new vpc client failed, err: %v", err)
+		}
+
+		_, err = client.BatchDeleteVpcTags(&vpcmodel.BatchDeleteVpcTagsRequest{
+			VpcId: cloudID,
+			Body: &vpcmodel.BatchDeleteVpcTagsRequestBody{
+				Action: vpcmodel.GetBatchDeleteVpcTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		})
+		return err
+	}
+}
+
+func (h *HuaWei) unTagLoadBalancerFunc(opt *resourcetag.UnTagResOption) func(cloudID string) error {
+	tags := make([]elbmodel.ResourceTag, 0, len(opt.TagKeys))
+	for _, key := range opt.TagKeys {
+		tags = append(tags, elbmodel.ResourceTag{Key: key})
+	}
+
+	return func(cloudID string) error {
+		client, err := h.clientSet.elbClientV2(opt.Region)
+		if err != nil {
+			return fmt.Errorf("new elb client failed, err: %v", err)
+		}
+
+		_, err = client.BatchDeleteLoadbalancerTags(&elbmodel.BatchDeleteLoadbalancerTagsRequest{
+			LoadbalancerId: cloudID,
+			Body: &elbmodel.BatchDeleteLoadbalancerTagsRequestBody{
+				Action: elbmodel.GetBatchDeleteLoadbalancerTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		})
+		return err
+	}
+}
+
+func convVpcTags(tags []vpcv3model.Tag) core.TagMap {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagMap := make(core.TagMap, len(tags))
+	for _, one := range tags {
+		tagMap.Set(one.Key, one.Value)
+	}
+	return tagMap
+}
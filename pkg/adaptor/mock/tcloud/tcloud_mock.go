@@ -8,14 +8,21 @@ import (
 	poller "hcm/pkg/adaptor/poller"
 	types "hcm/pkg/adaptor/types"
 	account "hcm/pkg/adaptor/types/account"
+	argstpl "hcm/pkg/adaptor/types/argument-template"
 	bill "hcm/pkg/adaptor/types/bill"
+	cert "hcm/pkg/adaptor/types/cert"
 	core "hcm/pkg/adaptor/types/core"
 	cvm "hcm/pkg/adaptor/types/cvm"
 	disk "hcm/pkg/adaptor/types/disk"
+	disksnapshot "hcm/pkg/adaptor/types/disk-snapshot"
 	eip "hcm/pkg/adaptor/types/eip"
 	image "hcm/pkg/adaptor/types/image"
 	instancetype "hcm/pkg/adaptor/types/instance-type"
+	keypair "hcm/pkg/adaptor/types/key-pair"
+	loadbalancer "hcm/pkg/adaptor/types/load-balancer"
 	region "hcm/pkg/adaptor/types/region"
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	resourcetag "hcm/pkg/adaptor/types/resource-tag"
 	routetable "hcm/pkg/adaptor/types/route-table"
 	securitygroup "hcm/pkg/adaptor/types/security-group"
 	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
@@ -27,6 +34,7 @@ import (
 
 	v20180709 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
 	v20190116 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
+	v20180317 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/clb/v20180317"
 	v20170312 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -92,6 +100,83 @@ func (c *TCloudAssociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipAsso
 	return c
 }
 
+// AssociateKeyPair mocks base method.
+func (m *MockTCloud) AssociateKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairAssociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateKeyPair", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssociateKeyPair indicates an expected call of AssociateKeyPair.
+func (mr *MockTCloudMockRecorder) AssociateKeyPair(kt, opt interface{}) *TCloudAssociateKeyPairCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateKeyPair", reflect.TypeOf((*MockTCloud)(nil).AssociateKeyPair), kt, opt)
+	return &TCloudAssociateKeyPairCall{Call: call}
+}
+
+// TCloudAssociateKeyPairCall wrap *gomock.Call
+type TCloudAssociateKeyPairCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudAssociateKeyPairCall) Return(arg0 error) *TCloudAssociateKeyPairCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudAssociateKeyPairCall) Do(f func(*kit.Kit, *keypair.TCloudKeyPairAssociateOption) error) *TCloudAssociateKeyPairCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudAssociateKeyPairCall) DoAndReturn(f func(*kit.Kit, *keypair.TCloudKeyPairAssociateOption) error) *TCloudAssociateKeyPairCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AssumeRole mocks base method.
+func (m *MockTCloud) AssumeRole(kt *kit.Kit, opt *account.AssumeRoleOption) (*account.RoleCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssumeRole", kt, opt)
+	ret0, _ := ret[0].(*account.RoleCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssumeRole indicates an expected call of AssumeRole.
+func (mr *MockTCloudMockRecorder) AssumeRole(kt, opt interface{}) *TCloudAssumeRoleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssumeRole", reflect.TypeOf((*MockTCloud)(nil).AssumeRole), kt, opt)
+	return &TCloudAssumeRoleCall{Call: call}
+}
+
+// TCloudAssumeRoleCall wrap *gomock.Call
+type TCloudAssumeRoleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudAssumeRoleCall) Return(arg0 *account.RoleCredential, arg1 error) *TCloudAssumeRoleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudAssumeRoleCall) Do(f func(*kit.Kit, *account.AssumeRoleOption) (*account.RoleCredential, error)) *TCloudAssumeRoleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudAssumeRoleCall) DoAndReturn(f func(*kit.Kit, *account.AssumeRoleOption) (*account.RoleCredential, error)) *TCloudAssumeRoleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // AttachDisk mocks base method.
 func (m *MockTCloud) AttachDisk(kt *kit.Kit, opt *disk.TCloudDiskAttachOption) error {
 	m.ctrl.T.Helper()
@@ -130,6 +215,82 @@ func (c *TCloudAttachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskAtta
 	return c
 }
 
+// BatchCvmAssociateSecurityGroups mocks base method.
+func (m *MockTCloud) BatchCvmAssociateSecurityGroups(kt *kit.Kit, opt *cvm.TCloudAssociateSecurityGroupsOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCvmAssociateSecurityGroups", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCvmAssociateSecurityGroups indicates an expected call of BatchCvmAssociateSecurityGroups.
+func (mr *MockTCloudMockRecorder) BatchCvmAssociateSecurityGroups(kt, opt interface{}) *TCloudBatchCvmAssociateSecurityGroupsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCvmAssociateSecurityGroups", reflect.TypeOf((*MockTCloud)(nil).BatchCvmAssociateSecurityGroups), kt, opt)
+	return &TCloudBatchCvmAssociateSecurityGroupsCall{Call: call}
+}
+
+// TCloudBatchCvmAssociateSecurityGroupsCall wrap *gomock.Call
+type TCloudBatchCvmAssociateSecurityGroupsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudBatchCvmAssociateSecurityGroupsCall) Return(arg0 error) *TCloudBatchCvmAssociateSecurityGroupsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudBatchCvmAssociateSecurityGroupsCall) Do(f func(*kit.Kit, *cvm.TCloudAssociateSecurityGroupsOption) error) *TCloudBatchCvmAssociateSecurityGroupsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudBatchCvmAssociateSecurityGroupsCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudAssociateSecurityGroupsOption) error) *TCloudBatchCvmAssociateSecurityGroupsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// BatchUpdateSecurityGroupRule mocks base method.
+func (m *MockTCloud) BatchUpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdateSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchUpdateSecurityGroupRule indicates an expected call of BatchUpdateSecurityGroupRule.
+func (mr *MockTCloudMockRecorder) BatchUpdateSecurityGroupRule(kt, opt interface{}) *TCloudBatchUpdateSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdateSecurityGroupRule", reflect.TypeOf((*MockTCloud)(nil).BatchUpdateSecurityGroupRule), kt, opt)
+	return &TCloudBatchUpdateSecurityGroupRuleCall{Call: call}
+}
+
+// TCloudBatchUpdateSecurityGroupRuleCall wrap *gomock.Call
+type TCloudBatchUpdateSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudBatchUpdateSecurityGroupRuleCall) Return(arg0 error) *TCloudBatchUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudBatchUpdateSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.TCloudUpdateOption) error) *TCloudBatchUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudBatchUpdateSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.TCloudUpdateOption) error) *TCloudBatchUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // CountAccount mocks base method.
 func (m *MockTCloud) CountAccount(kt *kit.Kit) (int32, error) {
 	m.ctrl.T.Helper()
@@ -442,742 +603,1782 @@ func (c *TCloudCountVpcCall) DoAndReturn(f func(*kit.Kit, string) (int32, error)
 	return c
 }
 
-// CreateCvm mocks base method.
-func (m *MockTCloud) CreateCvm(kt *kit.Kit, opt *cvm.TCloudCreateOption) (*poller.BaseDoneResult, error) {
+// CreateArgsTplAddress mocks base method.
+func (m *MockTCloud) CreateArgsTplAddress(kt *kit.Kit, opt *argstpl.TCloudCreateAddressOption) (*v20170312.AddressTemplate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCvm", kt, opt)
-	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret := m.ctrl.Call(m, "CreateArgsTplAddress", kt, opt)
+	ret0, _ := ret[0].(*v20170312.AddressTemplate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCvm indicates an expected call of CreateCvm.
-func (mr *MockTCloudMockRecorder) CreateCvm(kt, opt interface{}) *TCloudCreateCvmCall {
+// CreateArgsTplAddress indicates an expected call of CreateArgsTplAddress.
+func (mr *MockTCloudMockRecorder) CreateArgsTplAddress(kt, opt interface{}) *TCloudCreateArgsTplAddressCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCvm", reflect.TypeOf((*MockTCloud)(nil).CreateCvm), kt, opt)
-	return &TCloudCreateCvmCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArgsTplAddress", reflect.TypeOf((*MockTCloud)(nil).CreateArgsTplAddress), kt, opt)
+	return &TCloudCreateArgsTplAddressCall{Call: call}
 }
 
-// TCloudCreateCvmCall wrap *gomock.Call
-type TCloudCreateCvmCall struct {
+// TCloudCreateArgsTplAddressCall wrap *gomock.Call
+type TCloudCreateArgsTplAddressCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudCreateCvmCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateCvmCall {
+func (c *TCloudCreateArgsTplAddressCall) Return(arg0 *v20170312.AddressTemplate, arg1 error) *TCloudCreateArgsTplAddressCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudCreateCvmCall) Do(f func(*kit.Kit, *cvm.TCloudCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateCvmCall {
+func (c *TCloudCreateArgsTplAddressCall) Do(f func(*kit.Kit, *argstpl.TCloudCreateAddressOption) (*v20170312.AddressTemplate, error)) *TCloudCreateArgsTplAddressCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudCreateCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateCvmCall {
+func (c *TCloudCreateArgsTplAddressCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudCreateAddressOption) (*v20170312.AddressTemplate, error)) *TCloudCreateArgsTplAddressCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CreateDisk mocks base method.
-func (m *MockTCloud) CreateDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (*poller.BaseDoneResult, error) {
+// CreateArgsTplAddressGroup mocks base method.
+func (m *MockTCloud) CreateArgsTplAddressGroup(kt *kit.Kit, opt *argstpl.TCloudCreateAddressGroupOption) (*v20170312.AddressTemplateGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDisk", kt, opt)
-	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret := m.ctrl.Call(m, "CreateArgsTplAddressGroup", kt, opt)
+	ret0, _ := ret[0].(*v20170312.AddressTemplateGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateDisk indicates an expected call of CreateDisk.
-func (mr *MockTCloudMockRecorder) CreateDisk(kt, opt interface{}) *TCloudCreateDiskCall {
+// CreateArgsTplAddressGroup indicates an expected call of CreateArgsTplAddressGroup.
+func (mr *MockTCloudMockRecorder) CreateArgsTplAddressGroup(kt, opt interface{}) *TCloudCreateArgsTplAddressGroupCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDisk", reflect.TypeOf((*MockTCloud)(nil).CreateDisk), kt, opt)
-	return &TCloudCreateDiskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArgsTplAddressGroup", reflect.TypeOf((*MockTCloud)(nil).CreateArgsTplAddressGroup), kt, opt)
+	return &TCloudCreateArgsTplAddressGroupCall{Call: call}
 }
 
-// TCloudCreateDiskCall wrap *gomock.Call
-type TCloudCreateDiskCall struct {
+// TCloudCreateArgsTplAddressGroupCall wrap *gomock.Call
+type TCloudCreateArgsTplAddressGroupCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudCreateDiskCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateDiskCall {
+func (c *TCloudCreateArgsTplAddressGroupCall) Return(arg0 *v20170312.AddressTemplateGroup, arg1 error) *TCloudCreateArgsTplAddressGroupCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudCreateDiskCall) Do(f func(*kit.Kit, *disk.TCloudDiskCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateDiskCall {
+func (c *TCloudCreateArgsTplAddressGroupCall) Do(f func(*kit.Kit, *argstpl.TCloudCreateAddressGroupOption) (*v20170312.AddressTemplateGroup, error)) *TCloudCreateArgsTplAddressGroupCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudCreateDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateDiskCall {
+func (c *TCloudCreateArgsTplAddressGroupCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudCreateAddressGroupOption) (*v20170312.AddressTemplateGroup, error)) *TCloudCreateArgsTplAddressGroupCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CreateEip mocks base method.
-func (m *MockTCloud) CreateEip(kt *kit.Kit, opt *eip.TCloudEipCreateOption) (*poller.BaseDoneResult, error) {
+// CreateArgsTplService mocks base method.
+func (m *MockTCloud) CreateArgsTplService(kt *kit.Kit, opt *argstpl.TCloudCreateServiceOption) (*v20170312.ServiceTemplate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEip", kt, opt)
-	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret := m.ctrl.Call(m, "CreateArgsTplService", kt, opt)
+	ret0, _ := ret[0].(*v20170312.ServiceTemplate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateEip indicates an expected call of CreateEip.
-func (mr *MockTCloudMockRecorder) CreateEip(kt, opt interface{}) *TCloudCreateEipCall {
+// CreateArgsTplService indicates an expected call of CreateArgsTplService.
+func (mr *MockTCloudMockRecorder) CreateArgsTplService(kt, opt interface{}) *TCloudCreateArgsTplServiceCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEip", reflect.TypeOf((*MockTCloud)(nil).CreateEip), kt, opt)
-	return &TCloudCreateEipCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArgsTplService", reflect.TypeOf((*MockTCloud)(nil).CreateArgsTplService), kt, opt)
+	return &TCloudCreateArgsTplServiceCall{Call: call}
 }
 
-// TCloudCreateEipCall wrap *gomock.Call
-type TCloudCreateEipCall struct {
+// TCloudCreateArgsTplServiceCall wrap *gomock.Call
+type TCloudCreateArgsTplServiceCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudCreateEipCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateEipCall {
+func (c *TCloudCreateArgsTplServiceCall) Return(arg0 *v20170312.ServiceTemplate, arg1 error) *TCloudCreateArgsTplServiceCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudCreateEipCall) Do(f func(*kit.Kit, *eip.TCloudEipCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateEipCall {
+func (c *TCloudCreateArgsTplServiceCall) Do(f func(*kit.Kit, *argstpl.TCloudCreateServiceOption) (*v20170312.ServiceTemplate, error)) *TCloudCreateArgsTplServiceCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudCreateEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateEipCall {
+func (c *TCloudCreateArgsTplServiceCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudCreateServiceOption) (*v20170312.ServiceTemplate, error)) *TCloudCreateArgsTplServiceCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CreateSecurityGroup mocks base method.
-func (m *MockTCloud) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudCreateOption) (*v20170312.SecurityGroup, error) {
+// CreateArgsTplServiceGroup mocks base method.
+func (m *MockTCloud) CreateArgsTplServiceGroup(kt *kit.Kit, opt *argstpl.TCloudCreateServiceGroupOption) (*v20170312.ServiceTemplateGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSecurityGroup", kt, opt)
-	ret0, _ := ret[0].(*v20170312.SecurityGroup)
+	ret := m.ctrl.Call(m, "CreateArgsTplServiceGroup", kt, opt)
+	ret0, _ := ret[0].(*v20170312.ServiceTemplateGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSecurityGroup indicates an expected call of CreateSecurityGroup.
-func (mr *MockTCloudMockRecorder) CreateSecurityGroup(kt, opt interface{}) *TCloudCreateSecurityGroupCall {
+// CreateArgsTplServiceGroup indicates an expected call of CreateArgsTplServiceGroup.
+func (mr *MockTCloudMockRecorder) CreateArgsTplServiceGroup(kt, opt interface{}) *TCloudCreateArgsTplServiceGroupCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroup", reflect.TypeOf((*MockTCloud)(nil).CreateSecurityGroup), kt, opt)
-	return &TCloudCreateSecurityGroupCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArgsTplServiceGroup", reflect.TypeOf((*MockTCloud)(nil).CreateArgsTplServiceGroup), kt, opt)
+	return &TCloudCreateArgsTplServiceGroupCall{Call: call}
 }
 
-// TCloudCreateSecurityGroupCall wrap *gomock.Call
-type TCloudCreateSecurityGroupCall struct {
+// TCloudCreateArgsTplServiceGroupCall wrap *gomock.Call
+type TCloudCreateArgsTplServiceGroupCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudCreateSecurityGroupCall) Return(arg0 *v20170312.SecurityGroup, arg1 error) *TCloudCreateSecurityGroupCall {
+func (c *TCloudCreateArgsTplServiceGroupCall) Return(arg0 *v20170312.ServiceTemplateGroup, arg1 error) *TCloudCreateArgsTplServiceGroupCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudCreateSecurityGroupCall) Do(f func(*kit.Kit, *securitygroup.TCloudCreateOption) (*v20170312.SecurityGroup, error)) *TCloudCreateSecurityGroupCall {
+func (c *TCloudCreateArgsTplServiceGroupCall) Do(f func(*kit.Kit, *argstpl.TCloudCreateServiceGroupOption) (*v20170312.ServiceTemplateGroup, error)) *TCloudCreateArgsTplServiceGroupCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudCreateSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudCreateOption) (*v20170312.SecurityGroup, error)) *TCloudCreateSecurityGroupCall {
+func (c *TCloudCreateArgsTplServiceGroupCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudCreateServiceGroupOption) (*v20170312.ServiceTemplateGroup, error)) *TCloudCreateArgsTplServiceGroupCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CreateSecurityGroupRule mocks base method.
-func (m *MockTCloud) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudCreateOption) error {
+// CreateCert mocks base method.
+func (m *MockTCloud) CreateCert(kt *kit.Kit, opt *cert.TCloudCreateOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSecurityGroupRule", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateCert", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateSecurityGroupRule indicates an expected call of CreateSecurityGroupRule.
-func (mr *MockTCloudMockRecorder) CreateSecurityGroupRule(kt, opt interface{}) *TCloudCreateSecurityGroupRuleCall {
+// CreateCert indicates an expected call of CreateCert.
+func (mr *MockTCloudMockRecorder) CreateCert(kt, opt interface{}) *TCloudCreateCertCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroupRule", reflect.TypeOf((*MockTCloud)(nil).CreateSecurityGroupRule), kt, opt)
-	return &TCloudCreateSecurityGroupRuleCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCert", reflect.TypeOf((*MockTCloud)(nil).CreateCert), kt, opt)
+	return &TCloudCreateCertCall{Call: call}
 }
 
-// TCloudCreateSecurityGroupRuleCall wrap *gomock.Call
-type TCloudCreateSecurityGroupRuleCall struct {
+// TCloudCreateCertCall wrap *gomock.Call
+type TCloudCreateCertCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudCreateSecurityGroupRuleCall) Return(arg0 error) *TCloudCreateSecurityGroupRuleCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateCertCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateCertCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudCreateSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.TCloudCreateOption) error) *TCloudCreateSecurityGroupRuleCall {
+func (c *TCloudCreateCertCall) Do(f func(*kit.Kit, *cert.TCloudCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateCertCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudCreateSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.TCloudCreateOption) error) *TCloudCreateSecurityGroupRuleCall {
+func (c *TCloudCreateCertCall) DoAndReturn(f func(*kit.Kit, *cert.TCloudCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateCertCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CreateSubnet mocks base method.
-func (m *MockTCloud) CreateSubnet(kt *kit.Kit, opt *adtysubnet.TCloudSubnetCreateOption) (*adtysubnet.TCloudSubnet, error) {
+// CreateCvm mocks base method.
+func (m *MockTCloud) CreateCvm(kt *kit.Kit, opt *cvm.TCloudCreateOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSubnet", kt, opt)
-	ret0, _ := ret[0].(*adtysubnet.TCloudSubnet)
+	ret := m.ctrl.Call(m, "CreateCvm", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSubnet indicates an expected call of CreateSubnet.
-func (mr *MockTCloudMockRecorder) CreateSubnet(kt, opt interface{}) *TCloudCreateSubnetCall {
+// CreateCvm indicates an expected call of CreateCvm.
+func (mr *MockTCloudMockRecorder) CreateCvm(kt, opt interface{}) *TCloudCreateCvmCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnet", reflect.TypeOf((*MockTCloud)(nil).CreateSubnet), kt, opt)
-	return &TCloudCreateSubnetCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCvm", reflect.TypeOf((*MockTCloud)(nil).CreateCvm), kt, opt)
+	return &TCloudCreateCvmCall{Call: call}
 }
 
-// TCloudCreateSubnetCall wrap *gomock.Call
-type TCloudCreateSubnetCall struct {
+// TCloudCreateCvmCall wrap *gomock.Call
+type TCloudCreateCvmCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudCreateSubnetCall) Return(arg0 *adtysubnet.TCloudSubnet, arg1 error) *TCloudCreateSubnetCall {
+func (c *TCloudCreateCvmCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateCvmCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudCreateSubnetCall) Do(f func(*kit.Kit, *adtysubnet.TCloudSubnetCreateOption) (*adtysubnet.TCloudSubnet, error)) *TCloudCreateSubnetCall {
+func (c *TCloudCreateCvmCall) Do(f func(*kit.Kit, *cvm.TCloudCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateCvmCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudCreateSubnetCall) DoAndReturn(f func(*kit.Kit, *adtysubnet.TCloudSubnetCreateOption) (*adtysubnet.TCloudSubnet, error)) *TCloudCreateSubnetCall {
+func (c *TCloudCreateCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateCvmCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CreateSubnets mocks base method.
-func (m *MockTCloud) CreateSubnets(kt *kit.Kit, opt *adtysubnet.TCloudSubnetsCreateOption) ([]adtysubnet.TCloudSubnet, error) {
+// CreateDisk mocks base method.
+func (m *MockTCloud) CreateDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSubnets", kt, opt)
-	ret0, _ := ret[0].([]adtysubnet.TCloudSubnet)
+	ret := m.ctrl.Call(m, "CreateDisk", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSubnets indicates an expected call of CreateSubnets.
-func (mr *MockTCloudMockRecorder) CreateSubnets(kt, opt interface{}) *TCloudCreateSubnetsCall {
+// CreateDisk indicates an expected call of CreateDisk.
+func (mr *MockTCloudMockRecorder) CreateDisk(kt, opt interface{}) *TCloudCreateDiskCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnets", reflect.TypeOf((*MockTCloud)(nil).CreateSubnets), kt, opt)
-	return &TCloudCreateSubnetsCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDisk", reflect.TypeOf((*MockTCloud)(nil).CreateDisk), kt, opt)
+	return &TCloudCreateDiskCall{Call: call}
 }
 
-// TCloudCreateSubnetsCall wrap *gomock.Call
-type TCloudCreateSubnetsCall struct {
+// TCloudCreateDiskCall wrap *gomock.Call
+type TCloudCreateDiskCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudCreateSubnetsCall) Return(arg0 []adtysubnet.TCloudSubnet, arg1 error) *TCloudCreateSubnetsCall {
+func (c *TCloudCreateDiskCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateDiskCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudCreateSubnetsCall) Do(f func(*kit.Kit, *adtysubnet.TCloudSubnetsCreateOption) ([]adtysubnet.TCloudSubnet, error)) *TCloudCreateSubnetsCall {
+func (c *TCloudCreateDiskCall) Do(f func(*kit.Kit, *disk.TCloudDiskCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateDiskCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudCreateSubnetsCall) DoAndReturn(f func(*kit.Kit, *adtysubnet.TCloudSubnetsCreateOption) ([]adtysubnet.TCloudSubnet, error)) *TCloudCreateSubnetsCall {
+func (c *TCloudCreateDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateDiskCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CreateVpc mocks base method.
-func (m *MockTCloud) CreateVpc(kt *kit.Kit, opt *types.TCloudVpcCreateOption) (*types.TCloudVpc, error) {
+// CreateDiskSnapshot mocks base method.
+func (m *MockTCloud) CreateDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudSnapshotCreateOption) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVpc", kt, opt)
-	ret0, _ := ret[0].(*types.TCloudVpc)
+	ret := m.ctrl.Call(m, "CreateDiskSnapshot", kt, opt)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateVpc indicates an expected call of CreateVpc.
-func (mr *MockTCloudMockRecorder) CreateVpc(kt, opt interface{}) *TCloudCreateVpcCall {
+// CreateDiskSnapshot indicates an expected call of CreateDiskSnapshot.
+func (mr *MockTCloudMockRecorder) CreateDiskSnapshot(kt, opt interface{}) *TCloudCreateDiskSnapshotCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpc", reflect.TypeOf((*MockTCloud)(nil).CreateVpc), kt, opt)
-	return &TCloudCreateVpcCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiskSnapshot", reflect.TypeOf((*MockTCloud)(nil).CreateDiskSnapshot), kt, opt)
+	return &TCloudCreateDiskSnapshotCall{Call: call}
 }
 
-// TCloudCreateVpcCall wrap *gomock.Call
-type TCloudCreateVpcCall struct {
+// TCloudCreateDiskSnapshotCall wrap *gomock.Call
+type TCloudCreateDiskSnapshotCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudCreateVpcCall) Return(arg0 *types.TCloudVpc, arg1 error) *TCloudCreateVpcCall {
+func (c *TCloudCreateDiskSnapshotCall) Return(arg0 string, arg1 error) *TCloudCreateDiskSnapshotCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudCreateVpcCall) Do(f func(*kit.Kit, *types.TCloudVpcCreateOption) (*types.TCloudVpc, error)) *TCloudCreateVpcCall {
+func (c *TCloudCreateDiskSnapshotCall) Do(f func(*kit.Kit, *disksnapshot.TCloudSnapshotCreateOption) (string, error)) *TCloudCreateDiskSnapshotCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudCreateVpcCall) DoAndReturn(f func(*kit.Kit, *types.TCloudVpcCreateOption) (*types.TCloudVpc, error)) *TCloudCreateVpcCall {
+func (c *TCloudCreateDiskSnapshotCall) DoAndReturn(f func(*kit.Kit, *disksnapshot.TCloudSnapshotCreateOption) (string, error)) *TCloudCreateDiskSnapshotCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteCvm mocks base method.
-func (m *MockTCloud) DeleteCvm(kt *kit.Kit, opt *cvm.TCloudDeleteOption) error {
+// CreateEip mocks base method.
+func (m *MockTCloud) CreateEip(kt *kit.Kit, opt *eip.TCloudEipCreateOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCvm", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateEip", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCvm indicates an expected call of DeleteCvm.
-func (mr *MockTCloudMockRecorder) DeleteCvm(kt, opt interface{}) *TCloudDeleteCvmCall {
+// CreateEip indicates an expected call of CreateEip.
+func (mr *MockTCloudMockRecorder) CreateEip(kt, opt interface{}) *TCloudCreateEipCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCvm", reflect.TypeOf((*MockTCloud)(nil).DeleteCvm), kt, opt)
-	return &TCloudDeleteCvmCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEip", reflect.TypeOf((*MockTCloud)(nil).CreateEip), kt, opt)
+	return &TCloudCreateEipCall{Call: call}
 }
 
-// TCloudDeleteCvmCall wrap *gomock.Call
-type TCloudDeleteCvmCall struct {
+// TCloudCreateEipCall wrap *gomock.Call
+type TCloudCreateEipCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDeleteCvmCall) Return(arg0 error) *TCloudDeleteCvmCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateEipCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDeleteCvmCall) Do(f func(*kit.Kit, *cvm.TCloudDeleteOption) error) *TCloudDeleteCvmCall {
+func (c *TCloudCreateEipCall) Do(f func(*kit.Kit, *eip.TCloudEipCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateEipCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDeleteCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudDeleteOption) error) *TCloudDeleteCvmCall {
+func (c *TCloudCreateEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateEipCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteDisk mocks base method.
-func (m *MockTCloud) DeleteDisk(kt *kit.Kit, opt *disk.TCloudDiskDeleteOption) error {
+// CreateKeyPair mocks base method.
+func (m *MockTCloud) CreateKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairCreateOption) (*keypair.CreateResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDisk", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateKeyPair", kt, opt)
+	ret0, _ := ret[0].(*keypair.CreateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteDisk indicates an expected call of DeleteDisk.
-func (mr *MockTCloudMockRecorder) DeleteDisk(kt, opt interface{}) *TCloudDeleteDiskCall {
+// CreateKeyPair indicates an expected call of CreateKeyPair.
+func (mr *MockTCloudMockRecorder) CreateKeyPair(kt, opt interface{}) *TCloudCreateKeyPairCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDisk", reflect.TypeOf((*MockTCloud)(nil).DeleteDisk), kt, opt)
-	return &TCloudDeleteDiskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeyPair", reflect.TypeOf((*MockTCloud)(nil).CreateKeyPair), kt, opt)
+	return &TCloudCreateKeyPairCall{Call: call}
 }
 
-// TCloudDeleteDiskCall wrap *gomock.Call
-type TCloudDeleteDiskCall struct {
+// TCloudCreateKeyPairCall wrap *gomock.Call
+type TCloudCreateKeyPairCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDeleteDiskCall) Return(arg0 error) *TCloudDeleteDiskCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateKeyPairCall) Return(arg0 *keypair.CreateResult, arg1 error) *TCloudCreateKeyPairCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDeleteDiskCall) Do(f func(*kit.Kit, *disk.TCloudDiskDeleteOption) error) *TCloudDeleteDiskCall {
+func (c *TCloudCreateKeyPairCall) Do(f func(*kit.Kit, *keypair.TCloudKeyPairCreateOption) (*keypair.CreateResult, error)) *TCloudCreateKeyPairCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDeleteDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskDeleteOption) error) *TCloudDeleteDiskCall {
+func (c *TCloudCreateKeyPairCall) DoAndReturn(f func(*kit.Kit, *keypair.TCloudKeyPairCreateOption) (*keypair.CreateResult, error)) *TCloudCreateKeyPairCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteEip mocks base method.
-func (m *MockTCloud) DeleteEip(kt *kit.Kit, opt *eip.TCloudEipDeleteOption) error {
+// CreateListener mocks base method.
+func (m *MockTCloud) CreateListener(kt *kit.Kit, opt *loadbalancer.TCloudCreateListenerOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEip", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateListener", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteEip indicates an expected call of DeleteEip.
-func (mr *MockTCloudMockRecorder) DeleteEip(kt, opt interface{}) *TCloudDeleteEipCall {
+// CreateListener indicates an expected call of CreateListener.
+func (mr *MockTCloudMockRecorder) CreateListener(kt, opt interface{}) *TCloudCreateListenerCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEip", reflect.TypeOf((*MockTCloud)(nil).DeleteEip), kt, opt)
-	return &TCloudDeleteEipCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListener", reflect.TypeOf((*MockTCloud)(nil).CreateListener), kt, opt)
+	return &TCloudCreateListenerCall{Call: call}
 }
 
-// TCloudDeleteEipCall wrap *gomock.Call
-type TCloudDeleteEipCall struct {
+// TCloudCreateListenerCall wrap *gomock.Call
+type TCloudCreateListenerCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDeleteEipCall) Return(arg0 error) *TCloudDeleteEipCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateListenerCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateListenerCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDeleteEipCall) Do(f func(*kit.Kit, *eip.TCloudEipDeleteOption) error) *TCloudDeleteEipCall {
+func (c *TCloudCreateListenerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudCreateListenerOption) (*poller.BaseDoneResult, error)) *TCloudCreateListenerCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDeleteEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipDeleteOption) error) *TCloudDeleteEipCall {
+func (c *TCloudCreateListenerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudCreateListenerOption) (*poller.BaseDoneResult, error)) *TCloudCreateListenerCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteRouteTable mocks base method.
-func (m *MockTCloud) DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+// CreateLoadBalancer mocks base method.
+func (m *MockTCloud) CreateLoadBalancer(kt *kit.Kit, opt *loadbalancer.TCloudCreateClbOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRouteTable", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateLoadBalancer", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteRouteTable indicates an expected call of DeleteRouteTable.
-func (mr *MockTCloudMockRecorder) DeleteRouteTable(kt, opt interface{}) *TCloudDeleteRouteTableCall {
+// CreateLoadBalancer indicates an expected call of CreateLoadBalancer.
+func (mr *MockTCloudMockRecorder) CreateLoadBalancer(kt, opt interface{}) *TCloudCreateLoadBalancerCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRouteTable", reflect.TypeOf((*MockTCloud)(nil).DeleteRouteTable), kt, opt)
-	return &TCloudDeleteRouteTableCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockTCloud)(nil).CreateLoadBalancer), kt, opt)
+	return &TCloudCreateLoadBalancerCall{Call: call}
 }
 
-// TCloudDeleteRouteTableCall wrap *gomock.Call
-type TCloudDeleteRouteTableCall struct {
+// TCloudCreateLoadBalancerCall wrap *gomock.Call
+type TCloudCreateLoadBalancerCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDeleteRouteTableCall) Return(arg0 error) *TCloudDeleteRouteTableCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateLoadBalancerCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateLoadBalancerCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDeleteRouteTableCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteRouteTableCall {
+func (c *TCloudCreateLoadBalancerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudCreateClbOption) (*poller.BaseDoneResult, error)) *TCloudCreateLoadBalancerCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDeleteRouteTableCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteRouteTableCall {
+func (c *TCloudCreateLoadBalancerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudCreateClbOption) (*poller.BaseDoneResult, error)) *TCloudCreateLoadBalancerCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteSecurityGroup mocks base method.
-func (m *MockTCloud) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudDeleteOption) error {
+// CreateLoadBalancerSnatIps mocks base method.
+func (m *MockTCloud) CreateLoadBalancerSnatIps(kt *kit.Kit, opt *loadbalancer.TCloudCreateSnatIpOpt) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSecurityGroup", kt, opt)
+	ret := m.ctrl.Call(m, "CreateLoadBalancerSnatIps", kt, opt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteSecurityGroup indicates an expected call of DeleteSecurityGroup.
-func (mr *MockTCloudMockRecorder) DeleteSecurityGroup(kt, opt interface{}) *TCloudDeleteSecurityGroupCall {
+// CreateLoadBalancerSnatIps indicates an expected call of CreateLoadBalancerSnatIps.
+func (mr *MockTCloudMockRecorder) CreateLoadBalancerSnatIps(kt, opt interface{}) *TCloudCreateLoadBalancerSnatIpsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockTCloud)(nil).DeleteSecurityGroup), kt, opt)
-	return &TCloudDeleteSecurityGroupCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancerSnatIps", reflect.TypeOf((*MockTCloud)(nil).CreateLoadBalancerSnatIps), kt, opt)
+	return &TCloudCreateLoadBalancerSnatIpsCall{Call: call}
 }
 
-// TCloudDeleteSecurityGroupCall wrap *gomock.Call
-type TCloudDeleteSecurityGroupCall struct {
+// TCloudCreateLoadBalancerSnatIpsCall wrap *gomock.Call
+type TCloudCreateLoadBalancerSnatIpsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDeleteSecurityGroupCall) Return(arg0 error) *TCloudDeleteSecurityGroupCall {
+func (c *TCloudCreateLoadBalancerSnatIpsCall) Return(arg0 error) *TCloudCreateLoadBalancerSnatIpsCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDeleteSecurityGroupCall) Do(f func(*kit.Kit, *securitygroup.TCloudDeleteOption) error) *TCloudDeleteSecurityGroupCall {
+func (c *TCloudCreateLoadBalancerSnatIpsCall) Do(f func(*kit.Kit, *loadbalancer.TCloudCreateSnatIpOpt) error) *TCloudCreateLoadBalancerSnatIpsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDeleteSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudDeleteOption) error) *TCloudDeleteSecurityGroupCall {
+func (c *TCloudCreateLoadBalancerSnatIpsCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudCreateSnatIpOpt) error) *TCloudCreateLoadBalancerSnatIpsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteSecurityGroupRule mocks base method.
-func (m *MockTCloud) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudDeleteOption) error {
+// CreateRule mocks base method.
+func (m *MockTCloud) CreateRule(kt *kit.Kit, opt *loadbalancer.TCloudCreateRuleOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSecurityGroupRule", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateRule", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteSecurityGroupRule indicates an expected call of DeleteSecurityGroupRule.
-func (mr *MockTCloudMockRecorder) DeleteSecurityGroupRule(kt, opt interface{}) *TCloudDeleteSecurityGroupRuleCall {
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockTCloudMockRecorder) CreateRule(kt, opt interface{}) *TCloudCreateRuleCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroupRule", reflect.TypeOf((*MockTCloud)(nil).DeleteSecurityGroupRule), kt, opt)
-	return &TCloudDeleteSecurityGroupRuleCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockTCloud)(nil).CreateRule), kt, opt)
+	return &TCloudCreateRuleCall{Call: call}
 }
 
-// TCloudDeleteSecurityGroupRuleCall wrap *gomock.Call
-type TCloudDeleteSecurityGroupRuleCall struct {
+// TCloudCreateRuleCall wrap *gomock.Call
+type TCloudCreateRuleCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDeleteSecurityGroupRuleCall) Return(arg0 error) *TCloudDeleteSecurityGroupRuleCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateRuleCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateRuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDeleteSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.TCloudDeleteOption) error) *TCloudDeleteSecurityGroupRuleCall {
+func (c *TCloudCreateRuleCall) Do(f func(*kit.Kit, *loadbalancer.TCloudCreateRuleOption) (*poller.BaseDoneResult, error)) *TCloudCreateRuleCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDeleteSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.TCloudDeleteOption) error) *TCloudDeleteSecurityGroupRuleCall {
+func (c *TCloudCreateRuleCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudCreateRuleOption) (*poller.BaseDoneResult, error)) *TCloudCreateRuleCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteSubnet mocks base method.
-func (m *MockTCloud) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+// CreateSecurityGroup mocks base method.
+func (m *MockTCloud) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudCreateOption) (*v20170312.SecurityGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSubnet", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateSecurityGroup", kt, opt)
+	ret0, _ := ret[0].(*v20170312.SecurityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteSubnet indicates an expected call of DeleteSubnet.
-func (mr *MockTCloudMockRecorder) DeleteSubnet(kt, opt interface{}) *TCloudDeleteSubnetCall {
+// CreateSecurityGroup indicates an expected call of CreateSecurityGroup.
+func (mr *MockTCloudMockRecorder) CreateSecurityGroup(kt, opt interface{}) *TCloudCreateSecurityGroupCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnet", reflect.TypeOf((*MockTCloud)(nil).DeleteSubnet), kt, opt)
-	return &TCloudDeleteSubnetCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroup", reflect.TypeOf((*MockTCloud)(nil).CreateSecurityGroup), kt, opt)
+	return &TCloudCreateSecurityGroupCall{Call: call}
 }
 
-// TCloudDeleteSubnetCall wrap *gomock.Call
-type TCloudDeleteSubnetCall struct {
+// TCloudCreateSecurityGroupCall wrap *gomock.Call
+type TCloudCreateSecurityGroupCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDeleteSubnetCall) Return(arg0 error) *TCloudDeleteSubnetCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateSecurityGroupCall) Return(arg0 *v20170312.SecurityGroup, arg1 error) *TCloudCreateSecurityGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDeleteSubnetCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteSubnetCall {
+func (c *TCloudCreateSecurityGroupCall) Do(f func(*kit.Kit, *securitygroup.TCloudCreateOption) (*v20170312.SecurityGroup, error)) *TCloudCreateSecurityGroupCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDeleteSubnetCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteSubnetCall {
+func (c *TCloudCreateSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudCreateOption) (*v20170312.SecurityGroup, error)) *TCloudCreateSecurityGroupCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteVpc mocks base method.
-func (m *MockTCloud) DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+// CreateSecurityGroupRule mocks base method.
+func (m *MockTCloud) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudCreateOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteVpc", kt, opt)
+	ret := m.ctrl.Call(m, "CreateSecurityGroupRule", kt, opt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteVpc indicates an expected call of DeleteVpc.
-func (mr *MockTCloudMockRecorder) DeleteVpc(kt, opt interface{}) *TCloudDeleteVpcCall {
+// CreateSecurityGroupRule indicates an expected call of CreateSecurityGroupRule.
+func (mr *MockTCloudMockRecorder) CreateSecurityGroupRule(kt, opt interface{}) *TCloudCreateSecurityGroupRuleCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpc", reflect.TypeOf((*MockTCloud)(nil).DeleteVpc), kt, opt)
-	return &TCloudDeleteVpcCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroupRule", reflect.TypeOf((*MockTCloud)(nil).CreateSecurityGroupRule), kt, opt)
+	return &TCloudCreateSecurityGroupRuleCall{Call: call}
 }
 
-// TCloudDeleteVpcCall wrap *gomock.Call
-type TCloudDeleteVpcCall struct {
+// TCloudCreateSecurityGroupRuleCall wrap *gomock.Call
+type TCloudCreateSecurityGroupRuleCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDeleteVpcCall) Return(arg0 error) *TCloudDeleteVpcCall {
+func (c *TCloudCreateSecurityGroupRuleCall) Return(arg0 error) *TCloudCreateSecurityGroupRuleCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDeleteVpcCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteVpcCall {
+func (c *TCloudCreateSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.TCloudCreateOption) error) *TCloudCreateSecurityGroupRuleCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDeleteVpcCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteVpcCall {
+func (c *TCloudCreateSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.TCloudCreateOption) error) *TCloudCreateSecurityGroupRuleCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DetachDisk mocks base method.
-func (m *MockTCloud) DetachDisk(kt *kit.Kit, opt *disk.TCloudDiskDetachOption) error {
+// CreateSubnet mocks base method.
+func (m *MockTCloud) CreateSubnet(kt *kit.Kit, opt *adtysubnet.TCloudSubnetCreateOption) (*adtysubnet.TCloudSubnet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DetachDisk", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateSubnet", kt, opt)
+	ret0, _ := ret[0].(*adtysubnet.TCloudSubnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DetachDisk indicates an expected call of DetachDisk.
-func (mr *MockTCloudMockRecorder) DetachDisk(kt, opt interface{}) *TCloudDetachDiskCall {
+// CreateSubnet indicates an expected call of CreateSubnet.
+func (mr *MockTCloudMockRecorder) CreateSubnet(kt, opt interface{}) *TCloudCreateSubnetCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachDisk", reflect.TypeOf((*MockTCloud)(nil).DetachDisk), kt, opt)
-	return &TCloudDetachDiskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnet", reflect.TypeOf((*MockTCloud)(nil).CreateSubnet), kt, opt)
+	return &TCloudCreateSubnetCall{Call: call}
 }
 
-// TCloudDetachDiskCall wrap *gomock.Call
-type TCloudDetachDiskCall struct {
+// TCloudCreateSubnetCall wrap *gomock.Call
+type TCloudCreateSubnetCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDetachDiskCall) Return(arg0 error) *TCloudDetachDiskCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateSubnetCall) Return(arg0 *adtysubnet.TCloudSubnet, arg1 error) *TCloudCreateSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDetachDiskCall) Do(f func(*kit.Kit, *disk.TCloudDiskDetachOption) error) *TCloudDetachDiskCall {
+func (c *TCloudCreateSubnetCall) Do(f func(*kit.Kit, *adtysubnet.TCloudSubnetCreateOption) (*adtysubnet.TCloudSubnet, error)) *TCloudCreateSubnetCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDetachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskDetachOption) error) *TCloudDetachDiskCall {
+func (c *TCloudCreateSubnetCall) DoAndReturn(f func(*kit.Kit, *adtysubnet.TCloudSubnetCreateOption) (*adtysubnet.TCloudSubnet, error)) *TCloudCreateSubnetCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DetermineIPv6Type mocks base method.
-func (m *MockTCloud) DetermineIPv6Type(kt *kit.Kit, region string, ipv6Addresses []*string) ([]*string, []*string, error) {
+// CreateSubnets mocks base method.
+func (m *MockTCloud) CreateSubnets(kt *kit.Kit, opt *adtysubnet.TCloudSubnetsCreateOption) ([]adtysubnet.TCloudSubnet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DetermineIPv6Type", kt, region, ipv6Addresses)
-	ret0, _ := ret[0].([]*string)
-	ret1, _ := ret[1].([]*string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "CreateSubnets", kt, opt)
+	ret0, _ := ret[0].([]adtysubnet.TCloudSubnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DetermineIPv6Type indicates an expected call of DetermineIPv6Type.
-func (mr *MockTCloudMockRecorder) DetermineIPv6Type(kt, region, ipv6Addresses interface{}) *TCloudDetermineIPv6TypeCall {
+// CreateSubnets indicates an expected call of CreateSubnets.
+func (mr *MockTCloudMockRecorder) CreateSubnets(kt, opt interface{}) *TCloudCreateSubnetsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetermineIPv6Type", reflect.TypeOf((*MockTCloud)(nil).DetermineIPv6Type), kt, region, ipv6Addresses)
-	return &TCloudDetermineIPv6TypeCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnets", reflect.TypeOf((*MockTCloud)(nil).CreateSubnets), kt, opt)
+	return &TCloudCreateSubnetsCall{Call: call}
 }
 
-// TCloudDetermineIPv6TypeCall wrap *gomock.Call
-type TCloudDetermineIPv6TypeCall struct {
+// TCloudCreateSubnetsCall wrap *gomock.Call
+type TCloudCreateSubnetsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDetermineIPv6TypeCall) Return(arg0, arg1 []*string, arg2 error) *TCloudDetermineIPv6TypeCall {
-	c.Call = c.Call.Return(arg0, arg1, arg2)
+func (c *TCloudCreateSubnetsCall) Return(arg0 []adtysubnet.TCloudSubnet, arg1 error) *TCloudCreateSubnetsCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDetermineIPv6TypeCall) Do(f func(*kit.Kit, string, []*string) ([]*string, []*string, error)) *TCloudDetermineIPv6TypeCall {
+func (c *TCloudCreateSubnetsCall) Do(f func(*kit.Kit, *adtysubnet.TCloudSubnetsCreateOption) ([]adtysubnet.TCloudSubnet, error)) *TCloudCreateSubnetsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDetermineIPv6TypeCall) DoAndReturn(f func(*kit.Kit, string, []*string) ([]*string, []*string, error)) *TCloudDetermineIPv6TypeCall {
+func (c *TCloudCreateSubnetsCall) DoAndReturn(f func(*kit.Kit, *adtysubnet.TCloudSubnetsCreateOption) ([]adtysubnet.TCloudSubnet, error)) *TCloudCreateSubnetsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DisassociateEip mocks base method.
-func (m *MockTCloud) DisassociateEip(kt *kit.Kit, opt *eip.TCloudEipDisassociateOption) error {
+// CreateVpc mocks base method.
+func (m *MockTCloud) CreateVpc(kt *kit.Kit, opt *types.TCloudVpcCreateOption) (*types.TCloudVpc, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DisassociateEip", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateVpc", kt, opt)
+	ret0, _ := ret[0].(*types.TCloudVpc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DisassociateEip indicates an expected call of DisassociateEip.
-func (mr *MockTCloudMockRecorder) DisassociateEip(kt, opt interface{}) *TCloudDisassociateEipCall {
+// CreateVpc indicates an expected call of CreateVpc.
+func (mr *MockTCloudMockRecorder) CreateVpc(kt, opt interface{}) *TCloudCreateVpcCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateEip", reflect.TypeOf((*MockTCloud)(nil).DisassociateEip), kt, opt)
-	return &TCloudDisassociateEipCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpc", reflect.TypeOf((*MockTCloud)(nil).CreateVpc), kt, opt)
+	return &TCloudCreateVpcCall{Call: call}
 }
 
-// TCloudDisassociateEipCall wrap *gomock.Call
-type TCloudDisassociateEipCall struct {
+// TCloudCreateVpcCall wrap *gomock.Call
+type TCloudCreateVpcCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudDisassociateEipCall) Return(arg0 error) *TCloudDisassociateEipCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudCreateVpcCall) Return(arg0 *types.TCloudVpc, arg1 error) *TCloudCreateVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudDisassociateEipCall) Do(f func(*kit.Kit, *eip.TCloudEipDisassociateOption) error) *TCloudDisassociateEipCall {
+func (c *TCloudCreateVpcCall) Do(f func(*kit.Kit, *types.TCloudVpcCreateOption) (*types.TCloudVpc, error)) *TCloudCreateVpcCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudDisassociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipDisassociateOption) error) *TCloudDisassociateEipCall {
+func (c *TCloudCreateVpcCall) DoAndReturn(f func(*kit.Kit, *types.TCloudVpcCreateOption) (*types.TCloudVpc, error)) *TCloudCreateVpcCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetAccountInfoBySecret mocks base method.
-func (m *MockTCloud) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.TCloudInfoBySecret, error) {
+// DeRegisterTargets mocks base method.
+func (m *MockTCloud) DeRegisterTargets(kt *kit.Kit, opt *loadbalancer.TCloudRegisterTargetsOption) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountInfoBySecret", kt)
-	ret0, _ := ret[0].(*cloud.TCloudInfoBySecret)
+	ret := m.ctrl.Call(m, "DeRegisterTargets", kt, opt)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeRegisterTargets indicates an expected call of DeRegisterTargets.
+func (mr *MockTCloudMockRecorder) DeRegisterTargets(kt, opt interface{}) *TCloudDeRegisterTargetsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeRegisterTargets", reflect.TypeOf((*MockTCloud)(nil).DeRegisterTargets), kt, opt)
+	return &TCloudDeRegisterTargetsCall{Call: call}
+}
+
+// TCloudDeRegisterTargetsCall wrap *gomock.Call
+type TCloudDeRegisterTargetsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeRegisterTargetsCall) Return(arg0 []string, arg1 error) *TCloudDeRegisterTargetsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeRegisterTargetsCall) Do(f func(*kit.Kit, *loadbalancer.TCloudRegisterTargetsOption) ([]string, error)) *TCloudDeRegisterTargetsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeRegisterTargetsCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudRegisterTargetsOption) ([]string, error)) *TCloudDeRegisterTargetsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteArgsTplAddress mocks base method.
+func (m *MockTCloud) DeleteArgsTplAddress(kt *kit.Kit, opt *argstpl.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArgsTplAddress", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArgsTplAddress indicates an expected call of DeleteArgsTplAddress.
+func (mr *MockTCloudMockRecorder) DeleteArgsTplAddress(kt, opt interface{}) *TCloudDeleteArgsTplAddressCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArgsTplAddress", reflect.TypeOf((*MockTCloud)(nil).DeleteArgsTplAddress), kt, opt)
+	return &TCloudDeleteArgsTplAddressCall{Call: call}
+}
+
+// TCloudDeleteArgsTplAddressCall wrap *gomock.Call
+type TCloudDeleteArgsTplAddressCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteArgsTplAddressCall) Return(arg0 error) *TCloudDeleteArgsTplAddressCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteArgsTplAddressCall) Do(f func(*kit.Kit, *argstpl.TCloudDeleteOption) error) *TCloudDeleteArgsTplAddressCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteArgsTplAddressCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudDeleteOption) error) *TCloudDeleteArgsTplAddressCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteArgsTplAddressGroup mocks base method.
+func (m *MockTCloud) DeleteArgsTplAddressGroup(kt *kit.Kit, opt *argstpl.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArgsTplAddressGroup", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArgsTplAddressGroup indicates an expected call of DeleteArgsTplAddressGroup.
+func (mr *MockTCloudMockRecorder) DeleteArgsTplAddressGroup(kt, opt interface{}) *TCloudDeleteArgsTplAddressGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArgsTplAddressGroup", reflect.TypeOf((*MockTCloud)(nil).DeleteArgsTplAddressGroup), kt, opt)
+	return &TCloudDeleteArgsTplAddressGroupCall{Call: call}
+}
+
+// TCloudDeleteArgsTplAddressGroupCall wrap *gomock.Call
+type TCloudDeleteArgsTplAddressGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteArgsTplAddressGroupCall) Return(arg0 error) *TCloudDeleteArgsTplAddressGroupCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteArgsTplAddressGroupCall) Do(f func(*kit.Kit, *argstpl.TCloudDeleteOption) error) *TCloudDeleteArgsTplAddressGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteArgsTplAddressGroupCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudDeleteOption) error) *TCloudDeleteArgsTplAddressGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteArgsTplService mocks base method.
+func (m *MockTCloud) DeleteArgsTplService(kt *kit.Kit, opt *argstpl.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArgsTplService", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArgsTplService indicates an expected call of DeleteArgsTplService.
+func (mr *MockTCloudMockRecorder) DeleteArgsTplService(kt, opt interface{}) *TCloudDeleteArgsTplServiceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArgsTplService", reflect.TypeOf((*MockTCloud)(nil).DeleteArgsTplService), kt, opt)
+	return &TCloudDeleteArgsTplServiceCall{Call: call}
+}
+
+// TCloudDeleteArgsTplServiceCall wrap *gomock.Call
+type TCloudDeleteArgsTplServiceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteArgsTplServiceCall) Return(arg0 error) *TCloudDeleteArgsTplServiceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteArgsTplServiceCall) Do(f func(*kit.Kit, *argstpl.TCloudDeleteOption) error) *TCloudDeleteArgsTplServiceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteArgsTplServiceCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudDeleteOption) error) *TCloudDeleteArgsTplServiceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteArgsTplServiceGroup mocks base method.
+func (m *MockTCloud) DeleteArgsTplServiceGroup(kt *kit.Kit, opt *argstpl.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArgsTplServiceGroup", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArgsTplServiceGroup indicates an expected call of DeleteArgsTplServiceGroup.
+func (mr *MockTCloudMockRecorder) DeleteArgsTplServiceGroup(kt, opt interface{}) *TCloudDeleteArgsTplServiceGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArgsTplServiceGroup", reflect.TypeOf((*MockTCloud)(nil).DeleteArgsTplServiceGroup), kt, opt)
+	return &TCloudDeleteArgsTplServiceGroupCall{Call: call}
+}
+
+// TCloudDeleteArgsTplServiceGroupCall wrap *gomock.Call
+type TCloudDeleteArgsTplServiceGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteArgsTplServiceGroupCall) Return(arg0 error) *TCloudDeleteArgsTplServiceGroupCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteArgsTplServiceGroupCall) Do(f func(*kit.Kit, *argstpl.TCloudDeleteOption) error) *TCloudDeleteArgsTplServiceGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteArgsTplServiceGroupCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudDeleteOption) error) *TCloudDeleteArgsTplServiceGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteCert mocks base method.
+func (m *MockTCloud) DeleteCert(kt *kit.Kit, opt *cert.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCert", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCert indicates an expected call of DeleteCert.
+func (mr *MockTCloudMockRecorder) DeleteCert(kt, opt interface{}) *TCloudDeleteCertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCert", reflect.TypeOf((*MockTCloud)(nil).DeleteCert), kt, opt)
+	return &TCloudDeleteCertCall{Call: call}
+}
+
+// TCloudDeleteCertCall wrap *gomock.Call
+type TCloudDeleteCertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteCertCall) Return(arg0 error) *TCloudDeleteCertCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteCertCall) Do(f func(*kit.Kit, *cert.TCloudDeleteOption) error) *TCloudDeleteCertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteCertCall) DoAndReturn(f func(*kit.Kit, *cert.TCloudDeleteOption) error) *TCloudDeleteCertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteCvm mocks base method.
+func (m *MockTCloud) DeleteCvm(kt *kit.Kit, opt *cvm.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCvm indicates an expected call of DeleteCvm.
+func (mr *MockTCloudMockRecorder) DeleteCvm(kt, opt interface{}) *TCloudDeleteCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCvm", reflect.TypeOf((*MockTCloud)(nil).DeleteCvm), kt, opt)
+	return &TCloudDeleteCvmCall{Call: call}
+}
+
+// TCloudDeleteCvmCall wrap *gomock.Call
+type TCloudDeleteCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteCvmCall) Return(arg0 error) *TCloudDeleteCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteCvmCall) Do(f func(*kit.Kit, *cvm.TCloudDeleteOption) error) *TCloudDeleteCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudDeleteOption) error) *TCloudDeleteCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteDisk mocks base method.
+func (m *MockTCloud) DeleteDisk(kt *kit.Kit, opt *disk.TCloudDiskDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDisk indicates an expected call of DeleteDisk.
+func (mr *MockTCloudMockRecorder) DeleteDisk(kt, opt interface{}) *TCloudDeleteDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDisk", reflect.TypeOf((*MockTCloud)(nil).DeleteDisk), kt, opt)
+	return &TCloudDeleteDiskCall{Call: call}
+}
+
+// TCloudDeleteDiskCall wrap *gomock.Call
+type TCloudDeleteDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteDiskCall) Return(arg0 error) *TCloudDeleteDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteDiskCall) Do(f func(*kit.Kit, *disk.TCloudDiskDeleteOption) error) *TCloudDeleteDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskDeleteOption) error) *TCloudDeleteDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteDiskSnapshot mocks base method.
+func (m *MockTCloud) DeleteDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudSnapshotDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDiskSnapshot", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDiskSnapshot indicates an expected call of DeleteDiskSnapshot.
+func (mr *MockTCloudMockRecorder) DeleteDiskSnapshot(kt, opt interface{}) *TCloudDeleteDiskSnapshotCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDiskSnapshot", reflect.TypeOf((*MockTCloud)(nil).DeleteDiskSnapshot), kt, opt)
+	return &TCloudDeleteDiskSnapshotCall{Call: call}
+}
+
+// TCloudDeleteDiskSnapshotCall wrap *gomock.Call
+type TCloudDeleteDiskSnapshotCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteDiskSnapshotCall) Return(arg0 error) *TCloudDeleteDiskSnapshotCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteDiskSnapshotCall) Do(f func(*kit.Kit, *disksnapshot.TCloudSnapshotDeleteOption) error) *TCloudDeleteDiskSnapshotCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteDiskSnapshotCall) DoAndReturn(f func(*kit.Kit, *disksnapshot.TCloudSnapshotDeleteOption) error) *TCloudDeleteDiskSnapshotCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteEip mocks base method.
+func (m *MockTCloud) DeleteEip(kt *kit.Kit, opt *eip.TCloudEipDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEip indicates an expected call of DeleteEip.
+func (mr *MockTCloudMockRecorder) DeleteEip(kt, opt interface{}) *TCloudDeleteEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEip", reflect.TypeOf((*MockTCloud)(nil).DeleteEip), kt, opt)
+	return &TCloudDeleteEipCall{Call: call}
+}
+
+// TCloudDeleteEipCall wrap *gomock.Call
+type TCloudDeleteEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteEipCall) Return(arg0 error) *TCloudDeleteEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteEipCall) Do(f func(*kit.Kit, *eip.TCloudEipDeleteOption) error) *TCloudDeleteEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipDeleteOption) error) *TCloudDeleteEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteKeyPair mocks base method.
+func (m *MockTCloud) DeleteKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKeyPair", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKeyPair indicates an expected call of DeleteKeyPair.
+func (mr *MockTCloudMockRecorder) DeleteKeyPair(kt, opt interface{}) *TCloudDeleteKeyPairCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeyPair", reflect.TypeOf((*MockTCloud)(nil).DeleteKeyPair), kt, opt)
+	return &TCloudDeleteKeyPairCall{Call: call}
+}
+
+// TCloudDeleteKeyPairCall wrap *gomock.Call
+type TCloudDeleteKeyPairCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteKeyPairCall) Return(arg0 error) *TCloudDeleteKeyPairCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteKeyPairCall) Do(f func(*kit.Kit, *keypair.TCloudKeyPairDeleteOption) error) *TCloudDeleteKeyPairCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteKeyPairCall) DoAndReturn(f func(*kit.Kit, *keypair.TCloudKeyPairDeleteOption) error) *TCloudDeleteKeyPairCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteListener mocks base method.
+func (m *MockTCloud) DeleteListener(kt *kit.Kit, opt *loadbalancer.TCloudDeleteListenerOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListener", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteListener indicates an expected call of DeleteListener.
+func (mr *MockTCloudMockRecorder) DeleteListener(kt, opt interface{}) *TCloudDeleteListenerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListener", reflect.TypeOf((*MockTCloud)(nil).DeleteListener), kt, opt)
+	return &TCloudDeleteListenerCall{Call: call}
+}
+
+// TCloudDeleteListenerCall wrap *gomock.Call
+type TCloudDeleteListenerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteListenerCall) Return(arg0 error) *TCloudDeleteListenerCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteListenerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudDeleteListenerOption) error) *TCloudDeleteListenerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteListenerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudDeleteListenerOption) error) *TCloudDeleteListenerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteLoadBalancer mocks base method.
+func (m *MockTCloud) DeleteLoadBalancer(kt *kit.Kit, opt *loadbalancer.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoadBalancer", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoadBalancer indicates an expected call of DeleteLoadBalancer.
+func (mr *MockTCloudMockRecorder) DeleteLoadBalancer(kt, opt interface{}) *TCloudDeleteLoadBalancerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancer", reflect.TypeOf((*MockTCloud)(nil).DeleteLoadBalancer), kt, opt)
+	return &TCloudDeleteLoadBalancerCall{Call: call}
+}
+
+// TCloudDeleteLoadBalancerCall wrap *gomock.Call
+type TCloudDeleteLoadBalancerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteLoadBalancerCall) Return(arg0 error) *TCloudDeleteLoadBalancerCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteLoadBalancerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudDeleteOption) error) *TCloudDeleteLoadBalancerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteLoadBalancerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudDeleteOption) error) *TCloudDeleteLoadBalancerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteLoadBalancerSnatIps mocks base method.
+func (m *MockTCloud) DeleteLoadBalancerSnatIps(kt *kit.Kit, opt *loadbalancer.TCloudDeleteSnatIpOpt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoadBalancerSnatIps", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoadBalancerSnatIps indicates an expected call of DeleteLoadBalancerSnatIps.
+func (mr *MockTCloudMockRecorder) DeleteLoadBalancerSnatIps(kt, opt interface{}) *TCloudDeleteLoadBalancerSnatIpsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancerSnatIps", reflect.TypeOf((*MockTCloud)(nil).DeleteLoadBalancerSnatIps), kt, opt)
+	return &TCloudDeleteLoadBalancerSnatIpsCall{Call: call}
+}
+
+// TCloudDeleteLoadBalancerSnatIpsCall wrap *gomock.Call
+type TCloudDeleteLoadBalancerSnatIpsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteLoadBalancerSnatIpsCall) Return(arg0 error) *TCloudDeleteLoadBalancerSnatIpsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteLoadBalancerSnatIpsCall) Do(f func(*kit.Kit, *loadbalancer.TCloudDeleteSnatIpOpt) error) *TCloudDeleteLoadBalancerSnatIpsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteLoadBalancerSnatIpsCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudDeleteSnatIpOpt) error) *TCloudDeleteLoadBalancerSnatIpsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteRouteTable mocks base method.
+func (m *MockTCloud) DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRouteTable", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRouteTable indicates an expected call of DeleteRouteTable.
+func (mr *MockTCloudMockRecorder) DeleteRouteTable(kt, opt interface{}) *TCloudDeleteRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRouteTable", reflect.TypeOf((*MockTCloud)(nil).DeleteRouteTable), kt, opt)
+	return &TCloudDeleteRouteTableCall{Call: call}
+}
+
+// TCloudDeleteRouteTableCall wrap *gomock.Call
+type TCloudDeleteRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteRouteTableCall) Return(arg0 error) *TCloudDeleteRouteTableCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteRouteTableCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteRouteTableCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteRule mocks base method.
+func (m *MockTCloud) DeleteRule(kt *kit.Kit, opt *loadbalancer.TCloudDeleteRuleOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockTCloudMockRecorder) DeleteRule(kt, opt interface{}) *TCloudDeleteRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockTCloud)(nil).DeleteRule), kt, opt)
+	return &TCloudDeleteRuleCall{Call: call}
+}
+
+// TCloudDeleteRuleCall wrap *gomock.Call
+type TCloudDeleteRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteRuleCall) Return(arg0 error) *TCloudDeleteRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteRuleCall) Do(f func(*kit.Kit, *loadbalancer.TCloudDeleteRuleOption) error) *TCloudDeleteRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteRuleCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudDeleteRuleOption) error) *TCloudDeleteRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSecurityGroup mocks base method.
+func (m *MockTCloud) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecurityGroup", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecurityGroup indicates an expected call of DeleteSecurityGroup.
+func (mr *MockTCloudMockRecorder) DeleteSecurityGroup(kt, opt interface{}) *TCloudDeleteSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockTCloud)(nil).DeleteSecurityGroup), kt, opt)
+	return &TCloudDeleteSecurityGroupCall{Call: call}
+}
+
+// TCloudDeleteSecurityGroupCall wrap *gomock.Call
+type TCloudDeleteSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteSecurityGroupCall) Return(arg0 error) *TCloudDeleteSecurityGroupCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteSecurityGroupCall) Do(f func(*kit.Kit, *securitygroup.TCloudDeleteOption) error) *TCloudDeleteSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudDeleteOption) error) *TCloudDeleteSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSecurityGroupRule mocks base method.
+func (m *MockTCloud) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecurityGroupRule indicates an expected call of DeleteSecurityGroupRule.
+func (mr *MockTCloudMockRecorder) DeleteSecurityGroupRule(kt, opt interface{}) *TCloudDeleteSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroupRule", reflect.TypeOf((*MockTCloud)(nil).DeleteSecurityGroupRule), kt, opt)
+	return &TCloudDeleteSecurityGroupRuleCall{Call: call}
+}
+
+// TCloudDeleteSecurityGroupRuleCall wrap *gomock.Call
+type TCloudDeleteSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteSecurityGroupRuleCall) Return(arg0 error) *TCloudDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.TCloudDeleteOption) error) *TCloudDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.TCloudDeleteOption) error) *TCloudDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSubnet mocks base method.
+func (m *MockTCloud) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubnet", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubnet indicates an expected call of DeleteSubnet.
+func (mr *MockTCloudMockRecorder) DeleteSubnet(kt, opt interface{}) *TCloudDeleteSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnet", reflect.TypeOf((*MockTCloud)(nil).DeleteSubnet), kt, opt)
+	return &TCloudDeleteSubnetCall{Call: call}
+}
+
+// TCloudDeleteSubnetCall wrap *gomock.Call
+type TCloudDeleteSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteSubnetCall) Return(arg0 error) *TCloudDeleteSubnetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteSubnetCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteSubnetCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteVpc mocks base method.
+func (m *MockTCloud) DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVpc", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVpc indicates an expected call of DeleteVpc.
+func (mr *MockTCloudMockRecorder) DeleteVpc(kt, opt interface{}) *TCloudDeleteVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpc", reflect.TypeOf((*MockTCloud)(nil).DeleteVpc), kt, opt)
+	return &TCloudDeleteVpcCall{Call: call}
+}
+
+// TCloudDeleteVpcCall wrap *gomock.Call
+type TCloudDeleteVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteVpcCall) Return(arg0 error) *TCloudDeleteVpcCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteVpcCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteVpcCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *TCloudDeleteVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DescribeNetworkAccountType mocks base method.
+func (m *MockTCloud) DescribeNetworkAccountType(kt *kit.Kit) (*v20170312.DescribeNetworkAccountTypeResponseParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeNetworkAccountType", kt)
+	ret0, _ := ret[0].(*v20170312.DescribeNetworkAccountTypeResponseParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNetworkAccountType indicates an expected call of DescribeNetworkAccountType.
+func (mr *MockTCloudMockRecorder) DescribeNetworkAccountType(kt interface{}) *TCloudDescribeNetworkAccountTypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNetworkAccountType", reflect.TypeOf((*MockTCloud)(nil).DescribeNetworkAccountType), kt)
+	return &TCloudDescribeNetworkAccountTypeCall{Call: call}
+}
+
+// TCloudDescribeNetworkAccountTypeCall wrap *gomock.Call
+type TCloudDescribeNetworkAccountTypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDescribeNetworkAccountTypeCall) Return(arg0 *v20170312.DescribeNetworkAccountTypeResponseParams, arg1 error) *TCloudDescribeNetworkAccountTypeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDescribeNetworkAccountTypeCall) Do(f func(*kit.Kit) (*v20170312.DescribeNetworkAccountTypeResponseParams, error)) *TCloudDescribeNetworkAccountTypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDescribeNetworkAccountTypeCall) DoAndReturn(f func(*kit.Kit) (*v20170312.DescribeNetworkAccountTypeResponseParams, error)) *TCloudDescribeNetworkAccountTypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DescribeResources mocks base method.
+func (m *MockTCloud) DescribeResources(kt *kit.Kit, opt *loadbalancer.TCloudDescribeResourcesOption) (*v20180317.DescribeResourcesResponseParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeResources", kt, opt)
+	ret0, _ := ret[0].(*v20180317.DescribeResourcesResponseParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeResources indicates an expected call of DescribeResources.
+func (mr *MockTCloudMockRecorder) DescribeResources(kt, opt interface{}) *TCloudDescribeResourcesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeResources", reflect.TypeOf((*MockTCloud)(nil).DescribeResources), kt, opt)
+	return &TCloudDescribeResourcesCall{Call: call}
+}
+
+// TCloudDescribeResourcesCall wrap *gomock.Call
+type TCloudDescribeResourcesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDescribeResourcesCall) Return(arg0 *v20180317.DescribeResourcesResponseParams, arg1 error) *TCloudDescribeResourcesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDescribeResourcesCall) Do(f func(*kit.Kit, *loadbalancer.TCloudDescribeResourcesOption) (*v20180317.DescribeResourcesResponseParams, error)) *TCloudDescribeResourcesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDescribeResourcesCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudDescribeResourcesOption) (*v20180317.DescribeResourcesResponseParams, error)) *TCloudDescribeResourcesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DescribeSecurityGroupAssociationStatistics mocks base method.
+func (m *MockTCloud) DescribeSecurityGroupAssociationStatistics(kt *kit.Kit, opt *securitygroup.TCloudListOption) ([]securitygroup.TCloudSecurityGroupAssociationStatistic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeSecurityGroupAssociationStatistics", kt, opt)
+	ret0, _ := ret[0].([]securitygroup.TCloudSecurityGroupAssociationStatistic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeSecurityGroupAssociationStatistics indicates an expected call of DescribeSecurityGroupAssociationStatistics.
+func (mr *MockTCloudMockRecorder) DescribeSecurityGroupAssociationStatistics(kt, opt interface{}) *TCloudDescribeSecurityGroupAssociationStatisticsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSecurityGroupAssociationStatistics", reflect.TypeOf((*MockTCloud)(nil).DescribeSecurityGroupAssociationStatistics), kt, opt)
+	return &TCloudDescribeSecurityGroupAssociationStatisticsCall{Call: call}
+}
+
+// TCloudDescribeSecurityGroupAssociationStatisticsCall wrap *gomock.Call
+type TCloudDescribeSecurityGroupAssociationStatisticsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDescribeSecurityGroupAssociationStatisticsCall) Return(arg0 []securitygroup.TCloudSecurityGroupAssociationStatistic, arg1 error) *TCloudDescribeSecurityGroupAssociationStatisticsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDescribeSecurityGroupAssociationStatisticsCall) Do(f func(*kit.Kit, *securitygroup.TCloudListOption) ([]securitygroup.TCloudSecurityGroupAssociationStatistic, error)) *TCloudDescribeSecurityGroupAssociationStatisticsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDescribeSecurityGroupAssociationStatisticsCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudListOption) ([]securitygroup.TCloudSecurityGroupAssociationStatistic, error)) *TCloudDescribeSecurityGroupAssociationStatisticsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DetachDisk mocks base method.
+func (m *MockTCloud) DetachDisk(kt *kit.Kit, opt *disk.TCloudDiskDetachOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachDisk indicates an expected call of DetachDisk.
+func (mr *MockTCloudMockRecorder) DetachDisk(kt, opt interface{}) *TCloudDetachDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachDisk", reflect.TypeOf((*MockTCloud)(nil).DetachDisk), kt, opt)
+	return &TCloudDetachDiskCall{Call: call}
+}
+
+// TCloudDetachDiskCall wrap *gomock.Call
+type TCloudDetachDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDetachDiskCall) Return(arg0 error) *TCloudDetachDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDetachDiskCall) Do(f func(*kit.Kit, *disk.TCloudDiskDetachOption) error) *TCloudDetachDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDetachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskDetachOption) error) *TCloudDetachDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DetermineIPv6Type mocks base method.
+func (m *MockTCloud) DetermineIPv6Type(kt *kit.Kit, region string, ipv6Addresses []*string) ([]*string, []*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetermineIPv6Type", kt, region, ipv6Addresses)
+	ret0, _ := ret[0].([]*string)
+	ret1, _ := ret[1].([]*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DetermineIPv6Type indicates an expected call of DetermineIPv6Type.
+func (mr *MockTCloudMockRecorder) DetermineIPv6Type(kt, region, ipv6Addresses interface{}) *TCloudDetermineIPv6TypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetermineIPv6Type", reflect.TypeOf((*MockTCloud)(nil).DetermineIPv6Type), kt, region, ipv6Addresses)
+	return &TCloudDetermineIPv6TypeCall{Call: call}
+}
+
+// TCloudDetermineIPv6TypeCall wrap *gomock.Call
+type TCloudDetermineIPv6TypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDetermineIPv6TypeCall) Return(arg0, arg1 []*string, arg2 error) *TCloudDetermineIPv6TypeCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDetermineIPv6TypeCall) Do(f func(*kit.Kit, string, []*string) ([]*string, []*string, error)) *TCloudDetermineIPv6TypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDetermineIPv6TypeCall) DoAndReturn(f func(*kit.Kit, string, []*string) ([]*string, []*string, error)) *TCloudDetermineIPv6TypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DisassociateEip mocks base method.
+func (m *MockTCloud) DisassociateEip(kt *kit.Kit, opt *eip.TCloudEipDisassociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisassociateEip indicates an expected call of DisassociateEip.
+func (mr *MockTCloudMockRecorder) DisassociateEip(kt, opt interface{}) *TCloudDisassociateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateEip", reflect.TypeOf((*MockTCloud)(nil).DisassociateEip), kt, opt)
+	return &TCloudDisassociateEipCall{Call: call}
+}
+
+// TCloudDisassociateEipCall wrap *gomock.Call
+type TCloudDisassociateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDisassociateEipCall) Return(arg0 error) *TCloudDisassociateEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDisassociateEipCall) Do(f func(*kit.Kit, *eip.TCloudEipDisassociateOption) error) *TCloudDisassociateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDisassociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipDisassociateOption) error) *TCloudDisassociateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DisassociateKeyPair mocks base method.
+func (m *MockTCloud) DisassociateKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairAssociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateKeyPair", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisassociateKeyPair indicates an expected call of DisassociateKeyPair.
+func (mr *MockTCloudMockRecorder) DisassociateKeyPair(kt, opt interface{}) *TCloudDisassociateKeyPairCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateKeyPair", reflect.TypeOf((*MockTCloud)(nil).DisassociateKeyPair), kt, opt)
+	return &TCloudDisassociateKeyPairCall{Call: call}
+}
+
+// TCloudDisassociateKeyPairCall wrap *gomock.Call
+type TCloudDisassociateKeyPairCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDisassociateKeyPairCall) Return(arg0 error) *TCloudDisassociateKeyPairCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDisassociateKeyPairCall) Do(f func(*kit.Kit, *keypair.TCloudKeyPairAssociateOption) error) *TCloudDisassociateKeyPairCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDisassociateKeyPairCall) DoAndReturn(f func(*kit.Kit, *keypair.TCloudKeyPairAssociateOption) error) *TCloudDisassociateKeyPairCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAccountInfoBySecret mocks base method.
+func (m *MockTCloud) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.TCloudInfoBySecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountInfoBySecret", kt)
+	ret0, _ := ret[0].(*cloud.TCloudInfoBySecret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1185,997 +2386,2392 @@ func (m *MockTCloud) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.TCloudInfoBySec
 // GetAccountInfoBySecret indicates an expected call of GetAccountInfoBySecret.
 func (mr *MockTCloudMockRecorder) GetAccountInfoBySecret(kt interface{}) *TCloudGetAccountInfoBySecretCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountInfoBySecret", reflect.TypeOf((*MockTCloud)(nil).GetAccountInfoBySecret), kt)
-	return &TCloudGetAccountInfoBySecretCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountInfoBySecret", reflect.TypeOf((*MockTCloud)(nil).GetAccountInfoBySecret), kt)
+	return &TCloudGetAccountInfoBySecretCall{Call: call}
+}
+
+// TCloudGetAccountInfoBySecretCall wrap *gomock.Call
+type TCloudGetAccountInfoBySecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudGetAccountInfoBySecretCall) Return(arg0 *cloud.TCloudInfoBySecret, arg1 error) *TCloudGetAccountInfoBySecretCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudGetAccountInfoBySecretCall) Do(f func(*kit.Kit) (*cloud.TCloudInfoBySecret, error)) *TCloudGetAccountInfoBySecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudGetAccountInfoBySecretCall) DoAndReturn(f func(*kit.Kit) (*cloud.TCloudInfoBySecret, error)) *TCloudGetAccountInfoBySecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAccountZoneQuota mocks base method.
+func (m *MockTCloud) GetAccountZoneQuota(kt *kit.Kit, opt *account.GetTCloudAccountZoneQuotaOption) (*account.TCloudAccountQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountZoneQuota", kt, opt)
+	ret0, _ := ret[0].(*account.TCloudAccountQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountZoneQuota indicates an expected call of GetAccountZoneQuota.
+func (mr *MockTCloudMockRecorder) GetAccountZoneQuota(kt, opt interface{}) *TCloudGetAccountZoneQuotaCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountZoneQuota", reflect.TypeOf((*MockTCloud)(nil).GetAccountZoneQuota), kt, opt)
+	return &TCloudGetAccountZoneQuotaCall{Call: call}
+}
+
+// TCloudGetAccountZoneQuotaCall wrap *gomock.Call
+type TCloudGetAccountZoneQuotaCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudGetAccountZoneQuotaCall) Return(arg0 *account.TCloudAccountQuota, arg1 error) *TCloudGetAccountZoneQuotaCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudGetAccountZoneQuotaCall) Do(f func(*kit.Kit, *account.GetTCloudAccountZoneQuotaOption) (*account.TCloudAccountQuota, error)) *TCloudGetAccountZoneQuotaCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudGetAccountZoneQuotaCall) DoAndReturn(f func(*kit.Kit, *account.GetTCloudAccountZoneQuotaOption) (*account.TCloudAccountQuota, error)) *TCloudGetAccountZoneQuotaCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBillList mocks base method.
+func (m *MockTCloud) GetBillList(kt *kit.Kit, opt *bill.TCloudBillListOption) (*v20180709.DescribeBillDetailResponseParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillList", kt, opt)
+	ret0, _ := ret[0].(*v20180709.DescribeBillDetailResponseParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillList indicates an expected call of GetBillList.
+func (mr *MockTCloudMockRecorder) GetBillList(kt, opt interface{}) *TCloudGetBillListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillList", reflect.TypeOf((*MockTCloud)(nil).GetBillList), kt, opt)
+	return &TCloudGetBillListCall{Call: call}
+}
+
+// TCloudGetBillListCall wrap *gomock.Call
+type TCloudGetBillListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudGetBillListCall) Return(arg0 *v20180709.DescribeBillDetailResponseParams, arg1 error) *TCloudGetBillListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudGetBillListCall) Do(f func(*kit.Kit, *bill.TCloudBillListOption) (*v20180709.DescribeBillDetailResponseParams, error)) *TCloudGetBillListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudGetBillListCall) DoAndReturn(f func(*kit.Kit, *bill.TCloudBillListOption) (*v20180709.DescribeBillDetailResponseParams, error)) *TCloudGetBillListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ImportKeyPair mocks base method.
+func (m *MockTCloud) ImportKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairImportOption) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportKeyPair", kt, opt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportKeyPair indicates an expected call of ImportKeyPair.
+func (mr *MockTCloudMockRecorder) ImportKeyPair(kt, opt interface{}) *TCloudImportKeyPairCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportKeyPair", reflect.TypeOf((*MockTCloud)(nil).ImportKeyPair), kt, opt)
+	return &TCloudImportKeyPairCall{Call: call}
+}
+
+// TCloudImportKeyPairCall wrap *gomock.Call
+type TCloudImportKeyPairCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudImportKeyPairCall) Return(arg0 string, arg1 error) *TCloudImportKeyPairCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudImportKeyPairCall) Do(f func(*kit.Kit, *keypair.TCloudKeyPairImportOption) (string, error)) *TCloudImportKeyPairCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudImportKeyPairCall) DoAndReturn(f func(*kit.Kit, *keypair.TCloudKeyPairImportOption) (string, error)) *TCloudImportKeyPairCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InquiryPriceCvm mocks base method.
+func (m *MockTCloud) InquiryPriceCvm(kt *kit.Kit, opt *cvm.TCloudCreateOption) (*cvm.InquiryPriceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InquiryPriceCvm", kt, opt)
+	ret0, _ := ret[0].(*cvm.InquiryPriceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InquiryPriceCvm indicates an expected call of InquiryPriceCvm.
+func (mr *MockTCloudMockRecorder) InquiryPriceCvm(kt, opt interface{}) *TCloudInquiryPriceCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InquiryPriceCvm", reflect.TypeOf((*MockTCloud)(nil).InquiryPriceCvm), kt, opt)
+	return &TCloudInquiryPriceCvmCall{Call: call}
+}
+
+// TCloudInquiryPriceCvmCall wrap *gomock.Call
+type TCloudInquiryPriceCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudInquiryPriceCvmCall) Return(arg0 *cvm.InquiryPriceResult, arg1 error) *TCloudInquiryPriceCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudInquiryPriceCvmCall) Do(f func(*kit.Kit, *cvm.TCloudCreateOption) (*cvm.InquiryPriceResult, error)) *TCloudInquiryPriceCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudInquiryPriceCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudCreateOption) (*cvm.InquiryPriceResult, error)) *TCloudInquiryPriceCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InquiryPriceDisk mocks base method.
+func (m *MockTCloud) InquiryPriceDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (*cvm.InquiryPriceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InquiryPriceDisk", kt, opt)
+	ret0, _ := ret[0].(*cvm.InquiryPriceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InquiryPriceDisk indicates an expected call of InquiryPriceDisk.
+func (mr *MockTCloudMockRecorder) InquiryPriceDisk(kt, opt interface{}) *TCloudInquiryPriceDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InquiryPriceDisk", reflect.TypeOf((*MockTCloud)(nil).InquiryPriceDisk), kt, opt)
+	return &TCloudInquiryPriceDiskCall{Call: call}
+}
+
+// TCloudInquiryPriceDiskCall wrap *gomock.Call
+type TCloudInquiryPriceDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudInquiryPriceDiskCall) Return(arg0 *cvm.InquiryPriceResult, arg1 error) *TCloudInquiryPriceDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudInquiryPriceDiskCall) Do(f func(*kit.Kit, *disk.TCloudDiskCreateOption) (*cvm.InquiryPriceResult, error)) *TCloudInquiryPriceDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudInquiryPriceDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskCreateOption) (*cvm.InquiryPriceResult, error)) *TCloudInquiryPriceDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InquiryPriceLoadBalancer mocks base method.
+func (m *MockTCloud) InquiryPriceLoadBalancer(kt *kit.Kit, opt *loadbalancer.TCloudCreateClbOption) (*loadbalancer.TCloudLBPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InquiryPriceLoadBalancer", kt, opt)
+	ret0, _ := ret[0].(*loadbalancer.TCloudLBPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InquiryPriceLoadBalancer indicates an expected call of InquiryPriceLoadBalancer.
+func (mr *MockTCloudMockRecorder) InquiryPriceLoadBalancer(kt, opt interface{}) *TCloudInquiryPriceLoadBalancerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InquiryPriceLoadBalancer", reflect.TypeOf((*MockTCloud)(nil).InquiryPriceLoadBalancer), kt, opt)
+	return &TCloudInquiryPriceLoadBalancerCall{Call: call}
+}
+
+// TCloudInquiryPriceLoadBalancerCall wrap *gomock.Call
+type TCloudInquiryPriceLoadBalancerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudInquiryPriceLoadBalancerCall) Return(arg0 *loadbalancer.TCloudLBPrice, arg1 error) *TCloudInquiryPriceLoadBalancerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudInquiryPriceLoadBalancerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudCreateClbOption) (*loadbalancer.TCloudLBPrice, error)) *TCloudInquiryPriceLoadBalancerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudInquiryPriceLoadBalancerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudCreateClbOption) (*loadbalancer.TCloudLBPrice, error)) *TCloudInquiryPriceLoadBalancerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListAccount mocks base method.
+func (m *MockTCloud) ListAccount(kt *kit.Kit) ([]account.TCloudAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccount", kt)
+	ret0, _ := ret[0].([]account.TCloudAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccount indicates an expected call of ListAccount.
+func (mr *MockTCloudMockRecorder) ListAccount(kt interface{}) *TCloudListAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccount", reflect.TypeOf((*MockTCloud)(nil).ListAccount), kt)
+	return &TCloudListAccountCall{Call: call}
+}
+
+// TCloudListAccountCall wrap *gomock.Call
+type TCloudListAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListAccountCall) Return(arg0 []account.TCloudAccount, arg1 error) *TCloudListAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListAccountCall) Do(f func(*kit.Kit) ([]account.TCloudAccount, error)) *TCloudListAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListAccountCall) DoAndReturn(f func(*kit.Kit) ([]account.TCloudAccount, error)) *TCloudListAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListArgsTplAddress mocks base method.
+func (m *MockTCloud) ListArgsTplAddress(kt *kit.Kit, opt *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplAddress, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArgsTplAddress", kt, opt)
+	ret0, _ := ret[0].([]argstpl.TCloudArgsTplAddress)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListArgsTplAddress indicates an expected call of ListArgsTplAddress.
+func (mr *MockTCloudMockRecorder) ListArgsTplAddress(kt, opt interface{}) *TCloudListArgsTplAddressCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArgsTplAddress", reflect.TypeOf((*MockTCloud)(nil).ListArgsTplAddress), kt, opt)
+	return &TCloudListArgsTplAddressCall{Call: call}
+}
+
+// TCloudListArgsTplAddressCall wrap *gomock.Call
+type TCloudListArgsTplAddressCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListArgsTplAddressCall) Return(arg0 []argstpl.TCloudArgsTplAddress, arg1 uint64, arg2 error) *TCloudListArgsTplAddressCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListArgsTplAddressCall) Do(f func(*kit.Kit, *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplAddress, uint64, error)) *TCloudListArgsTplAddressCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListArgsTplAddressCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplAddress, uint64, error)) *TCloudListArgsTplAddressCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListArgsTplAddressGroup mocks base method.
+func (m *MockTCloud) ListArgsTplAddressGroup(kt *kit.Kit, opt *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplAddressGroup, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArgsTplAddressGroup", kt, opt)
+	ret0, _ := ret[0].([]argstpl.TCloudArgsTplAddressGroup)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListArgsTplAddressGroup indicates an expected call of ListArgsTplAddressGroup.
+func (mr *MockTCloudMockRecorder) ListArgsTplAddressGroup(kt, opt interface{}) *TCloudListArgsTplAddressGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArgsTplAddressGroup", reflect.TypeOf((*MockTCloud)(nil).ListArgsTplAddressGroup), kt, opt)
+	return &TCloudListArgsTplAddressGroupCall{Call: call}
+}
+
+// TCloudListArgsTplAddressGroupCall wrap *gomock.Call
+type TCloudListArgsTplAddressGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListArgsTplAddressGroupCall) Return(arg0 []argstpl.TCloudArgsTplAddressGroup, arg1 uint64, arg2 error) *TCloudListArgsTplAddressGroupCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListArgsTplAddressGroupCall) Do(f func(*kit.Kit, *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplAddressGroup, uint64, error)) *TCloudListArgsTplAddressGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListArgsTplAddressGroupCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplAddressGroup, uint64, error)) *TCloudListArgsTplAddressGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListArgsTplService mocks base method.
+func (m *MockTCloud) ListArgsTplService(kt *kit.Kit, opt *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplService, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArgsTplService", kt, opt)
+	ret0, _ := ret[0].([]argstpl.TCloudArgsTplService)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListArgsTplService indicates an expected call of ListArgsTplService.
+func (mr *MockTCloudMockRecorder) ListArgsTplService(kt, opt interface{}) *TCloudListArgsTplServiceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArgsTplService", reflect.TypeOf((*MockTCloud)(nil).ListArgsTplService), kt, opt)
+	return &TCloudListArgsTplServiceCall{Call: call}
+}
+
+// TCloudListArgsTplServiceCall wrap *gomock.Call
+type TCloudListArgsTplServiceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListArgsTplServiceCall) Return(arg0 []argstpl.TCloudArgsTplService, arg1 uint64, arg2 error) *TCloudListArgsTplServiceCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListArgsTplServiceCall) Do(f func(*kit.Kit, *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplService, uint64, error)) *TCloudListArgsTplServiceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListArgsTplServiceCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplService, uint64, error)) *TCloudListArgsTplServiceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListArgsTplServiceGroup mocks base method.
+func (m *MockTCloud) ListArgsTplServiceGroup(kt *kit.Kit, opt *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplServiceGroup, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArgsTplServiceGroup", kt, opt)
+	ret0, _ := ret[0].([]argstpl.TCloudArgsTplServiceGroup)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListArgsTplServiceGroup indicates an expected call of ListArgsTplServiceGroup.
+func (mr *MockTCloudMockRecorder) ListArgsTplServiceGroup(kt, opt interface{}) *TCloudListArgsTplServiceGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArgsTplServiceGroup", reflect.TypeOf((*MockTCloud)(nil).ListArgsTplServiceGroup), kt, opt)
+	return &TCloudListArgsTplServiceGroupCall{Call: call}
+}
+
+// TCloudListArgsTplServiceGroupCall wrap *gomock.Call
+type TCloudListArgsTplServiceGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListArgsTplServiceGroupCall) Return(arg0 []argstpl.TCloudArgsTplServiceGroup, arg1 uint64, arg2 error) *TCloudListArgsTplServiceGroupCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListArgsTplServiceGroupCall) Do(f func(*kit.Kit, *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplServiceGroup, uint64, error)) *TCloudListArgsTplServiceGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListArgsTplServiceGroupCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudListOption) ([]argstpl.TCloudArgsTplServiceGroup, uint64, error)) *TCloudListArgsTplServiceGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListBandwidthPackage mocks base method.
+func (m *MockTCloud) ListBandwidthPackage(kt *kit.Kit, opt *types.TCloudListBwPkgOption) (*types.TCloudListBwPkgResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBandwidthPackage", kt, opt)
+	ret0, _ := ret[0].(*types.TCloudListBwPkgResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBandwidthPackage indicates an expected call of ListBandwidthPackage.
+func (mr *MockTCloudMockRecorder) ListBandwidthPackage(kt, opt interface{}) *TCloudListBandwidthPackageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBandwidthPackage", reflect.TypeOf((*MockTCloud)(nil).ListBandwidthPackage), kt, opt)
+	return &TCloudListBandwidthPackageCall{Call: call}
+}
+
+// TCloudListBandwidthPackageCall wrap *gomock.Call
+type TCloudListBandwidthPackageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListBandwidthPackageCall) Return(arg0 *types.TCloudListBwPkgResult, arg1 error) *TCloudListBandwidthPackageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListBandwidthPackageCall) Do(f func(*kit.Kit, *types.TCloudListBwPkgOption) (*types.TCloudListBwPkgResult, error)) *TCloudListBandwidthPackageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListBandwidthPackageCall) DoAndReturn(f func(*kit.Kit, *types.TCloudListBwPkgOption) (*types.TCloudListBwPkgResult, error)) *TCloudListBandwidthPackageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCert mocks base method.
+func (m *MockTCloud) ListCert(kt *kit.Kit, opt *cert.TCloudListOption) ([]cert.TCloudCert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCert", kt, opt)
+	ret0, _ := ret[0].([]cert.TCloudCert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCert indicates an expected call of ListCert.
+func (mr *MockTCloudMockRecorder) ListCert(kt, opt interface{}) *TCloudListCertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCert", reflect.TypeOf((*MockTCloud)(nil).ListCert), kt, opt)
+	return &TCloudListCertCall{Call: call}
+}
+
+// TCloudListCertCall wrap *gomock.Call
+type TCloudListCertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListCertCall) Return(arg0 []cert.TCloudCert, arg1 error) *TCloudListCertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListCertCall) Do(f func(*kit.Kit, *cert.TCloudListOption) ([]cert.TCloudCert, error)) *TCloudListCertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListCertCall) DoAndReturn(f func(*kit.Kit, *cert.TCloudListOption) ([]cert.TCloudCert, error)) *TCloudListCertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCvm mocks base method.
+func (m *MockTCloud) ListCvm(kt *kit.Kit, opt *cvm.TCloudListOption) ([]cvm.TCloudCvm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCvm", kt, opt)
+	ret0, _ := ret[0].([]cvm.TCloudCvm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCvm indicates an expected call of ListCvm.
+func (mr *MockTCloudMockRecorder) ListCvm(kt, opt interface{}) *TCloudListCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCvm", reflect.TypeOf((*MockTCloud)(nil).ListCvm), kt, opt)
+	return &TCloudListCvmCall{Call: call}
+}
+
+// TCloudListCvmCall wrap *gomock.Call
+type TCloudListCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListCvmCall) Return(arg0 []cvm.TCloudCvm, arg1 error) *TCloudListCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListCvmCall) Do(f func(*kit.Kit, *cvm.TCloudListOption) ([]cvm.TCloudCvm, error)) *TCloudListCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudListOption) ([]cvm.TCloudCvm, error)) *TCloudListCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCvmWithCount mocks base method.
+func (m *MockTCloud) ListCvmWithCount(kt *kit.Kit, opt *cvm.ListCvmWithCountOption) (*cvm.CvmWithCountResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCvmWithCount", kt, opt)
+	ret0, _ := ret[0].(*cvm.CvmWithCountResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCvmWithCount indicates an expected call of ListCvmWithCount.
+func (mr *MockTCloudMockRecorder) ListCvmWithCount(kt, opt interface{}) *TCloudListCvmWithCountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCvmWithCount", reflect.TypeOf((*MockTCloud)(nil).ListCvmWithCount), kt, opt)
+	return &TCloudListCvmWithCountCall{Call: call}
+}
+
+// TCloudListCvmWithCountCall wrap *gomock.Call
+type TCloudListCvmWithCountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListCvmWithCountCall) Return(arg0 *cvm.CvmWithCountResp, arg1 error) *TCloudListCvmWithCountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListCvmWithCountCall) Do(f func(*kit.Kit, *cvm.ListCvmWithCountOption) (*cvm.CvmWithCountResp, error)) *TCloudListCvmWithCountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListCvmWithCountCall) DoAndReturn(f func(*kit.Kit, *cvm.ListCvmWithCountOption) (*cvm.CvmWithCountResp, error)) *TCloudListCvmWithCountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListDisk mocks base method.
+func (m *MockTCloud) ListDisk(kt *kit.Kit, opt *core.TCloudListOption) ([]disk.TCloudDisk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisk", kt, opt)
+	ret0, _ := ret[0].([]disk.TCloudDisk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisk indicates an expected call of ListDisk.
+func (mr *MockTCloudMockRecorder) ListDisk(kt, opt interface{}) *TCloudListDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisk", reflect.TypeOf((*MockTCloud)(nil).ListDisk), kt, opt)
+	return &TCloudListDiskCall{Call: call}
+}
+
+// TCloudListDiskCall wrap *gomock.Call
+type TCloudListDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListDiskCall) Return(arg0 []disk.TCloudDisk, arg1 error) *TCloudListDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListDiskCall) Do(f func(*kit.Kit, *core.TCloudListOption) ([]disk.TCloudDisk, error)) *TCloudListDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListDiskCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) ([]disk.TCloudDisk, error)) *TCloudListDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListDiskSnapshot mocks base method.
+func (m *MockTCloud) ListDiskSnapshot(kt *kit.Kit, opt *core.TCloudListOption) ([]disksnapshot.TCloudSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDiskSnapshot", kt, opt)
+	ret0, _ := ret[0].([]disksnapshot.TCloudSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDiskSnapshot indicates an expected call of ListDiskSnapshot.
+func (mr *MockTCloudMockRecorder) ListDiskSnapshot(kt, opt interface{}) *TCloudListDiskSnapshotCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiskSnapshot", reflect.TypeOf((*MockTCloud)(nil).ListDiskSnapshot), kt, opt)
+	return &TCloudListDiskSnapshotCall{Call: call}
+}
+
+// TCloudListDiskSnapshotCall wrap *gomock.Call
+type TCloudListDiskSnapshotCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListDiskSnapshotCall) Return(arg0 []disksnapshot.TCloudSnapshot, arg1 error) *TCloudListDiskSnapshotCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListDiskSnapshotCall) Do(f func(*kit.Kit, *core.TCloudListOption) ([]disksnapshot.TCloudSnapshot, error)) *TCloudListDiskSnapshotCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListDiskSnapshotCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) ([]disksnapshot.TCloudSnapshot, error)) *TCloudListDiskSnapshotCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListEip mocks base method.
+func (m *MockTCloud) ListEip(kt *kit.Kit, opt *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEip", kt, opt)
+	ret0, _ := ret[0].(*eip.TCloudEipListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEip indicates an expected call of ListEip.
+func (mr *MockTCloudMockRecorder) ListEip(kt, opt interface{}) *TCloudListEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEip", reflect.TypeOf((*MockTCloud)(nil).ListEip), kt, opt)
+	return &TCloudListEipCall{Call: call}
+}
+
+// TCloudListEipCall wrap *gomock.Call
+type TCloudListEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListEipCall) Return(arg0 *eip.TCloudEipListResult, arg1 error) *TCloudListEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListEipCall) Do(f func(*kit.Kit, *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error)) *TCloudListEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error)) *TCloudListEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListImage mocks base method.
+func (m *MockTCloud) ListImage(kt *kit.Kit, opt *image.TCloudImageListOption) (*image.TCloudImageListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImage", kt, opt)
+	ret0, _ := ret[0].(*image.TCloudImageListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImage indicates an expected call of ListImage.
+func (mr *MockTCloudMockRecorder) ListImage(kt, opt interface{}) *TCloudListImageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImage", reflect.TypeOf((*MockTCloud)(nil).ListImage), kt, opt)
+	return &TCloudListImageCall{Call: call}
+}
+
+// TCloudListImageCall wrap *gomock.Call
+type TCloudListImageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListImageCall) Return(arg0 *image.TCloudImageListResult, arg1 error) *TCloudListImageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListImageCall) Do(f func(*kit.Kit, *image.TCloudImageListOption) (*image.TCloudImageListResult, error)) *TCloudListImageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListImageCall) DoAndReturn(f func(*kit.Kit, *image.TCloudImageListOption) (*image.TCloudImageListResult, error)) *TCloudListImageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListInstanceType mocks base method.
+func (m *MockTCloud) ListInstanceType(kt *kit.Kit, opt *instancetype.TCloudInstanceTypeListOption) ([]instancetype.TCloudInstanceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstanceType", kt, opt)
+	ret0, _ := ret[0].([]instancetype.TCloudInstanceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstanceType indicates an expected call of ListInstanceType.
+func (mr *MockTCloudMockRecorder) ListInstanceType(kt, opt interface{}) *TCloudListInstanceTypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceType", reflect.TypeOf((*MockTCloud)(nil).ListInstanceType), kt, opt)
+	return &TCloudListInstanceTypeCall{Call: call}
+}
+
+// TCloudListInstanceTypeCall wrap *gomock.Call
+type TCloudListInstanceTypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListInstanceTypeCall) Return(arg0 []instancetype.TCloudInstanceType, arg1 error) *TCloudListInstanceTypeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListInstanceTypeCall) Do(f func(*kit.Kit, *instancetype.TCloudInstanceTypeListOption) ([]instancetype.TCloudInstanceType, error)) *TCloudListInstanceTypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListInstanceTypeCall) DoAndReturn(f func(*kit.Kit, *instancetype.TCloudInstanceTypeListOption) ([]instancetype.TCloudInstanceType, error)) *TCloudListInstanceTypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListKeyPair mocks base method.
+func (m *MockTCloud) ListKeyPair(kt *kit.Kit, opt *core.TCloudListOption) ([]keypair.TCloudKeyPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeyPair", kt, opt)
+	ret0, _ := ret[0].([]keypair.TCloudKeyPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeyPair indicates an expected call of ListKeyPair.
+func (mr *MockTCloudMockRecorder) ListKeyPair(kt, opt interface{}) *TCloudListKeyPairCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeyPair", reflect.TypeOf((*MockTCloud)(nil).ListKeyPair), kt, opt)
+	return &TCloudListKeyPairCall{Call: call}
+}
+
+// TCloudListKeyPairCall wrap *gomock.Call
+type TCloudListKeyPairCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListKeyPairCall) Return(arg0 []keypair.TCloudKeyPair, arg1 error) *TCloudListKeyPairCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListKeyPairCall) Do(f func(*kit.Kit, *core.TCloudListOption) ([]keypair.TCloudKeyPair, error)) *TCloudListKeyPairCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListKeyPairCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) ([]keypair.TCloudKeyPair, error)) *TCloudListKeyPairCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListListener mocks base method.
+func (m *MockTCloud) ListListener(kt *kit.Kit, opt *loadbalancer.TCloudListListenersOption) ([]loadbalancer.TCloudListener, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListListener", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudListener)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListListener indicates an expected call of ListListener.
+func (mr *MockTCloudMockRecorder) ListListener(kt, opt interface{}) *TCloudListListenerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListListener", reflect.TypeOf((*MockTCloud)(nil).ListListener), kt, opt)
+	return &TCloudListListenerCall{Call: call}
+}
+
+// TCloudListListenerCall wrap *gomock.Call
+type TCloudListListenerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListListenerCall) Return(arg0 []loadbalancer.TCloudListener, arg1 error) *TCloudListListenerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListListenerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudListListenersOption) ([]loadbalancer.TCloudListener, error)) *TCloudListListenerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListListenerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudListListenersOption) ([]loadbalancer.TCloudListener, error)) *TCloudListListenerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListLoadBalancer mocks base method.
+func (m *MockTCloud) ListLoadBalancer(kt *kit.Kit, opt *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudClb, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoadBalancer", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudClb)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoadBalancer indicates an expected call of ListLoadBalancer.
+func (mr *MockTCloudMockRecorder) ListLoadBalancer(kt, opt interface{}) *TCloudListLoadBalancerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancer", reflect.TypeOf((*MockTCloud)(nil).ListLoadBalancer), kt, opt)
+	return &TCloudListLoadBalancerCall{Call: call}
+}
+
+// TCloudListLoadBalancerCall wrap *gomock.Call
+type TCloudListLoadBalancerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListLoadBalancerCall) Return(arg0 []loadbalancer.TCloudClb, arg1 error) *TCloudListLoadBalancerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListLoadBalancerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudClb, error)) *TCloudListLoadBalancerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListLoadBalancerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudClb, error)) *TCloudListLoadBalancerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListLoadBalancerQuota mocks base method.
+func (m *MockTCloud) ListLoadBalancerQuota(kt *kit.Kit, opt *loadbalancer.ListTCloudLoadBalancerQuotaOption) ([]loadbalancer.TCloudLoadBalancerQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoadBalancerQuota", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudLoadBalancerQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoadBalancerQuota indicates an expected call of ListLoadBalancerQuota.
+func (mr *MockTCloudMockRecorder) ListLoadBalancerQuota(kt, opt interface{}) *TCloudListLoadBalancerQuotaCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancerQuota", reflect.TypeOf((*MockTCloud)(nil).ListLoadBalancerQuota), kt, opt)
+	return &TCloudListLoadBalancerQuotaCall{Call: call}
+}
+
+// TCloudListLoadBalancerQuotaCall wrap *gomock.Call
+type TCloudListLoadBalancerQuotaCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListLoadBalancerQuotaCall) Return(arg0 []loadbalancer.TCloudLoadBalancerQuota, arg1 error) *TCloudListLoadBalancerQuotaCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListLoadBalancerQuotaCall) Do(f func(*kit.Kit, *loadbalancer.ListTCloudLoadBalancerQuotaOption) ([]loadbalancer.TCloudLoadBalancerQuota, error)) *TCloudListLoadBalancerQuotaCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListLoadBalancerQuotaCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.ListTCloudLoadBalancerQuotaOption) ([]loadbalancer.TCloudLoadBalancerQuota, error)) *TCloudListLoadBalancerQuotaCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListPoliciesGrantingServiceAccess mocks base method.
+func (m *MockTCloud) ListPoliciesGrantingServiceAccess(kt *kit.Kit, opt *account.TCloudListPolicyOption) ([]*v20190116.ListGrantServiceAccessNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPoliciesGrantingServiceAccess", kt, opt)
+	ret0, _ := ret[0].([]*v20190116.ListGrantServiceAccessNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPoliciesGrantingServiceAccess indicates an expected call of ListPoliciesGrantingServiceAccess.
+func (mr *MockTCloudMockRecorder) ListPoliciesGrantingServiceAccess(kt, opt interface{}) *TCloudListPoliciesGrantingServiceAccessCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPoliciesGrantingServiceAccess", reflect.TypeOf((*MockTCloud)(nil).ListPoliciesGrantingServiceAccess), kt, opt)
+	return &TCloudListPoliciesGrantingServiceAccessCall{Call: call}
+}
+
+// TCloudListPoliciesGrantingServiceAccessCall wrap *gomock.Call
+type TCloudListPoliciesGrantingServiceAccessCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListPoliciesGrantingServiceAccessCall) Return(arg0 []*v20190116.ListGrantServiceAccessNode, arg1 error) *TCloudListPoliciesGrantingServiceAccessCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListPoliciesGrantingServiceAccessCall) Do(f func(*kit.Kit, *account.TCloudListPolicyOption) ([]*v20190116.ListGrantServiceAccessNode, error)) *TCloudListPoliciesGrantingServiceAccessCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListPoliciesGrantingServiceAccessCall) DoAndReturn(f func(*kit.Kit, *account.TCloudListPolicyOption) ([]*v20190116.ListGrantServiceAccessNode, error)) *TCloudListPoliciesGrantingServiceAccessCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRegion mocks base method.
+func (m *MockTCloud) ListRegion(kt *kit.Kit) (*region.TCloudRegionListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegion", kt)
+	ret0, _ := ret[0].(*region.TCloudRegionListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegion indicates an expected call of ListRegion.
+func (mr *MockTCloudMockRecorder) ListRegion(kt interface{}) *TCloudListRegionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegion", reflect.TypeOf((*MockTCloud)(nil).ListRegion), kt)
+	return &TCloudListRegionCall{Call: call}
+}
+
+// TCloudListRegionCall wrap *gomock.Call
+type TCloudListRegionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListRegionCall) Return(arg0 *region.TCloudRegionListResult, arg1 error) *TCloudListRegionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListRegionCall) Do(f func(*kit.Kit) (*region.TCloudRegionListResult, error)) *TCloudListRegionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListRegionCall) DoAndReturn(f func(*kit.Kit) (*region.TCloudRegionListResult, error)) *TCloudListRegionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListResourceChangeEvent mocks base method.
+func (m *MockTCloud) ListResourceChangeEvent(kt *kit.Kit, opt *resourceevent.ListOption) ([]resourceevent.ChangeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourceChangeEvent", kt, opt)
+	ret0, _ := ret[0].([]resourceevent.ChangeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourceChangeEvent indicates an expected call of ListResourceChangeEvent.
+func (mr *MockTCloudMockRecorder) ListResourceChangeEvent(kt, opt interface{}) *TCloudListResourceChangeEventCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceChangeEvent", reflect.TypeOf((*MockTCloud)(nil).ListResourceChangeEvent), kt, opt)
+	return &TCloudListResourceChangeEventCall{Call: call}
+}
+
+// TCloudListResourceChangeEventCall wrap *gomock.Call
+type TCloudListResourceChangeEventCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListResourceChangeEventCall) Return(arg0 []resourceevent.ChangeEvent, arg1 error) *TCloudListResourceChangeEventCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListResourceChangeEventCall) Do(f func(*kit.Kit, *resourceevent.ListOption) ([]resourceevent.ChangeEvent, error)) *TCloudListResourceChangeEventCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListResourceChangeEventCall) DoAndReturn(f func(*kit.Kit, *resourceevent.ListOption) ([]resourceevent.ChangeEvent, error)) *TCloudListResourceChangeEventCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRouteTable mocks base method.
+func (m *MockTCloud) ListRouteTable(kt *kit.Kit, opt *core.TCloudListOption) (*routetable.TCloudRouteTableListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRouteTable", kt, opt)
+	ret0, _ := ret[0].(*routetable.TCloudRouteTableListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRouteTable indicates an expected call of ListRouteTable.
+func (mr *MockTCloudMockRecorder) ListRouteTable(kt, opt interface{}) *TCloudListRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouteTable", reflect.TypeOf((*MockTCloud)(nil).ListRouteTable), kt, opt)
+	return &TCloudListRouteTableCall{Call: call}
+}
+
+// TCloudListRouteTableCall wrap *gomock.Call
+type TCloudListRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListRouteTableCall) Return(arg0 *routetable.TCloudRouteTableListResult, arg1 error) *TCloudListRouteTableCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListRouteTableCall) Do(f func(*kit.Kit, *core.TCloudListOption) (*routetable.TCloudRouteTableListResult, error)) *TCloudListRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListRouteTableCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) (*routetable.TCloudRouteTableListResult, error)) *TCloudListRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSecurityGroupNew mocks base method.
+func (m *MockTCloud) ListSecurityGroupNew(kt *kit.Kit, opt *securitygroup.TCloudListOption) ([]securitygroup.TCloudSG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityGroupNew", kt, opt)
+	ret0, _ := ret[0].([]securitygroup.TCloudSG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityGroupNew indicates an expected call of ListSecurityGroupNew.
+func (mr *MockTCloudMockRecorder) ListSecurityGroupNew(kt, opt interface{}) *TCloudListSecurityGroupNewCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroupNew", reflect.TypeOf((*MockTCloud)(nil).ListSecurityGroupNew), kt, opt)
+	return &TCloudListSecurityGroupNewCall{Call: call}
+}
+
+// TCloudListSecurityGroupNewCall wrap *gomock.Call
+type TCloudListSecurityGroupNewCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListSecurityGroupNewCall) Return(arg0 []securitygroup.TCloudSG, arg1 error) *TCloudListSecurityGroupNewCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListSecurityGroupNewCall) Do(f func(*kit.Kit, *securitygroup.TCloudListOption) ([]securitygroup.TCloudSG, error)) *TCloudListSecurityGroupNewCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListSecurityGroupNewCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudListOption) ([]securitygroup.TCloudSG, error)) *TCloudListSecurityGroupNewCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSecurityGroupRule mocks base method.
+func (m *MockTCloud) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudListOption) (*v20170312.SecurityGroupPolicySet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].(*v20170312.SecurityGroupPolicySet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityGroupRule indicates an expected call of ListSecurityGroupRule.
+func (mr *MockTCloudMockRecorder) ListSecurityGroupRule(kt, opt interface{}) *TCloudListSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroupRule", reflect.TypeOf((*MockTCloud)(nil).ListSecurityGroupRule), kt, opt)
+	return &TCloudListSecurityGroupRuleCall{Call: call}
+}
+
+// TCloudListSecurityGroupRuleCall wrap *gomock.Call
+type TCloudListSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListSecurityGroupRuleCall) Return(arg0 *v20170312.SecurityGroupPolicySet, arg1 error) *TCloudListSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.TCloudListOption) (*v20170312.SecurityGroupPolicySet, error)) *TCloudListSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.TCloudListOption) (*v20170312.SecurityGroupPolicySet, error)) *TCloudListSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSubnet mocks base method.
+func (m *MockTCloud) ListSubnet(kt *kit.Kit, opt *core.TCloudListOption) (*adtysubnet.TCloudSubnetListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnet", kt, opt)
+	ret0, _ := ret[0].(*adtysubnet.TCloudSubnetListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnet indicates an expected call of ListSubnet.
+func (mr *MockTCloudMockRecorder) ListSubnet(kt, opt interface{}) *TCloudListSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnet", reflect.TypeOf((*MockTCloud)(nil).ListSubnet), kt, opt)
+	return &TCloudListSubnetCall{Call: call}
+}
+
+// TCloudListSubnetCall wrap *gomock.Call
+type TCloudListSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListSubnetCall) Return(arg0 *adtysubnet.TCloudSubnetListResult, arg1 error) *TCloudListSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListSubnetCall) Do(f func(*kit.Kit, *core.TCloudListOption) (*adtysubnet.TCloudSubnetListResult, error)) *TCloudListSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListSubnetCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) (*adtysubnet.TCloudSubnetListResult, error)) *TCloudListSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListTargetHealth mocks base method.
+func (m *MockTCloud) ListTargetHealth(kt *kit.Kit, opt *loadbalancer.TCloudListTargetHealthOption) ([]loadbalancer.TCloudTargetHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTargetHealth", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudTargetHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTargetHealth indicates an expected call of ListTargetHealth.
+func (mr *MockTCloudMockRecorder) ListTargetHealth(kt, opt interface{}) *TCloudListTargetHealthCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTargetHealth", reflect.TypeOf((*MockTCloud)(nil).ListTargetHealth), kt, opt)
+	return &TCloudListTargetHealthCall{Call: call}
+}
+
+// TCloudListTargetHealthCall wrap *gomock.Call
+type TCloudListTargetHealthCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListTargetHealthCall) Return(arg0 []loadbalancer.TCloudTargetHealth, arg1 error) *TCloudListTargetHealthCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListTargetHealthCall) Do(f func(*kit.Kit, *loadbalancer.TCloudListTargetHealthOption) ([]loadbalancer.TCloudTargetHealth, error)) *TCloudListTargetHealthCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListTargetHealthCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudListTargetHealthOption) ([]loadbalancer.TCloudTargetHealth, error)) *TCloudListTargetHealthCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListTargets mocks base method.
+func (m *MockTCloud) ListTargets(kt *kit.Kit, opt *loadbalancer.TCloudListTargetsOption) ([]loadbalancer.TCloudListenerTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTargets", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudListenerTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTargets indicates an expected call of ListTargets.
+func (mr *MockTCloudMockRecorder) ListTargets(kt, opt interface{}) *TCloudListTargetsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTargets", reflect.TypeOf((*MockTCloud)(nil).ListTargets), kt, opt)
+	return &TCloudListTargetsCall{Call: call}
+}
+
+// TCloudListTargetsCall wrap *gomock.Call
+type TCloudListTargetsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListTargetsCall) Return(arg0 []loadbalancer.TCloudListenerTarget, arg1 error) *TCloudListTargetsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListTargetsCall) Do(f func(*kit.Kit, *loadbalancer.TCloudListTargetsOption) ([]loadbalancer.TCloudListenerTarget, error)) *TCloudListTargetsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListTargetsCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudListTargetsOption) ([]loadbalancer.TCloudListenerTarget, error)) *TCloudListTargetsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListVpc mocks base method.
+func (m *MockTCloud) ListVpc(kt *kit.Kit, opt *core.TCloudListOption) (*types.TCloudVpcListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVpc", kt, opt)
+	ret0, _ := ret[0].(*types.TCloudVpcListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVpc indicates an expected call of ListVpc.
+func (mr *MockTCloudMockRecorder) ListVpc(kt, opt interface{}) *TCloudListVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVpc", reflect.TypeOf((*MockTCloud)(nil).ListVpc), kt, opt)
+	return &TCloudListVpcCall{Call: call}
+}
+
+// TCloudListVpcCall wrap *gomock.Call
+type TCloudListVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListVpcCall) Return(arg0 *types.TCloudVpcListResult, arg1 error) *TCloudListVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListVpcCall) Do(f func(*kit.Kit, *core.TCloudListOption) (*types.TCloudVpcListResult, error)) *TCloudListVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListVpcCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) (*types.TCloudVpcListResult, error)) *TCloudListVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListZone mocks base method.
+func (m *MockTCloud) ListZone(kt *kit.Kit, opt *zone.TCloudZoneListOption) ([]zone.TCloudZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZone", kt, opt)
+	ret0, _ := ret[0].([]zone.TCloudZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZone indicates an expected call of ListZone.
+func (mr *MockTCloudMockRecorder) ListZone(kt, opt interface{}) *TCloudListZoneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZone", reflect.TypeOf((*MockTCloud)(nil).ListZone), kt, opt)
+	return &TCloudListZoneCall{Call: call}
+}
+
+// TCloudListZoneCall wrap *gomock.Call
+type TCloudListZoneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListZoneCall) Return(arg0 []zone.TCloudZone, arg1 error) *TCloudListZoneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListZoneCall) Do(f func(*kit.Kit, *zone.TCloudZoneListOption) ([]zone.TCloudZone, error)) *TCloudListZoneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListZoneCall) DoAndReturn(f func(*kit.Kit, *zone.TCloudZoneListOption) ([]zone.TCloudZone, error)) *TCloudListZoneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ModifyTargetPort mocks base method.
+func (m *MockTCloud) ModifyTargetPort(kt *kit.Kit, opt *loadbalancer.TCloudTargetPortUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyTargetPort", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyTargetPort indicates an expected call of ModifyTargetPort.
+func (mr *MockTCloudMockRecorder) ModifyTargetPort(kt, opt interface{}) *TCloudModifyTargetPortCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyTargetPort", reflect.TypeOf((*MockTCloud)(nil).ModifyTargetPort), kt, opt)
+	return &TCloudModifyTargetPortCall{Call: call}
 }
 
-// TCloudGetAccountInfoBySecretCall wrap *gomock.Call
-type TCloudGetAccountInfoBySecretCall struct {
+// TCloudModifyTargetPortCall wrap *gomock.Call
+type TCloudModifyTargetPortCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudGetAccountInfoBySecretCall) Return(arg0 *cloud.TCloudInfoBySecret, arg1 error) *TCloudGetAccountInfoBySecretCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudModifyTargetPortCall) Return(arg0 error) *TCloudModifyTargetPortCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudGetAccountInfoBySecretCall) Do(f func(*kit.Kit) (*cloud.TCloudInfoBySecret, error)) *TCloudGetAccountInfoBySecretCall {
+func (c *TCloudModifyTargetPortCall) Do(f func(*kit.Kit, *loadbalancer.TCloudTargetPortUpdateOption) error) *TCloudModifyTargetPortCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudGetAccountInfoBySecretCall) DoAndReturn(f func(*kit.Kit) (*cloud.TCloudInfoBySecret, error)) *TCloudGetAccountInfoBySecretCall {
+func (c *TCloudModifyTargetPortCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudTargetPortUpdateOption) error) *TCloudModifyTargetPortCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetAccountZoneQuota mocks base method.
-func (m *MockTCloud) GetAccountZoneQuota(kt *kit.Kit, opt *account.GetTCloudAccountZoneQuotaOption) (*account.TCloudAccountQuota, error) {
+// ModifyTargetWeight mocks base method.
+func (m *MockTCloud) ModifyTargetWeight(kt *kit.Kit, opt *loadbalancer.TCloudTargetWeightUpdateOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountZoneQuota", kt, opt)
-	ret0, _ := ret[0].(*account.TCloudAccountQuota)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ModifyTargetWeight", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAccountZoneQuota indicates an expected call of GetAccountZoneQuota.
-func (mr *MockTCloudMockRecorder) GetAccountZoneQuota(kt, opt interface{}) *TCloudGetAccountZoneQuotaCall {
+// ModifyTargetWeight indicates an expected call of ModifyTargetWeight.
+func (mr *MockTCloudMockRecorder) ModifyTargetWeight(kt, opt interface{}) *TCloudModifyTargetWeightCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountZoneQuota", reflect.TypeOf((*MockTCloud)(nil).GetAccountZoneQuota), kt, opt)
-	return &TCloudGetAccountZoneQuotaCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyTargetWeight", reflect.TypeOf((*MockTCloud)(nil).ModifyTargetWeight), kt, opt)
+	return &TCloudModifyTargetWeightCall{Call: call}
 }
 
-// TCloudGetAccountZoneQuotaCall wrap *gomock.Call
-type TCloudGetAccountZoneQuotaCall struct {
+// TCloudModifyTargetWeightCall wrap *gomock.Call
+type TCloudModifyTargetWeightCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudGetAccountZoneQuotaCall) Return(arg0 *account.TCloudAccountQuota, arg1 error) *TCloudGetAccountZoneQuotaCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudModifyTargetWeightCall) Return(arg0 error) *TCloudModifyTargetWeightCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudGetAccountZoneQuotaCall) Do(f func(*kit.Kit, *account.GetTCloudAccountZoneQuotaOption) (*account.TCloudAccountQuota, error)) *TCloudGetAccountZoneQuotaCall {
+func (c *TCloudModifyTargetWeightCall) Do(f func(*kit.Kit, *loadbalancer.TCloudTargetWeightUpdateOption) error) *TCloudModifyTargetWeightCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudGetAccountZoneQuotaCall) DoAndReturn(f func(*kit.Kit, *account.GetTCloudAccountZoneQuotaOption) (*account.TCloudAccountQuota, error)) *TCloudGetAccountZoneQuotaCall {
+func (c *TCloudModifyTargetWeightCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudTargetWeightUpdateOption) error) *TCloudModifyTargetWeightCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetBillList mocks base method.
-func (m *MockTCloud) GetBillList(kt *kit.Kit, opt *bill.TCloudBillListOption) (*v20180709.DescribeBillDetailResponseParams, error) {
+// RebootCvm mocks base method.
+func (m *MockTCloud) RebootCvm(kt *kit.Kit, opt *cvm.TCloudRebootOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBillList", kt, opt)
-	ret0, _ := ret[0].(*v20180709.DescribeBillDetailResponseParams)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RebootCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetBillList indicates an expected call of GetBillList.
-func (mr *MockTCloudMockRecorder) GetBillList(kt, opt interface{}) *TCloudGetBillListCall {
+// RebootCvm indicates an expected call of RebootCvm.
+func (mr *MockTCloudMockRecorder) RebootCvm(kt, opt interface{}) *TCloudRebootCvmCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillList", reflect.TypeOf((*MockTCloud)(nil).GetBillList), kt, opt)
-	return &TCloudGetBillListCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebootCvm", reflect.TypeOf((*MockTCloud)(nil).RebootCvm), kt, opt)
+	return &TCloudRebootCvmCall{Call: call}
 }
 
-// TCloudGetBillListCall wrap *gomock.Call
-type TCloudGetBillListCall struct {
+// TCloudRebootCvmCall wrap *gomock.Call
+type TCloudRebootCvmCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudGetBillListCall) Return(arg0 *v20180709.DescribeBillDetailResponseParams, arg1 error) *TCloudGetBillListCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudRebootCvmCall) Return(arg0 error) *TCloudRebootCvmCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudGetBillListCall) Do(f func(*kit.Kit, *bill.TCloudBillListOption) (*v20180709.DescribeBillDetailResponseParams, error)) *TCloudGetBillListCall {
+func (c *TCloudRebootCvmCall) Do(f func(*kit.Kit, *cvm.TCloudRebootOption) error) *TCloudRebootCvmCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudGetBillListCall) DoAndReturn(f func(*kit.Kit, *bill.TCloudBillListOption) (*v20180709.DescribeBillDetailResponseParams, error)) *TCloudGetBillListCall {
+func (c *TCloudRebootCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudRebootOption) error) *TCloudRebootCvmCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// InquiryPriceCvm mocks base method.
-func (m *MockTCloud) InquiryPriceCvm(kt *kit.Kit, opt *cvm.TCloudCreateOption) (*cvm.InquiryPriceResult, error) {
+// RegisterTargets mocks base method.
+func (m *MockTCloud) RegisterTargets(kt *kit.Kit, opt *loadbalancer.TCloudRegisterTargetsOption) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InquiryPriceCvm", kt, opt)
-	ret0, _ := ret[0].(*cvm.InquiryPriceResult)
+	ret := m.ctrl.Call(m, "RegisterTargets", kt, opt)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InquiryPriceCvm indicates an expected call of InquiryPriceCvm.
-func (mr *MockTCloudMockRecorder) InquiryPriceCvm(kt, opt interface{}) *TCloudInquiryPriceCvmCall {
+// RegisterTargets indicates an expected call of RegisterTargets.
+func (mr *MockTCloudMockRecorder) RegisterTargets(kt, opt interface{}) *TCloudRegisterTargetsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InquiryPriceCvm", reflect.TypeOf((*MockTCloud)(nil).InquiryPriceCvm), kt, opt)
-	return &TCloudInquiryPriceCvmCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTargets", reflect.TypeOf((*MockTCloud)(nil).RegisterTargets), kt, opt)
+	return &TCloudRegisterTargetsCall{Call: call}
 }
 
-// TCloudInquiryPriceCvmCall wrap *gomock.Call
-type TCloudInquiryPriceCvmCall struct {
+// TCloudRegisterTargetsCall wrap *gomock.Call
+type TCloudRegisterTargetsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudInquiryPriceCvmCall) Return(arg0 *cvm.InquiryPriceResult, arg1 error) *TCloudInquiryPriceCvmCall {
+func (c *TCloudRegisterTargetsCall) Return(arg0 []string, arg1 error) *TCloudRegisterTargetsCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudInquiryPriceCvmCall) Do(f func(*kit.Kit, *cvm.TCloudCreateOption) (*cvm.InquiryPriceResult, error)) *TCloudInquiryPriceCvmCall {
+func (c *TCloudRegisterTargetsCall) Do(f func(*kit.Kit, *loadbalancer.TCloudRegisterTargetsOption) ([]string, error)) *TCloudRegisterTargetsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudInquiryPriceCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudCreateOption) (*cvm.InquiryPriceResult, error)) *TCloudInquiryPriceCvmCall {
+func (c *TCloudRegisterTargetsCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudRegisterTargetsOption) ([]string, error)) *TCloudRegisterTargetsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// InquiryPriceDisk mocks base method.
-func (m *MockTCloud) InquiryPriceDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (*cvm.InquiryPriceResult, error) {
+// ResetCvmInstance mocks base method.
+func (m *MockTCloud) ResetCvmInstance(kt *kit.Kit, opt *cvm.ResetInstanceOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InquiryPriceDisk", kt, opt)
-	ret0, _ := ret[0].(*cvm.InquiryPriceResult)
+	ret := m.ctrl.Call(m, "ResetCvmInstance", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InquiryPriceDisk indicates an expected call of InquiryPriceDisk.
-func (mr *MockTCloudMockRecorder) InquiryPriceDisk(kt, opt interface{}) *TCloudInquiryPriceDiskCall {
+// ResetCvmInstance indicates an expected call of ResetCvmInstance.
+func (mr *MockTCloudMockRecorder) ResetCvmInstance(kt, opt interface{}) *TCloudResetCvmInstanceCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InquiryPriceDisk", reflect.TypeOf((*MockTCloud)(nil).InquiryPriceDisk), kt, opt)
-	return &TCloudInquiryPriceDiskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetCvmInstance", reflect.TypeOf((*MockTCloud)(nil).ResetCvmInstance), kt, opt)
+	return &TCloudResetCvmInstanceCall{Call: call}
 }
 
-// TCloudInquiryPriceDiskCall wrap *gomock.Call
-type TCloudInquiryPriceDiskCall struct {
+// TCloudResetCvmInstanceCall wrap *gomock.Call
+type TCloudResetCvmInstanceCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudInquiryPriceDiskCall) Return(arg0 *cvm.InquiryPriceResult, arg1 error) *TCloudInquiryPriceDiskCall {
+func (c *TCloudResetCvmInstanceCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudResetCvmInstanceCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudInquiryPriceDiskCall) Do(f func(*kit.Kit, *disk.TCloudDiskCreateOption) (*cvm.InquiryPriceResult, error)) *TCloudInquiryPriceDiskCall {
+func (c *TCloudResetCvmInstanceCall) Do(f func(*kit.Kit, *cvm.ResetInstanceOption) (*poller.BaseDoneResult, error)) *TCloudResetCvmInstanceCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudInquiryPriceDiskCall) DoAndReturn(f func(*kit.Kit, *disk.TCloudDiskCreateOption) (*cvm.InquiryPriceResult, error)) *TCloudInquiryPriceDiskCall {
+func (c *TCloudResetCvmInstanceCall) DoAndReturn(f func(*kit.Kit, *cvm.ResetInstanceOption) (*poller.BaseDoneResult, error)) *TCloudResetCvmInstanceCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListAccount mocks base method.
-func (m *MockTCloud) ListAccount(kt *kit.Kit) ([]account.TCloudAccount, error) {
+// ResetCvmPwd mocks base method.
+func (m *MockTCloud) ResetCvmPwd(kt *kit.Kit, opt *cvm.TCloudResetPwdOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccount", kt)
-	ret0, _ := ret[0].([]account.TCloudAccount)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ResetCvmPwd", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListAccount indicates an expected call of ListAccount.
-func (mr *MockTCloudMockRecorder) ListAccount(kt interface{}) *TCloudListAccountCall {
+// ResetCvmPwd indicates an expected call of ResetCvmPwd.
+func (mr *MockTCloudMockRecorder) ResetCvmPwd(kt, opt interface{}) *TCloudResetCvmPwdCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccount", reflect.TypeOf((*MockTCloud)(nil).ListAccount), kt)
-	return &TCloudListAccountCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetCvmPwd", reflect.TypeOf((*MockTCloud)(nil).ResetCvmPwd), kt, opt)
+	return &TCloudResetCvmPwdCall{Call: call}
 }
 
-// TCloudListAccountCall wrap *gomock.Call
-type TCloudListAccountCall struct {
+// TCloudResetCvmPwdCall wrap *gomock.Call
+type TCloudResetCvmPwdCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListAccountCall) Return(arg0 []account.TCloudAccount, arg1 error) *TCloudListAccountCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudResetCvmPwdCall) Return(arg0 error) *TCloudResetCvmPwdCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListAccountCall) Do(f func(*kit.Kit) ([]account.TCloudAccount, error)) *TCloudListAccountCall {
+func (c *TCloudResetCvmPwdCall) Do(f func(*kit.Kit, *cvm.TCloudResetPwdOption) error) *TCloudResetCvmPwdCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListAccountCall) DoAndReturn(f func(*kit.Kit) ([]account.TCloudAccount, error)) *TCloudListAccountCall {
+func (c *TCloudResetCvmPwdCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudResetPwdOption) error) *TCloudResetCvmPwdCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListCvm mocks base method.
-func (m *MockTCloud) ListCvm(kt *kit.Kit, opt *cvm.TCloudListOption) ([]cvm.TCloudCvm, error) {
+// RollbackDiskSnapshot mocks base method.
+func (m *MockTCloud) RollbackDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudSnapshotRollbackOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCvm", kt, opt)
-	ret0, _ := ret[0].([]cvm.TCloudCvm)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RollbackDiskSnapshot", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListCvm indicates an expected call of ListCvm.
-func (mr *MockTCloudMockRecorder) ListCvm(kt, opt interface{}) *TCloudListCvmCall {
+// RollbackDiskSnapshot indicates an expected call of RollbackDiskSnapshot.
+func (mr *MockTCloudMockRecorder) RollbackDiskSnapshot(kt, opt interface{}) *TCloudRollbackDiskSnapshotCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCvm", reflect.TypeOf((*MockTCloud)(nil).ListCvm), kt, opt)
-	return &TCloudListCvmCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackDiskSnapshot", reflect.TypeOf((*MockTCloud)(nil).RollbackDiskSnapshot), kt, opt)
+	return &TCloudRollbackDiskSnapshotCall{Call: call}
 }
 
-// TCloudListCvmCall wrap *gomock.Call
-type TCloudListCvmCall struct {
+// TCloudRollbackDiskSnapshotCall wrap *gomock.Call
+type TCloudRollbackDiskSnapshotCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListCvmCall) Return(arg0 []cvm.TCloudCvm, arg1 error) *TCloudListCvmCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudRollbackDiskSnapshotCall) Return(arg0 error) *TCloudRollbackDiskSnapshotCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListCvmCall) Do(f func(*kit.Kit, *cvm.TCloudListOption) ([]cvm.TCloudCvm, error)) *TCloudListCvmCall {
+func (c *TCloudRollbackDiskSnapshotCall) Do(f func(*kit.Kit, *disksnapshot.TCloudSnapshotRollbackOption) error) *TCloudRollbackDiskSnapshotCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudListOption) ([]cvm.TCloudCvm, error)) *TCloudListCvmCall {
+func (c *TCloudRollbackDiskSnapshotCall) DoAndReturn(f func(*kit.Kit, *disksnapshot.TCloudSnapshotRollbackOption) error) *TCloudRollbackDiskSnapshotCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListDisk mocks base method.
-func (m *MockTCloud) ListDisk(kt *kit.Kit, opt *core.TCloudListOption) ([]disk.TCloudDisk, error) {
+// SecurityGroupCvmAssociate mocks base method.
+func (m *MockTCloud) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.TCloudAssociateCvmOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDisk", kt, opt)
-	ret0, _ := ret[0].([]disk.TCloudDisk)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SecurityGroupCvmAssociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListDisk indicates an expected call of ListDisk.
-func (mr *MockTCloudMockRecorder) ListDisk(kt, opt interface{}) *TCloudListDiskCall {
+// SecurityGroupCvmAssociate indicates an expected call of SecurityGroupCvmAssociate.
+func (mr *MockTCloudMockRecorder) SecurityGroupCvmAssociate(kt, opt interface{}) *TCloudSecurityGroupCvmAssociateCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisk", reflect.TypeOf((*MockTCloud)(nil).ListDisk), kt, opt)
-	return &TCloudListDiskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupCvmAssociate", reflect.TypeOf((*MockTCloud)(nil).SecurityGroupCvmAssociate), kt, opt)
+	return &TCloudSecurityGroupCvmAssociateCall{Call: call}
 }
 
-// TCloudListDiskCall wrap *gomock.Call
-type TCloudListDiskCall struct {
+// TCloudSecurityGroupCvmAssociateCall wrap *gomock.Call
+type TCloudSecurityGroupCvmAssociateCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListDiskCall) Return(arg0 []disk.TCloudDisk, arg1 error) *TCloudListDiskCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudSecurityGroupCvmAssociateCall) Return(arg0 error) *TCloudSecurityGroupCvmAssociateCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListDiskCall) Do(f func(*kit.Kit, *core.TCloudListOption) ([]disk.TCloudDisk, error)) *TCloudListDiskCall {
+func (c *TCloudSecurityGroupCvmAssociateCall) Do(f func(*kit.Kit, *securitygroup.TCloudAssociateCvmOption) error) *TCloudSecurityGroupCvmAssociateCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListDiskCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) ([]disk.TCloudDisk, error)) *TCloudListDiskCall {
+func (c *TCloudSecurityGroupCvmAssociateCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudAssociateCvmOption) error) *TCloudSecurityGroupCvmAssociateCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListEip mocks base method.
-func (m *MockTCloud) ListEip(kt *kit.Kit, opt *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error) {
+// SecurityGroupCvmBatchAssociate mocks base method.
+func (m *MockTCloud) SecurityGroupCvmBatchAssociate(kt *kit.Kit, opt *securitygroup.TCloudBatchAssociateCvmOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEip", kt, opt)
-	ret0, _ := ret[0].(*eip.TCloudEipListResult)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SecurityGroupCvmBatchAssociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListEip indicates an expected call of ListEip.
-func (mr *MockTCloudMockRecorder) ListEip(kt, opt interface{}) *TCloudListEipCall {
+// SecurityGroupCvmBatchAssociate indicates an expected call of SecurityGroupCvmBatchAssociate.
+func (mr *MockTCloudMockRecorder) SecurityGroupCvmBatchAssociate(kt, opt interface{}) *TCloudSecurityGroupCvmBatchAssociateCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEip", reflect.TypeOf((*MockTCloud)(nil).ListEip), kt, opt)
-	return &TCloudListEipCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupCvmBatchAssociate", reflect.TypeOf((*MockTCloud)(nil).SecurityGroupCvmBatchAssociate), kt, opt)
+	return &TCloudSecurityGroupCvmBatchAssociateCall{Call: call}
 }
 
-// TCloudListEipCall wrap *gomock.Call
-type TCloudListEipCall struct {
+// TCloudSecurityGroupCvmBatchAssociateCall wrap *gomock.Call
+type TCloudSecurityGroupCvmBatchAssociateCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListEipCall) Return(arg0 *eip.TCloudEipListResult, arg1 error) *TCloudListEipCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudSecurityGroupCvmBatchAssociateCall) Return(arg0 error) *TCloudSecurityGroupCvmBatchAssociateCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListEipCall) Do(f func(*kit.Kit, *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error)) *TCloudListEipCall {
+func (c *TCloudSecurityGroupCvmBatchAssociateCall) Do(f func(*kit.Kit, *securitygroup.TCloudBatchAssociateCvmOption) error) *TCloudSecurityGroupCvmBatchAssociateCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error)) *TCloudListEipCall {
+func (c *TCloudSecurityGroupCvmBatchAssociateCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudBatchAssociateCvmOption) error) *TCloudSecurityGroupCvmBatchAssociateCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListImage mocks base method.
-func (m *MockTCloud) ListImage(kt *kit.Kit, opt *image.TCloudImageListOption) (*image.TCloudImageListResult, error) {
+// SecurityGroupCvmBatchDisassociate mocks base method.
+func (m *MockTCloud) SecurityGroupCvmBatchDisassociate(kt *kit.Kit, opt *securitygroup.TCloudBatchAssociateCvmOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListImage", kt, opt)
-	ret0, _ := ret[0].(*image.TCloudImageListResult)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SecurityGroupCvmBatchDisassociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListImage indicates an expected call of ListImage.
-func (mr *MockTCloudMockRecorder) ListImage(kt, opt interface{}) *TCloudListImageCall {
+// SecurityGroupCvmBatchDisassociate indicates an expected call of SecurityGroupCvmBatchDisassociate.
+func (mr *MockTCloudMockRecorder) SecurityGroupCvmBatchDisassociate(kt, opt interface{}) *TCloudSecurityGroupCvmBatchDisassociateCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImage", reflect.TypeOf((*MockTCloud)(nil).ListImage), kt, opt)
-	return &TCloudListImageCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupCvmBatchDisassociate", reflect.TypeOf((*MockTCloud)(nil).SecurityGroupCvmBatchDisassociate), kt, opt)
+	return &TCloudSecurityGroupCvmBatchDisassociateCall{Call: call}
 }
 
-// TCloudListImageCall wrap *gomock.Call
-type TCloudListImageCall struct {
+// TCloudSecurityGroupCvmBatchDisassociateCall wrap *gomock.Call
+type TCloudSecurityGroupCvmBatchDisassociateCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListImageCall) Return(arg0 *image.TCloudImageListResult, arg1 error) *TCloudListImageCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudSecurityGroupCvmBatchDisassociateCall) Return(arg0 error) *TCloudSecurityGroupCvmBatchDisassociateCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListImageCall) Do(f func(*kit.Kit, *image.TCloudImageListOption) (*image.TCloudImageListResult, error)) *TCloudListImageCall {
+func (c *TCloudSecurityGroupCvmBatchDisassociateCall) Do(f func(*kit.Kit, *securitygroup.TCloudBatchAssociateCvmOption) error) *TCloudSecurityGroupCvmBatchDisassociateCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListImageCall) DoAndReturn(f func(*kit.Kit, *image.TCloudImageListOption) (*image.TCloudImageListResult, error)) *TCloudListImageCall {
+func (c *TCloudSecurityGroupCvmBatchDisassociateCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudBatchAssociateCvmOption) error) *TCloudSecurityGroupCvmBatchDisassociateCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListInstanceType mocks base method.
-func (m *MockTCloud) ListInstanceType(kt *kit.Kit, opt *instancetype.TCloudInstanceTypeListOption) ([]instancetype.TCloudInstanceType, error) {
+// SecurityGroupCvmDisassociate mocks base method.
+func (m *MockTCloud) SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.TCloudAssociateCvmOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListInstanceType", kt, opt)
-	ret0, _ := ret[0].([]instancetype.TCloudInstanceType)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SecurityGroupCvmDisassociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListInstanceType indicates an expected call of ListInstanceType.
-func (mr *MockTCloudMockRecorder) ListInstanceType(kt, opt interface{}) *TCloudListInstanceTypeCall {
+// SecurityGroupCvmDisassociate indicates an expected call of SecurityGroupCvmDisassociate.
+func (mr *MockTCloudMockRecorder) SecurityGroupCvmDisassociate(kt, opt interface{}) *TCloudSecurityGroupCvmDisassociateCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceType", reflect.TypeOf((*MockTCloud)(nil).ListInstanceType), kt, opt)
-	return &TCloudListInstanceTypeCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupCvmDisassociate", reflect.TypeOf((*MockTCloud)(nil).SecurityGroupCvmDisassociate), kt, opt)
+	return &TCloudSecurityGroupCvmDisassociateCall{Call: call}
 }
 
-// TCloudListInstanceTypeCall wrap *gomock.Call
-type TCloudListInstanceTypeCall struct {
+// TCloudSecurityGroupCvmDisassociateCall wrap *gomock.Call
+type TCloudSecurityGroupCvmDisassociateCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListInstanceTypeCall) Return(arg0 []instancetype.TCloudInstanceType, arg1 error) *TCloudListInstanceTypeCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudSecurityGroupCvmDisassociateCall) Return(arg0 error) *TCloudSecurityGroupCvmDisassociateCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListInstanceTypeCall) Do(f func(*kit.Kit, *instancetype.TCloudInstanceTypeListOption) ([]instancetype.TCloudInstanceType, error)) *TCloudListInstanceTypeCall {
+func (c *TCloudSecurityGroupCvmDisassociateCall) Do(f func(*kit.Kit, *securitygroup.TCloudAssociateCvmOption) error) *TCloudSecurityGroupCvmDisassociateCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListInstanceTypeCall) DoAndReturn(f func(*kit.Kit, *instancetype.TCloudInstanceTypeListOption) ([]instancetype.TCloudInstanceType, error)) *TCloudListInstanceTypeCall {
+func (c *TCloudSecurityGroupCvmDisassociateCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudAssociateCvmOption) error) *TCloudSecurityGroupCvmDisassociateCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListPoliciesGrantingServiceAccess mocks base method.
-func (m *MockTCloud) ListPoliciesGrantingServiceAccess(kt *kit.Kit, opt *account.TCloudListPolicyOption) ([]*v20190116.ListGrantServiceAccessNode, error) {
+// SetLoadBalancerSecurityGroups mocks base method.
+func (m *MockTCloud) SetLoadBalancerSecurityGroups(kt *kit.Kit, opt *loadbalancer.TCloudSetClbSecurityGroupOption) (*v20180317.SetLoadBalancerSecurityGroupsResponseParams, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPoliciesGrantingServiceAccess", kt, opt)
-	ret0, _ := ret[0].([]*v20190116.ListGrantServiceAccessNode)
+	ret := m.ctrl.Call(m, "SetLoadBalancerSecurityGroups", kt, opt)
+	ret0, _ := ret[0].(*v20180317.SetLoadBalancerSecurityGroupsResponseParams)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPoliciesGrantingServiceAccess indicates an expected call of ListPoliciesGrantingServiceAccess.
-func (mr *MockTCloudMockRecorder) ListPoliciesGrantingServiceAccess(kt, opt interface{}) *TCloudListPoliciesGrantingServiceAccessCall {
+// SetLoadBalancerSecurityGroups indicates an expected call of SetLoadBalancerSecurityGroups.
+func (mr *MockTCloudMockRecorder) SetLoadBalancerSecurityGroups(kt, opt interface{}) *TCloudSetLoadBalancerSecurityGroupsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPoliciesGrantingServiceAccess", reflect.TypeOf((*MockTCloud)(nil).ListPoliciesGrantingServiceAccess), kt, opt)
-	return &TCloudListPoliciesGrantingServiceAccessCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoadBalancerSecurityGroups", reflect.TypeOf((*MockTCloud)(nil).SetLoadBalancerSecurityGroups), kt, opt)
+	return &TCloudSetLoadBalancerSecurityGroupsCall{Call: call}
 }
 
-// TCloudListPoliciesGrantingServiceAccessCall wrap *gomock.Call
-type TCloudListPoliciesGrantingServiceAccessCall struct {
+// TCloudSetLoadBalancerSecurityGroupsCall wrap *gomock.Call
+type TCloudSetLoadBalancerSecurityGroupsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListPoliciesGrantingServiceAccessCall) Return(arg0 []*v20190116.ListGrantServiceAccessNode, arg1 error) *TCloudListPoliciesGrantingServiceAccessCall {
+func (c *TCloudSetLoadBalancerSecurityGroupsCall) Return(arg0 *v20180317.SetLoadBalancerSecurityGroupsResponseParams, arg1 error) *TCloudSetLoadBalancerSecurityGroupsCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListPoliciesGrantingServiceAccessCall) Do(f func(*kit.Kit, *account.TCloudListPolicyOption) ([]*v20190116.ListGrantServiceAccessNode, error)) *TCloudListPoliciesGrantingServiceAccessCall {
+func (c *TCloudSetLoadBalancerSecurityGroupsCall) Do(f func(*kit.Kit, *loadbalancer.TCloudSetClbSecurityGroupOption) (*v20180317.SetLoadBalancerSecurityGroupsResponseParams, error)) *TCloudSetLoadBalancerSecurityGroupsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListPoliciesGrantingServiceAccessCall) DoAndReturn(f func(*kit.Kit, *account.TCloudListPolicyOption) ([]*v20190116.ListGrantServiceAccessNode, error)) *TCloudListPoliciesGrantingServiceAccessCall {
+func (c *TCloudSetLoadBalancerSecurityGroupsCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudSetClbSecurityGroupOption) (*v20180317.SetLoadBalancerSecurityGroupsResponseParams, error)) *TCloudSetLoadBalancerSecurityGroupsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
-
-// ListRegion mocks base method.
-func (m *MockTCloud) ListRegion(kt *kit.Kit) (*region.TCloudRegionListResult, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRegion", kt)
-	ret0, _ := ret[0].(*region.TCloudRegionListResult)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+
+// SetRateLimitRetryWithRandomInterval mocks base method.
+func (m *MockTCloud) SetRateLimitRetryWithRandomInterval(retryable bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRateLimitRetryWithRandomInterval", retryable)
 }
 
-// ListRegion indicates an expected call of ListRegion.
-func (mr *MockTCloudMockRecorder) ListRegion(kt interface{}) *TCloudListRegionCall {
+// SetRateLimitRetryWithRandomInterval indicates an expected call of SetRateLimitRetryWithRandomInterval.
+func (mr *MockTCloudMockRecorder) SetRateLimitRetryWithRandomInterval(retryable interface{}) *TCloudSetRateLimitRetryWithRandomIntervalCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegion", reflect.TypeOf((*MockTCloud)(nil).ListRegion), kt)
-	return &TCloudListRegionCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRateLimitRetryWithRandomInterval", reflect.TypeOf((*MockTCloud)(nil).SetRateLimitRetryWithRandomInterval), retryable)
+	return &TCloudSetRateLimitRetryWithRandomIntervalCall{Call: call}
 }
 
-// TCloudListRegionCall wrap *gomock.Call
-type TCloudListRegionCall struct {
+// TCloudSetRateLimitRetryWithRandomIntervalCall wrap *gomock.Call
+type TCloudSetRateLimitRetryWithRandomIntervalCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListRegionCall) Return(arg0 *region.TCloudRegionListResult, arg1 error) *TCloudListRegionCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudSetRateLimitRetryWithRandomIntervalCall) Return() *TCloudSetRateLimitRetryWithRandomIntervalCall {
+	c.Call = c.Call.Return()
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListRegionCall) Do(f func(*kit.Kit) (*region.TCloudRegionListResult, error)) *TCloudListRegionCall {
+func (c *TCloudSetRateLimitRetryWithRandomIntervalCall) Do(f func(bool)) *TCloudSetRateLimitRetryWithRandomIntervalCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListRegionCall) DoAndReturn(f func(*kit.Kit) (*region.TCloudRegionListResult, error)) *TCloudListRegionCall {
+func (c *TCloudSetRateLimitRetryWithRandomIntervalCall) DoAndReturn(f func(bool)) *TCloudSetRateLimitRetryWithRandomIntervalCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListRouteTable mocks base method.
-func (m *MockTCloud) ListRouteTable(kt *kit.Kit, opt *core.TCloudListOption) (*routetable.TCloudRouteTableListResult, error) {
+// StartCvm mocks base method.
+func (m *MockTCloud) StartCvm(kt *kit.Kit, opt *cvm.TCloudStartOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRouteTable", kt, opt)
-	ret0, _ := ret[0].(*routetable.TCloudRouteTableListResult)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "StartCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListRouteTable indicates an expected call of ListRouteTable.
-func (mr *MockTCloudMockRecorder) ListRouteTable(kt, opt interface{}) *TCloudListRouteTableCall {
+// StartCvm indicates an expected call of StartCvm.
+func (mr *MockTCloudMockRecorder) StartCvm(kt, opt interface{}) *TCloudStartCvmCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouteTable", reflect.TypeOf((*MockTCloud)(nil).ListRouteTable), kt, opt)
-	return &TCloudListRouteTableCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCvm", reflect.TypeOf((*MockTCloud)(nil).StartCvm), kt, opt)
+	return &TCloudStartCvmCall{Call: call}
 }
 
-// TCloudListRouteTableCall wrap *gomock.Call
-type TCloudListRouteTableCall struct {
+// TCloudStartCvmCall wrap *gomock.Call
+type TCloudStartCvmCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListRouteTableCall) Return(arg0 *routetable.TCloudRouteTableListResult, arg1 error) *TCloudListRouteTableCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudStartCvmCall) Return(arg0 error) *TCloudStartCvmCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListRouteTableCall) Do(f func(*kit.Kit, *core.TCloudListOption) (*routetable.TCloudRouteTableListResult, error)) *TCloudListRouteTableCall {
+func (c *TCloudStartCvmCall) Do(f func(*kit.Kit, *cvm.TCloudStartOption) error) *TCloudStartCvmCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListRouteTableCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) (*routetable.TCloudRouteTableListResult, error)) *TCloudListRouteTableCall {
+func (c *TCloudStartCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudStartOption) error) *TCloudStartCvmCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListSecurityGroupNew mocks base method.
-func (m *MockTCloud) ListSecurityGroupNew(kt *kit.Kit, opt *securitygroup.TCloudListOption) ([]securitygroup.TCloudSG, error) {
+// StopCvm mocks base method.
+func (m *MockTCloud) StopCvm(kt *kit.Kit, opt *cvm.TCloudStopOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSecurityGroupNew", kt, opt)
-	ret0, _ := ret[0].([]securitygroup.TCloudSG)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "StopCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListSecurityGroupNew indicates an expected call of ListSecurityGroupNew.
-func (mr *MockTCloudMockRecorder) ListSecurityGroupNew(kt, opt interface{}) *TCloudListSecurityGroupNewCall {
+// StopCvm indicates an expected call of StopCvm.
+func (mr *MockTCloudMockRecorder) StopCvm(kt, opt interface{}) *TCloudStopCvmCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroupNew", reflect.TypeOf((*MockTCloud)(nil).ListSecurityGroupNew), kt, opt)
-	return &TCloudListSecurityGroupNewCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCvm", reflect.TypeOf((*MockTCloud)(nil).StopCvm), kt, opt)
+	return &TCloudStopCvmCall{Call: call}
 }
 
-// TCloudListSecurityGroupNewCall wrap *gomock.Call
-type TCloudListSecurityGroupNewCall struct {
+// TCloudStopCvmCall wrap *gomock.Call
+type TCloudStopCvmCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListSecurityGroupNewCall) Return(arg0 []securitygroup.TCloudSG, arg1 error) *TCloudListSecurityGroupNewCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudStopCvmCall) Return(arg0 error) *TCloudStopCvmCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListSecurityGroupNewCall) Do(f func(*kit.Kit, *securitygroup.TCloudListOption) ([]securitygroup.TCloudSG, error)) *TCloudListSecurityGroupNewCall {
+func (c *TCloudStopCvmCall) Do(f func(*kit.Kit, *cvm.TCloudStopOption) error) *TCloudStopCvmCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListSecurityGroupNewCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudListOption) ([]securitygroup.TCloudSG, error)) *TCloudListSecurityGroupNewCall {
+func (c *TCloudStopCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudStopOption) error) *TCloudStopCvmCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListSecurityGroupRule mocks base method.
-func (m *MockTCloud) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudListOption) (*v20170312.SecurityGroupPolicySet, error) {
+// TagResources mocks base method.
+func (m *MockTCloud) TagResources(kt *kit.Kit, opt *resourcetag.TagResOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSecurityGroupRule", kt, opt)
-	ret0, _ := ret[0].(*v20170312.SecurityGroupPolicySet)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "TagResources", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListSecurityGroupRule indicates an expected call of ListSecurityGroupRule.
-func (mr *MockTCloudMockRecorder) ListSecurityGroupRule(kt, opt interface{}) *TCloudListSecurityGroupRuleCall {
+// TagResources indicates an expected call of TagResources.
+func (mr *MockTCloudMockRecorder) TagResources(kt, opt interface{}) *TCloudTagResourcesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroupRule", reflect.TypeOf((*MockTCloud)(nil).ListSecurityGroupRule), kt, opt)
-	return &TCloudListSecurityGroupRuleCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResources", reflect.TypeOf((*MockTCloud)(nil).TagResources), kt, opt)
+	return &TCloudTagResourcesCall{Call: call}
 }
 
-// TCloudListSecurityGroupRuleCall wrap *gomock.Call
-type TCloudListSecurityGroupRuleCall struct {
+// TCloudTagResourcesCall wrap *gomock.Call
+type TCloudTagResourcesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListSecurityGroupRuleCall) Return(arg0 *v20170312.SecurityGroupPolicySet, arg1 error) *TCloudListSecurityGroupRuleCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudTagResourcesCall) Return(arg0 error) *TCloudTagResourcesCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.TCloudListOption) (*v20170312.SecurityGroupPolicySet, error)) *TCloudListSecurityGroupRuleCall {
+func (c *TCloudTagResourcesCall) Do(f func(*kit.Kit, *resourcetag.TagResOption) error) *TCloudTagResourcesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.TCloudListOption) (*v20170312.SecurityGroupPolicySet, error)) *TCloudListSecurityGroupRuleCall {
+func (c *TCloudTagResourcesCall) DoAndReturn(f func(*kit.Kit, *resourcetag.TagResOption) error) *TCloudTagResourcesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListSubnet mocks base method.
-func (m *MockTCloud) ListSubnet(kt *kit.Kit, opt *core.TCloudListOption) (*adtysubnet.TCloudSubnetListResult, error) {
+// UnTagResources mocks base method.
+func (m *MockTCloud) UnTagResources(kt *kit.Kit, opt *resourcetag.UnTagResOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSubnet", kt, opt)
-	ret0, _ := ret[0].(*adtysubnet.TCloudSubnetListResult)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UnTagResources", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListSubnet indicates an expected call of ListSubnet.
-func (mr *MockTCloudMockRecorder) ListSubnet(kt, opt interface{}) *TCloudListSubnetCall {
+// UnTagResources indicates an expected call of UnTagResources.
+func (mr *MockTCloudMockRecorder) UnTagResources(kt, opt interface{}) *TCloudUnTagResourcesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnet", reflect.TypeOf((*MockTCloud)(nil).ListSubnet), kt, opt)
-	return &TCloudListSubnetCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnTagResources", reflect.TypeOf((*MockTCloud)(nil).UnTagResources), kt, opt)
+	return &TCloudUnTagResourcesCall{Call: call}
 }
 
-// TCloudListSubnetCall wrap *gomock.Call
-type TCloudListSubnetCall struct {
+// TCloudUnTagResourcesCall wrap *gomock.Call
+type TCloudUnTagResourcesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListSubnetCall) Return(arg0 *adtysubnet.TCloudSubnetListResult, arg1 error) *TCloudListSubnetCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *TCloudUnTagResourcesCall) Return(arg0 error) *TCloudUnTagResourcesCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListSubnetCall) Do(f func(*kit.Kit, *core.TCloudListOption) (*adtysubnet.TCloudSubnetListResult, error)) *TCloudListSubnetCall {
+func (c *TCloudUnTagResourcesCall) Do(f func(*kit.Kit, *resourcetag.UnTagResOption) error) *TCloudUnTagResourcesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListSubnetCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) (*adtysubnet.TCloudSubnetListResult, error)) *TCloudListSubnetCall {
+func (c *TCloudUnTagResourcesCall) DoAndReturn(f func(*kit.Kit, *resourcetag.UnTagResOption) error) *TCloudUnTagResourcesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListVpc mocks base method.
-func (m *MockTCloud) ListVpc(kt *kit.Kit, opt *core.TCloudListOption) (*types.TCloudVpcListResult, error) {
+// UpdateArgsTplAddress mocks base method.
+func (m *MockTCloud) UpdateArgsTplAddress(kt *kit.Kit, opt *argstpl.TCloudUpdateAddressOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListVpc", kt, opt)
-	ret0, _ := ret[0].(*types.TCloudVpcListResult)
+	ret := m.ctrl.Call(m, "UpdateArgsTplAddress", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListVpc indicates an expected call of ListVpc.
-func (mr *MockTCloudMockRecorder) ListVpc(kt, opt interface{}) *TCloudListVpcCall {
+// UpdateArgsTplAddress indicates an expected call of UpdateArgsTplAddress.
+func (mr *MockTCloudMockRecorder) UpdateArgsTplAddress(kt, opt interface{}) *TCloudUpdateArgsTplAddressCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVpc", reflect.TypeOf((*MockTCloud)(nil).ListVpc), kt, opt)
-	return &TCloudListVpcCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArgsTplAddress", reflect.TypeOf((*MockTCloud)(nil).UpdateArgsTplAddress), kt, opt)
+	return &TCloudUpdateArgsTplAddressCall{Call: call}
 }
 
-// TCloudListVpcCall wrap *gomock.Call
-type TCloudListVpcCall struct {
+// TCloudUpdateArgsTplAddressCall wrap *gomock.Call
+type TCloudUpdateArgsTplAddressCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListVpcCall) Return(arg0 *types.TCloudVpcListResult, arg1 error) *TCloudListVpcCall {
+func (c *TCloudUpdateArgsTplAddressCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudUpdateArgsTplAddressCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListVpcCall) Do(f func(*kit.Kit, *core.TCloudListOption) (*types.TCloudVpcListResult, error)) *TCloudListVpcCall {
+func (c *TCloudUpdateArgsTplAddressCall) Do(f func(*kit.Kit, *argstpl.TCloudUpdateAddressOption) (*poller.BaseDoneResult, error)) *TCloudUpdateArgsTplAddressCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListVpcCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOption) (*types.TCloudVpcListResult, error)) *TCloudListVpcCall {
+func (c *TCloudUpdateArgsTplAddressCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudUpdateAddressOption) (*poller.BaseDoneResult, error)) *TCloudUpdateArgsTplAddressCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ListZone mocks base method.
-func (m *MockTCloud) ListZone(kt *kit.Kit, opt *zone.TCloudZoneListOption) ([]zone.TCloudZone, error) {
+// UpdateArgsTplAddressGroup mocks base method.
+func (m *MockTCloud) UpdateArgsTplAddressGroup(kt *kit.Kit, opt *argstpl.TCloudUpdateAddressGroupOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListZone", kt, opt)
-	ret0, _ := ret[0].([]zone.TCloudZone)
+	ret := m.ctrl.Call(m, "UpdateArgsTplAddressGroup", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListZone indicates an expected call of ListZone.
-func (mr *MockTCloudMockRecorder) ListZone(kt, opt interface{}) *TCloudListZoneCall {
+// UpdateArgsTplAddressGroup indicates an expected call of UpdateArgsTplAddressGroup.
+func (mr *MockTCloudMockRecorder) UpdateArgsTplAddressGroup(kt, opt interface{}) *TCloudUpdateArgsTplAddressGroupCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZone", reflect.TypeOf((*MockTCloud)(nil).ListZone), kt, opt)
-	return &TCloudListZoneCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArgsTplAddressGroup", reflect.TypeOf((*MockTCloud)(nil).UpdateArgsTplAddressGroup), kt, opt)
+	return &TCloudUpdateArgsTplAddressGroupCall{Call: call}
 }
 
-// TCloudListZoneCall wrap *gomock.Call
-type TCloudListZoneCall struct {
+// TCloudUpdateArgsTplAddressGroupCall wrap *gomock.Call
+type TCloudUpdateArgsTplAddressGroupCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudListZoneCall) Return(arg0 []zone.TCloudZone, arg1 error) *TCloudListZoneCall {
+func (c *TCloudUpdateArgsTplAddressGroupCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudUpdateArgsTplAddressGroupCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudListZoneCall) Do(f func(*kit.Kit, *zone.TCloudZoneListOption) ([]zone.TCloudZone, error)) *TCloudListZoneCall {
+func (c *TCloudUpdateArgsTplAddressGroupCall) Do(f func(*kit.Kit, *argstpl.TCloudUpdateAddressGroupOption) (*poller.BaseDoneResult, error)) *TCloudUpdateArgsTplAddressGroupCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudListZoneCall) DoAndReturn(f func(*kit.Kit, *zone.TCloudZoneListOption) ([]zone.TCloudZone, error)) *TCloudListZoneCall {
+func (c *TCloudUpdateArgsTplAddressGroupCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudUpdateAddressGroupOption) (*poller.BaseDoneResult, error)) *TCloudUpdateArgsTplAddressGroupCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// RebootCvm mocks base method.
-func (m *MockTCloud) RebootCvm(kt *kit.Kit, opt *cvm.TCloudRebootOption) error {
+// UpdateArgsTplService mocks base method.
+func (m *MockTCloud) UpdateArgsTplService(kt *kit.Kit, opt *argstpl.TCloudUpdateServiceOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RebootCvm", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateArgsTplService", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RebootCvm indicates an expected call of RebootCvm.
-func (mr *MockTCloudMockRecorder) RebootCvm(kt, opt interface{}) *TCloudRebootCvmCall {
+// UpdateArgsTplService indicates an expected call of UpdateArgsTplService.
+func (mr *MockTCloudMockRecorder) UpdateArgsTplService(kt, opt interface{}) *TCloudUpdateArgsTplServiceCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebootCvm", reflect.TypeOf((*MockTCloud)(nil).RebootCvm), kt, opt)
-	return &TCloudRebootCvmCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArgsTplService", reflect.TypeOf((*MockTCloud)(nil).UpdateArgsTplService), kt, opt)
+	return &TCloudUpdateArgsTplServiceCall{Call: call}
 }
 
-// TCloudRebootCvmCall wrap *gomock.Call
-type TCloudRebootCvmCall struct {
+// TCloudUpdateArgsTplServiceCall wrap *gomock.Call
+type TCloudUpdateArgsTplServiceCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudRebootCvmCall) Return(arg0 error) *TCloudRebootCvmCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudUpdateArgsTplServiceCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudUpdateArgsTplServiceCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudRebootCvmCall) Do(f func(*kit.Kit, *cvm.TCloudRebootOption) error) *TCloudRebootCvmCall {
+func (c *TCloudUpdateArgsTplServiceCall) Do(f func(*kit.Kit, *argstpl.TCloudUpdateServiceOption) (*poller.BaseDoneResult, error)) *TCloudUpdateArgsTplServiceCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudRebootCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudRebootOption) error) *TCloudRebootCvmCall {
+func (c *TCloudUpdateArgsTplServiceCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudUpdateServiceOption) (*poller.BaseDoneResult, error)) *TCloudUpdateArgsTplServiceCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ResetCvmPwd mocks base method.
-func (m *MockTCloud) ResetCvmPwd(kt *kit.Kit, opt *cvm.TCloudResetPwdOption) error {
+// UpdateArgsTplServiceGroup mocks base method.
+func (m *MockTCloud) UpdateArgsTplServiceGroup(kt *kit.Kit, opt *argstpl.TCloudUpdateServiceGroupOption) (*poller.BaseDoneResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResetCvmPwd", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateArgsTplServiceGroup", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ResetCvmPwd indicates an expected call of ResetCvmPwd.
-func (mr *MockTCloudMockRecorder) ResetCvmPwd(kt, opt interface{}) *TCloudResetCvmPwdCall {
+// UpdateArgsTplServiceGroup indicates an expected call of UpdateArgsTplServiceGroup.
+func (mr *MockTCloudMockRecorder) UpdateArgsTplServiceGroup(kt, opt interface{}) *TCloudUpdateArgsTplServiceGroupCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetCvmPwd", reflect.TypeOf((*MockTCloud)(nil).ResetCvmPwd), kt, opt)
-	return &TCloudResetCvmPwdCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArgsTplServiceGroup", reflect.TypeOf((*MockTCloud)(nil).UpdateArgsTplServiceGroup), kt, opt)
+	return &TCloudUpdateArgsTplServiceGroupCall{Call: call}
 }
 
-// TCloudResetCvmPwdCall wrap *gomock.Call
-type TCloudResetCvmPwdCall struct {
+// TCloudUpdateArgsTplServiceGroupCall wrap *gomock.Call
+type TCloudUpdateArgsTplServiceGroupCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudResetCvmPwdCall) Return(arg0 error) *TCloudResetCvmPwdCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudUpdateArgsTplServiceGroupCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudUpdateArgsTplServiceGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudResetCvmPwdCall) Do(f func(*kit.Kit, *cvm.TCloudResetPwdOption) error) *TCloudResetCvmPwdCall {
+func (c *TCloudUpdateArgsTplServiceGroupCall) Do(f func(*kit.Kit, *argstpl.TCloudUpdateServiceGroupOption) (*poller.BaseDoneResult, error)) *TCloudUpdateArgsTplServiceGroupCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudResetCvmPwdCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudResetPwdOption) error) *TCloudResetCvmPwdCall {
+func (c *TCloudUpdateArgsTplServiceGroupCall) DoAndReturn(f func(*kit.Kit, *argstpl.TCloudUpdateServiceGroupOption) (*poller.BaseDoneResult, error)) *TCloudUpdateArgsTplServiceGroupCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// SecurityGroupCvmAssociate mocks base method.
-func (m *MockTCloud) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.TCloudAssociateCvmOption) error {
+// UpdateDomainAttr mocks base method.
+func (m *MockTCloud) UpdateDomainAttr(kt *kit.Kit, opt *loadbalancer.TCloudUpdateDomainAttrOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SecurityGroupCvmAssociate", kt, opt)
+	ret := m.ctrl.Call(m, "UpdateDomainAttr", kt, opt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SecurityGroupCvmAssociate indicates an expected call of SecurityGroupCvmAssociate.
-func (mr *MockTCloudMockRecorder) SecurityGroupCvmAssociate(kt, opt interface{}) *TCloudSecurityGroupCvmAssociateCall {
+// UpdateDomainAttr indicates an expected call of UpdateDomainAttr.
+func (mr *MockTCloudMockRecorder) UpdateDomainAttr(kt, opt interface{}) *TCloudUpdateDomainAttrCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupCvmAssociate", reflect.TypeOf((*MockTCloud)(nil).SecurityGroupCvmAssociate), kt, opt)
-	return &TCloudSecurityGroupCvmAssociateCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomainAttr", reflect.TypeOf((*MockTCloud)(nil).UpdateDomainAttr), kt, opt)
+	return &TCloudUpdateDomainAttrCall{Call: call}
 }
 
-// TCloudSecurityGroupCvmAssociateCall wrap *gomock.Call
-type TCloudSecurityGroupCvmAssociateCall struct {
+// TCloudUpdateDomainAttrCall wrap *gomock.Call
+type TCloudUpdateDomainAttrCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudSecurityGroupCvmAssociateCall) Return(arg0 error) *TCloudSecurityGroupCvmAssociateCall {
+func (c *TCloudUpdateDomainAttrCall) Return(arg0 error) *TCloudUpdateDomainAttrCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudSecurityGroupCvmAssociateCall) Do(f func(*kit.Kit, *securitygroup.TCloudAssociateCvmOption) error) *TCloudSecurityGroupCvmAssociateCall {
+func (c *TCloudUpdateDomainAttrCall) Do(f func(*kit.Kit, *loadbalancer.TCloudUpdateDomainAttrOption) error) *TCloudUpdateDomainAttrCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudSecurityGroupCvmAssociateCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudAssociateCvmOption) error) *TCloudSecurityGroupCvmAssociateCall {
+func (c *TCloudUpdateDomainAttrCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudUpdateDomainAttrOption) error) *TCloudUpdateDomainAttrCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// SecurityGroupCvmDisassociate mocks base method.
-func (m *MockTCloud) SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.TCloudAssociateCvmOption) error {
+// UpdateListener mocks base method.
+func (m *MockTCloud) UpdateListener(kt *kit.Kit, opt *loadbalancer.TCloudUpdateListenerOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SecurityGroupCvmDisassociate", kt, opt)
+	ret := m.ctrl.Call(m, "UpdateListener", kt, opt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SecurityGroupCvmDisassociate indicates an expected call of SecurityGroupCvmDisassociate.
-func (mr *MockTCloudMockRecorder) SecurityGroupCvmDisassociate(kt, opt interface{}) *TCloudSecurityGroupCvmDisassociateCall {
+// UpdateListener indicates an expected call of UpdateListener.
+func (mr *MockTCloudMockRecorder) UpdateListener(kt, opt interface{}) *TCloudUpdateListenerCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupCvmDisassociate", reflect.TypeOf((*MockTCloud)(nil).SecurityGroupCvmDisassociate), kt, opt)
-	return &TCloudSecurityGroupCvmDisassociateCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateListener", reflect.TypeOf((*MockTCloud)(nil).UpdateListener), kt, opt)
+	return &TCloudUpdateListenerCall{Call: call}
 }
 
-// TCloudSecurityGroupCvmDisassociateCall wrap *gomock.Call
-type TCloudSecurityGroupCvmDisassociateCall struct {
+// TCloudUpdateListenerCall wrap *gomock.Call
+type TCloudUpdateListenerCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudSecurityGroupCvmDisassociateCall) Return(arg0 error) *TCloudSecurityGroupCvmDisassociateCall {
+func (c *TCloudUpdateListenerCall) Return(arg0 error) *TCloudUpdateListenerCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudSecurityGroupCvmDisassociateCall) Do(f func(*kit.Kit, *securitygroup.TCloudAssociateCvmOption) error) *TCloudSecurityGroupCvmDisassociateCall {
+func (c *TCloudUpdateListenerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudUpdateListenerOption) error) *TCloudUpdateListenerCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudSecurityGroupCvmDisassociateCall) DoAndReturn(f func(*kit.Kit, *securitygroup.TCloudAssociateCvmOption) error) *TCloudSecurityGroupCvmDisassociateCall {
+func (c *TCloudUpdateListenerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudUpdateListenerOption) error) *TCloudUpdateListenerCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// StartCvm mocks base method.
-func (m *MockTCloud) StartCvm(kt *kit.Kit, opt *cvm.TCloudStartOption) error {
+// UpdateLoadBalancer mocks base method.
+func (m *MockTCloud) UpdateLoadBalancer(kt *kit.Kit, opt *loadbalancer.TCloudUpdateOption) (*string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartCvm", kt, opt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateLoadBalancer", kt, opt)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// StartCvm indicates an expected call of StartCvm.
-func (mr *MockTCloudMockRecorder) StartCvm(kt, opt interface{}) *TCloudStartCvmCall {
+// UpdateLoadBalancer indicates an expected call of UpdateLoadBalancer.
+func (mr *MockTCloudMockRecorder) UpdateLoadBalancer(kt, opt interface{}) *TCloudUpdateLoadBalancerCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCvm", reflect.TypeOf((*MockTCloud)(nil).StartCvm), kt, opt)
-	return &TCloudStartCvmCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancer", reflect.TypeOf((*MockTCloud)(nil).UpdateLoadBalancer), kt, opt)
+	return &TCloudUpdateLoadBalancerCall{Call: call}
 }
 
-// TCloudStartCvmCall wrap *gomock.Call
-type TCloudStartCvmCall struct {
+// TCloudUpdateLoadBalancerCall wrap *gomock.Call
+type TCloudUpdateLoadBalancerCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudStartCvmCall) Return(arg0 error) *TCloudStartCvmCall {
-	c.Call = c.Call.Return(arg0)
+func (c *TCloudUpdateLoadBalancerCall) Return(arg0 *string, arg1 error) *TCloudUpdateLoadBalancerCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudStartCvmCall) Do(f func(*kit.Kit, *cvm.TCloudStartOption) error) *TCloudStartCvmCall {
+func (c *TCloudUpdateLoadBalancerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudUpdateOption) (*string, error)) *TCloudUpdateLoadBalancerCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudStartCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudStartOption) error) *TCloudStartCvmCall {
+func (c *TCloudUpdateLoadBalancerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudUpdateOption) (*string, error)) *TCloudUpdateLoadBalancerCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// StopCvm mocks base method.
-func (m *MockTCloud) StopCvm(kt *kit.Kit, opt *cvm.TCloudStopOption) error {
+// UpdateRouteTable mocks base method.
+func (m *MockTCloud) UpdateRouteTable(arg0 *kit.Kit, arg1 *routetable.TCloudRouteTableUpdateOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StopCvm", kt, opt)
+	ret := m.ctrl.Call(m, "UpdateRouteTable", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// StopCvm indicates an expected call of StopCvm.
-func (mr *MockTCloudMockRecorder) StopCvm(kt, opt interface{}) *TCloudStopCvmCall {
+// UpdateRouteTable indicates an expected call of UpdateRouteTable.
+func (mr *MockTCloudMockRecorder) UpdateRouteTable(arg0, arg1 interface{}) *TCloudUpdateRouteTableCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCvm", reflect.TypeOf((*MockTCloud)(nil).StopCvm), kt, opt)
-	return &TCloudStopCvmCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRouteTable", reflect.TypeOf((*MockTCloud)(nil).UpdateRouteTable), arg0, arg1)
+	return &TCloudUpdateRouteTableCall{Call: call}
 }
 
-// TCloudStopCvmCall wrap *gomock.Call
-type TCloudStopCvmCall struct {
+// TCloudUpdateRouteTableCall wrap *gomock.Call
+type TCloudUpdateRouteTableCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudStopCvmCall) Return(arg0 error) *TCloudStopCvmCall {
+func (c *TCloudUpdateRouteTableCall) Return(arg0 error) *TCloudUpdateRouteTableCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudStopCvmCall) Do(f func(*kit.Kit, *cvm.TCloudStopOption) error) *TCloudStopCvmCall {
+func (c *TCloudUpdateRouteTableCall) Do(f func(*kit.Kit, *routetable.TCloudRouteTableUpdateOption) error) *TCloudUpdateRouteTableCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudStopCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.TCloudStopOption) error) *TCloudStopCvmCall {
+func (c *TCloudUpdateRouteTableCall) DoAndReturn(f func(*kit.Kit, *routetable.TCloudRouteTableUpdateOption) error) *TCloudUpdateRouteTableCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// UpdateRouteTable mocks base method.
-func (m *MockTCloud) UpdateRouteTable(arg0 *kit.Kit, arg1 *routetable.TCloudRouteTableUpdateOption) error {
+// UpdateRule mocks base method.
+func (m *MockTCloud) UpdateRule(kt *kit.Kit, opt *loadbalancer.TCloudUpdateRuleOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRouteTable", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateRule", kt, opt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateRouteTable indicates an expected call of UpdateRouteTable.
-func (mr *MockTCloudMockRecorder) UpdateRouteTable(arg0, arg1 interface{}) *TCloudUpdateRouteTableCall {
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockTCloudMockRecorder) UpdateRule(kt, opt interface{}) *TCloudUpdateRuleCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRouteTable", reflect.TypeOf((*MockTCloud)(nil).UpdateRouteTable), arg0, arg1)
-	return &TCloudUpdateRouteTableCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockTCloud)(nil).UpdateRule), kt, opt)
+	return &TCloudUpdateRuleCall{Call: call}
 }
 
-// TCloudUpdateRouteTableCall wrap *gomock.Call
-type TCloudUpdateRouteTableCall struct {
+// TCloudUpdateRuleCall wrap *gomock.Call
+type TCloudUpdateRuleCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *TCloudUpdateRouteTableCall) Return(arg0 error) *TCloudUpdateRouteTableCall {
+func (c *TCloudUpdateRuleCall) Return(arg0 error) *TCloudUpdateRuleCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *TCloudUpdateRouteTableCall) Do(f func(*kit.Kit, *routetable.TCloudRouteTableUpdateOption) error) *TCloudUpdateRouteTableCall {
+func (c *TCloudUpdateRuleCall) Do(f func(*kit.Kit, *loadbalancer.TCloudUpdateRuleOption) error) *TCloudUpdateRuleCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *TCloudUpdateRouteTableCall) DoAndReturn(f func(*kit.Kit, *routetable.TCloudRouteTableUpdateOption) error) *TCloudUpdateRouteTableCall {
+func (c *TCloudUpdateRuleCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudUpdateRuleOption) error) *TCloudUpdateRuleCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
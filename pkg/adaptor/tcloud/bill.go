@@ -57,6 +57,12 @@ func (t *TCloudImpl) GetBillList(kt *kit.Kit, opt *typesBill.TCloudBillListOptio
 	if opt.EndDate != "" {
 		req.EndTime = proto.String(opt.EndDate)
 	}
+	if opt.Context != nil {
+		req.Context = opt.Context
+	}
+	if opt.PayerUin != "" {
+		req.PayerUin = proto.String(opt.PayerUin)
+	}
 	// 是否需要访问列表的总记录数，用于前端分页(1-表示需要 0-表示不需要)
 	req.NeedRecordNum = proto.Int64(1)
 
@@ -53,6 +53,7 @@ type ClientSet interface {
 	BillClient() (*billing.Client, error)
 	ClbClient(region string) (*clb.Client, error)
 	CertClient() (*ssl.Client, error)
+	CommonClient(region string) (*common.Client, error)
 }
 
 // clientSet to get tcloud sdk client set
@@ -63,7 +64,7 @@ type clientSet struct {
 
 func newClientSet(s *types.BaseSecret, profile *profile.ClientProfile) ClientSet {
 	return &clientSet{
-		credential: common.NewCredential(s.CloudSecretID, s.CloudSecretKey),
+		credential: common.NewTokenCredential(s.CloudSecretID, s.CloudSecretKey, s.CloudSessionToken),
 		profile:    profile,
 	}
 }
@@ -156,3 +157,11 @@ func (c *clientSet) CertClient() (*ssl.Client, error) {
 
 	return client, nil
 }
+
+// CommonClient tcloud common client, used to call apis without sdk package, such as cloudaudit
+func (c *clientSet) CommonClient(region string) (*common.Client, error) {
+	client := common.NewCommonClient(c.credential, region, c.profile)
+	client.WithHttpTransport(metric.GetTCloudRecordRoundTripper(nil))
+
+	return client, nil
+}
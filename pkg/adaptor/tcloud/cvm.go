@@ -371,9 +371,7 @@ func (t *TCloudImpl) CreateCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOption) (*p
 		VpcId:    common.StringPtr(opt.CloudVpcID),
 		SubnetId: common.StringPtr(opt.CloudSubnetID),
 	}
-	req.LoginSettings = &cvm.LoginSettings{
-		Password: common.StringPtr(opt.Password),
-	}
+	req.LoginSettings = genLoginSettings(opt.Password, opt.CloudKeyPairID)
 	req.InternetAccessible = &cvm.InternetAccessible{
 		InternetMaxBandwidthOut: common.Int64Ptr(opt.InternetMaxBandwidthOut),
 		PublicIpAssigned:        common.BoolPtr(opt.PublicIPAssigned),
@@ -501,9 +499,7 @@ func (t *TCloudImpl) InquiryPriceCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOptio
 		VpcId:    common.StringPtr(opt.CloudVpcID),
 		SubnetId: common.StringPtr(opt.CloudSubnetID),
 	}
-	req.LoginSettings = &cvm.LoginSettings{
-		Password: common.StringPtr(opt.Password),
-	}
+	req.LoginSettings = genLoginSettings(opt.Password, opt.CloudKeyPairID)
 	req.InternetAccessible = &cvm.InternetAccessible{
 		PublicIpAssigned:        common.BoolPtr(opt.PublicIPAssigned),
 		InternetMaxBandwidthOut: common.Int64Ptr(opt.InternetMaxBandwidthOut),
@@ -845,3 +841,12 @@ func (h *resetCvmInstancePollingHandler) Done(cvms []*cvm.Instance) (bool, *poll
 
 	return flag, result
 }
+
+// genLoginSettings 生成实例登录设置，密码与SSH密钥对二选一
+func genLoginSettings(password, cloudKeyPairID string) *cvm.LoginSettings {
+	if len(cloudKeyPairID) != 0 {
+		return &cvm.LoginSettings{KeyIds: common.StringPtrs([]string{cloudKeyPairID})}
+	}
+
+	return &cvm.LoginSettings{Password: common.StringPtr(password)}
+}
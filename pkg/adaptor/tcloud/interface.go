@@ -31,11 +31,15 @@ import (
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/adaptor/types/disk-snapshot"
 	"hcm/pkg/adaptor/types/eip"
 	"hcm/pkg/adaptor/types/image"
 	"hcm/pkg/adaptor/types/instance-type"
+	"hcm/pkg/adaptor/types/key-pair"
 	typelb "hcm/pkg/adaptor/types/load-balancer"
 	"hcm/pkg/adaptor/types/region"
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	resourcetag "hcm/pkg/adaptor/types/resource-tag"
 	"hcm/pkg/adaptor/types/route-table"
 	"hcm/pkg/adaptor/types/security-group"
 	"hcm/pkg/adaptor/types/security-group-rule"
@@ -83,6 +87,7 @@ type TCloud interface {
 	GetAccountZoneQuota(kt *kit.Kit, opt *account.GetTCloudAccountZoneQuotaOption) (
 		*account.TCloudAccountQuota, error)
 	GetAccountInfoBySecret(kt *kit.Kit) (*cloud.TCloudInfoBySecret, error)
+	AssumeRole(kt *kit.Kit, opt *account.AssumeRoleOption) (*account.RoleCredential, error)
 	CreateDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (*poller.BaseDoneResult, error)
 	InquiryPriceDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (
 		*cvm.InquiryPriceResult, error)
@@ -91,6 +96,16 @@ type TCloud interface {
 	DeleteDisk(kt *kit.Kit, opt *disk.TCloudDiskDeleteOption) error
 	AttachDisk(kt *kit.Kit, opt *disk.TCloudDiskAttachOption) error
 	DetachDisk(kt *kit.Kit, opt *disk.TCloudDiskDetachOption) error
+	ListDiskSnapshot(kt *kit.Kit, opt *core.TCloudListOption) ([]disksnapshot.TCloudSnapshot, error)
+	CreateDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudSnapshotCreateOption) (string, error)
+	DeleteDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudSnapshotDeleteOption) error
+	RollbackDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudSnapshotRollbackOption) error
+	ListKeyPair(kt *kit.Kit, opt *core.TCloudListOption) ([]keypair.TCloudKeyPair, error)
+	CreateKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairCreateOption) (*keypair.CreateResult, error)
+	ImportKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairImportOption) (string, error)
+	DeleteKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairDeleteOption) error
+	AssociateKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairAssociateOption) error
+	DisassociateKeyPair(kt *kit.Kit, opt *keypair.TCloudKeyPairAssociateOption) error
 	ListEip(kt *kit.Kit, opt *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error)
 	CountEip(kt *kit.Kit, region string) (int32, error)
 	DeleteEip(kt *kit.Kit, opt *eip.TCloudEipDeleteOption) error
@@ -197,4 +212,9 @@ type TCloud interface {
 	ResetCvmInstance(kt *kit.Kit, opt *cvm.ResetInstanceOption) (*poller.BaseDoneResult, error)
 
 	BatchCvmAssociateSecurityGroups(kt *kit.Kit, opt *cvm.TCloudAssociateSecurityGroupsOption) error
+
+	ListResourceChangeEvent(kt *kit.Kit, opt *resourceevent.ListOption) ([]resourceevent.ChangeEvent, error)
+
+	TagResources(kt *kit.Kit, opt *resourcetag.TagResOption) error
+	UnTagResources(kt *kit.Kit, opt *resourcetag.UnTagResOption) error
 }
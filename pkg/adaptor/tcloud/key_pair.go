@@ -0,0 +1,213 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// ListKeyPair 查询SSH密钥对列表
+func (t *TCloudImpl) ListKeyPair(kt *kit.Kit, opt *core.TCloudListOption) ([]typekeypair.TCloudKeyPair, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "tcloud key pair list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewDescribeKeyPairsRequest()
+	if len(opt.CloudIDs) != 0 {
+		req.KeyIds = common.StringPtrs(opt.CloudIDs)
+		req.Limit = common.Int64Ptr(int64(core.TCloudQueryLimit))
+	}
+	if opt.Page != nil {
+		req.Offset = common.Int64Ptr(int64(opt.Page.Offset))
+		req.Limit = common.Int64Ptr(int64(opt.Page.Limit))
+	}
+
+	resp, err := client.DescribeKeyPairsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tcloud key pair failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	keyPairs := make([]typekeypair.TCloudKeyPair, 0, len(resp.Response.KeyPairSet))
+	for _, one := range resp.Response.KeyPairSet {
+		keyPairs = append(keyPairs, typekeypair.TCloudKeyPair{KeyPair: one})
+	}
+	return keyPairs, nil
+}
+
+// CreateKeyPair 创建SSH密钥对，私钥仅在创建时返回
+func (t *TCloudImpl) CreateKeyPair(kt *kit.Kit, opt *typekeypair.TCloudKeyPairCreateOption) (
+	*typekeypair.CreateResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "tcloud key pair create option is required")
+	}
+
+	req, err := opt.ToCreateKeyPairRequest()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	resp, err := client.CreateKeyPairWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create tcloud key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return nil, err
+	}
+
+	if resp.Response.KeyPair == nil {
+		return nil, fmt.Errorf("create tcloud key pair %s succeeded but key pair is not returned", opt.Name)
+	}
+
+	return &typekeypair.CreateResult{
+		CloudID:    converter.PtrToVal(resp.Response.KeyPair.KeyId),
+		Name:       converter.PtrToVal(resp.Response.KeyPair.KeyName),
+		PrivateKey: converter.PtrToVal(resp.Response.KeyPair.PrivateKey),
+	}, nil
+}
+
+// ImportKeyPair 导入已有的SSH公钥，返回密钥对云上ID
+func (t *TCloudImpl) ImportKeyPair(kt *kit.Kit, opt *typekeypair.TCloudKeyPairImportOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "tcloud key pair import option is required")
+	}
+
+	req, err := opt.ToImportKeyPairRequest()
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	resp, err := client.ImportKeyPairWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("import tcloud key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.Response.KeyId), nil
+}
+
+// DeleteKeyPair 删除SSH密钥对，已绑定实例的密钥对不允许删除
+func (t *TCloudImpl) DeleteKeyPair(kt *kit.Kit, opt *typekeypair.TCloudKeyPairDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tcloud key pair delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewDeleteKeyPairsRequest()
+	req.KeyIds = common.StringPtrs(opt.CloudIDs)
+	if _, err = client.DeleteKeyPairsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud key pair failed, err: %v, ids: %v, rid: %s", err, opt.CloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// AssociateKeyPair 实例绑定SSH密钥对
+func (t *TCloudImpl) AssociateKeyPair(kt *kit.Kit, opt *typekeypair.TCloudKeyPairAssociateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tcloud key pair associate option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewAssociateInstancesKeyPairsRequest()
+	req.KeyIds = common.StringPtrs(opt.CloudIDs)
+	req.InstanceIds = common.StringPtrs(opt.CloudInstanceIDs)
+	req.ForceStop = opt.ForceStop
+	if _, err = client.AssociateInstancesKeyPairsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("associate tcloud key pair failed, err: %v, ids: %v, instances: %v, rid: %s", err,
+			opt.CloudIDs, opt.CloudInstanceIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DisassociateKeyPair 实例解绑SSH密钥对
+func (t *TCloudImpl) DisassociateKeyPair(kt *kit.Kit, opt *typekeypair.TCloudKeyPairAssociateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tcloud key pair disassociate option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewDisassociateInstancesKeyPairsRequest()
+	req.KeyIds = common.StringPtrs(opt.CloudIDs)
+	req.InstanceIds = common.StringPtrs(opt.CloudInstanceIDs)
+	req.ForceStop = opt.ForceStop
+	if _, err = client.DisassociateInstancesKeyPairsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("disassociate tcloud key pair failed, err: %v, ids: %v, instances: %v, rid: %s", err,
+			opt.CloudIDs, opt.CloudInstanceIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	resourceevent "hcm/pkg/adaptor/types/resource-event"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	cloudAuditService  = "cloudaudit"
+	cloudAuditVersion  = "2019-03-19"
+	lookUpEventsAction = "LookUpEvents"
+	lookUpEventsLimit  = 50
+)
+
+// ListResourceChangeEvent 通过操作审计查询时间区间内的写操作事件，转换为资源变更事件
+// reference: https://cloud.tencent.com/document/api/629/45172
+func (t *TCloudImpl) ListResourceChangeEvent(kt *kit.Kit, opt *resourceevent.ListOption) (
+	[]resourceevent.ChangeEvent, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CommonClient(constant.TCloudDefaultRegion)
+	if err != nil {
+		return nil, fmt.Errorf("init tencent cloud common client failed, err: %v", err)
+	}
+
+	params := map[string]interface{}{
+		"StartTime":  opt.StartTime.Unix(),
+		"EndTime":    opt.EndTime.Unix(),
+		"MaxResults": lookUpEventsLimit,
+		"LookupAttributes": []map[string]string{
+			{"AttributeKey": "ReadOnly", "AttributeValue": "false"},
+		},
+	}
+
+	events := make([]resourceevent.ChangeEvent, 0)
+	for {
+		req := tchttp.NewCommonRequest(cloudAuditService, cloudAuditVersion, lookUpEventsAction)
+		req.SetContext(kt.Ctx)
+		if err = req.SetActionParameters(params); err != nil {
+			return nil, err
+		}
+
+		resp := tchttp.NewCommonResponse()
+		if err = client.Send(req, resp); err != nil {
+			logs.Errorf("look up tcloud audit events failed, err: %v, params: %v, rid: %s", err, params, kt.Rid)
+			return nil, err
+		}
+
+		result := new(resourceevent.TCloudLookUpEventsResp)
+		if err = json.Unmarshal(resp.GetBody(), result); err != nil {
+			return nil, fmt.Errorf("unmarshal tcloud audit events failed, err: %v", err)
+		}
+
+		for _, one := range result.Response.Events {
+			events = append(events, convTCloudEvent(one)...)
+		}
+
+		if result.Response.ListOver || result.Response.NextToken == nil || len(result.Response.Events) == 0 {
+			break
+		}
+		params["NextToken"] = *result.Response.NextToken
+	}
+
+	return events, nil
+}
+
+// convTCloudEvent 转换审计事件，失败的操作以及不支持增量同步的资源会被忽略
+func convTCloudEvent(event resourceevent.TCloudEvent) []resourceevent.ChangeEvent {
+	if event.ErrorCode != 0 || len(event.Resources.ResourceName) == 0 {
+		return nil
+	}
+
+	var eventTime time.Time
+	if sec, err := strconv.ParseInt(event.EventTime, 10, 64); err == nil {
+		eventTime = time.Unix(sec, 0)
+	}
+
+	result := make([]resourceevent.ChangeEvent, 0)
+	for _, cloudID := range strings.Split(event.Resources.ResourceName, ",") {
+		cloudID = strings.TrimSpace(cloudID)
+		resType, ok := resourceevent.TCloudResType(cloudID)
+		if !ok {
+			continue
+		}
+
+		result = append(result, resourceevent.ChangeEvent{
+			ResType:   resType,
+			CloudID:   cloudID,
+			Region:    event.EventRegion,
+			EventName: event.EventName,
+			EventTime: eventTime,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,171 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	resourcetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+const (
+	tagService           = "tag"
+	tagVersion           = "2018-08-13"
+	tagResourcesAction   = "TagResources"
+	unTagResourcesAction = "UnTagResources"
+)
+
+// tagResPrefix 资源类型对应的六段式资源描述中的服务类型和资源前缀
+// reference: https://cloud.tencent.com/document/product/651/89122
+var tagResPrefix = map[enumor.CloudResourceType][2]string{
+	enumor.CvmCloudResType:          {"cvm", "instance"},
+	enumor.DiskCloudResType:         {"cvm", "volume"},
+	enumor.VpcCloudResType:          {"vpc", "vpc"},
+	enumor.SubnetCloudResType:       {"vpc", "subnet"},
+	enumor.EipCloudResType:          {"cvm", "eip"},
+	enumor.LoadBalancerCloudResType: {"clb", "clb"},
+}
+
+// TagResources 为资源添加标签，标签键已存在时更新标签值
+// reference: https://cloud.tencent.com/document/api/651/72283
+func (t *TCloudImpl) TagResources(kt *kit.Kit, opt *resourcetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resList, err := t.genTagResNames(kt, opt.ResType, opt.Region, opt.Resources)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]map[string]string, 0, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags = append(tags, map[string]string{"TagKey": one.Key, "TagValue": one.Value})
+	}
+	params := map[string]interface{}{
+		"ResourceList": resList,
+		"Tags":         tags,
+	}
+
+	return t.sendTagRequest(kt, tagResourcesAction, params)
+}
+
+// UnTagResources 删除资源的标签
+// reference: https://cloud.tencent.com/document/api/651/72281
+func (t *TCloudImpl) UnTagResources(kt *kit.Kit, opt *resourcetag.UnTagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resList, err := t.genTagResNames(kt, opt.ResType, opt.Region, opt.Resources)
+	if err != nil {
+		return err
+	}
+
+	params := map[string]interface{}{
+		"ResourceList": resList,
+		"TagKeys":      opt.TagKeys,
+	}
+
+	return t.sendTagRequest(kt, unTagResourcesAction, params)
+}
+
+// genTagResNames 生成六段式资源描述，qcs::${ServiceType}:${Region}:uin/${Account}:${ResourcePrefix}/${ResourceId}
+func (t *TCloudImpl) genTagResNames(kt *kit.Kit, resType enumor.CloudResourceType, region string,
+	resources []resourcetag.TagResource) ([]string, error) {
+
+	prefix, exist := tagResPrefix[resType]
+	if !exist {
+		return nil, errf.Newf(errf.InvalidParameter, "tcloud %s does not support tag", resType)
+	}
+
+	if len(region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	info, err := t.GetAccountInfoBySecret(kt)
+	if err != nil {
+		logs.Errorf("get tcloud account info failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	names := make([]string, 0, len(resources))
+	for _, one := range resources {
+		names = append(names, fmt.Sprintf("qcs::%s:%s:uin/%s:%s/%s", prefix[0], region, info.CloudMainAccountID,
+			prefix[1], one.CloudID))
+	}
+
+	return names, nil
+}
+
+func (t *TCloudImpl) sendTagRequest(kt *kit.Kit, action string, params map[string]interface{}) error {
+	client, err := t.clientSet.CommonClient(constant.TCloudDefaultRegion)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud common client failed, err: %v", err)
+	}
+
+	req := tchttp.NewCommonRequest(tagService, tagVersion, action)
+	req.SetContext(kt.Ctx)
+	if err = req.SetActionParameters(params); err != nil {
+		return err
+	}
+
+	resp := tchttp.NewCommonResponse()
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("%s tcloud resource failed, err: %v, params: %v, rid: %s", action, err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// convVpcTags 转换vpc服务资源的标签，vpc、子网、弹性公网IP共用
+func convVpcTags(tags []*vpc.Tag) core.TagMap {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagMap := make(core.TagMap, len(tags))
+	for _, one := range tags {
+		if one == nil {
+			continue
+		}
+		tagMap.Set(converter.PtrToVal(one.Key), converter.PtrToVal(one.Value))
+	}
+	return tagMap
+}
@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	stsService       = "sts"
+	stsVersion       = "2018-08-13"
+	assumeRoleAction = "AssumeRole"
+)
+
+// AssumeRole 使用当前身份扮演指定角色，获取临时凭证
+// reference: https://cloud.tencent.com/document/api/1312/48197
+func (t *TCloudImpl) AssumeRole(kt *kit.Kit, opt *typeaccount.AssumeRoleOption) (*typeaccount.RoleCredential,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "assume role option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CommonClient(constant.TCloudDefaultRegion)
+	if err != nil {
+		return nil, fmt.Errorf("init tencent cloud common client failed, err: %v", err)
+	}
+
+	params := map[string]interface{}{
+		"RoleArn":         opt.RoleArn,
+		"RoleSessionName": opt.SessionName,
+		"DurationSeconds": int64(opt.Duration.Seconds()),
+	}
+	req := tchttp.NewCommonRequest(stsService, stsVersion, assumeRoleAction)
+	req.SetContext(kt.Ctx)
+	if err = req.SetActionParameters(params); err != nil {
+		return nil, err
+	}
+
+	resp := tchttp.NewCommonResponse()
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("tcloud assume role failed, err: %v, role: %s, rid: %s", err, opt.RoleArn, kt.Rid)
+		return nil, err
+	}
+
+	result := new(typeaccount.TCloudAssumeRoleResp)
+	if err = json.Unmarshal(resp.GetBody(), result); err != nil {
+		return nil, fmt.Errorf("unmarshal tcloud assume role response failed, err: %v", err)
+	}
+
+	return &typeaccount.RoleCredential{
+		SecretID:     result.Response.Credentials.TmpSecretId,
+		SecretKey:    result.Response.Credentials.TmpSecretKey,
+		SessionToken: result.Response.Credentials.Token,
+		ExpireAt:     time.Unix(result.Response.ExpiredTime, 0),
+	}, nil
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"time"
+
+	"hcm/pkg/criteria/validator"
+)
+
+// AssumeRoleOption 扮演角色获取临时凭证参数
+type AssumeRoleOption struct {
+	RoleArn     string `validate:"required"`
+	SessionName string `validate:"required"`
+	// ExternalID 角色信任策略中要求的外部ID，仅aws支持
+	ExternalID string        `validate:"omitempty"`
+	Duration   time.Duration `validate:"required"`
+}
+
+// Validate AssumeRoleOption
+func (opt AssumeRoleOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// RoleCredential 扮演角色获取的临时凭证
+type RoleCredential struct {
+	SecretID     string    `json:"secret_id"`
+	SecretKey    string    `json:"secret_key"`
+	SessionToken string    `json:"session_token"`
+	ExpireAt     time.Time `json:"expire_at"`
+}
+
+// TCloudAssumeRoleResp 腾讯云 sts:AssumeRole 返回结果
+type TCloudAssumeRoleResp struct {
+	Response struct {
+		Credentials struct {
+			Token        string `json:"Token"`
+			TmpSecretId  string `json:"TmpSecretId"`
+			TmpSecretKey string `json:"TmpSecretKey"`
+		} `json:"Credentials"`
+		ExpiredTime int64  `json:"ExpiredTime"`
+		RequestId   string `json:"RequestId"`
+	} `json:"Response"`
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package argstpl
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3/model"
+)
+
+// -------------------------- List --------------------------
+
+// HuaWeiListOption defines options to list huawei ip address group instances.
+type HuaWeiListOption struct {
+	Region   string           `json:"region" validate:"required"`
+	CloudIDs []string         `json:"cloud_ids" validate:"omitempty,max=100"`
+	Page     *core.HuaWeiPage `json:"page" validate:"omitempty"`
+}
+
+// Validate huawei ip address group list option.
+func (opt HuaWeiListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HuaWeiListResult defines huawei list ip address group result.
+type HuaWeiListResult struct {
+	NextMarker *string                `json:"next_marker,omitempty"`
+	Details    []HuaWeiArgsTplAddress `json:"details"`
+}
+
+// HuaWeiArgsTplAddress huawei ip address group, which is regional resource.
+type HuaWeiArgsTplAddress struct {
+	Region string `json:"region"`
+	model.AddressGroup
+}
+
+// GetCloudID ...
+func (group HuaWeiArgsTplAddress) GetCloudID() string {
+	return group.Id
+}
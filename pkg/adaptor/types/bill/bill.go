@@ -277,6 +277,8 @@ type TCloudBillListOption struct {
 	// 本次请求的上下文信息，可用于下一次请求的请求参数中，加快查询速度
 	// 注意：此字段可能返回 null，表示取不到有效值。
 	Context *string `json:"Context" validate:"omitempty"`
+	// 支付者的账号 ID，默认查询本账号账单，集团管理账号查询成员账号自付的账单时需传入成员账号UIN
+	PayerUin string `json:"payer_uin" validate:"omitempty"`
 }
 
 // Validate tcloud bill list option.
@@ -0,0 +1,83 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cert
+
+import (
+	"strings"
+
+	"hcm/pkg/criteria/validator"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/scm/v3/model"
+)
+
+// HuaWeiCertQueryLimit scm 查询证书列表单页最大数量
+const HuaWeiCertQueryLimit = 50
+
+// -------------------------- List --------------------------
+
+// HuaWeiListOption defines options to list huawei scm cert instances.
+type HuaWeiListOption struct {
+	Offset int32 `json:"offset" validate:"min=0"`
+	Limit  int32 `json:"limit" validate:"min=1,max=50"`
+}
+
+// Validate huawei cert list option.
+func (opt HuaWeiListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiListResult defines huawei list cert result.
+type HuaWeiListResult struct {
+	TotalCount int32        `json:"total_count"`
+	Details    []HuaWeiCert `json:"details"`
+}
+
+// HuaWeiCert huawei scm cert.
+type HuaWeiCert struct {
+	model.CertificateDetail
+}
+
+// GetCloudID ...
+func (cert HuaWeiCert) GetCloudID() string {
+	return cert.Id
+}
+
+// GetDomains 返回证书绑定的主域名及附加域名
+func (cert HuaWeiCert) GetDomains() []*string {
+	domains := make([]*string, 0)
+	exists := make(map[string]struct{})
+	for _, one := range append([]string{cert.Domain}, strings.FieldsFunc(cert.Sans, isDomainSep)...) {
+		one = strings.TrimSpace(one)
+		if len(one) == 0 {
+			continue
+		}
+		if _, ok := exists[one]; ok {
+			continue
+		}
+		exists[one] = struct{}{}
+		domain := one
+		domains = append(domains, &domain)
+	}
+	return domains
+}
+
+func isDomainSep(r rune) bool {
+	return r == ';' || r == ','
+}
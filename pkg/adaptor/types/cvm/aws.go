@@ -20,7 +20,10 @@
 package cvm
 
 import (
+	"errors"
+
 	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -128,7 +131,8 @@ type AwsCreateOption struct {
 	Zone                  string                  `json:"zone" validate:"required"`
 	InstanceType          string                  `json:"instance_type" validate:"required"`
 	CloudImageID          string                  `json:"cloud_image_id" validate:"required"`
-	Password              string                  `json:"password" validate:"required"`
+	Password              string                  `json:"password" validate:"omitempty"`
+	KeyName               string                  `json:"key_name" validate:"omitempty"`
 	RequiredCount         int64                   `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string                `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string                 `json:"client_token" validate:"omitempty"`
@@ -174,6 +178,10 @@ const (
 
 // Validate aws cvm operation option.
 func (opt AwsCreateOption) Validate() error {
+	if len(opt.Password) == 0 && len(opt.KeyName) == 0 {
+		return errors.New("password or key_name is required")
+	}
+
 	return validator.Validate.Struct(opt)
 }
 
@@ -187,6 +195,22 @@ func (cvm AwsCvm) GetCloudID() string {
 	return converter.PtrToVal(cvm.InstanceId)
 }
 
+// GetTagMap ...
+func (cvm AwsCvm) GetTagMap() apicore.TagMap {
+	if len(cvm.Tags) == 0 {
+		return nil
+	}
+
+	tagMap := make(apicore.TagMap, len(cvm.Tags))
+	for _, tag := range cvm.Tags {
+		if tag == nil {
+			continue
+		}
+		tagMap.Set(converter.PtrToVal(tag.Key), converter.PtrToVal(tag.Value))
+	}
+	return tagMap
+}
+
 // AwsAssociateSecurityGroupsOption defines options to associate security groups to cvm instance.
 type AwsAssociateSecurityGroupsOption struct {
 	Region                string   `json:"region" validate:"required"`
@@ -20,8 +20,10 @@
 package cvm
 
 import (
+	"errors"
 	"time"
 
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -112,7 +114,8 @@ type AzureCreateOption struct {
 	InstanceType         string          `json:"instance_type" validate:"required"`
 	Image                *AzureImage     `json:"image" validate:"required"`
 	Username             string          `json:"username" validate:"required"`
-	Password             string          `json:"password" validate:"required"`
+	Password             string          `json:"password" validate:"omitempty"`
+	SSHPublicKey         string          `json:"ssh_public_key" validate:"omitempty"`
 	CloudSubnetID        string          `json:"cloud_subnet_id" validate:"required"`
 	CloudSecurityGroupID string          `json:"cloud_security_group_id" validate:"required"`
 	OSDisk               *AzureOSDisk    `json:"os_disk" validate:"required"`
@@ -130,6 +133,10 @@ type AzureImage struct {
 
 // Validate azure cvm operation option.
 func (opt AzureCreateOption) Validate() error {
+	if len(opt.Password) == 0 && len(opt.SSHPublicKey) == 0 {
+		return errors.New("password or ssh_public_key is required")
+	}
+
 	return validator.Validate.Struct(opt)
 }
 
@@ -204,9 +211,15 @@ type AzureCvm struct {
 	VCPUsPerCore        *int32                                        `json:"vcpus_per_core"`
 	TimeCreated         *time.Time                                    `json:"time_created"`
 	StorageProfile      *armcompute.StorageProfile                    `json:"storage_profile"`
+	Tags                apicore.TagMap                                `json:"tags"`
 }
 
 // GetCloudID ...
 func (cvm AzureCvm) GetCloudID() string {
 	return converter.PtrToVal(cvm.ID)
 }
+
+// GetTagMap ...
+func (cvm AzureCvm) GetTagMap() apicore.TagMap {
+	return cvm.Tags
+}
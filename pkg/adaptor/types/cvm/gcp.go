@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 
 	"google.golang.org/api/compute/v1"
@@ -209,3 +210,12 @@ type GcpCvm struct {
 func (cvm GcpCvm) GetCloudID() string {
 	return fmt.Sprint(cvm.Id)
 }
+
+// GetTagMap ...
+func (cvm GcpCvm) GetTagMap() apicore.TagMap {
+	if len(cvm.Labels) == 0 {
+		return nil
+	}
+
+	return apicore.TagMap(cvm.Labels)
+}
@@ -21,8 +21,10 @@ package cvm
 
 import (
 	"fmt"
+	"strings"
 
 	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
@@ -132,7 +134,8 @@ type HuaWeiCreateOption struct {
 	Zone                  string                `json:"zone" validate:"required"`
 	InstanceType          string                `json:"instance_type" validate:"required"`
 	CloudImageID          string                `json:"cloud_image_id" validate:"required"`
-	Password              string                `json:"password" validate:"required"`
+	Password              string                `json:"password" validate:"omitempty"`
+	KeyName               string                `json:"key_name" validate:"omitempty"`
 	RequiredCount         int32                 `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string              `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string               `json:"client_token" validate:"omitempty"`
@@ -148,6 +151,10 @@ type HuaWeiCreateOption struct {
 
 // Validate aws cvm operation option.
 func (opt HuaWeiCreateOption) Validate() error {
+	if len(opt.Password) == 0 && len(opt.KeyName) == 0 {
+		return fmt.Errorf("password or key_name is required")
+	}
+
 	if opt.PublicIPAssigned {
 		if err := opt.Eip.Validate(); err != nil {
 			return err
@@ -320,3 +327,18 @@ type HuaWeiCvm struct {
 func (cvm HuaWeiCvm) GetCloudID() string {
 	return cvm.Id
 }
+
+// GetTagMap ...
+func (cvm HuaWeiCvm) GetTagMap() apicore.TagMap {
+	if cvm.Tags == nil || len(*cvm.Tags) == 0 {
+		return nil
+	}
+
+	// 华为云主机标签格式为 key=value
+	tagMap := make(apicore.TagMap, len(*cvm.Tags))
+	for _, tag := range *cvm.Tags {
+		key, value, _ := strings.Cut(tag, "=")
+		tagMap.Set(key, value)
+	}
+	return tagMap
+}
@@ -23,6 +23,7 @@ import (
 	"errors"
 
 	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -185,7 +186,8 @@ type TCloudCreateOption struct {
 	Zone                    string                       `json:"zone" validate:"required"`
 	InstanceType            string                       `json:"instance_type" validate:"required"`
 	CloudImageID            string                       `json:"cloud_image_id" validate:"required"`
-	Password                string                       `json:"password" validate:"required"`
+	Password                string                       `json:"password" validate:"omitempty"`
+	CloudKeyPairID          string                       `json:"cloud_key_pair_id" validate:"omitempty"`
 	RequiredCount           int64                        `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs   []string                     `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken             *string                      `json:"client_token" validate:"omitempty"`
@@ -207,6 +209,10 @@ func (opt TCloudCreateOption) Validate() error {
 		return err
 	}
 
+	if len(opt.Password) == 0 && len(opt.CloudKeyPairID) == 0 {
+		return errors.New("password or cloud_key_pair_id is required")
+	}
+
 	if opt.PublicIPAssigned && opt.InternetMaxBandwidthOut == 0 {
 		return errors.New("assign public ip, internet_max_bandwidth_out is required")
 	}
@@ -316,6 +322,22 @@ func (cvm TCloudCvm) GetCloudID() string {
 	return converter.PtrToVal(cvm.InstanceId)
 }
 
+// GetTagMap ...
+func (cvm TCloudCvm) GetTagMap() apicore.TagMap {
+	if len(cvm.Tags) == 0 {
+		return nil
+	}
+
+	tagMap := make(apicore.TagMap, len(cvm.Tags))
+	for _, tag := range cvm.Tags {
+		if tag == nil {
+			continue
+		}
+		tagMap.Set(converter.PtrToVal(tag.Key), converter.PtrToVal(tag.Value))
+	}
+	return tagMap
+}
+
 // InquiryPriceResult define tcloud inquiry price result.
 type InquiryPriceResult struct {
 	DiscountPrice float64 `json:"discount_price"`
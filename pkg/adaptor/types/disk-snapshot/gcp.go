@@ -0,0 +1,162 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
+	"hcm/pkg/criteria/validator"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// GcpSnapshotListOption define gcp snapshot list option. gcp 快照为全局资源，不区分地域
+type GcpSnapshotListOption struct {
+	CloudIDs []string      `json:"cloud_ids" validate:"omitempty"`
+	Names    []string      `json:"names" validate:"omitempty"`
+	Page     *core.GcpPage `json:"page" validate:"omitempty"`
+}
+
+// Validate gcp snapshot list option.
+func (opt GcpSnapshotListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) != 0 && opt.Page != nil {
+		return fmt.Errorf("list by cloud_ids not support page")
+	}
+
+	if len(opt.CloudIDs) > core.GcpQueryLimit {
+		return fmt.Errorf("cloud_ids should <= %d", core.GcpQueryLimit)
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GcpSnapshotCreateOption 谷歌云创建快照参数
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/createSnapshot
+type GcpSnapshotCreateOption struct {
+	Zone        string `json:"zone" validate:"required"`
+	DiskName    string `json:"disk_name" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *GcpSnapshotCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToSnapshot 转换成接口需要的 *compute.Snapshot 结构
+func (opt *GcpSnapshotCreateOption) ToSnapshot() (*compute.Snapshot, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &compute.Snapshot{Name: opt.Name, Description: opt.Description}, nil
+}
+
+// GcpSnapshotDeleteOption ...
+type GcpSnapshotDeleteOption struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// Validate ...
+func (opt *GcpSnapshotDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpDiskFromSnapshotOption 谷歌云不支持将快照原地回滚到云盘，需要基于快照创建新云盘
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/insert
+type GcpDiskFromSnapshotOption struct {
+	Zone     string `json:"zone" validate:"required"`
+	DiskName string `json:"disk_name" validate:"required"`
+	DiskType string `json:"disk_type" validate:"omitempty"`
+	// SelfLink 快照的 self_link
+	SelfLink string `json:"self_link" validate:"required"`
+}
+
+// Validate ...
+func (opt *GcpDiskFromSnapshotOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToDisk 转换成接口需要的 *compute.Disk 结构
+func (opt *GcpDiskFromSnapshotOption) ToDisk(cloudProjectID string) (*compute.Disk, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	diskReq := &compute.Disk{Name: opt.DiskName, SourceSnapshot: opt.SelfLink}
+	if opt.DiskType != "" {
+		diskReq.Type = fmt.Sprintf("projects/%s/zones/%s/diskTypes/%s", cloudProjectID, opt.Zone, opt.DiskType)
+	}
+
+	return diskReq, nil
+}
+
+// GcpSnapshot for compute Snapshot
+type GcpSnapshot struct {
+	*compute.Snapshot
+}
+
+// GetCloudID ...
+func (snap GcpSnapshot) GetCloudID() string {
+	return fmt.Sprint(snap.Id)
+}
+
+// GetZone 从源云盘链接中解析可用区，源云盘格式为 .../zones/{zone}/disks/{disk}
+func (snap GcpSnapshot) GetZone() string {
+	parts := strings.Split(snap.SourceDisk, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "zones" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// GetRegion 根据源云盘可用区解析地域
+func (snap GcpSnapshot) GetRegion() string {
+	zone := snap.GetZone()
+	if idx := strings.LastIndex(zone, "-"); idx > 0 {
+		return zone[:idx]
+	}
+	return zone
+}
+
+// GetTagMap ...
+func (snap GcpSnapshot) GetTagMap() apicore.TagMap {
+	if len(snap.Labels) == 0 {
+		return nil
+	}
+
+	return apicore.TagMap(snap.Labels)
+}
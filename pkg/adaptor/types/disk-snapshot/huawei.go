@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// HuaWeiSnapshotListOption define huawei snapshot list option.
+type HuaWeiSnapshotListOption struct {
+	Region string `json:"region" validate:"required"`
+	// CloudIDs 云上查询接口仅支持按单个ID过滤，指定CloudIDs时将逐个查询且忽略分页参数
+	CloudIDs []string                  `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.HuaWeiCvmOffsetPage `json:"page" validate:"omitempty"`
+}
+
+// Validate huawei snapshot list option.
+func (opt HuaWeiSnapshotListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HuaWeiSnapshotCreateOption 华为云创建快照参数
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2043.html
+type HuaWeiSnapshotCreateOption struct {
+	Region      string  `json:"region" validate:"required"`
+	CloudDiskID string  `json:"cloud_disk_id" validate:"required"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	// Force 云盘处于挂载状态时是否强制创建快照
+	Force *bool `json:"force"`
+}
+
+// Validate ...
+func (opt *HuaWeiSnapshotCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToCreateSnapshotRequest ...
+func (opt *HuaWeiSnapshotCreateOption) ToCreateSnapshotRequest() (*model.CreateSnapshotRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model.CreateSnapshotRequest{
+		Body: &model.CreateSnapshotRequestBody{
+			Snapshot: &model.CreateSnapshotOption{
+				VolumeId:    opt.CloudDiskID,
+				Force:       opt.Force,
+				Description: opt.Description,
+				Name:        opt.Name,
+			},
+		},
+	}, nil
+}
+
+// HuaWeiSnapshotDeleteOption ...
+type HuaWeiSnapshotDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate ...
+func (opt *HuaWeiSnapshotDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToDeleteSnapshotRequest ...
+func (opt *HuaWeiSnapshotDeleteOption) ToDeleteSnapshotRequest() (*model.DeleteSnapshotRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model.DeleteSnapshotRequest{SnapshotId: opt.CloudID}, nil
+}
+
+// HuaWeiSnapshotRollbackOption 华为云快照回滚参数，回滚会覆盖云盘上的现有数据
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2047.html
+type HuaWeiSnapshotRollbackOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+}
+
+// Validate ...
+func (opt *HuaWeiSnapshotRollbackOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToRollbackSnapshotRequest ...
+func (opt *HuaWeiSnapshotRollbackOption) ToRollbackSnapshotRequest() (*model.RollbackSnapshotRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model.RollbackSnapshotRequest{
+		SnapshotId: opt.CloudID,
+		Body: &model.RollbackSnapshotRequestBody{
+			Rollback: &model.RollbackSnapshotOption{VolumeId: opt.CloudDiskID},
+		},
+	}, nil
+}
+
+// HuaWeiSnapshot for model SnapshotList
+type HuaWeiSnapshot struct {
+	model.SnapshotList
+}
+
+// GetCloudID ...
+func (snap HuaWeiSnapshot) GetCloudID() string {
+	return snap.Id
+}
@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	apicore "hcm/pkg/api/core"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+)
+
+// TCloudSnapshotCreateOption 腾讯云创建快照参数
+// reference: https://cloud.tencent.com/document/api/362/15648
+type TCloudSnapshotCreateOption struct {
+	Region      string  `json:"region" validate:"required"`
+	CloudDiskID string  `json:"cloud_disk_id" validate:"required"`
+	Name        *string `json:"name"`
+	// Deadline 快照到期时间，到期后快照自动删除，格式为UTC下的ISO-8601时间，如：2022-01-08T09:47:55+00:00
+	Deadline *string `json:"deadline"`
+}
+
+// Validate ...
+func (opt *TCloudSnapshotCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToCreateSnapshotRequest 转换成接口需要的 CreateSnapshotRequest 结构
+func (opt *TCloudSnapshotCreateOption) ToCreateSnapshotRequest() (*cbs.CreateSnapshotRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := cbs.NewCreateSnapshotRequest()
+	req.DiskId = common.StringPtr(opt.CloudDiskID)
+	req.SnapshotName = opt.Name
+	req.Deadline = opt.Deadline
+	return req, nil
+}
+
+// TCloudSnapshotDeleteOption ...
+type TCloudSnapshotDeleteOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required"`
+}
+
+// Validate ...
+func (opt *TCloudSnapshotDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToDeleteSnapshotsRequest ...
+func (opt *TCloudSnapshotDeleteOption) ToDeleteSnapshotsRequest() (*cbs.DeleteSnapshotsRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := cbs.NewDeleteSnapshotsRequest()
+	req.SnapshotIds = common.StringPtrs(opt.CloudIDs)
+	return req, nil
+}
+
+// TCloudSnapshotRollbackOption 腾讯云快照回滚参数，回滚会覆盖云盘上的现有数据
+// reference: https://cloud.tencent.com/document/api/362/15650
+type TCloudSnapshotRollbackOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// AutoStopInstance 回滚前是否自动关机，云盘已挂载在运行中的实例上时需要传入true
+	AutoStopInstance *bool `json:"auto_stop_instance"`
+	// AutoStartInstance 回滚完成后是否自动开机，仅在AutoStopInstance为true时有效
+	AutoStartInstance *bool `json:"auto_start_instance"`
+}
+
+// Validate ...
+func (opt *TCloudSnapshotRollbackOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToApplySnapshotRequest ...
+func (opt *TCloudSnapshotRollbackOption) ToApplySnapshotRequest() (*cbs.ApplySnapshotRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := cbs.NewApplySnapshotRequest()
+	req.SnapshotId = common.StringPtr(opt.CloudID)
+	req.DiskId = common.StringPtr(opt.CloudDiskID)
+	req.AutoStopInstance = opt.AutoStopInstance
+	req.AutoStartInstance = opt.AutoStartInstance
+	return req, nil
+}
+
+// TCloudSnapshot for cbs Snapshot
+type TCloudSnapshot struct {
+	*cbs.Snapshot
+}
+
+// GetCloudID ...
+func (snap TCloudSnapshot) GetCloudID() string {
+	return converter.PtrToVal(snap.SnapshotId)
+}
+
+// GetTagMap ...
+func (snap TCloudSnapshot) GetTagMap() apicore.TagMap {
+	if len(snap.Tags) == 0 {
+		return nil
+	}
+
+	tagMap := make(apicore.TagMap, len(snap.Tags))
+	for _, tag := range snap.Tags {
+		if tag == nil {
+			continue
+		}
+		tagMap.Set(converter.PtrToVal(tag.Key), converter.PtrToVal(tag.Value))
+	}
+	return tagMap
+}
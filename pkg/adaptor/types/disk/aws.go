@@ -21,6 +21,7 @@ package disk
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -155,3 +156,19 @@ type AwsDisk struct {
 func (disk AwsDisk) GetCloudID() string {
 	return converter.PtrToVal(disk.VolumeId)
 }
+
+// GetTagMap ...
+func (disk AwsDisk) GetTagMap() apicore.TagMap {
+	if len(disk.Tags) == 0 {
+		return nil
+	}
+
+	tagMap := make(apicore.TagMap, len(disk.Tags))
+	for _, tag := range disk.Tags {
+		if tag == nil {
+			continue
+		}
+		tagMap.Set(converter.PtrToVal(tag.Key), converter.PtrToVal(tag.Value))
+	}
+	return tagMap
+}
@@ -20,6 +20,7 @@
 package disk
 
 import (
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -133,16 +134,17 @@ func (opt *AzureDiskDetachOption) Validate() error {
 
 // AzureDisk define azure disk.
 type AzureDisk struct {
-	ID       *string   `json:"id"`
-	Name     *string   `json:"name"`
-	Location *string   `json:"location"`
-	Type     *string   `json:"type"`
-	Status   *string   `json:"status"`
-	DiskSize *int64    `json:"disk_size"`
-	OSType   *string   `json:"os_type"`
-	Zones    []*string `json:"zone"`
-	SKUName  *string   `json:"sku_name"`
-	SKUTier  *string   `json:"sku_tier"`
+	ID       *string        `json:"id"`
+	Name     *string        `json:"name"`
+	Location *string        `json:"location"`
+	Type     *string        `json:"type"`
+	Status   *string        `json:"status"`
+	DiskSize *int64         `json:"disk_size"`
+	OSType   *string        `json:"os_type"`
+	Zones    []*string      `json:"zone"`
+	SKUName  *string        `json:"sku_name"`
+	SKUTier  *string        `json:"sku_tier"`
+	Tags     apicore.TagMap `json:"tags"`
 	Boot     *bool
 }
 
@@ -150,3 +152,8 @@ type AzureDisk struct {
 func (disk AzureDisk) GetCloudID() string {
 	return converter.PtrToVal(disk.ID)
 }
+
+// GetTagMap ...
+func (disk AzureDisk) GetTagMap() apicore.TagMap {
+	return disk.Tags
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 
 	"google.golang.org/api/compute/v1"
@@ -151,3 +152,12 @@ type GcpDisk struct {
 func (disk GcpDisk) GetCloudID() string {
 	return fmt.Sprint(disk.Id)
 }
+
+// GetTagMap ...
+func (disk GcpDisk) GetTagMap() apicore.TagMap {
+	if len(disk.Labels) == 0 {
+		return nil
+	}
+
+	return apicore.TagMap(disk.Labels)
+}
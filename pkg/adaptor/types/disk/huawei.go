@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -286,3 +287,12 @@ type HuaWeiDisk struct {
 func (disk HuaWeiDisk) GetCloudID() string {
 	return disk.Id
 }
+
+// GetTagMap ...
+func (disk HuaWeiDisk) GetTagMap() apicore.TagMap {
+	if len(disk.Tags) == 0 {
+		return nil
+	}
+
+	return apicore.TagMap(disk.Tags)
+}
@@ -20,6 +20,7 @@
 package eip
 
 import (
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -59,6 +60,7 @@ type AwsEip struct {
 	NetworkBorderGroup      *string
 	NetworkInterfaceId      *string
 	NetworkInterfaceOwnerId *string
+	Tags                    apicore.TagMap
 }
 
 // GetCloudID ...
@@ -66,6 +68,11 @@ func (eip *AwsEip) GetCloudID() string {
 	return eip.CloudID
 }
 
+// GetTagMap ...
+func (eip *AwsEip) GetTagMap() apicore.TagMap {
+	return eip.Tags
+}
+
 // AwsEipDeleteOption ...
 type AwsEipDeleteOption struct {
 	Region  string `json:"region" validate:"required"`
@@ -21,6 +21,7 @@ package eip
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 
 	"google.golang.org/api/compute/v1"
@@ -80,6 +81,7 @@ type GcpEip struct {
 	Subnetwork   string
 	SelfLink     string
 	Users        []string
+	Tags         apicore.TagMap
 }
 
 // GetCloudID ...
@@ -87,6 +89,11 @@ func (eip *GcpEip) GetCloudID() string {
 	return eip.CloudID
 }
 
+// GetTagMap ...
+func (eip *GcpEip) GetTagMap() apicore.TagMap {
+	return eip.Tags
+}
+
 // GcpEipDeleteOption ...
 type GcpEipDeleteOption struct {
 	Region  string `json:"region" validate:"required"`
@@ -0,0 +1,111 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// AwsKeyPairListOption define aws key pair list option, 云上查询接口不分页。
+type AwsKeyPairListOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate aws key pair list option.
+func (opt AwsKeyPairListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsKeyPairCreateOption AWS 创建密钥对参数
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_CreateKeyPair.html
+type AwsKeyPairCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	Name   string `json:"name" validate:"required,max=255"`
+	// KeyType 密钥类型，rsa 或 ed25519，默认为 rsa
+	KeyType *string `json:"key_type" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *AwsKeyPairCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToCreateKeyPairInput 转换成接口需要的 CreateKeyPairInput 结构
+func (opt *AwsKeyPairCreateOption) ToCreateKeyPairInput() (*ec2.CreateKeyPairInput, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ec2.CreateKeyPairInput{
+		KeyName: aws.String(opt.Name),
+		KeyType: opt.KeyType,
+	}, nil
+}
+
+// AwsKeyPairImportOption AWS 导入密钥对参数
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_ImportKeyPair.html
+type AwsKeyPairImportOption struct {
+	Region    string `json:"region" validate:"required"`
+	Name      string `json:"name" validate:"required,max=255"`
+	PublicKey string `json:"public_key" validate:"required"`
+}
+
+// Validate ...
+func (opt *AwsKeyPairImportOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToImportKeyPairInput 转换成接口需要的 ImportKeyPairInput 结构
+func (opt *AwsKeyPairImportOption) ToImportKeyPairInput() (*ec2.ImportKeyPairInput, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ec2.ImportKeyPairInput{
+		KeyName:           aws.String(opt.Name),
+		PublicKeyMaterial: []byte(opt.PublicKey),
+	}, nil
+}
+
+// AwsKeyPairDeleteOption ...
+type AwsKeyPairDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate ...
+func (opt *AwsKeyPairDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsKeyPair for ec2 KeyPairInfo
+type AwsKeyPair struct {
+	*ec2.KeyPairInfo
+}
+
+// GetCloudID ...
+func (kp AwsKeyPair) GetCloudID() string {
+	return converter.PtrToVal(kp.KeyPairId)
+}
@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+)
+
+// AzureKeyPairListOption define azure key pair list option.
+type AzureKeyPairListOption struct {
+	ResourceGroupName string   `json:"resource_group_name" validate:"required"`
+	CloudIDs          []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate azure key pair list option.
+func (opt AzureKeyPairListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AzureKeyPairCreateOption 微软云创建/导入SSH公钥参数，PublicKey 不为空时为导入，否则由云上生成密钥对并返回私钥
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/ssh-public-keys/create?tabs=Go
+type AzureKeyPairCreateOption struct {
+	ResourceGroupName string  `json:"resource_group_name" validate:"required"`
+	Region            string  `json:"region" validate:"required"`
+	Name              string  `json:"name" validate:"required"`
+	PublicKey         *string `json:"public_key" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *AzureKeyPairCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AzureKeyPairDeleteOption ...
+type AzureKeyPairDeleteOption struct {
+	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+	Name              string `json:"name" validate:"required"`
+}
+
+// Validate ...
+func (opt *AzureKeyPairDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AzureKeyPair define azure ssh public key.
+type AzureKeyPair struct {
+	ID        *string `json:"id"`
+	Name      *string `json:"name"`
+	Location  *string `json:"location"`
+	PublicKey *string `json:"public_key"`
+}
+
+// GetCloudID ...
+func (kp AzureKeyPair) GetCloudID() string {
+	return converter.PtrToVal(kp.ID)
+}
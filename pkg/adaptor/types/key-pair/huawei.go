@@ -0,0 +1,190 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/kps/v3/model"
+)
+
+// HuaWeiKeyPairListOption define huawei key pair list option, 云上查询接口不支持按名称过滤，CloudIDs 在查询结果中过滤。
+type HuaWeiKeyPairListOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate huawei key pair list option.
+func (opt HuaWeiKeyPairListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiKeyPairCreateOption 华为云创建/导入密钥对参数，PublicKey 不为空时为导入，否则由云上生成密钥对并返回私钥。
+// 华为云密钥对以名称作为唯一标识。
+type HuaWeiKeyPairCreateOption struct {
+	Region    string  `json:"region" validate:"required"`
+	Name      string  `json:"name" validate:"required,max=64"`
+	PublicKey *string `json:"public_key" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *HuaWeiKeyPairCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToCreateKeypairRequest 转换成接口需要的 CreateKeypairRequest 结构
+func (opt *HuaWeiKeyPairCreateOption) ToCreateKeypairRequest() (*model.CreateKeypairRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	keyType := model.GetCreateKeypairActionTypeEnum().SSH
+	return &model.CreateKeypairRequest{
+		Body: &model.CreateKeypairRequestBody{
+			Keypair: &model.CreateKeypairAction{
+				Name:      opt.Name,
+				Type:      &keyType,
+				PublicKey: opt.PublicKey,
+			},
+		},
+	}, nil
+}
+
+// HuaWeiKeyPairDeleteOption ...
+type HuaWeiKeyPairDeleteOption struct {
+	Region string `json:"region" validate:"required"`
+	Name   string `json:"name" validate:"required"`
+}
+
+// Validate ...
+func (opt *HuaWeiKeyPairDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiKeyPairAuthType 绑定/解绑密钥对时登录实例的认证方式
+type HuaWeiKeyPairAuthType string
+
+const (
+	// HuaWeiKeyPairAuthPassword 使用实例当前密码认证
+	HuaWeiKeyPairAuthPassword HuaWeiKeyPairAuthType = "password"
+	// HuaWeiKeyPairAuthKeyPair 使用实例当前绑定密钥对的私钥认证
+	HuaWeiKeyPairAuthKeyPair HuaWeiKeyPairAuthType = "keypair"
+)
+
+// HuaWeiKeyPairAuth 实例处于运行中时，需要提供当前的登录凭证，云上才能替换实例中的公钥
+type HuaWeiKeyPairAuth struct {
+	Type HuaWeiKeyPairAuthType `json:"type" validate:"required"`
+	Key  string                `json:"key" validate:"required"`
+}
+
+func (auth *HuaWeiKeyPairAuth) toAuth() (*model.Auth, error) {
+	if auth == nil {
+		return nil, nil
+	}
+
+	var authType model.AuthType
+	switch auth.Type {
+	case HuaWeiKeyPairAuthPassword:
+		authType = model.GetAuthTypeEnum().PASSWORD
+	case HuaWeiKeyPairAuthKeyPair:
+		authType = model.GetAuthTypeEnum().KEYPAIR
+	default:
+		return nil, fmt.Errorf("huawei key pair auth type: %s not support", auth.Type)
+	}
+
+	return &model.Auth{Type: &authType, Key: converter.ValToPtr(auth.Key)}, nil
+}
+
+// HuaWeiKeyPairAssociateOption 华为云实例绑定密钥对参数，仅支持Linux实例
+type HuaWeiKeyPairAssociateOption struct {
+	Region          string             `json:"region" validate:"required"`
+	Name            string             `json:"name" validate:"required"`
+	CloudInstanceID string             `json:"cloud_instance_id" validate:"required"`
+	Auth            *HuaWeiKeyPairAuth `json:"auth" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *HuaWeiKeyPairAssociateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToAssociateKeypairRequest 转换成接口需要的 AssociateKeypairRequest 结构
+func (opt *HuaWeiKeyPairAssociateOption) ToAssociateKeypairRequest() (*model.AssociateKeypairRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	auth, err := opt.Auth.toAuth()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AssociateKeypairRequest{
+		Body: &model.AssociateKeypairRequestBody{
+			KeypairName: opt.Name,
+			Server:      &model.EcsServerInfo{Id: opt.CloudInstanceID, Auth: auth},
+		},
+	}, nil
+}
+
+// HuaWeiKeyPairDisassociateOption 华为云实例解绑密钥对参数
+type HuaWeiKeyPairDisassociateOption struct {
+	Region          string             `json:"region" validate:"required"`
+	CloudInstanceID string             `json:"cloud_instance_id" validate:"required"`
+	Auth            *HuaWeiKeyPairAuth `json:"auth" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *HuaWeiKeyPairDisassociateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToDisassociateKeypairRequest 转换成接口需要的 DisassociateKeypairRequest 结构
+func (opt *HuaWeiKeyPairDisassociateOption) ToDisassociateKeypairRequest() (*model.DisassociateKeypairRequest,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	auth, err := opt.Auth.toAuth()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DisassociateKeypairRequest{
+		Body: &model.DisassociateKeypairRequestBody{
+			Server: &model.DisassociateEcsServerInfo{Id: opt.CloudInstanceID, Auth: auth},
+		},
+	}, nil
+}
+
+// HuaWeiKeyPair for kps Keypair
+type HuaWeiKeyPair struct {
+	model.Keypair
+}
+
+// GetCloudID 华为云密钥对没有ID，以名称作为云上唯一标识
+func (kp HuaWeiKeyPair) GetCloudID() string {
+	return converter.PtrToVal(kp.Name)
+}
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// TCloudKeyPairCreateOption 腾讯云创建密钥对参数
+type TCloudKeyPairCreateOption struct {
+	Region    string `json:"region" validate:"required"`
+	Name      string `json:"name" validate:"required,max=25"`
+	ProjectID *int64 `json:"project_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *TCloudKeyPairCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToCreateKeyPairRequest 转换成接口需要的 CreateKeyPairRequest 结构
+func (opt *TCloudKeyPairCreateOption) ToCreateKeyPairRequest() (*cvm.CreateKeyPairRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := cvm.NewCreateKeyPairRequest()
+	req.KeyName = common.StringPtr(opt.Name)
+	req.ProjectId = common.Int64Ptr(converter.PtrToVal(opt.ProjectID))
+	return req, nil
+}
+
+// TCloudKeyPairImportOption 腾讯云导入密钥对参数
+type TCloudKeyPairImportOption struct {
+	Region    string `json:"region" validate:"required"`
+	Name      string `json:"name" validate:"required,max=25"`
+	PublicKey string `json:"public_key" validate:"required"`
+	ProjectID *int64 `json:"project_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *TCloudKeyPairImportOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ToImportKeyPairRequest 转换成接口需要的 ImportKeyPairRequest 结构
+func (opt *TCloudKeyPairImportOption) ToImportKeyPairRequest() (*cvm.ImportKeyPairRequest, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := cvm.NewImportKeyPairRequest()
+	req.KeyName = common.StringPtr(opt.Name)
+	req.PublicKey = common.StringPtr(opt.PublicKey)
+	req.ProjectId = common.Int64Ptr(converter.PtrToVal(opt.ProjectID))
+	return req, nil
+}
+
+// TCloudKeyPairDeleteOption ...
+type TCloudKeyPairDeleteOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required,min=1,max=100"`
+}
+
+// Validate ...
+func (opt *TCloudKeyPairDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudKeyPairAssociateOption 腾讯云实例绑定/解绑密钥对参数，仅支持Linux实例
+type TCloudKeyPairAssociateOption struct {
+	Region           string   `json:"region" validate:"required"`
+	CloudIDs         []string `json:"cloud_ids" validate:"required,min=1,max=100"`
+	CloudInstanceIDs []string `json:"cloud_instance_ids" validate:"required,min=1,max=100"`
+	// ForceStop 是否对运行中的实例强制关机，实例处于运行中时需要关机后才能绑定/解绑密钥对
+	ForceStop *bool `json:"force_stop" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *TCloudKeyPairAssociateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudKeyPair for cvm KeyPair
+type TCloudKeyPair struct {
+	*cvm.KeyPair
+}
+
+// GetCloudID ...
+func (kp TCloudKeyPair) GetCloudID() string {
+	return converter.PtrToVal(kp.KeyId)
+}
@@ -0,0 +1,637 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	apicore "hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+const (
+	// AwsLBDescribeMax DescribeLoadBalancers 指定ARN时单次最多查询20个
+	AwsLBDescribeMax = 20
+	// AwsLBPageSizeMax elbv2 分页查询单页最大数量
+	AwsLBPageSizeMax = 400
+	// AwsTagDescribeMax DescribeTags 单次最多查询20个资源的标签
+	AwsTagDescribeMax = 20
+)
+
+// AwsLoadBalancerType aws 负载均衡类型
+type AwsLoadBalancerType string
+
+const (
+	// AwsApplicationLB 应用型负载均衡 ALB
+	AwsApplicationLB AwsLoadBalancerType = "application"
+	// AwsNetworkLB 网络型负载均衡 NLB
+	AwsNetworkLB AwsLoadBalancerType = "network"
+	// AwsGatewayLB 网关型负载均衡 GWLB
+	AwsGatewayLB AwsLoadBalancerType = "gateway"
+)
+
+// AwsLoadBalancerScheme aws 负载均衡网络类型
+type AwsLoadBalancerScheme string
+
+const (
+	// AwsInternetFacingScheme 公网
+	AwsInternetFacingScheme AwsLoadBalancerScheme = "internet-facing"
+	// AwsInternalScheme 内网
+	AwsInternalScheme AwsLoadBalancerScheme = "internal"
+)
+
+// Convert 转换为与腾讯云一致的 lb_type 取值，便于跨云统一查询
+func (s AwsLoadBalancerScheme) Convert() TCloudLoadBalancerType {
+	if s == AwsInternalScheme {
+		return InternalLoadBalancerType
+	}
+	return OpenLoadBalancerType
+}
+
+// AwsIPAddressType aws 负载均衡IP地址类型
+type AwsIPAddressType string
+
+const (
+	// AwsIPv4AddressType ipv4
+	AwsIPv4AddressType AwsIPAddressType = "ipv4"
+	// AwsDualStackAddressType 双栈
+	AwsDualStackAddressType AwsIPAddressType = "dualstack"
+	// AwsDualStackWithoutPublicIPv4AddressType 双栈，无公网ipv4
+	AwsDualStackWithoutPublicIPv4AddressType AwsIPAddressType = "dualstack-without-public-ipv4"
+)
+
+// AwsLBPage aws elbv2 分页参数，elbv2 使用Marker分页，不同于ec2的NextToken
+type AwsLBPage struct {
+	Marker   *string `json:"marker,omitempty"`
+	PageSize *int64  `json:"page_size,omitempty"`
+}
+
+// Validate ...
+func (p AwsLBPage) Validate() error {
+	if p.PageSize == nil {
+		return nil
+	}
+	if *p.PageSize < 1 || *p.PageSize > AwsLBPageSizeMax {
+		return fmt.Errorf("aws lb page size should >= 1 and <= %d", AwsLBPageSizeMax)
+	}
+	return nil
+}
+
+// -------------------------- List Load Balancer --------------------------
+
+// AwsListOption defines options to list aws load balancers.
+type AwsListOption struct {
+	Region   string     `json:"region" validate:"required"`
+	CloudIDs []string   `json:"cloud_ids" validate:"omitempty,max=20"`
+	Page     *AwsLBPage `json:"page" validate:"omitempty"`
+}
+
+// Validate aws load balancer list option.
+func (opt AwsListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) != 0 && opt.Page != nil {
+		return errors.New("only one of cloud_ids and page can be set")
+	}
+
+	if opt.Page != nil {
+		return opt.Page.Validate()
+	}
+
+	return nil
+}
+
+// AwsListResult defines aws list load balancer result.
+type AwsListResult struct {
+	NextMarker *string           `json:"next_marker,omitempty"`
+	Details    []AwsLoadBalancer `json:"details"`
+}
+
+// AwsLoadBalancer aws load balancer, cloud id is the load balancer arn.
+type AwsLoadBalancer struct {
+	*elbv2.LoadBalancer
+	Tags []*elbv2.Tag `json:"tags"`
+}
+
+// GetCloudID get cloud id
+func (lb AwsLoadBalancer) GetCloudID() string {
+	return cvt.PtrToVal(lb.LoadBalancerArn)
+}
+
+// GetScheme ...
+func (lb AwsLoadBalancer) GetScheme() AwsLoadBalancerScheme {
+	return AwsLoadBalancerScheme(cvt.PtrToVal(lb.Scheme))
+}
+
+// GetIPVersion 返回ip版本信息
+func (lb AwsLoadBalancer) GetIPVersion() enumor.IPAddressType {
+	switch AwsIPAddressType(cvt.PtrToVal(lb.IpAddressType)) {
+	case AwsIPv4AddressType:
+		return enumor.Ipv4
+	case AwsDualStackAddressType, AwsDualStackWithoutPublicIPv4AddressType:
+		return enumor.Ipv6DualStack
+	}
+	// fall back to unknown
+	return enumor.IPAddressType(cvt.PtrToVal(lb.IpAddressType))
+}
+
+// GetZones 返回负载均衡所在的可用区
+func (lb AwsLoadBalancer) GetZones() []string {
+	zones := make([]string, 0, len(lb.AvailabilityZones))
+	for _, zone := range lb.AvailabilityZones {
+		if zone == nil {
+			continue
+		}
+		zones = append(zones, cvt.PtrToVal(zone.ZoneName))
+	}
+	return zones
+}
+
+// GetCloudSubnetIDs 返回负载均衡所在子网，aws 负载均衡每个可用区对应一个子网
+func (lb AwsLoadBalancer) GetCloudSubnetIDs() []string {
+	subnetIDs := make([]string, 0, len(lb.AvailabilityZones))
+	for _, zone := range lb.AvailabilityZones {
+		if zone == nil || zone.SubnetId == nil {
+			continue
+		}
+		subnetIDs = append(subnetIDs, cvt.PtrToVal(zone.SubnetId))
+	}
+	return subnetIDs
+}
+
+// GetAddresses 返回负载均衡静态地址，只有NLB会返回。
+func (lb AwsLoadBalancer) GetAddresses() (privateIPv4, publicIPv4, ipv6 []string) {
+	for _, zone := range lb.AvailabilityZones {
+		if zone == nil {
+			continue
+		}
+		for _, addr := range zone.LoadBalancerAddresses {
+			if addr == nil {
+				continue
+			}
+			if ip := cvt.PtrToVal(addr.PrivateIPv4Address); len(ip) > 0 {
+				privateIPv4 = append(privateIPv4, ip)
+			}
+			if ip := cvt.PtrToVal(addr.IpAddress); len(ip) > 0 {
+				publicIPv4 = append(publicIPv4, ip)
+			}
+			if ip := cvt.PtrToVal(addr.IPv6Address); len(ip) > 0 {
+				ipv6 = append(ipv6, ip)
+			}
+		}
+	}
+	return privateIPv4, publicIPv4, ipv6
+}
+
+// GetStatus 返回负载均衡状态
+func (lb AwsLoadBalancer) GetStatus() string {
+	if lb.State == nil {
+		return ""
+	}
+	return cvt.PtrToVal(lb.State.Code)
+}
+
+// GetTagMap ...
+func (lb AwsLoadBalancer) GetTagMap() apicore.TagMap {
+	return awsTagsToMap(lb.Tags)
+}
+
+// GetName 返回负载均衡名称
+func (lb AwsLoadBalancer) GetName() string {
+	return cvt.PtrToVal(lb.LoadBalancerName)
+}
+
+func awsTagsToMap(tags []*elbv2.Tag) apicore.TagMap {
+	if len(tags) == 0 {
+		return nil
+	}
+	tagMap := make(apicore.TagMap, len(tags))
+	for _, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		tagMap.Set(cvt.PtrToVal(tag.Key), cvt.PtrToVal(tag.Value))
+	}
+	return tagMap
+}
+
+// -------------------------- Create Load Balancer --------------------------
+
+// AwsCreateLoadBalancerOption defines options to create aws load balancer.
+type AwsCreateLoadBalancerOption struct {
+	Region         string                `json:"region" validate:"required"`
+	Name           string                `json:"name" validate:"required,max=32"`
+	Type           AwsLoadBalancerType   `json:"type" validate:"required"`
+	Scheme         AwsLoadBalancerScheme `json:"scheme" validate:"omitempty"`
+	IPAddressType  AwsIPAddressType      `json:"ip_address_type" validate:"omitempty"`
+	CloudSubnetIDs []string              `json:"cloud_subnet_ids" validate:"required,min=1"`
+	CloudSGIDs     []string              `json:"cloud_security_group_ids" validate:"omitempty"`
+	Tags           []apicore.TagPair     `json:"tags" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt AwsCreateLoadBalancerOption) Validate() error {
+	if opt.Type == AwsApplicationLB && len(opt.CloudSubnetIDs) < 2 {
+		return errors.New("application load balancer requires subnets from at least two availability zones")
+	}
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Delete Load Balancer --------------------------
+
+// AwsDeleteOption defines options to delete aws load balancers.
+type AwsDeleteOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required,min=1,max=20"`
+}
+
+// Validate ...
+func (opt AwsDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- List Listener --------------------------
+
+// AwsListListenersOption defines options to list aws listeners.
+type AwsListListenersOption struct {
+	Region         string   `json:"region" validate:"required"`
+	LoadBalancerID string   `json:"load_balancer_id" validate:"required_without=CloudIDs"`
+	CloudIDs       []string `json:"cloud_ids" validate:"omitempty,max=20"`
+}
+
+// Validate ...
+func (opt AwsListListenersOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsListener aws listener, cloud id is the listener arn.
+type AwsListener struct {
+	Region string `json:"region"`
+	*elbv2.Listener
+}
+
+// GetCloudID get cloud id
+func (l AwsListener) GetCloudID() string {
+	return cvt.PtrToVal(l.ListenerArn)
+}
+
+// GetProtocol ...
+func (l AwsListener) GetProtocol() enumor.ProtocolType {
+	return enumor.ProtocolType(cvt.PtrToVal(l.Protocol))
+}
+
+// GetName aws 监听器没有名称，使用 协议:端口 作为名称
+func (l AwsListener) GetName() string {
+	return fmt.Sprintf("%s:%d", cvt.PtrToVal(l.Protocol), cvt.PtrToVal(l.Port))
+}
+
+// GetDefaultTargetGroupIDs 返回默认动作转发的目标组
+func (l AwsListener) GetDefaultTargetGroupIDs() []string {
+	return getForwardTargetGroups(l.DefaultActions)
+}
+
+func (l AwsListener) String() string {
+	return fmt.Sprintf("{arn:%s,protocol:%s,port:%d}",
+		cvt.PtrToVal(l.ListenerArn), cvt.PtrToVal(l.Protocol), cvt.PtrToVal(l.Port))
+}
+
+// -------------------------- Create Listener --------------------------
+
+// AwsCreateListenerOption defines options to create aws listener.
+type AwsCreateListenerOption struct {
+	Region         string              `json:"region" validate:"required"`
+	LoadBalancerID string              `json:"load_balancer_id" validate:"required"`
+	Protocol       enumor.ProtocolType `json:"protocol" validate:"required"`
+	Port           int64               `json:"port" validate:"required,min=1,max=65535"`
+	// TargetGroupID 默认动作转发到的目标组
+	TargetGroupID  string   `json:"target_group_id" validate:"required"`
+	SslPolicy      *string  `json:"ssl_policy" validate:"omitempty"`
+	CertificateIDs []string `json:"certificate_ids" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt AwsCreateListenerOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsDeleteListenerOption defines options to delete aws listener.
+type AwsDeleteListenerOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate ...
+func (opt AwsDeleteListenerOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- List Rule --------------------------
+
+// AwsListRuleOption defines options to list aws listener rules.
+type AwsListRuleOption struct {
+	Region     string   `json:"region" validate:"required"`
+	ListenerID string   `json:"listener_id" validate:"required_without=CloudIDs"`
+	CloudIDs   []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt AwsListRuleOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsRule aws listener rule, cloud id is the rule arn.
+type AwsRule struct {
+	ListenerID string `json:"listener_id"`
+	*elbv2.Rule
+}
+
+// GetCloudID get cloud id
+func (r AwsRule) GetCloudID() string {
+	return cvt.PtrToVal(r.RuleArn)
+}
+
+// GetName aws 规则没有名称，使用优先级作为名称，默认规则优先级为 default
+func (r AwsRule) GetName() string {
+	return cvt.PtrToVal(r.Priority)
+}
+
+// IsDefaultRule 是否为监听器的默认规则，默认规则即监听器的默认动作
+func (r AwsRule) IsDefaultRule() bool {
+	return cvt.PtrToVal(r.IsDefault)
+}
+
+// GetDomain 返回规则匹配的域名，多个以逗号分隔
+func (r AwsRule) GetDomain() string {
+	return strings.Join(r.GetConditionValues("host-header"), ",")
+}
+
+// GetURL 返回规则匹配的路径，多个以逗号分隔
+func (r AwsRule) GetURL() string {
+	return strings.Join(r.GetConditionValues("path-pattern"), ",")
+}
+
+// GetCloudTargetGroupID 返回规则转发的第一个目标组，加权转发到多个目标组时本地只记录第一个
+func (r AwsRule) GetCloudTargetGroupID() string {
+	tgIDs := r.GetTargetGroupIDs()
+	if len(tgIDs) == 0 {
+		return ""
+	}
+	return tgIDs[0]
+}
+
+// GetTargetGroupIDs 返回规则转发的目标组
+func (r AwsRule) GetTargetGroupIDs() []string {
+	return getForwardTargetGroups(r.Actions)
+}
+
+// GetConditionValues 返回指定字段的条件值，如 host-header、path-pattern
+func (r AwsRule) GetConditionValues(field string) []string {
+	values := make([]string, 0)
+	for _, cond := range r.Conditions {
+		if cond == nil || cvt.PtrToVal(cond.Field) != field {
+			continue
+		}
+		values = append(values, cvt.PtrToSlice(cond.Values)...)
+	}
+	return values
+}
+
+func getForwardTargetGroups(actions []*elbv2.Action) []string {
+	tgIDs := make([]string, 0)
+	for _, action := range actions {
+		if action == nil || cvt.PtrToVal(action.Type) != elbv2.ActionTypeEnumForward {
+			continue
+		}
+		if action.TargetGroupArn != nil {
+			tgIDs = append(tgIDs, cvt.PtrToVal(action.TargetGroupArn))
+			continue
+		}
+		if action.ForwardConfig == nil {
+			continue
+		}
+		for _, tg := range action.ForwardConfig.TargetGroups {
+			if tg != nil && tg.TargetGroupArn != nil {
+				tgIDs = append(tgIDs, cvt.PtrToVal(tg.TargetGroupArn))
+			}
+		}
+	}
+	return tgIDs
+}
+
+// -------------------------- Target Group --------------------------
+
+// AwsListTargetGroupOption defines options to list aws target groups.
+type AwsListTargetGroupOption struct {
+	Region         string     `json:"region" validate:"required"`
+	LoadBalancerID string     `json:"load_balancer_id" validate:"omitempty"`
+	CloudIDs       []string   `json:"cloud_ids" validate:"omitempty"`
+	Page           *AwsLBPage `json:"page" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt AwsListTargetGroupOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+	if opt.Page != nil {
+		return opt.Page.Validate()
+	}
+	return nil
+}
+
+// AwsTargetGroupListResult defines aws list target group result.
+type AwsTargetGroupListResult struct {
+	NextMarker *string          `json:"next_marker,omitempty"`
+	Details    []AwsTargetGroup `json:"details"`
+}
+
+// AwsTargetGroup aws target group, cloud id is the target group arn.
+type AwsTargetGroup struct {
+	*elbv2.TargetGroup
+}
+
+// GetCloudID get cloud id
+func (tg AwsTargetGroup) GetCloudID() string {
+	return cvt.PtrToVal(tg.TargetGroupArn)
+}
+
+// GetName ...
+func (tg AwsTargetGroup) GetName() string {
+	return cvt.PtrToVal(tg.TargetGroupName)
+}
+
+// GetProtocol lambda 类型目标组没有协议
+func (tg AwsTargetGroup) GetProtocol() enumor.ProtocolType {
+	return enumor.ProtocolType(cvt.PtrToVal(tg.Protocol))
+}
+
+// GetPort lambda 类型目标组没有端口
+func (tg AwsTargetGroup) GetPort() int64 {
+	return cvt.PtrToVal(tg.Port)
+}
+
+// GetCloudVpcID lambda 类型目标组没有vpc
+func (tg AwsTargetGroup) GetCloudVpcID() string {
+	return cvt.PtrToVal(tg.VpcId)
+}
+
+// GetHealthCheck 返回目标组健康检查配置，GRPC 目标组成功码为 GrpcCode
+func (tg AwsTargetGroup) GetHealthCheck() *corelb.AwsHealthCheckInfo {
+	health := &corelb.AwsHealthCheckInfo{
+		Enabled:            cvt.ValToPtr(cvt.PtrToVal(tg.HealthCheckEnabled)),
+		Protocol:           tg.HealthCheckProtocol,
+		Port:               tg.HealthCheckPort,
+		Path:               tg.HealthCheckPath,
+		IntervalSeconds:    tg.HealthCheckIntervalSeconds,
+		TimeoutSeconds:     tg.HealthCheckTimeoutSeconds,
+		HealthyThreshold:   tg.HealthyThresholdCount,
+		UnhealthyThreshold: tg.UnhealthyThresholdCount,
+	}
+	if tg.Matcher != nil {
+		health.Matcher = tg.Matcher.HttpCode
+		if tg.Matcher.GrpcCode != nil {
+			health.Matcher = tg.Matcher.GrpcCode
+		}
+	}
+	return health
+}
+
+// AwsCreateTargetGroupOption defines options to create aws target group.
+type AwsCreateTargetGroupOption struct {
+	Region     string              `json:"region" validate:"required"`
+	Name       string              `json:"name" validate:"required,max=32"`
+	Protocol   enumor.ProtocolType `json:"protocol" validate:"omitempty"`
+	Port       *int64              `json:"port" validate:"omitempty,min=1,max=65535"`
+	CloudVpcID *string             `json:"cloud_vpc_id" validate:"omitempty"`
+	// TargetType instance | ip | lambda | alb
+	TargetType string `json:"target_type" validate:"omitempty"`
+
+	HealthCheckEnabled  *bool   `json:"health_check_enabled" validate:"omitempty"`
+	HealthCheckProtocol *string `json:"health_check_protocol" validate:"omitempty"`
+	HealthCheckPort     *string `json:"health_check_port" validate:"omitempty"`
+	HealthCheckPath     *string `json:"health_check_path" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt AwsCreateTargetGroupOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsDeleteTargetGroupOption defines options to delete aws target group.
+type AwsDeleteTargetGroupOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate ...
+func (opt AwsDeleteTargetGroupOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Targets --------------------------
+
+// AwsTarget defines aws target group target.
+type AwsTarget struct {
+	// CloudInstID 实例ID、IP地址或Lambda ARN，取决于目标组的TargetType
+	CloudInstID string  `json:"cloud_inst_id" validate:"required"`
+	Port        *int64  `json:"port" validate:"omitempty,min=1,max=65535"`
+	Zone        *string `json:"zone" validate:"omitempty"`
+}
+
+// AwsRegisterTargetsOption defines options to register or deregister aws targets.
+type AwsRegisterTargetsOption struct {
+	Region        string       `json:"region" validate:"required"`
+	TargetGroupID string       `json:"target_group_id" validate:"required"`
+	Targets       []*AwsTarget `json:"targets" validate:"required,min=1,dive,required"`
+}
+
+// Validate ...
+func (opt AwsRegisterTargetsOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsListTargetHealthOption defines options to list aws target health.
+type AwsListTargetHealthOption struct {
+	Region        string       `json:"region" validate:"required"`
+	TargetGroupID string       `json:"target_group_id" validate:"required"`
+	Targets       []*AwsTarget `json:"targets" validate:"omitempty,dive,required"`
+}
+
+// Validate ...
+func (opt AwsListTargetHealthOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsTargetHealth aws target health.
+type AwsTargetHealth struct {
+	TargetGroupID string `json:"target_group_id"`
+	*elbv2.TargetHealthDescription
+}
+
+// GetCloudInstID ...
+func (h AwsTargetHealth) GetCloudInstID() string {
+	if h.Target == nil {
+		return ""
+	}
+	return cvt.PtrToVal(h.Target.Id)
+}
+
+// GetCloudID 同一实例可以以不同端口多次注册到目标组，使用 实例ID-端口 作为唯一标识
+func (h AwsTargetHealth) GetCloudID() string {
+	return fmt.Sprintf("%s-%d", h.GetCloudInstID(), h.GetPort())
+}
+
+// GetPort ...
+func (h AwsTargetHealth) GetPort() int64 {
+	if h.Target == nil {
+		return 0
+	}
+	return cvt.PtrToVal(h.Target.Port)
+}
+
+// GetZone 目标所在可用区，目标与负载均衡不在同一VPC时为 all
+func (h AwsTargetHealth) GetZone() string {
+	if h.Target == nil {
+		return ""
+	}
+	return cvt.PtrToVal(h.Target.AvailabilityZone)
+}
+
+// GetState 目标健康状态 initial | healthy | unhealthy | unused | draining | unavailable
+func (h AwsTargetHealth) GetState() string {
+	if h.TargetHealth == nil {
+		return ""
+	}
+	return cvt.PtrToVal(h.TargetHealth.State)
+}
+
+// IsHealthy ...
+func (h AwsTargetHealth) IsHealthy() bool {
+	return h.TargetHealth != nil && cvt.PtrToVal(h.TargetHealth.State) == elbv2.TargetHealthStateEnumHealthy
+}
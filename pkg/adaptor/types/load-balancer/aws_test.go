@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"testing"
+
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+func TestAwsTargetGroupGetHealthCheck(t *testing.T) {
+	tg := AwsTargetGroup{TargetGroup: &elbv2.TargetGroup{
+		HealthCheckPort:         cvt.ValToPtr("traffic-port"),
+		HealthCheckPath:         cvt.ValToPtr("/health"),
+		HealthyThresholdCount:   cvt.ValToPtr(int64(3)),
+		UnhealthyThresholdCount: cvt.ValToPtr(int64(2)),
+		Matcher:                 &elbv2.Matcher{HttpCode: cvt.ValToPtr("200"), GrpcCode: cvt.ValToPtr("12")},
+	}}
+
+	health := tg.GetHealthCheck()
+	if health.Enabled == nil || *health.Enabled {
+		t.Errorf("health check should be disabled when not set, got: %v", health.Enabled)
+	}
+	if cvt.PtrToVal(health.Port) != "traffic-port" || cvt.PtrToVal(health.Path) != "/health" {
+		t.Errorf("unexpected port or path, port: %v, path: %v", health.Port, health.Path)
+	}
+	if cvt.PtrToVal(health.Matcher) != "12" {
+		t.Errorf("grpc code should take precedence, got: %s", cvt.PtrToVal(health.Matcher))
+	}
+
+	tg.Matcher.GrpcCode = nil
+	if matcher := cvt.PtrToVal(tg.GetHealthCheck().Matcher); matcher != "200" {
+		t.Errorf("http code should be used without grpc code, got: %s", matcher)
+	}
+}
+
+func TestAwsTargetHealthGetCloudID(t *testing.T) {
+	target := AwsTargetHealth{TargetHealthDescription: &elbv2.TargetHealthDescription{
+		Target: &elbv2.TargetDescription{Id: cvt.ValToPtr("i-123"), Port: cvt.ValToPtr(int64(8080))},
+	}}
+	if id := target.GetCloudID(); id != "i-123-8080" {
+		t.Errorf("unexpected cloud id: %s", id)
+	}
+
+	empty := AwsTargetHealth{TargetHealthDescription: &elbv2.TargetHealthDescription{}}
+	if empty.GetPort() != 0 || empty.GetZone() != "" || empty.GetState() != "" {
+		t.Errorf("target without description should return zero values")
+	}
+}
+
+func TestAwsRuleConditionAndTargetGroup(t *testing.T) {
+	rule := AwsRule{Rule: &elbv2.Rule{
+		Priority: cvt.ValToPtr("10"),
+		Conditions: []*elbv2.RuleCondition{
+			{Field: cvt.ValToPtr("host-header"), Values: []*string{cvt.ValToPtr("a.com"), cvt.ValToPtr("b.com")}},
+			{Field: cvt.ValToPtr("path-pattern"), Values: []*string{cvt.ValToPtr("/api/*")}},
+		},
+		Actions: []*elbv2.Action{
+			{Type: cvt.ValToPtr(elbv2.ActionTypeEnumAuthenticateOidc)},
+			{
+				Type: cvt.ValToPtr(elbv2.ActionTypeEnumForward),
+				ForwardConfig: &elbv2.ForwardActionConfig{TargetGroups: []*elbv2.TargetGroupTuple{
+					{TargetGroupArn: cvt.ValToPtr("tg-1")}, {TargetGroupArn: cvt.ValToPtr("tg-2")},
+				}},
+			},
+		},
+	}}
+
+	if rule.GetName() != "10" || rule.IsDefaultRule() {
+		t.Errorf("unexpected name or default flag, name: %s", rule.GetName())
+	}
+	if rule.GetDomain() != "a.com,b.com" || rule.GetURL() != "/api/*" {
+		t.Errorf("unexpected domain or url, domain: %s, url: %s", rule.GetDomain(), rule.GetURL())
+	}
+	if tgID := rule.GetCloudTargetGroupID(); tgID != "tg-1" {
+		t.Errorf("first forward target group should be used, got: %s", tgID)
+	}
+
+	redirect := AwsRule{Rule: &elbv2.Rule{Actions: []*elbv2.Action{{Type: cvt.ValToPtr(elbv2.ActionTypeEnumRedirect)}}}}
+	if tgID := redirect.GetCloudTargetGroupID(); tgID != "" {
+		t.Errorf("redirect rule should not have target group, got: %s", tgID)
+	}
+}
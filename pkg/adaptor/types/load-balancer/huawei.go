@@ -0,0 +1,375 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3/model"
+)
+
+const (
+	// HuaWeiLBDescribeMax 按ID查询负载均衡时单次最多指定的ID数量
+	HuaWeiLBDescribeMax = 100
+	// HuaWeiLBPageLimitMax elb v3 分页查询单页最大数量
+	HuaWeiLBPageLimitMax = 2000
+)
+
+// HuaWeiMemberOnlineStatus 后端服务器健康检查正常
+const HuaWeiMemberOnlineStatus = "ONLINE"
+
+func validateHuaWeiPage(page *core.HuaWeiPage) error {
+	if page == nil || page.Limit == nil {
+		return nil
+	}
+	if *page.Limit < 1 || *page.Limit > HuaWeiLBPageLimitMax {
+		return fmt.Errorf("huawei lb page limit should >= 1 and <= %d", HuaWeiLBPageLimitMax)
+	}
+	return nil
+}
+
+// -------------------------- List Load Balancer --------------------------
+
+// HuaWeiListOption defines options to list huawei load balancers.
+type HuaWeiListOption struct {
+	Region   string           `json:"region" validate:"required"`
+	CloudIDs []string         `json:"cloud_ids" validate:"omitempty,max=100"`
+	Page     *core.HuaWeiPage `json:"page" validate:"omitempty"`
+}
+
+// Validate huawei load balancer list option.
+func (opt HuaWeiListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return validateHuaWeiPage(opt.Page)
+}
+
+// HuaWeiListResult defines huawei list load balancer result.
+type HuaWeiListResult struct {
+	NextMarker *string              `json:"next_marker,omitempty"`
+	Details    []HuaWeiLoadBalancer `json:"details"`
+}
+
+// HuaWeiLoadBalancer huawei elb load balancer.
+type HuaWeiLoadBalancer struct {
+	model.LoadBalancer
+}
+
+// GetCloudID get cloud id
+func (lb HuaWeiLoadBalancer) GetCloudID() string {
+	return lb.Id
+}
+
+// GetName 返回负载均衡名称
+func (lb HuaWeiLoadBalancer) GetName() string {
+	return lb.Name
+}
+
+// GetLBType 绑定了公网IP的负载均衡为公网类型，转换为与腾讯云一致的 lb_type 取值
+func (lb HuaWeiLoadBalancer) GetLBType() TCloudLoadBalancerType {
+	if len(lb.Eips) != 0 || len(lb.Publicips) != 0 {
+		return OpenLoadBalancerType
+	}
+	return InternalLoadBalancerType
+}
+
+// GetIPVersion 返回ip版本信息，开启IPv6的负载均衡为双栈
+func (lb HuaWeiLoadBalancer) GetIPVersion() enumor.IPAddressType {
+	if len(lb.Ipv6VipAddress) != 0 {
+		return enumor.Ipv6DualStack
+	}
+	return enumor.Ipv4
+}
+
+// GetZones 返回负载均衡所在的可用区
+func (lb HuaWeiLoadBalancer) GetZones() []string {
+	return lb.AvailabilityZoneList
+}
+
+// GetCloudSubnetIDs 返回负载均衡的后端子网，即VPC子网ID，与子网表中的 cloud_id 对应
+func (lb HuaWeiLoadBalancer) GetCloudSubnetIDs() []string {
+	return lb.ElbVirsubnetIds
+}
+
+// GetAddresses 返回负载均衡的内网IPv4、公网IPv4及IPv6地址
+func (lb HuaWeiLoadBalancer) GetAddresses() (privateIPv4, publicIPv4, ipv6 []string) {
+	privateIPv4 = make([]string, 0, 1)
+	publicIPv4 = make([]string, 0, len(lb.Eips))
+	ipv6 = make([]string, 0, 1)
+
+	if len(lb.VipAddress) != 0 {
+		privateIPv4 = append(privateIPv4, lb.VipAddress)
+	}
+	if len(lb.Ipv6VipAddress) != 0 {
+		ipv6 = append(ipv6, lb.Ipv6VipAddress)
+	}
+
+	exists := make(map[string]struct{})
+	for _, eip := range lb.Eips {
+		addr := cvt.PtrToVal(eip.EipAddress)
+		if len(addr) == 0 || cvt.PtrToVal(eip.IpVersion) == 6 {
+			continue
+		}
+		if _, ok := exists[addr]; !ok {
+			exists[addr] = struct{}{}
+			publicIPv4 = append(publicIPv4, addr)
+		}
+	}
+	for _, ip := range lb.Publicips {
+		if len(ip.PublicipAddress) == 0 || ip.IpVersion == 6 {
+			continue
+		}
+		if _, ok := exists[ip.PublicipAddress]; !ok {
+			exists[ip.PublicipAddress] = struct{}{}
+			publicIPv4 = append(publicIPv4, ip.PublicipAddress)
+		}
+	}
+
+	return privateIPv4, publicIPv4, ipv6
+}
+
+// GetStatus 返回负载均衡状态
+func (lb HuaWeiLoadBalancer) GetStatus() string {
+	return lb.ProvisioningStatus
+}
+
+// GetTagMap ...
+func (lb HuaWeiLoadBalancer) GetTagMap() apicore.TagMap {
+	if len(lb.Tags) == 0 {
+		return nil
+	}
+	tagMap := make(apicore.TagMap, len(lb.Tags))
+	for _, tag := range lb.Tags {
+		tagMap.Set(cvt.PtrToVal(tag.Key), cvt.PtrToVal(tag.Value))
+	}
+	return tagMap
+}
+
+// GetEipIDs 返回绑定的弹性公网IP的ID
+func (lb HuaWeiLoadBalancer) GetEipIDs() []string {
+	ids := make([]string, 0, len(lb.Eips))
+	for _, eip := range lb.Eips {
+		if id := cvt.PtrToVal(eip.EipId); len(id) != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// -------------------------- List Listener --------------------------
+
+// HuaWeiListListenersOption defines options to list huawei listeners.
+type HuaWeiListListenersOption struct {
+	Region         string   `json:"region" validate:"required"`
+	LoadBalancerID string   `json:"load_balancer_id" validate:"required_without=CloudIDs"`
+	CloudIDs       []string `json:"cloud_ids" validate:"omitempty,max=100"`
+}
+
+// Validate ...
+func (opt HuaWeiListListenersOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiListener huawei elb listener.
+type HuaWeiListener struct {
+	model.Listener
+}
+
+// GetCloudID get cloud id
+func (l HuaWeiListener) GetCloudID() string {
+	return l.Id
+}
+
+// GetProtocol ...
+func (l HuaWeiListener) GetProtocol() enumor.ProtocolType {
+	return enumor.ProtocolType(l.Protocol)
+}
+
+// GetName 华为云监听器名称可以为空，为空时使用 协议:端口 作为名称
+func (l HuaWeiListener) GetName() string {
+	if len(l.Name) != 0 {
+		return l.Name
+	}
+	return fmt.Sprintf("%s:%d", l.Protocol, l.ProtocolPort)
+}
+
+// GetCertificateIDs 返回监听器绑定的服务器证书及SNI证书
+func (l HuaWeiListener) GetCertificateIDs() []string {
+	ids := make([]string, 0, len(l.SniContainerRefs)+1)
+	if len(l.DefaultTlsContainerRef) != 0 {
+		ids = append(ids, l.DefaultTlsContainerRef)
+	}
+	return append(ids, l.SniContainerRefs...)
+}
+
+func (l HuaWeiListener) String() string {
+	return fmt.Sprintf("{id:%s,protocol:%s,port:%d}", l.Id, l.Protocol, l.ProtocolPort)
+}
+
+// -------------------------- List Pool --------------------------
+
+// HuaWeiListPoolOption defines options to list huawei backend server groups.
+type HuaWeiListPoolOption struct {
+	Region         string   `json:"region" validate:"required"`
+	LoadBalancerID string   `json:"load_balancer_id" validate:"omitempty"`
+	ListenerID     string   `json:"listener_id" validate:"omitempty"`
+	CloudIDs       []string `json:"cloud_ids" validate:"omitempty,max=100"`
+}
+
+// Validate ...
+func (opt HuaWeiListPoolOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiPool huawei elb backend server group.
+type HuaWeiPool struct {
+	model.Pool
+}
+
+// GetCloudID get cloud id
+func (p HuaWeiPool) GetCloudID() string {
+	return p.Id
+}
+
+// GetName 华为云后端服务器组名称可以为空，为空时使用ID作为名称
+func (p HuaWeiPool) GetName() string {
+	if len(p.Name) != 0 {
+		return p.Name
+	}
+	return p.Id
+}
+
+// GetProtocol ...
+func (p HuaWeiPool) GetProtocol() enumor.ProtocolType {
+	return enumor.ProtocolType(p.Protocol)
+}
+
+// GetCloudListenerIDs 后端服务器组关联的监听器ID
+func (p HuaWeiPool) GetCloudListenerIDs() []string {
+	ids := make([]string, 0, len(p.Listeners))
+	for _, one := range p.Listeners {
+		ids = append(ids, one.Id)
+	}
+	return ids
+}
+
+// GetCloudLoadBalancerIDs 后端服务器组关联的负载均衡ID
+func (p HuaWeiPool) GetCloudLoadBalancerIDs() []string {
+	ids := make([]string, 0, len(p.Loadbalancers))
+	for _, one := range p.Loadbalancers {
+		ids = append(ids, cvt.PtrToVal(one.Id))
+	}
+	return ids
+}
+
+func (p HuaWeiPool) String() string {
+	return fmt.Sprintf("{id:%s,protocol:%s,vpc:%s}", p.Id, p.Protocol, p.VpcId)
+}
+
+// -------------------------- List Member --------------------------
+
+// HuaWeiListMemberOption defines options to list huawei backend servers of pool.
+type HuaWeiListMemberOption struct {
+	Region string `json:"region" validate:"required"`
+	PoolID string `json:"pool_id" validate:"required"`
+}
+
+// Validate ...
+func (opt HuaWeiListMemberOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiMember huawei elb backend server.
+type HuaWeiMember struct {
+	PoolID string `json:"pool_id"`
+	model.Member
+}
+
+// GetCloudID get cloud id
+func (m HuaWeiMember) GetCloudID() string {
+	return m.Id
+}
+
+// IsInstanceMember 后端服务器是否为云主机，IP类型后端服务器没有实例ID
+func (m HuaWeiMember) IsInstanceMember() bool {
+	return len(cvt.PtrToVal(m.InstanceId)) != 0
+}
+
+// GetCloudInstID 云主机类型后端服务器返回云主机ID，IP类型后端服务器返回IP地址
+func (m HuaWeiMember) GetCloudInstID() string {
+	if m.IsInstanceMember() {
+		return cvt.PtrToVal(m.InstanceId)
+	}
+	return m.Address
+}
+
+// GetPort ...
+func (m HuaWeiMember) GetPort() int64 {
+	return int64(m.ProtocolPort)
+}
+
+// GetWeight ...
+func (m HuaWeiMember) GetWeight() int64 {
+	return int64(m.Weight)
+}
+
+// IsHealthy 后端服务器在所有监听器下均健康检查正常
+func (m HuaWeiMember) IsHealthy() bool {
+	if len(m.Status) == 0 {
+		return m.OperatingStatus == HuaWeiMemberOnlineStatus
+	}
+	for _, status := range m.Status {
+		if status.OperatingStatus != HuaWeiMemberOnlineStatus {
+			return false
+		}
+	}
+	return true
+}
+
+// -------------------------- List Health Monitor --------------------------
+
+// HuaWeiListHealthMonitorOption defines options to list huawei health monitors.
+type HuaWeiListHealthMonitorOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=100"`
+}
+
+// Validate ...
+func (opt HuaWeiListHealthMonitorOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiHealthMonitor huawei elb health monitor.
+type HuaWeiHealthMonitor struct {
+	model.HealthMonitor
+}
+
+// GetCloudID get cloud id
+func (h HuaWeiHealthMonitor) GetCloudID() string {
+	return h.Id
+}
@@ -0,0 +1,39 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resourceevent
+
+import "hcm/pkg/criteria/enumor"
+
+// awsResourceType CloudTrail 资源类型与hcm资源类型映射
+var awsResourceType = map[string]enumor.CloudResourceType{
+	"AWS::EC2::Instance":      enumor.CvmCloudResType,
+	"AWS::EC2::Volume":        enumor.DiskCloudResType,
+	"AWS::EC2::EIP":           enumor.EipCloudResType,
+	"AWS::EC2::SecurityGroup": enumor.SecurityGroupCloudResType,
+	"AWS::EC2::VPC":           enumor.VpcCloudResType,
+	"AWS::EC2::Subnet":        enumor.SubnetCloudResType,
+	"AWS::EC2::RouteTable":    enumor.RouteTableCloudResType,
+}
+
+// AwsResType 根据 CloudTrail 资源类型获取资源类型，不支持增量同步的资源返回false
+func AwsResType(cloudTrailType string) (enumor.CloudResourceType, bool) {
+	resType, ok := awsResourceType[cloudTrailType]
+	return resType, ok
+}
@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resourceevent
+
+import (
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// AzureActivityLogResp 活动日志查询返回结构
+type AzureActivityLogResp struct {
+	Value    []AzureActivityLog `json:"value"`
+	NextLink string             `json:"nextLink"`
+}
+
+// AzureActivityLog 活动日志
+type AzureActivityLog struct {
+	ResourceID        string              `json:"resourceId"`
+	ResourceGroupName string              `json:"resourceGroupName"`
+	ResourceType      AzureLocalizable    `json:"resourceType"`
+	OperationName     AzureLocalizable    `json:"operationName"`
+	Status            AzureLocalizable    `json:"status"`
+	EventTimestamp    string              `json:"eventTimestamp"`
+	SubscriptionID    string              `json:"subscriptionId"`
+	Authorization     *AzureAuthorization `json:"authorization"`
+}
+
+// AzureLocalizable 活动日志中可本地化的字段
+type AzureLocalizable struct {
+	Value          string `json:"value"`
+	LocalizedValue string `json:"localizedValue"`
+}
+
+// AzureAuthorization 活动日志鉴权信息
+type AzureAuthorization struct {
+	Action string `json:"action"`
+	Scope  string `json:"scope"`
+}
+
+// azureResourceType 活动日志资源类型与hcm资源类型映射，key为小写
+var azureResourceType = map[string]enumor.CloudResourceType{
+	"microsoft.compute/virtualmachines":       enumor.CvmCloudResType,
+	"microsoft.compute/disks":                 enumor.DiskCloudResType,
+	"microsoft.network/publicipaddresses":     enumor.EipCloudResType,
+	"microsoft.network/networksecuritygroups": enumor.SecurityGroupCloudResType,
+	"microsoft.network/virtualnetworks":       enumor.VpcCloudResType,
+	"microsoft.network/routetables":           enumor.RouteTableCloudResType,
+	"microsoft.network/networkinterfaces":     enumor.NetworkInterfaceCloudResType,
+}
+
+// AzureResType 根据活动日志资源类型获取资源类型，不支持增量同步的资源返回false
+func AzureResType(resourceType string) (enumor.CloudResourceType, bool) {
+	resType, ok := azureResourceType[strings.ToLower(resourceType)]
+	return resType, ok
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourceevent 云上资源变更事件，用于增量同步
+package resourceevent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// MaxLookupWindow 单次查询资源变更事件的最大时间跨度
+const MaxLookupWindow = 24 * time.Hour
+
+// ListOption 资源变更事件查询参数，时间区间为左闭右开
+type ListOption struct {
+	// Region 查询地域，Aws CloudTrail 按地域记录事件，需要指定
+	Region    string    `json:"region"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// Validate list option.
+func (opt ListOption) Validate() error {
+	if opt.StartTime.IsZero() || opt.EndTime.IsZero() {
+		return errors.New("start_time and end_time are required")
+	}
+
+	if !opt.EndTime.After(opt.StartTime) {
+		return errors.New("end_time should be after start_time")
+	}
+
+	if opt.EndTime.Sub(opt.StartTime) > MaxLookupWindow {
+		return fmt.Errorf("time range should <= %s", MaxLookupWindow)
+	}
+
+	return nil
+}
+
+// ChangeEvent 资源变更事件，一个云上操作涉及多个资源时会拆分为多个事件
+type ChangeEvent struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	CloudID string                   `json:"cloud_id"`
+	// Region 资源所在地域，Azure 为空
+	Region string `json:"region"`
+	// ResourceGroupName 资源所在资源组，仅 Azure 有效
+	ResourceGroupName string    `json:"resource_group_name"`
+	EventName         string    `json:"event_name"`
+	EventTime         time.Time `json:"event_time"`
+}
@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resourceevent
+
+import (
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// TCloudLookUpEventsResp 操作审计 LookUpEvents 返回结构
+type TCloudLookUpEventsResp struct {
+	Response struct {
+		ListOver  bool          `json:"ListOver"`
+		NextToken *uint64       `json:"NextToken"`
+		Events    []TCloudEvent `json:"Events"`
+		RequestId string        `json:"RequestId"`
+	} `json:"Response"`
+}
+
+// TCloudEvent 操作审计事件
+type TCloudEvent struct {
+	EventId     string `json:"EventId"`
+	EventName   string `json:"EventName"`
+	EventRegion string `json:"EventRegion"`
+	EventSource string `json:"EventSource"`
+	// EventTime 事件时间，unix 秒级时间戳
+	EventTime string              `json:"EventTime"`
+	ErrorCode int64               `json:"ErrorCode"`
+	Resources TCloudEventResource `json:"Resources"`
+}
+
+// TCloudEventResource 操作审计事件涉及的资源
+type TCloudEventResource struct {
+	ResourceType string `json:"ResourceType"`
+	// ResourceName 资源ID，多个资源之间以逗号分隔
+	ResourceName string `json:"ResourceName"`
+}
+
+// tcloudCloudIDPrefix 腾讯云资源ID前缀，用于从审计事件中识别资源类型
+var tcloudCloudIDPrefix = map[string]enumor.CloudResourceType{
+	"ins-":    enumor.CvmCloudResType,
+	"disk-":   enumor.DiskCloudResType,
+	"eip-":    enumor.EipCloudResType,
+	"sg-":     enumor.SecurityGroupCloudResType,
+	"vpc-":    enumor.VpcCloudResType,
+	"subnet-": enumor.SubnetCloudResType,
+	"rtb-":    enumor.RouteTableCloudResType,
+	"lb-":     enumor.LoadBalancerCloudResType,
+}
+
+// TCloudResType 根据资源ID前缀获取资源类型，不支持增量同步的资源返回false
+func TCloudResType(cloudID string) (enumor.CloudResourceType, bool) {
+	for prefix, resType := range tcloudCloudIDPrefix {
+		if strings.HasPrefix(cloudID, prefix) {
+			return resType, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines cloud resource tag adaptor types.
+package resourcetag
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+const (
+	// MaxTagResourceCount 单次标签操作的最大资源数量
+	MaxTagResourceCount = 20
+	// MaxTagCount 单次标签操作的最大标签数量，取各云厂商单次接口调用上限的最小值
+	MaxTagCount = 10
+)
+
+// TagResOption defines add or update tags of cloud resources option.
+type TagResOption struct {
+	// Region 资源所在地域，azure不需要
+	Region    string                   `json:"region" validate:"omitempty"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []TagResource            `json:"resources" validate:"required,min=1,max=20,dive"`
+	Tags      []core.TagPair           `json:"tags" validate:"required,min=1,max=10"`
+}
+
+// Validate TagResOption.
+func (opt TagResOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// UnTagResOption defines remove tags of cloud resources option.
+type UnTagResOption struct {
+	// Region 资源所在地域，azure不需要
+	Region    string                   `json:"region" validate:"omitempty"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []TagResource            `json:"resources" validate:"required,min=1,max=20,dive"`
+	TagKeys   []string                 `json:"tag_keys" validate:"required,min=1,max=10"`
+}
+
+// Validate UnTagResOption.
+func (opt UnTagResOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TagResource defines cloud resource to be tagged.
+type TagResource struct {
+	CloudID string `json:"cloud_id" validate:"required"`
+	// Name 资源名称，gcp通过名称操作资源
+	Name string `json:"name" validate:"omitempty"`
+	// Zone 资源所在可用区，gcp主机、硬盘需要
+	Zone string `json:"zone" validate:"omitempty"`
+}
+
+// CloudIDs return cloud ids of the resources.
+func CloudIDs(resources []TagResource) []string {
+	ids := make([]string, 0, len(resources))
+	for _, one := range resources {
+		ids = append(ids, one.CloudID)
+	}
+	return ids
+}
@@ -32,6 +32,8 @@ type BaseSecret struct {
 	CloudSecretKey string `json:"cloud_secret_key"`
 	// CloudAccountID is the account id to do credential.
 	CloudAccountID string `json:"cloud_account_id"`
+	// CloudSessionToken is the session token of temporary credential, only set in role credential mode.
+	CloudSessionToken string `json:"cloud_session_token,omitempty"`
 }
 
 // Validate BaseSecret.
@@ -63,7 +65,9 @@ type AzureCredential struct {
 	CloudTenantID        string `json:"cloud_tenant_id" validate:"required"`
 	CloudSubscriptionID  string `json:"cloud_subscription_id" validate:"required"`
 	CloudApplicationID   string `json:"cloud_application_id" validate:"required"`
-	CloudClientSecretKey string `json:"cloud_client_secret_key" validate:"required"`
+	CloudClientSecretKey string `json:"cloud_client_secret_key" validate:"required_without=CloudFederatedTokenFile"`
+	// CloudFederatedTokenFile is the federated token file of workload identity, only set in role credential mode.
+	CloudFederatedTokenFile string `json:"cloud_federated_token_file,omitempty"`
 }
 
 // Validate AzureCredential
@@ -20,6 +20,7 @@
 package adtysubnet
 
 import (
+	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/errf"
 )
 
@@ -65,14 +66,15 @@ func (s BaseSubnetUpdateData) Validate() error {
 type Subnet[T SubnetExtension] struct {
 	// TODO: gcp 添加 vpcSelfLink字段，不要和 CloudVpcID 字段混用
 	// CloudVpcID gcp 该字段为 self_link
-	CloudVpcID string   `json:"cloud_vpc_id"`
-	CloudID    string   `json:"cloud_id"`
-	Name       string   `json:"name"`
-	Region     string   `json:"region"`
-	Ipv4Cidr   []string `json:"ipv4_cidr,omitempty"`
-	Ipv6Cidr   []string `json:"ipv6_cidr,omitempty"`
-	Memo       *string  `json:"memo,omitempty"`
-	Extension  *T       `json:"extension"`
+	CloudVpcID string      `json:"cloud_vpc_id"`
+	CloudID    string      `json:"cloud_id"`
+	Name       string      `json:"name"`
+	Region     string      `json:"region"`
+	Ipv4Cidr   []string    `json:"ipv4_cidr,omitempty"`
+	Ipv6Cidr   []string    `json:"ipv6_cidr,omitempty"`
+	Memo       *string     `json:"memo,omitempty"`
+	Tags       core.TagMap `json:"tags,omitempty"`
+	Extension  *T          `json:"extension"`
 }
 
 // SubnetExtension defines subnet extensional info.
@@ -19,7 +19,10 @@
 
 package adtysubnet
 
-import "hcm/pkg/criteria/validator"
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/validator"
+)
 
 // TCloudSubnetCreateExt defines tencent cloud create subnet extensional info.
 type TCloudSubnetCreateExt struct {
@@ -90,3 +93,8 @@ type TCloudSubnet Subnet[TCloudSubnetExtension]
 func (vpc TCloudSubnet) GetCloudID() string {
 	return vpc.CloudID
 }
+
+// GetTagMap ...
+func (vpc TCloudSubnet) GetTagMap() core.TagMap {
+	return vpc.Tags
+}
@@ -22,6 +22,7 @@ package types
 import (
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/subnet"
+	apicore "hcm/pkg/api/core"
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -271,11 +272,12 @@ type HuaWeiVpcListResult struct {
 
 // Vpc defines vpc struct.
 type Vpc[T VpcExtension] struct {
-	CloudID   string  `json:"cloud_id"`
-	Name      string  `json:"name"`
-	Region    string  `json:"region"`
-	Memo      *string `json:"memo,omitempty"`
-	Extension *T      `json:"extension"`
+	CloudID   string         `json:"cloud_id"`
+	Name      string         `json:"name"`
+	Region    string         `json:"region"`
+	Memo      *string        `json:"memo,omitempty"`
+	Tags      apicore.TagMap `json:"tags,omitempty"`
+	Extension *T             `json:"extension"`
 }
 
 // AzureVpcExtension defines azure vpc extensional info.
@@ -300,6 +302,11 @@ func (vpc TCloudVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTagMap ...
+func (vpc TCloudVpc) GetTagMap() apicore.TagMap {
+	return vpc.Tags
+}
+
 // AwsVpc defines aws vpc.
 type AwsVpc Vpc[cloud.AwsVpcExtension]
 
@@ -308,6 +315,11 @@ func (vpc AwsVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTagMap ...
+func (vpc AwsVpc) GetTagMap() apicore.TagMap {
+	return vpc.Tags
+}
+
 // GcpVpc defines gcp vpc.
 type GcpVpc Vpc[cloud.GcpVpcExtension]
 
@@ -324,6 +336,11 @@ func (vpc AzureVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTagMap ...
+func (vpc AzureVpc) GetTagMap() apicore.TagMap {
+	return vpc.Tags
+}
+
 // HuaWeiVpc defines huawei vpc.
 type HuaWeiVpc Vpc[cloud.HuaWeiVpcExtension]
 
@@ -332,6 +349,11 @@ func (vpc HuaWeiVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTagMap ...
+func (vpc HuaWeiVpc) GetTagMap() apicore.TagMap {
+	return vpc.Tags
+}
+
 // VpcUsage define vpc usage.
 type VpcUsage struct {
 	ID           *string  `json:"id"`
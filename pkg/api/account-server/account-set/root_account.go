@@ -176,6 +176,22 @@ func (req *KaopuRootAccountExtensionUpdateReq) Validate() error {
 	return nil
 }
 
+// TCloudRootAccountExtensionUpdateReq ...
+type TCloudRootAccountExtensionUpdateReq struct {
+	CloudSubAccountID string `json:"cloud_sub_account_id" validate:"required"`
+	CloudSecretID     string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey    string `json:"cloud_secret_key" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *TCloudRootAccountExtensionUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GcpAccountInfoBySecretReq ...
 type GcpAccountInfoBySecretReq struct {
 	*cloud.GcpSecret `json:",inline" validate:"required"`
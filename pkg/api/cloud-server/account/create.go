@@ -37,6 +37,9 @@ type TCloudAccountExtensionCreateReq struct {
 	CloudSubAccountID  string `json:"cloud_sub_account_id" validate:"required"`
 	CloudSecretID      string `json:"cloud_secret_id" validate:"omitempty"`
 	CloudSecretKey     string `json:"cloud_secret_key" validate:"omitempty"`
+
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode" validate:"omitempty"`
+	CloudRoleArn   string                       `json:"cloud_role_arn" validate:"omitempty"`
 }
 
 // Validate ...
@@ -45,6 +48,10 @@ func (req *TCloudAccountExtensionCreateReq) Validate(accountType enumor.AccountT
 		return err
 	}
 
+	if err := validateRoleCredential(req.CredentialMode, req.CloudRoleArn); err != nil {
+		return err
+	}
+
 	// 登记账号密钥可为空，其他类型则必填
 	if accountType != enumor.RegistrationAccount && !req.IsFull() {
 		return secretEmptyError
@@ -55,6 +62,10 @@ func (req *TCloudAccountExtensionCreateReq) Validate(accountType enumor.AccountT
 
 // IsFull 对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *TCloudAccountExtensionCreateReq) IsFull() bool {
+	// 角色模式下不需要密钥
+	if req.CredentialMode.IsRole() {
+		return req.CloudRoleArn != ""
+	}
 	return req.CloudSecretID != "" && req.CloudSecretKey != ""
 }
 
@@ -64,6 +75,10 @@ type AwsAccountExtensionCreateReq struct {
 	CloudIamUsername string `json:"cloud_iam_username" validate:"required"`
 	CloudSecretID    string `json:"cloud_secret_id" validate:"omitempty"`
 	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
+
+	CredentialMode  enumor.AccountCredentialMode `json:"credential_mode" validate:"omitempty"`
+	CloudRoleArn    string                       `json:"cloud_role_arn" validate:"omitempty"`
+	CloudExternalID string                       `json:"cloud_external_id" validate:"omitempty"`
 }
 
 // Validate ...
@@ -72,6 +87,10 @@ func (req *AwsAccountExtensionCreateReq) Validate(accountType enumor.AccountType
 		return err
 	}
 
+	if err := validateRoleCredential(req.CredentialMode, req.CloudRoleArn); err != nil {
+		return err
+	}
+
 	// 登记账号密钥可为空，其他类型则必填
 	if accountType != enumor.RegistrationAccount && !req.IsFull() {
 		return secretEmptyError
@@ -82,6 +101,10 @@ func (req *AwsAccountExtensionCreateReq) Validate(accountType enumor.AccountType
 
 // IsFull 对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *AwsAccountExtensionCreateReq) IsFull() bool {
+	// 角色模式下不需要密钥
+	if req.CredentialMode.IsRole() {
+		return req.CloudRoleArn != ""
+	}
 	return req.CloudSecretID != "" && req.CloudSecretKey != ""
 }
 
@@ -159,6 +182,8 @@ type AzureAccountExtensionCreateReq struct {
 	CloudApplicationID    string `json:"cloud_application_id" validate:"omitempty"`
 	CloudApplicationName  string `json:"cloud_application_name" validate:"omitempty"`
 	CloudClientSecretKey  string `json:"cloud_client_secret_key" validate:"omitempty"`
+
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode" validate:"omitempty"`
 }
 
 // Validate ...
@@ -167,6 +192,12 @@ func (req *AzureAccountExtensionCreateReq) Validate(accountType enumor.AccountTy
 		return err
 	}
 
+	if len(req.CredentialMode) != 0 {
+		if err := req.CredentialMode.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// 登记账号密钥可为空，其他类型则必填
 	if accountType != enumor.RegistrationAccount && !req.IsFull() {
 		return errors.New("ApplicationID/ApplicationName/SecretID/SecretKey can not be empty")
@@ -181,11 +212,32 @@ func (req *AzureAccountExtensionCreateReq) Validate(accountType enumor.AccountTy
 
 // IsFull  对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *AzureAccountExtensionCreateReq) IsFull() bool {
+	// 角色模式下使用工作负载身份，不需要应用密钥
+	if req.CredentialMode.IsRole() {
+		return req.CloudApplicationID != "" && req.CloudApplicationName != ""
+	}
 	return req.CloudClientSecretKey != "" &&
 		req.CloudApplicationID != "" &&
 		req.CloudApplicationName != ""
 }
 
+// validateRoleCredential 校验凭证模式，角色模式下必须提供扮演的角色
+func validateRoleCredential(mode enumor.AccountCredentialMode, roleArn string) error {
+	if len(mode) == 0 {
+		return nil
+	}
+
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+
+	if mode.IsRole() && len(roleArn) == 0 {
+		return errors.New("cloud_role_arn is required in role credential mode")
+	}
+
+	return nil
+}
+
 // AccountCommonInfoCreateReq ...
 type AccountCommonInfoCreateReq struct {
 	Vendor   enumor.Vendor          `json:"vendor" validate:"required"`
@@ -21,6 +21,7 @@ package account
 
 import (
 	"hcm/pkg/api/core"
+	hcsync "hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/validator"
 )
 
@@ -36,3 +37,25 @@ type TCloudResCondSyncReq struct {
 func (r *TCloudResCondSyncReq) Validate() error {
 	return validator.Validate.Struct(r)
 }
+
+// ResSyncWithOptionReq 指定删除选项同步资源的请求
+type ResSyncWithOptionReq struct {
+	// Regions 同步的地域，azure 为资源组名称
+	Regions []string `json:"regions" validate:"required,min=1,max=5"`
+}
+
+// Validate ...
+func (r *ResSyncWithOptionReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// ResSyncPreviewResult 资源同步预览结果
+type ResSyncPreviewResult struct {
+	Details []ResSyncRegionPlan `json:"details"`
+}
+
+// ResSyncRegionPlan 单个地域的资源同步计划
+type ResSyncRegionPlan struct {
+	Region                 string `json:"region"`
+	*hcsync.SyncPlanResult `json:",inline"`
+}
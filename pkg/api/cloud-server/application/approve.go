@@ -20,6 +20,9 @@
 package application
 
 import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 )
 
@@ -35,3 +38,28 @@ type ItsmApproveResult struct {
 func (req *ItsmApproveResult) Validate() error {
 	return validator.Validate.Struct(req)
 }
+
+// ApprovalActionReq 内置审批引擎的审批操作请求
+type ApprovalActionReq struct {
+	Action enumor.ApprovalAction `json:"action" validate:"required"`
+	// TransferTo 转派的目标审批人，仅转派时需要
+	TransferTo []string `json:"transfer_to" validate:"omitempty"`
+	Memo       string   `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (req *ApprovalActionReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Action.Validate(); err != nil {
+		return err
+	}
+
+	if req.Action == enumor.ApprovalActionTransfer && len(req.TransferTo) == 0 {
+		return errors.New("transfer_to is required when transfer approval")
+	}
+
+	return nil
+}
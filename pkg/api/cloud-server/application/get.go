@@ -34,6 +34,7 @@ type ApplicationGetResp struct {
 	Applicant      string                   `json:"applicant"`
 	Content        string                   `json:"content"`
 	DeliveryDetail string                   `json:"delivery_detail"`
+	ApprovalDetail string                   `json:"approval_detail"`
 	Memo           *string                  `json:"memo"`
 	core.Revision  `json:",inline"`
 
@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package assign
+
+import (
+	"errors"
+
+	corebizassign "hcm/pkg/api/core/cloud/biz-assign-rule"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// BizAssignRuleCreateReq create biz assign rule request.
+type BizAssignRuleCreateReq struct {
+	Name    string                   `json:"name" validate:"required,max=255"`
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	// Rule 资源匹配规则，支持资源表字段及"tags.{key}"形式的资源标签
+	Rule    *filter.Expression `json:"rule" validate:"required"`
+	BkBizID int64              `json:"bk_biz_id" validate:"required,min=1"`
+	// Priority 优先级，值越小优先级越高
+	Priority uint32 `json:"priority" validate:"omitempty"`
+	// Enabled 是否启用，默认启用
+	Enabled *bool   `json:"enabled" validate:"omitempty"`
+	Memo    *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BizAssignRuleCreateReq.
+func (req *BizAssignRuleCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corebizassign.ValidateResType(req.ResType)
+}
+
+// BizAssignRuleUpdateReq update biz assign rule request, resource type of rule can not be updated.
+type BizAssignRuleUpdateReq struct {
+	Name     string             `json:"name" validate:"omitempty,max=255"`
+	Rule     *filter.Expression `json:"rule" validate:"omitempty"`
+	BkBizID  int64              `json:"bk_biz_id" validate:"omitempty,min=1"`
+	Priority *uint32            `json:"priority" validate:"omitempty"`
+	Enabled  *bool              `json:"enabled" validate:"omitempty"`
+	Memo     *string            `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BizAssignRuleUpdateReq.
+func (req *BizAssignRuleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && req.Rule == nil && req.BkBizID == 0 && req.Priority == nil && req.Enabled == nil &&
+		req.Memo == nil {
+		return errors.New("at least one field should be set")
+	}
+
+	return nil
+}
+
+// BizAssignRulePreviewReq preview unassigned resources matched by a rule not saved yet request.
+type BizAssignRulePreviewReq struct {
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Rule      *filter.Expression       `json:"rule" validate:"required"`
+	BkBizID   int64                    `json:"bk_biz_id" validate:"required,min=1"`
+	AccountID string                   `json:"account_id" validate:"omitempty"`
+}
+
+// Validate BizAssignRulePreviewReq.
+func (req *BizAssignRulePreviewReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corebizassign.ValidateResType(req.ResType)
+}
+
+// BizAssignRuleAccountReq restrict biz assign rule preview or apply to an account request.
+type BizAssignRuleAccountReq struct {
+	AccountID string `json:"account_id" validate:"omitempty"`
+}
+
+// Validate BizAssignRuleAccountReq.
+func (req *BizAssignRuleAccountReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// BizAssignRuleApplyReq apply biz assign rules request, all enabled rules are applied when rule ids is empty.
+type BizAssignRuleApplyReq struct {
+	AccountID string   `json:"account_id" validate:"omitempty"`
+	RuleIDs   []string `json:"rule_ids" validate:"omitempty,max=100"`
+}
+
+// Validate BizAssignRuleApplyReq.
+func (req *BizAssignRuleApplyReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
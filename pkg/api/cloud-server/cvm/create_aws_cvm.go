@@ -56,9 +56,12 @@ type AwsCvmCreateReq struct {
 	} `json:"data_disk" validate:"omitempty"`
 
 	// Note: aws是通过执行用户脚本添加密码的，可能有特殊字符会导致脚本执行失败，而且这是无法通过DryRun测试出来的
-	Password          string `json:"password" validate:"required"`
+	Password          string `json:"password" validate:"omitempty"`
 	ConfirmedPassword string `json:"confirmed_password" validate:"eqfield=Password"`
 
+	// CloudKeyPairID 登录使用的SSH密钥对云上ID，与 Password 二选一
+	CloudKeyPairID string `json:"cloud_key_pair_id" validate:"omitempty"`
+
 	RequiredCount int64 `json:"required_count" validate:"required,min=1,max=500"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
@@ -100,6 +103,11 @@ func (req *AwsCvmCreateReq) Validate(bizRequired bool) error {
 		return errors.New("data disk count should <= 23")
 	}
 
+	// 校验登录方式
+	if err := validateLoginSettings(req.Password, req.CloudKeyPairID); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -73,9 +73,12 @@ type AzureCvmCreateReq struct {
 	// https://learn.microsoft.com/en-us/azure/virtual-machines/linux/faq
 	// https://learn.microsoft.com/en-us/azure/virtual-machines/windows/faq
 	Username          string `json:"username" validate:"required,min=1,max=32"`
-	Password          string `json:"password" validate:"required"`
+	Password          string `json:"password" validate:"omitempty"`
 	ConfirmedPassword string `json:"confirmed_password" validate:"eqfield=Password"`
 
+	// CloudKeyPairID 登录使用的SSH密钥对云上ID，与 Password 二选一
+	CloudKeyPairID string `json:"cloud_key_pair_id" validate:"omitempty"`
+
 	RequiredCount int64 `json:"required_count" validate:"required,min=1,max=500"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
@@ -122,6 +125,11 @@ func (req *AzureCvmCreateReq) Validate(bizRequired bool) error {
 		}
 	}
 
+	// 校验登录方式
+	if err := validateLoginSettings(req.Password, req.CloudKeyPairID); err != nil {
+		return err
+	}
+
 	// TODO: 密码校验比较复杂，暂时不支持
 
 	return nil
@@ -68,9 +68,12 @@ type TCloudCvmCreateReq struct {
 		DiskCount  int64                      `json:"disk_count" validate:"required,min=1"`
 	} `json:"data_disk" validate:"omitempty,max=20"`
 
-	Password          string `json:"password" validate:"required"`
+	Password          string `json:"password" validate:"omitempty"`
 	ConfirmedPassword string `json:"confirmed_password" validate:"eqfield=Password"`
 
+	// CloudKeyPairID 登录使用的SSH密钥对云上ID，与 Password 二选一
+	CloudKeyPairID string `json:"cloud_key_pair_id" validate:"omitempty"`
+
 	InstanceChargeType typecvm.TCloudInstanceChargeType `json:"instance_charge_type" validate:"required"`
 
 	InstanceChargePaidPeriod int64 `json:"instance_charge_paid_period" validate:"required,min=1"`
@@ -135,11 +138,17 @@ func (req *TCloudCvmCreateReq) Validate(bizRequired bool) error {
 		)
 	}
 
-	// 校验密码是否符合要求
-	if err := req.validatePassword(); err != nil {
+	// 校验登录方式，使用密码登录时校验密码是否符合要求
+	if err := validateLoginSettings(req.Password, req.CloudKeyPairID); err != nil {
 		return err
 	}
 
+	if len(req.Password) != 0 {
+		if err := req.validatePassword(); err != nil {
+			return err
+		}
+	}
+
 	if req.PublicIPAssigned && req.InternetMaxBandwidthOut == 0 {
 		return errors.New("assign public ip, internet_max_bandwidth_out is required")
 	}
@@ -55,6 +55,19 @@ func (req *AssignCvmToBizReq) Validate() error {
 	return nil
 }
 
+// validateLoginSettings 校验主机登录方式，登录密码与SSH密钥对二选一
+func validateLoginSettings(password, cloudKeyPairID string) error {
+	if len(password) == 0 && len(cloudKeyPairID) == 0 {
+		return errors.New("password or cloud_key_pair_id is required")
+	}
+
+	if len(password) != 0 && len(cloudKeyPairID) != 0 {
+		return errors.New("password and cloud_key_pair_id can not be specified at the same time")
+	}
+
+	return nil
+}
+
 // BatchStartCvmReq batch start cvm req.
 type BatchStartCvmReq struct {
 	IDs []string `json:"ids" validate:"required"`
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot defines cloud-server disk snapshot api.
+package disksnapshot
+
+import (
+	"hcm/pkg/api/core"
+	rr "hcm/pkg/api/core/recycle-record"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// DiskSnapshotListReq ...
+type DiskSnapshotListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate ...
+func (req *DiskSnapshotListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotCreateReq 基于云盘创建快照，快照与源云盘属于同一账号、地域
+type DiskSnapshotCreateReq struct {
+	DiskID      string  `json:"disk_id" validate:"required"`
+	Name        string  `json:"name" validate:"required,max=60"`
+	Description *string `json:"description" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *DiskSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskFromSnapshotCreateReq 基于快照创建新云盘，仅 aws、azure、gcp 支持
+type DiskFromSnapshotCreateReq struct {
+	DiskName string `json:"disk_name" validate:"omitempty"`
+	Zone     string `json:"zone" validate:"omitempty"`
+	DiskType string `json:"disk_type" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *DiskFromSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotAssignReq ...
+type DiskSnapshotAssignReq struct {
+	IDs     []string `json:"snapshot_ids" validate:"required,min=1,max=100"`
+	BkBizID uint64   `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate ...
+func (req *DiskSnapshotAssignReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Recycle ------------------------
+
+// DiskSnapshotRecycleReq recycle disk snapshot request.
+type DiskSnapshotRecycleReq struct {
+	Infos []DiskSnapshotRecycleInfo `json:"infos" validate:"min=1,max=100"`
+}
+
+// DiskSnapshotRecycleInfo defines recycle one disk snapshot info.
+type DiskSnapshotRecycleInfo struct {
+	ID                             string `json:"id" validate:"required"`
+	*rr.DiskSnapshotRecycleOptions `json:",inline" validate:"omitempty"`
+}
+
+// Validate DiskSnapshotRecycleReq
+func (req *DiskSnapshotRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Recover ------------------------
+
+// DiskSnapshotRecoverReq recover disk snapshot request.
+type DiskSnapshotRecoverReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate DiskSnapshotRecoverReq
+func (req *DiskSnapshotRecoverReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Delete Recycled ------------------------
+
+// DiskSnapshotDeleteRecycleReq delete recycled disk snapshot request.
+type DiskSnapshotDeleteRecycleReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate DiskSnapshotDeleteRecycleReq
+func (req *DiskSnapshotDeleteRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
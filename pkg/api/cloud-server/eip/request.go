@@ -21,6 +21,7 @@ package eip
 
 import (
 	"hcm/pkg/api/core"
+	rr "hcm/pkg/api/core/recycle-record"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/runtime/filter"
 )
@@ -58,3 +59,45 @@ type AssociateReq struct {
 func (req *AssociateReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
+
+// -------------------------- Recycle ------------------------
+
+// EipRecycleReq recycle eip request.
+type EipRecycleReq struct {
+	Infos []EipRecycleInfo `json:"infos" validate:"min=1,max=100"`
+}
+
+// EipRecycleInfo defines recycle one eip info.
+type EipRecycleInfo struct {
+	ID                    string `json:"id" validate:"required"`
+	*rr.EipRecycleOptions `json:",inline" validate:"omitempty"`
+}
+
+// Validate EipRecycleReq
+func (req *EipRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Recover ------------------------
+
+// EipRecoverReq recover eip request.
+type EipRecoverReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate EipRecoverReq
+func (req *EipRecoverReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Delete Recycled ------------------------
+
+// EipDeleteRecycleReq delete recycled eip request.
+type EipDeleteRecycleReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate EipDeleteRecycleReq
+func (req *EipDeleteRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines cloud-server ssh key pair api.
+package keypair
+
+import (
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// KeyPairListReq ...
+type KeyPairListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate ...
+func (req *KeyPairListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// KeyPairCreateReq 创建或导入SSH密钥对，指定公钥时为导入，否则由云上生成密钥对，私钥仅在创建结果中返回一次
+type KeyPairCreateReq struct {
+	AccountID string  `json:"account_id" validate:"required"`
+	Region    string  `json:"region" validate:"required"`
+	Name      string  `json:"name" validate:"required,max=255"`
+	PublicKey *string `json:"public_key" validate:"omitempty"`
+	// ProjectID 密钥对所属项目，仅 tcloud 支持
+	ProjectID *int64 `json:"project_id" validate:"omitempty"`
+	// ResourceGroupName 资源组名称，azure 必填
+	ResourceGroupName string `json:"resource_group_name" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *KeyPairCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// KeyPairAssignReq ...
+type KeyPairAssignReq struct {
+	IDs     []string `json:"key_pair_ids" validate:"required,min=1,max=100"`
+	BkBizID uint64   `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate ...
+func (req *KeyPairAssignReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// KeyPairAssociateReq 绑定/解绑密钥对与主机，仅 tcloud、huawei 支持
+type KeyPairAssociateReq struct {
+	CvmID string `json:"cvm_id" validate:"required"`
+	// ForceStop 主机未关机时是否强制关机，仅 tcloud 支持
+	ForceStop *bool `json:"force_stop" validate:"omitempty"`
+	// Auth 主机运行中时的当前登录凭证，仅 huawei 支持
+	Auth *typekeypair.HuaWeiKeyPairAuth `json:"auth" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *KeyPairAssociateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -27,6 +27,7 @@ import (
 	"hcm/pkg/adaptor/types/load-balancer"
 	"hcm/pkg/api/core"
 	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	rr "hcm/pkg/api/core/recycle-record"
 	"hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
@@ -832,3 +833,45 @@ type ImportValidateReq struct {
 func (i *ImportValidateReq) Validate() error {
 	return validator.Validate.Struct(i)
 }
+
+// -------------------------- Recycle ------------------------
+
+// LoadBalancerRecycleReq recycle load balancer request.
+type LoadBalancerRecycleReq struct {
+	Infos []LoadBalancerRecycleInfo `json:"infos" validate:"min=1,max=100"`
+}
+
+// LoadBalancerRecycleInfo defines recycle one load balancer info.
+type LoadBalancerRecycleInfo struct {
+	ID                             string `json:"id" validate:"required"`
+	*rr.LoadBalancerRecycleOptions `json:",inline" validate:"omitempty"`
+}
+
+// Validate LoadBalancerRecycleReq
+func (req *LoadBalancerRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Recover ------------------------
+
+// LoadBalancerRecoverReq recover load balancer request.
+type LoadBalancerRecoverReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate LoadBalancerRecoverReq
+func (req *LoadBalancerRecoverReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Delete Recycled ------------------------
+
+// LoadBalancerDeleteRecycleReq delete recycled load balancer request.
+type LoadBalancerDeleteRecycleReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate LoadBalancerDeleteRecycleReq
+func (req *LoadBalancerDeleteRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
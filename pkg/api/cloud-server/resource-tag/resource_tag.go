@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package csrestag resource tag cloud server api.
+package csrestag
+
+import (
+	"hcm/pkg/api/core"
+	corerestag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// BatchAddReq define batch add or update tags of resources request.
+type BatchAddReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1,max=20"`
+	Tags    []core.TagPair           `json:"tags" validate:"required,min=1,max=10"`
+}
+
+// Validate BatchAddReq.
+func (req *BatchAddReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := corerestag.ValidateResType(req.ResType); err != nil {
+		return err
+	}
+
+	return corerestag.ValidateTags(req.Tags)
+}
+
+// BatchRemoveReq define batch remove tags of resources request.
+type BatchRemoveReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1,max=20"`
+	TagKeys []string                 `json:"tag_keys" validate:"required,min=1,max=10"`
+}
+
+// Validate BatchRemoveReq.
+func (req *BatchRemoveReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corerestag.ValidateResType(req.ResType)
+}
+
+// ListReq define list tags of resources request.
+type ListReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1,max=500"`
+}
+
+// Validate ListReq.
+func (req *ListReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corerestag.ValidateResType(req.ResType)
+}
@@ -26,6 +26,7 @@ import (
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/core/cloud"
+	rr "hcm/pkg/api/core/recycle-record"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
@@ -146,6 +147,44 @@ func (req *SecurityGroupBatchDeleteReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
 
+// -------------------------- Recycle --------------------------
+
+// SecurityGroupRecycleReq recycle security group request.
+type SecurityGroupRecycleReq struct {
+	Infos []SecurityGroupRecycleInfo `json:"infos" validate:"min=1,max=100"`
+}
+
+// SecurityGroupRecycleInfo defines recycle one security group info.
+type SecurityGroupRecycleInfo struct {
+	ID                              string `json:"id" validate:"required"`
+	*rr.SecurityGroupRecycleOptions `json:",inline" validate:"omitempty"`
+}
+
+// Validate SecurityGroupRecycleReq
+func (req *SecurityGroupRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// SecurityGroupRecoverReq recover security group request.
+type SecurityGroupRecoverReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate SecurityGroupRecoverReq
+func (req *SecurityGroupRecoverReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// SecurityGroupDeleteRecycleReq delete recycled security group request.
+type SecurityGroupDeleteRecycleReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate SecurityGroupDeleteRecycleReq
+func (req *SecurityGroupDeleteRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // -------------------------- Associate --------------------------
 
 // SecurityGroupAssociateCvmReq define security group associate cvm option.
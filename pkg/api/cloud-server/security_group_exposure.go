@@ -0,0 +1,142 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// SGExposureMaxCvmLimit 单次暴露面分析支持的最大主机数量
+const SGExposureMaxCvmLimit = 100
+
+// SGExposureReq security group exposure analysis request.
+type SGExposureReq struct {
+	// Filter 主机过滤条件
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	// Page 主机分页，单页最多 SGExposureMaxCvmLimit 台
+	Page *core.BasePage `json:"page" validate:"required"`
+	// Source 访问来源，支持 IPv4/IPv6 地址或 CIDR，为空时默认为 0.0.0.0/0
+	Source string `json:"source" validate:"omitempty"`
+	// Protocol 只返回该协议的暴露项，可选值：tcp、udp、icmp，为空表示全部
+	Protocol string `json:"protocol" validate:"omitempty,oneof=tcp udp icmp"`
+	// Port 只返回包含该端口的暴露项，为空表示全部端口
+	Port *int64 `json:"port" validate:"omitempty,min=0,max=65535"`
+}
+
+// Validate SGExposureReq.
+func (req *SGExposureReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Page.Validate(&core.PageOption{MaxLimit: SGExposureMaxCvmLimit}); err != nil {
+		return err
+	}
+
+	if req.Page.Count {
+		return errors.New("count page is not supported")
+	}
+
+	if len(req.Source) != 0 {
+		if _, err := ParseExposureSource(req.Source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseExposureSource 解析访问来源，单个地址视为掩码全长的网段
+func ParseExposureSource(source string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(source); err == nil {
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(source)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("source %s is not a valid ip or cidr", source)
+	}
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// SGExposureResult security group exposure analysis result.
+type SGExposureResult struct {
+	// Source 本次分析使用的访问来源
+	Source string `json:"source"`
+	// Details 主机维度的暴露情况
+	Details []CvmExposure `json:"details"`
+	// PortRanges 端口段维度的暴露情况
+	PortRanges []PortRangeExposure `json:"port_ranges"`
+}
+
+// CvmExposure define cvm exposure.
+type CvmExposure struct {
+	CvmID               string        `json:"cvm_id"`
+	CloudID             string        `json:"cloud_id"`
+	Name                string        `json:"name"`
+	Vendor              enumor.Vendor `json:"vendor"`
+	BkBizID             int64         `json:"bk_biz_id"`
+	Region              string        `json:"region"`
+	PublicIPv4Addresses []string      `json:"public_ipv4_addresses"`
+	PublicIPv6Addresses []string      `json:"public_ipv6_addresses"`
+	// SecurityGroupIDs 主机关联的安全组，腾讯云按绑定顺序排列，微软云包含网卡所在子网的安全组，
+	// gcp 主机使用所在 vpc 下的防火墙规则，该字段为空
+	SecurityGroupIDs []string `json:"security_group_ids"`
+	// Exposures 对访问来源放通的端口段
+	Exposures []PortExposure `json:"exposures"`
+	// UnresolvedRules 无法判定是否对访问来源放通的规则，如引用安全组、前缀列表、gcp 网络标记等
+	UnresolvedRules []ExposureRuleRef `json:"unresolved_rules"`
+}
+
+// PortExposure define port range exposure of one cvm.
+type PortExposure struct {
+	// Protocol 协议：tcp、udp、icmp
+	Protocol string `json:"protocol"`
+	// FromPort 起始端口，icmp 为 0
+	FromPort int64 `json:"from_port"`
+	// ToPort 结束端口，icmp 为 0
+	ToPort int64 `json:"to_port"`
+	// Rules 放通该端口段的规则
+	Rules []ExposureRuleRef `json:"rules"`
+}
+
+// ExposureRuleRef define rule reference of exposure.
+type ExposureRuleRef struct {
+	// SecurityGroupID 安全组ID，gcp 为空
+	SecurityGroupID string `json:"security_group_id,omitempty"`
+	// RuleID 安全组规则ID 或 gcp 防火墙规则ID
+	RuleID string `json:"rule_id"`
+}
+
+// PortRangeExposure define cvms exposed by one port range.
+type PortRangeExposure struct {
+	Protocol string   `json:"protocol"`
+	FromPort int64    `json:"from_port"`
+	ToPort   int64    `json:"to_port"`
+	CvmIDs   []string `json:"cvm_ids"`
+}
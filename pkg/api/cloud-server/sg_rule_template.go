@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	coresgruletpl "hcm/pkg/api/core/cloud/sg-rule-template"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// SGRuleTemplateCreateReq create security group rule template request.
+type SGRuleTemplateCreateReq struct {
+	Name  string               `json:"name" validate:"required,max=255"`
+	Rules []coresgruletpl.Rule `json:"rules" validate:"required,min=1,max=100"`
+	Memo  *string              `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SGRuleTemplateCreateReq.
+func (req *SGRuleTemplateCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coresgruletpl.ValidateRules(req.Rules)
+}
+
+// SGRuleTemplateUpdateReq update security group rule template request, rules changed will increase template version.
+type SGRuleTemplateUpdateReq struct {
+	Name  string               `json:"name" validate:"omitempty,max=255"`
+	Rules []coresgruletpl.Rule `json:"rules" validate:"omitempty,min=1,max=100"`
+	Memo  *string              `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SGRuleTemplateUpdateReq.
+func (req *SGRuleTemplateUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && req.Rules == nil && req.Memo == nil {
+		return errors.New("at least one of name, rules and memo should be set")
+	}
+
+	if req.Rules != nil {
+		return coresgruletpl.ValidateRules(req.Rules)
+	}
+
+	return nil
+}
+
+// SGRuleTemplateBindReq bind or unbind security group to rule template request.
+type SGRuleTemplateBindReq struct {
+	SecurityGroupIDs []string `json:"security_group_ids" validate:"required,min=1,max=100"`
+}
+
+// Validate SGRuleTemplateBindReq.
+func (req *SGRuleTemplateBindReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// SGRuleTemplateApplyReq apply rule template to bound security groups request.
+type SGRuleTemplateApplyReq struct {
+	// SecurityGroupIDs 需要应用模板的安全组，必须已绑定该模板
+	SecurityGroupIDs []string `json:"security_group_ids" validate:"required,min=1,max=100"`
+}
+
+// Validate SGRuleTemplateApplyReq.
+func (req *SGRuleTemplateApplyReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// SGRuleTemplateApplyResult apply rule template result.
+type SGRuleTemplateApplyResult struct {
+	// FlowID 异步任务ID
+	FlowID string `json:"flow_id"`
+	// SkippedSecurityGroupIDs 模板规则均已存在、无需创建规则的安全组，仍会在异步任务中记录应用版本
+	SkippedSecurityGroupIDs []string `json:"skipped_security_group_ids"`
+}
+
+// SGRuleTemplateDriftReq rule template drift report request.
+type SGRuleTemplateDriftReq struct {
+	// Page 绑定关系分页，单页最多 constant.BatchOperationMaxLimit 条
+	Page *core.BasePage `json:"page" validate:"required"`
+}
+
+// Validate SGRuleTemplateDriftReq.
+func (req *SGRuleTemplateDriftReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Page.Validate(&core.PageOption{MaxLimit: constant.BatchOperationMaxLimit}); err != nil {
+		return err
+	}
+
+	if req.Page.Count {
+		return errors.New("count page is not supported")
+	}
+
+	return nil
+}
+
+// SGRuleTemplateDriftResult rule template drift report.
+type SGRuleTemplateDriftResult struct {
+	TemplateID      string                   `json:"template_id"`
+	TemplateVersion uint64                   `json:"template_version"`
+	Details         []SGRuleTemplateRelDrift `json:"details"`
+}
+
+// SGRuleTemplateRelDrift drift status of one security group bound to rule template.
+type SGRuleTemplateRelDrift struct {
+	coresgruletpl.SGRuleTemplateRel `json:",inline"`
+	Status                          enumor.SGRuleTemplateDriftStatus `json:"status"`
+	// MissingRules 模板中存在但安全组同步后的规则中不存在的规则
+	MissingRules []coresgruletpl.Rule `json:"missing_rules"`
+	// ExtraRules 安全组同步后的规则中存在但模板中不存在的规则
+	ExtraRules []coresgruletpl.Rule `json:"extra_rules"`
+}
@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"errors"
+	"fmt"
+
+	cgconf "hcm/pkg/api/core/global-config"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/cidr"
+)
+
+// -------------------------- Plan --------------------------
+
+// SubnetCidrPlanReq defines plan subnet cidr request.
+type SubnetCidrPlanReq struct {
+	VpcID  string               `json:"vpc_id" validate:"required"`
+	IPType enumor.IPAddressType `json:"ip_type" validate:"required"`
+	// MaskLen 期望的子网掩码长度，与HostCount二选一
+	MaskLen int `json:"mask_len" validate:"omitempty,min=1,max=128"`
+	// HostCount 期望的子网ip数量（包括网络号和广播地址等保留地址），与MaskLen二选一
+	HostCount uint64 `json:"host_count" validate:"omitempty,min=1"`
+	// Limit 最多返回的候选网段数量，默认为1
+	Limit int `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Validate SubnetCidrPlanReq.
+func (req *SubnetCidrPlanReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.IPType != enumor.Ipv4 && req.IPType != enumor.Ipv6 {
+		return fmt.Errorf("ip_type only supports %s and %s", enumor.Ipv4, enumor.Ipv6)
+	}
+
+	if (req.MaskLen == 0) == (req.HostCount == 0) {
+		return errors.New("one and only one of mask_len and host_count should be set")
+	}
+
+	addrBits, err := cidr.IPAddressBits(req.IPType)
+	if err != nil {
+		return err
+	}
+	if req.MaskLen > addrBits {
+		return fmt.Errorf("mask_len should be less than or equal to %d for %s", addrBits, req.IPType)
+	}
+
+	return nil
+}
+
+// SubnetCidrPlanResult defines plan subnet cidr result.
+type SubnetCidrPlanResult struct {
+	// VpcCidrs vpc下对应ip地址类型的网段
+	VpcCidrs []string `json:"vpc_cidrs"`
+	// MaskLen 候选网段的掩码长度
+	MaskLen int `json:"mask_len"`
+	// Cidrs 候选网段，与vpc下已有子网、业务预留网段、同管控区域及对等连接的网段均不重叠
+	Cidrs []string `json:"cidrs"`
+	// Conflicts vpc网段中被其他来源占用的网段
+	Conflicts []SubnetCidrConflict `json:"conflicts"`
+}
+
+// SubnetCidrConflict defines the cidr which overlaps with vpc cidr.
+type SubnetCidrConflict struct {
+	Source enumor.SubnetCidrConflictSource `json:"source"`
+	// ResID 占用该网段的资源ID，来源为业务预留网段时为空
+	ResID   string `json:"res_id,omitempty"`
+	Cidr    string `json:"cidr"`
+	VpcCidr string `json:"vpc_cidr"`
+}
+
+// -------------------------- Reserved --------------------------
+
+// SubnetReservedCidrUpdateReq defines update biz reserved subnet cidr request.
+type SubnetReservedCidrUpdateReq struct {
+	Cidrs []cgconf.SubnetReservedCidr `json:"cidrs" validate:"omitempty,max=100,dive"`
+}
+
+// Validate SubnetReservedCidrUpdateReq.
+func (req *SubnetReservedCidrUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// SubnetReservedCidrResult defines biz reserved subnet cidr result.
+type SubnetReservedCidrResult struct {
+	Cidrs []cgconf.SubnetReservedCidr `json:"cidrs"`
+}
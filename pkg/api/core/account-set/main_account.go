@@ -152,3 +152,22 @@ func (e *KaopuMainAccountExtension) DecryptSecretKey(cipher cryptography.Crypto)
 	}
 	return nil
 }
+
+// TCloudMainAccountExtension 云主账号/云二级账号扩展字段
+type TCloudMainAccountExtension struct {
+	CloudMainAccountID   string `json:"cloud_main_account_id"`
+	CloudMainAccountName string `json:"cloud_main_account_name"`
+	CloudInitPassword    string `json:"cloud_init_password"`
+}
+
+// DecryptSecretKey ...
+func (e *TCloudMainAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
+	if e.CloudInitPassword != "" {
+		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudInitPassword)
+		if err != nil {
+			return err
+		}
+		e.CloudInitPassword = plainSecretKey
+	}
+	return nil
+}
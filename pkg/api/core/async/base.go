@@ -28,14 +28,17 @@ import (
 
 // AsyncFlow ...
 type AsyncFlow struct {
-	ID            string                `json:"id"`
-	Name          enumor.FlowName       `json:"name"`
-	State         enumor.FlowState      `json:"state"`
-	Reason        *tableasync.Reason    `json:"reason"`
-	ShareData     *tableasync.ShareData `json:"share_data"`
-	Memo          string                `json:"memo"`
-	Worker        *string               `json:"worker"`
-	core.Revision `json:",inline"`
+	ID               string                `json:"id"`
+	Name             enumor.FlowName       `json:"name"`
+	State            enumor.FlowState      `json:"state"`
+	Reason           *tableasync.Reason    `json:"reason"`
+	ShareData        *tableasync.ShareData `json:"share_data"`
+	Memo             string                `json:"memo"`
+	Worker           *string               `json:"worker"`
+	ScheduledAt      string                `json:"scheduled_at"`
+	ConcurrencyKey   string                `json:"concurrency_key"`
+	ConcurrencyLimit uint                  `json:"concurrency_limit"`
+	core.Revision    `json:",inline"`
 }
 
 // AsyncFlowTask ...
@@ -53,3 +56,19 @@ type AsyncFlowTask struct {
 	Reason        *tableasync.Reason `json:"reason"`
 	core.Revision `json:",inline"`
 }
+
+// AsyncFlowTaskEvent 任务事件，包括状态流转事件和执行日志
+type AsyncFlowTaskEvent struct {
+	ID          string               `json:"id"`
+	FlowID      string               `json:"flow_id"`
+	TaskID      string               `json:"task_id"`
+	Type        enumor.TaskEventType `json:"type"`
+	SourceState enumor.TaskState     `json:"source_state,omitempty"`
+	TargetState enumor.TaskState     `json:"target_state,omitempty"`
+	Attempt     uint                 `json:"attempt"`
+	Level       enumor.TaskLogLevel  `json:"level,omitempty"`
+	Message     string               `json:"message"`
+	OccurredAt  string               `json:"occurred_at"`
+	// DurationMS 状态事件在源状态停留的时长，单位毫秒，仅状态流转事件有效
+	DurationMS int64 `json:"duration_ms"`
+}
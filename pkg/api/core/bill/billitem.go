@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/bssintl/v2/model"
 	"github.com/shopspring/decimal"
+	billing "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
 )
 
 // BaseBillItem 存储分账后的明细
@@ -98,6 +100,7 @@ type BillItemExtension interface {
 
 // TCloudBillItemExtension ...
 type TCloudBillItemExtension struct {
+	*billing.BillDetail `json:",inline"`
 }
 
 // AwsBillItemExtension ...
@@ -163,6 +166,15 @@ type GcpBillItemExtension struct {
 
 // AzureBillItemExtension ...
 type AzureBillItemExtension struct {
+	*AzureRawBillItem `json:",inline"`
+}
+
+// AzureRawBillItem azure legacy usage detail, same as the json of armconsumption.LegacyUsageDetail
+type AzureRawBillItem struct {
+	ID         *string                                     `json:"id,omitempty"`
+	Name       *string                                     `json:"name,omitempty"`
+	Kind       *string                                     `json:"kind,omitempty"`
+	Properties *armconsumption.LegacyUsageDetailProperties `json:"properties,omitempty"`
 }
 
 // KaopuBillItemExtension ...
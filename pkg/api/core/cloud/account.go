@@ -49,6 +49,10 @@ type TCloudAccountExtension struct {
 	CloudSubAccountID  string `json:"cloud_sub_account_id"`
 	CloudSecretID      string `json:"cloud_secret_id"`
 	CloudSecretKey     string `json:"cloud_secret_key,omitempty"`
+	// CredentialMode 凭证模式，为空时视为密钥模式
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode,omitempty"`
+	// CloudRoleArn 角色模式下扮演的角色
+	CloudRoleArn string `json:"cloud_role_arn,omitempty"`
 }
 
 // DecryptSecretKey ...
@@ -70,6 +74,12 @@ type AwsAccountExtension struct {
 	CloudIamUsername string `json:"cloud_iam_username"`
 	CloudSecretID    string `json:"cloud_secret_id"`
 	CloudSecretKey   string `json:"cloud_secret_key,omitempty"`
+	// CredentialMode 凭证模式，为空时视为密钥模式
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode,omitempty"`
+	// CloudRoleArn 角色模式下扮演的角色
+	CloudRoleArn string `json:"cloud_role_arn,omitempty"`
+	// CloudExternalID 角色模式下扮演角色时需要提供的外部ID
+	CloudExternalID string `json:"cloud_external_id,omitempty"`
 }
 
 // DecryptSecretKey ...
@@ -143,6 +153,8 @@ type AzureAccountExtension struct {
 	CloudApplicationName  string `json:"cloud_application_name"`
 	CloudClientSecretID   string `json:"cloud_client_secret_id"`
 	CloudClientSecretKey  string `json:"cloud_client_secret_key,omitempty"`
+	// CredentialMode 凭证模式，角色模式下使用工作负载身份以CloudApplicationID应用换取访问令牌
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode,omitempty"`
 }
 
 // DecryptSecretKey ...
@@ -33,6 +33,7 @@ type BaseArgsTpl struct {
 	Vendor         enumor.Vendor       `json:"vendor"`
 	BkBizID        int64               `json:"bk_biz_id"`
 	AccountID      string              `json:"account_id"`
+	Region         string              `json:"region"`
 	Type           enumor.TemplateType `json:"type"`
 	Templates      *[]TemplateInfo     `json:"templates"`
 	GroupTemplates *[]string           `json:"group_templates"`
@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bizassign resource auto assign to biz rule core types.
+package bizassign
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// BizAssignRule define resource auto assign to biz rule.
+type BizAssignRule struct {
+	ID      string                   `json:"id"`
+	Name    string                   `json:"name"`
+	ResType enumor.CloudResourceType `json:"res_type"`
+	// Rule 资源匹配规则，格式同资源查询的filter，支持通过 tags.{tag_key} 字段查询资源标签
+	Rule    *filter.Expression `json:"rule"`
+	BkBizID int64              `json:"bk_biz_id"`
+	// Priority 优先级，值越小越优先匹配，资源被分配后不会再被其他规则匹配
+	Priority       uint32  `json:"priority"`
+	Enabled        bool    `json:"enabled"`
+	Memo           *string `json:"memo"`
+	*core.Revision `json:",inline"`
+}
+
+// SupportedResTypes 支持自动分配业务的资源类型
+var SupportedResTypes = []enumor.CloudResourceType{
+	enumor.CvmCloudResType,
+	enumor.DiskCloudResType,
+	enumor.DiskSnapshotCloudResType,
+	enumor.KeyPairCloudResType,
+	enumor.VpcCloudResType,
+	enumor.SubnetCloudResType,
+	enumor.EipCloudResType,
+	enumor.LoadBalancerCloudResType,
+}
+
+// ValidateResType validate resource type supports auto assign to biz or not.
+func ValidateResType(resType enumor.CloudResourceType) error {
+	if !slice.IsItemInSlice(SupportedResTypes, resType) {
+		return fmt.Errorf("resource type %s not support auto assign to biz", resType)
+	}
+
+	return nil
+}
+
+// AssignResult define resources assigned to biz by one rule.
+type AssignResult struct {
+	RuleID  string                   `json:"rule_id"`
+	ResType enumor.CloudResourceType `json:"res_type"`
+	BkBizID int64                    `json:"bk_biz_id"`
+	ResIDs  []string                 `json:"res_ids"`
+}
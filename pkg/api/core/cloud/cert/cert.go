@@ -67,7 +67,7 @@ func (cert Cert[T]) GetCloudID() string {
 
 // Extension extension.
 type Extension interface {
-	TCloudCertExtension
+	TCloudCertExtension | HuaWeiCertExtension
 }
 
 // CertCreateResp ...
@@ -0,0 +1,111 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package coredisksnapshot defines disk snapshot core models.
+package coredisksnapshot
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseDiskSnapshot define base disk snapshot.
+type BaseDiskSnapshot struct {
+	ID            string        `json:"id"`
+	Vendor        enumor.Vendor `json:"vendor"`
+	AccountID     string        `json:"account_id"`
+	CloudID       string        `json:"cloud_id"`
+	BkBizID       int64         `json:"bk_biz_id"`
+	Name          string        `json:"name"`
+	Region        string        `json:"region"`
+	Zone          string        `json:"zone"`
+	DiskID        string        `json:"disk_id"`
+	CloudDiskID   string        `json:"cloud_disk_id"`
+	DiskSize      uint64        `json:"disk_size"`
+	Status        string        `json:"status"`
+	RecycleStatus string        `json:"recycle_status"`
+	Memo          *string       `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// DiskSnapshot define disk snapshot.
+type DiskSnapshot[Ext Extension] struct {
+	BaseDiskSnapshot `json:",inline"`
+	Extension        *Ext `json:"extension"`
+}
+
+// GetID ...
+func (snap DiskSnapshot[T]) GetID() string {
+	return snap.ID
+}
+
+// GetCloudID ...
+func (snap DiskSnapshot[T]) GetCloudID() string {
+	return snap.CloudID
+}
+
+// Extension ...
+type Extension interface {
+	TCloudExtension | AwsExtension | HuaWeiExtension | AzureExtension | GcpExtension
+}
+
+// TCloudExtension ...
+type TCloudExtension struct {
+	// SnapshotType 快照类型，PRIVATE_SNAPSHOT：普通快照，SHARED_SNAPSHOT：共享快照
+	SnapshotType *string `json:"snapshot_type,omitempty"`
+	Encrypt      *bool   `json:"encrypt,omitempty"`
+	// DiskUsage 源云盘类型，SYSTEM_DISK：系统盘，DATA_DISK：数据盘
+	DiskUsage        *string `json:"disk_usage,omitempty"`
+	IsPermanent      *bool   `json:"is_permanent,omitempty"`
+	DeadlineTime     *string `json:"deadline_time,omitempty"`
+	CloudCreatedTime *string `json:"cloud_created_time,omitempty"`
+}
+
+// AwsExtension ...
+type AwsExtension struct {
+	Description      *string `json:"description,omitempty"`
+	Encrypted        *bool   `json:"encrypted,omitempty"`
+	Progress         *string `json:"progress,omitempty"`
+	StorageTier      *string `json:"storage_tier,omitempty"`
+	CloudCreatedTime *string `json:"cloud_created_time,omitempty"`
+}
+
+// HuaWeiExtension ...
+type HuaWeiExtension struct {
+	Description      *string `json:"description,omitempty"`
+	CloudCreatedTime *string `json:"cloud_created_time,omitempty"`
+	CloudUpdatedTime *string `json:"cloud_updated_time,omitempty"`
+}
+
+// AzureExtension ...
+type AzureExtension struct {
+	ResourceGroupName string  `json:"resource_group_name" validate:"required"`
+	Incremental       *bool   `json:"incremental,omitempty"`
+	OSType            *string `json:"os_type,omitempty"`
+	SKUName           *string `json:"sku_name,omitempty"`
+	CloudCreatedTime  *string `json:"cloud_created_time,omitempty"`
+}
+
+// GcpExtension ...
+type GcpExtension struct {
+	SelfLink         string  `json:"self_link" validate:"required"`
+	Description      *string `json:"description,omitempty"`
+	StorageBytes     *int64  `json:"storage_bytes,omitempty"`
+	CloudCreatedTime *string `json:"cloud_created_time,omitempty"`
+}
@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package corekeypair defines ssh key pair core models.
+package corekeypair
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseKeyPair define base ssh key pair.
+type BaseKeyPair struct {
+	ID            string        `json:"id"`
+	Vendor        enumor.Vendor `json:"vendor"`
+	AccountID     string        `json:"account_id"`
+	CloudID       string        `json:"cloud_id"`
+	BkBizID       int64         `json:"bk_biz_id"`
+	Name          string        `json:"name"`
+	Region        string        `json:"region"`
+	Fingerprint   string        `json:"fingerprint"`
+	PublicKey     string        `json:"public_key"`
+	Memo          *string       `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// KeyPair define ssh key pair.
+type KeyPair[Ext Extension] struct {
+	BaseKeyPair `json:",inline"`
+	Extension   *Ext `json:"extension"`
+}
+
+// GetID ...
+func (kp KeyPair[T]) GetID() string {
+	return kp.ID
+}
+
+// GetCloudID ...
+func (kp KeyPair[T]) GetCloudID() string {
+	return kp.CloudID
+}
+
+// Extension ...
+type Extension interface {
+	TCloudExtension | AwsExtension | HuaWeiExtension | AzureExtension
+}
+
+// TCloudExtension ...
+type TCloudExtension struct {
+	ProjectID *int64 `json:"project_id,omitempty"`
+	// CloudInstanceIDs 密钥对已绑定的实例云上ID
+	CloudInstanceIDs []string `json:"cloud_instance_ids,omitempty"`
+	CloudCreatedTime *string  `json:"cloud_created_time,omitempty"`
+}
+
+// AwsExtension ...
+type AwsExtension struct {
+	// KeyType 密钥类型，rsa 或 ed25519
+	KeyType          *string `json:"key_type,omitempty"`
+	CloudCreatedTime *string `json:"cloud_created_time,omitempty"`
+}
+
+// HuaWeiExtension ...
+type HuaWeiExtension struct {
+	// Scope 密钥对级别，domain：租户级，user：用户级
+	Scope *string `json:"scope,omitempty"`
+	// FrozenState 密钥对冻结状态，0：正常，3：冻结
+	FrozenState *string `json:"frozen_state,omitempty"`
+}
+
+// AzureExtension ...
+type AzureExtension struct {
+	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+}
@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+// AwsLoadBalancer ...
+type AwsLoadBalancer = LoadBalancer[AwsLoadBalancerExtension]
+
+// AwsLoadBalancerExtension aws load balancer extension.
+type AwsLoadBalancerExtension struct {
+	// Type 负载均衡类型 application | network | gateway
+	Type *string `json:"type,omitempty"`
+	// Scheme 网络类型 internet-facing | internal
+	Scheme *string `json:"scheme,omitempty"`
+	// IPAddressType 云上IP地址类型 ipv4 | dualstack | dualstack-without-public-ipv4
+	IPAddressType *string `json:"ip_address_type,omitempty"`
+	// CanonicalHostedZoneID 负载均衡所在Route 53托管区域ID
+	CanonicalHostedZoneID *string `json:"canonical_hosted_zone_id,omitempty"`
+	// CloudSubnetIDs aws 负载均衡可以跨多个可用区，每个可用区对应一个子网
+	CloudSubnetIDs []string `json:"cloud_subnet_ids,omitempty"`
+	// CloudSecurityGroupIDs 绑定的安全组，NLB 可能为空
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids,omitempty"`
+	// StateReason 状态原因
+	StateReason *string `json:"state_reason,omitempty"`
+}
+
+// AwsListenerExtension aws listener extension.
+type AwsListenerExtension struct {
+	SslPolicy *string `json:"ssl_policy,omitempty"`
+	// CertificateIDs 证书ARN列表
+	CertificateIDs []string `json:"certificate_ids,omitempty"`
+	// DefaultCloudTargetGroupIDs 默认动作转发到的目标组ARN
+	DefaultCloudTargetGroupIDs []string `json:"default_cloud_target_group_ids,omitempty"`
+	AlpnPolicy                 []string `json:"alpn_policy,omitempty"`
+}
+
+// AwsListener ...
+type AwsListener = Listener[AwsListenerExtension]
+
+// AwsTargetGroupExtension aws target group extension.
+type AwsTargetGroupExtension struct {
+	// TargetType 目标类型 instance | ip | lambda | alb
+	TargetType *string `json:"target_type,omitempty"`
+	// IPAddressType 目标组IP地址类型 ipv4 | ipv6
+	IPAddressType *string `json:"ip_address_type,omitempty"`
+	// ProtocolVersion 协议版本 GRPC | HTTP1 | HTTP2，仅HTTP/HTTPS目标组有效
+	ProtocolVersion *string `json:"protocol_version,omitempty"`
+	// HealthCheck aws 目标组健康检查配置，与腾讯云健康检查字段不同，不使用基础信息中的 health_check
+	HealthCheck *AwsHealthCheckInfo `json:"health_check,omitempty"`
+	// CloudLoadBalancerIDs 目标组关联的负载均衡ARN
+	CloudLoadBalancerIDs []string `json:"cloud_load_balancer_ids,omitempty"`
+}
+
+// AwsTargetGroup ...
+type AwsTargetGroup = TargetGroup[AwsTargetGroupExtension]
+
+// AwsHealthCheckInfo aws target group health check.
+type AwsHealthCheckInfo struct {
+	// Enabled 是否开启健康检查
+	Enabled *bool `json:"enabled,omitempty"`
+	// Protocol 健康检查协议 HTTP | HTTPS | TCP | TLS | UDP | TCP_UDP | GENEVE
+	Protocol *string `json:"protocol,omitempty"`
+	// Port 健康检查端口，traffic-port 表示使用目标接收流量的端口
+	Port *string `json:"port,omitempty"`
+	// Path 健康检查路径，仅HTTP/HTTPS健康检查有效
+	Path *string `json:"path,omitempty"`
+	// IntervalSeconds 检查间隔，单位：秒
+	IntervalSeconds *int64 `json:"interval_seconds,omitempty"`
+	// TimeoutSeconds 响应超时时间，单位：秒
+	TimeoutSeconds *int64 `json:"timeout_seconds,omitempty"`
+	// HealthyThreshold 连续检查成功多少次后认为目标健康
+	HealthyThreshold *int64 `json:"healthy_threshold,omitempty"`
+	// UnhealthyThreshold 连续检查失败多少次后认为目标不健康
+	UnhealthyThreshold *int64 `json:"unhealthy_threshold,omitempty"`
+	// Matcher 健康检查成功码，HTTP为HttpCode，GRPC为GrpcCode
+	Matcher *string `json:"matcher,omitempty"`
+}
@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+// HuaWeiLoadBalancer ...
+type HuaWeiLoadBalancer = LoadBalancer[HuaWeiLoadBalancerExtension]
+
+// HuaWeiLoadBalancerExtension huawei load balancer extension.
+type HuaWeiLoadBalancerExtension struct {
+	// Guaranteed 是否独享型负载均衡
+	Guaranteed *bool `json:"guaranteed,omitempty"`
+	// CloudSubnetIDs 后端子网，即VPC子网ID列表
+	CloudSubnetIDs []string `json:"cloud_subnet_ids,omitempty"`
+	// VipSubnetCidrID IPv4子网ID(neutron_subnet_id)
+	VipSubnetCidrID *string `json:"vip_subnet_cidr_id,omitempty"`
+	// VipPortID 负载均衡IPv4地址对应的端口ID
+	VipPortID *string `json:"vip_port_id,omitempty"`
+	// Ipv6VipVirsubnetID 双栈负载均衡所在的IPv6网络ID
+	Ipv6VipVirsubnetID *string `json:"ipv6_vip_virsubnet_id,omitempty"`
+	// CloudEipIDs 绑定的弹性公网IP
+	CloudEipIDs []string `json:"cloud_eip_ids,omitempty"`
+	// L4FlavorID 四层规格ID
+	L4FlavorID *string `json:"l4_flavor_id,omitempty"`
+	// L7FlavorID 七层规格ID
+	L7FlavorID *string `json:"l7_flavor_id,omitempty"`
+	// DeletionProtectionEnable 是否开启删除保护
+	DeletionProtectionEnable *bool `json:"deletion_protection_enable,omitempty"`
+}
+
+// HuaWeiListenerExtension huawei listener extension.
+type HuaWeiListenerExtension struct {
+	// DefaultCloudPoolID 默认后端服务器组ID
+	DefaultCloudPoolID *string `json:"default_cloud_pool_id,omitempty"`
+	// CertificateIDs 服务器证书及SNI证书ID列表，第一个为默认证书
+	CertificateIDs []string `json:"certificate_ids,omitempty"`
+	// ClientCaTlsContainerRef 双向认证的CA证书ID
+	ClientCaTlsContainerRef *string `json:"client_ca_tls_container_ref,omitempty"`
+	// TlsCiphersPolicy 安全策略
+	TlsCiphersPolicy *string `json:"tls_ciphers_policy,omitempty"`
+	// SecurityPolicyID 自定义安全策略ID
+	SecurityPolicyID *string `json:"security_policy_id,omitempty"`
+}
+
+// HuaWeiListener ...
+type HuaWeiListener = Listener[HuaWeiListenerExtension]
+
+// HuaWeiTargetGroupExtension huawei target group extension, 对应华为云后端服务器组.
+type HuaWeiTargetGroupExtension struct {
+	// LbAlgorithm 负载均衡算法 ROUND_ROBIN | LEAST_CONNECTIONS | SOURCE_IP | QUIC_CID
+	LbAlgorithm *string `json:"lb_algorithm,omitempty"`
+	// IPVersion 后端服务器组支持的IP版本 dualstack | v4 | v6
+	IPVersion *string `json:"ip_version,omitempty"`
+	// PoolType 后端服务器组类型 instance | ip，为空表示混合类型
+	PoolType *string `json:"pool_type,omitempty"`
+	// CloudHealthMonitorID 健康检查ID
+	CloudHealthMonitorID *string `json:"cloud_health_monitor_id,omitempty"`
+	// HealthCheck 华为云健康检查配置，与腾讯云健康检查字段不同，不使用基础信息中的 health_check
+	HealthCheck *HuaWeiHealthCheckInfo `json:"health_check,omitempty"`
+	// CloudListenerIDs 后端服务器组关联的监听器ID
+	CloudListenerIDs []string `json:"cloud_listener_ids,omitempty"`
+	// CloudLoadBalancerIDs 后端服务器组关联的负载均衡ID
+	CloudLoadBalancerIDs []string `json:"cloud_load_balancer_ids,omitempty"`
+}
+
+// HuaWeiTargetGroup ...
+type HuaWeiTargetGroup = TargetGroup[HuaWeiTargetGroupExtension]
+
+// HuaWeiHealthCheckInfo huawei health monitor.
+type HuaWeiHealthCheckInfo struct {
+	// Enabled 是否开启健康检查
+	Enabled *bool `json:"enabled,omitempty"`
+	// Type 健康检查协议 TCP | UDP_CONNECT | HTTP | HTTPS | PING | GRPC
+	Type *string `json:"type,omitempty"`
+	// MonitorPort 健康检查端口，为空表示使用后端服务器的端口
+	MonitorPort *int64 `json:"monitor_port,omitempty"`
+	// Delay 检查间隔，单位：秒
+	Delay *int64 `json:"delay,omitempty"`
+	// Timeout 响应超时时间，单位：秒
+	Timeout *int64 `json:"timeout,omitempty"`
+	// MaxRetries 连续检查成功多少次后认为后端服务器健康
+	MaxRetries *int64 `json:"max_retries,omitempty"`
+	// MaxRetriesDown 连续检查失败多少次后认为后端服务器不健康
+	MaxRetriesDown *int64 `json:"max_retries_down,omitempty"`
+	// DomainName HTTP健康检查的域名
+	DomainName *string `json:"domain_name,omitempty"`
+	// URLPath HTTP健康检查的路径
+	URLPath *string `json:"url_path,omitempty"`
+	// HTTPMethod HTTP健康检查的方法
+	HTTPMethod *string `json:"http_method,omitempty"`
+	// ExpectedCodes 健康检查期望的响应状态码
+	ExpectedCodes *string `json:"expected_codes,omitempty"`
+}
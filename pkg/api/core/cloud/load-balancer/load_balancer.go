@@ -54,6 +54,7 @@ type BaseLoadBalancer struct {
 	CloudCreatedTime     string   `json:"cloud_created_time"`
 	CloudStatusTime      string   `json:"cloud_status_time"`
 	CloudExpiredTime     string   `json:"cloud_expired_time"`
+	RecycleStatus        string   `json:"recycle_status,omitempty"`
 
 	Tags core.TagMap `json:"tags"`
 
@@ -91,7 +92,7 @@ func (lb LoadBalancer[T]) GetCloudID() string {
 
 // Extension extension.
 type Extension interface {
-	TCloudClbExtension
+	TCloudClbExtension | AwsLoadBalancerExtension | HuaWeiLoadBalancerExtension
 }
 
 // BaseListener define base listener.
@@ -136,7 +137,7 @@ func (lbl Listener[T]) GetCloudID() string {
 
 // ListenerExtension 监听器拓展
 type ListenerExtension interface {
-	TCloudListenerExtension
+	TCloudListenerExtension | AwsListenerExtension | HuaWeiListenerExtension
 }
 
 // TCloudLbUrlRule define base tcloud lb url rule.
@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package restag cloud resource tag core types.
+package restag
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// ResourceTag define cloud resource tag.
+type ResourceTag struct {
+	ID             string                   `json:"id"`
+	ResType        enumor.CloudResourceType `json:"res_type"`
+	ResID          string                   `json:"res_id"`
+	ResCloudID     string                   `json:"res_cloud_id"`
+	Vendor         enumor.Vendor            `json:"vendor"`
+	AccountID      string                   `json:"account_id"`
+	TagKey         string                   `json:"tag_key"`
+	TagValue       string                   `json:"tag_value"`
+	*core.Revision `json:",inline"`
+}
+
+const (
+	// MaxTagKeyLength 标签键最大长度
+	MaxTagKeyLength = 255
+	// MaxTagValueLength 标签值最大长度
+	MaxTagValueLength = 255
+)
+
+// supportedResTypes 各云厂商支持标签同步和标签写回的资源类型。
+// 未列出的组合说明云上接口不返回或不支持该资源的标签，如gcp的vpc和子网没有label，华为云子网、eip的列表接口不返回标签，
+// azure、gcp的负载均衡未纳管。
+var supportedResTypes = map[enumor.Vendor]map[enumor.CloudResourceType]struct{}{
+	enumor.TCloud: {
+		enumor.CvmCloudResType: {}, enumor.DiskCloudResType: {}, enumor.VpcCloudResType: {},
+		enumor.SubnetCloudResType: {}, enumor.EipCloudResType: {}, enumor.LoadBalancerCloudResType: {},
+	},
+	enumor.Aws: {
+		enumor.CvmCloudResType: {}, enumor.DiskCloudResType: {}, enumor.VpcCloudResType: {},
+		enumor.SubnetCloudResType: {}, enumor.EipCloudResType: {}, enumor.LoadBalancerCloudResType: {},
+	},
+	enumor.HuaWei: {
+		enumor.CvmCloudResType: {}, enumor.DiskCloudResType: {}, enumor.VpcCloudResType: {},
+		enumor.LoadBalancerCloudResType: {},
+	},
+	enumor.Azure: {
+		enumor.CvmCloudResType: {}, enumor.DiskCloudResType: {}, enumor.VpcCloudResType: {},
+		enumor.EipCloudResType: {},
+	},
+	enumor.Gcp: {
+		enumor.CvmCloudResType: {}, enumor.DiskCloudResType: {}, enumor.EipCloudResType: {},
+	},
+}
+
+// resTypes 支持标签的资源类型
+var resTypes = map[enumor.CloudResourceType]struct{}{
+	enumor.CvmCloudResType:          {},
+	enumor.DiskCloudResType:         {},
+	enumor.VpcCloudResType:          {},
+	enumor.SubnetCloudResType:       {},
+	enumor.EipCloudResType:          {},
+	enumor.LoadBalancerCloudResType: {},
+}
+
+// ValidateResType validate resource type supports tag or not.
+func ValidateResType(resType enumor.CloudResourceType) error {
+	if _, exist := resTypes[resType]; !exist {
+		return fmt.Errorf("resource type %s does not support tag", resType)
+	}
+
+	return nil
+}
+
+// ValidateVendorResType validate vendor's resource type supports tag or not.
+func ValidateVendorResType(vendor enumor.Vendor, resType enumor.CloudResourceType) error {
+	if _, exist := supportedResTypes[vendor][resType]; !exist {
+		return fmt.Errorf("%s %s does not support tag", vendor, resType)
+	}
+
+	return nil
+}
+
+// ValidateTag validate tag key and value.
+func ValidateTag(key, value string) error {
+	if len(key) == 0 {
+		return errors.New("tag key can not be empty")
+	}
+
+	if len(key) > MaxTagKeyLength {
+		return fmt.Errorf("tag key %s length should <= %d", key, MaxTagKeyLength)
+	}
+
+	if len(value) > MaxTagValueLength {
+		return fmt.Errorf("tag %s's value length should <= %d", key, MaxTagValueLength)
+	}
+
+	return nil
+}
+
+// ValidateTags validate tag pairs, tag key can not be duplicated.
+func ValidateTags(tags []core.TagPair) error {
+	keys := make(map[string]struct{}, len(tags))
+	for _, one := range tags {
+		if err := ValidateTag(one.Key, one.Value); err != nil {
+			return err
+		}
+
+		if _, exist := keys[one.Key]; exist {
+			return fmt.Errorf("tag key %s is duplicated", one.Key)
+		}
+		keys[one.Key] = struct{}{}
+	}
+
+	return nil
+}
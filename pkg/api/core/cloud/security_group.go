@@ -37,6 +37,7 @@ type BaseSecurityGroup struct {
 	Tags             core.TagMap   `json:"tags"`
 	AccountID        string        `json:"account_id"`
 	BkBizID          int64         `json:"bk_biz_id"`
+	RecycleStatus    string        `json:"recycle_status,omitempty"`
 	Creator          string        `json:"creator"`
 	Reviser          string        `json:"reviser"`
 	CreatedAt        string        `json:"created_at"`
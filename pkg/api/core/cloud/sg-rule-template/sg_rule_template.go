@@ -0,0 +1,233 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sgruletpl security group rule template core types.
+package sgruletpl
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// SGRuleTemplate define security group rule template.
+type SGRuleTemplate struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	BkBizID int64  `json:"bk_biz_id"`
+	Rules   []Rule `json:"rules"`
+	// Version 模板版本，每次修改规则后递增
+	Version        uint64  `json:"version"`
+	Memo           *string `json:"memo"`
+	*core.Revision `json:",inline"`
+}
+
+// SGRuleTemplateRel define security group rule template and security group rel.
+type SGRuleTemplateRel struct {
+	ID              string        `json:"id"`
+	TemplateID      string        `json:"template_id"`
+	SecurityGroupID string        `json:"security_group_id"`
+	Vendor          enumor.Vendor `json:"vendor"`
+	AccountID       string        `json:"account_id"`
+	// AppliedVersion 最近一次成功应用的模板版本，0表示未应用
+	AppliedVersion uint64                           `json:"applied_version"`
+	ApplyStatus    enumor.SGRuleTemplateApplyStatus `json:"apply_status"`
+	ApplyMessage   string                           `json:"apply_message"`
+	AppliedAt      string                           `json:"applied_at"`
+	*core.Revision `json:",inline"`
+}
+
+const (
+	// RuleActionAccept 放通
+	RuleActionAccept = "accept"
+	// RuleActionDrop 拒绝
+	RuleActionDrop = "drop"
+
+	// ProtocolTCP tcp
+	ProtocolTCP = "tcp"
+	// ProtocolUDP udp
+	ProtocolUDP = "udp"
+	// ProtocolICMP icmp
+	ProtocolICMP = "icmp"
+	// ProtocolAll 全部协议
+	ProtocolAll = "all"
+
+	// PortAll 全部端口
+	PortAll = "ALL"
+
+	maxPort = 65535
+)
+
+// SupportedVendors 支持绑定规则模板的云厂商
+var SupportedVendors = []enumor.Vendor{enumor.TCloud, enumor.Aws}
+
+// Rule 规则模板中的规则，与云厂商无关，应用时转换为对应云厂商的安全组规则
+type Rule struct {
+	Type enumor.SecurityGroupRuleType `json:"type"`
+	// Protocol 协议，可选值：tcp、udp、icmp、all
+	Protocol string `json:"protocol"`
+	// Port 端口，支持单个端口(22)、端口段(8000-9000)及全部端口(ALL)，icmp、all 协议只能为 ALL
+	Port string `json:"port"`
+	// IPv4Cidr 与 IPv6Cidr 必须且只能设置一个
+	IPv4Cidr string `json:"ipv4_cidr,omitempty"`
+	IPv6Cidr string `json:"ipv6_cidr,omitempty"`
+	// Action 策略，可选值：accept、drop
+	Action string `json:"action"`
+	Memo   string `json:"memo,omitempty"`
+}
+
+// Validate Rule.
+func (r Rule) Validate() error {
+	if r.Type != enumor.Ingress && r.Type != enumor.Egress {
+		return fmt.Errorf("unsupported rule type: %s", r.Type)
+	}
+
+	switch r.Protocol {
+	case ProtocolTCP, ProtocolUDP:
+		if _, _, err := r.PortRange(); err != nil {
+			return err
+		}
+	case ProtocolICMP, ProtocolAll:
+		if r.Port != PortAll {
+			return fmt.Errorf("port of %s protocol must be %s", r.Protocol, PortAll)
+		}
+	default:
+		return fmt.Errorf("unsupported protocol: %s", r.Protocol)
+	}
+
+	if (len(r.IPv4Cidr) == 0) == (len(r.IPv6Cidr) == 0) {
+		return errors.New("one and only one of ipv4_cidr and ipv6_cidr should be set")
+	}
+	if len(r.IPv4Cidr) != 0 {
+		if prefix, err := netip.ParsePrefix(r.IPv4Cidr); err != nil || !prefix.Addr().Is4() {
+			return fmt.Errorf("invalid ipv4 cidr: %s", r.IPv4Cidr)
+		}
+	}
+	if len(r.IPv6Cidr) != 0 {
+		if prefix, err := netip.ParsePrefix(r.IPv6Cidr); err != nil || !prefix.Addr().Is6() {
+			return fmt.Errorf("invalid ipv6 cidr: %s", r.IPv6Cidr)
+		}
+	}
+
+	if r.Action != RuleActionAccept && r.Action != RuleActionDrop {
+		return fmt.Errorf("unsupported action: %s", r.Action)
+	}
+
+	if len(r.Memo) > 100 {
+		return errors.New("memo length should <= 100")
+	}
+
+	return nil
+}
+
+// ValidateRules 校验规则列表，规则不能为空且不能重复
+func ValidateRules(rules []Rule) error {
+	if len(rules) == 0 {
+		return errors.New("rules is required")
+	}
+
+	keys := make(map[string]struct{}, len(rules))
+	for idx, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rules[%d] is invalid, err: %v", idx, err)
+		}
+
+		if _, exists := keys[rule.Key()]; exists {
+			return fmt.Errorf("rules[%d] is duplicated", idx)
+		}
+		keys[rule.Key()] = struct{}{}
+	}
+
+	return nil
+}
+
+// ValidateVendor 校验规则是否可以应用到该云厂商的安全组
+func (r Rule) ValidateVendor(vendor enumor.Vendor) error {
+	switch vendor {
+	case enumor.TCloud:
+	case enumor.Aws:
+		if r.Action != RuleActionAccept {
+			return errors.New("aws security group only support accept rule")
+		}
+	default:
+		return fmt.Errorf("security group rule template not support vendor: %s", vendor)
+	}
+
+	return nil
+}
+
+// PortRange 返回规则的端口段，全部端口返回 -1, -1
+func (r Rule) PortRange() (int64, int64, error) {
+	if r.Port == PortAll {
+		return -1, -1, nil
+	}
+
+	from, to, found := strings.Cut(r.Port, "-")
+	if !found {
+		to = from
+	}
+	fromPort, err := strconv.ParseInt(from, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port: %s", r.Port)
+	}
+	toPort, err := strconv.ParseInt(to, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port: %s", r.Port)
+	}
+	if fromPort < 0 || toPort > maxPort || fromPort > toPort {
+		return 0, 0, fmt.Errorf("invalid port range: %s", r.Port)
+	}
+
+	return fromPort, toPort, nil
+}
+
+// Key 规则的唯一标识，端口及网段按规范格式比较，备注不参与比较
+func (r Rule) Key() string {
+	port := r.Port
+	if from, to, err := r.PortRange(); err == nil {
+		port = FormatPort(from, to)
+	}
+
+	return strings.Join([]string{string(r.Type), r.Protocol, port, formatCidr(r.IPv4Cidr), formatCidr(r.IPv6Cidr),
+		r.Action}, "|")
+}
+
+// FormatPort 将端口段转换为规则模板的端口格式，from 小于 0 或 0-65535 表示全部端口
+func FormatPort(from, to int64) string {
+	if from < 0 || (from == 0 && to == maxPort) {
+		return PortAll
+	}
+	if from == to {
+		return strconv.FormatInt(from, 10)
+	}
+	return fmt.Sprintf("%d-%d", from, to)
+}
+
+func formatCidr(cidr string) string {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return cidr
+	}
+	return prefix.Masked().String()
+}
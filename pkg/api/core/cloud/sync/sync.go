@@ -20,6 +20,8 @@
 package coresync
 
 import (
+	"time"
+
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/table/types"
 )
@@ -37,3 +39,24 @@ type AccountSyncDetailTable struct {
 	CreatedAt       types.Time      `json:"created_at"`
 	UpdatedAt       types.Time      `json:"updated_at"`
 }
+
+// SyncRunRecord 资源同步运行记录
+type SyncRunRecord struct {
+	ID           string                   `json:"id"`
+	Vendor       enumor.Vendor            `json:"vendor"`
+	AccountID    string                   `json:"account_id"`
+	Scope        enumor.SyncRunScope      `json:"scope"`
+	Region       string                   `json:"region"`
+	ResType      enumor.CloudResourceType `json:"res_type"`
+	State        enumor.SyncStatus        `json:"state"`
+	Added        uint                     `json:"added"`
+	Updated      uint                     `json:"updated"`
+	Deleted      uint                     `json:"deleted"`
+	FailedReason string                   `json:"failed_reason"`
+	Rid          string                   `json:"rid"`
+	StartedAt    time.Time                `json:"started_at"`
+	EndedAt      time.Time                `json:"ended_at"`
+	CostMS       uint64                   `json:"cost_ms"`
+	Creator      string                   `json:"creator"`
+	CreatedAt    types.Time               `json:"created_at"`
+}
@@ -35,3 +35,12 @@ type GlobalConfig struct {
 	// Memo global config memo
 	Memo *string `json:"memo"`
 }
+
+// SubnetReservedCidrConfigType 业务预留子网网段配置类型，config_key为业务ID，config_value为预留网段列表
+const SubnetReservedCidrConfigType = "subnet_reserved_cidr"
+
+// SubnetReservedCidr 业务预留的子网网段
+type SubnetReservedCidr struct {
+	Cidr string  `json:"cidr" validate:"required,cidr"`
+	Memo *string `json:"memo,omitempty"`
+}
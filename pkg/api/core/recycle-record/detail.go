@@ -96,6 +96,18 @@ type CvmRecycleOptions struct {
 // DiskRecycleOptions disk recycle record options.
 type DiskRecycleOptions struct{}
 
+// DiskSnapshotRecycleOptions disk snapshot recycle record options.
+type DiskSnapshotRecycleOptions struct{}
+
+// EipRecycleOptions eip recycle record options.
+type EipRecycleOptions struct{}
+
+// LoadBalancerRecycleOptions load balancer recycle record options.
+type LoadBalancerRecycleOptions struct{}
+
+// SecurityGroupRecycleOptions security group recycle record options.
+type SecurityGroupRecycleOptions struct{}
+
 // DiskRelatedRecycleOpt 磁盘作为关联资源回收时的回收选项，记录关联的cvm_id
 type DiskRelatedRecycleOpt struct {
 	CvmID string `json:"cvm_id"`
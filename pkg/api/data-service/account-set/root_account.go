@@ -35,7 +35,8 @@ import (
 type RootAccountExtensionCreateReq interface {
 	AwsRootAccountExtensionCreateReq | GcpRootAccountExtensionCreateReq |
 		AzureRootAccountExtensionCreateReq | HuaWeiRootAccountExtensionCreateReq |
-		ZenlayerRootAccountExtensionCreateReq | KaopuRootAccountExtensionCreateReq
+		ZenlayerRootAccountExtensionCreateReq | KaopuRootAccountExtensionCreateReq |
+		TCloudRootAccountExtensionCreateReq
 }
 
 // AwsRootAccountExtensionCreateReq ...
@@ -52,8 +53,10 @@ func (req *AwsRootAccountExtensionCreateReq) Validate() error {
 }
 
 // EncryptSecretKey encrypt secret key
-func (req *AwsRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
+func (req *AwsRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudSecretKey, err = cipher.EncryptToBase64(req.CloudSecretKey)
+	return err
 }
 
 // GcpRootAccountExtensionCreateReq ...
@@ -73,8 +76,10 @@ func (req *GcpRootAccountExtensionCreateReq) Validate() error {
 }
 
 // EncryptSecretKey encrypt secret key
-func (req *GcpRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudServiceSecretKey = cipher.EncryptToBase64(req.CloudServiceSecretKey)
+func (req *GcpRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudServiceSecretKey, err = cipher.EncryptToBase64(req.CloudServiceSecretKey)
+	return err
 }
 
 // AzureRootAccountExtensionCreateReq ...
@@ -94,8 +99,10 @@ func (req *AzureRootAccountExtensionCreateReq) Validate() error {
 }
 
 // EncryptSecretKey encrypt secret key
-func (req *AzureRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudClientSecretKey = cipher.EncryptToBase64(req.CloudClientSecretKey)
+func (req *AzureRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudClientSecretKey, err = cipher.EncryptToBase64(req.CloudClientSecretKey)
+	return err
 }
 
 // HuaWeiRootAccountExtensionCreateReq ...
@@ -114,8 +121,10 @@ func (req *HuaWeiRootAccountExtensionCreateReq) Validate() error {
 }
 
 // EncryptSecretKey ...
-func (req *HuaWeiRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
+func (req *HuaWeiRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudSecretKey, err = cipher.EncryptToBase64(req.CloudSecretKey)
+	return err
 }
 
 // ZenlayerRootAccountExtensionCreateReq ...
@@ -129,8 +138,8 @@ func (req *ZenlayerRootAccountExtensionCreateReq) Validate() error {
 }
 
 // EncryptSecretKey ...
-func (req *ZenlayerRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-
+func (req *ZenlayerRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	return nil
 }
 
 // KaopuRootAccountExtensionCreateReq ...
@@ -144,7 +153,28 @@ func (req *KaopuRootAccountExtensionCreateReq) Validate() error {
 }
 
 // EncryptSecretKey ...
-func (req *KaopuRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *KaopuRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	return nil
+}
+
+// TCloudRootAccountExtensionCreateReq ...
+type TCloudRootAccountExtensionCreateReq struct {
+	CloudMainAccountID string `json:"cloud_main_account_id" validate:"required"`
+	CloudSubAccountID  string `json:"cloud_sub_account_id" validate:"required"`
+	CloudSecretID      string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey     string `json:"cloud_secret_key" validate:"required"`
+}
+
+// Validate ...
+func (req *TCloudRootAccountExtensionCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// EncryptSecretKey ...
+func (req *TCloudRootAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudSecretKey, err = cipher.EncryptToBase64(req.CloudSecretKey)
+	return err
 }
 
 // RootAccountCreateReq ...
@@ -171,7 +201,8 @@ func (c *RootAccountCreateReq[T]) Validate() error {
 type RootAccountExtensionUpdateReq interface {
 	AwsRootAccountExtensionUpdateReq | GcpRootAccountExtensionUpdateReq |
 		HuaWeiRootAccountExtensionUpdateReq | AzureRootAccountExtensionUpdateReq |
-		ZenlayerRootAccountExtensionUpdateReq | KaopuRootAccountExtensionUpdateReq
+		ZenlayerRootAccountExtensionUpdateReq | KaopuRootAccountExtensionUpdateReq |
+		TCloudRootAccountExtensionUpdateReq
 }
 
 // AwsRootAccountExtensionUpdateReq ...
@@ -183,11 +214,16 @@ type AwsRootAccountExtensionUpdateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *AwsRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *AwsRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudSecretKey != nil {
-		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
+		encryptedCloudSecretKey, err := cipher.EncryptToBase64(*req.CloudSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudSecretKey = &encryptedCloudSecretKey
 	}
+
+	return nil
 }
 
 // HuaWeiRootAccountExtensionUpdateReq ...
@@ -201,11 +237,16 @@ type HuaWeiRootAccountExtensionUpdateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *HuaWeiRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *HuaWeiRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudSecretKey != nil {
-		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
+		encryptedCloudSecretKey, err := cipher.EncryptToBase64(*req.CloudSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudSecretKey = &encryptedCloudSecretKey
 	}
+
+	return nil
 }
 
 // GcpRootAccountExtensionUpdateReq ...
@@ -222,11 +263,16 @@ type GcpRootAccountExtensionUpdateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *GcpRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *GcpRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudServiceSecretKey != nil {
-		encryptedCloudServiceSecretKey := cipher.EncryptToBase64(*req.CloudServiceSecretKey)
+		encryptedCloudServiceSecretKey, err := cipher.EncryptToBase64(*req.CloudServiceSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudServiceSecretKey = &encryptedCloudServiceSecretKey
 	}
+
+	return nil
 }
 
 // AzureRootAccountExtensionUpdateReq ...
@@ -242,11 +288,16 @@ type AzureRootAccountExtensionUpdateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *AzureRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *AzureRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudClientSecretKey != nil {
-		encryptedCloudClientSecretKey := cipher.EncryptToBase64(*req.CloudClientSecretKey)
+		encryptedCloudClientSecretKey, err := cipher.EncryptToBase64(*req.CloudClientSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudClientSecretKey = &encryptedCloudClientSecretKey
 	}
+
+	return nil
 }
 
 // ZenlayerRootAccountExtensionUpdateReq ...
@@ -254,8 +305,8 @@ type ZenlayerRootAccountExtensionUpdateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *ZenlayerRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-
+func (req *ZenlayerRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	return nil
 }
 
 // KaopuRootAccountExtensionUpdateReq ...
@@ -263,8 +314,28 @@ type KaopuRootAccountExtensionUpdateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *KaopuRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *KaopuRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	return nil
+}
+
+// TCloudRootAccountExtensionUpdateReq ...
+type TCloudRootAccountExtensionUpdateReq struct {
+	CloudSubAccountID string  `json:"cloud_sub_account_id,omitempty" validate:"omitempty"`
+	CloudSecretID     *string `json:"cloud_secret_id,omitempty" validate:"omitempty"`
+	CloudSecretKey    *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
+}
+
+// EncryptSecretKey ...
+func (req *TCloudRootAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	if req.CloudSecretKey != nil {
+		encryptedCloudSecretKey, err := cipher.EncryptToBase64(*req.CloudSecretKey)
+		if err != nil {
+			return err
+		}
+		req.CloudSecretKey = &encryptedCloudSecretKey
+	}
 
+	return nil
 }
 
 // RootAccountUpdateReq 不允许对extension更新，允许更新字段：负责人/备份负责人/组织架构/运营产品/业务，单独更新状态
@@ -299,7 +370,8 @@ type RootAccountGetBaseResp struct {
 type RootAccountExtensionGetResp interface {
 	protocore.AwsRootAccountExtension | protocore.GcpRootAccountExtension |
 		protocore.HuaWeiRootAccountExtension | protocore.AzureRootAccountExtension |
-		protocore.ZenlayerRootAccountExtension | protocore.KaopuRootAccountExtension
+		protocore.ZenlayerRootAccountExtension | protocore.KaopuRootAccountExtension |
+		protocore.TCloudRootAccountExtension
 }
 
 // RootAccountGetResult ...
@@ -314,6 +386,9 @@ type AwsRootAccount = RootAccountGetResult[protocore.AwsRootAccountExtension]
 // HuaweiRootAccount ...
 type HuaweiRootAccount = RootAccountGetResult[protocore.HuaWeiRootAccountExtension]
 
+// TCloudRootAccount ...
+type TCloudRootAccount = RootAccountGetResult[protocore.TCloudRootAccountExtension]
+
 // RootAccountGetResp ...
 type RootAccountGetResp[T RootAccountExtensionGetResp] struct {
 	rest.BaseResp `json:",inline"`
@@ -338,7 +413,7 @@ type RootAccountListResp struct {
 // RootSecretEncryptor ... 用于加密"泛型"Extension密钥
 type RootSecretEncryptor[T RootAccountExtensionCreateReq | RootAccountExtensionUpdateReq] interface {
 	// EncryptSecretKey 加密约束，将密钥进行加密设置
-	EncryptSecretKey(cryptography.Crypto)
+	EncryptSecretKey(cryptography.Crypto) error
 	*T
 }
 
@@ -37,6 +37,7 @@ type ApplicationCreateReq struct {
 	Applicant      string                   `json:"applicant" validate:"required"`
 	Content        string                   `json:"content" validate:"required"`
 	DeliveryDetail string                   `json:"delivery_detail" validate:"required"`
+	ApprovalDetail string                   `json:"approval_detail" validate:"omitempty"`
 	Memo           *string                  `json:"memo" validate:"omitempty"`
 }
 
@@ -49,6 +50,9 @@ func (req *ApplicationCreateReq) Validate() error {
 type ApplicationUpdateReq struct {
 	Status         enumor.ApplicationStatus `json:"status" validate:"required"`
 	DeliveryDetail *string                  `json:"delivery_detail" validate:"omitempty"`
+	ApprovalDetail *string                  `json:"approval_detail" validate:"omitempty"`
+	// ApprovalRevision 不为空时，仅当单据当前审批详情的版本号与之相等时才更新，避免并发审批互相覆盖
+	ApprovalRevision *int64 `json:"approval_revision" validate:"omitempty"`
 }
 
 // Validate ...
@@ -67,10 +71,36 @@ type ApplicationResp struct {
 	Applicant      string                   `json:"applicant"`
 	Content        string                   `json:"content"`
 	DeliveryDetail string                   `json:"delivery_detail"`
+	ApprovalDetail string                   `json:"approval_detail"`
 	Memo           *string                  `json:"memo"`
 	core.Revision  `json:",inline"`
 }
 
+// ApprovalDetail 内置审批引擎的审批详情
+type ApprovalDetail struct {
+	// Revision 每次审批操作后递增，用于并发控制
+	Revision int64 `json:"revision"`
+	// CurrentStage 当前所处审批节点的下标
+	CurrentStage int `json:"current_stage"`
+	// StageStartedAt 当前节点开始审批的时间，用于计算超时
+	StageStartedAt string `json:"stage_started_at"`
+	// CurrentApprovers 当前节点的审批人，审批结束后为空，用于查询待我审批的单据
+	CurrentApprovers []string `json:"current_approvers"`
+	// Stages 创建单据时审批流程节点的快照，流程配置变更不影响已创建的单据
+	Stages  []ApprovalStage  `json:"stages"`
+	Records []ApprovalRecord `json:"records"`
+}
+
+// ApprovalRecord 审批操作记录
+type ApprovalRecord struct {
+	Stage      int                   `json:"stage"`
+	Operator   string                `json:"operator"`
+	Action     enumor.ApprovalAction `json:"action"`
+	TransferTo []string              `json:"transfer_to,omitempty"`
+	Memo       string                `json:"memo,omitempty"`
+	OperatedAt string                `json:"operated_at"`
+}
+
 // ApplicationGetResp ...
 type ApplicationGetResp struct {
 	rest.BaseResp `json:",inline"`
@@ -20,6 +20,9 @@
 package dataservice
 
 import (
+	"errors"
+	"fmt"
+
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
@@ -27,25 +30,89 @@ import (
 	"hcm/pkg/runtime/filter"
 )
 
+// ApprovalProcessDefaultBizID 对所有业务生效的默认审批流程的业务ID
+const ApprovalProcessDefaultBizID int64 = -1
+
 // ApprovalProcessCreateReq ...
 type ApprovalProcessCreateReq struct {
 	ApplicationType enumor.ApplicationType `json:"application_type" validate:"required"`
-	ServiceID       int64                  `json:"service_id" validate:"required,min=1"`
+	// BkBizID 业务ID，-1表示对所有业务生效的默认流程
+	BkBizID   int64                 `json:"bk_biz_id" validate:"required"`
+	Engine    enumor.ApprovalEngine `json:"engine" validate:"required"`
+	ServiceID int64                 `json:"service_id" validate:"omitempty,min=1"`
+	Managers  string                `json:"managers" validate:"omitempty,max=255"`
+	Stages    []ApprovalStage       `json:"stages" validate:"omitempty,dive"`
 }
 
 // Validate ...
 func (req *ApprovalProcessCreateReq) Validate() error {
-	return validator.Validate.Struct(req)
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Engine.Validate(); err != nil {
+		return err
+	}
+
+	switch req.Engine {
+	case enumor.ApprovalEngineItsm:
+		if req.ServiceID <= 0 || len(req.Managers) == 0 {
+			return errors.New("service_id and managers are required by itsm approval engine")
+		}
+	case enumor.ApprovalEngineNative:
+		if len(req.Stages) == 0 {
+			return errors.New("stages is required by native approval engine")
+		}
+	}
+
+	return validateApprovalStages(req.Stages)
 }
 
 // ApprovalProcessUpdateReq ...
 type ApprovalProcessUpdateReq struct {
-	ServiceID int64 `json:"service_id" validate:"required,min=1"`
+	Engine    enumor.ApprovalEngine `json:"engine" validate:"omitempty"`
+	ServiceID int64                 `json:"service_id" validate:"omitempty,min=1"`
+	Managers  string                `json:"managers" validate:"omitempty,max=255"`
+	Stages    []ApprovalStage       `json:"stages" validate:"omitempty,dive"`
 }
 
 // Validate ...
 func (req *ApprovalProcessUpdateReq) Validate() error {
-	return validator.Validate.Struct(req)
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Engine) != 0 {
+		if err := req.Engine.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validateApprovalStages(req.Stages)
+}
+
+// ApprovalStage 内置审批引擎的审批节点，节点按顺序依次审批，节点内任意一个审批人同意即进入下个节点
+type ApprovalStage struct {
+	Name      string   `json:"name" validate:"required,max=64"`
+	Approvers []string `json:"approvers" validate:"required,min=1"`
+	// TimeoutHour 节点审批超时时间（小时），为0表示不超时
+	TimeoutHour int64 `json:"timeout_hour" validate:"min=0"`
+	// TimeoutAction 节点超时后的自动处理方式，仅支持 approve 和 reject
+	TimeoutAction enumor.ApprovalAction `json:"timeout_action" validate:"omitempty"`
+}
+
+func validateApprovalStages(stages []ApprovalStage) error {
+	for idx, stage := range stages {
+		if stage.TimeoutHour == 0 {
+			continue
+		}
+
+		if stage.TimeoutAction != enumor.ApprovalActionApprove && stage.TimeoutAction != enumor.ApprovalActionReject {
+			return fmt.Errorf("stage[%d] timeout_action should be approve or reject", idx)
+		}
+	}
+
+	return nil
 }
 
 // ApprovalProcessListReq ...
@@ -63,8 +130,11 @@ func (l *ApprovalProcessListReq) Validate() error {
 type ApprovalProcessResp struct {
 	ID              string                 `json:"id"`
 	ApplicationType enumor.ApplicationType `json:"application_type"`
+	BkBizID         int64                  `json:"bk_biz_id"`
+	Engine          enumor.ApprovalEngine  `json:"engine"`
 	ServiceID       int64                  `json:"service_id"`
 	Managers        string                 `json:"managers"`
+	Stages          []ApprovalStage        `json:"stages"`
 	core.Revision   `json:",inline"`
 }
 
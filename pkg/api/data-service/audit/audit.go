@@ -141,6 +141,8 @@ func (o *OperationAction) ConvAuditAction() (enumor.AuditAction, error) {
 		return enumor.Associate, nil
 	case Disassociate:
 		return enumor.Disassociate, nil
+	case Rollback:
+		return enumor.Rollback, nil
 
 	default:
 		return "", fmt.Errorf("action is not corresponding audit action")
@@ -157,6 +159,8 @@ const (
 	Associate OperationAction = "associate"
 	// Disassociate 解绑、解挂载等操作
 	Disassociate OperationAction = "disassociate"
+	// Rollback 回滚，如使用快照回滚云盘
+	Rollback OperationAction = "rollback"
 )
 
 // CloudResourceOperationAuditReq define cloud resource operation audit req.
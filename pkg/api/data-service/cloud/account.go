@@ -44,11 +44,16 @@ type TCloudAccountExtensionCreateReq struct {
 	CloudSubAccountID  string `json:"cloud_sub_account_id" validate:"required"`
 	CloudSecretID      string `json:"cloud_secret_id" validate:"omitempty"`
 	CloudSecretKey     string `json:"cloud_secret_key" validate:"omitempty"`
+
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode,omitempty" validate:"omitempty"`
+	CloudRoleArn   string                       `json:"cloud_role_arn,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
-func (req *TCloudAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
+func (req *TCloudAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudSecretKey, err = cipher.EncryptToBase64(req.CloudSecretKey)
+	return err
 }
 
 // AwsAccountExtensionCreateReq ...
@@ -57,11 +62,17 @@ type AwsAccountExtensionCreateReq struct {
 	CloudIamUsername string `json:"cloud_iam_username" validate:"required"`
 	CloudSecretID    string `json:"cloud_secret_id" validate:"omitempty"`
 	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
+
+	CredentialMode  enumor.AccountCredentialMode `json:"credential_mode,omitempty" validate:"omitempty"`
+	CloudRoleArn    string                       `json:"cloud_role_arn,omitempty" validate:"omitempty"`
+	CloudExternalID string                       `json:"cloud_external_id,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
-func (req *AwsAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
+func (req *AwsAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudSecretKey, err = cipher.EncryptToBase64(req.CloudSecretKey)
+	return err
 }
 
 // HuaWeiAccountExtensionCreateReq ...
@@ -75,8 +86,10 @@ type HuaWeiAccountExtensionCreateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *HuaWeiAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
+func (req *HuaWeiAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudSecretKey, err = cipher.EncryptToBase64(req.CloudSecretKey)
+	return err
 }
 
 // GcpAccountExtensionCreateReq ...
@@ -91,8 +104,10 @@ type GcpAccountExtensionCreateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *GcpAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudServiceSecretKey = cipher.EncryptToBase64(req.CloudServiceSecretKey)
+func (req *GcpAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudServiceSecretKey, err = cipher.EncryptToBase64(req.CloudServiceSecretKey)
+	return err
 }
 
 // AzureAccountExtensionCreateReq ...
@@ -104,11 +119,15 @@ type AzureAccountExtensionCreateReq struct {
 	CloudApplicationID    string `json:"cloud_application_id" validate:"omitempty"`
 	CloudApplicationName  string `json:"cloud_application_name" validate:"omitempty"`
 	CloudClientSecretKey  string `json:"cloud_client_secret_key" validate:"omitempty"`
+
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
-func (req *AzureAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudClientSecretKey = cipher.EncryptToBase64(req.CloudClientSecretKey)
+func (req *AzureAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
+	var err error
+	req.CloudClientSecretKey, err = cipher.EncryptToBase64(req.CloudClientSecretKey)
+	return err
 }
 
 // AccountCreateReq ...
@@ -141,14 +160,22 @@ type TCloudAccountExtensionUpdateReq struct {
 	CloudSubAccountID  string  `json:"cloud_sub_account_id,omitempty" validate:"omitempty"`
 	CloudSecretID      *string `json:"cloud_secret_id,omitempty" validate:"omitempty"`
 	CloudSecretKey     *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
+
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode,omitempty" validate:"omitempty"`
+	CloudRoleArn   string                       `json:"cloud_role_arn,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
-func (req *TCloudAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *TCloudAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudSecretKey != nil {
-		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
+		encryptedCloudSecretKey, err := cipher.EncryptToBase64(*req.CloudSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudSecretKey = &encryptedCloudSecretKey
 	}
+
+	return nil
 }
 
 type AwsAccountExtensionUpdateReq struct {
@@ -156,14 +183,23 @@ type AwsAccountExtensionUpdateReq struct {
 	CloudIamUsername string  `json:"cloud_iam_username,omitempty" validate:"omitempty"`
 	CloudSecretID    *string `json:"cloud_secret_id,omitempty" validate:"omitempty"`
 	CloudSecretKey   *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
+
+	CredentialMode  enumor.AccountCredentialMode `json:"credential_mode,omitempty" validate:"omitempty"`
+	CloudRoleArn    string                       `json:"cloud_role_arn,omitempty" validate:"omitempty"`
+	CloudExternalID string                       `json:"cloud_external_id,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
-func (req *AwsAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *AwsAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudSecretKey != nil {
-		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
+		encryptedCloudSecretKey, err := cipher.EncryptToBase64(*req.CloudSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudSecretKey = &encryptedCloudSecretKey
 	}
+
+	return nil
 }
 
 type HuaWeiAccountExtensionUpdateReq struct {
@@ -176,11 +212,16 @@ type HuaWeiAccountExtensionUpdateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *HuaWeiAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *HuaWeiAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudSecretKey != nil {
-		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
+		encryptedCloudSecretKey, err := cipher.EncryptToBase64(*req.CloudSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudSecretKey = &encryptedCloudSecretKey
 	}
+
+	return nil
 }
 
 type GcpAccountExtensionUpdateReq struct {
@@ -194,11 +235,16 @@ type GcpAccountExtensionUpdateReq struct {
 }
 
 // EncryptSecretKey ...
-func (req *GcpAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *GcpAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudServiceSecretKey != nil {
-		encryptedCloudServiceSecretKey := cipher.EncryptToBase64(*req.CloudServiceSecretKey)
+		encryptedCloudServiceSecretKey, err := cipher.EncryptToBase64(*req.CloudServiceSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudServiceSecretKey = &encryptedCloudServiceSecretKey
 	}
+
+	return nil
 }
 
 type AzureAccountExtensionUpdateReq struct {
@@ -210,14 +256,21 @@ type AzureAccountExtensionUpdateReq struct {
 	CloudApplicationName  *string `json:"cloud_application_name,omitempty" validate:"omitempty"`
 	CloudClientSecretID   *string `json:"cloud_client_secret_id,omitempty" validate:"omitempty"`
 	CloudClientSecretKey  *string `json:"cloud_client_secret_key,omitempty" validate:"omitempty"`
+
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
-func (req *AzureAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+func (req *AzureAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) error {
 	if req.CloudClientSecretKey != nil {
-		encryptedCloudClientSecretKey := cipher.EncryptToBase64(*req.CloudClientSecretKey)
+		encryptedCloudClientSecretKey, err := cipher.EncryptToBase64(*req.CloudClientSecretKey)
+		if err != nil {
+			return err
+		}
 		req.CloudClientSecretKey = &encryptedCloudClientSecretKey
 	}
+
+	return nil
 }
 
 // AccountUpdateReq ...
@@ -328,7 +381,7 @@ type AccountWithExtensionListResp struct {
 // SecretEncryptor 用于加密"泛型"Extension密钥
 type SecretEncryptor[T AccountExtensionCreateReq | AccountExtensionUpdateReq] interface {
 	// EncryptSecretKey 加密约束，将密钥进行加密设置
-	EncryptSecretKey(cryptography.Crypto)
+	EncryptSecretKey(cryptography.Crypto) error
 	*T
 }
 
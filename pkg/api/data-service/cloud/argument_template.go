@@ -46,6 +46,7 @@ type ArgsTplBatchCreate[Extension coreargstpl.Extension] struct {
 	Name           string              `json:"name" validate:"required"`
 	Vendor         string              `json:"vendor" validate:"required"`
 	AccountID      string              `json:"account_id" validate:"required"`
+	Region         string              `json:"region" validate:"omitempty"`
 	BkBizID        int64               `json:"bk_biz_id" validate:"omitempty"`
 	Type           enumor.TemplateType `json:"type"`
 	Templates      types.JsonField     `json:"templates"`
@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dsbizassign resource auto assign to biz rule data service api.
+package dsbizassign
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corebizassign "hcm/pkg/api/core/cloud/biz-assign-rule"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// BatchCreateReq define batch create biz assign rule request.
+type BatchCreateReq struct {
+	Rules []RuleCreate `json:"rules" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchCreateReq.
+func (req *BatchCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Rules {
+		if err := corebizassign.ValidateResType(one.ResType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RuleCreate define biz assign rule create field.
+type RuleCreate struct {
+	Name     string                   `json:"name" validate:"required,max=255"`
+	ResType  enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Rule     *filter.Expression       `json:"rule" validate:"required"`
+	BkBizID  int64                    `json:"bk_biz_id" validate:"required,min=1"`
+	Priority uint32                   `json:"priority" validate:"omitempty"`
+	Enabled  bool                     `json:"enabled" validate:"omitempty"`
+	Memo     *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// BatchUpdateReq define batch update biz assign rule request.
+type BatchUpdateReq struct {
+	Rules []RuleUpdate `json:"rules" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchUpdateReq.
+func (req *BatchUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RuleUpdate define biz assign rule update field, resource type of rule can not be updated.
+type RuleUpdate struct {
+	ID       string             `json:"id" validate:"required"`
+	Name     string             `json:"name" validate:"omitempty,max=255"`
+	Rule     *filter.Expression `json:"rule" validate:"omitempty"`
+	BkBizID  int64              `json:"bk_biz_id" validate:"omitempty,min=1"`
+	Priority *uint32            `json:"priority" validate:"omitempty"`
+	Enabled  *bool              `json:"enabled" validate:"omitempty"`
+	Memo     *string            `json:"memo" validate:"omitempty,max=255"`
+}
+
+// ListReq define list biz assign rule request.
+type ListReq struct {
+	core.ListReq `json:",inline"`
+}
+
+// Validate ListReq.
+func (req *ListReq) Validate() error {
+	return req.ListReq.Validate()
+}
+
+// ListResp define list biz assign rule response.
+type ListResp core.ListResultT[corebizassign.BizAssignRule]
+
+// BatchDeleteReq define batch delete biz assign rule request.
+type BatchDeleteReq struct {
+	core.BatchDeleteReq `json:",inline"`
+}
+
+// Validate BatchDeleteReq.
+func (req *BatchDeleteReq) Validate() error {
+	if err := req.BatchDeleteReq.Validate(); err != nil {
+		return err
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// PreviewReq define preview resources matched by biz assign rule request, one of rule id and rule should be set.
+type PreviewReq struct {
+	RuleID  string                   `json:"rule_id" validate:"omitempty"`
+	ResType enumor.CloudResourceType `json:"res_type" validate:"omitempty"`
+	Rule    *filter.Expression       `json:"rule" validate:"omitempty"`
+	BkBizID int64                    `json:"bk_biz_id" validate:"omitempty,min=1"`
+	// AccountID 仅预览该账号下的资源，为空时预览所有与规则业务关联的账号下的资源
+	AccountID string `json:"account_id" validate:"omitempty"`
+}
+
+// Validate PreviewReq.
+func (req *PreviewReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.RuleID) != 0 {
+		if req.Rule != nil || len(req.ResType) != 0 || req.BkBizID != 0 {
+			return errors.New("res_type, rule and bk_biz_id can not be set when rule_id is set")
+		}
+		return nil
+	}
+
+	if req.Rule == nil || req.BkBizID == 0 {
+		return errors.New("rule and bk_biz_id are required when rule_id is not set")
+	}
+
+	return corebizassign.ValidateResType(req.ResType)
+}
+
+// PreviewResult define preview resources matched by biz assign rule result.
+type PreviewResult struct {
+	// Count 规则匹配的未分配资源数量
+	Count uint64 `json:"count"`
+	// ResIDs 规则匹配的未分配资源ID，最多返回 MaxPreviewResCount 个
+	ResIDs []string `json:"res_ids"`
+}
+
+// MaxPreviewResCount 预览时最多返回的资源ID数量
+const MaxPreviewResCount = 500
+
+// ApplyReq define apply biz assign rules request, all enabled rules are applied when rule ids is empty.
+type ApplyReq struct {
+	// AccountID 仅分配该账号下的资源，为空时分配所有与规则业务关联的账号下的资源
+	AccountID string   `json:"account_id" validate:"omitempty"`
+	RuleIDs   []string `json:"rule_ids" validate:"omitempty,max=100"`
+}
+
+// Validate ApplyReq.
+func (req *ApplyReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ApplyResult define apply biz assign rules result.
+type ApplyResult struct {
+	Details []corebizassign.AssignResult `json:"details"`
+}
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot defines data-service disk snapshot protocols.
+package disksnapshot
+
+import (
+	"fmt"
+
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// DiskSnapshotExtCreateReq ...
+type DiskSnapshotExtCreateReq[T coresnapshot.Extension] struct {
+	AccountID   string  `json:"account_id" validate:"required"`
+	CloudID     string  `json:"cloud_id" validate:"required"`
+	BkBizID     int64   `json:"bk_biz_id"`
+	Name        string  `json:"name" validate:"omitempty"`
+	Region      string  `json:"region" validate:"omitempty"`
+	Zone        string  `json:"zone" validate:"omitempty"`
+	DiskID      string  `json:"disk_id" validate:"omitempty"`
+	CloudDiskID string  `json:"cloud_disk_id" validate:"required"`
+	DiskSize    uint64  `json:"disk_size"`
+	Status      string  `json:"status"`
+	Memo        *string `json:"memo"`
+	Extension   *T      `json:"extension"`
+}
+
+// Validate ...
+func (req *DiskSnapshotExtCreateReq[T]) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotExtBatchCreateReq ...
+type DiskSnapshotExtBatchCreateReq[T coresnapshot.Extension] []*DiskSnapshotExtCreateReq[T]
+
+// Validate ...
+func (req *DiskSnapshotExtBatchCreateReq[T]) Validate() error {
+	if len(*req) == 0 || len(*req) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("disk snapshot count should between 1 and %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, r := range *req {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// -------------------------- Update --------------------------
+
+// DiskSnapshotExtUpdateReq ...
+type DiskSnapshotExtUpdateReq[T coresnapshot.Extension] struct {
+	ID          string  `json:"id" validate:"required"`
+	Name        string  `json:"name"`
+	Zone        string  `json:"zone"`
+	DiskID      string  `json:"disk_id"`
+	CloudDiskID string  `json:"cloud_disk_id"`
+	DiskSize    uint64  `json:"disk_size"`
+	Status      string  `json:"status"`
+	Memo        *string `json:"memo"`
+	Extension   *T      `json:"extension"`
+}
+
+// Validate ...
+func (req *DiskSnapshotExtUpdateReq[T]) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotExtBatchUpdateReq ...
+type DiskSnapshotExtBatchUpdateReq[T coresnapshot.Extension] []*DiskSnapshotExtUpdateReq[T]
+
+// Validate ...
+func (req *DiskSnapshotExtBatchUpdateReq[T]) Validate() error {
+	if len(*req) == 0 || len(*req) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("disk snapshot count should between 1 and %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, r := range *req {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiskSnapshotBatchUpdateReq 批量更新快照基础字段，用于分配业务、修改备注等
+type DiskSnapshotBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required,min=1,max=100"`
+	BkBizID int64    `json:"bk_biz_id" validate:"omitempty,min=-1"`
+	Memo    *string  `json:"memo"`
+}
+
+// Validate ...
+func (req *DiskSnapshotBatchUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Delete --------------------------
+
+// DiskSnapshotDeleteReq ...
+type DiskSnapshotDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate ...
+func (req *DiskSnapshotDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines data-service ssh key pair protocols.
+package keypair
+
+import (
+	"fmt"
+
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// KeyPairExtCreateReq ...
+type KeyPairExtCreateReq[T corekeypair.Extension] struct {
+	AccountID   string  `json:"account_id" validate:"required"`
+	CloudID     string  `json:"cloud_id" validate:"required"`
+	BkBizID     int64   `json:"bk_biz_id"`
+	Name        string  `json:"name" validate:"omitempty"`
+	Region      string  `json:"region" validate:"omitempty"`
+	Fingerprint string  `json:"fingerprint" validate:"omitempty"`
+	PublicKey   string  `json:"public_key" validate:"omitempty"`
+	Memo        *string `json:"memo"`
+	Extension   *T      `json:"extension"`
+}
+
+// Validate ...
+func (req *KeyPairExtCreateReq[T]) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// KeyPairExtBatchCreateReq ...
+type KeyPairExtBatchCreateReq[T corekeypair.Extension] []*KeyPairExtCreateReq[T]
+
+// Validate ...
+func (req *KeyPairExtBatchCreateReq[T]) Validate() error {
+	if len(*req) == 0 || len(*req) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("key pair count should between 1 and %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, r := range *req {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// -------------------------- Update --------------------------
+
+// KeyPairExtUpdateReq ...
+type KeyPairExtUpdateReq[T corekeypair.Extension] struct {
+	ID          string  `json:"id" validate:"required"`
+	Name        string  `json:"name"`
+	Fingerprint string  `json:"fingerprint"`
+	PublicKey   string  `json:"public_key"`
+	Memo        *string `json:"memo"`
+	Extension   *T      `json:"extension"`
+}
+
+// Validate ...
+func (req *KeyPairExtUpdateReq[T]) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// KeyPairExtBatchUpdateReq ...
+type KeyPairExtBatchUpdateReq[T corekeypair.Extension] []*KeyPairExtUpdateReq[T]
+
+// Validate ...
+func (req *KeyPairExtBatchUpdateReq[T]) Validate() error {
+	if len(*req) == 0 || len(*req) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("key pair count should between 1 and %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, r := range *req {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KeyPairBatchUpdateReq 批量更新密钥对基础字段，用于分配业务、修改备注等
+type KeyPairBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required,min=1,max=100"`
+	BkBizID int64    `json:"bk_biz_id" validate:"omitempty,min=-1"`
+	Memo    *string  `json:"memo"`
+}
+
+// Validate ...
+func (req *KeyPairBatchUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Delete --------------------------
+
+// KeyPairDeleteReq ...
+type KeyPairDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate ...
+func (req *KeyPairDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -45,6 +45,18 @@ type TCloudCLBCreateReq = LoadBalancerBatchCreateReq[corelb.TCloudClbExtension]
 // TCloudCLBCreate create load balancer
 type TCloudCLBCreate = LbBatchCreate[corelb.TCloudClbExtension]
 
+// AwsLBCreateReq batch create aws load balancer
+type AwsLBCreateReq = LoadBalancerBatchCreateReq[corelb.AwsLoadBalancerExtension]
+
+// AwsLBCreate create aws load balancer
+type AwsLBCreate = LbBatchCreate[corelb.AwsLoadBalancerExtension]
+
+// HuaWeiLBCreateReq batch create huawei load balancer
+type HuaWeiLBCreateReq = LoadBalancerBatchCreateReq[corelb.HuaWeiLoadBalancerExtension]
+
+// HuaWeiLBCreate create huawei load balancer
+type HuaWeiLBCreate = LbBatchCreate[corelb.HuaWeiLoadBalancerExtension]
+
 // LbBatchCreate define load balancer batch create.
 type LbBatchCreate[Extension corelb.Extension] struct {
 	CloudID          string               `json:"cloud_id" validate:"required"`
@@ -137,6 +149,12 @@ func (req *LbExtBatchUpdateReq[T]) Validate() error {
 // TCloudClbBatchUpdateReq ...
 type TCloudClbBatchUpdateReq = LbExtBatchUpdateReq[corelb.TCloudClbExtension]
 
+// AwsLBBatchUpdateReq ...
+type AwsLBBatchUpdateReq = LbExtBatchUpdateReq[corelb.AwsLoadBalancerExtension]
+
+// HuaWeiLBBatchUpdateReq ...
+type HuaWeiLBBatchUpdateReq = LbExtBatchUpdateReq[corelb.HuaWeiLoadBalancerExtension]
+
 // BizBatchUpdateReq 批量更新业务id
 type BizBatchUpdateReq struct {
 	IDs     []string `json:"ids" validate:"required"`
@@ -193,6 +211,12 @@ type ListenerListResult = core.ListResultT[corelb.BaseListener]
 // TCloudListenerListResult ...
 type TCloudListenerListResult = core.ListResultT[corelb.Listener[corelb.TCloudListenerExtension]]
 
+// AwsListenerListResult ...
+type AwsListenerListResult = core.ListResultT[corelb.AwsListener]
+
+// HuaWeiListenerListResult ...
+type HuaWeiListenerListResult = core.ListResultT[corelb.HuaWeiListener]
+
 // -------------------------- List Count Listener By LbIDs --------------------------
 
 // ListListenerCountByLbIDsReq define list listener count by lbIDs req.
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dsrestag resource tag data service api.
+package dsrestag
+
+import (
+	"hcm/pkg/api/core"
+	corerestag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// SyncReq define sync resource tag request, tags of each resource will be replaced by the given tags.
+type SyncReq struct {
+	Vendor    enumor.Vendor            `json:"vendor" validate:"required"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	AccountID string                   `json:"account_id" validate:"required"`
+	Resources []SyncResource           `json:"resources" validate:"required,min=1,max=500"`
+}
+
+// Validate SyncReq.
+func (req *SyncReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	return corerestag.ValidateVendorResType(req.Vendor, req.ResType)
+}
+
+// SyncResource define resource and its all tags on cloud.
+type SyncResource struct {
+	CloudID string      `json:"cloud_id" validate:"required"`
+	Tags    core.TagMap `json:"tags" validate:"omitempty"`
+}
+
+// BatchUpsertReq define batch add or update tags of resources request.
+type BatchUpsertReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResIDs  []string                 `json:"res_ids" validate:"required,min=1,max=100"`
+	Tags    []core.TagPair           `json:"tags" validate:"required,min=1,max=50"`
+}
+
+// Validate BatchUpsertReq.
+func (req *BatchUpsertReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := corerestag.ValidateResType(req.ResType); err != nil {
+		return err
+	}
+
+	return corerestag.ValidateTags(req.Tags)
+}
+
+// BatchRemoveReq define batch remove tags of resources request.
+type BatchRemoveReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResIDs  []string                 `json:"res_ids" validate:"required,min=1,max=100"`
+	TagKeys []string                 `json:"tag_keys" validate:"required,min=1,max=50"`
+}
+
+// Validate BatchRemoveReq.
+func (req *BatchRemoveReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corerestag.ValidateResType(req.ResType)
+}
+
+// ListReq define list resource tag request.
+type ListReq struct {
+	core.ListReq `json:",inline"`
+}
+
+// Validate ListReq.
+func (req *ListReq) Validate() error {
+	return req.ListReq.Validate()
+}
+
+// ListResp define list resource tag response.
+type ListResp core.ListResultT[corerestag.ResourceTag]
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dssgruletpl security group rule template data service api.
+package dssgruletpl
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresgruletpl "hcm/pkg/api/core/cloud/sg-rule-template"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Template --------------------------
+
+// BatchCreateReq define batch create security group rule template request.
+type BatchCreateReq struct {
+	Templates []TemplateCreate `json:"templates" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchCreateReq.
+func (req *BatchCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Templates {
+		if err := coresgruletpl.ValidateRules(one.Rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TemplateCreate define security group rule template create field.
+type TemplateCreate struct {
+	Name    string               `json:"name" validate:"required,max=255"`
+	BkBizID int64                `json:"bk_biz_id" validate:"required,min=1"`
+	Rules   []coresgruletpl.Rule `json:"rules" validate:"required,min=1,max=100"`
+	Memo    *string              `json:"memo" validate:"omitempty,max=255"`
+}
+
+// BatchUpdateReq define batch update security group rule template request.
+type BatchUpdateReq struct {
+	Templates []TemplateUpdate `json:"templates" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchUpdateReq.
+func (req *BatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Templates {
+		if one.Rules == nil {
+			continue
+		}
+
+		if err := coresgruletpl.ValidateRules(one.Rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TemplateUpdate define security group rule template update field, rules changed will increase template version.
+type TemplateUpdate struct {
+	ID    string               `json:"id" validate:"required"`
+	Name  string               `json:"name" validate:"omitempty,max=255"`
+	Rules []coresgruletpl.Rule `json:"rules" validate:"omitempty,min=1,max=100"`
+	Memo  *string              `json:"memo" validate:"omitempty,max=255"`
+}
+
+// ListReq define list security group rule template request.
+type ListReq struct {
+	core.ListReq `json:",inline"`
+}
+
+// Validate ListReq.
+func (req *ListReq) Validate() error {
+	return req.ListReq.Validate()
+}
+
+// ListResp define list security group rule template response.
+type ListResp core.ListResultT[coresgruletpl.SGRuleTemplate]
+
+// BatchDeleteReq define batch delete security group rule template request, rels of template will be deleted too.
+type BatchDeleteReq struct {
+	core.BatchDeleteReq `json:",inline"`
+}
+
+// Validate BatchDeleteReq.
+func (req *BatchDeleteReq) Validate() error {
+	if err := req.BatchDeleteReq.Validate(); err != nil {
+		return err
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- Rel --------------------------
+
+// RelBatchCreateReq define batch create security group rule template rel request.
+type RelBatchCreateReq struct {
+	Rels []RelCreate `json:"rels" validate:"required,min=1,max=100"`
+}
+
+// Validate RelBatchCreateReq.
+func (req *RelBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RelCreate define security group rule template rel create field.
+type RelCreate struct {
+	TemplateID      string        `json:"template_id" validate:"required"`
+	SecurityGroupID string        `json:"security_group_id" validate:"required"`
+	Vendor          enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID       string        `json:"account_id" validate:"required"`
+}
+
+// RelBatchUpdateReq define batch update security group rule template rel request.
+type RelBatchUpdateReq struct {
+	Rels []RelUpdate `json:"rels" validate:"required,min=1,max=100"`
+}
+
+// Validate RelBatchUpdateReq.
+func (req *RelBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Rels {
+		if len(one.ApplyStatus) == 0 {
+			continue
+		}
+
+		if err := one.ApplyStatus.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RelUpdate define security group rule template rel update field.
+type RelUpdate struct {
+	ID             string                           `json:"id" validate:"required"`
+	AppliedVersion uint64                           `json:"applied_version" validate:"omitempty"`
+	ApplyStatus    enumor.SGRuleTemplateApplyStatus `json:"apply_status" validate:"omitempty"`
+	ApplyMessage   *string                          `json:"apply_message" validate:"omitempty,max=1024"`
+	AppliedAt      string                           `json:"applied_at" validate:"omitempty"`
+}
+
+// RelListReq define list security group rule template rel request.
+type RelListReq struct {
+	core.ListReq `json:",inline"`
+}
+
+// Validate RelListReq.
+func (req *RelListReq) Validate() error {
+	return req.ListReq.Validate()
+}
+
+// RelListResp define list security group rule template rel response.
+type RelListResp core.ListResultT[coresgruletpl.SGRuleTemplateRel]
+
+// RelBatchDeleteReq define batch delete security group rule template rel request.
+type RelBatchDeleteReq struct {
+	core.BatchDeleteReq `json:",inline"`
+}
+
+// Validate RelBatchDeleteReq.
+func (req *RelBatchDeleteReq) Validate() error {
+	if err := req.BatchDeleteReq.Validate(); err != nil {
+		return err
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package dssync
+
+import (
+	"time"
+
+	coresync "hcm/pkg/api/core/cloud/sync"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+// CreateRunRecordReq define create sync run record request.
+type CreateRunRecordReq struct {
+	Items []RunRecordCreateField `json:"items" validate:"required,min=1,max=100"`
+}
+
+// Validate CreateRunRecordReq.
+func (req CreateRunRecordReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, item := range req.Items {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunRecordCreateField define sync run record create field.
+type RunRecordCreateField struct {
+	Vendor       enumor.Vendor            `json:"vendor" validate:"required"`
+	AccountID    string                   `json:"account_id" validate:"required"`
+	Scope        enumor.SyncRunScope      `json:"scope" validate:"required"`
+	Region       string                   `json:"region" validate:"omitempty"`
+	ResType      enumor.CloudResourceType `json:"res_type" validate:"omitempty"`
+	State        enumor.SyncStatus        `json:"state" validate:"required"`
+	Added        uint                     `json:"added" validate:"omitempty"`
+	Updated      uint                     `json:"updated" validate:"omitempty"`
+	Deleted      uint                     `json:"deleted" validate:"omitempty"`
+	FailedReason string                   `json:"failed_reason" validate:"omitempty"`
+	Rid          string                   `json:"rid" validate:"omitempty"`
+	StartedAt    time.Time                `json:"started_at" validate:"required"`
+	EndedAt      time.Time                `json:"ended_at" validate:"required"`
+}
+
+// Validate RunRecordCreateField.
+func (req RunRecordCreateField) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Scope == enumor.SyncRunResourceScope && len(req.ResType) == 0 {
+		return errf.New(errf.InvalidParameter, "res_type is required for resource scope")
+	}
+
+	return req.State.Validate()
+}
+
+// ListRunRecordResult defines list sync run record result.
+type ListRunRecordResult struct {
+	Count   uint64                   `json:"count"`
+	Details []coresync.SyncRunRecord `json:"details"`
+}
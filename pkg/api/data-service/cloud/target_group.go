@@ -107,8 +107,16 @@ func (req *TargetGroupBatchCreateReq[T]) Validate() error {
 // TCloudTargetGroupCreateReq ...
 type TCloudTargetGroupCreateReq = TargetGroupBatchCreateReq[corelb.TCloudTargetGroupExtension]
 
+// AwsTargetGroupCreateReq ...
+type AwsTargetGroupCreateReq = TargetGroupBatchCreateReq[corelb.AwsTargetGroupExtension]
+
+// HuaWeiTargetGroupCreateReq ...
+type HuaWeiTargetGroupCreateReq = TargetGroupBatchCreateReq[corelb.HuaWeiTargetGroupExtension]
+
 // TargetGroupBatchCreate define target group batch create.
 type TargetGroupBatchCreate[Extension corelb.TargetGroupExtension] struct {
+	// CloudID 云上目标组ID，本地目标组不传，此时与本地ID相同
+	CloudID         string                 `json:"cloud_id" validate:"omitempty"`
 	Name            string                 `json:"name" validate:"required"`
 	Vendor          enumor.Vendor          `json:"vendor" validate:"required"`
 	AccountID       string                 `json:"account_id" validate:"required"`
@@ -239,6 +247,12 @@ func (req *TargetGroupBatchUpdateReq[T]) Validate() error {
 	return nil
 }
 
+// AwsTargetGroupBatchUpdateReq ...
+type AwsTargetGroupBatchUpdateReq = TargetGroupBatchUpdateReq[corelb.AwsTargetGroupExtension]
+
+// HuaWeiTargetGroupBatchUpdateReq ...
+type HuaWeiTargetGroupBatchUpdateReq = TargetGroupBatchUpdateReq[corelb.HuaWeiTargetGroupExtension]
+
 // -------------------------- List Target Group --------------------------
 
 // TargetGroupListResult define target group list result.
@@ -250,6 +264,12 @@ type TargetGroupExtListResult[T corelb.TargetGroupExtension] struct {
 	Details []corelb.TargetGroup[T] `json:"details,omitempty"`
 }
 
+// AwsTargetGroupListResult ...
+type AwsTargetGroupListResult = TargetGroupExtListResult[corelb.AwsTargetGroupExtension]
+
+// HuaWeiTargetGroupListResult ...
+type HuaWeiTargetGroupListResult = TargetGroupExtListResult[corelb.HuaWeiTargetGroupExtension]
+
 // -------------------------- Delete Target Group --------------------------
 
 // TargetGroupBatchDeleteReq delete request.
@@ -306,6 +326,12 @@ func (req *TGListenerRelStatusUpdateReq) Validate() error {
 // TCloudListenerBatchCreateReq ...
 type TCloudListenerBatchCreateReq = ListenerBatchCreateReq[corelb.TCloudListenerExtension]
 
+// AwsListenerBatchCreateReq ...
+type AwsListenerBatchCreateReq = ListenerBatchCreateReq[corelb.AwsListenerExtension]
+
+// HuaWeiListenerBatchCreateReq ...
+type HuaWeiListenerBatchCreateReq = ListenerBatchCreateReq[corelb.HuaWeiListenerExtension]
+
 // ListenerBatchCreateReq listener batch create req.
 type ListenerBatchCreateReq[T corelb.ListenerExtension] struct {
 	Listeners []ListenersCreateReq[T] `json:"listeners" validate:"required,min=1,dive,required"`
@@ -398,6 +424,12 @@ type ListenerBatchUpdateReq[Extension corelb.ListenerExtension] struct {
 // TCloudListenerUpdateReq ...
 type TCloudListenerUpdateReq = ListenerBatchUpdateReq[corelb.TCloudListenerExtension]
 
+// AwsListenerUpdateReq ...
+type AwsListenerUpdateReq = ListenerBatchUpdateReq[corelb.AwsListenerExtension]
+
+// HuaWeiListenerUpdateReq ...
+type HuaWeiListenerUpdateReq = ListenerBatchUpdateReq[corelb.HuaWeiListenerExtension]
+
 // Validate 验证监听器更新参数
 func (req *ListenerBatchUpdateReq[T]) Validate() error {
 	for _, item := range req.Listeners {
@@ -427,6 +459,12 @@ func (req *ListenerUpdateReq[T]) Validate() error {
 // TCloudListenerUpdate ...
 type TCloudListenerUpdate = ListenerUpdateReq[corelb.TCloudListenerExtension]
 
+// AwsListenerUpdate ...
+type AwsListenerUpdate = ListenerUpdateReq[corelb.AwsListenerExtension]
+
+// HuaWeiListenerUpdate ...
+type HuaWeiListenerUpdate = ListenerUpdateReq[corelb.HuaWeiListenerExtension]
+
 // -------------------------- Create Target --------------------------
 
 // TargetBatchCreateReq batch create target req
@@ -26,32 +26,45 @@ import (
 
 // TCloudAccountCheckReq ...
 type TCloudAccountCheckReq struct {
-	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
-	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required_unless=CredentialMode role"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required_unless=CredentialMode role"`
 
 	CloudMainAccountID string `json:"cloud_main_account_id" validate:"required"`
 	CloudSubAccountID  string `json:"cloud_sub_account_id" validate:"required"`
+
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode" validate:"omitempty"`
+	CloudRoleArn   string                       `json:"cloud_role_arn" validate:"required_if=CredentialMode role"`
 }
 
 // Validate ...
 func (r *TCloudAccountCheckReq) Validate() error {
 	// TODO: 是否还需要添加其他规则校验呢？
+	if err := validateCredentialMode(r.CredentialMode); err != nil {
+		return err
+	}
 	return validator.Validate.Struct(r)
 }
 
 // AwsAccountCheckReq ...
 type AwsAccountCheckReq struct {
-	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
-	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required_unless=CredentialMode role"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required_unless=CredentialMode role"`
 
 	CloudAccountID   string `json:"cloud_account_id" validate:"required"`
 	CloudIamUsername string `json:"cloud_iam_username" validate:"required"`
 
 	Site enumor.AccountSiteType `json:"site"`
+
+	CredentialMode  enumor.AccountCredentialMode `json:"credential_mode" validate:"omitempty"`
+	CloudRoleArn    string                       `json:"cloud_role_arn" validate:"required_if=CredentialMode role"`
+	CloudExternalID string                       `json:"cloud_external_id" validate:"omitempty"`
 }
 
 // Validate ...
 func (r *AwsAccountCheckReq) Validate() error {
+	if err := validateCredentialMode(r.CredentialMode); err != nil {
+		return err
+	}
 	return validator.Validate.Struct(r)
 }
 
@@ -91,14 +104,27 @@ func (r *GcpAccountCheckReq) Validate() error {
 type AzureAccountCheckReq struct {
 	CloudTenantID        string `json:"cloud_tenant_id" validate:"required"`
 	CloudApplicationID   string `json:"cloud_application_id" validate:"required"`
-	CloudClientSecretKey string `json:"cloud_client_secret_key" validate:"required"`
+	CloudClientSecretKey string `json:"cloud_client_secret_key" validate:"required_unless=CredentialMode role"`
 
 	CloudSubscriptionID   string `json:"cloud_subscription_id" validate:"required"`
 	CloudSubscriptionName string `json:"cloud_subscription_name" validate:"required"`
 	CloudApplicationName  string `json:"cloud_application_name" validate:"required"`
+
+	CredentialMode enumor.AccountCredentialMode `json:"credential_mode" validate:"omitempty"`
 }
 
 // Validate ...
 func (r *AzureAccountCheckReq) Validate() error {
+	if err := validateCredentialMode(r.CredentialMode); err != nil {
+		return err
+	}
 	return validator.Validate.Struct(r)
 }
+
+// validateCredentialMode 凭证模式为空时视为密钥模式
+func validateCredentialMode(mode enumor.AccountCredentialMode) error {
+	if len(mode) == 0 {
+		return nil
+	}
+	return mode.Validate()
+}
@@ -425,3 +425,40 @@ type AzureRootBillListReq struct {
 func (r *AzureRootBillListReq) Validate() error {
 	return validator.Validate.Struct(r)
 }
+
+// TCloudRootBillListReq tcloud root account bill list
+type TCloudRootBillListReq struct {
+	// 本地主账号
+	RootAccountID string `json:"root_account_id" validate:"required"`
+	// 云上二级账号UIN
+	MainAccountCloudID string `json:"main_account_cloud_id" validate:"required"`
+	// 月份，格式为yyyy-mm，Month和BeginDate&EndDate必传一个，如果有BeginDate&EndDate则Month字段无效
+	Month string `json:"month" validate:"omitempty"`
+	// 起始日期，格式为Y-m-d H:i:s，不支持跨月查询
+	BeginDate string `json:"begin_date" validate:"omitempty"`
+	// 截止日期，格式为Y-m-d H:i:s，不支持跨月查询
+	EndDate string `json:"end_date" validate:"omitempty"`
+	// Limit: 最大值为100
+	Page *core.TCloudPage `json:"page" validate:"omitempty"`
+	// 上一次请求返回的上下文信息，可用于加快翻页查询速度
+	Context *string `json:"context" validate:"omitempty"`
+}
+
+// Validate ...
+func (r *TCloudRootBillListReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if r.Month == "" && (r.BeginDate == "" || r.EndDate == "") {
+		return errf.New(errf.InvalidParameter, "month or begin_date and end_date is required")
+	}
+
+	if r.Page != nil {
+		if err := r.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
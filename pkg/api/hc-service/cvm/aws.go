@@ -126,7 +126,8 @@ type AwsBatchCreateReq struct {
 	PublicIPAssigned      bool                            `json:"public_ip_assigned" validate:"omitempty"`
 	CloudSecurityGroupIDs []string                        `json:"cloud_security_group_ids" validate:"required"`
 	BlockDeviceMapping    []typecvm.AwsBlockDeviceMapping `json:"block_device_mapping" validate:"required"`
-	Password              string                          `json:"password" validate:"required"`
+	Password              string                          `json:"password" validate:"omitempty"`
+	CloudKeyPairID        string                          `json:"cloud_key_pair_id" validate:"omitempty"`
 	RequiredCount         int64                           `json:"required_count" validate:"required"`
 	ClientToken           *string                         `json:"client_token" validate:"omitempty"`
 }
@@ -137,6 +138,10 @@ func (req *AwsBatchCreateReq) Validate() error {
 		return fmt.Errorf("required_count should <= %d", constant.BatchCreateCvmFromCloudMaxLimit)
 	}
 
+	if err := validateLoginSettings(req.Password, req.CloudKeyPairID); err != nil {
+		return err
+	}
+
 	return validator.Validate.Struct(req)
 }
 
@@ -78,7 +78,8 @@ type AzureCreateReq struct {
 	InstanceType         string                  `json:"instance_type" validate:"required"`
 	CloudImageID         string                  `json:"cloud_image_id" validate:"required"`
 	Username             string                  `json:"username" validate:"required"`
-	Password             string                  `json:"password" validate:"required"`
+	Password             string                  `json:"password" validate:"omitempty"`
+	CloudKeyPairID       string                  `json:"cloud_key_pair_id" validate:"omitempty"`
 	CloudSubnetID        string                  `json:"cloud_subnet_id" validate:"required"`
 	CloudSecurityGroupID string                  `json:"cloud_security_group_id" validate:"required"`
 	OSDisk               *typecvm.AzureOSDisk    `json:"os_disk" validate:"required"`
@@ -88,6 +89,10 @@ type AzureCreateReq struct {
 
 // Validate request.
 func (req *AzureCreateReq) Validate() error {
+	if err := validateLoginSettings(req.Password, req.CloudKeyPairID); err != nil {
+		return err
+	}
+
 	return validator.Validate.Struct(req)
 }
 
@@ -52,3 +52,12 @@ type OperateSyncReq struct {
 	CloudIDs  []string `json:"cloud_ids" validate:"omitempty"`
 	SelfLinks []string `json:"self_links" validate:"omitempty"`
 }
+
+// validateLoginSettings 校验主机登录方式，登录密码与SSH密钥对至少指定其一
+func validateLoginSettings(password, cloudKeyPairID string) error {
+	if len(password) == 0 && len(cloudKeyPairID) == 0 {
+		return fmt.Errorf("password or cloud_key_pair_id is required")
+	}
+
+	return nil
+}
@@ -141,7 +141,8 @@ type HuaWeiBatchCreateReq struct {
 	Zone                  string                        `json:"zone" validate:"required"`
 	InstanceType          string                        `json:"instance_type" validate:"required"`
 	CloudImageID          string                        `json:"cloud_image_id" validate:"required"`
-	Password              string                        `json:"password" validate:"required"`
+	Password              string                        `json:"password" validate:"omitempty"`
+	CloudKeyPairID        string                        `json:"cloud_key_pair_id" validate:"omitempty"`
 	RequiredCount         int32                         `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string                      `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string                       `json:"client_token" validate:"omitempty"`
@@ -161,5 +162,9 @@ func (req *HuaWeiBatchCreateReq) Validate() error {
 		return fmt.Errorf("required_count should <= %d", constant.BatchCreateCvmFromCloudMaxLimit)
 	}
 
+	if err := validateLoginSettings(req.Password, req.CloudKeyPairID); err != nil {
+		return err
+	}
+
 	return validator.Validate.Struct(req)
 }
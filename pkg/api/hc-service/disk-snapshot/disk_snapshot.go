@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot defines hc-service disk snapshot api.
+package disksnapshot
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// DiskSnapshotCreateReq 基于云盘创建快照，账号、地域等信息从云盘中获取
+type DiskSnapshotCreateReq struct {
+	DiskID      string  `json:"disk_id" validate:"required"`
+	Name        string  `json:"name" validate:"required,max=60"`
+	Description *string `json:"description" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *DiskSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotDeleteReq ...
+type DiskSnapshotDeleteReq struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// Validate ...
+func (req *DiskSnapshotDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotRollbackReq 将快照回滚到其源云盘，会覆盖源云盘上的现有数据，仅 tcloud、huawei 支持
+type DiskSnapshotRollbackReq struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// Validate ...
+func (req *DiskSnapshotRollbackReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskFromSnapshotCreateReq 基于快照创建新云盘，aws、azure、gcp 不支持原地回滚，以此代替回滚操作
+type DiskFromSnapshotCreateReq struct {
+	ID string `json:"id" validate:"required"`
+	// DiskName 新云盘名称，azure、gcp 必填
+	DiskName string `json:"disk_name" validate:"omitempty"`
+	// Zone 新云盘可用区，aws、gcp 必填，为空时使用源云盘可用区
+	Zone     string `json:"zone" validate:"omitempty"`
+	DiskType string `json:"disk_type" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *DiskFromSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
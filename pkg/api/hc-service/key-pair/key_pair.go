@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines hc-service key pair api.
+package keypair
+
+import (
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/criteria/validator"
+)
+
+// KeyPairCreateReq 创建或导入SSH密钥对，指定公钥时为导入，否则由云上生成密钥对并返回私钥
+type KeyPairCreateReq struct {
+	AccountID string  `json:"account_id" validate:"required"`
+	Region    string  `json:"region" validate:"required"`
+	Name      string  `json:"name" validate:"required,max=255"`
+	PublicKey *string `json:"public_key" validate:"omitempty"`
+	// ProjectID 密钥对所属项目，仅 tcloud 支持
+	ProjectID *int64 `json:"project_id" validate:"omitempty"`
+	// ResourceGroupName 资源组名称，azure 必填
+	ResourceGroupName string `json:"resource_group_name" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *KeyPairCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// KeyPairCreateResult 创建SSH密钥对结果，私钥仅在由云上生成密钥对时返回，HCM不保存私钥
+type KeyPairCreateResult struct {
+	ID         string `json:"id"`
+	CloudID    string `json:"cloud_id"`
+	PrivateKey string `json:"private_key,omitempty"`
+}
+
+// KeyPairDeleteReq ...
+type KeyPairDeleteReq struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// Validate ...
+func (req *KeyPairDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TCloudKeyPairAssociateReq 绑定/解绑密钥对与主机，主机需处于关机状态，ForceStop 为 true 时会强制关机
+type TCloudKeyPairAssociateReq struct {
+	ID        string `json:"id" validate:"required"`
+	CvmID     string `json:"cvm_id" validate:"required"`
+	ForceStop *bool  `json:"force_stop" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *TCloudKeyPairAssociateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// HuaWeiKeyPairAssociateReq 绑定/解绑密钥对与主机，仅支持Linux主机，主机运行中时需通过 Auth 提供当前登录凭证
+type HuaWeiKeyPairAssociateReq struct {
+	ID    string                         `json:"id" validate:"required"`
+	CvmID string                         `json:"cvm_id" validate:"required"`
+	Auth  *typekeypair.HuaWeiKeyPairAuth `json:"auth" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *HuaWeiKeyPairAssociateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package hcrestag resource tag hc service api.
+package hcrestag
+
+import (
+	"hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/api/core"
+	corerestag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// BatchAddReq define batch add or update tags of cloud resources request.
+type BatchAddReq struct {
+	AccountID string                   `json:"account_id" validate:"required"`
+	Region    string                   `json:"region" validate:"omitempty"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []TagResource            `json:"resources" validate:"required,min=1,max=20,dive"`
+	Tags      []core.TagPair           `json:"tags" validate:"required,min=1,max=10"`
+}
+
+// Validate BatchAddReq.
+func (req *BatchAddReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corerestag.ValidateTags(req.Tags)
+}
+
+// BatchRemoveReq define batch remove tags of cloud resources request.
+type BatchRemoveReq struct {
+	AccountID string                   `json:"account_id" validate:"required"`
+	Region    string                   `json:"region" validate:"omitempty"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []TagResource            `json:"resources" validate:"required,min=1,max=20,dive"`
+	TagKeys   []string                 `json:"tag_keys" validate:"required,min=1,max=10"`
+}
+
+// Validate BatchRemoveReq.
+func (req *BatchRemoveReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TagResource define resource to be tagged.
+type TagResource struct {
+	ID      string `json:"id" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+	Name    string `json:"name" validate:"omitempty"`
+	Zone    string `json:"zone" validate:"omitempty"`
+}
+
+// IDs return local ids of the resources.
+func IDs(resources []TagResource) []string {
+	ids := make([]string, 0, len(resources))
+	for _, one := range resources {
+		ids = append(ids, one.ID)
+	}
+	return ids
+}
+
+// ToCloudResources convert to adaptor tag resources.
+func ToCloudResources(resources []TagResource) []resourcetag.TagResource {
+	result := make([]resourcetag.TagResource, 0, len(resources))
+	for _, one := range resources {
+		result = append(result, resourcetag.TagResource{CloudID: one.CloudID, Name: one.Name, Zone: one.Zone})
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// IncrementalSyncReq 增量同步请求，根据时间区间内的云上资源变更事件，只同步发生变更的资源
+type IncrementalSyncReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// Regions 查询变更事件的地域，仅 Aws 需要
+	Regions []string `json:"regions" validate:"omitempty,max=100"`
+	// StartTime 事件开始时间，格式为 constant.TimeStdFormat
+	StartTime string `json:"start_time" validate:"required"`
+	// EndTime 事件结束时间，格式为 constant.TimeStdFormat
+	EndTime string `json:"end_time" validate:"required"`
+}
+
+// Validate incremental sync request.
+func (req *IncrementalSyncReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	start, end, err := req.TimeRange()
+	if err != nil {
+		return err
+	}
+
+	if !end.After(start) {
+		return errors.New("end_time should be after start_time")
+	}
+
+	return nil
+}
+
+// TimeRange parse start time and end time.
+func (req *IncrementalSyncReq) TimeRange() (time.Time, time.Time, error) {
+	start, err := time.Parse(constant.TimeStdFormat, req.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end, err := time.Parse(constant.TimeStdFormat, req.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return start, end, nil
+}
+
+// IncrementalSyncResult 增量同步结果
+type IncrementalSyncResult struct {
+	EventCount int `json:"event_count"`
+	// Synced 各类型同步成功的资源数量
+	Synced map[enumor.CloudResourceType]int `json:"synced"`
+	// Failed 各类型同步失败的资源数量，失败的资源由周期全量同步兜底
+	Failed map[enumor.CloudResourceType]int `json:"failed"`
+}
@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/rest"
+)
+
+// SyncDeleteOption 资源同步的预览及删除确认参数
+type SyncDeleteOption struct {
+	// DryRun 只计算同步计划并返回，不写入任何数据
+	DryRun bool `json:"dry_run,omitempty"`
+	// DeleteCheck 只以预览模式执行删除对比，返回扫描及待删除的本地资源数量，用于账号维度汇总删除保护检查
+	DeleteCheck bool `json:"delete_check,omitempty"`
+}
+
+// SyncPlanResult 资源同步预览结果
+type SyncPlanResult struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	// Scanned 删除对比时扫描的本地资源数量
+	Scanned uint `json:"scanned"`
+	Added   uint `json:"added"`
+	Updated uint `json:"updated"`
+	Deleted uint `json:"deleted"`
+	// 待变更资源的云ID，每类最多返回1000个
+	AddCloudIDs    []string `json:"add_cloud_ids"`
+	UpdateCloudIDs []string `json:"update_cloud_ids"`
+	DeleteCloudIDs []string `json:"delete_cloud_ids"`
+	// Truncated 云ID列表是否被截断
+	Truncated bool `json:"truncated"`
+}
+
+// SyncPlanResp 资源同步预览返回
+type SyncPlanResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SyncPlanResult `json:"data"`
+}
@@ -28,6 +28,8 @@ import (
 // TCloudGlobalSyncReq tcloud sync request
 type TCloudGlobalSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate tcloud sync request.
@@ -45,6 +47,8 @@ type TCloudSyncReq struct {
 	Concurrent uint `json:"concurrent,omitempty"`
 	// 指定标签同步，仅特定资源支持
 	TagFilters core.MultiValueTagMap `json:"tag_filters,omitempty"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate tcloud sync request.
@@ -55,6 +59,8 @@ func (req *TCloudSyncReq) Validate() error {
 // AwsGlobalSyncReq aws sync request
 type AwsGlobalSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate aws sync request.
@@ -66,6 +72,8 @@ func (req *AwsGlobalSyncReq) Validate() error {
 type AwsSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate aws sync request.
@@ -76,6 +84,8 @@ func (req *AwsSyncReq) Validate() error {
 // HuaWeiGlobalSyncReq huawei sync request
 type HuaWeiGlobalSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate huawei sync request.
@@ -87,6 +97,8 @@ func (req *HuaWeiGlobalSyncReq) Validate() error {
 type HuaWeiSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate huawei sync request.
@@ -99,6 +111,8 @@ type HuaWeiSubnetSyncReq struct {
 	AccountID  string `json:"account_id" validate:"required"`
 	CloudVpcID string `json:"cloud_vpc_id" validate:"required"`
 	Region     string `json:"region" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate huawei sync request.
@@ -111,6 +125,8 @@ type GcpCvmSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
 	Zone      string `json:"zone" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate gcp sync request.
@@ -122,6 +138,8 @@ func (req *GcpCvmSyncReq) Validate() error {
 type GcpSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate gcp sync request.
@@ -132,6 +150,8 @@ func (req *GcpSyncReq) Validate() error {
 // GcpGlobalSyncReq gcp sync request
 type GcpGlobalSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate gcp sync request.
@@ -143,6 +163,8 @@ func (req *GcpGlobalSyncReq) Validate() error {
 type GcpDiskSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Zone      string `json:"zone" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate gcp disk sync request.
@@ -153,6 +175,8 @@ func (req *GcpDiskSyncReq) Validate() error {
 // GcpRouteSyncReq gcp route sync request
 type GcpRouteSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate gcp route sync request.
@@ -163,6 +187,8 @@ func (req *GcpRouteSyncReq) Validate() error {
 // GcpFireWallSyncReq gcp firewall sync request
 type GcpFireWallSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate gcp firewall sync request.
@@ -173,6 +199,8 @@ func (req *GcpFireWallSyncReq) Validate() error {
 // AzureGlobalSyncReq azure sync request
 type AzureGlobalSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate azure sync request.
@@ -184,6 +212,8 @@ func (req *AzureGlobalSyncReq) Validate() error {
 type AzureSyncReq struct {
 	AccountID         string `json:"account_id" validate:"required"`
 	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate azure sync request.
@@ -196,6 +226,8 @@ type AzureSubnetSyncReq struct {
 	AccountID         string `json:"account_id" validate:"required"`
 	ResourceGroupName string `json:"resource_group_name" validate:"required"`
 	CloudVpcID        string `json:"cloud_vpc_id" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate azure sync request.
@@ -207,6 +239,8 @@ func (req *AzureSubnetSyncReq) Validate() error {
 type AzureImageReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate azure sync request.
@@ -221,6 +255,8 @@ type TCloudListenerSyncReq struct {
 	LoadBalancerCloudID string `json:"lb_cloud_id" validate:"required"`
 	// 支持传入指定监听器id同步
 	CloudIDs []string `json:"lbl_cloud_ids" validate:"omitempty,max=20"`
+
+	SyncDeleteOption `json:",inline"`
 }
 
 // Validate ...
@@ -20,6 +20,8 @@
 package taskserver
 
 import (
+	"time"
+
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
@@ -36,6 +38,12 @@ type AddTemplateFlowReq struct {
 	Tasks []TemplateFlowTask `json:"tasks" validate:"required, min=1"`
 	// IsInitState 是否初始化状态
 	IsInitState bool `json:"is_init_state" validate:"omitempty"`
+	// ScheduledAt 任务流最早可被派发执行的时间，不设置则立即执行
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty" validate:"omitempty"`
+	// ConcurrencyKey 互斥键，相同互斥键的任务流同时执行的数量不超过 ConcurrencyLimit，如: lb:<id>、account:<id>
+	ConcurrencyKey string `json:"concurrency_key,omitempty" validate:"omitempty,max=128"`
+	// ConcurrencyLimit 相同互斥键任务流的最大并发数，设置了互斥键但未设置并发数时默认为1
+	ConcurrencyLimit uint `json:"concurrency_limit,omitempty" validate:"omitempty"`
 }
 
 // Validate AddTemplateFlowReq
@@ -79,6 +87,12 @@ type AddCustomFlowReq struct {
 	Tasks []CustomFlowTask `json:"tasks" validate:"omitempty"`
 	// IsInitState 是否初始化状态
 	IsInitState bool `json:"is_init_state" validate:"omitempty"`
+	// ScheduledAt 任务流最早可被派发执行的时间，不设置则立即执行
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty" validate:"omitempty"`
+	// ConcurrencyKey 互斥键，相同互斥键的任务流同时执行的数量不超过 ConcurrencyLimit，如: lb:<id>、account:<id>
+	ConcurrencyKey string `json:"concurrency_key,omitempty" validate:"omitempty,max=128"`
+	// ConcurrencyLimit 相同互斥键任务流的最大并发数，设置了互斥键但未设置并发数时默认为1
+	ConcurrencyLimit uint `json:"concurrency_limit,omitempty" validate:"omitempty"`
 }
 
 // Validate AddCustomFlowReq
@@ -19,7 +19,10 @@
 
 package taskserver
 
-import "hcm/pkg/api/core/async"
+import (
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/criteria/enumor"
+)
 
 // ListFlowResult ...
 type ListFlowResult struct {
@@ -32,3 +35,21 @@ type ListTaskResult struct {
 	Count   uint64                    `json:"count"`
 	Details []coreasync.AsyncFlowTask `json:"details"`
 }
+
+// FlowTimelineResult 任务流执行时间线
+type FlowTimelineResult struct {
+	FlowID string           `json:"flow_id"`
+	State  enumor.FlowState `json:"state"`
+	Tasks  []TaskTimeline   `json:"tasks"`
+}
+
+// TaskTimeline 任务执行时间线，事件按发生时间升序排列
+type TaskTimeline struct {
+	TaskID     string                         `json:"task_id"`
+	ActionID   string                         `json:"action_id"`
+	ActionName enumor.ActionName              `json:"action_name"`
+	State      enumor.TaskState               `json:"state"`
+	Attempts   uint                           `json:"attempts"`
+	DurationMS int64                          `json:"duration_ms"`
+	Events     []coreasync.AsyncFlowTaskEvent `json:"events"`
+}
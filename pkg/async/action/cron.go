@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package action
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/tools/cron"
+)
+
+// CronFlow 周期任务流定义，主节点会按照 Spec 定期基于任务流模版创建任务流实例，
+// 创建出的任务流与普通任务流一致，可以查看执行历史、重试以及取消。
+type CronFlow struct {
+	// Name 周期任务唯一标识，会以 CronFlowMemo 的形式记录在任务流备注中，用于判断上一次触发时间
+	Name string `json:"name" validate:"required,max=32"`
+	// Spec 五段式 cron 表达式，如: "0 2 * * *"
+	Spec string `json:"spec" validate:"required"`
+	// FlowName 任务流模版名称
+	FlowName enumor.FlowName `json:"flow_name" validate:"required"`
+	// Params 任务流模版中各任务的请求参数
+	Params map[ActIDType]types.JsonField `json:"params" validate:"omitempty"`
+}
+
+// Validate CronFlow.
+func (cf *CronFlow) Validate() error {
+	if err := validator.Validate.Struct(cf); err != nil {
+		return err
+	}
+
+	if _, err := cron.Parse(cf.Spec); err != nil {
+		return fmt.Errorf("cron flow: %s spec is invalid, err: %v", cf.Name, err)
+	}
+
+	return nil
+}
+
+// Memo 周期任务创建的任务流备注
+func (cf *CronFlow) Memo() string {
+	return CronFlowMemoPrefix + cf.Name
+}
+
+// CronFlowMemoPrefix 周期任务创建的任务流备注前缀
+const CronFlowMemoPrefix = "cron:"
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package action
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+)
+
+// ErrorClass 任务执行错误分类，决定任务失败后是否进行重试。
+type ErrorClass string
+
+const (
+	// ErrorRetryable 可重试错误，如限频、网络抖动等
+	ErrorRetryable ErrorClass = "retryable"
+	// ErrorFatal 不可重试错误，如参数错误等，重试也不会成功
+	ErrorFatal ErrorClass = "fatal"
+)
+
+// ErrorClassifier Action如果需要自定义错误分类，实现该接口。未实现时使用 ClassifyError 中的默认规则。
+type ErrorClassifier interface {
+	ClassifyError(err error) ErrorClass
+}
+
+// classifiedError 已经指定了分类的错误
+type classifiedError struct {
+	class ErrorClass
+	err   error
+}
+
+// Error ...
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap ...
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+// NewRetryableError 将错误标记为可重试错误
+func NewRetryableError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{class: ErrorRetryable, err: err}
+}
+
+// NewFatalError 将错误标记为不可重试错误
+func NewFatalError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{class: ErrorFatal, err: err}
+}
+
+// retryableErrCodes 默认可重试的错误码及错误信息，错误信息中包含其中任一项即认为可重试
+var retryableErrCodes = []string{
+	// 限频
+	constant.TCloudLimitExceededErrCode,
+	"LimitExceeded",
+	"Throttling",
+	"TooManyRequests",
+	// 网络
+	constant.TCloudNetworkErrorErrCode,
+	"connection reset by peer",
+	"connection refused",
+	"i/o timeout",
+}
+
+// ClassifyError 对任务执行错误进行分类，优先级: 错误自身标记 > Action自定义分类 > 默认规则。
+// 默认规则: 云厂商限频、网络错误等明确可重试的错误才进行重试，其余错误均不可重试，避免重复执行非幂等操作。
+func ClassifyError(act Action, err error) ErrorClass {
+	var ce *classifiedError
+	if errors.As(err, &ce) {
+		return ce.class
+	}
+
+	if classifier, ok := act.(ErrorClassifier); ok {
+		if class := classifier.ClassifyError(err); len(class) != 0 {
+			return class
+		}
+	}
+
+	var ef *errf.ErrorF
+	if errors.As(err, &ef) && ef.Code == errf.TooManyRequest {
+		return ErrorRetryable
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorRetryable
+	}
+
+	msg := err.Error()
+	for _, code := range retryableErrCodes {
+		if strings.Contains(msg, code) {
+			return ErrorRetryable
+		}
+	}
+
+	return ErrorFatal
+}
@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+)
+
+type plainAction struct{}
+
+func (plainAction) Name() enumor.ActionName { return enumor.ActionSleep }
+
+func (plainAction) Run(run.ExecuteKit, interface{}) (interface{}, error) { return nil, nil }
+
+type classifierAction struct {
+	plainAction
+	class ErrorClass
+}
+
+func (a classifierAction) ClassifyError(error) ErrorClass { return a.class }
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		name   string
+		act    Action
+		err    error
+		expect ErrorClass
+	}{
+		{name: "unknown error", act: plainAction{}, err: errors.New("unknown"), expect: ErrorFatal},
+		{name: "marked fatal", act: plainAction{}, err: NewFatalError(errors.New("bad")), expect: ErrorFatal},
+		{
+			name:   "wrapped marked fatal",
+			act:    plainAction{},
+			err:    fmt.Errorf("run failed, err: %w", NewFatalError(errors.New("bad"))),
+			expect: ErrorFatal,
+		},
+		{
+			name:   "marked retryable over default rule",
+			act:    plainAction{},
+			err:    NewRetryableError(errf.New(errf.InvalidParameter, "invalid")),
+			expect: ErrorRetryable,
+		},
+		{
+			name:   "marked over action classifier",
+			act:    classifierAction{class: ErrorRetryable},
+			err:    NewFatalError(errors.New("bad")),
+			expect: ErrorFatal,
+		},
+		{
+			name:   "action classifier",
+			act:    classifierAction{class: ErrorFatal},
+			err:    errors.New("unknown"),
+			expect: ErrorFatal,
+		},
+		{
+			name:   "empty action classifier fallback to default",
+			act:    classifierAction{},
+			err:    errf.New(errf.RecordNotFound, "not found"),
+			expect: ErrorFatal,
+		},
+		{
+			name:   "tcloud limit exceeded",
+			act:    plainAction{},
+			err:    errors.New("[TencentCloudSDKError] Code=RequestLimitExceeded, Message=limit"),
+			expect: ErrorRetryable,
+		},
+		{
+			name:   "tcloud network error",
+			act:    plainAction{},
+			err:    errors.New("[TencentCloudSDKError] Code=ClientError.NetworkError, Message=timeout"),
+			expect: ErrorRetryable,
+		},
+		{
+			name:   "invalid parameter",
+			act:    plainAction{},
+			err:    errf.New(errf.InvalidParameter, "bad"),
+			expect: ErrorFatal,
+		},
+		{
+			name:   "wrapped permission denied",
+			act:    plainAction{},
+			err:    fmt.Errorf("run failed, err: %w", errf.New(errf.PermissionDenied, "denied")),
+			expect: ErrorFatal,
+		},
+		{name: "other errf code", act: plainAction{}, err: errf.New(errf.Unknown, "unknown"), expect: ErrorFatal},
+		{
+			name:   "too many request",
+			act:    plainAction{},
+			err:    errf.New(errf.TooManyRequest, "busy"),
+			expect: ErrorRetryable,
+		},
+		{
+			name:   "aws throttling",
+			act:    plainAction{},
+			err:    errors.New("Throttling: Rate exceeded, status code: 400"),
+			expect: ErrorRetryable,
+		},
+		{
+			name:   "huawei limit exceeded",
+			act:    plainAction{},
+			err:    errors.New("error_code: APIGW.0308, error_msg: LimitExceeded"),
+			expect: ErrorRetryable,
+		},
+		{
+			name:   "net error",
+			act:    plainAction{},
+			err:    fmt.Errorf("call api failed, err: %w", &net.OpError{Op: "dial", Err: errors.New("refused")}),
+			expect: ErrorRetryable,
+		},
+		{
+			name:   "context deadline exceeded",
+			act:    plainAction{},
+			err:    fmt.Errorf("call api failed, err: %w", context.DeadlineExceeded),
+			expect: ErrorRetryable,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ClassifyError(c.act, c.err); got != c.expect {
+				t.Errorf("error %v should be classified as %s, got: %s", c.err, c.expect, got)
+			}
+		})
+	}
+}
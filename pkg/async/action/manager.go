@@ -37,6 +37,7 @@ func init() {
 type Manager struct {
 	actionMap  map[enumor.ActionName]Action
 	flowTplMap map[enumor.FlowName]FlowTemplate
+	cronMap    map[string]CronFlow
 	rwLock     *sync.RWMutex
 }
 
@@ -45,6 +46,7 @@ func NewManager() *Manager {
 	return &Manager{
 		actionMap:  make(map[enumor.ActionName]Action),
 		flowTplMap: make(map[enumor.FlowName]FlowTemplate),
+		cronMap:    make(map[string]CronFlow),
 		rwLock:     &sync.RWMutex{},
 	}
 }
@@ -122,6 +124,43 @@ func (am *Manager) GetFlowTpl(name enumor.FlowName) (FlowTemplate, bool) {
 	return tpl, ok
 }
 
+// RegisterCronFlow register cron flows, flow template used by cron flow should be registered first.
+func (am *Manager) RegisterCronFlow(crons ...CronFlow) error {
+	am.rwLock.Lock()
+	defer am.rwLock.Unlock()
+
+	for _, one := range crons {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+
+		if _, exist := am.flowTplMap[one.FlowName]; !exist {
+			return fmt.Errorf("cron flow: %s use flow template: %s not found", one.Name, one.FlowName)
+		}
+
+		if _, exist := am.cronMap[one.Name]; exist {
+			return fmt.Errorf("cron flow: %s repeat", one.Name)
+		}
+
+		am.cronMap[one.Name] = one
+	}
+
+	return nil
+}
+
+// ListCronFlow list all registered cron flows.
+func (am *Manager) ListCronFlow() []CronFlow {
+	am.rwLock.RLock()
+	defer am.rwLock.RUnlock()
+
+	crons := make([]CronFlow, 0, len(am.cronMap))
+	for _, one := range am.cronMap {
+		crons = append(crons, one)
+	}
+
+	return crons
+}
+
 // RegisterAction register action.
 func RegisterAction(acts ...Action) {
 	if err := manager.RegisterAction(acts...); err != nil {
@@ -145,3 +184,15 @@ func RegisterTpl(templates ...FlowTemplate) {
 func GetTpl(name enumor.FlowName) (FlowTemplate, bool) {
 	return manager.GetFlowTpl(name)
 }
+
+// RegisterCronFlow register cron flow.
+func RegisterCronFlow(crons ...CronFlow) {
+	if err := manager.RegisterCronFlow(crons...); err != nil {
+		panic(fmt.Sprintf("register cron flow failed, err: %v", err))
+	}
+}
+
+// ListCronFlow list all registered cron flows.
+func ListCronFlow() []CronFlow {
+	return manager.ListCronFlow()
+}
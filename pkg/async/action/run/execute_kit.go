@@ -30,6 +30,7 @@ type ExecuteKit interface {
 	AsyncKit() *kit.Kit
 	KitWithNewRid() *kit.Kit
 	ShareData() ShareDataOperator
+	Logger() TaskLogger
 }
 
 // ShareDataOperator used to operate share data
@@ -41,9 +42,15 @@ type ShareDataOperator interface {
 
 // NewExecuteContext new execute context for task exec.
 func NewExecuteContext(kt *kit.Kit, shareData ShareDataOperator) ExecuteKit {
+	return NewExecuteContextWithRecorder(kt, shareData, nil)
+}
+
+// NewExecuteContextWithRecorder new execute context for task exec, task logs will be persisted by recorder.
+func NewExecuteContextWithRecorder(kt *kit.Kit, shareData ShareDataOperator, recorder LogRecorder) ExecuteKit {
 	return &DefExecuteContext{
 		kit:       kt,
 		shareData: shareData,
+		logger:    newTaskLogger(kt, recorder),
 	}
 }
 
@@ -51,6 +58,7 @@ func NewExecuteContext(kt *kit.Kit, shareData ShareDataOperator) ExecuteKit {
 type DefExecuteContext struct {
 	kit       *kit.Kit
 	shareData ShareDataOperator
+	logger    TaskLogger
 }
 
 // KitWithNewRid return kit with new rid.
@@ -72,3 +80,8 @@ func (ctx *DefExecuteContext) Kit() *kit.Kit {
 func (ctx *DefExecuteContext) ShareData() ShareDataOperator {
 	return ctx.shareData
 }
+
+// Logger return task logger.
+func (ctx *DefExecuteContext) Logger() TaskLogger {
+	return ctx.logger
+}
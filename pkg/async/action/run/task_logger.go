@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package run
+
+import (
+	"fmt"
+	"sync"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// MaxTaskLogLines 单次任务执行最多持久化的日志条数，超出部分只输出到服务日志
+	MaxTaskLogLines = 200
+	// MaxTaskLogLength 单条任务日志最大长度
+	MaxTaskLogLength = 1024
+)
+
+// TaskLogger 任务日志，输出的日志除了打印到服务日志外，还会作为任务事件持久化，可通过任务时间线查看
+type TaskLogger interface {
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// LogRecorder 任务日志持久化函数
+type LogRecorder func(level enumor.TaskLogLevel, message string)
+
+type taskLogger struct {
+	kt       *kit.Kit
+	recorder LogRecorder
+
+	lock  sync.Mutex
+	lines int
+}
+
+func newTaskLogger(kt *kit.Kit, recorder LogRecorder) *taskLogger {
+	return &taskLogger{kt: kt, recorder: recorder}
+}
+
+// Infof record info level task log.
+func (l *taskLogger) Infof(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logs.Infof("[task log] %s, rid: %s", msg, l.rid())
+	l.record(enumor.TaskLogInfo, msg)
+}
+
+// Warnf record warn level task log.
+func (l *taskLogger) Warnf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logs.Warnf("[task log] %s, rid: %s", msg, l.rid())
+	l.record(enumor.TaskLogWarn, msg)
+}
+
+// Errorf record error level task log.
+func (l *taskLogger) Errorf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logs.Errorf("[task log] %s, rid: %s", msg, l.rid())
+	l.record(enumor.TaskLogError, msg)
+}
+
+func (l *taskLogger) rid() string {
+	if l.kt == nil {
+		return ""
+	}
+	return l.kt.Rid
+}
+
+func (l *taskLogger) record(level enumor.TaskLogLevel, msg string) {
+	if l.recorder == nil {
+		return
+	}
+
+	l.lock.Lock()
+	if l.lines >= MaxTaskLogLines {
+		l.lock.Unlock()
+		return
+	}
+	l.lines++
+	last := l.lines == MaxTaskLogLines
+	l.lock.Unlock()
+
+	if last {
+		msg = fmt.Sprintf("task log lines reached limit %d, subsequent logs are omitted. %s", MaxTaskLogLines, msg)
+	}
+	if len(msg) > MaxTaskLogLength {
+		msg = msg[:MaxTaskLogLength]
+	}
+	l.recorder(level, msg)
+}
@@ -119,7 +119,7 @@ type Sleep struct{}
 
 // Rollback ...
 func (s Sleep) Rollback(kt run.ExecuteKit, params interface{}) error {
-	logs.Infof(" ----------- Sleep Rollback -----------, rid: %s", kt.Kit().Rid)
+	kt.Logger().Infof(" ----------- Sleep Rollback -----------")
 	return nil
 }
 
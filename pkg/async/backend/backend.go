@@ -56,6 +56,14 @@ type Backend interface {
 
 	// RetryTask 重试任务 将flow置为running, task 置为pending
 	RetryTask(kt *kit.Kit, flowID, taskID string) error
+
+	/*
+		TaskEvent 相关接口
+	*/
+	// BatchCreateTaskEvent 批量创建任务事件
+	BatchCreateTaskEvent(kt *kit.Kit, events []model.TaskEvent) error
+	// ListTaskEvent 查询任务事件
+	ListTaskEvent(kt *kit.Kit, input *ListInput) ([]model.TaskEvent, error)
 }
 
 // ListInput 查询输入参数
@@ -0,0 +1,433 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/times"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	flowBucket      = []byte("flow")
+	taskBucket      = []byte("task")
+	taskEventBucket = []byte("task_event")
+)
+
+// NewBolt create bolt backend instance, data is persisted in a local bolt file, used for single node
+// deployment without mysql. the file is locked by current process, so it can not be shared by multiple nodes.
+func NewBolt(path string) (Backend, error) {
+	if len(path) == 0 {
+		return nil, errors.New("bolt file path is required")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("create bolt file dir failed, err: %v", err)
+	}
+
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt file %s failed, err: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{flowBucket, taskBucket, taskEventBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create bolt buckets failed, err: %v", err)
+	}
+
+	return &boltBackend{db: db}, nil
+}
+
+// boltBackend 嵌入式bolt存储，每个表对应一个bucket，记录以json存储，id使用bucket自增序列。
+// 查询时在内存中按条件过滤及分页，与memory实现共用过滤和字段处理逻辑。
+type boltBackend struct {
+	db *bbolt.DB
+}
+
+var _ Backend = new(boltBackend)
+
+// Close close bolt file.
+func (b *boltBackend) Close() error {
+	return b.db.Close()
+}
+
+func nextBoltID(bucket *bbolt.Bucket) (string, error) {
+	seq, err := bucket.NextSequence()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%08d", seq), nil
+}
+
+// getBoltRecord 获取记录，记录不存在时返回nil
+func getBoltRecord[T any](bucket *bbolt.Bucket, id string) (*T, error) {
+	raw := bucket.Get([]byte(id))
+	if raw == nil {
+		return nil, nil
+	}
+
+	record := new(T)
+	if err := json.Unmarshal(raw, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func putBoltRecord[T any](bucket *bbolt.Bucket, id string, record *T) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(id), raw)
+}
+
+// listBoltRecords 遍历bucket，按条件过滤后分页
+func listBoltRecords[T any](db *bbolt.DB, name []byte, input *ListInput, fields func(*T) map[string]interface{}) (
+	[]T, error) {
+
+	if input == nil {
+		return nil, errors.New("list input is required")
+	}
+
+	matched := make([]*T, 0)
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(name).ForEach(func(_, raw []byte) error {
+			record := new(T)
+			if err := json.Unmarshal(raw, record); err != nil {
+				return err
+			}
+
+			hit, err := matchExpression(input.Filter, fields(record))
+			if err != nil {
+				return err
+			}
+			if hit {
+				matched = append(matched, record)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err = pageRecords(matched, input, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]T, 0, len(matched))
+	for _, one := range matched {
+		records = append(records, *one)
+	}
+	return records, nil
+}
+
+// CreateFlow 创建任务流，任务流及其任务在同一事务中写入
+func (b *boltBackend) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
+	if flow == nil {
+		return "", errors.New("flow is required")
+	}
+
+	var flowID string
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		flows, tasks := tx.Bucket(flowBucket), tx.Bucket(taskBucket)
+
+		id, err := nextBoltID(flows)
+		if err != nil {
+			return err
+		}
+
+		now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+		stored, err := newFlowRecord(kt, flow, id, now)
+		if err != nil {
+			return err
+		}
+		if err = putBoltRecord(flows, stored.ID, stored); err != nil {
+			return err
+		}
+
+		for i := range flow.Tasks {
+			taskID, err := nextBoltID(tasks)
+			if err != nil {
+				return err
+			}
+
+			task, err := newFlowTaskRecord(kt, &flow.Tasks[i], taskID, stored.ID, now)
+			if err != nil {
+				return err
+			}
+			if err = putBoltRecord(tasks, task.ID, task); err != nil {
+				return err
+			}
+		}
+
+		flowID = stored.ID
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return flowID, nil
+}
+
+// BatchUpdateFlow 批量更新任务流，只更新非零值字段
+func (b *boltBackend) BatchUpdateFlow(kt *kit.Kit, flows []model.Flow) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(flowBucket)
+
+		now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+		for i := range flows {
+			stored, err := getBoltRecord[model.Flow](bucket, flows[i].ID)
+			if err != nil {
+				return err
+			}
+			if stored == nil {
+				return errf.Newf(errf.RecordNotFound, "flow %s not found", flows[i].ID)
+			}
+
+			update, err := cloneByJSON(&flows[i])
+			if err != nil {
+				return err
+			}
+
+			mergeFlow(stored, update, now)
+			if err = putBoltRecord(bucket, stored.ID, stored); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListFlow 查询任务流，不支持按Fields裁剪返回字段
+func (b *boltBackend) ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error) {
+	return listBoltRecords(b.db, flowBucket, input, flowFields)
+}
+
+// BatchUpdateFlowStateByCAS CAS批量更新流状态，任一更新失败则事务回滚，全部不生效
+func (b *boltBackend) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo) error {
+	for _, one := range infos {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(flowBucket)
+
+		for _, one := range infos {
+			stored, err := getBoltRecord[model.Flow](bucket, one.ID)
+			if err != nil {
+				return err
+			}
+			if stored == nil || stored.State != one.Source {
+				return errf.Newf(errf.RecordNotUpdate, "flow[%s] update state from `%s` failed, worker: %+v",
+					one.ID, one.Source, one.Worker)
+			}
+
+			applyFlowState(stored, one.Target, one.Reason, one.Worker)
+			if err = putBoltRecord(bucket, stored.ID, stored); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// BatchCreateTask 批量创建任务
+func (b *boltBackend) BatchCreateTask(kt *kit.Kit, tasks []model.Task) ([]string, error) {
+	ids := make([]string, 0, len(tasks))
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(taskBucket)
+
+		now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+		for i := range tasks {
+			task, err := cloneByJSON(&tasks[i])
+			if err != nil {
+				return err
+			}
+
+			if task.ID, err = nextBoltID(bucket); err != nil {
+				return err
+			}
+			task.State = enumor.TaskPending
+			task.CreatedAt = now
+			task.UpdatedAt = now
+			if err = putBoltRecord(bucket, task.ID, task); err != nil {
+				return err
+			}
+			ids = append(ids, task.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// UpdateTask 更新任务，只更新非零值字段
+func (b *boltBackend) UpdateTask(kt *kit.Kit, task *model.Task) error {
+	if task == nil || len(task.ID) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	update, err := cloneByJSON(task)
+	if err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(taskBucket)
+
+		stored, err := getBoltRecord[model.Task](bucket, task.ID)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return errf.Newf(errf.RecordNotFound, "task %s not found", task.ID)
+		}
+
+		mergeTask(kt, stored, update, times.ConvStdTimeFormat(times.ConvStdTimeNow()))
+		return putBoltRecord(bucket, stored.ID, stored)
+	})
+}
+
+// UpdateTaskStateByCAS CAS更新任务状态
+func (b *boltBackend) UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error {
+	if info == nil {
+		return errf.New(errf.InvalidParameter, "update info is required")
+	}
+
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(taskBucket)
+
+		stored, err := getBoltRecord[model.Task](bucket, info.ID)
+		if err != nil {
+			return err
+		}
+		if stored == nil || stored.State != info.Source {
+			return errf.Newf(errf.RecordNotUpdate, "task[%s: %s] update state to %s failed", info.ID, info.Source,
+				info.Target)
+		}
+
+		applyTaskState(stored, info.Target, info.Reason)
+		return putBoltRecord(bucket, stored.ID, stored)
+	})
+}
+
+// ListTask 查询任务，不支持按Fields裁剪返回字段
+func (b *boltBackend) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
+	return listBoltRecords(b.db, taskBucket, input, taskFields)
+}
+
+// RetryTask 重试任务 将flow置为pending, task 置为pending
+func (b *boltBackend) RetryTask(kt *kit.Kit, flowID, taskID string) error {
+	if len(flowID) == 0 || len(taskID) == 0 {
+		return errors.New("empty flow id or task id")
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		flows, tasks := tx.Bucket(flowBucket), tx.Bucket(taskBucket)
+
+		flow, err := getBoltRecord[model.Flow](flows, flowID)
+		if err != nil {
+			return err
+		}
+		task, err := getBoltRecord[model.Task](tasks, taskID)
+		if err != nil {
+			return err
+		}
+		if err = validateRetry(flowID, taskID, flow, task); err != nil {
+			return err
+		}
+
+		reason := &tableasync.Reason{Message: "retry task " + taskID}
+		applyTaskState(task, enumor.TaskPending, reason)
+		applyFlowState(flow, enumor.FlowPending, reason, nil)
+		if err = putBoltRecord(tasks, task.ID, task); err != nil {
+			return err
+		}
+		return putBoltRecord(flows, flow.ID, flow)
+	})
+}
+
+// BatchCreateTaskEvent 批量创建任务事件
+func (b *boltBackend) BatchCreateTaskEvent(kt *kit.Kit, events []model.TaskEvent) error {
+	for _, one := range events {
+		if len(one.FlowID) == 0 || len(one.TaskID) == 0 || len(one.Type) == 0 {
+			return errors.New("flow_id, task_id and type are required")
+		}
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(taskEventBucket)
+
+		for _, one := range events {
+			event := one
+			id, err := nextBoltID(bucket)
+			if err != nil {
+				return err
+			}
+			event.ID = id
+			event.Creator = kt.User
+			if err = putBoltRecord(bucket, event.ID, &event); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListTaskEvent 查询任务事件
+func (b *boltBackend) ListTaskEvent(kt *kit.Kit, input *ListInput) ([]model.TaskEvent, error) {
+	return listBoltRecords(b.db, taskEventBucket, input, taskEventFields)
+}
@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend_test
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/conformance"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+)
+
+func newBolt(t *testing.T, path string) backend.Backend {
+	bd, err := backend.NewBolt(path)
+	if err != nil {
+		t.Fatalf("new bolt backend failed, err: %v", err)
+	}
+	return bd
+}
+
+func TestBoltBackend(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) backend.Backend {
+		bd := newBolt(t, filepath.Join(t.TempDir(), "async.db"))
+		t.Cleanup(func() { bd.(io.Closer).Close() })
+		return bd
+	})
+}
+
+func TestBoltBackendReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "async.db")
+	kt := kit.New()
+
+	bd := newBolt(t, path)
+	flowID, err := bd.CreateFlow(kt, &model.Flow{
+		Name:      enumor.FlowStartCvm,
+		ShareData: tableasync.NewShareData(map[string]string{"key": "value"}),
+		Tasks: []model.Task{{
+			FlowName:   enumor.FlowStartCvm,
+			ActionID:   "action",
+			ActionName: enumor.ActionAssignCvm,
+			Params:     `{"a":1}`,
+			Retry:      &tableasync.Retry{Enable: false},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+	if err = bd.(io.Closer).Close(); err != nil {
+		t.Fatalf("close bolt backend failed, err: %v", err)
+	}
+
+	bd = newBolt(t, path)
+	defer bd.(io.Closer).Close()
+
+	flows, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 || flows[0].State != enumor.FlowPending {
+		t.Fatalf("flow %s should survive reopen, got: %+v", flowID, flows)
+	}
+	if v, ok := flows[0].ShareData.Get("key"); !ok || v != "value" {
+		t.Errorf("share data should survive reopen, got: %v", flows[0].ShareData.GetInitData())
+	}
+
+	tasks, err := bd.ListTask(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("flow_id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].State != enumor.TaskPending {
+		t.Errorf("flow task should survive reopen, got: %+v", tasks)
+	}
+
+	// 重新打开后id序列应延续，不能与已有记录冲突
+	newID, err := bd.CreateFlow(kt, &model.Flow{Name: enumor.FlowStartCvm})
+	if err != nil {
+		t.Fatalf("create flow after reopen failed, err: %v", err)
+	}
+	if newID == flowID {
+		t.Errorf("flow id should not be reused after reopen, got: %s", newID)
+	}
+}
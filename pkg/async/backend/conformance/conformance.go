@@ -0,0 +1,405 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package conformance 异步任务 backend 一致性测试套件，所有 backend 实现都需要通过该套件
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+// Factory 返回一个空的 backend 实例，每个用例都会调用一次
+type Factory func(t *testing.T) backend.Backend
+
+// Run 执行一致性测试套件
+func Run(t *testing.T, newBackend Factory) {
+	cases := []struct {
+		name string
+		fn   func(t *testing.T, bd backend.Backend)
+	}{
+		{name: "CreateAndListFlow", fn: testCreateAndListFlow},
+		{name: "BatchUpdateFlow", fn: testBatchUpdateFlow},
+		{name: "BatchUpdateFlowStateByCAS", fn: testBatchUpdateFlowStateByCAS},
+		{name: "UpdateTaskStateByCAS", fn: testUpdateTaskStateByCAS},
+		{name: "UpdateTask", fn: testUpdateTask},
+		{name: "RetryTask", fn: testRetryTask},
+		{name: "ScheduledFlow", fn: testScheduledFlow},
+		{name: "TaskEvent", fn: testTaskEvent},
+	}
+
+	for _, c := range cases {
+		fn := c.fn
+		t.Run(c.name, func(t *testing.T) {
+			fn(t, newBackend(t))
+		})
+	}
+}
+
+func newKit() *kit.Kit {
+	kt := kit.New()
+	kt.User = "conformance"
+	return kt
+}
+
+func createFlow(t *testing.T, bd backend.Backend, taskNum int) (string, []model.Task) {
+	kt := newKit()
+	flow := &model.Flow{
+		Name:      enumor.FlowStartCvm,
+		ShareData: tableasync.NewShareData(map[string]string{"key": "value"}),
+		Memo:      "conformance",
+	}
+	for i := 0; i < taskNum; i++ {
+		flow.Tasks = append(flow.Tasks, model.Task{
+			FlowName:   enumor.FlowStartCvm,
+			ActionID:   "action",
+			ActionName: enumor.ActionAssignCvm,
+			Params:     `{"a":1}`,
+			Retry:      &tableasync.Retry{Enable: false},
+		})
+	}
+
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	return flowID, listTasks(t, bd, flowID)
+}
+
+func getFlow(t *testing.T, bd backend.Backend, flowID string) model.Flow {
+	flows, err := bd.ListFlow(newKit(), &backend.ListInput{
+		Filter: tools.EqualExpression("id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 {
+		t.Fatalf("flow %s should be found once, got: %d", flowID, len(flows))
+	}
+	return flows[0]
+}
+
+func listTasks(t *testing.T, bd backend.Backend, flowID string) []model.Task {
+	tasks, err := bd.ListTask(newKit(), &backend.ListInput{
+		Filter: tools.EqualExpression("flow_id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	return tasks
+}
+
+func getTask(t *testing.T, bd backend.Backend, taskID string) model.Task {
+	tasks, err := bd.ListTask(newKit(), &backend.ListInput{
+		Filter: tools.EqualExpression("id", taskID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("task %s should be found once, got: %d", taskID, len(tasks))
+	}
+	return tasks[0]
+}
+
+func assertNotUpdated(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("cas update with wrong source state should fail")
+	}
+	if ef := errf.Error(err); ef.Code != errf.RecordNotUpdate {
+		t.Errorf("cas update failure should return RecordNotUpdate, got: %v", err)
+	}
+}
+
+func testCreateAndListFlow(t *testing.T, bd backend.Backend) {
+	flowID, tasks := createFlow(t, bd, 2)
+
+	flow := getFlow(t, bd, flowID)
+	if flow.State != enumor.FlowPending {
+		t.Errorf("new flow state should be pending, got: %s", flow.State)
+	}
+	if cvt.PtrToVal(flow.Worker) != "" {
+		t.Errorf("new flow should not have worker, got: %s", cvt.PtrToVal(flow.Worker))
+	}
+	if v, ok := flow.ShareData.Get("key"); !ok || v != "value" {
+		t.Errorf("share data should be saved, got: %v", flow.ShareData.GetInitData())
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("flow should have 2 tasks, got: %d", len(tasks))
+	}
+	for _, one := range tasks {
+		if one.State != enumor.TaskPending || one.FlowID != flowID {
+			t.Errorf("task %s should be pending and belongs to flow %s, got: %s, %s", one.ID, flowID,
+				one.State, one.FlowID)
+		}
+	}
+
+	// 其他任务流不应被查出
+	otherID, _ := createFlow(t, bd, 1)
+	pending, err := bd.ListFlow(newKit(), &backend.ListInput{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("state", enumor.FlowPending),
+			tools.RuleIn("id", []string{flowID, otherID}),
+		),
+		Page: core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Errorf("should list 2 pending flows, got: %d", len(pending))
+	}
+}
+
+func testBatchUpdateFlow(t *testing.T, bd backend.Backend) {
+	flowID, _ := createFlow(t, bd, 1)
+
+	update := model.Flow{
+		ID:     flowID,
+		State:  enumor.FlowScheduled,
+		Worker: cvt.ValToPtr("node-1"),
+	}
+	if err := bd.BatchUpdateFlow(newKit(), []model.Flow{update}); err != nil {
+		t.Fatalf("batch update flow failed, err: %v", err)
+	}
+
+	flow := getFlow(t, bd, flowID)
+	if flow.State != enumor.FlowScheduled || cvt.PtrToVal(flow.Worker) != "node-1" {
+		t.Errorf("flow should be updated, got state: %s, worker: %s", flow.State, cvt.PtrToVal(flow.Worker))
+	}
+	if flow.Memo != "conformance" {
+		t.Errorf("unset field should not be updated, got memo: %s", flow.Memo)
+	}
+}
+
+func testBatchUpdateFlowStateByCAS(t *testing.T, bd backend.Backend) {
+	flowID, _ := createFlow(t, bd, 1)
+
+	infos := []backend.UpdateFlowInfo{{
+		ID:     flowID,
+		Source: enumor.FlowPending,
+		Target: enumor.FlowScheduled,
+		Worker: cvt.ValToPtr("node-1"),
+	}}
+	if err := bd.BatchUpdateFlowStateByCAS(newKit(), infos); err != nil {
+		t.Fatalf("cas update flow failed, err: %v", err)
+	}
+
+	flow := getFlow(t, bd, flowID)
+	if flow.State != enumor.FlowScheduled || cvt.PtrToVal(flow.Worker) != "node-1" {
+		t.Errorf("flow should be scheduled to node-1, got state: %s, worker: %s", flow.State,
+			cvt.PtrToVal(flow.Worker))
+	}
+
+	// 源状态不匹配时不允许更新
+	assertNotUpdated(t, bd.BatchUpdateFlowStateByCAS(newKit(), infos))
+
+	flow = getFlow(t, bd, flowID)
+	if flow.State != enumor.FlowScheduled {
+		t.Errorf("flow state should not be changed by failed cas, got: %s", flow.State)
+	}
+}
+
+func testUpdateTaskStateByCAS(t *testing.T, bd backend.Backend) {
+	_, tasks := createFlow(t, bd, 1)
+	taskID := tasks[0].ID
+
+	info := &backend.UpdateTaskInfo{
+		ID:     taskID,
+		Source: enumor.TaskPending,
+		Target: enumor.TaskRunning,
+		Reason: &tableasync.Reason{Message: "start"},
+	}
+	if err := bd.UpdateTaskStateByCAS(newKit(), info); err != nil {
+		t.Fatalf("cas update task failed, err: %v", err)
+	}
+
+	task := getTask(t, bd, taskID)
+	if task.State != enumor.TaskRunning {
+		t.Errorf("task should be running, got: %s", task.State)
+	}
+	if task.Reason == nil || task.Reason.Message != "start" {
+		t.Errorf("task reason should be updated, got: %+v", task.Reason)
+	}
+
+	assertNotUpdated(t, bd.UpdateTaskStateByCAS(newKit(), info))
+
+	// 不存在的任务同样视为未更新
+	assertNotUpdated(t, bd.UpdateTaskStateByCAS(newKit(), &backend.UpdateTaskInfo{
+		ID:     "not-exist",
+		Source: enumor.TaskPending,
+		Target: enumor.TaskRunning,
+	}))
+}
+
+func testUpdateTask(t *testing.T, bd backend.Backend) {
+	_, tasks := createFlow(t, bd, 1)
+	taskID := tasks[0].ID
+
+	update := &model.Task{
+		ID:     taskID,
+		State:  enumor.TaskSuccess,
+		Result: `{"ok":true}`,
+	}
+	if err := bd.UpdateTask(newKit(), update); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+
+	task := getTask(t, bd, taskID)
+	if task.State != enumor.TaskSuccess || string(task.Result) != `{"ok":true}` {
+		t.Errorf("task should be updated, got state: %s, result: %s", task.State, task.Result)
+	}
+	if string(task.Params) != `{"a":1}` {
+		t.Errorf("unset field should not be updated, got params: %s", task.Params)
+	}
+}
+
+func testRetryTask(t *testing.T, bd backend.Backend) {
+	kt := newKit()
+	flowID, tasks := createFlow(t, bd, 1)
+	taskID := tasks[0].ID
+
+	// 非失败状态不允许重试
+	if err := bd.RetryTask(kt, flowID, taskID); err == nil {
+		t.Fatalf("retry task of pending flow should fail")
+	}
+
+	err := bd.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{{
+		ID:     flowID,
+		Source: enumor.FlowPending,
+		Target: enumor.FlowFailed,
+	}})
+	if err != nil {
+		t.Fatalf("set flow failed state failed, err: %v", err)
+	}
+	err = bd.UpdateTaskStateByCAS(kt, &backend.UpdateTaskInfo{
+		ID:     taskID,
+		Source: enumor.TaskPending,
+		Target: enumor.TaskFailed,
+	})
+	if err != nil {
+		t.Fatalf("set task failed state failed, err: %v", err)
+	}
+
+	if err = bd.RetryTask(kt, flowID, "not-exist"); err == nil {
+		t.Errorf("retry not exist task should fail")
+	}
+
+	if err = bd.RetryTask(kt, flowID, taskID); err != nil {
+		t.Fatalf("retry task failed, err: %v", err)
+	}
+
+	if flow := getFlow(t, bd, flowID); flow.State != enumor.FlowPending {
+		t.Errorf("flow should be pending after retry, got: %s", flow.State)
+	}
+	if task := getTask(t, bd, taskID); task.State != enumor.TaskPending {
+		t.Errorf("task should be pending after retry, got: %s", task.State)
+	}
+
+	// 重试后状态已变更，再次重试应失败
+	if err = bd.RetryTask(kt, flowID, taskID); err == nil {
+		t.Errorf("retry task twice should fail")
+	}
+}
+
+func testScheduledFlow(t *testing.T, bd backend.Backend) {
+	now := times.ConvStdTimeNow()
+	future := &model.Flow{
+		Name:        enumor.FlowStartCvm,
+		Memo:        "conformance",
+		ScheduledAt: times.ConvStdTimeFormat(now.Add(time.Hour)),
+	}
+	futureID, err := bd.CreateFlow(newKit(), future)
+	if err != nil {
+		t.Fatalf("create scheduled flow failed, err: %v", err)
+	}
+	dueID, _ := createFlow(t, bd, 1)
+
+	if flow := getFlow(t, bd, futureID); flow.ScheduledAt != future.ScheduledAt {
+		t.Errorf("scheduled_at should be %s, got: %s", future.ScheduledAt, flow.ScheduledAt)
+	}
+	if flow := getFlow(t, bd, dueID); len(flow.ScheduledAt) == 0 {
+		t.Errorf("scheduled_at should default to create time")
+	}
+
+	due, err := bd.ListFlow(newKit(), &backend.ListInput{
+		Filter: tools.ExpressionAnd(
+			tools.RuleIn("id", []string{futureID, dueID}),
+			tools.RuleLessThanEqual("scheduled_at", times.ConvStdTimeFormat(now.Add(time.Minute))),
+		),
+		Page: core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != dueID {
+		t.Errorf("only flow %s should be due, got: %+v", dueID, due)
+	}
+}
+
+func testTaskEvent(t *testing.T, bd backend.Backend) {
+	flowID, tasks := createFlow(t, bd, 2)
+
+	now := time.Now()
+	events := []model.TaskEvent{
+		{FlowID: flowID, TaskID: tasks[0].ID, Type: enumor.TaskEventState, SourceState: enumor.TaskPending,
+			TargetState: enumor.TaskRunning, Attempt: 1, OccurredAt: now},
+		{FlowID: flowID, TaskID: tasks[0].ID, Type: enumor.TaskEventLog, Level: enumor.TaskLogInfo,
+			Message: "create clb rule", Attempt: 1, OccurredAt: now.Add(time.Second)},
+		{FlowID: flowID, TaskID: tasks[1].ID, Type: enumor.TaskEventState, SourceState: enumor.TaskPending,
+			TargetState: enumor.TaskRunning, Attempt: 1, OccurredAt: now.Add(2 * time.Second)},
+	}
+	if err := bd.BatchCreateTaskEvent(newKit(), events); err != nil {
+		t.Fatalf("batch create task event failed, err: %v", err)
+	}
+
+	list, err := bd.ListTaskEvent(newKit(), &backend.ListInput{
+		Filter: tools.EqualExpression("task_id", tasks[0].ID),
+		Page:   &core.BasePage{Limit: core.DefaultMaxPageLimit, Sort: "occurred_at", Order: core.Ascending},
+	})
+	if err != nil {
+		t.Fatalf("list task event failed, err: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("task %s should have 2 events, got: %d", tasks[0].ID, len(list))
+	}
+	if len(list[0].ID) == 0 || list[0].Type != enumor.TaskEventState || list[1].Message != "create clb rule" {
+		t.Errorf("unexpected task events: %+v", list)
+	}
+	if list[1].OccurredAt.Sub(list[0].OccurredAt) != time.Second {
+		t.Errorf("occurred_at should keep millisecond precision, got: %+v", list)
+	}
+}
@@ -36,7 +36,13 @@ func Factory(typ enumor.BackendType, client interface{}) (Backend, error) {
 			return nil, errors.New("client is not mysql dao set")
 		}
 		return NewMysql(cli), nil
+	case enumor.BackendBolt:
+		path, ok := client.(string)
+		if !ok {
+			return nil, errors.New("client is not bolt file path")
+		}
+		return NewBolt(path)
 	default:
-		return nil, fmt.Errorf("unsupported mysql type: %s", typ)
+		return nil, fmt.Errorf("unsupported backend type: %s", typ)
 	}
 }
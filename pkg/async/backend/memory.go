@@ -0,0 +1,376 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/times"
+)
+
+// NewMemory create memory backend instance, data only lives in current process and is lost on restart,
+// only used for testing, use bolt backend for single node deployment without mysql.
+func NewMemory() Backend {
+	return &memory{
+		flows:  make(map[string]*model.Flow),
+		tasks:  make(map[string]*model.Task),
+		events: make(map[string]*model.TaskEvent),
+	}
+}
+
+// memory 内存存储，返回给调用方的数据都是深拷贝，与mysql行为保持一致
+type memory struct {
+	lock   sync.RWMutex
+	seq    uint64
+	flows  map[string]*model.Flow
+	tasks  map[string]*model.Task
+	events map[string]*model.TaskEvent
+}
+
+var _ Backend = new(memory)
+
+func (m *memory) nextID() string {
+	m.seq++
+	return fmt.Sprintf("%08d", m.seq)
+}
+
+// CreateFlow 创建任务流
+func (m *memory) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
+	if flow == nil {
+		return "", errors.New("flow is required")
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+	stored, err := newFlowRecord(kt, flow, m.nextID(), now)
+	if err != nil {
+		return "", err
+	}
+
+	tasks := make([]*model.Task, 0, len(flow.Tasks))
+	for i := range flow.Tasks {
+		task, err := newFlowTaskRecord(kt, &flow.Tasks[i], m.nextID(), stored.ID, now)
+		if err != nil {
+			return "", err
+		}
+		tasks = append(tasks, task)
+	}
+
+	m.flows[stored.ID] = stored
+	for _, one := range tasks {
+		m.tasks[one.ID] = one
+	}
+
+	return stored.ID, nil
+}
+
+// BatchUpdateFlow 批量更新任务流，只更新非零值字段
+func (m *memory) BatchUpdateFlow(kt *kit.Kit, flows []model.Flow) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, one := range flows {
+		if _, exist := m.flows[one.ID]; !exist {
+			return errf.Newf(errf.RecordNotFound, "flow %s not found", one.ID)
+		}
+	}
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+	for _, one := range flows {
+		update, err := cloneByJSON(&one)
+		if err != nil {
+			return err
+		}
+
+		mergeFlow(m.flows[one.ID], update, now)
+	}
+
+	return nil
+}
+
+// ListFlow 查询任务流，不支持按Fields裁剪返回字段
+func (m *memory) ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error) {
+	if input == nil {
+		return nil, errors.New("list input is required")
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	matched := make([]*model.Flow, 0)
+	for _, one := range m.flows {
+		hit, err := matchExpression(input.Filter, flowFields(one))
+		if err != nil {
+			return nil, err
+		}
+		if hit {
+			matched = append(matched, one)
+		}
+	}
+
+	matched, err := pageRecords(matched, input, flowFields)
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make([]model.Flow, 0, len(matched))
+	for _, one := range matched {
+		flow, err := cloneByJSON(one)
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, *flow)
+	}
+
+	return flows, nil
+}
+
+// BatchUpdateFlowStateByCAS CAS批量更新流状态，任一更新失败则全部不生效
+func (m *memory) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo) error {
+	for _, one := range infos {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, one := range infos {
+		if err := m.checkFlowCAS(one.ID, one.Source, one.Worker); err != nil {
+			return err
+		}
+	}
+
+	for _, one := range infos {
+		applyFlowState(m.flows[one.ID], one.Target, one.Reason, one.Worker)
+	}
+
+	return nil
+}
+
+func (m *memory) checkFlowCAS(id string, source enumor.FlowState, worker *string) error {
+	stored, exist := m.flows[id]
+	if !exist || stored.State != source {
+		return errf.Newf(errf.RecordNotUpdate, "flow[%s] update state from `%s` failed, worker: %+v",
+			id, source, worker)
+	}
+	return nil
+}
+
+// BatchCreateTask 批量创建任务
+func (m *memory) BatchCreateTask(kt *kit.Kit, tasks []model.Task) ([]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+	created := make([]*model.Task, 0, len(tasks))
+	for _, one := range tasks {
+		task, err := cloneByJSON(&one)
+		if err != nil {
+			return nil, err
+		}
+		task.ID = m.nextID()
+		task.State = enumor.TaskPending
+		task.CreatedAt = now
+		task.UpdatedAt = now
+		created = append(created, task)
+	}
+
+	ids := make([]string, 0, len(created))
+	for _, one := range created {
+		m.tasks[one.ID] = one
+		ids = append(ids, one.ID)
+	}
+
+	return ids, nil
+}
+
+// UpdateTask 更新任务，只更新非零值字段
+func (m *memory) UpdateTask(kt *kit.Kit, task *model.Task) error {
+	if task == nil || len(task.ID) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	update, err := cloneByJSON(task)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	stored, exist := m.tasks[task.ID]
+	if !exist {
+		return errf.Newf(errf.RecordNotFound, "task %s not found", task.ID)
+	}
+
+	mergeTask(kt, stored, update, times.ConvStdTimeFormat(times.ConvStdTimeNow()))
+
+	return nil
+}
+
+// UpdateTaskStateByCAS CAS更新任务状态
+func (m *memory) UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error {
+	if info == nil {
+		return errf.New(errf.InvalidParameter, "update info is required")
+	}
+
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkTaskCAS(info.ID, info.Source, info.Target); err != nil {
+		return err
+	}
+	applyTaskState(m.tasks[info.ID], info.Target, info.Reason)
+
+	return nil
+}
+
+func (m *memory) checkTaskCAS(id string, source, target enumor.TaskState) error {
+	stored, exist := m.tasks[id]
+	if !exist || stored.State != source {
+		return errf.Newf(errf.RecordNotUpdate, "task[%s: %s] update state to %s failed", id, source, target)
+	}
+	return nil
+}
+
+// ListTask 查询任务，不支持按Fields裁剪返回字段
+func (m *memory) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
+	if input == nil {
+		return nil, errors.New("list input is required")
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	matched := make([]*model.Task, 0)
+	for _, one := range m.tasks {
+		hit, err := matchExpression(input.Filter, taskFields(one))
+		if err != nil {
+			return nil, err
+		}
+		if hit {
+			matched = append(matched, one)
+		}
+	}
+
+	matched, err := pageRecords(matched, input, taskFields)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]model.Task, 0, len(matched))
+	for _, one := range matched {
+		task, err := cloneByJSON(one)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *task)
+	}
+
+	return tasks, nil
+}
+
+// RetryTask 重试任务 将flow置为pending, task 置为pending
+func (m *memory) RetryTask(kt *kit.Kit, flowID, taskID string) error {
+	if len(flowID) == 0 || len(taskID) == 0 {
+		return errors.New("empty flow id or task id")
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	flow, task := m.flows[flowID], m.tasks[taskID]
+	if err := validateRetry(flowID, taskID, flow, task); err != nil {
+		return err
+	}
+
+	reason := &tableasync.Reason{Message: "retry task " + taskID}
+	applyTaskState(task, enumor.TaskPending, reason)
+	applyFlowState(flow, enumor.FlowPending, reason, nil)
+
+	return nil
+}
+
+// BatchCreateTaskEvent 批量创建任务事件
+func (m *memory) BatchCreateTaskEvent(kt *kit.Kit, events []model.TaskEvent) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, one := range events {
+		if len(one.FlowID) == 0 || len(one.TaskID) == 0 || len(one.Type) == 0 {
+			return errors.New("flow_id, task_id and type are required")
+		}
+
+		event := one
+		event.ID = m.nextID()
+		event.Creator = kt.User
+		m.events[event.ID] = &event
+	}
+
+	return nil
+}
+
+// ListTaskEvent 查询任务事件
+func (m *memory) ListTaskEvent(kt *kit.Kit, input *ListInput) ([]model.TaskEvent, error) {
+	if input == nil {
+		return nil, errors.New("list input is required")
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	matched := make([]*model.TaskEvent, 0)
+	for _, one := range m.events {
+		hit, err := matchExpression(input.Filter, taskEventFields(one))
+		if err != nil {
+			return nil, err
+		}
+		if hit {
+			matched = append(matched, one)
+		}
+	}
+
+	matched, err := pageRecords(matched, input, taskEventFields)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]model.TaskEvent, 0, len(matched))
+	for _, one := range matched {
+		events = append(events, *one)
+	}
+
+	return events, nil
+}
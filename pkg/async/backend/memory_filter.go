@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/runtime/filter"
+)
+
+// matchExpression 在内存中计算过滤条件，仅支持基础比较操作符，不支持json相关操作符
+func matchExpression(exp *filter.Expression, fields map[string]interface{}) (bool, error) {
+	if exp == nil || len(exp.Rules) == 0 {
+		return true, nil
+	}
+
+	for _, rule := range exp.Rules {
+		hit, err := matchRule(rule, fields)
+		if err != nil {
+			return false, err
+		}
+
+		switch exp.Op {
+		case filter.And:
+			if !hit {
+				return false, nil
+			}
+		case filter.Or:
+			if hit {
+				return true, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported logic operator: %s", exp.Op)
+		}
+	}
+
+	return exp.Op == filter.And, nil
+}
+
+func matchRule(rule filter.RuleFactory, fields map[string]interface{}) (bool, error) {
+	switch r := rule.(type) {
+	case *filter.Expression:
+		return matchExpression(r, fields)
+	case *filter.AtomRule:
+		return matchAtomRule(r, fields)
+	case filter.AtomRule:
+		return matchAtomRule(&r, fields)
+	default:
+		return false, fmt.Errorf("unsupported rule type: %T", rule)
+	}
+}
+
+func matchAtomRule(rule *filter.AtomRule, fields map[string]interface{}) (bool, error) {
+	value, exist := fields[rule.Field]
+	if !exist {
+		return false, fmt.Errorf("unsupported filter field: %s", rule.Field)
+	}
+
+	switch rule.Op {
+	case filter.Equal.Factory():
+		return compareValue(value, rule.Value) == 0, nil
+	case filter.NotEqual.Factory():
+		return compareValue(value, rule.Value) != 0, nil
+	case filter.GreaterThan.Factory():
+		return compareValue(value, rule.Value) > 0, nil
+	case filter.GreaterThanEqual.Factory():
+		return compareValue(value, rule.Value) >= 0, nil
+	case filter.LessThan.Factory():
+		return compareValue(value, rule.Value) < 0, nil
+	case filter.LessThanEqual.Factory():
+		return compareValue(value, rule.Value) <= 0, nil
+	case filter.In.Factory():
+		return containsValue(rule.Value, value)
+	case filter.NotIn.Factory():
+		hit, err := containsValue(rule.Value, value)
+		return !hit, err
+	default:
+		return false, fmt.Errorf("unsupported filter operator: %s", rule.Op)
+	}
+}
+
+func containsValue(list interface{}, value interface{}) (bool, error) {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false, fmt.Errorf("in/nin operator value should be an array, but got %T", list)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if compareValue(value, rv.Index(i).Interface()) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// compareValue 两边都是数字时按数值比较，否则按字符串比较
+func compareValue(a, b interface{}) int {
+	strA, strB := fmt.Sprint(a), fmt.Sprint(b)
+
+	numA, errA := strconv.ParseFloat(strA, 64)
+	numB, errB := strconv.ParseFloat(strB, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(strA, strB)
+}
@@ -21,9 +21,13 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/tools/times"
 )
 
 // Flow 任务流
@@ -32,14 +36,17 @@ type Flow struct {
 	ShareData *tableasync.ShareData `json:"share_data"`
 	Memo      string                `json:"memo"`
 
-	ID        string             `json:"id"`
-	State     enumor.FlowState   `json:"state"`
-	Reason    *tableasync.Reason `json:"reason"`
-	Worker    *string            `json:"worker"`
-	Creator   string             `json:"creator"`
-	Reviser   string             `json:"reviser"`
-	CreatedAt string             `json:"created_at"`
-	UpdatedAt string             `json:"updated_at"`
+	ID               string             `json:"id"`
+	State            enumor.FlowState   `json:"state"`
+	Reason           *tableasync.Reason `json:"reason"`
+	Worker           *string            `json:"worker"`
+	ScheduledAt      string             `json:"scheduled_at"`
+	ConcurrencyKey   string             `json:"concurrency_key"`
+	ConcurrencyLimit uint               `json:"concurrency_limit"`
+	Creator          string             `json:"creator"`
+	Reviser          string             `json:"reviser"`
+	CreatedAt        string             `json:"created_at"`
+	UpdatedAt        string             `json:"updated_at"`
 
 	Tasks []Task `json:"tasks"`
 }
@@ -82,6 +89,20 @@ func (f Flow) CreateValidate() error {
 	return nil
 }
 
+// ScheduledTime 解析任务流最早可被派发执行的时间，未设置时返回当前时间
+func (f Flow) ScheduledTime() (time.Time, error) {
+	if len(f.ScheduledAt) == 0 {
+		return times.ConvStdTimeNow(), nil
+	}
+
+	scheduledAt, err := time.Parse(constant.TimeStdFormat, f.ScheduledAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("scheduled_at: %s is invalid, err: %v", f.ScheduledAt, err)
+	}
+
+	return scheduledAt, nil
+}
+
 // UpdateValidate Flow.
 func (f Flow) UpdateValidate() error {
 
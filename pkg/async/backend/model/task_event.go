@@ -0,0 +1,43 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package model
+
+import (
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// TaskEvent 任务事件，记录任务状态流转和执行过程中输出的日志
+type TaskEvent struct {
+	ID          string               `json:"id"`
+	FlowID      string               `json:"flow_id"`
+	TaskID      string               `json:"task_id"`
+	Type        enumor.TaskEventType `json:"type"`
+	SourceState enumor.TaskState     `json:"source_state"`
+	TargetState enumor.TaskState     `json:"target_state"`
+	// Attempt 事件发生在任务第几次执行过程中，从1开始
+	Attempt uint                `json:"attempt"`
+	Level   enumor.TaskLogLevel `json:"level"`
+	Message string              `json:"message"`
+	// OccurredAt 事件发生时间，精确到毫秒，用于计算各阶段耗时
+	OccurredAt time.Time `json:"occurred_at"`
+	Creator    string    `json:"creator"`
+}
@@ -38,6 +38,7 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -195,17 +196,25 @@ func (db *mysql) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
 		flowState = flow.State
 	}
 
+	scheduledAt, err := flow.ScheduledTime()
+	if err != nil {
+		return "", err
+	}
+
 	result, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		// 创建任务流
 		md := &tableasync.AsyncFlowTable{
-			Name:      flow.Name,
-			State:     flowState,
-			Reason:    new(tableasync.Reason),
-			ShareData: flow.ShareData,
-			Memo:      flow.Memo,
-			Worker:    converter.ValToPtr(""),
-			Creator:   kt.User,
-			Reviser:   kt.User,
+			Name:             flow.Name,
+			State:            flowState,
+			Reason:           new(tableasync.Reason),
+			ShareData:        flow.ShareData,
+			Memo:             flow.Memo,
+			Worker:           converter.ValToPtr(""),
+			ScheduledAt:      tabletypes.Time(times.ConvStdTimeFormat(scheduledAt)),
+			ConcurrencyKey:   flow.ConcurrencyKey,
+			ConcurrencyLimit: flow.ConcurrencyLimit,
+			Creator:          kt.User,
+			Reviser:          kt.User,
 		}
 		flowID, err := db.dao.AsyncFlow().Create(kt, txn, md)
 		if err != nil {
@@ -297,17 +306,20 @@ func (db *mysql) ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error) {
 	flows := make([]model.Flow, 0, len(list.Details))
 	for _, one := range list.Details {
 		flows = append(flows, model.Flow{
-			ID:        one.ID,
-			Name:      one.Name,
-			State:     one.State,
-			Reason:    one.Reason,
-			ShareData: one.ShareData,
-			Memo:      one.Memo,
-			Worker:    one.Worker,
-			Creator:   one.Creator,
-			Reviser:   one.Reviser,
-			CreatedAt: one.CreatedAt.String(),
-			UpdatedAt: one.UpdatedAt.String(),
+			ID:               one.ID,
+			Name:             one.Name,
+			State:            one.State,
+			Reason:           one.Reason,
+			ShareData:        one.ShareData,
+			Memo:             one.Memo,
+			Worker:           one.Worker,
+			ScheduledAt:      one.ScheduledAt.String(),
+			ConcurrencyKey:   one.ConcurrencyKey,
+			ConcurrencyLimit: one.ConcurrencyLimit,
+			Creator:          one.Creator,
+			Reviser:          one.Reviser,
+			CreatedAt:        one.CreatedAt.String(),
+			UpdatedAt:        one.UpdatedAt.String(),
 		})
 	}
 
@@ -388,6 +400,65 @@ func (db *mysql) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
 	return tasks, nil
 }
 
+// BatchCreateTaskEvent 批量创建任务事件
+func (db *mysql) BatchCreateTaskEvent(kt *kit.Kit, events []model.TaskEvent) error {
+
+	mds := make([]tableasync.AsyncFlowTaskEventTable, 0, len(events))
+	for _, one := range events {
+		mds = append(mds, tableasync.AsyncFlowTaskEventTable{
+			FlowID:      one.FlowID,
+			TaskID:      one.TaskID,
+			Type:        one.Type,
+			SourceState: one.SourceState,
+			TargetState: one.TargetState,
+			Attempt:     one.Attempt,
+			Level:       one.Level,
+			Message:     one.Message,
+			OccurredAt:  one.OccurredAt,
+			Creator:     kt.User,
+		})
+	}
+
+	if _, err := db.dao.AsyncFlowTaskEvent().BatchCreate(kt, mds); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListTaskEvent 查询任务事件
+func (db *mysql) ListTaskEvent(kt *kit.Kit, input *ListInput) ([]model.TaskEvent, error) {
+
+	opt := &types.ListOption{
+		Fields: input.Fields,
+		Filter: input.Filter,
+		Page:   input.Page,
+	}
+	list, err := db.dao.AsyncFlowTaskEvent().List(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]model.TaskEvent, 0, len(list.Details))
+	for _, one := range list.Details {
+		events = append(events, model.TaskEvent{
+			ID:          one.ID,
+			FlowID:      one.FlowID,
+			TaskID:      one.TaskID,
+			Type:        one.Type,
+			SourceState: one.SourceState,
+			TargetState: one.TargetState,
+			Attempt:     one.Attempt,
+			Level:       one.Level,
+			Message:     one.Message,
+			OccurredAt:  one.OccurredAt,
+			Creator:     one.Creator,
+		})
+	}
+
+	return events, nil
+}
+
 func dependOnToStringArray(d []action.ActIDType) tabletypes.StringArray {
 	result := make(tabletypes.StringArray, 0, len(d))
 	for _, one := range d {
@@ -0,0 +1,274 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"sort"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/times"
+)
+
+// 内存及嵌入式存储共用的记录构造及更新逻辑，与mysql实现的字段处理保持一致
+
+// newFlowRecord 构造待存储的任务流，初始状态只允许为init，其余情况置为pending
+func newFlowRecord(kt *kit.Kit, flow *model.Flow, id string, now string) (*model.Flow, error) {
+	flowState := enumor.FlowPending
+	if flow.State == enumor.FlowInit {
+		flowState = flow.State
+	}
+
+	scheduledAt, err := flow.ScheduledTime()
+	if err != nil {
+		return nil, err
+	}
+
+	md := &model.Flow{
+		ID:               id,
+		Name:             flow.Name,
+		State:            flowState,
+		Reason:           new(tableasync.Reason),
+		ShareData:        flow.ShareData,
+		Memo:             flow.Memo,
+		Worker:           converter.ValToPtr(""),
+		ScheduledAt:      times.ConvStdTimeFormat(scheduledAt),
+		ConcurrencyKey:   flow.ConcurrencyKey,
+		ConcurrencyLimit: flow.ConcurrencyLimit,
+		Creator:          kt.User,
+		Reviser:          kt.User,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	return cloneByJSON(md)
+}
+
+// newFlowTaskRecord 构造随任务流一起创建的任务，初始状态只允许为init，其余情况置为pending
+func newFlowTaskRecord(kt *kit.Kit, one *model.Task, id, flowID string, now string) (*model.Task, error) {
+	taskState := enumor.TaskPending
+	if one.State == enumor.TaskInit {
+		taskState = one.State
+	}
+
+	task := &model.Task{
+		ID:         id,
+		FlowID:     flowID,
+		FlowName:   one.FlowName,
+		ActionID:   one.ActionID,
+		ActionName: one.ActionName,
+		Params:     one.Params,
+		Retry:      one.Retry,
+		DependOn:   one.DependOn,
+		State:      taskState,
+		Reason:     new(tableasync.Reason),
+		Creator:    kt.User,
+		Reviser:    kt.User,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	return cloneByJSON(task)
+}
+
+// mergeFlow 将更新中的非零值字段合并到已存储的任务流
+func mergeFlow(stored, update *model.Flow, now string) {
+	if len(update.State) != 0 {
+		stored.State = update.State
+	}
+	if update.Reason != nil {
+		stored.Reason = update.Reason
+	}
+	if update.ShareData != nil {
+		stored.ShareData = update.ShareData
+	}
+	if len(update.Memo) != 0 {
+		stored.Memo = update.Memo
+	}
+	if update.Worker != nil {
+		stored.Worker = update.Worker
+	}
+	if len(update.Reviser) != 0 {
+		stored.Reviser = update.Reviser
+	}
+	stored.UpdatedAt = now
+}
+
+// mergeTask 将更新中的非零值字段合并到已存储的任务
+func mergeTask(kt *kit.Kit, stored, update *model.Task, now string) {
+	if update.Retry != nil {
+		stored.Retry = update.Retry
+	}
+	if len(update.State) != 0 {
+		stored.State = update.State
+	}
+	if len(update.Result) != 0 {
+		stored.Result = update.Result
+	}
+	if update.Reason != nil {
+		stored.Reason = update.Reason
+	}
+	stored.Reviser = kt.User
+	stored.UpdatedAt = now
+}
+
+func applyFlowState(stored *model.Flow, target enumor.FlowState, reason *tableasync.Reason, worker *string) {
+	stored.State = target
+	if reason != nil {
+		stored.Reason = converter.ValToPtr(*reason)
+	}
+	if worker != nil {
+		stored.Worker = converter.ValToPtr(*worker)
+	}
+	stored.UpdatedAt = times.ConvStdTimeFormat(times.ConvStdTimeNow())
+}
+
+func applyTaskState(stored *model.Task, target enumor.TaskState, reason *tableasync.Reason) {
+	stored.State = target
+	if reason != nil {
+		stored.Reason = converter.ValToPtr(*reason)
+	}
+	stored.UpdatedAt = times.ConvStdTimeFormat(times.ConvStdTimeNow())
+}
+
+// validateRetry 校验任务流及任务是否允许重试，只有失败的任务流下失败的任务允许重试
+func validateRetry(flowID, taskID string, flow *model.Flow, task *model.Task) error {
+	if flow == nil {
+		return fmt.Errorf("flow %s not found", flowID)
+	}
+	if flow.State != enumor.FlowFailed {
+		return fmt.Errorf("flow(%s) state(%s) wrong, only `failed` allowed for retry", flowID, flow.State)
+	}
+
+	if task == nil || task.FlowID != flowID {
+		return fmt.Errorf("task(%s) of flow(%s) not found", taskID, flowID)
+	}
+	if task.State != enumor.TaskFailed {
+		return fmt.Errorf("task(%s) state(%s) wrong, only `failed` allowed for retry", taskID, task.State)
+	}
+
+	return nil
+}
+
+// pageRecords 按照分页参数排序并截取，未指定排序字段时按id升序
+func pageRecords[T any](records []*T, input *ListInput, fields func(*T) map[string]interface{}) ([]*T, error) {
+	sortField, desc := "id", false
+	if input.Page != nil && len(input.Page.Sort) != 0 {
+		sortField = input.Page.Sort
+		desc = input.Page.Order == core.Descending
+	}
+
+	var sortErr error
+	sort.SliceStable(records, func(i, j int) bool {
+		a, okA := fields(records[i])[sortField]
+		b, okB := fields(records[j])[sortField]
+		if !okA || !okB {
+			sortErr = fmt.Errorf("unsupported sort field: %s", sortField)
+			return false
+		}
+		if desc {
+			return compareValue(a, b) > 0
+		}
+		return compareValue(a, b) < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	if input.Page == nil {
+		return records, nil
+	}
+
+	start := int(input.Page.Start)
+	if start >= len(records) {
+		return records[:0], nil
+	}
+	end := len(records)
+	if input.Page.Limit != 0 && start+int(input.Page.Limit) < end {
+		end = start + int(input.Page.Limit)
+	}
+
+	return records[start:end], nil
+}
+
+func flowFields(flow *model.Flow) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                flow.ID,
+		"name":              flow.Name,
+		"state":             flow.State,
+		"memo":              flow.Memo,
+		"worker":            converter.PtrToVal(flow.Worker),
+		"scheduled_at":      flow.ScheduledAt,
+		"concurrency_key":   flow.ConcurrencyKey,
+		"concurrency_limit": flow.ConcurrencyLimit,
+		"creator":           flow.Creator,
+		"reviser":           flow.Reviser,
+		"created_at":        flow.CreatedAt,
+		"updated_at":        flow.UpdatedAt,
+	}
+}
+
+func taskFields(task *model.Task) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          task.ID,
+		"flow_id":     task.FlowID,
+		"flow_name":   task.FlowName,
+		"action_id":   task.ActionID,
+		"action_name": task.ActionName,
+		"state":       task.State,
+		"creator":     task.Creator,
+		"reviser":     task.Reviser,
+		"created_at":  task.CreatedAt,
+		"updated_at":  task.UpdatedAt,
+	}
+}
+
+// cloneByJSON 通过序列化深拷贝，保证存储的数据不会被调用方修改
+func cloneByJSON[T any](src *T) (*T, error) {
+	raw, err := json.Marshal(src)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := new(T)
+	if err = json.Unmarshal(raw, dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+func taskEventFields(event *model.TaskEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           event.ID,
+		"flow_id":      event.FlowID,
+		"task_id":      event.TaskID,
+		"type":         event.Type,
+		"source_state": event.SourceState,
+		"target_state": event.TargetState,
+		"attempt":      event.Attempt,
+		"level":        event.Level,
+		"occurred_at":  event.OccurredAt.Format("2006-01-02T15:04:05.000Z07:00"),
+		"creator":      event.Creator,
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/producer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/cron"
+	"hcm/pkg/tools/times"
+)
+
+// TriggerCronFlow 根据注册的周期任务定义，为已到达触发时间的周期任务创建任务流。
+// 错过的多次触发（如主节点切换期间）只会补偿创建最近一次。
+func (d *Dispatcher) TriggerCronFlow(kt *kit.Kit) {
+	now := times.ConvStdTimeNow()
+	for _, one := range action.ListCronFlow() {
+		if err := d.triggerCronFlow(kt, one, now); err != nil {
+			logs.Errorf("%s: trigger cron flow %s failed, err: %v, rid: %s", constant.AsyncTaskWarnSign, one.Name,
+				err, kt.Rid)
+		}
+	}
+}
+
+func (d *Dispatcher) triggerCronFlow(kt *kit.Kit, cf action.CronFlow, now time.Time) error {
+	sch, err := cron.Parse(cf.Spec)
+	if err != nil {
+		return err
+	}
+
+	// 上一次触发时间每次都从任务流记录中获取，不在内存中缓存，保证切主或重启后不会重复或遗漏触发
+	last, exist, err := d.getCronLastFireTime(kt, cf)
+	if err != nil {
+		return err
+	}
+
+	next := sch.Next(now)
+	if exist {
+		if next = sch.Next(last); next.IsZero() || next.After(now) {
+			return nil
+		}
+		for n := sch.Next(next); !n.IsZero() && !n.After(now); n = sch.Next(n) {
+			next = n
+		}
+	}
+	if next.IsZero() {
+		return nil
+	}
+
+	opt := &producer.AddTemplateFlowOption{
+		Name:        cf.FlowName,
+		Memo:        cf.Memo(),
+		Tasks:       make([]producer.TemplateFlowTask, 0, len(cf.Params)),
+		ScheduledAt: &next,
+	}
+	for actID, params := range cf.Params {
+		opt.Tasks = append(opt.Tasks, producer.TemplateFlowTask{ActionID: actID, Params: params})
+	}
+
+	flow, err := producer.BuildTemplateFlow(kt, opt)
+	if err != nil {
+		return err
+	}
+
+	id, err := d.bd.CreateFlow(kt, flow)
+	if err != nil {
+		return err
+	}
+
+	logs.Infof("cron flow %s triggered, flow id: %s, scheduled at: %s, rid: %s", cf.Name, id, flow.ScheduledAt,
+		kt.Rid)
+
+	return nil
+}
+
+// getCronLastFireTime 获取周期任务最近一次创建的任务流的调度时间，从未触发过时返回false，
+// 此时会预先创建下一次触发时间的任务流，以此持久化周期任务的起始触发时间。
+func (d *Dispatcher) getCronLastFireTime(kt *kit.Kit, cf action.CronFlow) (time.Time, bool, error) {
+	input := &backend.ListInput{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("name", cf.FlowName),
+			tools.RuleEqual("memo", cf.Memo()),
+		),
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: 1,
+			Sort:  "scheduled_at",
+			Order: core.Descending,
+		},
+	}
+	flows, err := d.bd.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list cron flow %s last flow failed, err: %v, rid: %s", cf.Name, err, kt.Rid)
+		return time.Time{}, false, err
+	}
+
+	if len(flows) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	last, err := time.Parse(constant.TimeStdFormat, flows[0].ScheduledAt)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("parse flow %s scheduled_at failed, err: %v", flows[0].ID, err)
+	}
+
+	return last, true, nil
+}
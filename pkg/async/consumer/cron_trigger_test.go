@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	_ "hcm/pkg/async/action/test"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/times"
+)
+
+func TestTriggerCronFlow(t *testing.T) {
+	sleepParams := types.JsonField(`{"id":"cron","sleep_sec":0}`)
+	action.RegisterCronFlow(action.CronFlow{
+		Name:     "sleep_test",
+		Spec:     "*/10 * * * *",
+		FlowName: enumor.FlowSleepTest,
+		Params: map[action.ActIDType]types.JsonField{
+			"1": sleepParams,
+			"2": sleepParams,
+			"3": sleepParams,
+			"4": sleepParams,
+		},
+	})
+
+	var cf action.CronFlow
+	for _, one := range action.ListCronFlow() {
+		if one.Name == "sleep_test" {
+			cf = one
+		}
+	}
+	if len(cf.Name) == 0 {
+		t.Fatalf("cron flow sleep_test should be registered")
+	}
+
+	bd := backend.NewMemory()
+	kt := kit.New()
+	kt.User = "test"
+	base := time.Date(2024, 1, 1, 10, 5, 0, 0, time.Local)
+
+	listCronFlow := func() []string {
+		flows, err := bd.ListFlow(kt, &backend.ListInput{
+			Filter: tools.EqualExpression("memo", cf.Memo()),
+			Page:   &core.BasePage{Limit: core.DefaultMaxPageLimit, Sort: "scheduled_at"},
+		})
+		if err != nil {
+			t.Fatalf("list flow failed, err: %v", err)
+		}
+
+		scheduled := make([]string, 0, len(flows))
+		for _, one := range flows {
+			scheduled = append(scheduled, one.ScheduledAt)
+		}
+		return scheduled
+	}
+
+	d := NewDispatcher(bd, fakeLeader{}, &DispatcherOption{WatchIntervalSec: 1})
+	// 从未触发过时预先创建下一次触发的任务流，未到调度时间前不会重复创建
+	if err := d.triggerCronFlow(kt, cf, base); err != nil {
+		t.Fatalf("trigger cron flow failed, err: %v", err)
+	}
+	if err := d.triggerCronFlow(kt, cf, base.Add(time.Minute)); err != nil {
+		t.Fatalf("trigger cron flow failed, err: %v", err)
+	}
+	if got := listCronFlow(); len(got) != 1 {
+		t.Fatalf("cron flow should be created once before next fire time, got: %v", got)
+	}
+
+	// 错过多次触发时只补偿最近一次
+	if err := d.triggerCronFlow(kt, cf, base.Add(20*time.Minute)); err != nil {
+		t.Fatalf("trigger cron flow failed, err: %v", err)
+	}
+	if err := d.triggerCronFlow(kt, cf, base.Add(21*time.Minute)); err != nil {
+		t.Fatalf("trigger cron flow failed, err: %v", err)
+	}
+
+	// 切主后从任务流记录中获取上一次触发时间
+	d = NewDispatcher(bd, fakeLeader{}, &DispatcherOption{WatchIntervalSec: 1})
+	if err := d.triggerCronFlow(kt, cf, base.Add(22*time.Minute)); err != nil {
+		t.Fatalf("trigger cron flow failed, err: %v", err)
+	}
+	if err := d.triggerCronFlow(kt, cf, base.Add(26*time.Minute)); err != nil {
+		t.Fatalf("trigger cron flow failed, err: %v", err)
+	}
+
+	expects := []string{
+		times.ConvStdTimeFormat(base.Add(5 * time.Minute)),
+		times.ConvStdTimeFormat(base.Add(15 * time.Minute)),
+		times.ConvStdTimeFormat(base.Add(25 * time.Minute)),
+	}
+	// 到达调度时间的周期任务流与普通任务流一样被派发
+	if err := d.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+	scheduled, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("memo", cf.Memo()),
+			tools.RuleEqual("state", enumor.FlowScheduled),
+		),
+		Page: core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(scheduled) != len(expects) {
+		t.Errorf("all due cron flows should be dispatched, got: %d", len(scheduled))
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
 	"hcm/pkg/async/consumer/leader"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
@@ -33,6 +34,8 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
 )
 
 // NewDispatcher new dispatcher.
@@ -46,7 +49,8 @@ func NewDispatcher(bd backend.Backend, ld leader.Leader, opt *DispatcherOption)
 	}
 }
 
-// Dispatcher 派发器，负责将Pending状态的任务流，派发到指定节点去执行，并将Flow状态改为Scheduled。。
+// Dispatcher 派发器，负责将Pending状态且已到达调度时间的任务流，派发到指定节点去执行，并将Flow状态改为Scheduled。
+// 同时负责按照周期任务定义创建任务流。
 type Dispatcher struct {
 	watchIntervalSec time.Duration
 
@@ -73,6 +77,8 @@ func (d *Dispatcher) WatchPendingFlow() {
 		}
 
 		kt := NewKit()
+		d.TriggerCronFlow(kt)
+
 		if err := d.Do(kt); err != nil {
 			logs.Errorf("%s: dispatcher do failed, err: %v, rid: %s", constant.AsyncTaskWarnSign, err, kt.Rid)
 		}
@@ -83,19 +89,10 @@ func (d *Dispatcher) WatchPendingFlow() {
 	d.wg.Done()
 }
 
-// Do 监听处于Pending状态的流，并派发到指定节点。
+// Do 监听处于Pending状态且已到达调度时间的流，并派发到指定节点。
 func (d *Dispatcher) Do(kt *kit.Kit) error {
-	input := &backend.ListInput{
-		Filter: tools.ExpressionAnd(
-			// 走worker,state 索引
-			tools.RuleEqual("worker", ""),
-			tools.RuleEqual("state", enumor.FlowPending),
-		),
-		Page: core.NewDefaultBasePage(),
-	}
-	flows, err := d.bd.ListFlow(kt, input)
+	flows, err := d.listDispatchableFlow(kt)
 	if err != nil {
-		logs.Errorf("list flow failed, err: %v, rid: %s", err, kt.Rid)
 		return err
 	}
 
@@ -131,6 +128,131 @@ func (d *Dispatcher) Do(kt *kit.Kit) error {
 	return nil
 }
 
+// listDispatchableFlow 分页查询处于Pending状态且已到达调度时间的流，并过滤掉互斥键并发数已达上限的任务流。
+// 互斥键并发已满的任务流可能占满一整页，所以需要继续向后翻页，避免后续任务流无法被派发，单次最多派发一页任务流。
+func (d *Dispatcher) listDispatchableFlow(kt *kit.Kit) ([]model.Flow, error) {
+	input := &backend.ListInput{
+		Filter: tools.ExpressionAnd(
+			// 走worker,state 索引
+			tools.RuleEqual("worker", ""),
+			tools.RuleEqual("state", enumor.FlowPending),
+			tools.RuleLessThanEqual("scheduled_at", times.ConvStdTimeFormat(times.ConvStdTimeNow())),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+
+	running := make(map[string]uint)
+	result := make([]model.Flow, 0)
+	for {
+		flows, err := d.bd.ListFlow(kt, input)
+		if err != nil {
+			logs.Errorf("list flow failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		dispatchable, err := d.filterByConcurrency(kt, flows, running)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dispatchable...)
+
+		if len(result) >= int(core.DefaultMaxPageLimit) {
+			return result[:core.DefaultMaxPageLimit], nil
+		}
+
+		if len(flows) < int(core.DefaultMaxPageLimit) {
+			return result, nil
+		}
+		input.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+// filterByConcurrency 过滤掉互斥键并发数已达上限的任务流，同一批次内的任务流按顺序占用并发额度。
+// running 记录各互斥键已占用的并发数，未记录的互斥键会查询已派发和执行中的任务流数量。
+func (d *Dispatcher) filterByConcurrency(kt *kit.Kit, flows []model.Flow, running map[string]uint) ([]model.Flow,
+	error) {
+
+	keys := make([]string, 0)
+	keyMap := make(map[string]struct{})
+	for _, one := range flows {
+		if len(one.ConcurrencyKey) == 0 {
+			continue
+		}
+		if _, exist := running[one.ConcurrencyKey]; exist {
+			continue
+		}
+		if _, exist := keyMap[one.ConcurrencyKey]; exist {
+			continue
+		}
+		keyMap[one.ConcurrencyKey] = struct{}{}
+		keys = append(keys, one.ConcurrencyKey)
+	}
+
+	if len(keys) != 0 {
+		counts, err := d.countRunningByConcurrencyKey(kt, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			running[key] = counts[key]
+		}
+	}
+
+	result := make([]model.Flow, 0, len(flows))
+	for _, one := range flows {
+		if len(one.ConcurrencyKey) == 0 {
+			result = append(result, one)
+			continue
+		}
+
+		if running[one.ConcurrencyKey] >= max(one.ConcurrencyLimit, 1) {
+			logs.V(3).Infof("flow %s concurrency key %s reach limit %d, wait for next dispatch, rid: %s", one.ID,
+				one.ConcurrencyKey, one.ConcurrencyLimit, kt.Rid)
+			continue
+		}
+
+		running[one.ConcurrencyKey]++
+		result = append(result, one)
+	}
+
+	return result, nil
+}
+
+// countRunningByConcurrencyKey 统计各互斥键下已派发（Scheduled）和执行中（Running）的任务流数量。
+func (d *Dispatcher) countRunningByConcurrencyKey(kt *kit.Kit, keys []string) (map[string]uint, error) {
+	counts := make(map[string]uint, len(keys))
+	for _, part := range slice.Split(keys, int(core.DefaultMaxPageLimit)) {
+		input := &backend.ListInput{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("concurrency_key", part),
+				tools.RuleIn("state", []enumor.FlowState{enumor.FlowScheduled, enumor.FlowRunning}),
+			),
+			Fields: []string{"id", "concurrency_key"},
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			flows, err := d.bd.ListFlow(kt, input)
+			if err != nil {
+				logs.Errorf("list running flow by concurrency key failed, err: %v, keys: %v, rid: %s", err, part,
+					kt.Rid)
+				return nil, err
+			}
+
+			for _, one := range flows {
+				counts[one.ConcurrencyKey]++
+			}
+
+			if len(flows) < int(core.DefaultMaxPageLimit) {
+				break
+			}
+			input.Page.Start += uint32(core.DefaultMaxPageLimit)
+		}
+	}
+
+	return counts, nil
+}
+
 // Close dispatcher
 func (d *Dispatcher) Close() {
 
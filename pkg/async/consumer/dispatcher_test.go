@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+)
+
+type fakeLeader struct{}
+
+func (fakeLeader) IsLeader() bool                { return true }
+func (fakeLeader) AliveNodes() ([]string, error) { return []string{"node-1"}, nil }
+func (fakeLeader) CurrNode() string              { return "node-1" }
+
+func TestDispatcherConcurrencyKey(t *testing.T) {
+	bd := backend.NewMemory()
+	kt := kit.New()
+	kt.User = "test"
+
+	create := func(key string, limit uint) {
+		_, err := bd.CreateFlow(kt, &model.Flow{
+			Name:             enumor.FlowStartCvm,
+			ConcurrencyKey:   key,
+			ConcurrencyLimit: limit,
+		})
+		if err != nil {
+			t.Fatalf("create flow failed, err: %v", err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		create("lb:1", 1)
+		create("account:1", 2)
+		create("", 0)
+	}
+
+	d := NewDispatcher(bd, fakeLeader{}, &DispatcherOption{WatchIntervalSec: 1})
+	if err := d.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+	// 再次派发时，已派发的任务流仍占用并发额度
+	if err := d.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+
+	scheduled, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("state", enumor.FlowScheduled),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, one := range scheduled {
+		counts[one.ConcurrencyKey]++
+	}
+	expects := map[string]int{"lb:1": 1, "account:1": 2, "": 3}
+	for key, expect := range expects {
+		if counts[key] != expect {
+			t.Errorf("concurrency key %q should have %d scheduled flows, got: %d", key, expect, counts[key])
+		}
+	}
+}
+
+func TestDispatcherSaturatedKeyNotStarve(t *testing.T) {
+	bd := backend.NewMemory()
+	kt := kit.New()
+	kt.User = "test"
+
+	create := func(key string, limit uint) {
+		_, err := bd.CreateFlow(kt, &model.Flow{
+			Name:             enumor.FlowStartCvm,
+			ConcurrencyKey:   key,
+			ConcurrencyLimit: limit,
+		})
+		if err != nil {
+			t.Fatalf("create flow failed, err: %v", err)
+		}
+	}
+	// 互斥键并发已满的任务流占满第一页以上，排在后面的任务流仍需要被派发
+	for i := 0; i < int(core.DefaultMaxPageLimit)+10; i++ {
+		create("lb:1", 1)
+	}
+	for i := 0; i < 3; i++ {
+		create("", 0)
+		create("lb:2", 1)
+	}
+
+	d := NewDispatcher(bd, fakeLeader{}, &DispatcherOption{WatchIntervalSec: 1})
+	if err := d.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+
+	scheduled, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("state", enumor.FlowScheduled),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, one := range scheduled {
+		counts[one.ConcurrencyKey]++
+	}
+	expects := map[string]int{"lb:1": 1, "lb:2": 1, "": 3}
+	for key, expect := range expects {
+		if counts[key] != expect {
+			t.Errorf("concurrency key %q should have %d scheduled flows, got: %d", key, expect, counts[key])
+		}
+	}
+}
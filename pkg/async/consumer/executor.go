@@ -27,7 +27,6 @@ import (
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/async/action"
-	"hcm/pkg/async/action/run"
 	"hcm/pkg/async/backend"
 	"hcm/pkg/async/backend/model"
 	"hcm/pkg/async/compctrl"
@@ -141,9 +140,15 @@ func (exec *executor) initWorkerTask(flow *Flow, task *Task) {
 	}
 
 	// 设置task执行所需要的 kit，更新Task函数，所属流
-	task.InitDep(run.NewExecuteContext(task.Kit, flow.ShareData), func(taskKit *kit.Kit, task *model.Task) error {
-		return exec.backend.UpdateTask(exec.kt, task)
-	}, flow)
+	// 任务执行过程中通过 ExecuteKit.Logger 输出的日志作为任务事件批量保存
+	task.InitDep(task.newExecuteKit(exec.kt, exec.backend, flow.ShareData),
+		func(taskKit *kit.Kit, md *model.Task) error {
+			if err := exec.backend.UpdateTask(exec.kt, md); err != nil {
+				return err
+			}
+			recordTaskEvents(exec.kt, exec.backend, newTaskStateEvent(task.FlowID, task.State, md))
+			return nil
+		}, flow)
 
 	// cancel存储到cancelMap中
 	exec.cancelMap.Store(task.ID, cancel)
@@ -172,6 +177,8 @@ func (exec *executor) workerDo(task *Task) (err error) {
 	// 无论任务成功还是失败，都需要交给scheduler分析任务流的状态
 	// 执行完的任务回写到scheduler用于获取待执行的任务
 	defer exec.GetSchedulerFunc().EntryTask(task)
+	// 任务执行结束后保存缓存的执行日志
+	defer task.flushLogs()
 	var runErr error
 	var failedRet any
 
@@ -209,37 +216,46 @@ func (exec *executor) workerDo(task *Task) (err error) {
 		return
 	}()
 
-	if !task.Retry.IsEnable() {
-		_, failedRet, runErr = exec.runTaskOnce(task, act)
-		return
-	}
-
-	if task.State == enumor.TaskRollback && task.Reason.RollbackCount >= task.Retry.Policy.Count {
+	if task.Retry.IsEnable() && task.State == enumor.TaskRollback &&
+		task.Reason.RollbackCount >= task.Retry.Policy.Count {
 		// 超过指定重试次数，置为失败
 		runErr = fmt.Errorf("too many retries: %w", errors.New(task.Reason.Message))
 		return
 	}
-	// 减去已经执行的count
-	task.Retry.Policy.Count -= task.Reason.RollbackCount
-	failedRet, runErr = task.Retry.Run(func() (stop bool, failRet any, err error) {
-		needRetry, failRet, err := exec.runTaskOnce(task, act)
-		if err == nil {
-			return false, nil, nil
-		}
 
-		if !needRetry {
-			return true, failRet, err
-		}
-		// 允许重试，将Task状态由 running -> rollback，进行回滚
-		if patchErr := exec.UpdateTask(task, enumor.TaskRollback, err.Error(), failRet); patchErr != nil {
-			e := fmt.Errorf("task set rollback state failed, after runAction failed, err: %v, patchErr: %v",
-				err, patchErr)
-			return false, failRet, e
-		}
+	needRetry, failRet, err := exec.runTaskOnce(task, act)
+	if err == nil {
+		return nil
+	}
 
-		return false, nil, nil
+	class := action.ErrorFatal
+	if needRetry {
+		class = action.ClassifyError(act, err)
+	}
+	task.Reason.AppendAttempt(tableasync.TaskAttempt{
+		Index:    task.Reason.RollbackCount + 1,
+		Class:    string(class),
+		Message:  err.Error(),
+		FailedAt: times.ConvStdTimeFormat(times.ConvStdTimeNow()),
 	})
 
+	if !task.Retry.IsEnable() || class == action.ErrorFatal ||
+		task.Reason.RollbackCount+1 >= task.Retry.Policy.Count {
+		failedRet, runErr = failRet, err
+		return
+	}
+
+	// 允许重试，将Task状态由 running -> rollback，并记录下一次重试时间，由调度器到期后重新推送执行，不占用执行器协程等待
+	backoff := task.Retry.Policy.Backoff(task.Reason.RollbackCount + 1)
+	task.Reason.NextAttemptAt = times.ConvStdTimeFormat(times.ConvStdTimeNow().Add(backoff))
+	if patchErr := exec.UpdateTask(task, enumor.TaskRollback, err.Error(), failRet); patchErr != nil {
+		runErr = fmt.Errorf("task set rollback state failed, after runAction failed, err: %v, patchErr: %v",
+			err, patchErr)
+		return
+	}
+	logs.Infof("task %s run failed, retry after %s, attempt: %d, err: %v, rid: %s", task.ID, backoff,
+		task.Reason.RollbackCount, err, task.Kit.Rid)
+
 	return nil
 }
 
@@ -257,7 +273,7 @@ func (exec *executor) runTaskOnce(task *Task, act action.Action) (needRetry bool
 		}
 
 		if err = rollbackAct.Rollback(task.ExecuteKit, params); err != nil {
-			return true, nil, fmt.Errorf("rollback failed, err: %v", err)
+			return true, nil, fmt.Errorf("rollback failed, err: %w", err)
 		}
 
 		if err = exec.UpdateTaskState(task, enumor.TaskPending); err != nil {
@@ -276,7 +292,7 @@ func (exec *executor) runTaskOnce(task *Task, act action.Action) (needRetry bool
 				// 被取消不需要重试
 				return false, result, err
 			}
-			return true, result, fmt.Errorf("run failed, err: %v, time: %s",
+			return true, result, fmt.Errorf("run failed, err: %w, time: %s",
 				err, times.ConvStdTimeNow())
 		}
 
@@ -405,8 +421,10 @@ func (exec *executor) UpdateTask(task *Task, state enumor.TaskState, reason stri
 			err, DefRetryCount, task.ID, state, reason, exec.kt.Rid)
 		return err
 	}
+	recordTaskEvents(exec.kt, exec.backend, newTaskStateEvent(task.FlowID, task.State, md))
 
 	task.State = state
+	task.Reason = md.Reason
 
 	return nil
 }
@@ -0,0 +1,226 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+)
+
+// flakyAction 每次运行都返回指定错误的测试任务
+type flakyAction struct {
+	runErr    error
+	rollbacks *int
+}
+
+func (flakyAction) Name() enumor.ActionName { return enumor.ActionAssembleTest }
+
+func (a flakyAction) Run(run.ExecuteKit, interface{}) (interface{}, error) { return nil, a.runErr }
+
+func (a flakyAction) Rollback(run.ExecuteKit, interface{}) error {
+	*a.rollbacks++
+	return nil
+}
+
+type fakeScheduler struct {
+	entries []*Task
+}
+
+func (s *fakeScheduler) Close()                    {}
+func (s *fakeScheduler) Start()                    {}
+func (s *fakeScheduler) EntryTask(task *Task)      { s.entries = append(s.entries, task) }
+func (s *fakeScheduler) DeleteFlowTaskTree(string) {}
+
+func TestExecutorWorkerDoReschedule(t *testing.T) {
+	orig, _ := action.GetAction(enumor.ActionAssembleTest)
+	t.Cleanup(func() { action.RegisterAction(orig) })
+
+	cases := []struct {
+		name          string
+		retry         *tableasync.Retry
+		state         enumor.TaskState
+		rollbackCount uint
+		runErr        error
+		expectErr     bool
+		expectState   enumor.TaskState
+		expectCount   uint
+		expectBackoff time.Duration
+		rollbacks     int
+	}{
+		{
+			name:          "retryable error reschedule",
+			retry:         tableasync.NewRetryWithPolicy(3, 1000, 10000),
+			state:         enumor.TaskPending,
+			runErr:        errors.New("dial tcp: i/o timeout"),
+			expectState:   enumor.TaskRollback,
+			expectCount:   1,
+			expectBackoff: time.Second,
+		},
+		{
+			name:          "rollback then reschedule with longer backoff",
+			retry:         tableasync.NewRetryWithPolicy(3, 1000, 10000),
+			state:         enumor.TaskRollback,
+			rollbackCount: 1,
+			runErr:        errors.New("dial tcp: i/o timeout"),
+			expectState:   enumor.TaskRollback,
+			expectCount:   2,
+			expectBackoff: 2 * time.Second,
+			rollbacks:     1,
+		},
+		{
+			name:          "last attempt failed",
+			retry:         tableasync.NewRetryWithPolicy(3, 1000, 10000),
+			state:         enumor.TaskRollback,
+			rollbackCount: 2,
+			runErr:        errors.New("dial tcp: i/o timeout"),
+			expectErr:     true,
+			expectState:   enumor.TaskFailed,
+			expectCount:   2,
+			rollbacks:     1,
+		},
+		{
+			name:          "too many retries",
+			retry:         tableasync.NewRetryWithPolicy(3, 1000, 10000),
+			state:         enumor.TaskRollback,
+			rollbackCount: 3,
+			runErr:        errors.New("dial tcp: i/o timeout"),
+			expectErr:     true,
+			expectState:   enumor.TaskFailed,
+			expectCount:   3,
+		},
+		{
+			name:        "fatal error not retry",
+			retry:       tableasync.NewRetryWithPolicy(3, 1000, 10000),
+			state:       enumor.TaskPending,
+			runErr:      action.NewFatalError(errors.New("invalid param")),
+			expectErr:   true,
+			expectState: enumor.TaskFailed,
+		},
+		{
+			name:        "unclassified error not retry",
+			retry:       tableasync.NewRetryWithPolicy(3, 1000, 10000),
+			state:       enumor.TaskPending,
+			runErr:      errors.New("unknown"),
+			expectErr:   true,
+			expectState: enumor.TaskFailed,
+		},
+		{
+			name:        "retry disabled",
+			retry:       new(tableasync.Retry),
+			state:       enumor.TaskPending,
+			runErr:      errors.New("dial tcp: i/o timeout"),
+			expectErr:   true,
+			expectState: enumor.TaskFailed,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rollbacks := 0
+			action.RegisterAction(flakyAction{runErr: c.runErr, rollbacks: &rollbacks})
+
+			bd := backend.NewMemory()
+			kt := kit.New()
+			kt.User = "test"
+			flowID, err := bd.CreateFlow(kt, &model.Flow{
+				Name: enumor.FlowNormalTest,
+				Tasks: []model.Task{{
+					FlowName:   enumor.FlowNormalTest,
+					ActionID:   "1",
+					ActionName: enumor.ActionAssembleTest,
+					Retry:      c.retry,
+				}},
+			})
+			if err != nil {
+				t.Fatalf("create flow failed, err: %v", err)
+			}
+
+			listTask := func() model.Task {
+				tasks, err := bd.ListTask(kt, &backend.ListInput{
+					Filter: tools.EqualExpression("flow_id", flowID),
+					Page:   core.NewDefaultBasePage(),
+				})
+				if err != nil || len(tasks) != 1 {
+					t.Fatalf("list task failed, err: %v, count: %d", err, len(tasks))
+				}
+				return tasks[0]
+			}
+
+			task := &Task{Task: listTask(), Kit: kt}
+			task.State = c.state
+			task.Reason.RollbackCount = c.rollbackCount
+			task.InitDep(run.NewExecuteContext(kt, nil), nil, nil)
+
+			sch := new(fakeScheduler)
+			exec := NewExecutor(kt, bd, &ExecutorOption{WorkerNumber: 1, TaskExecTimeoutSec: 10}).(*executor)
+			exec.SetGetSchedulerFunc(func() Scheduler { return sch })
+
+			start := time.Now()
+			err = exec.workerDo(task)
+			end := time.Now()
+			if (err != nil) != c.expectErr {
+				t.Fatalf("workerDo should return error: %v, got: %v", c.expectErr, err)
+			}
+			if len(sch.entries) != 1 {
+				t.Errorf("task should be entried to scheduler once, got: %d", len(sch.entries))
+			}
+			if rollbacks != c.rollbacks {
+				t.Errorf("rollback should be called %d times, got: %d", c.rollbacks, rollbacks)
+			}
+
+			stored := listTask()
+			if stored.State != c.expectState {
+				t.Errorf("task state should be %s, got: %s", c.expectState, stored.State)
+			}
+			if stored.Reason.RollbackCount != c.expectCount {
+				t.Errorf("task rollback count should be %d, got: %d", c.expectCount, stored.Reason.RollbackCount)
+			}
+
+			if c.expectState != enumor.TaskRollback {
+				if len(stored.Reason.NextAttemptAt) != 0 {
+					t.Errorf("failed task should not have next attempt at, got: %s", stored.Reason.NextAttemptAt)
+				}
+				return
+			}
+
+			// 下一次重试时间在 [backoff/2, backoff] 之间，时间格式精确到秒
+			next, err := time.Parse(constant.TimeStdFormat, stored.Reason.NextAttemptAt)
+			if err != nil {
+				t.Fatalf("parse next attempt at %s failed, err: %v", stored.Reason.NextAttemptAt, err)
+			}
+			lower := start.Add(c.expectBackoff / 2).Truncate(time.Second)
+			if next.Before(lower) || next.After(end.Add(c.expectBackoff)) {
+				t.Errorf("next attempt at should be in [%s, %s], got: %s", lower, end.Add(c.expectBackoff), next)
+			}
+		})
+	}
+}
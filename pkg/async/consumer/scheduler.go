@@ -368,7 +368,7 @@ func (sch *scheduler) parseFlowAndPushTask(kt *kit.Kit, flow *Flow) error {
 	// 所有可执行任务推送到执行器
 	flow.State = enumor.FlowRunning
 	for _, taskID := range executableTaskNodes {
-		sch.executor.Push(flow, taskIDMap[taskID])
+		sch.pushTask(flow, taskIDMap[taskID])
 	}
 
 	return nil
@@ -544,12 +544,54 @@ func (sch *scheduler) pushTasks(kt *kit.Kit, flow *Flow, ids []string) error {
 
 	// 可执行任务推送到执行器
 	for _, one := range tasks {
-		sch.executor.Push(flow, one)
+		sch.pushTask(flow, one)
 	}
 
 	return nil
 }
 
+// pushTask 推送任务到执行器，处于重试等待中的任务，到达下一次重试时间后再推送
+func (sch *scheduler) pushTask(flow *Flow, task *Task) {
+	wait := task.RetryWaitTime()
+	if wait <= 0 {
+		sch.executor.Push(flow, task)
+		return
+	}
+
+	logs.V(3).Infof("task %s is waiting for retry, push after %s, rid: %s", task.ID, wait, task.Kit.Rid)
+	time.AfterFunc(wait, func() {
+		sch.pushDelayedTask(flow, task.ID)
+	})
+}
+
+// pushDelayedTask 重试等待结束后，重新查询任务并推送到执行器
+func (sch *scheduler) pushDelayedTask(flow *Flow, taskID string) {
+	select {
+	case <-sch.closeCh:
+		return
+	default:
+	}
+
+	// 等待期间任务流可能已经被取消或者结束
+	if _, ok := sch.getTaskTree(flow.ID); !ok {
+		return
+	}
+
+	tasks, err := listTaskByIDs(flow.Kit, sch.backend, []string{taskID})
+	if err != nil {
+		logs.Errorf("%s: list delayed task failed, err: %v, id: %s, rid: %s", constant.AsyncTaskWarnSign, err,
+			taskID, flow.Kit.Rid)
+		return
+	}
+
+	for _, one := range tasks {
+		if one.State != enumor.TaskRollback {
+			continue
+		}
+		sch.executor.Push(flow, one)
+	}
+}
+
 // 获取存储的任务流树
 func (sch *scheduler) getTaskTree(flowID string) (*TaskTree, bool) {
 	tasks, ok := sch.taskTrees.Load(flowID)
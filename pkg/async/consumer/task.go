@@ -23,10 +23,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"hcm/pkg/async/action"
 	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
 	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/dal/table/types"
@@ -44,6 +47,8 @@ type Task struct {
 	ExecuteKit run.ExecuteKit `json:"-"`
 	Patch      func(taskKit *kit.Kit, task *model.Task) error
 	Flow       *Flow
+
+	logBuffer *taskLogBuffer
 }
 
 // ValidateBeforeExec task validate before execute.
@@ -92,6 +97,37 @@ func (task *Task) ValidateBeforeRollback(act action.Action) error {
 	return nil
 }
 
+// RetryWaitTime 处于重试等待中的任务，返回距离下一次重试还需等待的时间
+func (task *Task) RetryWaitTime() time.Duration {
+	if task.State != enumor.TaskRollback || task.Reason == nil || len(task.Reason.NextAttemptAt) == 0 {
+		return 0
+	}
+
+	next, err := time.Parse(constant.TimeStdFormat, task.Reason.NextAttemptAt)
+	if err != nil {
+		return 0
+	}
+
+	return time.Until(next)
+}
+
+// newExecuteKit 创建任务执行或回滚所需的 ExecuteKit，过程中通过 ExecuteKit.Logger 输出的日志作为任务事件批量保存
+func (task *Task) newExecuteKit(kt *kit.Kit, bd backend.Backend, shareData run.ShareDataOperator) run.ExecuteKit {
+	task.logBuffer = newTaskLogBuffer(kt, bd)
+	recorder := func(level enumor.TaskLogLevel, msg string) {
+		task.logBuffer.add(newTaskLogEvent(task, level, msg))
+	}
+	return run.NewExecuteContextWithRecorder(task.Kit, shareData, recorder)
+}
+
+// flushLogs 保存任务执行过程中缓存的日志
+func (task *Task) flushLogs() {
+	if task.logBuffer == nil {
+		return
+	}
+	task.logBuffer.flush()
+}
+
 // InitDep init task for exec.
 func (task *Task) InitDep(kt run.ExecuteKit, patch func(kt *kit.Kit, task *model.Task) error, flow *Flow) {
 	task.ExecuteKit = kt
@@ -201,6 +237,7 @@ func (task *Task) UpdateTask(state enumor.TaskState, reason string, result inter
 	}
 
 	task.State = state
+	task.Reason = md.Reason
 
 	return nil
 }
@@ -215,15 +252,17 @@ func (task *Task) buildTaskUpdateModel(kt *kit.Kit, state enumor.TaskState, reas
 			Message:       task.Reason.Message,
 			RollbackCount: task.Reason.RollbackCount,
 			PreState:      string(task.State),
+			Attempts:      task.Reason.Attempts,
 		},
 	}
 	if reason != "" {
 		md.Reason.Message = reason
 	}
 
-	// 更新为rollback，记录rollback次数
+	// 更新为rollback，记录rollback次数以及下一次重试时间
 	if state == enumor.TaskRollback {
 		md.Reason.RollbackCount = task.Reason.RollbackCount + 1
+		md.Reason.NextAttemptAt = task.Reason.NextAttemptAt
 	}
 	if result != nil {
 		field, err := types.NewJsonField(result)
@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"sync"
+	"time"
+
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// newTaskStateEvent 根据任务更新模型构建任务状态流转事件
+func newTaskStateEvent(flowID string, source enumor.TaskState, md *model.Task) model.TaskEvent {
+	event := model.TaskEvent{
+		FlowID:      flowID,
+		TaskID:      md.ID,
+		Type:        enumor.TaskEventState,
+		SourceState: source,
+		TargetState: md.State,
+		Attempt:     1,
+		OccurredAt:  time.Now(),
+	}
+
+	if md.Reason != nil {
+		event.Message = md.Reason.Message
+		event.Attempt = md.Reason.RollbackCount + 1
+		// 进入rollback状态时回滚次数已经加一，事件归属于刚刚失败的那次执行
+		if md.State == enumor.TaskRollback && md.Reason.RollbackCount > 0 {
+			event.Attempt = md.Reason.RollbackCount
+		}
+	}
+	if len(event.Message) > tableasync.MaxTaskEventMessageLength {
+		event.Message = event.Message[:tableasync.MaxTaskEventMessageLength]
+	}
+
+	return event
+}
+
+// newTaskLogEvent 构建任务执行日志事件
+func newTaskLogEvent(task *Task, level enumor.TaskLogLevel, msg string) model.TaskEvent {
+	attempt := uint(1)
+	if task.Reason != nil {
+		attempt = task.Reason.RollbackCount + 1
+	}
+
+	return model.TaskEvent{
+		FlowID:     task.FlowID,
+		TaskID:     task.ID,
+		Type:       enumor.TaskEventLog,
+		Attempt:    attempt,
+		Level:      level,
+		Message:    msg,
+		OccurredAt: time.Now(),
+	}
+}
+
+// recordTaskEvents 保存任务事件，事件仅用于展示任务执行时间线，保存失败不影响任务执行
+func recordTaskEvents(kt *kit.Kit, bd backend.Backend, events ...model.TaskEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	if err := bd.BatchCreateTaskEvent(kt, events); err != nil {
+		logs.Errorf("record task events failed, err: %v, count: %d, rid: %s", err, len(events), kt.Rid)
+	}
+}
+
+// taskLogBatchSize 任务执行日志批量保存的条数
+const taskLogBatchSize = 50
+
+// taskLogBuffer 缓存任务执行日志，达到批量条数或任务执行结束时批量保存，避免每条日志写一次数据库。
+// 事件的发生时间在产生日志时确定，批量保存不影响时间线中的日志顺序。
+type taskLogBuffer struct {
+	kt *kit.Kit
+	bd backend.Backend
+
+	lock   sync.Mutex
+	events []model.TaskEvent
+}
+
+func newTaskLogBuffer(kt *kit.Kit, bd backend.Backend) *taskLogBuffer {
+	return &taskLogBuffer{kt: kt, bd: bd}
+}
+
+// add 添加日志事件，缓存达到批量条数时保存
+func (b *taskLogBuffer) add(event model.TaskEvent) {
+	b.lock.Lock()
+	b.events = append(b.events, event)
+	if len(b.events) < taskLogBatchSize {
+		b.lock.Unlock()
+		return
+	}
+	events := b.events
+	b.events = nil
+	b.lock.Unlock()
+
+	recordTaskEvents(b.kt, b.bd, events...)
+}
+
+// flush 保存缓存中的全部日志事件
+func (b *taskLogBuffer) flush() {
+	b.lock.Lock()
+	events := b.events
+	b.events = nil
+	b.lock.Unlock()
+
+	recordTaskEvents(b.kt, b.bd, events...)
+}
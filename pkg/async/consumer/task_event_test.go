@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+)
+
+func TestNewTaskStateEventAttempt(t *testing.T) {
+	cases := []struct {
+		name    string
+		source  enumor.TaskState
+		target  enumor.TaskState
+		reason  *tableasync.Reason
+		attempt uint
+	}{
+		{name: "no reason", source: enumor.TaskPending, target: enumor.TaskRunning, attempt: 1},
+		{
+			name:    "first run",
+			source:  enumor.TaskPending,
+			target:  enumor.TaskRunning,
+			reason:  &tableasync.Reason{},
+			attempt: 1,
+		},
+		{
+			name:    "first run failed to rollback",
+			source:  enumor.TaskRunning,
+			target:  enumor.TaskRollback,
+			reason:  &tableasync.Reason{RollbackCount: 1},
+			attempt: 1,
+		},
+		{
+			name:    "rollback to pending start second attempt",
+			source:  enumor.TaskRollback,
+			target:  enumor.TaskPending,
+			reason:  &tableasync.Reason{RollbackCount: 1},
+			attempt: 2,
+		},
+		{
+			name:    "second run failed to rollback",
+			source:  enumor.TaskRunning,
+			target:  enumor.TaskRollback,
+			reason:  &tableasync.Reason{RollbackCount: 2},
+			attempt: 2,
+		},
+		{
+			name:    "third run failed",
+			source:  enumor.TaskRunning,
+			target:  enumor.TaskFailed,
+			reason:  &tableasync.Reason{RollbackCount: 2},
+			attempt: 3,
+		},
+		{
+			name:    "rollback without count",
+			source:  enumor.TaskRunning,
+			target:  enumor.TaskRollback,
+			reason:  &tableasync.Reason{},
+			attempt: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			event := newTaskStateEvent("flow-1", c.source, &model.Task{ID: "task-1", State: c.target,
+				Reason: c.reason})
+			if event.Attempt != c.attempt {
+				t.Errorf("event attempt should be %d, got: %d", c.attempt, event.Attempt)
+			}
+			if event.SourceState != c.source || event.TargetState != c.target {
+				t.Errorf("event should be %s -> %s, got: %s -> %s", c.source, c.target, event.SourceState,
+					event.TargetState)
+			}
+		})
+	}
+
+	long := strings.Repeat("a", tableasync.MaxTaskEventMessageLength+10)
+	event := newTaskStateEvent("flow-1", enumor.TaskRunning, &model.Task{ID: "task-1", State: enumor.TaskFailed,
+		Reason: &tableasync.Reason{Message: long}})
+	if len(event.Message) != tableasync.MaxTaskEventMessageLength {
+		t.Errorf("event message should be truncated to %d, got: %d", tableasync.MaxTaskEventMessageLength,
+			len(event.Message))
+	}
+}
+
+func TestTaskLogBuffer(t *testing.T) {
+	bd := backend.NewMemory()
+	kt := kit.New()
+	kt.User = "test"
+
+	countEvents := func() int {
+		events, err := bd.ListTaskEvent(kt, &backend.ListInput{
+			Filter: tools.EqualExpression("task_id", "task-1"),
+			Page:   core.NewDefaultBasePage(),
+		})
+		if err != nil {
+			t.Fatalf("list task event failed, err: %v", err)
+		}
+		return len(events)
+	}
+
+	task := &Task{Task: model.Task{ID: "task-1", FlowID: "flow-1"}}
+	buffer := newTaskLogBuffer(kt, bd)
+	for i := 0; i < taskLogBatchSize-1; i++ {
+		buffer.add(newTaskLogEvent(task, enumor.TaskLogInfo, "log"))
+	}
+	if got := countEvents(); got != 0 {
+		t.Fatalf("task logs should be buffered before reach batch size, got: %d", got)
+	}
+
+	buffer.add(newTaskLogEvent(task, enumor.TaskLogInfo, "log"))
+	buffer.add(newTaskLogEvent(task, enumor.TaskLogInfo, "log"))
+	if got := countEvents(); got != taskLogBatchSize {
+		t.Fatalf("task logs should be saved when reach batch size, expect: %d, got: %d", taskLogBatchSize, got)
+	}
+
+	buffer.flush()
+	buffer.flush()
+	if got := countEvents(); got != taskLogBatchSize+1 {
+		t.Errorf("task logs should be saved after flush, expect: %d, got: %d", taskLogBatchSize+1, got)
+	}
+}
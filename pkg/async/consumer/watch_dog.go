@@ -25,7 +25,6 @@ import (
 	"time"
 
 	"hcm/pkg/api/core"
-	"hcm/pkg/async/action/run"
 	"hcm/pkg/async/backend"
 	"hcm/pkg/async/backend/model"
 	"hcm/pkg/async/compctrl"
@@ -162,7 +161,7 @@ func (wd *watchDog) handleExpiredTasks(kt *kit.Kit) error {
 		}
 
 		ids = append(ids, one.ID)
-		if err = wd.updateTimeoutTask(kt, one.ID); err != nil {
+		if err = wd.updateTimeoutTask(kt, one); err != nil {
 			return err
 		}
 
@@ -186,18 +185,24 @@ func (wd *watchDog) handleExpiredTasks(kt *kit.Kit) error {
 	return nil
 }
 
-func (wd *watchDog) updateTimeoutTask(kt *kit.Kit, id string) error {
-	task := &model.Task{
-		ID:    id,
+func (wd *watchDog) updateTimeoutTask(kt *kit.Kit, one model.Task) error {
+	md := &model.Task{
+		ID:    one.ID,
 		State: enumor.TaskFailed,
 		Reason: &tableasync.Reason{
 			Message: ErrTaskExecTimeout,
 		},
 	}
-	if err := wd.bd.UpdateTask(kt, task); err != nil {
+	if err := wd.bd.UpdateTask(kt, md); err != nil {
 		logs.Errorf("update task to failed state failed, err: %v, rid: %s", err, kt.Rid)
 		return err
 	}
+
+	event := newTaskStateEvent(one.FlowID, one.State, md)
+	if one.Reason != nil {
+		event.Attempt = one.Reason.RollbackCount + 1
+	}
+	recordTaskEvents(kt, wd.bd, event)
 	return nil
 }
 
@@ -377,7 +382,7 @@ func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []strin
 		isExpired := wd.checkIsExpireTask(kt, task.Task)
 		if isExpired {
 			// 如果任务已经超时，更新为失败状态，失败原因超时
-			if err = wd.updateTimeoutTask(kt, task.ID); err != nil {
+			if err = wd.updateTimeoutTask(kt, task.Task); err != nil {
 				return err
 			}
 			continue
@@ -398,13 +403,20 @@ func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []strin
 			}
 		}
 
-		taskExecKit := run.NewExecuteContext(task.Kit, flow.ShareData)
-		task.InitDep(taskExecKit, func(taskKit *kit.Kit, task *model.Task) error {
-			return wd.bd.UpdateTask(kt, task)
+		// 回滚过程中输出的日志与执行器中一样作为任务事件保存
+		taskExecKit := task.newExecuteKit(kt, wd.bd, flow.ShareData)
+		task.InitDep(taskExecKit, func(taskKit *kit.Kit, md *model.Task) error {
+			if err := wd.bd.UpdateTask(kt, md); err != nil {
+				return err
+			}
+			recordTaskEvents(kt, wd.bd, newTaskStateEvent(flow.ID, task.State, md))
+			return nil
 		}, &Flow{Flow: flow})
 
 		// 如果任务可以重试，更新任务状态为RollBack ，等待回滚
-		if err = task.Rollback(); err != nil {
+		err = task.Rollback()
+		task.flushLogs()
+		if err != nil {
 			logs.Errorf("rollback not exist node task failed, err: %v, rid: %s", err, kt.Rid)
 
 			md := &model.Task{
@@ -425,6 +437,14 @@ func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []strin
 
 // checkIsExpireTask 检查任务是否超时
 func (wd *watchDog) checkIsExpireTask(kt *kit.Kit, task model.Task) bool {
+	// 处于重试等待中的任务，从下一次重试时间开始计算超时
+	if task.State == enumor.TaskRollback && task.Reason != nil && len(task.Reason.NextAttemptAt) != 0 {
+		next, err := time.Parse(constant.TimeStdFormat, task.Reason.NextAttemptAt)
+		if err == nil {
+			return time.Now().After(next.Add(wd.taskTimeoutSec))
+		}
+	}
+
 	if task.Retry == nil || !task.Retry.IsEnable() || task.Retry.Policy == nil {
 		return task.UpdatedAt < times.ConvStdTimeFormat(times.ConvStdTimeNow().Add(-wd.taskTimeoutSec))
 	}
@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+)
+
+func TestWatchDogRollbackRecordLogs(t *testing.T) {
+	bd := backend.NewMemory()
+	kt := kit.New()
+	kt.User = "test"
+
+	flowID, err := bd.CreateFlow(kt, &model.Flow{
+		Name:      enumor.FlowSleepTest,
+		ShareData: tableasync.NewShareData(nil),
+		Tasks: []model.Task{{
+			FlowName:   enumor.FlowSleepTest,
+			ActionID:   "1",
+			ActionName: enumor.ActionSleep,
+			Params:     `{"id":"1","sleep_sec":0}`,
+			Retry:      tableasync.NewRetryWithPolicy(3, 1000, 10000),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("list flow task failed, err: %v, count: %d", err, len(tasks))
+	}
+	taskID := tasks[0].ID
+	if err = bd.UpdateTask(kt, &model.Task{ID: taskID, State: enumor.TaskRunning}); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+
+	flows, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil || len(flows) != 1 {
+		t.Fatalf("list flow failed, err: %v, count: %d", err, len(flows))
+	}
+
+	wd := NewWatchDog(bd, fakeLeader{}, &WatchDogOption{TaskRunTimeoutSec: 3600}).(*watchDog)
+	if err = wd.handleRunningTasks(kt, flows[0], []string{taskID}); err != nil {
+		t.Fatalf("handle running tasks failed, err: %v", err)
+	}
+
+	// 回滚过程中通过 ExecuteKit.Logger 输出的日志需要作为任务事件保存
+	events, err := bd.ListTaskEvent(kt, &backend.ListInput{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("task_id", taskID),
+			tools.RuleEqual("type", enumor.TaskEventLog),
+		),
+		Page: core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list task event failed, err: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("rollback log should be recorded as task event, got: %+v", events)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/tools/times"
 )
 
 // AddCustomFlow add custom flow
@@ -117,6 +118,13 @@ func buildCustomFlow(opt *AddCustomFlowOption) *model.Flow {
 	if opt.IsInitState {
 		flow.State = enumor.FlowInit
 	}
+	if opt.ScheduledAt != nil {
+		flow.ScheduledAt = times.ConvStdTimeFormat(*opt.ScheduledAt)
+	}
+	if len(opt.ConcurrencyKey) != 0 {
+		flow.ConcurrencyKey = opt.ConcurrencyKey
+		flow.ConcurrencyLimit = max(opt.ConcurrencyLimit, 1)
+	}
 
 	for _, one := range opt.Tasks {
 		if one.Retry == nil {
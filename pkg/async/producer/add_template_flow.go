@@ -29,26 +29,16 @@ import (
 	"hcm/pkg/dal/table/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/tools/times"
 )
 
 // AddTemplateFlow add template flow
 func (p *producer) AddTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (id string, err error) {
-	if err = opt.Validate(); err != nil {
-		return "", err
-	}
-
-	tpl, exist := action.GetTpl(opt.Name)
-	if !exist {
-		return "", fmt.Errorf("flow tempalte: %s not found", opt.Name)
-	}
-
-	if err = validateTplUseParam(kt, tpl, opt); err != nil {
-		logs.Errorf("validate flow template use param failed, err: %v, rid: %s", err, kt.Rid)
+	flow, err := BuildTemplateFlow(kt, opt)
+	if err != nil {
 		return "", err
 	}
 
-	flow := buildFlow(tpl, opt)
-
 	id, err = p.backend.CreateFlow(kt, flow)
 	if err != nil {
 		logs.Errorf("create flow failed, err: %v, rid: %s", err, kt.Rid)
@@ -58,6 +48,25 @@ func (p *producer) AddTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (id
 	return id, nil
 }
 
+// BuildTemplateFlow 校验参数并基于任务流模版构建任务流
+func BuildTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (*model.Flow, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	tpl, exist := action.GetTpl(opt.Name)
+	if !exist {
+		return nil, fmt.Errorf("flow tempalte: %s not found", opt.Name)
+	}
+
+	if err := validateTplUseParam(kt, tpl, opt); err != nil {
+		logs.Errorf("validate flow template use param failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return buildFlow(tpl, opt), nil
+}
+
 func buildFlow(tpl action.FlowTemplate, opt *AddTemplateFlowOption) *model.Flow {
 	flow := &model.Flow{
 		Name:      tpl.Name,
@@ -68,6 +77,13 @@ func buildFlow(tpl action.FlowTemplate, opt *AddTemplateFlowOption) *model.Flow
 	if opt.IsInitState {
 		flow.State = enumor.FlowInit
 	}
+	if opt.ScheduledAt != nil {
+		flow.ScheduledAt = times.ConvStdTimeFormat(*opt.ScheduledAt)
+	}
+	if len(opt.ConcurrencyKey) != 0 {
+		flow.ConcurrencyKey = opt.ConcurrencyKey
+		flow.ConcurrencyLimit = max(opt.ConcurrencyLimit, 1)
+	}
 
 	m := make(map[action.ActIDType]types.JsonField, len(opt.Tasks))
 	for _, one := range opt.Tasks {
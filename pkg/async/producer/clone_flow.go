@@ -76,6 +76,9 @@ func clone(kt *kit.Kit, oldFlow model.Flow, oldTaskList []model.Task, opt *Clone
 		Tasks:     make([]model.Task, len(oldTaskList)),
 		Creator:   kt.User,
 		Reviser:   kt.User,
+
+		ConcurrencyKey:   oldFlow.ConcurrencyKey,
+		ConcurrencyLimit: oldFlow.ConcurrencyLimit,
 	}
 
 	if opt.IsInitState {
@@ -21,6 +21,7 @@ package producer
 
 import (
 	"errors"
+	"time"
 
 	"hcm/pkg/async/action"
 	"hcm/pkg/async/backend"
@@ -40,6 +41,12 @@ type AddTemplateFlowOption struct {
 	Tasks []TemplateFlowTask `json:"tasks" validate:"omitempty"`
 	// IsInitState 是否初始化状态
 	IsInitState bool `json:"is_init_state" validate:"omitempty"`
+	// ScheduledAt 任务流最早可被派发执行的时间，不设置则立即执行
+	ScheduledAt *time.Time `json:"scheduled_at" validate:"omitempty"`
+	// ConcurrencyKey 互斥键，相同互斥键的任务流同时执行的数量不超过 ConcurrencyLimit，如: lb:<id>、account:<id>
+	ConcurrencyKey string `json:"concurrency_key" validate:"omitempty,max=128"`
+	// ConcurrencyLimit 相同互斥键任务流的最大并发数，设置了互斥键但未设置并发数时默认为1
+	ConcurrencyLimit uint `json:"concurrency_limit" validate:"omitempty"`
 }
 
 // Validate AddTemplateFlowOption
@@ -87,6 +94,12 @@ type AddCustomFlowOption struct {
 	Tasks []CustomFlowTask `json:"tasks" validate:"required"`
 	// IsInitState 是否初始化状态
 	IsInitState bool `json:"is_init_state" validate:"omitempty"`
+	// ScheduledAt 任务流最早可被派发执行的时间，不设置则立即执行
+	ScheduledAt *time.Time `json:"scheduled_at" validate:"omitempty"`
+	// ConcurrencyKey 互斥键，相同互斥键的任务流同时执行的数量不超过 ConcurrencyLimit，如: lb:<id>、account:<id>
+	ConcurrencyKey string `json:"concurrency_key" validate:"omitempty,max=128"`
+	// ConcurrencyLimit 相同互斥键任务流的最大并发数，设置了互斥键但未设置并发数时默认为1
+	ConcurrencyLimit uint `json:"concurrency_limit" validate:"omitempty"`
 }
 
 // Validate AddCustomFlowOption
@@ -238,6 +238,10 @@ type HCServiceSetting struct {
 	Service    Service    `yaml:"service"`
 	Log        LogOption  `yaml:"log"`
 	SyncConfig SyncConfig `yaml:"sync"`
+	// RoleCredential 角色模式账号使用的可信身份配置
+	RoleCredential RoleCredentialOption `yaml:"roleCredential"`
+	// CredentialCache 账号凭证缓存配置
+	CredentialCache CredentialCacheOption `yaml:"credentialCache"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -251,6 +255,8 @@ func (s *HCServiceSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
 	s.SyncConfig.trySetDefault()
+	s.RoleCredential.trySetDefault()
+	s.CredentialCache.trySetDefault()
 
 	return
 }
@@ -268,6 +274,12 @@ func (s HCServiceSetting) Validate() error {
 	if err := s.SyncConfig.Validate(); err != nil {
 		return fmt.Errorf("syncConfig validate error: %w", err)
 	}
+	if err := s.RoleCredential.Validate(); err != nil {
+		return fmt.Errorf("roleCredential validate error: %w", err)
+	}
+	if err := s.CredentialCache.Validate(); err != nil {
+		return fmt.Errorf("credentialCache validate error: %w", err)
+	}
 	return nil
 }
 
@@ -404,6 +416,7 @@ func (s *TaskServerSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Database.trySetDefault()
 	s.Log.trySetDefault()
+	s.Async.trySetDefault()
 
 	return
 }
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -166,12 +167,27 @@ func (lm *Limiter) trySetDefault() {
 
 // Async defines async relating.
 type Async struct {
+	// Backend 任务流存储类型，默认为mysql
+	Backend enumor.BackendType `yaml:"backend"`
+	// BoltFile bolt存储文件路径，仅Backend为bolt时生效
+	BoltFile   string     `yaml:"boltFile"`
 	Scheduler  Parser     `yaml:"scheduler"`
 	Executor   Executor   `yaml:"executor"`
 	Dispatcher Dispatcher `yaml:"dispatcher"`
 	WatchDog   WatchDog   `yaml:"watchDog"`
 }
 
+// trySetDefault set the Async default value if user not configured.
+func (a *Async) trySetDefault() {
+	if len(a.Backend) == 0 {
+		a.Backend = enumor.BackendMysql
+	}
+
+	if a.Backend == enumor.BackendBolt && len(a.BoltFile) == 0 {
+		a.BoltFile = "./data/task_server.db"
+	}
+}
+
 // Validate Async
 func (a Async) Validate() error {
 	// 这里不进行校验，统一由异步任务框架进行校验
@@ -602,14 +618,61 @@ func (a AesGcm) validate() error {
 }
 
 // Crypto 定义项目里需要用到的加密，包括选择的算法等
-// TODO: 这里默认只支持AES Gcm算法，后续需要支持国密等的选择，可能还需要支持根据不同场景配置不同（比如不同场景，加密的密钥等都不一样）
+// TODO: 后续可能还需要支持根据不同场景配置不同（比如不同场景，加密的密钥等都不一样）
 type Crypto struct {
+	// AesGcm 固定nonce的AES-GCM密钥，未配置 Keys 时用于加解密，配置了 Keys 后仅用于解密历史密文
 	AesGcm AesGcm `yaml:"aesGcm"`
+	// Keys 信封加密使用的多版本密钥，按从旧到新的顺序配置，使用最后一个密钥加密，所有密钥均可用于解密
+	Keys []CryptoKey `yaml:"keys"`
 }
 
 func (c Crypto) validate() error {
-	if err := c.AesGcm.validate(); err != nil {
-		return err
+	// 配置了多版本密钥且没有历史密文时，可以不配置 AesGcm
+	if len(c.Keys) == 0 || len(c.AesGcm.Key) != 0 {
+		if err := c.AesGcm.validate(); err != nil {
+			return err
+		}
+	}
+
+	ids := make(map[string]struct{}, len(c.Keys))
+	for _, key := range c.Keys {
+		if err := key.validate(); err != nil {
+			return err
+		}
+
+		if _, exists := ids[key.ID]; exists {
+			return fmt.Errorf("crypto key id %s is duplicated", key.ID)
+		}
+		ids[key.ID] = struct{}{}
+	}
+
+	return nil
+}
+
+// CryptoKey 带有ID的加密密钥，ID会记录在密文中用于解密时选择密钥，已使用的密钥ID、算法及密钥不能修改
+type CryptoKey struct {
+	ID string `yaml:"id"`
+	// Algorithm 加密算法，支持 aes-gcm 及 sm4-gcm，为空时默认为 aes-gcm
+	Algorithm enumor.CryptoAlgorithm `yaml:"algorithm"`
+	Key       string                 `yaml:"key"`
+}
+
+func (k CryptoKey) validate() error {
+	if len(k.ID) == 0 || len(k.ID) > 32 {
+		return errors.New("invalid crypto key id, length should be 1~32")
+	}
+
+	algorithm := k.Algorithm
+	if len(algorithm) == 0 {
+		algorithm = enumor.CryptoAesGcm
+	}
+
+	if err := algorithm.Validate(); err != nil {
+		return fmt.Errorf("invalid crypto key %s, err: %v", k.ID, err)
+	}
+
+	if !slices.Contains(algorithm.KeySizes(), len(k.Key)) {
+		return fmt.Errorf("invalid crypto key %s, should be %v bytes", k.ID, algorithm.KeySizes())
 	}
 
 	return nil
@@ -633,6 +696,10 @@ type CloudResourceSync struct {
 	Enable                       bool   `yaml:"enable"`
 	SyncIntervalMin              uint64 `yaml:"syncIntervalMin"`
 	SyncFrequencyLimitingTimeMin uint64 `yaml:"syncFrequencyLimitingTimeMin"`
+	// IncrementalSync 基于云上资源变更事件的增量同步，周期全量同步作为兜底
+	IncrementalSync IncrementalSync `yaml:"incrementalSync"`
+	// DeleteProtection 账号全量同步的删除保护配置
+	DeleteProtection SyncDeleteProtection `yaml:"deleteProtection"`
 }
 
 func (c CloudResourceSync) validate() error {
@@ -642,6 +709,43 @@ func (c CloudResourceSync) validate() error {
 		}
 	}
 
+	if err := c.IncrementalSync.validate(); err != nil {
+		return err
+	}
+
+	if err := c.DeleteProtection.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IncrementalSync 增量同步配置
+type IncrementalSync struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 增量同步间隔，单位：分钟
+	IntervalMin uint64 `yaml:"intervalMin"`
+	// Vendors 开启增量同步的云厂商，目前支持 tcloud、aws、azure
+	Vendors []enumor.Vendor `yaml:"vendors"`
+}
+
+func (c IncrementalSync) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.IntervalMin == 0 || c.IntervalMin > 60 {
+		return errors.New("incrementalSync.intervalMin should be in range [1, 60]")
+	}
+
+	for _, vendor := range c.Vendors {
+		switch vendor {
+		case enumor.TCloud, enumor.Aws, enumor.Azure:
+		default:
+			return fmt.Errorf("incrementalSync not support vendor: %s", vendor)
+		}
+	}
+
 	return nil
 }
 
@@ -975,6 +1079,7 @@ type BillAllocationOption struct {
 	GcpCredits          []GcpCreditConfig       `yaml:"gcpCredits"`
 	GcpCommonExpense    BillCommonExpense       `yaml:"gcpCommonExpense"`
 	HuaweiCommonExpense BillCommonExpense       `yaml:"huaweiCommonExpense"`
+	TCloudCommonExpense BillCommonExpense       `yaml:"tcloudCommonExpense"`
 }
 
 func (opt *BillAllocationOption) validate() error {
@@ -1049,6 +1154,38 @@ func (s SyncConfig) GetSyncConcurrent(vendor enumor.Vendor, resource enumor.Clou
 	return s.DefaultConcurrent, s.DefaultConcurrent
 }
 
+// SyncDeleteProtection 同步删除保护配置，账号单次全量同步待删除的资源占账号下本地资源的比例超过阈值时，拒绝该账号的同步
+type SyncDeleteProtection struct {
+	// MaxPercent 账号单次全量同步允许删除的资源最大百分比，为0时不开启删除保护
+	MaxPercent uint `yaml:"maxPercent"`
+	// MinCount 删除数量达到该值时才进行比例检查，避免资源较少的账号频繁触发保护
+	MinCount uint `yaml:"minCount"`
+}
+
+// Validate ...
+func (p SyncDeleteProtection) Validate() error {
+	if p.MaxPercent > 100 {
+		return fmt.Errorf("deleteProtection.maxPercent should be <= 100, but got %d", p.MaxPercent)
+	}
+	return nil
+}
+
+// Enabled 是否开启删除保护
+func (p SyncDeleteProtection) Enabled() bool {
+	return p.MaxPercent > 0
+}
+
+// Exceeded 删除数量是否超过保护阈值，scanned 为删除对比时扫描的本地资源数量
+func (p SyncDeleteProtection) Exceeded(deleted, scanned uint) bool {
+	if !p.Enabled() || deleted == 0 || deleted < p.MinCount {
+		return false
+	}
+	if scanned == 0 {
+		return true
+	}
+	return deleted*100 > scanned*p.MaxPercent
+}
+
 // SyncConcurrentRule 同步并发配置
 type SyncConcurrentRule struct {
 	Rule     string                   `yaml:"rule"`
@@ -1130,3 +1267,106 @@ func (r *SyncConcurrentRule) Validate() error {
 	}
 	return nil
 }
+
+var (
+	defaultRoleCredentialDuration     = time.Hour
+	defaultRoleCredentialRefreshAhead = 5 * time.Minute
+)
+
+// RoleCredentialOption 角色模式下HCM持有的可信身份配置，用于扮演各账号下的角色获取临时凭证
+type RoleCredentialOption struct {
+	// SessionName 扮演角色时使用的会话名称
+	SessionName string `yaml:"sessionName"`
+	// Duration 临时凭证有效期
+	Duration *time.Duration `yaml:"duration,omitempty"`
+	// RefreshAhead 临时凭证在过期前多久刷新
+	RefreshAhead *time.Duration        `yaml:"refreshAhead,omitempty"`
+	TCloud       TCloudTrustedIdentity `yaml:"tcloud"`
+	Aws          AwsTrustedIdentity    `yaml:"aws"`
+	Azure        AzureTrustedIdentity  `yaml:"azure"`
+}
+
+func (r *RoleCredentialOption) trySetDefault() {
+	if len(r.SessionName) == 0 {
+		r.SessionName = "hcm"
+	}
+	if r.Duration == nil {
+		r.Duration = &defaultRoleCredentialDuration
+	}
+	if r.RefreshAhead == nil {
+		r.RefreshAhead = &defaultRoleCredentialRefreshAhead
+	}
+	if len(r.Azure.FederatedTokenFile) == 0 {
+		r.Azure.FederatedTokenFile = os.Getenv("AZURE_FEDERATED_TOKEN_FILE")
+	}
+}
+
+// Validate RoleCredentialOption
+func (r RoleCredentialOption) Validate() error {
+	if r.Duration != nil && (*r.Duration < 15*time.Minute || *r.Duration > 12*time.Hour) {
+		return errors.New("role credential duration should be in the range of 15m~12h")
+	}
+	if r.Duration != nil && r.RefreshAhead != nil && *r.RefreshAhead >= *r.Duration {
+		return errors.New("role credential refreshAhead should be less than duration")
+	}
+	return nil
+}
+
+// TCloudTrustedIdentity 腾讯云可信身份，需具备 sts:AssumeRole 权限
+type TCloudTrustedIdentity struct {
+	SecretID  string `yaml:"secretId"`
+	SecretKey string `yaml:"secretKey"`
+}
+
+// AwsTrustedIdentity aws可信身份，中国站与国际站分属不同分区，需分别配置
+type AwsTrustedIdentity struct {
+	International AwsTrustedSecret `yaml:"international"`
+	China         AwsTrustedSecret `yaml:"china"`
+}
+
+// AwsTrustedSecret aws可信身份密钥，需具备 sts:AssumeRole 权限
+type AwsTrustedSecret struct {
+	SecretID  string `yaml:"secretId"`
+	SecretKey string `yaml:"secretKey"`
+}
+
+// AzureTrustedIdentity azure工作负载身份，使用联合令牌文件换取账号应用的访问令牌
+type AzureTrustedIdentity struct {
+	// FederatedTokenFile 联合令牌文件路径，未配置时取环境变量 AZURE_FEDERATED_TOKEN_FILE
+	FederatedTokenFile string `yaml:"federatedTokenFile"`
+}
+
+var (
+	defaultCredentialCacheTTL     = 5 * time.Minute
+	defaultCredentialCacheMaxSize = 2000
+)
+
+// CredentialCacheOption 账号凭证缓存配置，缓存从data-service获取的账号凭证，避免同步时重复查询
+type CredentialCacheOption struct {
+	// Disable 是否关闭缓存，默认开启
+	Disable bool `yaml:"disable"`
+	// TTL 缓存有效期，账号更新或删除时会主动失效，TTL作为主动失效通知丢失时的兜底
+	TTL *time.Duration `yaml:"ttl,omitempty"`
+	// MaxSize 缓存的最大条目数，超出时淘汰最久未使用的条目
+	MaxSize int `yaml:"maxSize"`
+}
+
+func (c *CredentialCacheOption) trySetDefault() {
+	if c.TTL == nil {
+		c.TTL = &defaultCredentialCacheTTL
+	}
+	if c.MaxSize == 0 {
+		c.MaxSize = defaultCredentialCacheMaxSize
+	}
+}
+
+// Validate CredentialCacheOption
+func (c CredentialCacheOption) Validate() error {
+	if c.TTL != nil && *c.TTL <= 0 {
+		return errors.New("credential cache ttl should be positive")
+	}
+	if c.MaxSize < 0 {
+		return errors.New("credential cache maxSize should not be negative")
+	}
+	return nil
+}
@@ -110,6 +110,25 @@ func (cs *ClientSet) HCService(labels ...string) *hcservice.Client {
 	return hcservice.NewClient(c, cs.version)
 }
 
+// HCServiceAllNodes get hc-service clients of every node, used to broadcast requests that only affect the
+// node itself, e.g. invalidate the in-process cache.
+func (cs *ClientSet) HCServiceAllNodes() ([]*hcservice.Client, error) {
+	servers, err := cs.discovery(cc.HCServiceName).GetServers()
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]*hcservice.Client, 0, len(servers))
+	for _, server := range servers {
+		c := &client.Capability{
+			Client:   cs.client,
+			Discover: rdisc.StaticServers(server),
+		}
+		clients = append(clients, hcservice.NewClient(c, cs.version))
+	}
+	return clients, nil
+}
+
 // AuthServer get auth-server client.
 func (cs *ClientSet) AuthServer() *authserver.Client {
 	c := &client.Capability{
@@ -39,6 +39,7 @@ type Client struct {
 	MainAccount           *MainAccountClient
 	RootAccount           *RootAccountClient
 	RootAccountBillConfig *RootAccountBillConfigClient
+	LoadBalancer          *LoadBalancerClient
 }
 
 type restClient struct {
@@ -62,5 +63,6 @@ func NewClient(client rest.ClientInterface) *Client {
 		MainAccount:           NewMainAccountClient(client),
 		RootAccount:           NewRootAccountClient(client),
 		RootAccountBillConfig: NewRootAccountBillConfigClient(client),
+		LoadBalancer:          NewLoadBalancerClient(client),
 	}
 }
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BatchCreateDiskSnapshot 批量创建云硬盘快照
+func (rc *restClient) BatchCreateDiskSnapshot(kt *kit.Kit,
+	req *dataproto.DiskSnapshotExtBatchCreateReq[coresnapshot.GcpExtension]) (*core.BatchCreateResult, error) {
+
+	return common.Request[dataproto.DiskSnapshotExtBatchCreateReq[coresnapshot.GcpExtension],
+		core.BatchCreateResult](rc.client, rest.POST, kt, req, "/disk_snapshots/batch/create")
+}
+
+// ListDiskSnapshot 查询云硬盘快照列表(带 extension 字段)
+func (rc *restClient) ListDiskSnapshot(kt *kit.Kit, req *core.ListReq) (
+	*dataproto.ListExtResult[coresnapshot.GcpExtension], error) {
+
+	return common.Request[core.ListReq, dataproto.ListExtResult[coresnapshot.GcpExtension]](
+		rc.client, rest.POST, kt, req, "/disk_snapshots/list")
+}
+
+// BatchUpdateDiskSnapshot 批量更新云硬盘快照(支持 extension 字段)
+func (rc *restClient) BatchUpdateDiskSnapshot(kt *kit.Kit,
+	req *dataproto.DiskSnapshotExtBatchUpdateReq[coresnapshot.GcpExtension]) error {
+
+	return common.RequestNoResp[dataproto.DiskSnapshotExtBatchUpdateReq[coresnapshot.GcpExtension]](
+		rc.client, rest.PATCH, kt, req, "/disk_snapshots")
+}
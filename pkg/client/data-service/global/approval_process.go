@@ -42,7 +42,8 @@ func NewApprovalProcessClient(client rest.ClientInterface) *ApprovalProcessClien
 }
 
 // CreateApprovalProcesses ...
-func (a *ApprovalProcessClient) CreateApprovalProcesses(ctx context.Context, h http.Header, request *proto.ApplicationCreateReq) (
+func (a *ApprovalProcessClient) CreateApprovalProcesses(ctx context.Context, h http.Header,
+	request *proto.ApprovalProcessCreateReq) (
 	*core.CreateResult, error,
 ) {
 	resp := new(core.CreateResp)
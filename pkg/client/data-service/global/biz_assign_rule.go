@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dsbizassign "hcm/pkg/api/data-service/cloud/biz-assign-rule"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BizAssignRuleClient is data service biz assign rule api client.
+type BizAssignRuleClient struct {
+	client rest.ClientInterface
+}
+
+// NewBizAssignRuleClient create a new biz assign rule api client.
+func NewBizAssignRuleClient(client rest.ClientInterface) *BizAssignRuleClient {
+	return &BizAssignRuleClient{
+		client: client,
+	}
+}
+
+// BatchCreate biz assign rule.
+func (cli *BizAssignRuleClient) BatchCreate(kt *kit.Kit, req *dsbizassign.BatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dsbizassign.BatchCreateReq, core.BatchCreateResult](
+		cli.client, rest.POST, kt, req, "/biz_assign_rules/batch/create")
+}
+
+// BatchUpdate biz assign rule.
+func (cli *BizAssignRuleClient) BatchUpdate(kt *kit.Kit, req *dsbizassign.BatchUpdateReq) error {
+	return common.RequestNoResp[dsbizassign.BatchUpdateReq](cli.client, rest.PATCH, kt, req, "/biz_assign_rules/batch")
+}
+
+// List biz assign rule.
+func (cli *BizAssignRuleClient) List(kt *kit.Kit, req *core.ListReq) (*dsbizassign.ListResp, error) {
+	return common.Request[dsbizassign.ListReq, dsbizassign.ListResp](
+		cli.client, rest.POST, kt, &dsbizassign.ListReq{ListReq: *req}, "/biz_assign_rules/list")
+}
+
+// BatchDelete biz assign rule.
+func (cli *BizAssignRuleClient) BatchDelete(kt *kit.Kit, req *dsbizassign.BatchDeleteReq) error {
+	return common.RequestNoResp[dsbizassign.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/biz_assign_rules/batch")
+}
+
+// Preview unassigned resources matched by biz assign rule.
+func (cli *BizAssignRuleClient) Preview(kt *kit.Kit, req *dsbizassign.PreviewReq) (*dsbizassign.PreviewResult,
+	error) {
+
+	return common.Request[dsbizassign.PreviewReq, dsbizassign.PreviewResult](
+		cli.client, rest.POST, kt, req, "/biz_assign_rules/preview")
+}
+
+// Apply biz assign rules, assign matched unassigned resources to rule's biz.
+func (cli *BizAssignRuleClient) Apply(kt *kit.Kit, req *dsbizassign.ApplyReq) (*dsbizassign.ApplyResult, error) {
+	return common.Request[dsbizassign.ApplyReq, dsbizassign.ApplyResult](
+		cli.client, rest.POST, kt, req, "/biz_assign_rules/apply")
+}
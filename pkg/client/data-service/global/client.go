@@ -41,6 +41,7 @@ type Client struct {
 	NetworkInterfaceCvmRel *NetworkInterfaceCvmRelClient
 	SubAccount             *SubAccountClient
 	AccountSyncDetail      *AccountSyncDetailClient
+	SyncRunRecord          *SyncRunRecordClient
 
 	Auth          *AuthClient
 	Account       *AccountClient
@@ -66,6 +67,10 @@ type Client struct {
 	TaskManagement *TaskManagementClient
 
 	GlobalConfig *GlobalConfigsClient
+
+	SGRuleTemplate *SGRuleTemplateClient
+	ResourceTag    *ResourceTagClient
+	BizAssignRule  *BizAssignRuleClient
 }
 
 type restClient struct {
@@ -90,6 +95,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		NetworkInterfaceCvmRel: NewNetworkInterfaceCvmRelClient(client),
 		SubAccount:             NewSubAccountClient(client),
 		AccountSyncDetail:      NewAccountSyncDetailClient(client),
+		SyncRunRecord:          NewSyncRunRecordClient(client),
 
 		Auth:          NewAuthClient(client),
 		Account:       NewAccountClient(client),
@@ -113,5 +119,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		TaskDetail:     NewTaskDetailClient(client),
 		TaskManagement: NewTaskManagementClient(client),
 		GlobalConfig:   NewGlobalConfigClient(client),
+		SGRuleTemplate: NewSGRuleTemplateClient(client),
+		ResourceTag:    NewResourceTagClient(client),
+		BizAssignRule:  NewBizAssignRuleClient(client),
 	}
 }
@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ListDiskSnapshot 查询云硬盘快照列表
+func (rc *restClient) ListDiskSnapshot(kt *kit.Kit, req *core.ListReq) (*dataproto.ListResult, error) {
+	return common.Request[core.ListReq, dataproto.ListResult](rc.client, rest.POST, kt, req, "/disk_snapshots/list")
+}
+
+// BatchUpdateDiskSnapshot 批量更新云硬盘快照的基础信息
+func (rc *restClient) BatchUpdateDiskSnapshot(kt *kit.Kit, req *dataproto.DiskSnapshotBatchUpdateReq) error {
+	return common.RequestNoResp[dataproto.DiskSnapshotBatchUpdateReq](rc.client, rest.PATCH, kt, req,
+		"/disk_snapshots")
+}
+
+// BatchDeleteDiskSnapshot 删除云硬盘快照记录
+func (rc *restClient) BatchDeleteDiskSnapshot(kt *kit.Kit, req *dataproto.DiskSnapshotDeleteReq) error {
+	return common.RequestNoResp[dataproto.DiskSnapshotDeleteReq](rc.client, rest.DELETE, kt, req,
+		"/disk_snapshots/batch")
+}
@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ListKeyPair 查询SSH密钥对列表
+func (rc *restClient) ListKeyPair(kt *kit.Kit, req *core.ListReq) (*dataproto.ListResult, error) {
+	return common.Request[core.ListReq, dataproto.ListResult](rc.client, rest.POST, kt, req, "/key_pairs/list")
+}
+
+// BatchUpdateKeyPair 批量更新SSH密钥对的基础信息
+func (rc *restClient) BatchUpdateKeyPair(kt *kit.Kit, req *dataproto.KeyPairBatchUpdateReq) error {
+	return common.RequestNoResp[dataproto.KeyPairBatchUpdateReq](rc.client, rest.PATCH, kt, req,
+		"/key_pairs")
+}
+
+// BatchDeleteKeyPair 删除SSH密钥对记录
+func (rc *restClient) BatchDeleteKeyPair(kt *kit.Kit, req *dataproto.KeyPairDeleteReq) error {
+	return common.RequestNoResp[dataproto.KeyPairDeleteReq](rc.client, rest.DELETE, kt, req,
+		"/key_pairs/batch")
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dsrestag "hcm/pkg/api/data-service/cloud/resource-tag"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ResourceTagClient is data service resource tag api client.
+type ResourceTagClient struct {
+	client rest.ClientInterface
+}
+
+// NewResourceTagClient create a new resource tag api client.
+func NewResourceTagClient(client rest.ClientInterface) *ResourceTagClient {
+	return &ResourceTagClient{
+		client: client,
+	}
+}
+
+// Sync resource tags from cloud, tags of each resource will be replaced by the given tags.
+func (cli *ResourceTagClient) Sync(kt *kit.Kit, req *dsrestag.SyncReq) error {
+	return common.RequestNoResp[dsrestag.SyncReq](cli.client, rest.POST, kt, req, "/resource_tags/sync")
+}
+
+// BatchUpsert add or update tags of resources.
+func (cli *ResourceTagClient) BatchUpsert(kt *kit.Kit, req *dsrestag.BatchUpsertReq) error {
+	return common.RequestNoResp[dsrestag.BatchUpsertReq](cli.client, rest.POST, kt, req, "/resource_tags/batch/upsert")
+}
+
+// BatchRemove remove tags of resources by tag keys.
+func (cli *ResourceTagClient) BatchRemove(kt *kit.Kit, req *dsrestag.BatchRemoveReq) error {
+	return common.RequestNoResp[dsrestag.BatchRemoveReq](cli.client, rest.POST, kt, req, "/resource_tags/batch/remove")
+}
+
+// List resource tag.
+func (cli *ResourceTagClient) List(kt *kit.Kit, req *core.ListReq) (*dsrestag.ListResp, error) {
+	return common.Request[dsrestag.ListReq, dsrestag.ListResp](
+		cli.client, rest.POST, kt, &dsrestag.ListReq{ListReq: *req}, "/resource_tags/list")
+}
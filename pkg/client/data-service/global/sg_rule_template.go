@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dssgruletpl "hcm/pkg/api/data-service/cloud/sg-rule-template"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// SGRuleTemplateClient is data service security group rule template api client.
+type SGRuleTemplateClient struct {
+	client rest.ClientInterface
+}
+
+// NewSGRuleTemplateClient create a new security group rule template api client.
+func NewSGRuleTemplateClient(client rest.ClientInterface) *SGRuleTemplateClient {
+	return &SGRuleTemplateClient{
+		client: client,
+	}
+}
+
+// BatchCreate security group rule template.
+func (cli *SGRuleTemplateClient) BatchCreate(kt *kit.Kit, req *dssgruletpl.BatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dssgruletpl.BatchCreateReq, core.BatchCreateResult](
+		cli.client, rest.POST, kt, req, "/security_group_rule_templates/batch/create")
+}
+
+// BatchUpdate security group rule template.
+func (cli *SGRuleTemplateClient) BatchUpdate(kt *kit.Kit, req *dssgruletpl.BatchUpdateReq) error {
+	return common.RequestNoResp[dssgruletpl.BatchUpdateReq](
+		cli.client, rest.PATCH, kt, req, "/security_group_rule_templates/batch")
+}
+
+// List security group rule template.
+func (cli *SGRuleTemplateClient) List(kt *kit.Kit, req *core.ListReq) (*dssgruletpl.ListResp, error) {
+	return common.Request[dssgruletpl.ListReq, dssgruletpl.ListResp](
+		cli.client, rest.POST, kt, &dssgruletpl.ListReq{ListReq: *req}, "/security_group_rule_templates/list")
+}
+
+// BatchDelete security group rule template.
+func (cli *SGRuleTemplateClient) BatchDelete(kt *kit.Kit, req *dssgruletpl.BatchDeleteReq) error {
+	return common.RequestNoResp[dssgruletpl.BatchDeleteReq](
+		cli.client, rest.DELETE, kt, req, "/security_group_rule_templates/batch")
+}
+
+// BatchCreateRel security group rule template rel.
+func (cli *SGRuleTemplateClient) BatchCreateRel(kt *kit.Kit, req *dssgruletpl.RelBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dssgruletpl.RelBatchCreateReq, core.BatchCreateResult](
+		cli.client, rest.POST, kt, req, "/security_group_rule_template_rels/batch/create")
+}
+
+// BatchUpdateRel security group rule template rel.
+func (cli *SGRuleTemplateClient) BatchUpdateRel(kt *kit.Kit, req *dssgruletpl.RelBatchUpdateReq) error {
+	return common.RequestNoResp[dssgruletpl.RelBatchUpdateReq](
+		cli.client, rest.PATCH, kt, req, "/security_group_rule_template_rels/batch")
+}
+
+// ListRel security group rule template rel.
+func (cli *SGRuleTemplateClient) ListRel(kt *kit.Kit, req *core.ListReq) (*dssgruletpl.RelListResp, error) {
+	return common.Request[dssgruletpl.RelListReq, dssgruletpl.RelListResp](
+		cli.client, rest.POST, kt, &dssgruletpl.RelListReq{ListReq: *req}, "/security_group_rule_template_rels/list")
+}
+
+// BatchDeleteRel security group rule template rel.
+func (cli *SGRuleTemplateClient) BatchDeleteRel(kt *kit.Kit, req *dssgruletpl.RelBatchDeleteReq) error {
+	return common.RequestNoResp[dssgruletpl.RelBatchDeleteReq](
+		cli.client, rest.DELETE, kt, req, "/security_group_rule_template_rels/batch")
+}
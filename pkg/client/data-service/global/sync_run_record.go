@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dssync "hcm/pkg/api/data-service/cloud/sync"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// SyncRunRecordClient is data service sync_run_record api client.
+type SyncRunRecordClient struct {
+	client rest.ClientInterface
+}
+
+// NewSyncRunRecordClient create a new sync_run_record api client.
+func NewSyncRunRecordClient(client rest.ClientInterface) *SyncRunRecordClient {
+	return &SyncRunRecordClient{
+		client: client,
+	}
+}
+
+// List sync run record.
+func (cli *SyncRunRecordClient) List(kt *kit.Kit, req *core.ListReq) (*dssync.ListRunRecordResult, error) {
+	return common.Request[core.ListReq, dssync.ListRunRecordResult](cli.client, rest.POST, kt, req,
+		"/sync_run_records/list")
+}
+
+// BatchCreate sync run record.
+func (cli *SyncRunRecordClient) BatchCreate(kt *kit.Kit, req *dssync.CreateRunRecordReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dssync.CreateRunRecordReq, core.BatchCreateResult](cli.client, rest.POST, kt, req,
+		"/sync_run_records/batch/create")
+}
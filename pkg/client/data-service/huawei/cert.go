@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/api/core"
+	corecert "hcm/pkg/api/core/cloud/cert"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ListCert 查询证书列表(带 extension 字段)
+func (rc *restClient) ListCert(kt *kit.Kit, request *core.ListReq) (
+	*protocloud.CertListExtResult[corecert.HuaWeiCertExtension], error) {
+
+	return common.Request[core.ListReq, protocloud.CertListExtResult[corecert.HuaWeiCertExtension]](
+		rc.client, rest.POST, kt, request, "/certs/list")
+}
+
+// BatchCreateCert batch create cert.
+func (rc *restClient) BatchCreateCert(kt *kit.Kit,
+	request *protocloud.CertBatchCreateReq[corecert.HuaWeiCertExtension]) (*core.BatchCreateResult, error) {
+
+	return common.Request[protocloud.CertBatchCreateReq[corecert.HuaWeiCertExtension], core.BatchCreateResult](
+		rc.client, rest.POST, kt, request, "/certs/create")
+}
+
+// BatchUpdateCert batch update cert.
+func (rc *restClient) BatchUpdateCert(kt *kit.Kit,
+	request *protocloud.CertExtBatchUpdateReq[corecert.HuaWeiCertExtension]) error {
+
+	return common.RequestNoResp[protocloud.CertExtBatchUpdateReq[corecert.HuaWeiCertExtension]](
+		rc.client, rest.PATCH, kt, request, "/certs")
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	dataproto "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BatchCreateKeyPair 批量创建SSH密钥对
+func (rc *restClient) BatchCreateKeyPair(kt *kit.Kit,
+	req *dataproto.KeyPairExtBatchCreateReq[corekeypair.HuaWeiExtension]) (*core.BatchCreateResult, error) {
+
+	return common.Request[dataproto.KeyPairExtBatchCreateReq[corekeypair.HuaWeiExtension],
+		core.BatchCreateResult](rc.client, rest.POST, kt, req, "/key_pairs/batch/create")
+}
+
+// ListKeyPair 查询SSH密钥对列表(带 extension 字段)
+func (rc *restClient) ListKeyPair(kt *kit.Kit, req *core.ListReq) (
+	*dataproto.ListExtResult[corekeypair.HuaWeiExtension], error) {
+
+	return common.Request[core.ListReq, dataproto.ListExtResult[corekeypair.HuaWeiExtension]](
+		rc.client, rest.POST, kt, req, "/key_pairs/list")
+}
+
+// BatchUpdateKeyPair 批量更新SSH密钥对(支持 extension 字段)
+func (rc *restClient) BatchUpdateKeyPair(kt *kit.Kit,
+	req *dataproto.KeyPairExtBatchUpdateReq[corekeypair.HuaWeiExtension]) error {
+
+	return common.RequestNoResp[dataproto.KeyPairExtBatchUpdateReq[corekeypair.HuaWeiExtension]](
+		rc.client, rest.PATCH, kt, req, "/key_pairs")
+}
@@ -36,6 +36,9 @@ type Client struct {
 	RouteTable    *RouteTableClient
 	SubAccount    *SubAccountClient
 	LoadBalancer  *LoadBalancerClient
+	Bill          *BillClient
+	MainAccount   *MainAccountClient
+	RootAccount   *RootAccountClient
 }
 
 type restClient struct {
@@ -56,5 +59,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		RouteTable:    NewRouteTableClient(client),
 		SubAccount:    NewSubAccountClient(client),
 		LoadBalancer:  NewLoadBalancerClient(client),
+		Bill:          NewBillClient(client),
+		MainAccount:   NewMainAccountClient(client),
+		RootAccount:   NewRootAccountClient(client),
 	}
 }
@@ -31,6 +31,8 @@ type Client struct {
 	Subnet        *SubnetClient
 	Eip           *EipClient
 	Disk          *DiskClient
+	DiskSnapshot  *DiskSnapshotClient
+	KeyPair       *KeyPairClient
 	Zone          *ZoneClient
 	Region        *RegionClient
 	Cvm           *CvmClient
@@ -39,6 +41,10 @@ type Client struct {
 	InstanceType  *InstanceTypeClient
 	Bill          *BillClient
 	MainAccount   *MainAccountClient
+	LoadBalancer  *LoadBalancerClient
+	IncrementSync *IncrementalSyncClient
+	ResSync       *ResSyncClient
+	ResourceTag   *ResourceTagClient
 }
 
 // NewClient create a new aws api client.
@@ -50,6 +56,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		Subnet:        NewSubnetClient(client),
 		Eip:           NewEipClient(client),
 		Disk:          NewCloudDiskClient(client),
+		DiskSnapshot:  NewDiskSnapshotClient(client),
+		KeyPair:       NewKeyPairClient(client),
 		Zone:          NewZoneClient(client),
 		Region:        NewRegionClient(client),
 		Cvm:           NewCvmClient(client),
@@ -58,5 +66,9 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:  NewInstanceTypeClient(client),
 		Bill:          NewBillClient(client),
 		MainAccount:   NewMainAccountClient(client),
+		LoadBalancer:  NewLoadBalancerClient(client),
+		IncrementSync: NewIncrementalSyncClient(client),
+		ResSync:       NewResSyncClient(client),
+		ResourceTag:   NewResourceTagClient(client),
 	}
 }
@@ -31,6 +31,8 @@ type Client struct {
 	Subnet           *SubnetClient
 	Eip              *EipClient
 	Disk             *DiskClient
+	DiskSnapshot     *DiskSnapshotClient
+	KeyPair          *KeyPairClient
 	Region           *RegionClient
 	ResourceGroup    *ResourceGroupClient
 	Image            *ImageClient
@@ -39,6 +41,9 @@ type Client struct {
 	InstanceType     *InstanceTypeClient
 	NetworkInterface *NetworkInterfaceClient
 	Bill             *BillClient
+	IncrementSync    *IncrementalSyncClient
+	ResSync          *ResSyncClient
+	ResourceTag      *ResourceTagClient
 }
 
 // NewClient create a new azure api client.
@@ -50,6 +55,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		Subnet:           NewSubnetClient(client),
 		Eip:              NewEipClient(client),
 		Disk:             NewCloudDiskClient(client),
+		DiskSnapshot:     NewDiskSnapshotClient(client),
+		KeyPair:          NewKeyPairClient(client),
 		Region:           NewRegionClient(client),
 		ResourceGroup:    NewResourceGroupClient(client),
 		Cvm:              NewCvmClient(client),
@@ -58,5 +65,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:     NewInstanceTypeClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		Bill:             NewBillClient(client),
+		IncrementSync:    NewIncrementalSyncClient(client),
+		ResSync:          NewResSyncClient(client),
+		ResourceTag:      NewResourceTagClient(client),
 	}
 }
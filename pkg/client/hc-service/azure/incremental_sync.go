@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewIncrementalSyncClient create a new incremental sync api client.
+func NewIncrementalSyncClient(client rest.ClientInterface) *IncrementalSyncClient {
+	return &IncrementalSyncClient{
+		client: client,
+	}
+}
+
+// IncrementalSyncClient is hc service incremental sync api client.
+type IncrementalSyncClient struct {
+	client rest.ClientInterface
+}
+
+// Sync 根据云上资源变更事件增量同步资源
+func (cli *IncrementalSyncClient) Sync(kt *kit.Kit, req *sync.IncrementalSyncReq) (*sync.IncrementalSyncResult,
+	error) {
+
+	return common.Request[sync.IncrementalSyncReq, sync.IncrementalSyncResult](cli.client, rest.POST, kt, req,
+		"/resources/incremental/sync")
+}
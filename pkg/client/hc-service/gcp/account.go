@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/api/core/cloud"
 	hsaccount "hcm/pkg/api/hc-service/account"
 	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
@@ -165,3 +166,9 @@ func (a *AccountClient) GetResCountBySecret(kt *kit.Kit, request *cloud.GcpCrede
 
 	return resp.Data, nil
 }
+
+// InvalidateCredentialCache 清除账号凭证缓存
+func (a *AccountClient) InvalidateCredentialCache(kt *kit.Kit, accountID string) error {
+	return common.RequestNoResp[common.Empty](a.client, rest.DELETE, kt, common.NoData,
+		"/accounts/%s/credential_cache", accountID)
+}
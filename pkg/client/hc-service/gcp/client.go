@@ -30,6 +30,7 @@ type Client struct {
 	Vpc              *VpcClient
 	Subnet           *SubnetClient
 	Disk             *DiskClient
+	DiskSnapshot     *DiskSnapshotClient
 	Cvm              *CvmClient
 	Image            *ImageClient
 	RouteTable       *RouteTableClient
@@ -40,6 +41,7 @@ type Client struct {
 	NetworkInterface *NetworkInterfaceClient
 	Bill             *BillClient
 	MainAccount      *MainAccountClient
+	ResourceTag      *ResourceTagClient
 }
 
 // NewClient create a new gcp api client.
@@ -50,6 +52,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		Vpc:              NewVpcClient(client),
 		Subnet:           NewSubnetClient(client),
 		Disk:             NewCloudDiskClient(client),
+		DiskSnapshot:     NewDiskSnapshotClient(client),
 		Cvm:              NewCvmClient(client),
 		Image:            NewCloudPublicClient(client),
 		RouteTable:       NewRouteTableClient(client),
@@ -60,5 +63,6 @@ func NewClient(client rest.ClientInterface) *Client {
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		Bill:             NewBillClient(client),
 		MainAccount:      NewMainAccountClient(client),
+		ResourceTag:      NewResourceTagClient(client),
 	}
 }
@@ -31,6 +31,8 @@ type Client struct {
 	Subnet           *SubnetClient
 	Eip              *EipClient
 	Disk             *DiskClient
+	DiskSnapshot     *DiskSnapshotClient
+	KeyPair          *KeyPairClient
 	Zone             *ZoneClient
 	Region           *RegionClient
 	Cvm              *CvmClient
@@ -39,6 +41,11 @@ type Client struct {
 	InstanceType     *InstanceTypeClient
 	NetworkInterface *NetworkInterfaceClient
 	Bill             *BillClient
+	ResSync          *ResSyncClient
+	LoadBalancer     *LoadBalancerClient
+	Cert             *CertClient
+	ArgsTpl          *ArgsTplClient
+	ResourceTag      *ResourceTagClient
 }
 
 // NewClient create a new huawei api client.
@@ -51,6 +58,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		SecurityGroup:    NewCloudSecurityGroupClient(client),
 		Eip:              NewEipClient(client),
 		Disk:             NewCloudDiskClient(client),
+		DiskSnapshot:     NewDiskSnapshotClient(client),
+		KeyPair:          NewKeyPairClient(client),
 		Zone:             NewZoneClient(client),
 		Region:           NewRegionClient(client),
 		Cvm:              NewCvmClient(client),
@@ -58,5 +67,10 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:     NewInstanceTypeClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		Bill:             NewBillClient(client),
+		ResSync:          NewResSyncClient(client),
+		LoadBalancer:     NewLoadBalancerClient(client),
+		Cert:             NewCertClient(client),
+		ArgsTpl:          NewArgsTplClient(client),
+		ResourceTag:      NewResourceTagClient(client),
 	}
 }
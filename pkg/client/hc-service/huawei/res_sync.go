@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewResSyncClient create a new resource sync api client.
+func NewResSyncClient(client rest.ClientInterface) *ResSyncClient {
+	return &ResSyncClient{
+		client: client,
+	}
+}
+
+// ResSyncClient is hc service resource sync api client, used to sync resource with delete option.
+type ResSyncClient struct {
+	client rest.ClientInterface
+}
+
+// Sync 同步 resPath 对应的资源，预览模式下返回同步计划，否则返回nil
+func (cli *ResSyncClient) Sync(kt *kit.Kit, resPath string, req *sync.HuaWeiSyncReq) (*sync.SyncPlanResult, error) {
+	return common.Request[sync.HuaWeiSyncReq, sync.SyncPlanResult](cli.client, rest.POST, kt, req, "/%s/sync", resPath)
+}
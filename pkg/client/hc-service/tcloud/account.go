@@ -205,3 +205,9 @@ func (a *AccountClient) GetNetworkAccountType(kt *kit.Kit, accountID string) (
 		a.client, http.MethodGet, kt, nil, "accounts/%s/network_type", accountID)
 
 }
+
+// InvalidateCredentialCache 清除账号凭证缓存
+func (a *AccountClient) InvalidateCredentialCache(kt *kit.Kit, accountID string) error {
+	return common.RequestNoResp[common.Empty](a.client, rest.DELETE, kt, common.NoData,
+		"/accounts/%s/credential_cache", accountID)
+}
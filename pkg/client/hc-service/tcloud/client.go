@@ -31,6 +31,8 @@ type Client struct {
 	Vpc           *VpcClient
 	Eip           *EipClient
 	Disk          *DiskClient
+	DiskSnapshot  *DiskSnapshotClient
+	KeyPair       *KeyPairClient
 	Zone          *ZoneClient
 	Region        *RegionClient
 	Cvm           *CvmClient
@@ -42,6 +44,9 @@ type Client struct {
 	Cert          *CertClient
 	Clb           *ClbClient
 	BandPkg       *BandwidthPackageClient
+	IncrementSync *IncrementalSyncClient
+	ResSync       *ResSyncClient
+	ResourceTag   *ResourceTagClient
 }
 
 // NewClient create a new tcloud api client.
@@ -53,6 +58,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		Vpc:           NewVpcClient(client),
 		Eip:           NewEipClient(client),
 		Disk:          NewCloudDiskClient(client),
+		DiskSnapshot:  NewDiskSnapshotClient(client),
+		KeyPair:       NewKeyPairClient(client),
 		Zone:          NewZoneClient(client),
 		Region:        NewRegionClient(client),
 		Cvm:           NewCvmClient(client),
@@ -64,5 +71,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		Cert:          NewCertClient(client),
 		Clb:           NewClbClient(client),
 		BandPkg:       NewBandPkgClient(client),
+		IncrementSync: NewIncrementalSyncClient(client),
+		ResSync:       NewResSyncClient(client),
+		ResourceTag:   NewResourceTagClient(client),
 	}
 }
@@ -183,3 +183,15 @@ func (c *Client) RetryTask(kt *kit.Kit, flowID string, taskID string) error {
 	return common.RequestNoResp[common.Empty](c.client, rest.PATCH, kt, nil,
 		"/flows/%s/tasks/%s/retry", flowID, taskID)
 }
+
+// GetFlowTimeline 查询任务流执行时间线
+func (c *Client) GetFlowTimeline(kt *kit.Kit, flowID string) (*apits.FlowTimelineResult, error) {
+	return common.Request[common.Empty, apits.FlowTimelineResult](c.client, rest.GET, kt, nil,
+		"/flows/%s/timeline", flowID)
+}
+
+// GetTaskTimeline 查询任务执行时间线
+func (c *Client) GetTaskTimeline(kt *kit.Kit, taskID string) (*apits.TaskTimeline, error) {
+	return common.Request[common.Empty, apits.TaskTimeline](c.client, rest.GET, kt, nil,
+		"/tasks/%s/timeline", taskID)
+}
@@ -24,4 +24,6 @@ const (
 	UnbindBkCloudID int64 = -1
 	// TCloudDefaultRegion defineds default value for tcloud region.
 	TCloudDefaultRegion = "ap-guangzhou"
+	// HuaWeiDefaultRegion defines default value for huawei region, used by global service such as scm.
+	HuaWeiDefaultRegion = "cn-north-4"
 )
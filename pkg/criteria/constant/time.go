@@ -27,6 +27,8 @@ const (
 
 	// TimeStdFormat is the system's standard time format to store or to query, equal to time.RFC3339
 	TimeStdFormat = "2006-01-02T15:04:05Z07:00"
+	// TimeStdMsFormat is the TimeStdFormat with millisecond
+	TimeStdMsFormat = "2006-01-02T15:04:05.000Z07:00"
 	// DateLayout is the date layout with '%Y-%m-%d'
 	DateLayout = "2006-01-02"
 	// DateTimeLayout is the date layout with '%Y-%m-%d %H:%M:%S'
@@ -72,6 +72,33 @@ const (
 	InternationalSite AccountSiteType = "international"
 )
 
+// AccountCredentialMode is account credential mode.
+type AccountCredentialMode string
+
+// Validate the AccountCredentialMode is valid or not
+func (m AccountCredentialMode) Validate() error {
+	switch m {
+	case SecretCredentialMode:
+	case RoleCredentialMode:
+	default:
+		return fmt.Errorf("unsupported account credential mode: %s", m)
+	}
+
+	return nil
+}
+
+// IsRole 是否为角色扮演模式，为空时视为密钥模式
+func (m AccountCredentialMode) IsRole() bool {
+	return m == RoleCredentialMode
+}
+
+const (
+	// SecretCredentialMode 密钥模式，使用账号登记的静态密钥访问云上资源，为默认模式。
+	SecretCredentialMode AccountCredentialMode = "secret"
+	// RoleCredentialMode 角色模式，由HCM持有的可信身份扮演账号下的角色获取临时凭证访问云上资源。
+	RoleCredentialMode AccountCredentialMode = "role"
+)
+
 // AccountSyncStatus is account sync status.
 type AccountSyncStatus string
 
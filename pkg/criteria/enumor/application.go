@@ -119,4 +119,52 @@ type ApplicationSource string
 const (
 	// ApplicationSourceITSM itsm 单据
 	ApplicationSourceITSM ApplicationSource = "itsm"
+	// ApplicationSourceHCM hcm 内置审批引擎单据
+	ApplicationSourceHCM ApplicationSource = "hcm"
+)
+
+// ApprovalEngine 审批引擎
+type ApprovalEngine string
+
+// Validate the ApprovalEngine is valid or not
+func (e ApprovalEngine) Validate() error {
+	switch e {
+	case ApprovalEngineItsm, ApprovalEngineNative:
+	default:
+		return fmt.Errorf("unsupported approval engine: %s", e)
+	}
+
+	return nil
+}
+
+const (
+	// ApprovalEngineItsm 使用蓝鲸ITSM审批
+	ApprovalEngineItsm ApprovalEngine = "itsm"
+	// ApprovalEngineNative 使用hcm内置审批引擎
+	ApprovalEngineNative ApprovalEngine = "native"
+)
+
+// ApprovalAction 内置审批引擎的审批操作
+type ApprovalAction string
+
+// Validate the ApprovalAction is valid or not
+func (a ApprovalAction) Validate() error {
+	switch a {
+	case ApprovalActionApprove, ApprovalActionReject, ApprovalActionTransfer:
+	default:
+		return fmt.Errorf("unsupported approval action: %s", a)
+	}
+
+	return nil
+}
+
+const (
+	// ApprovalActionApprove 同意
+	ApprovalActionApprove ApprovalAction = "approve"
+	// ApprovalActionReject 驳回
+	ApprovalActionReject ApprovalAction = "reject"
+	// ApprovalActionTransfer 转派给其他审批人
+	ApprovalActionTransfer ApprovalAction = "transfer"
+	// ApprovalActionTimeout 节点超时，由系统按节点配置自动处理
+	ApprovalActionTimeout ApprovalAction = "timeout"
 )
@@ -68,6 +68,7 @@ type BackendType string
 func (v BackendType) Validate() error {
 	switch v {
 	case BackendMysql:
+	case BackendBolt:
 	default:
 		return fmt.Errorf("unsupported backend type: %s", v)
 	}
@@ -78,4 +79,28 @@ func (v BackendType) Validate() error {
 const (
 	// BackendMysql mysql backend
 	BackendMysql BackendType = "mysql"
+	// BackendBolt bolt backend, data is persisted in local bolt file, for single node deployment without mysql
+	BackendBolt BackendType = "bolt"
+)
+
+// TaskEventType is async task event type.
+type TaskEventType string
+
+const (
+	// TaskEventState task state transition event
+	TaskEventState TaskEventType = "state"
+	// TaskEventLog task execute log event
+	TaskEventLog TaskEventType = "log"
+)
+
+// TaskLogLevel is async task execute log level.
+type TaskLogLevel string
+
+const (
+	// TaskLogInfo info level log
+	TaskLogInfo TaskLogLevel = "info"
+	// TaskLogWarn warn level log
+	TaskLogWarn TaskLogLevel = "warn"
+	// TaskLogError error level log
+	TaskLogError TaskLogLevel = "error"
 )
@@ -52,6 +52,7 @@ var defaultFlowNameMap = map[FlowName]struct{}{
 	FlowSleepTest:              {},
 	FlowDeleteSecurityGroup:    {},
 	FlowCreateHuaweiSGRule:     {},
+	FlowApplySGRuleTemplate:    {},
 	FlowDeleteEIP:              {},
 	FlowPullRawBill:            {},
 	FlowSplitBill:              {},
@@ -59,6 +60,7 @@ var defaultFlowNameMap = map[FlowName]struct{}{
 	FlowBillMainAccountSummary: {},
 	FlowBillRootAccountSummary: {},
 	FlowBillMonthTask:          {},
+	FlowRefreshTaskMgmtState:   {},
 }
 
 // ValidateDefault validate default FlowName.
@@ -127,6 +129,13 @@ const (
 const (
 	FlowDeleteSecurityGroup FlowName = "delete_security_group"
 	FlowCreateHuaweiSGRule  FlowName = "create_huawei_sg_rule"
+	FlowApplySGRuleTemplate FlowName = "apply_sg_rule_template"
+)
+
+// 任务管理相关Flow
+const (
+	// FlowRefreshTaskMgmtState 刷新任务管理状态
+	FlowRefreshTaskMgmtState FlowName = "refresh_task_management_state"
 )
 
 // EIP 相关Flow
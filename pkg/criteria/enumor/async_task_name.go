@@ -33,7 +33,7 @@ func (v ActionName) Validate() error {
 	case ActionDeleteFirewallRule:
 
 	case ActionDeleteSubnet:
-	case ActionDeleteSecurityGroup, ActionCreateHuaweiSGRule:
+	case ActionDeleteSecurityGroup, ActionCreateHuaweiSGRule, ActionApplySGRuleTemplate:
 	case ActionDeleteEIP:
 
 	case VirRoot:
@@ -48,6 +48,7 @@ func (v ActionName) Validate() error {
 	case ActionBatchTaskTCloudCreateL7Rule, ActionBatchTaskTCloudBindTarget, ActionBatchTaskTCloudCreateListener,
 		ActionBatchTaskTCloudUnBindTarget, ActionBatchTaskTCloudModifyRsWeight, ActionBatchTaskDeleteListener:
 	case ActionSyncTCloudLoadBalancer, SyncTCloudLoadBalancerListener:
+	case ActionRefreshTaskMgmtState:
 
 	default:
 		return fmt.Errorf("unsupported action name type: %s", v)
@@ -96,6 +97,13 @@ const (
 const (
 	ActionDeleteSecurityGroup ActionName = "delete_security_group"
 	ActionCreateHuaweiSGRule  ActionName = "create_huawei_sg_rule"
+	ActionApplySGRuleTemplate ActionName = "apply_sg_rule_template"
+)
+
+// Task Management
+const (
+	// ActionRefreshTaskMgmtState 刷新任务管理状态
+	ActionRefreshTaskMgmtState ActionName = "refresh_task_management_state"
 )
 
 // EIP related action
@@ -35,6 +35,8 @@ const (
 	VpcCloudAuditResType          AuditResourceType = "vpc"
 	SubnetAuditResType            AuditResourceType = "subnet"
 	DiskAuditResType              AuditResourceType = "disk"
+	DiskSnapshotAuditResType      AuditResourceType = "disk_snapshot"
+	KeyPairAuditResType           AuditResourceType = "key_pair"
 	CvmAuditResType               AuditResourceType = "cvm"
 	RouteTableAuditResType        AuditResourceType = "route_table"
 	RouteAuditResType             AuditResourceType = "route"
@@ -61,6 +63,8 @@ var AuditResourceTypeEnums = map[AuditResourceType]struct{}{
 	VpcCloudAuditResType:          {},
 	SubnetAuditResType:            {},
 	DiskAuditResType:              {},
+	DiskSnapshotAuditResType:      {},
+	KeyPairAuditResType:           {},
 	CvmAuditResType:               {},
 	RouteTableAuditResType:        {},
 	EipAuditResType:               {},
@@ -115,6 +119,8 @@ const (
 	Bind AuditAction = "bind"
 	// Deliver 交付
 	Deliver AuditAction = "deliver"
+	// Rollback 回滚
+	Rollback AuditAction = "rollback"
 )
 
 // AuditActionEnums op type map.
@@ -133,6 +139,7 @@ var AuditActionEnums = map[AuditAction]struct{}{
 	Disassociate: {},
 	Bind:         {},
 	Deliver:      {},
+	Rollback:     {},
 }
 
 // Exist judge enum value exist.
@@ -229,6 +229,9 @@ const (
 
 	// HuaweiSupportMonthTask 华为support plan
 	HuaweiSupportMonthTask MonthTaskType = "support"
+
+	// TCloudSupportMonthTask 腾讯云根账号自身支出（support plan等），分摊至各二级账号
+	TCloudSupportMonthTask MonthTaskType = "support"
 )
 
 // MonthTaskStep 月度任务步骤
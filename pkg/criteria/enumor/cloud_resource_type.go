@@ -37,6 +37,8 @@ var typeMapping = map[CloudResourceType]table.Name{
 	SubnetCloudResType:           table.SubnetTable,
 	EipCloudResType:              table.EipTable,
 	DiskCloudResType:             table.DiskTable,
+	DiskSnapshotCloudResType:     table.DiskSnapshotTable,
+	KeyPairCloudResType:          table.KeyPairTable,
 	CvmCloudResType:              table.CvmTable,
 	RouteTableCloudResType:       table.RouteTableTable,
 	NetworkInterfaceCloudResType: table.NetworkInterfaceTable,
@@ -75,6 +77,8 @@ const (
 	EipCloudResType              CloudResourceType = "eip"
 	CvmCloudResType              CloudResourceType = "cvm"
 	DiskCloudResType             CloudResourceType = "disk"
+	DiskSnapshotCloudResType     CloudResourceType = "disk_snapshot"
+	KeyPairCloudResType          CloudResourceType = "key_pair"
 	RouteTableCloudResType       CloudResourceType = "route_table"
 	RouteCloudResType            CloudResourceType = "route"
 	NetworkInterfaceCloudResType CloudResourceType = "network_interface"
@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// CryptoAlgorithm is the symmetric encryption algorithm used to encrypt secrets.
+type CryptoAlgorithm string
+
+// Validate the CryptoAlgorithm is valid or not
+func (a CryptoAlgorithm) Validate() error {
+	switch a {
+	case CryptoAesGcm, CryptoSm4Gcm:
+	default:
+		return fmt.Errorf("unsupported crypto algorithm: %s", a)
+	}
+
+	return nil
+}
+
+// KeySizes return the valid key sizes in bytes of the algorithm.
+func (a CryptoAlgorithm) KeySizes() []int {
+	switch a {
+	case CryptoAesGcm:
+		return []int{16, 32}
+	case CryptoSm4Gcm:
+		return []int{16}
+	default:
+		return nil
+	}
+}
+
+const (
+	// CryptoAesGcm AES算法的GCM模式
+	CryptoAesGcm CryptoAlgorithm = "aes-gcm"
+	// CryptoSm4Gcm 国密SM4算法的GCM模式
+	CryptoSm4Gcm CryptoAlgorithm = "sm4-gcm"
+)
@@ -108,6 +108,11 @@ var MainAccountNameFieldNameMap = map[Vendor]MainAccountCommonFields{
 		AccountID:    "cloud_main_account_id",
 		InitPassword: "cloud_init_password",
 	},
+	TCloud: {
+		AccountName:  "cloud_main_account_name",
+		AccountID:    "cloud_main_account_id",
+		InitPassword: "cloud_init_password",
+	},
 }
 
 // GetMainAccountNameFieldName get the main account name field name
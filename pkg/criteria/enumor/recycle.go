@@ -42,8 +42,12 @@ const (
 
 // RecycleAuditResTypeMap recycle resource audit type to cloud resource type map.
 var RecycleAuditResTypeMap = map[AuditResourceType]CloudResourceType{
-	CvmAuditResType:  CvmCloudResType,
-	DiskAuditResType: DiskCloudResType,
+	CvmAuditResType:           CvmCloudResType,
+	DiskAuditResType:          DiskCloudResType,
+	DiskSnapshotAuditResType:  DiskSnapshotCloudResType,
+	EipAuditResType:           EipCloudResType,
+	LoadBalancerAuditResType:  LoadBalancerCloudResType,
+	SecurityGroupAuditResType: SecurityGroupCloudResType,
 }
 
 // RecycleType 回收类型
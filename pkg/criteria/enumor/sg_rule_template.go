@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// SGRuleTemplateApplyStatus is security group rule template apply status.
+type SGRuleTemplateApplyStatus string
+
+// Validate SGRuleTemplateApplyStatus.
+func (s SGRuleTemplateApplyStatus) Validate() error {
+	switch s {
+	case SGRuleTemplateApplyPending, SGRuleTemplateApplying, SGRuleTemplateApplySuccess, SGRuleTemplateApplyFailed:
+	default:
+		return fmt.Errorf("unsupported security group rule template apply status: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// SGRuleTemplateApplyPending 已绑定，尚未应用
+	SGRuleTemplateApplyPending SGRuleTemplateApplyStatus = "pending"
+	// SGRuleTemplateApplying 应用中
+	SGRuleTemplateApplying SGRuleTemplateApplyStatus = "applying"
+	// SGRuleTemplateApplySuccess 应用成功
+	SGRuleTemplateApplySuccess SGRuleTemplateApplyStatus = "success"
+	// SGRuleTemplateApplyFailed 应用失败
+	SGRuleTemplateApplyFailed SGRuleTemplateApplyStatus = "failed"
+)
+
+// SGRuleTemplateDriftStatus is security group rule template drift status.
+type SGRuleTemplateDriftStatus string
+
+const (
+	// SGRuleTemplateNotApplied 模板从未成功应用到该安全组
+	SGRuleTemplateNotApplied SGRuleTemplateDriftStatus = "not_applied"
+	// SGRuleTemplateOutdated 模板在最近一次应用后被修改
+	SGRuleTemplateOutdated SGRuleTemplateDriftStatus = "outdated"
+	// SGRuleTemplateDrifted 安全组同步后的规则与模板不一致
+	SGRuleTemplateDrifted SGRuleTemplateDriftStatus = "drifted"
+	// SGRuleTemplateInSync 安全组规则与模板一致
+	SGRuleTemplateInSync SGRuleTemplateDriftStatus = "in_sync"
+)
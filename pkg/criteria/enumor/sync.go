@@ -47,3 +47,13 @@ const (
 	// Syncing status
 	Syncing SyncStatus = "syncing"
 )
+
+// SyncRunScope 同步运行记录的粒度
+type SyncRunScope string
+
+const (
+	// SyncRunAccountScope 账号全量同步
+	SyncRunAccountScope SyncRunScope = "account"
+	// SyncRunResourceScope 单个资源同步
+	SyncRunResourceScope SyncRunScope = "resource"
+)
@@ -69,3 +69,15 @@ const (
 	// ContainerTCloudCidr is container cidr category.
 	ContainerTCloudCidr TCloudCidrCategory = "container"
 )
+
+// SubnetCidrConflictSource is the source of cidr which conflicts with vpc cidr when planning subnet cidr.
+type SubnetCidrConflictSource string
+
+const (
+	// ReservedCidrConflictSource 业务预留网段。
+	ReservedCidrConflictSource SubnetCidrConflictSource = "reserved"
+	// CloudAreaVpcCidrConflictSource 同一管控区域下其他VPC的网段。
+	CloudAreaVpcCidrConflictSource SubnetCidrConflictSource = "cloud_area_vpc"
+	// PeeringRouteCidrConflictSource 对等连接路由的目的网段。
+	PeeringRouteCidrConflictSource SubnetCidrConflictSource = "peering_route"
+)
@@ -72,4 +72,6 @@ const (
 	BillItemImportDataError int32 = 2000016
 	// BillItemImportEmptyDataError 账单导入空列表
 	BillItemImportEmptyDataError int32 = 2000017
+	// SyncDeleteProtected 同步删除的资源超过删除保护阈值，需要确认后才能执行
+	SyncDeleteProtected int32 = 2000018
 )
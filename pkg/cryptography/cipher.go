@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/slice"
+
+	"github.com/tjfoc/gmsm/sm4"
+)
+
+// NewCipher 根据加密算法生成对应的AEAD加解密器，算法为空时默认使用AES-GCM
+func NewCipher(algorithm enumor.CryptoAlgorithm, key []byte) (cipher.AEAD, error) {
+	if len(algorithm) == 0 {
+		algorithm = enumor.CryptoAesGcm
+	}
+
+	if err := algorithm.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !slice.IsItemInSlice(algorithm.KeySizes(), len(key)) {
+		return nil, fmt.Errorf("invalid %s key, should be %v bytes", algorithm, algorithm.KeySizes())
+	}
+
+	var block cipher.Block
+	var err error
+	switch algorithm {
+	case enumor.CryptoSm4Gcm:
+		block, err = sm4.NewCipher(key)
+	default:
+		block, err = aes.NewCipher(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
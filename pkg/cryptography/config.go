@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"errors"
+
+	"hcm/pkg/cc"
+)
+
+// NewFromConfig 根据配置文件里的加密配置生成对应的加解密器，配置了多版本密钥时使用信封加密，
+// 否则保持使用固定nonce的AES-GCM加密
+func NewFromConfig(cfg cc.Crypto) (Crypto, error) {
+	var legacy *AESGcm
+	if len(cfg.AesGcm.Key) != 0 {
+		var err error
+		legacy, err = NewAESGcm([]byte(cfg.AesGcm.Key), []byte(cfg.AesGcm.Nonce))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.Keys) == 0 {
+		if legacy == nil {
+			return nil, errors.New("crypto aes gcm key or keys is required")
+		}
+		return legacy, nil
+	}
+
+	keys := make([]Key, 0, len(cfg.Keys))
+	for _, one := range cfg.Keys {
+		keys = append(keys, Key{ID: one.ID, Algorithm: one.Algorithm, Key: []byte(one.Key)})
+	}
+
+	return NewEnvelope(keys, legacy)
+}
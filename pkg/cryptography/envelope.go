@@ -0,0 +1,246 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/TencentBlueKing/gopkg/conv"
+	gopkgcryptography "github.com/TencentBlueKing/gopkg/cryptography"
+)
+
+// 信封密文格式: magic(3字节) | version(1字节) | keyID长度(1字节) | keyID | nonce(12字节) | GCM密文
+// 其中 magic、version 及 keyID 作为附加认证数据参与加密，避免被篡改
+var envelopeMagic = []byte("HCM")
+
+const (
+	envelopeVersionV1 byte = 1
+	// MaxKeyIDLength 密钥ID的最大长度
+	MaxKeyIDLength = 32
+)
+
+// Key 带有ID的加密密钥，ID会记录在密文中，用于解密时选择对应的密钥及算法
+type Key struct {
+	ID string
+	// Algorithm 密钥使用的加密算法，为空时默认使用AES-GCM
+	Algorithm enumor.CryptoAlgorithm
+	Key       []byte
+}
+
+// Rotator 支持密钥轮换的加解密器
+type Rotator interface {
+	Crypto
+	// PrimaryKeyID 返回当前加密使用的密钥ID
+	PrimaryKeyID() string
+	// ReEncryptFromBase64 将Base64格式的密文使用当前加密密钥重新加密，密文已经是当前加密密钥加密的则返回false
+	ReEncryptFromBase64(encryptedTextB64 string) (string, bool, error)
+}
+
+// Envelope 多版本密钥的加解密器，加密时使用最新的密钥及随机生成的nonce，并将密钥ID及nonce记录在密文中；
+// 解密时根据密文中的密钥ID选择密钥，不是信封格式的历史密文使用旧的固定nonce加解密器解密
+type Envelope struct {
+	primaryID string
+	aeads     map[string]cipher.AEAD
+	legacy    *AESGcm
+}
+
+// NewEnvelope keys 需按从旧到新的顺序传入，最后一个为当前加密使用的密钥，legacy 为空时不支持解密历史密文
+func NewEnvelope(keys []Key, legacy *AESGcm) (*Envelope, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("envelope crypto keys are required")
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for _, key := range keys {
+		if len(key.ID) == 0 || len(key.ID) > MaxKeyIDLength {
+			return nil, fmt.Errorf("invalid key id %s, length should be 1~%d", key.ID, MaxKeyIDLength)
+		}
+
+		if _, exists := aeads[key.ID]; exists {
+			return nil, fmt.Errorf("key id %s is duplicated", key.ID)
+		}
+
+		aead, err := NewCipher(key.Algorithm, key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key %s is invalid, err: %v", key.ID, err)
+		}
+		aeads[key.ID] = aead
+	}
+
+	return &Envelope{primaryID: keys[len(keys)-1].ID, aeads: aeads, legacy: legacy}, nil
+}
+
+// PrimaryKeyID 返回当前加密使用的密钥ID
+func (e *Envelope) PrimaryKeyID() string {
+	return e.primaryID
+}
+
+// Encrypt 使用当前密钥及随机nonce加密明文，返回信封格式的密文
+func (e *Envelope) Encrypt(plaintext []byte) ([]byte, error) {
+	aead := e.aeads[e.primaryID]
+
+	header := envelopeHeader(e.primaryID)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		// 系统随机数不可用时无法安全加密，不能降级为固定nonce
+		return nil, fmt.Errorf("generate random nonce failed, err: %v", err)
+	}
+
+	encryptedText := make([]byte, 0, len(header)+len(nonce)+len(plaintext)+aead.Overhead())
+	encryptedText = append(encryptedText, header...)
+	encryptedText = append(encryptedText, nonce...)
+	return aead.Seal(encryptedText, nonce, plaintext, header), nil
+}
+
+// Decrypt 解密信封格式或历史格式的密文
+func (e *Envelope) Decrypt(encryptedText []byte) ([]byte, error) {
+	keyID, header, nonce, sealed, ok := parseEnvelope(encryptedText)
+	if !ok {
+		return e.decryptLegacy(encryptedText)
+	}
+
+	aead, exists := e.aeads[keyID]
+	if !exists {
+		// 历史密文有极小概率恰好以信封头部开头，此时仍尝试使用旧的加解密器解密
+		if plaintext, err := e.decryptLegacy(encryptedText); err == nil {
+			return plaintext, nil
+		}
+		return nil, fmt.Errorf("crypto key %s not found", keyID)
+	}
+
+	plaintext, err := aead.Open(nil, nonce, sealed, header)
+	if err != nil {
+		if legacyPlaintext, legacyErr := e.decryptLegacy(encryptedText); legacyErr == nil {
+			return legacyPlaintext, nil
+		}
+		return nil, err
+	}
+
+	return plaintext, nil
+}
+
+// EncryptToString 加密明文并以字符串返回
+func (e *Envelope) EncryptToString(plaintext []byte) (string, error) {
+	encryptedText, err := e.Encrypt(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.BytesToString(encryptedText), nil
+}
+
+// DecryptString 解密字符串格式的密文
+func (e *Envelope) DecryptString(encryptedText string) ([]byte, error) {
+	return e.Decrypt(conv.StringToBytes(encryptedText))
+}
+
+// EncryptToBase64 将字符串明文加密后再转化为Base64格式的字符串
+func (e *Envelope) EncryptToBase64(plaintext string) (string, error) {
+	encryptedText, err := e.Encrypt(conv.StringToBytes(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(encryptedText), nil
+}
+
+// DecryptFromBase64 将Base64格式的密文解密为明文字符串
+func (e *Envelope) DecryptFromBase64(encryptedTextB64 string) (string, error) {
+	encryptedText, err := base64.StdEncoding.DecodeString(encryptedTextB64)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := e.Decrypt(encryptedText)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.BytesToString(plaintext), nil
+}
+
+// ReEncryptFromBase64 将Base64格式的密文使用当前加密密钥重新加密，密文已经是当前加密密钥加密的则返回false
+func (e *Envelope) ReEncryptFromBase64(encryptedTextB64 string) (string, bool, error) {
+	encryptedText, err := base64.StdEncoding.DecodeString(encryptedTextB64)
+	if err != nil {
+		return "", false, err
+	}
+
+	plaintext, err := e.Decrypt(encryptedText)
+	if err != nil {
+		return "", false, err
+	}
+
+	keyID, _, _, _, ok := parseEnvelope(encryptedText)
+	if ok && keyID == e.primaryID {
+		return encryptedTextB64, false, nil
+	}
+
+	reEncryptedText, err := e.Encrypt(plaintext)
+	if err != nil {
+		return "", false, err
+	}
+
+	return base64.StdEncoding.EncodeToString(reEncryptedText), true, nil
+}
+
+func (e *Envelope) decryptLegacy(encryptedText []byte) ([]byte, error) {
+	if e.legacy == nil {
+		return nil, errors.New("encrypted text is not envelope format and legacy crypto is not set")
+	}
+
+	return e.legacy.Decrypt(encryptedText)
+}
+
+func envelopeHeader(keyID string) []byte {
+	header := make([]byte, 0, len(envelopeMagic)+2+len(keyID))
+	header = append(header, envelopeMagic...)
+	header = append(header, envelopeVersionV1, byte(len(keyID)))
+	return append(header, keyID...)
+}
+
+// parseEnvelope 解析信封格式的密文，返回密钥ID、头部、nonce及GCM密文，不是信封格式时ok为false
+func parseEnvelope(encryptedText []byte) (keyID string, header, nonce, sealed []byte, ok bool) {
+	prefixLen := len(envelopeMagic) + 2
+	if len(encryptedText) < prefixLen || !bytes.Equal(encryptedText[:len(envelopeMagic)], envelopeMagic) ||
+		encryptedText[len(envelopeMagic)] != envelopeVersionV1 {
+		return "", nil, nil, nil, false
+	}
+
+	keyIDLen := int(encryptedText[len(envelopeMagic)+1])
+	headerLen := prefixLen + keyIDLen
+	if keyIDLen == 0 || keyIDLen > MaxKeyIDLength ||
+		len(encryptedText) < headerLen+gopkgcryptography.NonceByteSize {
+		return "", nil, nil, nil, false
+	}
+
+	header = encryptedText[:headerLen]
+	nonce = encryptedText[headerLen : headerLen+gopkgcryptography.NonceByteSize]
+	sealed = encryptedText[headerLen+gopkgcryptography.NonceByteSize:]
+	return string(encryptedText[prefixLen:headerLen]), header, nonce, sealed, true
+}
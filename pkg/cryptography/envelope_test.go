@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	legacy, err := NewAESGcm([]byte("0123456789abcdef"), []byte("0123456789ab"))
+	assert.NoError(t, err)
+
+	oldKeys := []Key{{ID: "v1", Key: []byte("abcdef0123456789")}}
+	oldEnvelope, err := NewEnvelope(oldKeys, legacy)
+	assert.NoError(t, err)
+
+	newEnvelope, err := NewEnvelope(append(oldKeys, Key{ID: "v2", Key: []byte("abcdef0123456789abcdef0123456789")}),
+		legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", newEnvelope.PrimaryKeyID())
+
+	// 同一明文每次加密的结果都不一样
+	encrypted, err := newEnvelope.EncryptToBase64("secret")
+	assert.NoError(t, err)
+	another, err := newEnvelope.EncryptToBase64("secret")
+	assert.NoError(t, err)
+	assert.NotEqual(t, encrypted, another)
+	plaintext, err := newEnvelope.DecryptFromBase64(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	// 历史密文及旧密钥加密的密文都可以解密，并重新加密为新密钥
+	legacyEncrypted, err := legacy.EncryptToBase64("secret")
+	assert.NoError(t, err)
+	oldEncrypted, err := oldEnvelope.EncryptToBase64("secret")
+	assert.NoError(t, err)
+	for _, old := range []string{legacyEncrypted, oldEncrypted} {
+		reEncrypted, changed, err := newEnvelope.ReEncryptFromBase64(old)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+
+		plaintext, err = newEnvelope.DecryptFromBase64(reEncrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", plaintext)
+
+		_, changed, err = newEnvelope.ReEncryptFromBase64(reEncrypted)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	}
+
+	// 旧密钥不认识新密钥加密的密文
+	_, err = oldEnvelope.DecryptFromBase64(encrypted)
+	assert.Error(t, err)
+
+	_, err = NewEnvelope([]Key{{ID: "v1", Key: []byte("short")}}, nil)
+	assert.Error(t, err)
+}
+
+func TestEnvelopeSm4(t *testing.T) {
+	aesKey := Key{ID: "v1", Key: []byte("abcdef0123456789")}
+	aesEnvelope, err := NewEnvelope([]Key{aesKey}, nil)
+	assert.NoError(t, err)
+
+	sm4Envelope, err := NewEnvelope([]Key{aesKey, {ID: "sm4-v1", Algorithm: enumor.CryptoSm4Gcm,
+		Key: []byte("0123456789abcdef")}}, nil)
+	assert.NoError(t, err)
+
+	// AES加密的历史数据仍可读，并可转换为SM4加密
+	aesEncrypted, err := aesEnvelope.EncryptToBase64("secret")
+	assert.NoError(t, err)
+	reEncrypted, changed, err := sm4Envelope.ReEncryptFromBase64(aesEncrypted)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	plaintext, err := sm4Envelope.DecryptFromBase64(reEncrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	_, err = aesEnvelope.DecryptFromBase64(reEncrypted)
+	assert.Error(t, err)
+
+	// SM4只支持16字节的密钥
+	_, err = NewCipher(enumor.CryptoSm4Gcm, []byte("abcdef0123456789abcdef0123456789"))
+	assert.Error(t, err)
+}
+
+type failedReader struct{}
+
+func (failedReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy source unavailable")
+}
+
+func TestEnvelopeNonceFailed(t *testing.T) {
+	envelope, err := NewEnvelope([]Key{{ID: "v1", Key: []byte("abcdef0123456789")}}, nil)
+	assert.NoError(t, err)
+
+	reader := rand.Reader
+	rand.Reader = failedReader{}
+	defer func() { rand.Reader = reader }()
+
+	// 随机数不可用时返回错误，不能降级为固定nonce加密
+	_, err = envelope.EncryptToBase64("secret")
+	assert.Error(t, err)
+}
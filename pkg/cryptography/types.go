@@ -21,12 +21,12 @@ package cryptography
 
 // Crypto 定义了需要实现的三组加解密方法，分别是以字节、字符串、Base64字符串格式的方法
 type Crypto interface {
-	Encrypt(plaintext []byte) []byte
+	Encrypt(plaintext []byte) ([]byte, error)
 	Decrypt(encryptedText []byte) ([]byte, error)
 
-	EncryptToString(plaintext []byte) string
+	EncryptToString(plaintext []byte) (string, error)
 	DecryptString(encryptedText string) ([]byte, error)
 
-	EncryptToBase64(plaintext string) string
+	EncryptToBase64(plaintext string) (string, error)
 	DecryptFromBase64(encryptedTextB64 string) (string, error)
 }
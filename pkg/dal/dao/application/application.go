@@ -43,6 +43,7 @@ import (
 type Application interface {
 	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *application.ApplicationTable) (string, error)
 	Update(kt *kit.Kit, expr *filter.Expression, model *application.ApplicationTable) error
+	CompareAndUpdate(kt *kit.Kit, expr *filter.Expression, model *application.ApplicationTable) error
 	List(kt *kit.Kit, opt *types.ListOption) (*types.ListApplicationDetails, error)
 }
 
@@ -81,6 +82,19 @@ func (a *ApplicationDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *applicati
 
 // Update ...
 func (a *ApplicationDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *application.ApplicationTable) error {
+	return a.update(kt, filterExpr, model, false)
+}
+
+// CompareAndUpdate 条件更新，用于乐观锁，没有记录满足过滤条件时返回 RecordNotUpdate 错误
+func (a *ApplicationDao) CompareAndUpdate(kt *kit.Kit, filterExpr *filter.Expression,
+	model *application.ApplicationTable) error {
+
+	return a.update(kt, filterExpr, model, true)
+}
+
+func (a *ApplicationDao) update(kt *kit.Kit, filterExpr *filter.Expression, model *application.ApplicationTable,
+	mustEffect bool) error {
+
 	if filterExpr == nil {
 		return errf.New(errf.InvalidParameter, "filter expr is nil")
 	}
@@ -111,7 +125,9 @@ func (a *ApplicationDao) Update(kt *kit.Kit, filterExpr *filter.Expression, mode
 
 		if effected == 0 {
 			logs.ErrorJson("update application, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
-			// return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
+			if mustEffect {
+				return nil, errf.New(errf.RecordNotUpdate, "no application matches the update condition")
+			}
 		}
 
 		return nil, nil
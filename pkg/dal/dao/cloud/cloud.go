@@ -97,7 +97,7 @@ func (dao CloudDao) ListResourceBasicInfo(kt *kit.Kit, resType enumor.CloudResou
 	return list, nil
 }
 
-// ListResourceIDs list cloud resource ids.
+// ListResourceIDs list cloud resource ids, expr supports tag operators of the resource type.
 func (dao CloudDao) ListResourceIDs(kt *kit.Kit, resType enumor.CloudResourceType, expr *filter.Expression) ([]string,
 	error) {
 
@@ -110,7 +110,7 @@ func (dao CloudDao) ListResourceIDs(kt *kit.Kit, resType enumor.CloudResourceTyp
 		return nil, errf.New(errf.InvalidParameter, "ids is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TagSqlWhereOption(resType))
 	if err != nil {
 		return nil, err
 	}
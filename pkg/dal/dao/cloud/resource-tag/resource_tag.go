@@ -0,0 +1,251 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package restag resource tag dao.
+package restag
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablerestag "hcm/pkg/dal/table/cloud/resource-tag"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Interface only used for resource tag.
+type Interface interface {
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablerestag.ResourceTagTable], error)
+	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablerestag.ResourceTagTable) ([]string, error)
+	UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression, model *tablerestag.ResourceTagTable) error
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, f *filter.Expression) error
+	DeleteByResWithTx(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType, resIDs []string) error
+	ListResByCloudID(kt *kit.Kit, resType enumor.CloudResourceType, accountID string, cloudIDs []string) (
+		[]ResBasicInfo, error)
+}
+
+var _ Interface = new(Dao)
+
+// Dao resource tag dao.
+type Dao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// CreateWithTx ...
+func (d Dao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablerestag.ResourceTagTable) ([]string, error) {
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	for index := range models {
+		if err := models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	ids, err := d.IDGen.Batch(kt, models[0].TableName(), len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
+		tablerestag.ResourceTagColumns.ColumnExpr(), tablerestag.ResourceTagColumns.ColonNameExpr())
+
+	if err = d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+	}
+
+	return ids, nil
+}
+
+// UpdateWithTx ...
+func (d Dao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression,
+	model *tablerestag.ResourceTagTable) error {
+
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...).AddBlankedFields("tag_value")
+	// tag identity fields can not be updated.
+	opts = opts.AddIgnoredFields("res_type", "res_id", "res_cloud_id", "vendor", "account_id", "tag_key")
+
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+
+	effected, err := d.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
+	if err != nil {
+		logs.ErrorJson("update resource tag failed, filter: %v, err: %v, rid: %v", filterExpr, err, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		logs.ErrorJson("update resource tag, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
+	}
+
+	return nil
+}
+
+// List ...
+func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablerestag.ResourceTagTable], error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list resource tag options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablerestag.ResourceTagColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceTagTable, whereExpr)
+
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count resource tag failed, err: %v, filter: %v, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablerestag.ResourceTagTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablerestag.ResourceTagColumns.FieldsNamedExpr(opt.Fields),
+		table.ResourceTagTable, whereExpr, pageExpr)
+
+	details := make([]tablerestag.ResourceTagTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListResult[tablerestag.ResourceTagTable]{Count: 0, Details: details}, nil
+}
+
+// DeleteWithTx delete resource tag with tx.
+func (d Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.ResourceTagTable, whereExpr)
+
+	if _, err = d.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete resource tag failed, err: %v, filter: %v, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByResWithTx delete all tags of the resources with tx, used when the resources are deleted.
+func (d Dao) DeleteByResWithTx(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
+	resIDs []string) error {
+
+	for _, partIDs := range slice.Split(resIDs, int(filter.DefaultMaxInLimit)) {
+		expr := tools.ExpressionAnd(tools.RuleEqual("res_type", resType), tools.RuleIn("res_id", partIDs))
+		if err := d.DeleteWithTx(kt, tx, expr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResBasicInfo is the basic info of the resource that tags belong to.
+type ResBasicInfo struct {
+	ID        string        `db:"id"`
+	CloudID   string        `db:"cloud_id"`
+	Vendor    enumor.Vendor `db:"vendor"`
+	AccountID string        `db:"account_id"`
+}
+
+// ListResByCloudID list resource basic info by cloud ids under the account, used to convert cloud id to resource id.
+func (d Dao) ListResByCloudID(kt *kit.Kit, resType enumor.CloudResourceType, accountID string, cloudIDs []string) (
+	[]ResBasicInfo, error) {
+
+	tableName, err := resType.ConvTableName()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(accountID) == 0 || len(cloudIDs) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "account id and cloud ids are required")
+	}
+
+	sql := fmt.Sprintf("SELECT id, cloud_id, vendor, account_id FROM %s WHERE account_id = :account_id "+
+		"AND cloud_id IN (:cloud_ids)", tableName)
+	args := map[string]interface{}{
+		"account_id": accountID,
+		"cloud_ids":  cloudIDs,
+	}
+
+	list := make([]ResBasicInfo, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &list, sql, args); err != nil {
+		logs.Errorf("select %s by cloud ids failed, err: %v, account: %s, rid: %s", resType, err, accountID, kt.Rid)
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,149 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daosync
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typessync "hcm/pkg/dal/dao/types/sync"
+	"hcm/pkg/dal/table"
+	tablessync "hcm/pkg/dal/table/cloud/sync"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SyncRunRecord only used for sync run record.
+type SyncRunRecord interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablessync.SyncRunRecordTable) ([]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*typessync.ListSyncRunRecords, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ SyncRunRecord = new(SyncRunRecordDao)
+
+// SyncRunRecordDao sync run record dao.
+type SyncRunRecordDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchCreateWithTx sync run record with tx.
+func (dao *SyncRunRecordDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	models []tablessync.SyncRunRecordTable) ([]string, error) {
+
+	ids, err := dao.IDGen.Batch(kt, table.SyncRunRecordTable, len(models))
+	if err != nil {
+		return nil, err
+	}
+	for index := range models {
+		models[index].ID = ids[index]
+
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.SyncRunRecordTable,
+		tablessync.SyncRunRecordColumns.ColumnExpr(), tablessync.SyncRunRecordColumns.ColonNameExpr())
+
+	err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
+	if err != nil {
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.SyncRunRecordTable, err, sql, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", table.SyncRunRecordTable, err)
+	}
+
+	return ids, nil
+}
+
+// List sync run record.
+func (dao *SyncRunRecordDao) List(kt *kit.Kit, opt *types.ListOption) (*typessync.ListSyncRunRecords, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list sync run record options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablessync.SyncRunRecordColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.SyncRunRecordTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count sync run record failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typessync.ListSyncRunRecords{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablessync.SyncRunRecordColumns.FieldsNamedExpr(opt.Fields),
+		table.SyncRunRecordTable, whereExpr, pageExpr)
+
+	details := make([]tablessync.SyncRunRecordTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select sync run record failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
+			opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &typessync.ListSyncRunRecords{Count: 0, Details: details}, nil
+}
+
+// DeleteWithTx sync run record with tx.
+func (dao *SyncRunRecordDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.SyncRunRecordTable, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete sync run record failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
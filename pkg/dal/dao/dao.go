@@ -36,23 +36,28 @@ import (
 	daoselection "hcm/pkg/dal/dao/cloud-selection"
 	argstpl "hcm/pkg/dal/dao/cloud/argument-template"
 	cloudbill "hcm/pkg/dal/dao/cloud/bill"
+	bizassign "hcm/pkg/dal/dao/cloud/biz-assign-rule"
 	"hcm/pkg/dal/dao/cloud/cert"
 	"hcm/pkg/dal/dao/cloud/cvm"
 	"hcm/pkg/dal/dao/cloud/disk"
 	diskcvmrel "hcm/pkg/dal/dao/cloud/disk-cvm-rel"
+	disksnapshot "hcm/pkg/dal/dao/cloud/disk-snapshot"
 	"hcm/pkg/dal/dao/cloud/eip"
 	eipcvmrel "hcm/pkg/dal/dao/cloud/eip-cvm-rel"
 	cimage "hcm/pkg/dal/dao/cloud/image"
+	keypair "hcm/pkg/dal/dao/cloud/key-pair"
 	loadbalancer "hcm/pkg/dal/dao/cloud/load-balancer"
 	networkinterface "hcm/pkg/dal/dao/cloud/network-interface"
 	nicvmrel "hcm/pkg/dal/dao/cloud/network-interface-cvm-rel"
 	"hcm/pkg/dal/dao/cloud/region"
 	resflow "hcm/pkg/dal/dao/cloud/resource-flow"
 	resourcegroup "hcm/pkg/dal/dao/cloud/resource-group"
+	restag "hcm/pkg/dal/dao/cloud/resource-tag"
 	routetable "hcm/pkg/dal/dao/cloud/route-table"
 	securitygroup "hcm/pkg/dal/dao/cloud/security-group"
 	sgcomrel "hcm/pkg/dal/dao/cloud/security-group-common-rel"
 	sgcvmrel "hcm/pkg/dal/dao/cloud/security-group-cvm-rel"
+	sgruletpl "hcm/pkg/dal/dao/cloud/sg-rule-template"
 	daosubaccount "hcm/pkg/dal/dao/cloud/sub-account"
 	daosync "hcm/pkg/dal/dao/cloud/sync"
 	"hcm/pkg/dal/dao/cloud/zone"
@@ -91,6 +96,7 @@ type Set interface {
 	AzureRegion() region.AzureRegion
 	Zone() zone.Zone
 	AccountSyncDetail() daosync.AccountSyncDetail
+	SyncRunRecord() daosync.SyncRunRecord
 	TCloudRegion() region.TCloudRegion
 	AwsRegion() region.AwsRegion
 	GcpRegion() region.GcpRegion
@@ -121,6 +127,7 @@ type Set interface {
 	AccountBillSyncRecord() bill.AccountBillSyncRecord
 	AsyncFlow() daoasync.AsyncFlow
 	AsyncFlowTask() daoasync.AsyncFlowTask
+	AsyncFlowTaskEvent() daoasync.AsyncFlowTaskEvent
 	UserCollection() daouser.Interface
 	CloudSelectionScheme() daoselection.SchemeInterface
 	CloudSelectionBizType() daoselection.BizTypeInterface
@@ -141,6 +148,12 @@ type Set interface {
 	TaskDetail() task.Detail
 	TaskManagement() task.Management
 	GlobalConfig() globalconfig.Interface
+	SGRuleTemplate() sgruletpl.Interface
+	SGRuleTemplateRel() sgruletpl.RelInterface
+	ResourceTag() restag.Interface
+	BizAssignRule() bizassign.Interface
+	DiskSnapshot() disksnapshot.Interface
+	KeyPair() keypair.Interface
 
 	Txn() *Txn
 }
@@ -273,6 +286,14 @@ func (s *set) AccountSyncDetail() daosync.AccountSyncDetail {
 	}
 }
 
+// SyncRunRecord return SyncRunRecord dao.
+func (s *set) SyncRunRecord() daosync.SyncRunRecord {
+	return &daosync.SyncRunRecordDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // AzureRegion return AzureRegion dao.
 func (s *set) AzureRegion() region.AzureRegion {
 	return &region.AzureRegionDao{
@@ -615,6 +636,14 @@ func (s *set) AsyncFlowTask() daoasync.AsyncFlowTask {
 	}
 }
 
+// AsyncFlowTaskEvent return AsyncFlowTaskEvent dao.
+func (s *set) AsyncFlowTaskEvent() daoasync.AsyncFlowTaskEvent {
+	return &daoasync.AsyncFlowTaskEventDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // CloudSelectionScheme returns cloud selection scheme dao.
 func (s *set) CloudSelectionScheme() daoselection.SchemeInterface {
 	return &daoselection.SchemeDao{
@@ -771,3 +800,53 @@ func (s *set) GlobalConfig() globalconfig.Interface {
 		IDGen: s.idGen,
 	}
 }
+
+// SGRuleTemplate return security group rule template dao.
+func (s *set) SGRuleTemplate() sgruletpl.Interface {
+	return &sgruletpl.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// SGRuleTemplateRel return security group rule template rel dao.
+func (s *set) SGRuleTemplateRel() sgruletpl.RelInterface {
+	return &sgruletpl.RelDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ResourceTag return resource tag dao.
+func (s *set) ResourceTag() restag.Interface {
+	return &restag.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// BizAssignRule return biz assign rule dao.
+func (s *set) BizAssignRule() bizassign.Interface {
+	return &bizassign.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// DiskSnapshot return disk snapshot dao.
+func (s *set) DiskSnapshot() disksnapshot.Interface {
+	return &disksnapshot.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// KeyPair return key pair dao.
+func (s *set) KeyPair() keypair.Interface {
+	return &keypair.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
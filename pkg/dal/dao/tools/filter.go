@@ -22,6 +22,8 @@ package tools
 import (
 	"fmt"
 
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/runtime/filter"
 )
 
@@ -70,6 +72,14 @@ var DefaultSqlWhereOption = &filter.SQLWhereOption{
 	Priority: filter.Priority{"id"},
 }
 
+// TagSqlWhereOption define sql where option which supports tag operators of the resource type.
+func TagSqlWhereOption(resType enumor.CloudResourceType) *filter.SQLWhereOption {
+	return &filter.SQLWhereOption{
+		Priority:  filter.Priority{"id"},
+		TagOption: &filter.TagOption{Table: string(table.ResourceTagTable), ResType: string(resType)},
+	}
+}
+
 // And merge expressions using 'and' operation.
 func And(rules ...filter.RuleFactory) (*filter.Expression, error) {
 	if len(rules) == 0 {
@@ -167,6 +177,21 @@ func RuleJSONContains[T any](fieldName string, values T) *filter.AtomRule {
 	return &filter.AtomRule{Field: fieldName, Op: filter.JSONContains.Factory(), Value: values}
 }
 
+// RuleTagEqual 生成资源标签等于查询的AtomRule，即资源标签tagKey的值为value
+func RuleTagEqual(tagKey string, value string) *filter.AtomRule {
+	return &filter.AtomRule{Field: filter.TagFieldPrefix + tagKey, Op: filter.TagEqual.Factory(), Value: value}
+}
+
+// RuleTagIn 生成资源标签值包含查询的AtomRule，即资源标签tagKey的值 in values
+func RuleTagIn(tagKey string, values []string) *filter.AtomRule {
+	return &filter.AtomRule{Field: filter.TagFieldPrefix + tagKey, Op: filter.TagIn.Factory(), Value: values}
+}
+
+// RuleTagExists 生成资源标签键是否存在查询的AtomRule
+func RuleTagExists(tagKey string, exists bool) *filter.AtomRule {
+	return &filter.AtomRule{Field: filter.TagFieldPrefix + tagKey, Op: filter.TagExists.Factory(), Value: exists}
+}
+
 // ExpressionAnd expression with op and
 func ExpressionAnd(rules ...*filter.AtomRule) *filter.Expression {
 	// for type transformation
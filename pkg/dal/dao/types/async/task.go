@@ -43,3 +43,9 @@ type UpdateTaskInfo struct {
 func (info *UpdateTaskInfo) Validate() error {
 	return validator.Validate.Struct(info)
 }
+
+// ListAsyncFlowTaskEvents list async flow task events.
+type ListAsyncFlowTaskEvents struct {
+	Count   uint64                               `json:"count,omitempty"`
+	Details []tableasync.AsyncFlowTaskEventTable `json:"details,omitempty"`
+}
@@ -28,3 +28,9 @@ type ListAccountSyncDetails struct {
 	Count   uint64                              `json:"count,omitempty"`
 	Details []tablessync.AccountSyncDetailTable `json:"details,omitempty"`
 }
+
+// ListSyncRunRecords list sync run records.
+type ListSyncRunRecords struct {
+	Count   uint64                          `json:"count,omitempty"`
+	Details []tablessync.SyncRunRecordTable `json:"details,omitempty"`
+}
@@ -45,6 +45,7 @@ var ApplicationColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "applicant", NamedC: "applicant", Type: enumor.String},
 	{Column: "content", NamedC: "content", Type: enumor.Json},
 	{Column: "delivery_detail", NamedC: "delivery_detail", Type: enumor.Json},
+	{Column: "approval_detail", NamedC: "approval_detail", Type: enumor.Json},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
 
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
@@ -74,6 +75,8 @@ type ApplicationTable struct {
 	Content types.JsonField `db:"content" json:"content"`
 	// DeliveryDetail 交付细节，主要是包括一些交付资源ID
 	DeliveryDetail types.JsonField `db:"delivery_detail" json:"delivery_detail"`
+	// ApprovalDetail 内置审批引擎的审批进度及操作记录，ITSM单据为空
+	ApprovalDetail types.JsonField `db:"approval_detail" json:"approval_detail"`
 	// Memo 备注或申请理由
 	Memo *string `db:"memo" json:"memo" validate:"omitempty,max=255"`
 
@@ -42,6 +42,9 @@ var ApprovalProcessColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
 	{Column: "managers", NamedC: "managers", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "engine", NamedC: "engine", Type: enumor.String},
+	{Column: "stages", NamedC: "stages", Type: enumor.Json},
 }
 
 // ApprovalProcessTable 审批流程表
@@ -50,8 +53,8 @@ type ApprovalProcessTable struct {
 	ID string `db:"id" json:"id" validate:"max=64"`
 	// ApplicationType 申请类型（新增账号、新增CVM等）
 	ApplicationType string `db:"application_type" json:"application_type" validate:"max=64"`
-	// ServiceID ITSM流程的服务ID
-	ServiceID int64 `db:"service_id" json:"service_id" validate:"min=1"`
+	// ServiceID ITSM流程的服务ID，内置审批引擎不需要
+	ServiceID int64 `db:"service_id" json:"service_id" validate:"min=0"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator" validate:"max=64"`
 	// Reviser 更新者
@@ -62,6 +65,12 @@ type ApprovalProcessTable struct {
 	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
 	// Managers 审批人
 	Managers string `db:"managers" json:"managers" validate:"max=255"`
+	// BkBizID 业务ID，-1表示对所有业务生效的默认流程
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Engine 审批引擎，itsm 或 native
+	Engine string `db:"engine" json:"engine" validate:"max=16"`
+	// Stages 内置审批引擎的多级审批节点配置
+	Stages types.JsonField `db:"stages" json:"stages"`
 }
 
 // TableName return approval process table name.
@@ -83,10 +92,18 @@ func (a ApprovalProcessTable) InsertValidate() error {
 		return errors.New("application type is required")
 	}
 
-	if a.ServiceID <= 0 {
+	if err := enumor.ApprovalEngine(a.Engine).Validate(); err != nil {
+		return err
+	}
+
+	if a.Engine == string(enumor.ApprovalEngineItsm) && a.ServiceID <= 0 {
 		return errors.New("service id should be gt 0")
 	}
 
+	if a.Engine == string(enumor.ApprovalEngineNative) && a.Stages.IsEmpty() {
+		return errors.New("stages is required")
+	}
+
 	if len(a.Creator) == 0 {
 		return errors.New("creator is required")
 	}
@@ -95,7 +112,7 @@ func (a ApprovalProcessTable) InsertValidate() error {
 		return errors.New("reviser is required")
 	}
 
-	if len(a.Managers) == 0 {
+	if a.Engine == string(enumor.ApprovalEngineItsm) && len(a.Managers) == 0 {
 		return errors.New("managers is required")
 	}
 
@@ -112,5 +129,11 @@ func (a ApprovalProcessTable) UpdateValidate() error {
 		return errors.New("creator can not update")
 	}
 
+	if len(a.Engine) != 0 {
+		if err := enumor.ApprovalEngine(a.Engine).Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
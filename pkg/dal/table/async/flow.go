@@ -41,6 +41,9 @@ var AsyncFlowTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
 	{Column: "share_data", NamedC: "share_data", Type: enumor.Json},
 	{Column: "worker", NamedC: "worker", Type: enumor.String},
+	{Column: "scheduled_at", NamedC: "scheduled_at", Type: enumor.Time},
+	{Column: "concurrency_key", NamedC: "concurrency_key", Type: enumor.String},
+	{Column: "concurrency_limit", NamedC: "concurrency_limit", Type: enumor.Numeric},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -49,17 +52,20 @@ var AsyncFlowTableColumnDescriptor = utils.ColumnDescriptors{
 
 // AsyncFlowTable define async_flow table.
 type AsyncFlowTable struct {
-	ID        string           `db:"id" json:"id" validate:"lte=64"`
-	Name      enumor.FlowName  `db:"name" json:"name"`
-	State     enumor.FlowState `db:"state" json:"state"`
-	Reason    *Reason          `db:"reason" json:"reason"`
-	ShareData *ShareData       `db:"share_data" json:"share_data"`
-	Memo      string           `db:"memo" json:"memo"`
-	Worker    *string          `db:"worker" json:"worker"`
-	Creator   string           `db:"creator" json:"creator" validate:"lte=64"`
-	Reviser   string           `db:"reviser" json:"reviser" validate:"lte=64"`
-	CreatedAt types.Time       `db:"created_at" json:"created_at" validate:"excluded_unless"`
-	UpdatedAt types.Time       `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+	ID               string           `db:"id" json:"id" validate:"lte=64"`
+	Name             enumor.FlowName  `db:"name" json:"name"`
+	State            enumor.FlowState `db:"state" json:"state"`
+	Reason           *Reason          `db:"reason" json:"reason"`
+	ShareData        *ShareData       `db:"share_data" json:"share_data"`
+	Memo             string           `db:"memo" json:"memo"`
+	Worker           *string          `db:"worker" json:"worker"`
+	ScheduledAt      types.Time       `db:"scheduled_at" json:"scheduled_at"`
+	ConcurrencyKey   string           `db:"concurrency_key" json:"concurrency_key" validate:"lte=128"`
+	ConcurrencyLimit uint             `db:"concurrency_limit" json:"concurrency_limit"`
+	Creator          string           `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser          string           `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt        types.Time       `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt        types.Time       `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
 }
 
 // TableName return async_flow table name.
@@ -22,11 +22,11 @@ package tableasync
 import (
 	"database/sql/driver"
 	"errors"
-	"fmt"
+	"math/rand"
+	"time"
 
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/table/types"
-	"hcm/pkg/tools/retry"
 )
 
 // Retry define retry relation setting.
@@ -42,40 +42,6 @@ func (r Retry) IsEnable() bool {
 	return r.Enable
 }
 
-// Run retry run func.
-func (r Retry) Run(do func() (stop bool, result any, err error)) (result any, err error) {
-	if !r.IsEnable() {
-		return nil, errors.New("retry not enable")
-	}
-
-	rp := retry.NewRetryPolicy(r.Policy.Count, r.Policy.SleepRangeMS)
-	var lastErr error
-	var lastResult any
-	var stop bool
-	for {
-		if rp.RetryCount() >= uint32(r.Policy.Count) {
-			break
-		}
-
-		stop, result, err = do()
-		if stop {
-			// 主动停止
-			return result, err
-		}
-		if err != nil {
-			lastErr = err
-			lastResult = result
-			rp.Sleep()
-			continue
-		}
-
-		return result, nil
-	}
-
-	return lastResult, fmt.Errorf("retry exceed the max number of retryable times: %d, lastErr: %v",
-		r.Policy.Count, lastErr)
-}
-
 // Validate retry.
 func (r Retry) Validate() error {
 	if !r.Enable && r.Policy != nil {
@@ -118,6 +84,31 @@ func (rp RetryPolicy) Validate() error {
 	return validator.Validate.Struct(rp)
 }
 
+// Backoff 计算第 attempt 次失败后的重试等待时间，从 SleepRangeMS[0] 开始指数增长，最大不超过 SleepRangeMS[1]，
+// 并在 [backoff/2, backoff] 之间随机抖动，避免大量任务同时重试。
+func (rp RetryPolicy) Backoff(attempt uint) time.Duration {
+	base, limit := rp.SleepRangeMS[0], rp.SleepRangeMS[1]
+	if limit < base {
+		limit = base
+	}
+
+	backoff := base
+	for i := uint(1); i < attempt && backoff < limit; i++ {
+		backoff *= 2
+	}
+	if backoff > limit {
+		backoff = limit
+	}
+
+	half := backoff / 2
+	jitter := uint(0)
+	if backoff-half > 0 {
+		jitter = uint(rand.Int63n(int64(backoff - half + 1)))
+	}
+
+	return time.Duration(half+jitter) * time.Millisecond
+}
+
 // NewRetryWithPolicy return retry with policy
 func NewRetryWithPolicy(count, sleepMsMin, sleepMsMax uint) *Retry {
 	return &Retry{
@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt uint
+		max     time.Duration
+	}{
+		{name: "first attempt use min", policy: RetryPolicy{SleepRangeMS: [2]uint{100, 1000}}, attempt: 1, max: 100},
+		{name: "zero attempt use min", policy: RetryPolicy{SleepRangeMS: [2]uint{100, 1000}}, attempt: 0, max: 100},
+		{name: "exponential growth", policy: RetryPolicy{SleepRangeMS: [2]uint{100, 1000}}, attempt: 3, max: 400},
+		{name: "limited by max", policy: RetryPolicy{SleepRangeMS: [2]uint{100, 1000}}, attempt: 5, max: 1000},
+		{name: "large attempt", policy: RetryPolicy{SleepRangeMS: [2]uint{100, 1000}}, attempt: 100, max: 1000},
+		{name: "max less than min", policy: RetryPolicy{SleepRangeMS: [2]uint{500, 100}}, attempt: 3, max: 500},
+		{name: "zero range", policy: RetryPolicy{SleepRangeMS: [2]uint{0, 0}}, attempt: 3, max: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			upper := c.max * time.Millisecond
+			lower := c.max / 2 * time.Millisecond
+			seen := make(map[time.Duration]bool)
+			for i := 0; i < 200; i++ {
+				got := c.policy.Backoff(c.attempt)
+				if got < lower || got > upper {
+					t.Fatalf("backoff should be in [%s, %s], got: %s", lower, upper, got)
+				}
+				seen[got] = true
+			}
+
+			// 抖动区间不为空时，多次计算的结果不应完全相同
+			if upper-lower >= 10*time.Millisecond && len(seen) == 1 {
+				t.Errorf("backoff should be jittered in [%s, %s], got only: %v", lower, upper, seen)
+			}
+		})
+	}
+}
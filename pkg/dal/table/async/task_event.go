@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AsyncFlowTaskEventColumns defines all the async_flow_task_event table's columns.
+var AsyncFlowTaskEventColumns = utils.MergeColumns(nil, AsyncFlowTaskEventTableColumnDescriptor)
+
+// AsyncFlowTaskEventTableColumnDescriptor is async_flow_task_event's column descriptors.
+var AsyncFlowTaskEventTableColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "flow_id", NamedC: "flow_id", Type: enumor.String},
+	{Column: "task_id", NamedC: "task_id", Type: enumor.String},
+	{Column: "type", NamedC: "type", Type: enumor.String},
+	{Column: "source_state", NamedC: "source_state", Type: enumor.String},
+	{Column: "target_state", NamedC: "target_state", Type: enumor.String},
+	{Column: "attempt", NamedC: "attempt", Type: enumor.Numeric},
+	{Column: "level", NamedC: "level", Type: enumor.String},
+	{Column: "message", NamedC: "message", Type: enumor.String},
+	{Column: "occurred_at", NamedC: "occurred_at", Type: enumor.Time},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// MaxTaskEventMessageLength 任务事件内容最大长度
+const MaxTaskEventMessageLength = 1024
+
+// AsyncFlowTaskEventTable define async_flow_task_event table.
+type AsyncFlowTaskEventTable struct {
+	ID          string               `db:"id" json:"id" validate:"lte=64"`
+	FlowID      string               `db:"flow_id" json:"flow_id"`
+	TaskID      string               `db:"task_id" json:"task_id"`
+	Type        enumor.TaskEventType `db:"type" json:"type"`
+	SourceState enumor.TaskState     `db:"source_state" json:"source_state"`
+	TargetState enumor.TaskState     `db:"target_state" json:"target_state"`
+	Attempt     uint                 `db:"attempt" json:"attempt"`
+	Level       enumor.TaskLogLevel  `db:"level" json:"level"`
+	Message     string               `db:"message" json:"message" validate:"lte=1024"`
+	OccurredAt  time.Time            `db:"occurred_at" json:"occurred_at"`
+	Creator     string               `db:"creator" json:"creator" validate:"lte=64"`
+	CreatedAt   types.Time           `db:"created_at" json:"created_at" validate:"excluded_unless"`
+}
+
+// TableName return async_flow_task_event table name.
+func (a AsyncFlowTaskEventTable) TableName() table.Name {
+	return table.AsyncFlowTaskEventTable
+}
+
+// InsertValidate async_flow_task_event table when insert.
+func (a AsyncFlowTaskEventTable) InsertValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(a.FlowID) == 0 {
+		return errors.New("flow_id is required")
+	}
+
+	if len(a.TaskID) == 0 {
+		return errors.New("task_id is required")
+	}
+
+	if len(a.Type) == 0 {
+		return errors.New("type is required")
+	}
+
+	if len(a.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
@@ -31,6 +31,33 @@ type Reason struct {
 	PreState string `json:"pre_state,omitempty"`
 	// 改为rollback的次数
 	RollbackCount uint `json:"rollback_count,omitempty"`
+	// NextAttemptAt 任务下一次重试的最早执行时间，为空表示可立即执行
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+	// Attempts 任务最近几次执行失败的记录
+	Attempts []TaskAttempt `json:"attempts,omitempty"`
+}
+
+// MaxTaskAttemptHistory 任务保留的最大执行失败记录数
+const MaxTaskAttemptHistory = 10
+
+// TaskAttempt define task attempt failed record.
+type TaskAttempt struct {
+	// Index 第几次执行，从1开始
+	Index uint `json:"index"`
+	// Class 错误分类，retryable 或 fatal
+	Class string `json:"class"`
+	// Message 错误信息
+	Message string `json:"message"`
+	// FailedAt 执行失败时间
+	FailedAt string `json:"failed_at"`
+}
+
+// AppendAttempt 追加执行失败记录，超过 MaxTaskAttemptHistory 时丢弃最早的记录
+func (d *Reason) AppendAttempt(attempt TaskAttempt) {
+	d.Attempts = append(d.Attempts, attempt)
+	if len(d.Attempts) > MaxTaskAttemptHistory {
+		d.Attempts = d.Attempts[len(d.Attempts)-MaxTaskAttemptHistory:]
+	}
 }
 
 // Scan is used to decode raw message which is read from db into Reason.
@@ -40,6 +40,7 @@ var ArgumentTplTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
 	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
 	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
 	{Column: "type", NamedC: "type", Type: enumor.String},
 	{Column: "templates", NamedC: "templates", Type: enumor.Json},
 	{Column: "group_templates", NamedC: "group_templates", Type: enumor.Json},
@@ -64,6 +65,8 @@ type ArgumentTemplateTable struct {
 	BkBizID int64 `db:"bk_biz_id" validate:"min=-1" json:"bk_biz_id"`
 	// AccountID 账号ID
 	AccountID string `json:"account_id" db:"account_id"`
+	// Region 地域，腾讯云参数模版不区分地域，为空
+	Region string `db:"region" validate:"max=255" json:"region"`
 	// Type 参数模版类型
 	Type enumor.TemplateType `db:"type" json:"type"`
 	// Templates 参数模版的参数数组
@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablebizassign resource auto assign to biz rule table.
+package tablebizassign
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// BizAssignRuleColumns defines all the biz assign rule table's columns.
+var BizAssignRuleColumns = utils.MergeColumns(nil, BizAssignRuleColumnDescriptor)
+
+// BizAssignRuleColumnDescriptor is biz assign rule table column descriptors.
+var BizAssignRuleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "rule", NamedC: "rule", Type: enumor.Json},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "priority", NamedC: "priority", Type: enumor.Numeric},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BizAssignRuleTable define biz assign rule table.
+type BizAssignRuleTable struct {
+	// ID 规则ID
+	ID string `db:"id" validate:"len=0" json:"id"`
+	// Name 规则名称，全局唯一
+	Name string `db:"name" validate:"max=255" json:"name"`
+	// ResType 规则匹配的资源类型
+	ResType enumor.CloudResourceType `db:"res_type" validate:"max=64" json:"res_type"`
+	// Rule 资源匹配规则，格式同资源查询的filter
+	Rule types.JsonField `db:"rule" json:"rule"`
+	// BkBizID 匹配的资源分配到的业务ID
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Priority 优先级，值越小越优先匹配
+	Priority *uint32 `db:"priority" json:"priority"`
+	// Enabled 是否启用
+	Enabled *bool `db:"enabled" validate:"-" json:"enabled"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,max=255" json:"memo"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return biz assign rule table name.
+func (t BizAssignRuleTable) TableName() table.Name {
+	return table.BizAssignRuleTable
+}
+
+// InsertValidate validate biz assign rule table on insert.
+func (t BizAssignRuleTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(t.ResType) == 0 {
+		return errors.New("res_type can not be empty")
+	}
+
+	if len(t.Rule) == 0 {
+		return errors.New("rule can not be empty")
+	}
+
+	if t.BkBizID <= 0 {
+		return errors.New("bk_biz_id should > 0")
+	}
+
+	if t.Priority == nil {
+		return errors.New("priority is required")
+	}
+
+	if t.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate biz assign rule table on update.
+func (t BizAssignRuleTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ResType) != 0 {
+		return errors.New("res_type can not update")
+	}
+
+	if t.BkBizID < 0 {
+		return errors.New("bk_biz_id should > 0")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -63,6 +63,7 @@ var LoadBalancerColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "cloud_expired_time", NamedC: "cloud_expired_time", Type: enumor.String},
 	{Column: "tags", NamedC: "tags", Type: enumor.Json},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "recycle_status", NamedC: "recycle_status", Type: enumor.String},
 
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
@@ -99,6 +100,7 @@ type LoadBalancerTable struct {
 	CloudExpiredTime     string            `db:"cloud_expired_time" json:"cloud_expired_time"`
 	Tags                 types.StringMap   `db:"tags" json:"tags"`
 	Extension            types.JsonField   `db:"extension" json:"extension"`
+	RecycleStatus        string            `db:"recycle_status" json:"recycle_status,omitempty"`
 
 	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
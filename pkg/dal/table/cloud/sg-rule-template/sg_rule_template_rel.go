@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tablesgruletpl
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SGRuleTemplateRelColumns defines all the security group rule template rel table's columns.
+var SGRuleTemplateRelColumns = utils.MergeColumns(nil, SGRuleTemplateRelColumnDescriptor)
+
+// SGRuleTemplateRelColumnDescriptor is security group rule template rel table column descriptors.
+var SGRuleTemplateRelColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "template_id", NamedC: "template_id", Type: enumor.String},
+	{Column: "security_group_id", NamedC: "security_group_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "applied_version", NamedC: "applied_version", Type: enumor.Numeric},
+	{Column: "apply_status", NamedC: "apply_status", Type: enumor.String},
+	{Column: "apply_message", NamedC: "apply_message", Type: enumor.String},
+	{Column: "applied_at", NamedC: "applied_at", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SGRuleTemplateRelTable define security group rule template and security group rel table.
+type SGRuleTemplateRelTable struct {
+	// ID 主键
+	ID string `db:"id" validate:"len=0" json:"id"`
+	// TemplateID 模板ID
+	TemplateID string `db:"template_id" validate:"max=64" json:"template_id"`
+	// SecurityGroupID 安全组ID
+	SecurityGroupID string `db:"security_group_id" validate:"max=64" json:"security_group_id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"max=16" json:"vendor"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"max=64" json:"account_id"`
+	// AppliedVersion 最近一次成功应用的模板版本，0表示未应用
+	AppliedVersion uint64 `db:"applied_version" json:"applied_version"`
+	// ApplyStatus 应用状态
+	ApplyStatus enumor.SGRuleTemplateApplyStatus `db:"apply_status" validate:"max=16" json:"apply_status"`
+	// ApplyMessage 应用失败原因
+	ApplyMessage *string `db:"apply_message" validate:"omitempty,max=1024" json:"apply_message"`
+	// AppliedAt 最近一次应用成功时间
+	AppliedAt string `db:"applied_at" validate:"max=64" json:"applied_at"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return security group rule template rel table name.
+func (t SGRuleTemplateRelTable) TableName() table.Name {
+	return table.SGRuleTemplateRelTable
+}
+
+// InsertValidate validate security group rule template rel table on insert.
+func (t SGRuleTemplateRelTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.TemplateID) == 0 {
+		return errors.New("template_id can not be empty")
+	}
+
+	if len(t.SecurityGroupID) == 0 {
+		return errors.New("security_group_id can not be empty")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor can not be empty")
+	}
+
+	if err := t.ApplyStatus.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate security group rule template rel table on update.
+func (t SGRuleTemplateRelTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.TemplateID) != 0 || len(t.SecurityGroupID) != 0 {
+		return errors.New("template_id and security_group_id can not update")
+	}
+
+	if len(t.ApplyStatus) != 0 {
+		if err := t.ApplyStatus.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
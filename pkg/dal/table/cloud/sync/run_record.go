@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tablessync
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SyncRunRecordColumns defines all the sync_run_record table's columns.
+var SyncRunRecordColumns = utils.MergeColumns(nil, SyncRunRecordColumnDescriptor)
+
+// SyncRunRecordColumnDescriptor is sync_run_record's column descriptors.
+var SyncRunRecordColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "scope", NamedC: "scope", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "added", NamedC: "added", Type: enumor.Numeric},
+	{Column: "updated", NamedC: "updated", Type: enumor.Numeric},
+	{Column: "deleted", NamedC: "deleted", Type: enumor.Numeric},
+	{Column: "failed_reason", NamedC: "failed_reason", Type: enumor.String},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "started_at", NamedC: "started_at", Type: enumor.Time},
+	{Column: "ended_at", NamedC: "ended_at", Type: enumor.Time},
+	{Column: "cost_ms", NamedC: "cost_ms", Type: enumor.Numeric},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// MaxSyncFailedReasonLength 同步失败原因最大长度
+const MaxSyncFailedReasonLength = 1024
+
+// SyncRunRecordTable define sync_run_record table.
+type SyncRunRecordTable struct {
+	ID           string                   `db:"id" json:"id" validate:"lte=64"`
+	Vendor       enumor.Vendor            `db:"vendor" json:"vendor"`
+	AccountID    string                   `db:"account_id" json:"account_id" validate:"lte=64"`
+	Scope        enumor.SyncRunScope      `db:"scope" json:"scope"`
+	Region       string                   `db:"region" json:"region" validate:"lte=255"`
+	ResType      enumor.CloudResourceType `db:"res_type" json:"res_type" validate:"lte=64"`
+	State        enumor.SyncStatus        `db:"state" json:"state"`
+	Added        uint                     `db:"added" json:"added"`
+	Updated      uint                     `db:"updated" json:"updated"`
+	Deleted      uint                     `db:"deleted" json:"deleted"`
+	FailedReason string                   `db:"failed_reason" json:"failed_reason" validate:"lte=1024"`
+	Rid          string                   `db:"rid" json:"rid" validate:"lte=64"`
+	StartedAt    time.Time                `db:"started_at" json:"started_at"`
+	EndedAt      time.Time                `db:"ended_at" json:"ended_at"`
+	CostMS       uint64                   `db:"cost_ms" json:"cost_ms"`
+	Creator      string                   `db:"creator" json:"creator" validate:"lte=64"`
+	CreatedAt    types.Time               `db:"created_at" json:"created_at" validate:"excluded_unless"`
+}
+
+// TableName return sync_run_record table name.
+func (s SyncRunRecordTable) TableName() table.Name {
+	return table.SyncRunRecordTable
+}
+
+// InsertValidate sync_run_record table when insert.
+func (s SyncRunRecordTable) InsertValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(s.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(s.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(s.Scope) == 0 {
+		return errors.New("scope is required")
+	}
+
+	if err := s.State.Validate(); err != nil {
+		return err
+	}
+
+	if len(s.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
@@ -68,6 +68,14 @@ const (
 	AzureSecurityGroupRuleTable = "azure_security_group_rule"
 	// SGNetworkInterfaceRelTable is security group and network interface rel table's name.
 	SGNetworkInterfaceRelTable = "security_group_network_interface_rel"
+	// SGRuleTemplateTable is security group rule template table's name.
+	SGRuleTemplateTable = "security_group_rule_template"
+	// SGRuleTemplateRelTable is security group rule template and security group rel table's name.
+	SGRuleTemplateRelTable = "security_group_rule_template_rel"
+	// ResourceTagTable is cloud resource tag table's name.
+	ResourceTagTable Name = "resource_tag"
+	// BizAssignRuleTable is resource auto assign to biz rule table's name.
+	BizAssignRuleTable Name = "biz_assign_rule"
 	// GcpFirewallRuleTable is gcp firewall rule table's name.
 	GcpFirewallRuleTable = "gcp_firewall_rule"
 	// VpcTable is vpc table's name.
@@ -82,6 +90,10 @@ const (
 	AzureRegionTable Name = "azure_region"
 	// DiskTable is disk table's name.
 	DiskTable Name = "disk"
+	// DiskSnapshotTable is disk snapshot table's name.
+	DiskSnapshotTable Name = "disk_snapshot"
+	// KeyPairTable is key pair table's name.
+	KeyPairTable Name = "key_pair"
 	// TCloudRegionTable is tcloud region table's name.
 	TCloudRegionTable Name = "tcloud_region"
 	// AwsRegionTable is aws region table's name.
@@ -115,6 +127,8 @@ const (
 
 	// AccountSyncDetailTable is account_sync_detail table's name.
 	AccountSyncDetailTable Name = "account_sync_detail"
+	// SyncRunRecordTable is sync_run_record table's name.
+	SyncRunRecordTable Name = "sync_run_record"
 
 	// ApplicationTable is application table name
 	ApplicationTable Name = "application"
@@ -138,6 +152,8 @@ const (
 	AsyncFlowTable Name = "async_flow"
 	// AsyncFlowTaskTable is async flow task table's name.
 	AsyncFlowTaskTable Name = "async_flow_task"
+	// AsyncFlowTaskEventTable is async flow task event table's name.
+	AsyncFlowTaskEventTable Name = "async_flow_task_event"
 
 	// CloudSelectionSchemeTable is cloud selection scheme table's name.
 	CloudSelectionSchemeTable Name = "cloud_selection_scheme"
@@ -239,6 +255,10 @@ var TableMap = map[Name]struct{}{
 	HuaWeiSecurityGroupRuleTable: {},
 	AzureSecurityGroupRuleTable:  {},
 	SGNetworkInterfaceRelTable:   {},
+	SGRuleTemplateTable:          {},
+	SGRuleTemplateRelTable:       {},
+	ResourceTagTable:             {},
+	BizAssignRuleTable:           {},
 	GcpFirewallRuleTable:         {},
 	HuaWeiRegionTable:            {},
 	AzureRGTable:                 {},
@@ -261,12 +281,15 @@ var TableMap = map[Name]struct{}{
 	RecycleRecordTable:           {},
 	EipTable:                     {},
 	DiskTable:                    {},
+	DiskSnapshotTable:            {},
+	KeyPairTable:                 {},
 	ImageTable:                   {},
 	DiskCvmRelTableName:          {},
 	EipCvmRelTableName:           {},
 	AccountBillConfigTable:       {},
 	UserCollectionTable:          {},
 	AccountSyncDetailTable:       {},
+	SyncRunRecordTable:           {},
 	CloudSelectionSchemeTable:    {},
 	CloudSelectionBizTypeTable:   {},
 	CloudSelectionIdcTable:       {},
@@ -275,8 +298,9 @@ var TableMap = map[Name]struct{}{
 	// TODO: 临时方案
 	RecycleRecordTableTaskID: {},
 
-	AsyncFlowTable:     {},
-	AsyncFlowTaskTable: {},
+	AsyncFlowTable:          {},
+	AsyncFlowTaskTable:      {},
+	AsyncFlowTaskEventTable: {},
 
 	ArgumentTemplateTable: {},
 
@@ -24,7 +24,7 @@ import (
 	"fmt"
 	"time"
 
-	"hcm/pkg/tools/json"
+	"hcm/pkg/criteria/constant"
 )
 
 // Time ISO 8610时间格式的时间
@@ -51,11 +51,16 @@ func (t *Time) Scan(raw interface{}) error {
 	}
 }
 
-// Value encode the Time to a json raw, so that it can be stored to db with json raw.
+// Value parse the Time to time.Time, so that it can be stored to db with timestamp column.
 func (t Time) Value() (driver.Value, error) {
 	if len(t) == 0 {
 		return "", nil
 	}
 
-	return json.Marshal(t)
+	parsed, err := time.Parse(constant.TimeStdFormat, string(t))
+	if err != nil {
+		return nil, fmt.Errorf("time: %s is invalid, err: %v", t, err)
+	}
+
+	return parsed, nil
 }
@@ -39,6 +39,10 @@ const (
 	Subnet ResourceType = "subnet"
 	// Disk defines disk hcm auth resource type
 	Disk ResourceType = "disk"
+	// DiskSnapshot defines disk snapshot hcm auth resource type
+	DiskSnapshot ResourceType = "disk_snapshot"
+	// KeyPair defines ssh key pair hcm auth resource type
+	KeyPair ResourceType = "key_pair"
 	// SecurityGroup defines security group's hcm auth resource type
 	SecurityGroup ResourceType = "security_group"
 	// SecurityGroupRule defines security group rule's hcm auth resource type
@@ -81,6 +85,12 @@ const (
 	CloudSelectionDataSource ResourceType = "cloud_selection_data_source"
 	// ArgumentTemplate 参数模版
 	ArgumentTemplate ResourceType = "argument_template"
+	// SGRuleTemplate 安全组规则模板
+	SGRuleTemplate ResourceType = "security_group_rule_template"
+	// BizAssignRule 资源自动分配业务规则
+	BizAssignRule ResourceType = "biz_assign_rule"
+	// SubnetReservedCidr 业务预留子网网段
+	SubnetReservedCidr ResourceType = "subnet_reserved_cidr"
 	// Cert defines cert hcm auth resource type
 	Cert ResourceType = "cert"
 	// LoadBalancer defines clb hcm auth resource type
@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// credentialCacheMetric is used to collect account credential cache metrics.
+var credentialCacheMetric *credentialMetric
+
+// InitCredentialCacheMetrics init account credential cache metrics.
+func InitCredentialCacheMetrics(reg prometheus.Registerer) {
+	m := new(credentialMetric)
+
+	m.requestCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: CredentialCacheSubSys,
+		Name:      "requests_total",
+		Help:      "the total count of account credential cache lookups, grouped by hit or miss",
+	}, []string{"vendor", "result"})
+	reg.MustRegister(m.requestCounter)
+
+	m.fetchSec = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: CredentialCacheSubSys,
+		Name:      "fetch_seconds",
+		Help:      "the cost seconds to fetch account credential from data-service on cache miss",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 5},
+	}, []string{"vendor", "state"})
+	reg.MustRegister(m.fetchSec)
+
+	m.invalidateCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: CredentialCacheSubSys,
+		Name:      "invalidations_total",
+		Help:      "the total count of account credential cache entries removed, grouped by reason",
+	}, []string{"reason"})
+	reg.MustRegister(m.invalidateCounter)
+
+	credentialCacheMetric = m
+}
+
+type credentialMetric struct {
+	// requestCounter record the count of cache lookups, hit rate = hit / (hit + miss).
+	requestCounter *prometheus.CounterVec
+
+	// fetchSec record the cost time of fetching credential on cache miss.
+	fetchSec *prometheus.HistogramVec
+
+	// invalidateCounter record the count of entries removed by invalidation, expiration or eviction.
+	invalidateCounter *prometheus.CounterVec
+}
+
+// ObserveCredentialCacheLookup 记录凭证缓存查询是否命中，未初始化指标时忽略
+func ObserveCredentialCacheLookup(vendor string, hit bool) {
+	if credentialCacheMetric == nil {
+		return
+	}
+
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	credentialCacheMetric.requestCounter.With(prometheus.Labels{"vendor": vendor, "result": result}).Inc()
+}
+
+// ObserveCredentialFetch 记录缓存未命中时获取凭证的耗时，未初始化指标时忽略
+func ObserveCredentialFetch(vendor string, cost time.Duration, err error) {
+	if credentialCacheMetric == nil {
+		return
+	}
+
+	state := "success"
+	if err != nil {
+		state = "failed"
+	}
+	credentialCacheMetric.fetchSec.With(prometheus.Labels{"vendor": vendor, "state": state}).Observe(cost.Seconds())
+}
+
+// ObserveCredentialInvalidate 记录凭证缓存条目被移除，reason 为 invalidate、expire 或 evict，未初始化指标时忽略
+func ObserveCredentialInvalidate(reason string, count int) {
+	if credentialCacheMetric == nil || count == 0 {
+		return
+	}
+
+	credentialCacheMetric.invalidateCounter.With(prometheus.Labels{"reason": reason}).Add(float64(count))
+}
@@ -65,6 +65,12 @@ const (
 
 	// CloudApiSubSys defines all cloud api related subsystem
 	CloudApiSubSys = "cloudapi"
+
+	// ResSyncSubSys defines all cloud resource sync related subsystem
+	ResSyncSubSys = "res_sync"
+
+	// CredentialCacheSubSys defines account credential cache related subsystem
+	CredentialCacheSubSys = "credential_cache"
 )
 
 // labels
@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// resSyncMetric is used to collect cloud resource sync metrics.
+var resSyncMetric *syncMetric
+
+// InitResSyncMetrics init cloud resource sync metrics.
+func InitResSyncMetrics(reg prometheus.Registerer) {
+	m := new(syncMetric)
+
+	m.costSec = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: ResSyncSubSys,
+		Name:      "cost_seconds",
+		Help:      "the cost seconds of cloud resource sync run",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+	}, []string{"vendor", "scope", "res_type", "state"})
+	reg.MustRegister(m.costSec)
+
+	m.changeCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: ResSyncSubSys,
+		Name:      "changed_total",
+		Help:      "the total count of resources added, updated or deleted by cloud resource sync",
+	}, []string{"vendor", "res_type", "action"})
+	reg.MustRegister(m.changeCounter)
+
+	m.lastRunTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: ResSyncSubSys,
+		Name:      "last_run_timestamp_seconds",
+		Help:      "the unix timestamp of the last cloud resource sync run finished, grouped by state",
+	}, []string{"vendor", "account_id", "scope", "res_type", "state"})
+	reg.MustRegister(m.lastRunTime)
+
+	resSyncMetric = m
+}
+
+type syncMetric struct {
+	// costSec record the cost time of every sync run.
+	costSec *prometheus.HistogramVec
+
+	// changeCounter record the count of resources changed by sync.
+	changeCounter *prometheus.CounterVec
+
+	// lastRunTime record the finished time of the last sync run, used to alert on stalled sync.
+	lastRunTime *prometheus.GaugeVec
+}
+
+// ResSyncObservation 一次资源同步运行的观测数据
+type ResSyncObservation struct {
+	Vendor    string
+	AccountID string
+	Scope     string
+	ResType   string
+	State     string
+	Added     uint
+	Updated   uint
+	Deleted   uint
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// ObserveResSync 记录资源同步运行指标，未初始化指标时忽略
+func ObserveResSync(obs ResSyncObservation) {
+	if resSyncMetric == nil {
+		return
+	}
+
+	resSyncMetric.costSec.With(prometheus.Labels{
+		"vendor":   obs.Vendor,
+		"scope":    obs.Scope,
+		"res_type": obs.ResType,
+		"state":    obs.State,
+	}).Observe(obs.EndedAt.Sub(obs.StartedAt).Seconds())
+
+	changes := map[string]uint{"add": obs.Added, "update": obs.Updated, "delete": obs.Deleted}
+	for action, count := range changes {
+		if count == 0 {
+			continue
+		}
+		resSyncMetric.changeCounter.With(prometheus.Labels{
+			"vendor":   obs.Vendor,
+			"res_type": obs.ResType,
+			"action":   action,
+		}).Add(float64(count))
+	}
+
+	resSyncMetric.lastRunTime.With(prometheus.Labels{
+		"vendor":     obs.Vendor,
+		"account_id": obs.AccountID,
+		"scope":      obs.Scope,
+		"res_type":   obs.ResType,
+		"state":      obs.State,
+	}).Set(float64(obs.EndedAt.Unix()))
+}
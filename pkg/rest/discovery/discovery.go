@@ -46,3 +46,19 @@ type deniedServers struct {
 func (ud deniedServers) GetServers() ([]string, error) {
 	return nil, fmt.Errorf("access to %s server is not allowed", ud.name)
 }
+
+// StaticServers are fixed servers instance which is used to request the specified server nodes,
+// e.g. broadcast a request to all nodes of a service.
+func StaticServers(servers ...string) Interface {
+	return staticServers(servers)
+}
+
+type staticServers []string
+
+// GetServers return the fixed servers.
+func (s staticServers) GetServers() ([]string, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("there is no server can be used")
+	}
+	return s, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"hcm/pkg/kit"
+)
+
+// SecretReEncryptor re-encrypts all the stored secrets with the newest crypto key.
+type SecretReEncryptor interface {
+	ReEncryptSecrets(kt *kit.Kit) (interface{}, error)
+}
+
+const reEncryptSecretUsage = "re-encrypt account, main account and root account secrets, and cvm passwords and " +
+	"account secrets in application content with the newest crypto key"
+
+// WithReEncryptSecret init and returns the re-encrypt secret command, it's used after a new crypto key is added
+// to config, so that the old keys can be removed after all the secrets are re-encrypted.
+func WithReEncryptSecret(encryptor SecretReEncryptor) Cmd {
+	cmd := &secretCmd{
+		encryptor: encryptor,
+		cmd: &Command{
+			Name:    "re-encrypt-secret",
+			Usage:   reEncryptSecretUsage,
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				return encryptor.ReEncryptSecrets(kt)
+			},
+		},
+	}
+
+	return cmd
+}
+
+// secretCmd secret related Cmd.
+type secretCmd struct {
+	cmd       *Command
+	encryptor SecretReEncryptor
+}
+
+// GetCommand get secret related Command.
+func (c *secretCmd) GetCommand() *Command {
+	return c.cmd
+}
+
+// Validate secret related Command.
+func (c *secretCmd) Validate() error {
+	if c.encryptor == nil {
+		return errors.New("secret re-encryptor is not set")
+	}
+
+	return c.cmd.Validate()
+}
@@ -466,8 +466,11 @@ func (ar AtomRule) Validate(opt *ExprOption) error {
 		if !exist {
 			return fmt.Errorf("rule field: %s is not exist in the expr option", ar.Field)
 		}
-		if err := validateFieldValue(ar.Value, typ); err != nil {
-			return fmt.Errorf("invalid %s's value, %v", ar.Field, err)
+		// tag operator's value type is not the field's column type, it is validated by the operator itself.
+		if _, isTagOp := ar.Op.Operator().(TagOperator); !isTagOp {
+			if err := validateFieldValue(ar.Value, typ); err != nil {
+				return fmt.Errorf("invalid %s's value, %v", ar.Field, err)
+			}
 		}
 	}
 	if strings.HasPrefix(string(ar.Op), "id_") && ar.Field != "id" {
@@ -565,6 +568,14 @@ func (ar AtomRule) RuleField() string {
 
 // SQLExprAndValue convert this atom rule to a mysql's sub query expression, and field's value.
 func (ar AtomRule) SQLExprAndValue(opt *SQLWhereOption) (string, map[string]interface{}, error) {
+	if tagOp, isTagOp := ar.Op.Operator().(TagOperator); isTagOp {
+		if opt == nil || opt.TagOption == nil {
+			return "", nil, fmt.Errorf("%s operator is not supported by the resource", ar.Op)
+		}
+
+		return tagOp.TagSQLExprAndValue(opt.TagOption, ar.Field, ar.Value)
+	}
+
 	expr, value, err := ar.Op.Operator().SQLExprAndValue(ar.Field, ar.Value)
 	if err != nil {
 		return "", nil, err
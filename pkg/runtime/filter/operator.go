@@ -65,6 +65,10 @@ func init() {
 	opFactory[JSONContainsPath.Factory()] = JSONContainsPathOp(JSONContainsPath)
 	opFactory[JSONNotContainsPath.Factory()] = JSONNotContainsPathOp(JSONNotContainsPath)
 	opFactory[JSONLength.Factory()] = JSONLengthOp(JSONLength)
+
+	opFactory[TagEqual.Factory()] = TagEqualOp(TagEqual)
+	opFactory[TagIn.Factory()] = TagInOp(TagIn)
+	opFactory[TagExists.Factory()] = TagExistsOp(TagExists)
 }
 
 const (
@@ -164,6 +168,17 @@ const (
 	JSONLength OpType = "json_length"
 )
 
+// 标签操作符通过资源标签表过滤资源，规则字段格式为 "tags.{tag_key}"，
+// 生成SQL时需要 SQLWhereOption 设置 TagOption。
+const (
+	// TagEqual 资源存在该标签键，且标签值等于给定值
+	TagEqual OpType = "tag_eq"
+	// TagIn 资源存在该标签键，且标签值为给定值之一
+	TagIn OpType = "tag_in"
+	// TagExists 值为true时资源存在该标签键，为false时资源不存在该标签键
+	TagExists OpType = "tag_exists"
+)
+
 // OpType defines the operators supported by mysql.
 type OpType string
 
@@ -179,6 +194,8 @@ func (op OpType) Validate() error {
 	case JSONEqual, JSONNotEqual, JSONIn, JSONContains, JSONOverlaps,
 		JSONContainsPath, JSONNotContainsPath, JSONLength:
 
+	case TagEqual, TagIn, TagExists:
+
 	case IDGreaterThan:
 
 	default:
@@ -1064,3 +1081,192 @@ func (op JSONLengthOp) SQLExprAndValue(field string, value interface{}) (string,
 			placeholder: value,
 		}, nil
 }
+
+// TagFieldPrefix is the prefix of tag operator's rule field, the left part is tag key.
+const TagFieldPrefix = "tags" + JSONFieldSeparator
+
+// TagOperator is the operator which filters resources by the tags stored in the resource tag table,
+// it generates sql expression with the TagOption instead of SQLExprAndValue.
+type TagOperator interface {
+	Operator
+	// TagSQLExprAndValue generate tag operator's SQL expression with its field and value.
+	TagSQLExprAndValue(opt *TagOption, field string, value interface{}) (string, map[string]interface{}, error)
+}
+
+// tagSubQuery generate the sub query which selects the resource ids that have the tag key,
+// valueExpr is the condition of tag value, it can be empty.
+func tagSubQuery(opt *TagOption, field string, valueExpr string) (string, map[string]interface{}, error) {
+	if opt == nil {
+		return "", nil, errors.New("tag option is nil")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	if !strings.HasPrefix(field, TagFieldPrefix) || len(field) == len(TagFieldPrefix) {
+		return "", nil, fmt.Errorf("tag operator's field should be like %s{tag_key}, but got %s", TagFieldPrefix,
+			field)
+	}
+
+	resTypePH := fieldPlaceholderName("tag_res_type")
+	keyPH := fieldPlaceholderName("tag_key")
+	expr := fmt.Sprintf(`SELECT res_id FROM %s WHERE res_type = %s%s AND tag_key = %s%s`, opt.Table,
+		SqlPlaceholder, resTypePH, SqlPlaceholder, keyPH)
+	if len(valueExpr) != 0 {
+		expr += " AND " + valueExpr
+	}
+
+	return expr, map[string]interface{}{resTypePH: opt.ResType, keyPH: field[len(TagFieldPrefix):]}, nil
+}
+
+// TagEqualOp is tag equal operator
+type TagEqualOp OpType
+
+// Name is tag equal operator
+func (op TagEqualOp) Name() OpType {
+	return TagEqual
+}
+
+// ValidateValue validate tag equal's value
+func (op TagEqualOp) ValidateValue(v interface{}, _ *ExprOption) error {
+	if reflect.ValueOf(v).Kind() != reflect.String {
+		return errors.New("tag eq operator's value should be a string")
+	}
+
+	return nil
+}
+
+// SQLExprAndValue tag operator can only generate sql expression with tag option.
+func (op TagEqualOp) SQLExprAndValue(_ string, _ interface{}) (string, map[string]interface{}, error) {
+	return "", nil, fmt.Errorf("%s operator requires tag option", TagEqual)
+}
+
+// TagSQLExprAndValue convert this operator's field and value to a mysql's sub query expression.
+func (op TagEqualOp) TagSQLExprAndValue(opt *TagOption, field string, value interface{}) (string,
+	map[string]interface{}, error) {
+
+	if reflect.ValueOf(value).Kind() != reflect.String {
+		return "", nil, errors.New("invalid value field")
+	}
+
+	valuePH := fieldPlaceholderName("tag_value")
+	subQuery, values, err := tagSubQuery(opt, field, fmt.Sprintf(`tag_value = %s%s`, SqlPlaceholder, valuePH))
+	if err != nil {
+		return "", nil, err
+	}
+	values[valuePH] = value
+
+	return fmt.Sprintf(`id IN (%s)`, subQuery), values, nil
+}
+
+// TagInOp is tag in operator
+type TagInOp OpType
+
+// Name is tag in operator
+func (op TagInOp) Name() OpType {
+	return TagIn
+}
+
+// ValidateValue validate tag in's value
+func (op TagInOp) ValidateValue(v interface{}, opt *ExprOption) error {
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Array:
+	case reflect.Slice:
+	default:
+		return errors.New("tag in operator's value should be an array")
+	}
+
+	value := reflect.ValueOf(v)
+	length := value.Len()
+	if length == 0 {
+		return errors.New("invalid tag in operator's value, at least have one element")
+	}
+
+	maxInV := DefaultMaxInLimit
+	if opt != nil && opt.MaxInLimit > 0 {
+		maxInV = opt.MaxInLimit
+	}
+
+	if length > int(maxInV) {
+		return fmt.Errorf("invalid tag in operator's value, at most have %d elements", maxInV)
+	}
+
+	for i := 0; i < length; i++ {
+		if _, ok := value.Index(i).Interface().(string); !ok {
+			return fmt.Errorf("invalid tag in operator's value: %v, each element should be a string",
+				value.Index(i).Interface())
+		}
+	}
+
+	return nil
+}
+
+// SQLExprAndValue tag operator can only generate sql expression with tag option.
+func (op TagInOp) SQLExprAndValue(_ string, _ interface{}) (string, map[string]interface{}, error) {
+	return "", nil, fmt.Errorf("%s operator requires tag option", TagIn)
+}
+
+// TagSQLExprAndValue convert this operator's field and value to a mysql's sub query expression.
+func (op TagInOp) TagSQLExprAndValue(opt *TagOption, field string, value interface{}) (string,
+	map[string]interface{}, error) {
+
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Array:
+	case reflect.Slice:
+	default:
+		return "", nil, errors.New("tag in operator's value should be an array")
+	}
+
+	valuePH := fieldPlaceholderName("tag_value")
+	subQuery, values, err := tagSubQuery(opt, field, fmt.Sprintf(`tag_value IN (%s%s)`, SqlPlaceholder, valuePH))
+	if err != nil {
+		return "", nil, err
+	}
+	values[valuePH] = value
+
+	return fmt.Sprintf(`id IN (%s)`, subQuery), values, nil
+}
+
+// TagExistsOp is tag exists operator
+type TagExistsOp OpType
+
+// Name is tag exists operator
+func (op TagExistsOp) Name() OpType {
+	return TagExists
+}
+
+// ValidateValue validate tag exists's value
+func (op TagExistsOp) ValidateValue(v interface{}, _ *ExprOption) error {
+	if reflect.ValueOf(v).Kind() != reflect.Bool {
+		return errors.New("tag exists operator's value should be a boolean")
+	}
+
+	return nil
+}
+
+// SQLExprAndValue tag operator can only generate sql expression with tag option.
+func (op TagExistsOp) SQLExprAndValue(_ string, _ interface{}) (string, map[string]interface{}, error) {
+	return "", nil, fmt.Errorf("%s operator requires tag option", TagExists)
+}
+
+// TagSQLExprAndValue convert this operator's field and value to a mysql's sub query expression.
+func (op TagExistsOp) TagSQLExprAndValue(opt *TagOption, field string, value interface{}) (string,
+	map[string]interface{}, error) {
+
+	exists, ok := value.(bool)
+	if !ok {
+		return "", nil, errors.New("invalid value field")
+	}
+
+	subQuery, values, err := tagSubQuery(opt, field, "")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if exists {
+		return fmt.Sprintf(`id IN (%s)`, subQuery), values, nil
+	}
+
+	return fmt.Sprintf(`id NOT IN (%s)`, subQuery), values, nil
+}
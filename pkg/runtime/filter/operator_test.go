@@ -20,6 +20,7 @@
 package filter
 
 import (
+	"regexp"
 	"testing"
 )
 
@@ -304,3 +305,51 @@ func TestJSONInSQLExpr(t *testing.T) {
 		}
 	}
 }
+
+func TestTagSQLExpr(t *testing.T) {
+	opt := &TagOption{Table: "resource_tag", ResType: "cvm"}
+	subQuery := `SELECT res_id FROM resource_tag WHERE res_type = :tag_res_type_\w{4} AND tag_key = :tag_key_\w{4}`
+
+	cases := []struct {
+		op     TagOperator
+		value  interface{}
+		expect string
+	}{
+		{op: TagEqualOp(TagEqual), value: "payments",
+			expect: `^id IN \(` + subQuery + ` AND tag_value = :tag_value_\w{4}\)$`},
+		{op: TagInOp(TagIn), value: []string{"a", "b"},
+			expect: `^id IN \(` + subQuery + ` AND tag_value IN \(:tag_value_\w{4}\)\)$`},
+		{op: TagExistsOp(TagExists), value: true, expect: `^id IN \(` + subQuery + `\)$`},
+		{op: TagExistsOp(TagExists), value: false, expect: `^id NOT IN \(` + subQuery + `\)$`},
+	}
+
+	for _, c := range cases {
+		expr, valueMap, err := c.op.TagSQLExprAndValue(opt, "tags.team", c.value)
+		if err != nil {
+			t.Errorf("test %s operator failed, err: %v", c.op.Name(), err)
+			return
+		}
+
+		if !regexp.MustCompile(c.expect).MatchString(expr) {
+			t.Errorf("test %s operator got wrong expr: %s", c.op.Name(), expr)
+			return
+		}
+
+		for key, val := range valueMap {
+			if regexp.MustCompile(`^tag_key_`).MatchString(key) && val != "team" {
+				t.Errorf("test %s operator got wrong tag key: %v", c.op.Name(), val)
+				return
+			}
+		}
+	}
+
+	if _, _, err := TagEqualOp(TagEqual).TagSQLExprAndValue(opt, "name", "payments"); err == nil {
+		t.Errorf("test tag operator with non tag field should fail")
+		return
+	}
+
+	if _, _, err := TagEqualOp(TagEqual).SQLExprAndValue("tags.team", "payments"); err == nil {
+		t.Errorf("test tag operator without tag option should fail")
+		return
+	}
+}
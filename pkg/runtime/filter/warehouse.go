@@ -68,6 +68,30 @@ type SQLWhereOption struct {
 	// field during query.
 	Priority      Priority
 	CrownedOption *CrownedOption
+	// TagOption defines the resource tag table that tag operators query with, expression with tag operators
+	// can not generate SQL expression if it is not set.
+	TagOption *TagOption
+}
+
+// TagOption defines how to generate the tag operators' SQL expression.
+type TagOption struct {
+	// Table is the resource tag table name.
+	Table string
+	// ResType is the resource type of the queried resource table in the resource tag table.
+	ResType string
+}
+
+// Validate the tag option is valid or not.
+func (opt TagOption) Validate() error {
+	if len(opt.Table) == 0 {
+		return errors.New("tag option's table can not be empty")
+	}
+
+	if len(opt.ResType) == 0 {
+		return errors.New("tag option's res type can not be empty")
+	}
+
+	return nil
 }
 
 // Validate the options is valid or not
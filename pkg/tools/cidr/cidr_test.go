@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"net"
 	"testing"
+
+	"hcm/pkg/criteria/enumor"
 )
 
 type NextAvailableNetResult struct {
@@ -183,3 +185,89 @@ func TestNextAvailableNetByIPNum(t *testing.T) {
 
 	}
 }
+
+func TestAvailableNets(t *testing.T) {
+	cases := []struct {
+		outer   string
+		used    []string
+		masklen int
+		limit   int
+		except  []string
+	}{
+		{
+			outer:   "172.0.0.0/24",
+			used:    []string{"172.0.0.0/28", "172.0.0.32/27", "172.0.0.64/29", "172.0.1.0/24"},
+			masklen: 28,
+			limit:   3,
+			except:  []string{"172.0.0.16/28", "172.0.0.80/28", "172.0.0.96/28"},
+		},
+		{
+			outer:   "172.0.0.0/24",
+			used:    []string{"172.0.0.0/25", "172.0.0.64/26", "fd00::/64"},
+			masklen: 26,
+			limit:   5,
+			except:  []string{"172.0.0.128/26", "172.0.0.192/26"},
+		},
+		{
+			outer:   "172.0.0.0/24",
+			used:    []string{"10.0.0.0/8", "172.0.0.0/16"},
+			masklen: 28,
+			limit:   1,
+			except:  []string{},
+		},
+		{
+			outer:   "fd00::/56",
+			used:    []string{"fd00::/64", "fd00:0:0:2::/63", "172.0.0.0/24"},
+			masklen: 64,
+			limit:   2,
+			except:  []string{"fd00:0:0:1::/64", "fd00:0:0:4::/64"},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprint("case-", i), func(t *testing.T) {
+			_, outer, _ := net.ParseCIDR(c.outer)
+			used := make([]net.IPNet, 0, len(c.used))
+			for _, one := range c.used {
+				_, usedNet, _ := net.ParseCIDR(one)
+				used = append(used, *usedNet)
+			}
+
+			nets, err := AvailableNets(*outer, used, c.masklen, c.limit)
+			if err != nil {
+				t.Errorf("available nets failed, err: %v", err)
+				return
+			}
+
+			got := make([]string, 0, len(nets))
+			for _, one := range nets {
+				got = append(got, one.String())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(c.except) {
+				t.Errorf("got %v, except %v", got, c.except)
+			}
+		})
+	}
+}
+
+func TestHostNumToMasklen(t *testing.T) {
+	cases := []struct {
+		ipType enumor.IPAddressType
+		ipNum  uint64
+		except int
+	}{
+		{enumor.Ipv4, 1, 30},
+		{enumor.Ipv4, 5, 29},
+		{enumor.Ipv4, 256, 24},
+		{enumor.Ipv4, 257, 23},
+		{enumor.Ipv6, 1 << 63, 65},
+		{enumor.Ipv6, 3, 126},
+	}
+
+	for _, c := range cases {
+		got, err := HostNumToMasklen(c.ipType, c.ipNum)
+		if err != nil || got != c.except {
+			t.Errorf("%s ip num %d, got %d, err: %v, except %d", c.ipType, c.ipNum, got, err, c.except)
+		}
+	}
+}
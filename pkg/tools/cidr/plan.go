@@ -0,0 +1,176 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cidr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net"
+	"sort"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// IPAddressBits 获取指定ip地址类型的地址位数
+func IPAddressBits(ipType enumor.IPAddressType) (int, error) {
+	switch ipType {
+	case enumor.Ipv4:
+		return net.IPv4len * 8, nil
+	case enumor.Ipv6:
+		return net.IPv6len * 8, nil
+	default:
+		return 0, fmt.Errorf("unsupported ip address type: %s", ipType)
+	}
+}
+
+// HostNumToMasklen 根据所需ip数量计算掩码长度，兼容ipv4与ipv6，ip数量小于4时按4计算
+func HostNumToMasklen(ipType enumor.IPAddressType, ipNum uint64) (int, error) {
+	addrBits, err := IPAddressBits(ipType)
+	if err != nil {
+		return 0, err
+	}
+
+	if ipNum <= 4 {
+		return addrBits - 2, nil
+	}
+
+	// ceil(log2(ipNum))
+	return addrBits - bits.Len64(ipNum-1), nil
+}
+
+// IsOverlapped 判断两个网段是否存在重叠，不同ip地址类型的网段视为不重叠
+func IsOverlapped(a, b net.IPNet) bool {
+	if (a.IP.To4() == nil) != (b.IP.To4() == nil) {
+		return false
+	}
+
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// ipRange 网段对应的地址区间，闭区间
+type ipRange struct {
+	start *big.Int
+	end   *big.Int
+}
+
+func netToRange(n net.IPNet) (ipRange, int) {
+	ip := n.IP.To4()
+	if ip == nil {
+		ip = n.IP.To16()
+	}
+	ones, addrBits := n.Mask.Size()
+
+	start := new(big.Int).SetBytes(ip.Mask(n.Mask))
+	size := new(big.Int).Lsh(big.NewInt(1), uint(addrBits-ones))
+	end := new(big.Int).Sub(new(big.Int).Add(start, size), big.NewInt(1))
+	return ipRange{start: start, end: end}, addrBits
+}
+
+func intToIP(v *big.Int, addrBits int) net.IP {
+	ip := make(net.IP, addrBits/8)
+	return v.FillBytes(ip)
+}
+
+// mergeRanges 合并已使用的地址区间，返回按起始地址升序且互不相交的区间列表
+func mergeRanges(ranges []ipRange) []ipRange {
+	if len(ranges) == 0 {
+		return ranges
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start.Cmp(ranges[j].start) < 0 })
+	merged := []ipRange{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		next := new(big.Int).Add(last.end, big.NewInt(1))
+		if r.start.Cmp(next) > 0 {
+			merged = append(merged, r)
+			continue
+		}
+		if r.end.Cmp(last.end) > 0 {
+			last.end = r.end
+		}
+	}
+	return merged
+}
+
+// AvailableNets 在给定网段中查找可用的子网段，兼容ipv4与ipv6
+// Params:
+// 1. outer: 待分配的网段
+// 2. used: 已经被占用的网段，可以与outer不相交、彼此重叠，与outer地址类型不同的网段会被忽略
+// 3. masklen: 待分配的网段掩码长度
+// 4. limit: 最多返回的可用网段数量
+// 与 NextAvailableNet 只在最后一个已用网段之后分配不同，该方法会从低地址开始复用已用网段之间的空隙。
+func AvailableNets(outer net.IPNet, used []net.IPNet, masklen int, limit int) ([]net.IPNet, error) {
+	outerMasklen, addrBits := outer.Mask.Size()
+	if masklen < outerMasklen {
+		return nil, errors.New("new net mask length is shorter than outer net")
+	}
+	if masklen > addrBits {
+		return nil, fmt.Errorf("new net mask length should be less than or equal to %d", addrBits)
+	}
+	if limit <= 0 {
+		return nil, errors.New("limit should be greater than 0")
+	}
+
+	outerRange, _ := netToRange(outer)
+	usedRanges := make([]ipRange, 0, len(used))
+	for _, one := range used {
+		if !IsOverlapped(outer, one) {
+			continue
+		}
+		r, _ := netToRange(one)
+		usedRanges = append(usedRanges, r)
+	}
+	usedRanges = mergeRanges(usedRanges)
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(addrBits-masklen))
+	mask := net.CIDRMask(masklen, addrBits)
+	result := make([]net.IPNet, 0)
+	cand := new(big.Int).Set(outerRange.start)
+	idx := 0
+	for len(result) < limit {
+		candEnd := new(big.Int).Sub(new(big.Int).Add(cand, size), big.NewInt(1))
+		if candEnd.Cmp(outerRange.end) > 0 {
+			break
+		}
+
+		for idx < len(usedRanges) && usedRanges[idx].end.Cmp(cand) < 0 {
+			idx++
+		}
+
+		if idx < len(usedRanges) && usedRanges[idx].start.Cmp(candEnd) <= 0 {
+			// 与已用网段冲突，跳到该已用网段之后第一个按掩码对齐的地址
+			next := new(big.Int).Add(usedRanges[idx].end, big.NewInt(1))
+			rem := new(big.Int).Mod(next, size)
+			if rem.Sign() != 0 {
+				next.Add(next, new(big.Int).Sub(size, rem))
+			}
+			cand = next
+			continue
+		}
+
+		result = append(result, net.IPNet{IP: intToIP(cand, addrBits), Mask: mask})
+		cand = new(big.Int).Add(cand, size)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,223 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cron 标准五段式 cron 表达式解析
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 预定义的 cron 表达式
+var descriptors = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+type bounds struct {
+	min, max uint
+}
+
+var (
+	minuteBound = bounds{0, 59}
+	hourBound   = bounds{0, 23}
+	domBound    = bounds{1, 31}
+	monthBound  = bounds{1, 12}
+	// 星期取值 0-7，0 和 7 都表示周日
+	dowBound = bounds{0, 7}
+)
+
+// Schedule 解析后的 cron 表达式，每个字段用位图表示允许的取值。
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar, dowStar 日期和星期字段是否为 *，二者均有限制时满足其一即可
+	domStar, dowStar bool
+}
+
+// Parse 解析五段式 cron 表达式: 分 时 日 月 周，支持 *、*/n、a-b、a-b/n、a,b 以及 @daily 等预定义表达式。
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if desc, exist := descriptors[spec]; exist {
+		spec = desc
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron spec: %s should have 5 fields, but got %d", spec, len(fields))
+	}
+
+	sch := new(Schedule)
+	var err error
+	if sch.minute, err = parseField(fields[0], minuteBound); err != nil {
+		return nil, fmt.Errorf("parse minute field failed, err: %v", err)
+	}
+
+	if sch.hour, err = parseField(fields[1], hourBound); err != nil {
+		return nil, fmt.Errorf("parse hour field failed, err: %v", err)
+	}
+
+	if sch.dom, err = parseField(fields[2], domBound); err != nil {
+		return nil, fmt.Errorf("parse day of month field failed, err: %v", err)
+	}
+
+	if sch.month, err = parseField(fields[3], monthBound); err != nil {
+		return nil, fmt.Errorf("parse month field failed, err: %v", err)
+	}
+
+	if sch.dow, err = parseField(fields[4], dowBound); err != nil {
+		return nil, fmt.Errorf("parse day of week field failed, err: %v", err)
+	}
+	// 7 和 0 都表示周日
+	if sch.dow&(1<<7) != 0 {
+		sch.dow |= 1
+	}
+
+	sch.domStar = strings.HasPrefix(fields[2], "*")
+	sch.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return sch, nil
+}
+
+// parseField 解析单个字段，字段内多个取值以逗号分隔
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		one, err := parseRange(expr, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= one
+	}
+
+	return bits, nil
+}
+
+// parseRange 解析 *、*/n、a、a-b、a-b/n 形式的表达式
+func parseRange(expr string, b bounds) (uint64, error) {
+	rangeExpr, step := expr, uint(1)
+	if idx := strings.Index(expr, "/"); idx >= 0 {
+		val, err := strconv.ParseUint(expr[idx+1:], 10, 8)
+		if err != nil || val == 0 {
+			return 0, fmt.Errorf("invalid step in %s", expr)
+		}
+		rangeExpr, step = expr[:idx], uint(val)
+	}
+
+	start, end := b.min, b.max
+	switch {
+	case rangeExpr == "*":
+	case strings.Contains(rangeExpr, "-"):
+		parts := strings.SplitN(rangeExpr, "-", 2)
+		var err error
+		if start, err = parseValue(parts[0], b); err != nil {
+			return 0, err
+		}
+
+		if end, err = parseValue(parts[1], b); err != nil {
+			return 0, err
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("invalid range %s, start > end", expr)
+		}
+	default:
+		val, err := parseValue(rangeExpr, b)
+		if err != nil {
+			return 0, err
+		}
+		start = val
+		// 单个值带步长时，从该值开始直到最大值
+		if step == 1 {
+			end = val
+		}
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+
+	return bits, nil
+}
+
+func parseValue(val string, b bounds) (uint, error) {
+	num, err := strconv.ParseUint(val, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %s", val)
+	}
+
+	if uint(num) < b.min || uint(num) > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", num, b.min, b.max)
+	}
+
+	return uint(num), nil
+}
+
+// 查找下一次执行时间的最大年份跨度，防止 2月30日 这类永远无法满足的表达式死循环
+const maxSearchYears = 5
+
+// Next 返回严格晚于 t 的下一次执行时间，精确到分钟，时区与 t 相同。如果找不到满足条件的时间，返回零值。
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + maxSearchYears
+
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches 日期和星期字段均有限制时，满足其一即可，否则需要同时满足
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
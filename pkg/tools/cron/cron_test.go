@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleNext(t *testing.T) {
+	base := time.Date(2025, 1, 15, 10, 30, 20, 0, time.UTC)
+	testCases := []struct {
+		spec   string
+		expect time.Time
+	}{
+		{spec: "* * * * *", expect: time.Date(2025, 1, 15, 10, 31, 0, 0, time.UTC)},
+		{spec: "*/15 * * * *", expect: time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{spec: "0 2 * * *", expect: time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC)},
+		{spec: "@hourly", expect: time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{spec: "0 0 1 * *", expect: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "30 9 * * 1-5", expect: time.Date(2025, 1, 16, 9, 30, 0, 0, time.UTC)},
+		// 2025-01-19 是周日
+		{spec: "0 0 * * 7", expect: time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		// 日期和星期均有限制时满足其一即可
+		{spec: "0 0 20 * 6", expect: time.Date(2025, 1, 18, 0, 0, 0, 0, time.UTC)},
+		{spec: "5,35 10 15 1 *", expect: time.Date(2025, 1, 15, 10, 35, 0, 0, time.UTC)},
+		{spec: "0 0 29 2 *", expect: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, one := range testCases {
+		sch, err := Parse(one.spec)
+		assert.NoError(t, err, one.spec)
+		assert.Equal(t, one.expect, sch.Next(base), one.spec)
+	}
+
+	sch, err := Parse("0 0 30 2 *")
+	assert.NoError(t, err)
+	assert.True(t, sch.Next(base).IsZero())
+}
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"*/0 * * * *", "5-1 * * * *", "a * * * *"}
+	for _, spec := range specs {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
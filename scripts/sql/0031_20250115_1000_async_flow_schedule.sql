@@ -0,0 +1,39 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0031,HCMVER=v1.7.3
+
+    Notes:
+    1. 修改`async_flow`表，增加`scheduled_at`字段，任务流在该时间之前不会被派发执行
+*/
+
+START TRANSACTION;
+
+alter table async_flow
+    add scheduled_at timestamp not null default current_timestamp after worker;
+update async_flow
+set scheduled_at = created_at;
+alter table async_flow
+    add index idx_state_scheduled_at (state, scheduled_at);
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.3' as `hcm_ver`, '0031' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,39 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0032,HCMVER=v1.7.3
+
+    Notes:
+    1. 修改`async_flow`表，增加`concurrency_key`、`concurrency_limit`字段，用于限制相同互斥键的任务流并发执行数量
+*/
+
+START TRANSACTION;
+
+alter table async_flow
+    add concurrency_key varchar(128) not null default '' after scheduled_at;
+alter table async_flow
+    add concurrency_limit int unsigned not null default 0 after concurrency_key;
+alter table async_flow
+    add index idx_concurrency_key_state (concurrency_key, state);
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.3' as `hcm_ver`, '0032' as `sql_ver`;
+
+COMMIT;
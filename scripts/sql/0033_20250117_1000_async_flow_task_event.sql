@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0033,HCMVER=v1.7.3
+
+    Notes:
+    1. 添加异步任务事件表 async_flow_task_event，记录任务状态流转和执行日志
+*/
+
+START TRANSACTION;
+
+--  1. 异步任务事件表
+create table if not exists `async_flow_task_event`
+(
+    `id`           varchar(64)   not null comment '主键',
+    `flow_id`      varchar(64)   not null comment '任务流ID',
+    `task_id`      varchar(64)   not null comment '任务ID',
+    `type`         varchar(16)   not null comment '事件类型，state: 状态流转，log: 执行日志',
+    `source_state` varchar(16)            default '' comment '原状态',
+    `target_state` varchar(16)            default '' comment '目标状态',
+    `attempt`      int unsigned  not null default 0 comment '第几次执行',
+    `level`        varchar(16)            default '' comment '日志级别',
+    `message`      varchar(1024)          default '' comment '事件内容',
+    `occurred_at`  timestamp(3)  not null default current_timestamp(3) comment '发生时间',
+    `creator`      varchar(64)   not null comment '创建者',
+    `created_at`   timestamp     not null default current_timestamp comment '创建时间',
+    primary key (`id`),
+    key `idx_flow_id` (`flow_id`),
+    key `idx_task_id` (`task_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='异步任务事件表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('async_flow_task_event', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.3' as `hcm_ver`, '0033' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0034,HCMVER=v1.7.3
+
+    Notes:
+    1. 添加资源同步运行记录表 sync_run_record，记录每次同步的变更数量、耗时及失败原因
+*/
+
+START TRANSACTION;
+
+--  1. 资源同步运行记录表
+create table if not exists `sync_run_record`
+(
+    `id`            varchar(64)     not null comment '主键',
+    `vendor`        varchar(16)     not null comment '云厂商',
+    `account_id`    varchar(64)     not null comment '账号ID',
+    `scope`         varchar(16)     not null comment '同步粒度，account: 账号全量同步，resource: 单个资源同步',
+    `region`        varchar(255)             default '' comment '地域或资源组',
+    `res_type`      varchar(64)              default '' comment '资源类型，账号粒度记录为空',
+    `state`         varchar(16)     not null comment '同步结果',
+    `added`         int unsigned    not null default 0 comment '新增资源数量',
+    `updated`       int unsigned    not null default 0 comment '更新资源数量',
+    `deleted`       int unsigned    not null default 0 comment '删除资源数量',
+    `failed_reason` varchar(1024)            default '' comment '失败原因',
+    `rid`           varchar(64)              default '' comment '请求ID',
+    `started_at`    timestamp(3)    not null default current_timestamp(3) comment '开始时间',
+    `ended_at`      timestamp(3)    not null default current_timestamp(3) comment '结束时间',
+    `cost_ms`       bigint unsigned not null default 0 comment '耗时（毫秒）',
+    `creator`       varchar(64)     not null comment '创建者',
+    `created_at`    timestamp       not null default current_timestamp comment '创建时间',
+    primary key (`id`),
+    key `idx_account_id_res_type` (`account_id`, `res_type`),
+    key `idx_started_at` (`started_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源同步运行记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('sync_run_record', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.3' as `hcm_ver`, '0034' as `sql_ver`;
+
+COMMIT;
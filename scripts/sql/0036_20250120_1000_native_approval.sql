@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+
+/*
+    SQLVER=0036,HCMVER=v1.7.3
+
+    Notes:
+    1. 审批流程表 approval_process 添加业务ID、审批引擎及审批节点字段，支持按业务配置hcm内置审批流程
+    2. 申请单表 application 添加审批详情字段，记录内置审批引擎的审批进度及操作记录
+*/
+
+START TRANSACTION;
+
+--  1. 审批流程表支持按业务及申请类型配置内置审批流程
+alter table approval_process
+    add column `bk_biz_id` bigint      not null default -1 comment '业务ID，-1表示对所有业务生效' after `application_type`,
+    add column `engine`    varchar(16) not null default 'itsm' comment '审批引擎，itsm 或 native' after `bk_biz_id`,
+    add column `stages`    json        default null comment '内置审批引擎的审批节点' after `managers`;
+
+alter table approval_process
+    drop index `idx_uk_type`,
+    add unique key `idx_uk_type_bk_biz_id` (`application_type`, `bk_biz_id`);
+
+--  2. 申请单表添加审批详情字段
+alter table application
+    add column `approval_detail` json default null comment '内置审批引擎的审批进度及操作记录' after `delivery_detail`;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.3' as `hcm_ver`, '0036' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0038,HCMVER=v1.7.3
+
+    Notes:
+    1. 添加安全组规则模板表 security_group_rule_template
+    2. 添加安全组规则模板与安全组绑定关系表 security_group_rule_template_rel
+*/
+
+START TRANSACTION;
+
+--  1. 安全组规则模板表
+create table if not exists `security_group_rule_template`
+(
+    `id`         varchar(64)     not null comment '主键',
+    `name`       varchar(255)    not null comment '模板名称',
+    `bk_biz_id`  bigint          not null comment '业务ID',
+    `rules`      json            not null comment '规则列表',
+    `version`    bigint unsigned not null default 1 comment '模板版本，每次修改规则后递增',
+    `memo`       varchar(255)             default '' comment '备注',
+    `creator`    varchar(64)     not null comment '创建者',
+    `reviser`    varchar(64)     not null comment '更新者',
+    `created_at` timestamp       not null default current_timestamp comment '创建时间',
+    `updated_at` timestamp       not null default current_timestamp on update current_timestamp comment '更新时间',
+    primary key (`id`),
+    unique key `idx_uk_bk_biz_id_name` (`bk_biz_id`, `name`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='安全组规则模板表';
+
+--  2. 安全组规则模板与安全组绑定关系表
+create table if not exists `security_group_rule_template_rel`
+(
+    `id`                varchar(64)     not null comment '主键',
+    `template_id`       varchar(64)     not null comment '模板ID',
+    `security_group_id` varchar(64)     not null comment '安全组ID',
+    `vendor`            varchar(16)     not null comment '云厂商',
+    `account_id`        varchar(64)     not null comment '账号ID',
+    `applied_version`   bigint unsigned not null default 0 comment '最近一次成功应用的模板版本，0表示未应用',
+    `apply_status`      varchar(16)     not null default 'pending' comment '应用状态',
+    `apply_message`     varchar(1024)            default '' comment '应用失败原因',
+    `applied_at`        varchar(64)              default '' comment '最近一次应用成功时间',
+    `creator`           varchar(64)     not null comment '创建者',
+    `reviser`           varchar(64)     not null comment '更新者',
+    `created_at`        timestamp       not null default current_timestamp comment '创建时间',
+    `updated_at`        timestamp       not null default current_timestamp on update current_timestamp comment '更新时间',
+    primary key (`id`),
+    unique key `idx_uk_template_id_security_group_id` (`template_id`, `security_group_id`),
+    key `idx_security_group_id` (`security_group_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='安全组规则模板与安全组绑定关系表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('security_group_rule_template', '0'),
+       ('security_group_rule_template_rel', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.3' as `hcm_ver`, '0038' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0040,HCMVER=v1.7.3
+
+    Notes:
+    1. 添加资源自动分配业务规则表 biz_assign_rule
+*/
+
+START TRANSACTION;
+
+--  1. 资源自动分配业务规则表
+create table if not exists `biz_assign_rule`
+(
+    `id`         varchar(64)  not null comment '主键',
+    `name`       varchar(255) not null comment '规则名称',
+    `res_type`   varchar(64)  not null comment '资源类型',
+    `rule`       json         not null comment '资源匹配规则，格式同资源查询的filter，支持标签查询',
+    `bk_biz_id`  bigint       not null comment '匹配的资源分配到的业务ID',
+    `priority`   int unsigned not null default 0 comment '优先级，值越小越优先匹配',
+    `enabled`    boolean               default true comment '是否启用',
+    `memo`       varchar(255)          default '' comment '备注',
+    `creator`    varchar(64)  not null comment '创建者',
+    `reviser`    varchar(64)  not null comment '更新者',
+    `created_at` timestamp    not null default current_timestamp comment '创建时间',
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp comment '更新时间',
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`),
+    key `idx_res_type_priority` (`res_type`, `priority`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源自动分配业务规则表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('biz_assign_rule', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.3' as `hcm_ver`, '0040' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0041,HCMVER=v1.7.3
+
+    Notes:
+    1. 添加云硬盘快照表 disk_snapshot
+*/
+
+START TRANSACTION;
+
+--  1. 云硬盘快照表
+create table if not exists `disk_snapshot`
+(
+    `id`             varchar(64)        not null comment '主键',
+    `vendor`         varchar(16)        not null comment '云厂商',
+    `account_id`     varchar(64)        not null comment '账号ID',
+    `cloud_id`       varchar(255)       not null comment '云上快照ID',
+    `bk_biz_id`      bigint             not null default -1 comment '业务ID，-1表示未分配',
+    `name`           varchar(255)                default '' comment '快照名称',
+    `region`         varchar(128)                default '' comment '地域',
+    `zone`           varchar(128)                default '' comment '可用区',
+    `disk_id`        varchar(64)                 default '' comment '源云盘ID',
+    `cloud_disk_id`  varchar(255)       not null comment '源云盘云上ID',
+    `disk_size`      bigint(1) unsigned not null default 0 comment '源云盘大小，单位GB',
+    `status`         varchar(128)                default '' comment '快照状态',
+    `recycle_status` varchar(32)                 default '' comment '回收状态',
+    `memo`           varchar(255)                default '' comment '备注',
+    `extension`      json               not null comment '云厂商差异扩展字段',
+    `creator`        varchar(64)        not null comment '创建者',
+    `reviser`        varchar(64)        not null comment '更新者',
+    `created_at`     timestamp          not null default current_timestamp comment '创建时间',
+    `updated_at`     timestamp          not null default current_timestamp on update current_timestamp comment '更新时间',
+    primary key (`id`),
+    unique key `idx_uk_cloud_id_vendor` (`cloud_id`, `vendor`),
+    key `idx_disk_id` (`disk_id`),
+    key `idx_cloud_disk_id` (`cloud_disk_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='云硬盘快照表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('disk_snapshot', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.3' as `hcm_ver`, '0041' as `sql_ver`;
+
+COMMIT;